@@ -1,6 +1,7 @@
 const jsonfile = require('jsonfile');
 const elliptic = require('elliptic');
 const path = require('path');
+const fs = require('fs');
 
 const Wormhole = artifacts.require("Wormhole");
 const MockImplementation = artifacts.require("MockImplementation");
@@ -243,6 +244,41 @@ contract("Wormhole", function () {
         assert.equal(result.reason, "");
     })
 
+    it("parses the shared VAA test vectors", async function () {
+        const initialized = new web3.eth.Contract(ImplementationFullABI, Wormhole.address);
+
+        // Generated by the Go SDK, see sdk/vaa/vectors_test.go. The vectors are outside of the docker build context.
+        const vectorsPath = path.join(__dirname, "../../sdk/vaa/testdata/vectors.json");
+        if (!fs.existsSync(vectorsPath)) {
+            this.skip();
+        }
+        const corpus = jsonfile.readFileSync(vectorsPath);
+
+        for (const vector of corpus.vectors) {
+            const result = await initialized.methods.parseVM("0x" + vector.bytes).call();
+
+            assert.equal(result.version, vector.version, vector.name);
+            assert.equal(result.guardianSetIndex, vector.guardian_set_index, vector.name);
+            assert.equal(result.timestamp, vector.timestamp, vector.name);
+            assert.equal(result.nonce, vector.nonce, vector.name);
+            assert.equal(result.emitterChainId, vector.emitter_chain, vector.name);
+            assert.equal(result.emitterAddress, "0x" + vector.emitter_address, vector.name);
+            assert.equal(result.sequence, vector.sequence, vector.name);
+            assert.equal(result.consistencyLevel, vector.consistency_level, vector.name);
+            assert.equal(result.payload, "0x" + vector.payload, vector.name);
+            assert.equal(result.hash, "0x" + vector.digest, vector.name);
+
+            assert.equal(result.signatures.length, vector.signatures.length, vector.name);
+            for (let i = 0; i < vector.signatures.length; i++) {
+                const signature = vector.signatures[i].signature;
+                assert.equal(result.signatures[i].guardianIndex, vector.signatures[i].guardian_index, vector.name);
+                assert.equal(result.signatures[i].r, "0x" + signature.substring(0, 64), vector.name);
+                assert.equal(result.signatures[i].s, "0x" + signature.substring(64, 128), vector.name);
+                assert.equal(result.signatures[i].v, parseInt(signature.substring(128, 130), 16) + 27, vector.name);
+            }
+        }
+    })
+
 
     it("should fail quorum on VMs with no signers", async function () {
         const initialized = new web3.eth.Contract(ImplementationFullABI, Wormhole.address);
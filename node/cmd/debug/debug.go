@@ -9,4 +9,5 @@ var DebugCmd = &cobra.Command{
 
 func init() {
 	DebugCmd.AddCommand(decodeVaaCmd)
+	DebugCmd.AddCommand(verifyVaaCmd)
 }
@@ -0,0 +1,181 @@
+package debug
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/processor"
+	publicrpcv1 "github.com/certusone/wormhole/node/pkg/proto/publicrpc/v1"
+	"github.com/davecgh/go-spew/spew"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var (
+	verifyGuardianSetPath *string
+	verifyRPC             *string
+)
+
+func init() {
+	verifyGuardianSetPath = verifyVaaCmd.Flags().String("guardianSet", "", "Path to a guardian set JSON file (as returned by /v1/guardianset/current)")
+	verifyRPC = verifyVaaCmd.Flags().String("rpc", "", "Public gRPC endpoint of a guardian node to fetch the current guardian set from")
+}
+
+var verifyVaaCmd = &cobra.Command{
+	Use:   "verify-vaa [DATA]",
+	Short: "Verify signatures and quorum of a hex-encoded VAA against a guardian set",
+	Args:  cobra.ExactArgs(1),
+	Run:   runVerifyVaa,
+}
+
+// guardianSet is the subset of a guardian set needed to verify a VAA.
+type guardianSet struct {
+	index uint32
+	keys  []common.Address
+}
+
+func runVerifyVaa(cmd *cobra.Command, args []string) {
+	if (*verifyGuardianSetPath == "") == (*verifyRPC == "") {
+		log.Fatal("exactly one of --guardianSet or --rpc must be specified")
+	}
+
+	b, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(args[0]), "0x"))
+	if err != nil {
+		log.Fatalf("failed to decode hex: %v", err)
+	}
+
+	v, err := vaa.Unmarshal(b)
+	if err != nil {
+		log.Fatalf("failed to parse VAA: %v", err)
+	}
+
+	var gs *guardianSet
+	if *verifyGuardianSetPath != "" {
+		gs, err = guardianSetFromFile(*verifyGuardianSetPath)
+	} else {
+		gs, err = guardianSetFromRPC(*verifyRPC)
+	}
+	if err != nil {
+		log.Fatalf("failed to load guardian set: %v", err)
+	}
+
+	spew.Dump(v)
+	printPayload(v)
+
+	fmt.Printf("Message ID:         %s\n", v.MessageID())
+	fmt.Printf("Digest:             %s\n", v.HexDigest())
+
+	if err := verifyVAA(v, gs); err != nil {
+		log.Fatalf("VAA is INVALID: %v", err)
+	}
+	fmt.Printf("VAA is VALID: %d of %d signatures, quorum %d, guardian set %d\n",
+		len(v.Signatures), len(gs.keys), processor.CalculateQuorum(len(gs.keys)), gs.index)
+}
+
+// verifyVAA checks that v is signed by a quorum of gs.
+func verifyVAA(v *vaa.VAA, gs *guardianSet) error {
+	if v.GuardianSetIndex != gs.index {
+		return fmt.Errorf("VAA was signed by guardian set %d, but guardian set %d was provided", v.GuardianSetIndex, gs.index)
+	}
+
+	quorum := processor.CalculateQuorum(len(gs.keys))
+	if len(v.Signatures) < quorum {
+		return fmt.Errorf("VAA has %d signatures, but quorum is %d", len(v.Signatures), quorum)
+	}
+
+	for _, s := range v.Signatures {
+		if int(s.Index) >= len(gs.keys) {
+			return fmt.Errorf("signature index %d out of range for guardian set of size %d", s.Index, len(gs.keys))
+		}
+	}
+
+	if !v.VerifySignatures(gs.keys) {
+		return fmt.Errorf("signatures do not match the guardian set")
+	}
+
+	return nil
+}
+
+func guardianSetFromFile(path string) (*guardianSet, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Accept both the full GetCurrentGuardianSet response and a bare guardian set.
+	var resp publicrpcv1.GetCurrentGuardianSetResponse
+	if err := protojson.Unmarshal(b, &resp); err == nil && resp.GuardianSet != nil {
+		return parseGuardianSet(resp.GuardianSet)
+	}
+
+	var set publicrpcv1.GuardianSet
+	if err := protojson.Unmarshal(b, &set); err != nil {
+		return nil, fmt.Errorf("failed to parse guardian set JSON: %w", err)
+	}
+	return parseGuardianSet(&set)
+}
+
+func guardianSetFromRPC(addr string) (*guardianSet, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	conn, err := grpc.DialContext(ctx, addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", addr, err)
+	}
+	defer conn.Close()
+
+	resp, err := publicrpcv1.NewPublicRPCServiceClient(conn).GetCurrentGuardianSet(ctx, &publicrpcv1.GetCurrentGuardianSetRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch current guardian set: %w", err)
+	}
+	return parseGuardianSet(resp.GuardianSet)
+}
+
+func parseGuardianSet(set *publicrpcv1.GuardianSet) (*guardianSet, error) {
+	if set == nil || len(set.Addresses) == 0 {
+		return nil, fmt.Errorf("guardian set is empty")
+	}
+
+	gs := &guardianSet{index: set.Index}
+	for _, a := range set.Addresses {
+		if !common.IsHexAddress(a) {
+			return nil, fmt.Errorf("invalid guardian address %q", a)
+		}
+		gs.keys = append(gs.keys, common.HexToAddress(a))
+	}
+	return gs, nil
+}
+
+// printPayload pretty-prints payloads of well-known formats.
+func printPayload(v *vaa.VAA) {
+	if vaa.IsTransfer(v.Payload) {
+		hdr, err := vaa.DecodeTransferPayloadHdr(v.Payload)
+		if err != nil {
+			fmt.Printf("Payload:            malformed transfer: %v\n", err)
+			return
+		}
+		fmt.Printf("Payload type:       %d (transfer)\n", hdr.Type)
+		fmt.Printf("Amount:             %s\n", hdr.Amount)
+		fmt.Printf("Origin:             %s/%s\n", hdr.OriginChain, hdr.OriginAddress)
+		fmt.Printf("Target:             %s/%s\n", hdr.TargetChain, hdr.TargetAddress)
+		return
+	}
+
+	if v.EmitterChain == vaa.GovernanceChain && v.EmitterAddress == vaa.GovernanceEmitter && len(v.Payload) >= 35 {
+		fmt.Printf("Governance module:  %s\n", strings.TrimLeft(string(v.Payload[:32]), "\x00"))
+		fmt.Printf("Governance action:  %d\n", v.Payload[32])
+		fmt.Printf("Target chain:       %s\n", vaa.ChainID(uint16(v.Payload[33])<<8|uint16(v.Payload[34])))
+	}
+
+	fmt.Printf("Payload:            %s\n", hex.EncodeToString(v.Payload))
+}
@@ -0,0 +1,48 @@
+package debug
+
+import (
+	"crypto/ecdsa"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+func TestVerifyVAA(t *testing.T) {
+	var keys []*ecdsa.PrivateKey
+	gs := &guardianSet{index: 2}
+	for i := 0; i < 4; i++ {
+		k, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		keys = append(keys, k)
+		gs.keys = append(gs.keys, crypto.PubkeyToAddress(k.PublicKey))
+	}
+
+	newVAA := func(signers ...int) *vaa.VAA {
+		v := &vaa.VAA{
+			Version:          vaa.SupportedVAAVersion,
+			GuardianSetIndex: 2,
+			Timestamp:        time.Unix(0, 0),
+			EmitterChain:     vaa.ChainIDEthereum,
+			Payload:          []byte{1, 2, 3},
+		}
+		for _, i := range signers {
+			v.AddSignature(keys[i], uint8(i))
+		}
+		return v
+	}
+
+	assert.NoError(t, verifyVAA(newVAA(0, 1, 2), gs))
+	assert.Error(t, verifyVAA(newVAA(0, 1), gs), "no quorum")
+
+	wrongIndex := newVAA(0, 1, 2)
+	wrongIndex.GuardianSetIndex = 1
+	assert.Error(t, verifyVAA(wrongIndex, gs))
+
+	otherSet := &guardianSet{index: 2, keys: []common.Address{gs.keys[1], gs.keys[0], gs.keys[2], gs.keys[3]}}
+	assert.Error(t, verifyVAA(newVAA(0, 1, 2), otherSet))
+}
@@ -0,0 +1,265 @@
+package guardiand
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	"golang.org/x/sys/unix"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// adminAuditMethodPrefix selects the calls of the admin service, which shares its socket with the public RPC service.
+const adminAuditMethodPrefix = "/node.v1.NodePrivilegedService/"
+
+// adminAuditRecord is one line of the admin audit log.
+type adminAuditRecord struct {
+	Time   time.Time `json:"time"`
+	Method string    `json:"method"`
+	// ArgsHash is the hex encoded SHA-256 of the deterministically marshaled request. The arguments themselves are
+	// not logged, as they may be large or sensitive.
+	ArgsHash string `json:"args_hash"`
+	// CallerUID and CallerPID identify the process that called the admin socket, or are -1 if they are unknown.
+	CallerUID int64 `json:"caller_uid"`
+	CallerPID int64 `json:"caller_pid"`
+	// Code is the gRPC status code of the call, and Error its error message, if any.
+	Code  string `json:"code"`
+	Error string `json:"error,omitempty"`
+	// Prev is the hex encoded SHA-256 of the previous line of the log, or empty for the first one. It chains the
+	// records, so that records removed from or changed in the middle of the log are detected by the export.
+	Prev string `json:"prev,omitempty"`
+}
+
+// adminAuditLog appends a record for every admin RPC call to a local file.
+type adminAuditLog struct {
+	mu   sync.Mutex
+	f    *os.File
+	prev string
+}
+
+// openAdminAuditLog opens the audit log at path for appending, creating it if it does not exist, and continues the
+// hash chain of its last record.
+func openAdminAuditLog(path string) (*adminAuditLog, error) {
+	b, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read audit log: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	l := &adminAuditLog{f: f}
+	if lines := bytes.Split(bytes.TrimRight(b, "\n"), []byte("\n")); len(lines[len(lines)-1]) > 0 {
+		l.prev = adminAuditLineHash(lines[len(lines)-1])
+	}
+	return l, nil
+}
+
+func adminAuditLineHash(line []byte) string {
+	h := sha256.Sum256(line)
+	return hex.EncodeToString(h[:])
+}
+
+// append writes r to the log, chained to the previous record, and syncs it to disk.
+func (l *adminAuditLog) append(r adminAuditRecord) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	r.Prev = l.prev
+	line, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	if _, err := l.f.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	if err := l.f.Sync(); err != nil {
+		return err
+	}
+	l.prev = adminAuditLineHash(line)
+	return nil
+}
+
+func (l *adminAuditLog) Close() error {
+	return l.f.Close()
+}
+
+// unaryInterceptor records the admin service calls after they returned. A record that cannot be written is logged,
+// but does not fail the call, which has already taken effect.
+func (l *adminAuditLog) unaryInterceptor(logger *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if !strings.HasPrefix(info.FullMethod, adminAuditMethodPrefix) {
+			return resp, err
+		}
+
+		r := adminAuditRecord{
+			Time:      time.Now().UTC(),
+			Method:    strings.TrimPrefix(info.FullMethod, adminAuditMethodPrefix),
+			CallerUID: -1,
+			CallerPID: -1,
+			Code:      status.Code(err).String(),
+		}
+		if m, ok := req.(proto.Message); ok {
+			b, merr := proto.MarshalOptions{Deterministic: true}.Marshal(m)
+			if merr == nil {
+				r.ArgsHash = adminAuditLineHash(b)
+			}
+		}
+		if p, ok := peer.FromContext(ctx); ok {
+			if creds, ok := p.AuthInfo.(peerCredentialsInfo); ok {
+				r.CallerUID = int64(creds.UID)
+				r.CallerPID = int64(creds.PID)
+			}
+		}
+		if err != nil {
+			r.Error = err.Error()
+		}
+		if aerr := l.append(r); aerr != nil {
+			logger.Error("failed to write admin audit record", zap.String("method", r.Method), zap.Error(aerr))
+		}
+		return resp, err
+	}
+}
+
+// peerCredentials reads the credentials of the process on the other end of a UNIX socket connection. It does not
+// exchange any data, so clients connect without transport security as before.
+type peerCredentials struct{}
+
+type peerCredentialsInfo struct {
+	credentials.CommonAuthInfo
+	UID uint32
+	PID int32
+}
+
+func (peerCredentialsInfo) AuthType() string {
+	return "peercred"
+}
+
+func (peerCredentials) ServerHandshake(conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	info := peerCredentialsInfo{CommonAuthInfo: credentials.CommonAuthInfo{SecurityLevel: credentials.NoSecurity}}
+	uc, ok := conn.(*net.UnixConn)
+	if !ok {
+		return conn, info, nil
+	}
+	raw, err := uc.SyscallConn()
+	if err != nil {
+		return nil, nil, err
+	}
+	var cred *unix.Ucred
+	var credErr error
+	err = raw.Control(func(fd uintptr) {
+		cred, credErr = unix.GetsockoptUcred(int(fd), unix.SOL_SOCKET, unix.SO_PEERCRED)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if credErr != nil {
+		return nil, nil, fmt.Errorf("failed to read peer credentials: %w", credErr)
+	}
+	info.UID = cred.Uid
+	info.PID = cred.Pid
+	return conn, info, nil
+}
+
+func (peerCredentials) ClientHandshake(ctx context.Context, authority string, conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return nil, nil, errors.New("peer credentials are server-side only")
+}
+
+func (peerCredentials) Info() credentials.ProtocolInfo {
+	return credentials.ProtocolInfo{SecurityProtocol: "peercred"}
+}
+
+func (c peerCredentials) Clone() credentials.TransportCredentials {
+	return c
+}
+
+func (peerCredentials) OverrideServerName(string) error {
+	return nil
+}
+
+// readAdminAuditLog reads the records of the audit log at path and checks their hash chain.
+func readAdminAuditLog(path string) ([]adminAuditRecord, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var records []adminAuditRecord
+	var prev string
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 1024*1024)
+	for n := 1; scanner.Scan(); n++ {
+		line := scanner.Bytes()
+		var r adminAuditRecord
+		if err := json.Unmarshal(line, &r); err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		if r.Prev != prev {
+			return nil, fmt.Errorf("line %d: hash chain broken, expected previous hash %q, got %q", n, prev, r.Prev)
+		}
+		prev = adminAuditLineHash(line)
+		records = append(records, r)
+	}
+	return records, scanner.Err()
+}
+
+var auditLogSince *string
+
+func init() {
+	auditLogSince = AdminAuditLogExportCmd.Flags().String("since", "", "only export records at or after this RFC 3339 time")
+}
+
+var AdminAuditLogExportCmd = &cobra.Command{
+	Use:   "audit-log-export [FILENAME]",
+	Short: "Verify the hash chain of an admin audit log and print its records as JSON lines (offline)",
+	Run:   runAuditLogExport,
+	Args:  cobra.ExactArgs(1),
+}
+
+func runAuditLogExport(cmd *cobra.Command, args []string) {
+	var since time.Time
+	if *auditLogSince != "" {
+		var err error
+		since, err = time.Parse(time.RFC3339, *auditLogSince)
+		if err != nil {
+			log.Fatalf("invalid --since: %v", err)
+		}
+	}
+
+	records, err := readAdminAuditLog(args[0])
+	if err != nil {
+		log.Fatalf("invalid audit log: %v", err)
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	exported := 0
+	for _, r := range records {
+		if r.Time.Before(since) {
+			continue
+		}
+		if err := enc.Encode(r); err != nil {
+			log.Fatalf("failed to write record: %v", err)
+		}
+		exported++
+	}
+	log.Printf("Exported %d of %d records", exported, len(records))
+}
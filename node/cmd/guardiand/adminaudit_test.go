@@ -0,0 +1,85 @@
+package guardiand
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	nodev1 "github.com/certusone/wormhole/node/pkg/proto/node/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// auditedService fails every node key rotation and leaves the other calls unimplemented.
+type auditedService struct {
+	nodev1.UnimplementedNodePrivilegedServiceServer
+}
+
+func (auditedService) RotateNodeKey(ctx context.Context, req *nodev1.RotateNodeKeyRequest) (*nodev1.RotateNodeKeyResponse, error) {
+	return nil, status.Error(codes.FailedPrecondition, "rotation disabled")
+}
+
+func TestAdminAuditLog(t *testing.T) {
+	dir := t.TempDir()
+	logPath := filepath.Join(dir, "audit.log")
+	auditLog, err := openAdminAuditLog(logPath)
+	require.NoError(t, err)
+
+	socketPath := filepath.Join(dir, "admin.sock")
+	l, err := listenUnixSocket(socketPath)
+	require.NoError(t, err)
+	server := grpc.NewServer(grpc.Creds(peerCredentials{}), grpc.ChainUnaryInterceptor(auditLog.unaryInterceptor(zap.NewNop())))
+	nodev1.RegisterNodePrivilegedServiceServer(server, auditedService{})
+	go func() { _ = server.Serve(l) }()
+	defer server.Stop()
+
+	conn, err := grpc.Dial("unix:///"+socketPath, grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", socketPath)
+		}))
+	require.NoError(t, err)
+	defer conn.Close()
+	client := nodev1.NewNodePrivilegedServiceClient(conn)
+
+	_, err = client.RotateNodeKey(context.Background(), &nodev1.RotateNodeKeyRequest{})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	_, err = client.GetChainCounters(context.Background(), &nodev1.GetChainCountersRequest{})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+	require.NoError(t, auditLog.Close())
+
+	// Reopening continues the hash chain
+	auditLog, err = openAdminAuditLog(logPath)
+	require.NoError(t, err)
+	require.NoError(t, auditLog.append(adminAuditRecord{Method: "Test"}))
+	require.NoError(t, auditLog.Close())
+
+	records, err := readAdminAuditLog(logPath)
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, "RotateNodeKey", records[0].Method)
+	assert.Equal(t, "FailedPrecondition", records[0].Code)
+	assert.Contains(t, records[0].Error, "rotation disabled")
+	assert.Equal(t, int64(os.Getuid()), records[0].CallerUID)
+	assert.Equal(t, int64(os.Getpid()), records[0].CallerPID)
+	assert.NotEmpty(t, records[0].ArgsHash)
+	assert.Empty(t, records[0].Prev)
+	assert.Equal(t, "GetChainCounters", records[1].Method)
+	assert.Equal(t, "Unimplemented", records[1].Code)
+	assert.NotEmpty(t, records[1].Prev)
+
+	// Removing a record breaks the chain
+	b, err := os.ReadFile(logPath)
+	require.NoError(t, err)
+	lines := bytes.Split(bytes.TrimRight(b, "\n"), []byte("\n"))
+	require.NoError(t, os.WriteFile(logPath, append(append(lines[0], '\n'), append(lines[2], '\n')...), 0600))
+	_, err = readAdminAuditLog(logPath)
+	assert.ErrorContains(t, err, "hash chain broken")
+}
@@ -23,7 +23,9 @@ import (
 	"github.com/status-im/keycard-go/hexutils"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
 
 	nodev1 "github.com/certusone/wormhole/node/pkg/proto/node/v1"
 )
@@ -31,6 +33,8 @@ import (
 var (
 	clientSocketPath *string
 	shouldBackfill   *bool
+	includeSubmitted *bool
+	bundleMaxVAAs    *uint32
 )
 
 func init() {
@@ -56,6 +60,18 @@ func init() {
 	ClientChainGovernorReleasePendingVAACmd.Flags().AddFlagSet(pf)
 	ClientChainGovernorResetReleaseTimerCmd.Flags().AddFlagSet(pf)
 	PurgePythNetVaasCmd.Flags().AddFlagSet(pf)
+	DumpProcessorStateCmd.Flags().AddFlagSet(pf)
+	ExportStateBundleCmd.Flags().AddFlagSet(pf)
+	GetChainCountersCmd.Flags().AddFlagSet(pf)
+	ExportConfigManifestCmd.Flags().AddFlagSet(pf)
+	RotateNodeKeyCmd.Flags().AddFlagSet(pf)
+	InjectSignedVAACmd.Flags().AddFlagSet(pf)
+
+	includeSubmitted = DumpProcessorStateCmd.Flags().Bool(
+		"includeSubmitted", false, "include observations that already reached quorum")
+
+	bundleMaxVAAs = ExportStateBundleCmd.Flags().Uint32(
+		"maxVAAs", 100, "maximum number of recently stored VAAs to include")
 
 	AdminCmd.AddCommand(AdminClientInjectGuardianSetUpdateCmd)
 	AdminCmd.AddCommand(AdminClientFindMissingMessagesCmd)
@@ -69,6 +85,15 @@ func init() {
 	AdminCmd.AddCommand(ClientChainGovernorReleasePendingVAACmd)
 	AdminCmd.AddCommand(ClientChainGovernorResetReleaseTimerCmd)
 	AdminCmd.AddCommand(PurgePythNetVaasCmd)
+	AdminCmd.AddCommand(DumpProcessorStateCmd)
+	AdminCmd.AddCommand(ExportStateBundleCmd)
+	AdminCmd.AddCommand(GetChainCountersCmd)
+	AdminCmd.AddCommand(AdminClientStateBundleVerifyCmd)
+	AdminCmd.AddCommand(ExportConfigManifestCmd)
+	AdminCmd.AddCommand(AdminClientConfigManifestDiffCmd)
+	AdminCmd.AddCommand(RotateNodeKeyCmd)
+	AdminCmd.AddCommand(InjectSignedVAACmd)
+	AdminCmd.AddCommand(AdminAuditLogExportCmd)
 }
 
 var AdminCmd = &cobra.Command{
@@ -98,10 +123,12 @@ var DumpVAAByMessageID = &cobra.Command{
 }
 
 var SendObservationRequest = &cobra.Command{
-	Use:   "send-observation-request [CHAIN_ID|CHAIN_NAME] [TX_HASH_HEX]",
-	Short: "Broadcast an observation request for the given chain ID and chain-specific tx_hash",
-	Run:   runSendObservationRequest,
-	Args:  cobra.ExactArgs(2),
+	Use:   "send-observation-request [CHAIN_ID|CHAIN_NAME] [TX_HASH_HEX | EMITTER_ADDRESS SEQUENCE]",
+	Short: "Broadcast an observation request for the given chain ID and either a chain-specific tx_hash or the emitter address and sequence of a message",
+	Long: "Broadcast an observation request for the given chain ID and either a chain-specific tx_hash or the emitter address and sequence of a message.\n" +
+		"Requests by emitter address and sequence are only supported by the wormchain and cosmwasm watchers.",
+	Run:  runSendObservationRequest,
+	Args: cobra.RangeArgs(2, 3),
 }
 
 var ClientChainGovernorStatusCmd = &cobra.Command{
@@ -146,6 +173,48 @@ var PurgePythNetVaasCmd = &cobra.Command{
 	Args:  cobra.RangeArgs(1, 2),
 }
 
+var ExportStateBundleCmd = &cobra.Command{
+	Use:   "export-state-bundle [FILENAME]",
+	Short: "Writes a state bundle signed with the guardian key for support escalations: node identity, config hashes, chain heights and recent VAAs",
+	Run:   runExportStateBundle,
+	Args:  cobra.ExactArgs(1),
+}
+
+var ExportConfigManifestCmd = &cobra.Command{
+	Use:   "export-config-manifest [FILENAME]",
+	Short: "Writes a manifest of the node's configuration signed with the guardian key: network, chains, contracts, hashed endpoints and features",
+	Run:   runExportConfigManifest,
+	Args:  cobra.ExactArgs(1),
+}
+
+var DumpProcessorStateCmd = &cobra.Command{
+	Use:   "dump-processor-state",
+	Short: "Dumps the observations pending in the processor as JSON, including their signature count and missing guardians",
+	Run:   runDumpProcessorState,
+	Args:  cobra.ExactArgs(0),
+}
+
+var GetChainCountersCmd = &cobra.Command{
+	Use:   "get-chain-counters",
+	Short: "Displays the messages observed, observations signed, quorum VAAs and re-observations per chain as JSON",
+	Run:   runGetChainCounters,
+	Args:  cobra.ExactArgs(0),
+}
+
+var RotateNodeKeyCmd = &cobra.Command{
+	Use:   "rotate-node-key",
+	Short: "Replaces the p2p node key with a new one and rejoins the gossip network under the new peer ID, keeping the guardian key",
+	Run:   runRotateNodeKey,
+	Args:  cobra.ExactArgs(0),
+}
+
+var InjectSignedVAACmd = &cobra.Command{
+	Use:   "inject-signed-vaa [VAA_HEX | FILENAME]",
+	Short: "Verifies a VAA signed by the current guardian set and stores it locally, so that the public API serves it",
+	Run:   runInjectSignedVAA,
+	Args:  cobra.ExactArgs(1),
+}
+
 func getAdminClient(ctx context.Context, addr string) (*grpc.ClientConn, nodev1.NodePrivilegedServiceClient, error) {
 	conn, err := grpc.DialContext(ctx, fmt.Sprintf("unix:///%s", addr), grpc.WithTransportCredentials(insecure.NewCredentials()))
 
@@ -238,19 +307,9 @@ func runFindMissingMessages(cmd *cobra.Command, args []string) {
 // runDumpVAAByMessageID uses GetSignedVAA to request the given message,
 // then decode and dump the VAA.
 func runDumpVAAByMessageID(cmd *cobra.Command, args []string) {
-	// Parse the {chain,emitter,seq} string.
-	parts := strings.Split(args[0], "/")
-	if len(parts) != 3 {
-		log.Fatalf("invalid message ID: %s", args[0])
-	}
-	chainID, err := strconv.ParseUint(parts[0], 10, 32)
+	id, err := vaa.ParseMessageID(args[0])
 	if err != nil {
-		log.Fatalf("invalid chain ID: %v", err)
-	}
-	emitterAddress := parts[1]
-	seq, err := strconv.ParseUint(parts[2], 10, 64)
-	if err != nil {
-		log.Fatalf("invalid sequence number: %v", err)
+		log.Fatalf("invalid message ID: %v", err)
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -264,9 +323,9 @@ func runDumpVAAByMessageID(cmd *cobra.Command, args []string) {
 
 	msg := publicrpcv1.GetSignedVAARequest{
 		MessageId: &publicrpcv1.MessageID{
-			EmitterChain:   publicrpcv1.ChainID(chainID),
-			EmitterAddress: emitterAddress,
-			Sequence:       seq,
+			EmitterChain:   publicrpcv1.ChainID(id.EmitterChain),
+			EmitterAddress: id.EmitterAddress.String(),
+			Sequence:       id.Sequence,
 		},
 	}
 	resp, err := c.GetSignedVAA(ctx, &msg)
@@ -289,11 +348,24 @@ func runSendObservationRequest(cmd *cobra.Command, args []string) {
 		log.Fatalf("invalid chain ID: %v", err)
 	}
 
-	txHash, err := hex.DecodeString(args[1])
-	if err != nil {
-		txHash, err = base58.Decode(args[1])
+	req := &gossipv1.ObservationRequest{ChainId: uint32(chainID)}
+	if len(args) == 3 {
+		emitter, err := vaa.StringToAddress(args[1])
 		if err != nil {
-			log.Fatalf("invalid transaction hash (neither hex nor base58): %v", err)
+			log.Fatalf("invalid emitter address: %v", err)
+		}
+		req.EmitterAddress = emitter.Bytes()
+		req.Sequence, err = strconv.ParseUint(args[2], 10, 64)
+		if err != nil {
+			log.Fatalf("invalid sequence number: %v", err)
+		}
+	} else {
+		req.TxHash, err = hex.DecodeString(args[1])
+		if err != nil {
+			req.TxHash, err = base58.Decode(args[1])
+			if err != nil {
+				log.Fatalf("invalid transaction hash (neither hex nor base58): %v", err)
+			}
 		}
 	}
 
@@ -307,10 +379,7 @@ func runSendObservationRequest(cmd *cobra.Command, args []string) {
 	defer conn.Close()
 
 	_, err = c.SendObservationRequest(ctx, &nodev1.SendObservationRequestRequest{
-		ObservationRequest: &gossipv1.ObservationRequest{
-			ChainId: uint32(chainID),
-			TxHash:  txHash,
-		},
+		ObservationRequest: req,
 	})
 	if err != nil {
 		log.Fatalf("failed to send observation request: %v", err)
@@ -457,3 +526,156 @@ func runPurgePythNetVaas(cmd *cobra.Command, args []string) {
 
 	fmt.Println(resp.Response)
 }
+
+func runDumpProcessorState(cmd *cobra.Command, args []string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, c, err := getAdminClient(ctx, *clientSocketPath)
+	if err != nil {
+		log.Fatalf("failed to get admin client: %v", err)
+	}
+	defer conn.Close()
+
+	msg := nodev1.DumpProcessorStateRequest{IncludeSubmitted: *includeSubmitted}
+	resp, err := c.DumpProcessorState(ctx, &msg)
+	if err != nil {
+		log.Fatalf("failed to run DumpProcessorState RPC: %s", err)
+	}
+
+	b, err := protojson.MarshalOptions{Multiline: true, Indent: "  ", EmitUnpopulated: true}.Marshal(resp)
+	if err != nil {
+		log.Fatalf("failed to marshal response: %v", err)
+	}
+
+	fmt.Println(string(b))
+}
+
+func runGetChainCounters(cmd *cobra.Command, args []string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, c, err := getAdminClient(ctx, *clientSocketPath)
+	if err != nil {
+		log.Fatalf("failed to get admin client: %v", err)
+	}
+	defer conn.Close()
+
+	resp, err := c.GetChainCounters(ctx, &nodev1.GetChainCountersRequest{})
+	if err != nil {
+		log.Fatalf("failed to run GetChainCounters RPC: %s", err)
+	}
+
+	b, err := protojson.MarshalOptions{Multiline: true, Indent: "  ", EmitUnpopulated: true}.Marshal(resp)
+	if err != nil {
+		log.Fatalf("failed to marshal response: %v", err)
+	}
+
+	fmt.Println(string(b))
+}
+
+func runExportStateBundle(cmd *cobra.Command, args []string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	conn, c, err := getAdminClient(ctx, *clientSocketPath)
+	if err != nil {
+		log.Fatalf("failed to get admin client: %v", err)
+	}
+	defer conn.Close()
+
+	msg := nodev1.ExportStateBundleRequest{MaxVaas: *bundleMaxVAAs}
+	resp, err := c.ExportStateBundle(ctx, &msg)
+	if err != nil {
+		log.Fatalf("failed to run ExportStateBundle RPC: %s", err)
+	}
+
+	b, err := proto.Marshal(resp)
+	if err != nil {
+		log.Fatalf("failed to marshal state bundle: %v", err)
+	}
+	if err := ioutil.WriteFile(args[0], b, 0600); err != nil {
+		log.Fatalf("failed to write state bundle: %v", err)
+	}
+
+	fmt.Printf("Wrote state bundle to %s\n", args[0])
+}
+
+func runExportConfigManifest(cmd *cobra.Command, args []string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	conn, c, err := getAdminClient(ctx, *clientSocketPath)
+	if err != nil {
+		log.Fatalf("failed to get admin client: %v", err)
+	}
+	defer conn.Close()
+
+	resp, err := c.ExportConfigManifest(ctx, &nodev1.ExportConfigManifestRequest{})
+	if err != nil {
+		log.Fatalf("failed to run ExportConfigManifest RPC: %s", err)
+	}
+
+	b, err := proto.Marshal(resp)
+	if err != nil {
+		log.Fatalf("failed to marshal config manifest: %v", err)
+	}
+	if err := ioutil.WriteFile(args[0], b, 0600); err != nil {
+		log.Fatalf("failed to write config manifest: %v", err)
+	}
+
+	fmt.Printf("Wrote config manifest to %s\n", args[0])
+}
+
+func runRotateNodeKey(cmd *cobra.Command, args []string) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	conn, c, err := getAdminClient(ctx, *clientSocketPath)
+	if err != nil {
+		log.Fatalf("failed to get admin client: %v", err)
+	}
+	defer conn.Close()
+
+	resp, err := c.RotateNodeKey(ctx, &nodev1.RotateNodeKeyRequest{})
+	if err != nil {
+		log.Fatalf("failed to run RotateNodeKey RPC: %s", err)
+	}
+
+	fmt.Printf("Rotated node key from %s to %s, the previous key was saved to %s\n", resp.OldPeerId, resp.NewPeerId, resp.BackupPath)
+}
+
+func runInjectSignedVAA(cmd *cobra.Command, args []string) {
+	vaaBytes, err := hex.DecodeString(strings.TrimPrefix(args[0], "0x"))
+	if err != nil {
+		// Not hex, so it has to be a file with the VAA in hex or binary.
+		b, err := ioutil.ReadFile(args[0])
+		if err != nil {
+			log.Fatalf("failed to read VAA: %v", err)
+		}
+		vaaBytes, err = hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(string(b)), "0x"))
+		if err != nil {
+			vaaBytes = b
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, c, err := getAdminClient(ctx, *clientSocketPath)
+	if err != nil {
+		log.Fatalf("failed to get admin client: %v", err)
+	}
+	defer conn.Close()
+
+	resp, err := c.InjectSignedVAA(ctx, &nodev1.InjectSignedVAARequest{VaaBytes: vaaBytes})
+	if err != nil {
+		log.Fatalf("failed to run InjectSignedVAA RPC: %s", err)
+	}
+
+	if resp.AlreadyStored {
+		fmt.Printf("VAA %s with digest %s was already stored\n", resp.MessageId, resp.Digest)
+	} else {
+		fmt.Printf("Stored VAA %s with digest %s\n", resp.MessageId, resp.Digest)
+	}
+}
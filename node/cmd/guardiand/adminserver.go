@@ -2,6 +2,7 @@ package guardiand
 
 import (
 	"context"
+	"crypto/sha256"
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
@@ -12,17 +13,26 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/certusone/wormhole/node/pkg/db"
 	"github.com/certusone/wormhole/node/pkg/governor"
+	"github.com/certusone/wormhole/node/pkg/p2p"
+	"github.com/certusone/wormhole/node/pkg/processor"
 	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
 	publicrpcv1 "github.com/certusone/wormhole/node/pkg/proto/publicrpc/v1"
 	"github.com/certusone/wormhole/node/pkg/publicrpc"
+	"github.com/certusone/wormhole/node/pkg/version"
 	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/spf13/pflag"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/certusone/wormhole/node/pkg/common"
 	nodev1 "github.com/certusone/wormhole/node/pkg/proto/node/v1"
@@ -32,12 +42,40 @@ import (
 
 type nodePrivilegedService struct {
 	nodev1.UnimplementedNodePrivilegedServiceServer
-	db           *db.Database
-	injectC      chan<- *vaa.VAA
-	obsvReqSendC chan *gossipv1.ObservationRequest
-	logger       *zap.Logger
-	signedInC    chan *gossipv1.SignedVAAWithQuorum
-	governor     *governor.ChainGovernor
+	db            *db.Database
+	injectC       chan<- *vaa.VAA
+	obsvReqSendC  chan *gossipv1.ObservationRequest
+	logger        *zap.Logger
+	signedInC     chan *gossipv1.SignedVAAWithQuorum
+	gst           *common.GuardianSetState
+	governor      *governor.ChainGovernor
+	stateDumpC    chan<- *processor.StateDumpRequest
+	gk            *common.GuardianKeyState
+	identity      stateBundleIdentity
+	chainCounters *common.ChainCounters
+	nodeKeyPath   string
+	nodeKeyC      chan<- *p2p.NodeKeyRotation
+	// nodeKeyMu guards identity.peerID and serializes node key rotations.
+	nodeKeyMu sync.Mutex
+}
+
+// stateBundleIdentity describes the node in exported state bundles and config manifests.
+type stateBundleIdentity struct {
+	nodeName     string
+	peerID       string
+	configHashes map[string]string
+	// configManifest is the part of the config manifest derived from the flags, see configManifestFromFlags.
+	configManifest *nodev1.GuardianConfigManifest
+}
+
+// flagHashes returns the hex-encoded SHA-256 hash of the value of each flag by flag name.
+func flagHashes(flags *pflag.FlagSet) map[string]string {
+	hashes := make(map[string]string)
+	flags.VisitAll(func(f *pflag.Flag) {
+		h := sha256.Sum256([]byte(f.Value.String()))
+		hashes[f.Name] = hex.EncodeToString(h[:])
+	})
+	return hashes
 }
 
 // adminGuardianSetUpdateToVAA converts a nodev1.GuardianSetUpdate message to its canonical VAA representation.
@@ -336,7 +374,7 @@ func (s *nodePrivilegedService) FindMissingMessages(ctx context.Context, req *no
 
 	resp := make([]string, len(ids))
 	for i, v := range ids {
-		resp[i] = fmt.Sprintf("%d/%s/%d", req.EmitterChain, emitterAddress, v)
+		resp[i] = vaa.MessageID{EmitterChain: vaa.ChainID(req.EmitterChain), EmitterAddress: emitterAddress, Sequence: v}.String()
 	}
 	return &nodev1.FindMissingMessagesResponse{
 		MissingMessages: resp,
@@ -345,8 +383,8 @@ func (s *nodePrivilegedService) FindMissingMessages(ctx context.Context, req *no
 	}, nil
 }
 
-func adminServiceRunnable(logger *zap.Logger, socketPath string, injectC chan<- *vaa.VAA, signedInC chan *gossipv1.SignedVAAWithQuorum, obsvReqSendC chan *gossipv1.ObservationRequest,
-	db *db.Database, gst *common.GuardianSetState, gov *governor.ChainGovernor) (supervisor.Runnable, error) {
+// listenUnixSocket listens on a new UNIX socket at socketPath, replacing an existing socket at that path.
+func listenUnixSocket(socketPath string) (*net.UnixListener, error) {
 	// Delete existing UNIX socket, if present.
 	fi, err := os.Stat(socketPath)
 	if err == nil {
@@ -375,27 +413,55 @@ func adminServiceRunnable(logger *zap.Logger, socketPath string, injectC chan<-
 	if err != nil {
 		return nil, fmt.Errorf("failed to listen on %s: %w", socketPath, err)
 	}
+	return l, nil
+}
+
+func adminServiceRunnable(logger *zap.Logger, socketPath string, injectC chan<- *vaa.VAA, signedInC chan *gossipv1.SignedVAAWithQuorum, obsvReqSendC chan *gossipv1.ObservationRequest,
+	db *db.Database, gst *common.GuardianSetState, gov *governor.ChainGovernor, stateDumpC chan<- *processor.StateDumpRequest, gk *common.GuardianKeyState, identity stateBundleIdentity, chainCounters *common.ChainCounters,
+	obsvReqGate *publicrpc.ObservationRequestGate, nodeKeyPath string, nodeKeyC chan<- *p2p.NodeKeyRotation, auditLog *adminAuditLog) (supervisor.Runnable, error) {
+	l, err := listenUnixSocket(socketPath)
+	if err != nil {
+		return nil, err
+	}
 
 	logger.Info("admin server listening on", zap.String("path", socketPath))
 
 	nodeService := &nodePrivilegedService{
-		injectC:      injectC,
-		obsvReqSendC: obsvReqSendC,
-		db:           db,
-		logger:       logger.Named("adminservice"),
-		signedInC:    signedInC,
-		governor:     gov,
+		injectC:       injectC,
+		obsvReqSendC:  obsvReqSendC,
+		db:            db,
+		logger:        logger.Named("adminservice"),
+		signedInC:     signedInC,
+		gst:           gst,
+		governor:      gov,
+		stateDumpC:    stateDumpC,
+		gk:            gk,
+		identity:      identity,
+		chainCounters: chainCounters,
+		nodeKeyPath:   nodeKeyPath,
+		nodeKeyC:      nodeKeyC,
 	}
 
 	publicrpcService := publicrpc.NewPublicrpcServer(logger, db, gst, gov)
+	publicrpcService.SetObservationRequestGate(obsvReqGate)
 
-	grpcServer := common.NewInstrumentedGRPCServer(logger)
+	// Every admin call is recorded with the credentials of the calling process.
+	grpcServer := common.NewInstrumentedGRPCServer(logger,
+		grpc.Creds(peerCredentials{}),
+		grpc.ChainUnaryInterceptor(auditLog.unaryInterceptor(logger.Named("adminaudit"))),
+	)
 	nodev1.RegisterNodePrivilegedServiceServer(grpcServer, nodeService)
 	publicrpcv1.RegisterPublicRPCServiceServer(grpcServer, publicrpcService)
 	return supervisor.GRPCServer(grpcServer, l, false), nil
 }
 
 func (s *nodePrivilegedService) SendObservationRequest(ctx context.Context, req *nodev1.SendObservationRequestRequest) (*nodev1.SendObservationRequestResponse, error) {
+	if req.ObservationRequest == nil {
+		return nil, status.Error(codes.InvalidArgument, "no observation request")
+	}
+	if err := common.ValidateObservationRequest(req.ObservationRequest); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
 	if err := common.PostObservationRequest(s.obsvReqSendC, req.ObservationRequest); err != nil {
 		return nil, err
 	}
@@ -498,3 +564,225 @@ func (s *nodePrivilegedService) PurgePythNetVaas(ctx context.Context, req *nodev
 		Response: resp,
 	}, nil
 }
+
+func (s *nodePrivilegedService) DumpProcessorState(ctx context.Context, req *nodev1.DumpProcessorStateRequest) (*nodev1.DumpProcessorStateResponse, error) {
+	r := &processor.StateDumpRequest{
+		IncludeSubmitted: req.IncludeSubmitted,
+		ResponseC:        make(chan []*nodev1.PendingObservation, 1),
+	}
+
+	select {
+	case s.stateDumpC <- r:
+	case <-ctx.Done():
+		return nil, status.Error(codes.DeadlineExceeded, "timed out waiting for the processor")
+	}
+
+	select {
+	case observations := <-r.ResponseC:
+		return &nodev1.DumpProcessorStateResponse{Observations: observations}, nil
+	case <-ctx.Done():
+		return nil, status.Error(codes.DeadlineExceeded, "timed out waiting for the processor")
+	}
+}
+
+func (s *nodePrivilegedService) GetChainCounters(ctx context.Context, req *nodev1.GetChainCountersRequest) (*nodev1.GetChainCountersResponse, error) {
+	snapshot := s.chainCounters.Snapshot()
+	resp := &nodev1.GetChainCountersResponse{Chains: make([]*nodev1.ChainCounters, 0, len(snapshot))}
+	for _, c := range snapshot {
+		resp.Chains = append(resp.Chains, &nodev1.ChainCounters{
+			ChainId:            uint32(c.ChainID),
+			ChainName:          c.ChainID.String(),
+			MessagesObserved:   c.MessagesObserved,
+			ObservationsSigned: c.ObservationsSigned,
+			QuorumVaas:         c.QuorumVAAs,
+			Reobservations:     c.Reobservations,
+		})
+	}
+	return resp, nil
+}
+
+var stateBundlePrefix = []byte("state_bundle|")
+
+func stateBundleDigest(b []byte) ethcommon.Hash {
+	return ethcrypto.Keccak256Hash(append(stateBundlePrefix, b...))
+}
+
+func (s *nodePrivilegedService) ExportStateBundle(ctx context.Context, req *nodev1.ExportStateBundleRequest) (*nodev1.ExportStateBundleResponse, error) {
+	maxVAAs := int(req.MaxVaas)
+	if maxVAAs > db.RecentVAAsSize {
+		maxVAAs = db.RecentVAAsSize
+	}
+	vaas, err := s.db.RecentSignedVAAs(maxVAAs)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to read recent VAAs: %v", err)
+	}
+
+	gk := s.gk.Get()
+	bundle := &nodev1.GuardianStateBundle{
+		Timestamp:    time.Now().Unix(),
+		GuardianAddr: ethcrypto.PubkeyToAddress(gk.PublicKey).Hex(),
+		NodeName:     s.identity.nodeName,
+		P2PPeerId:    s.peerID(),
+		Version:      version.Version(),
+		ConfigHashes: s.identity.configHashes,
+		Networks:     p2p.DefaultRegistry.NetworkStats(),
+		SignedVaas:   vaas,
+	}
+	b, err := proto.Marshal(bundle)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to marshal state bundle: %v", err)
+	}
+
+	sig, err := ethcrypto.Sign(stateBundleDigest(b).Bytes(), gk)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to sign state bundle: %v", err)
+	}
+
+	s.logger.Info("exported state bundle", zap.Int("vaas", len(vaas)))
+	return &nodev1.ExportStateBundleResponse{
+		Bundle:    b,
+		Signature: sig,
+	}, nil
+}
+
+func (s *nodePrivilegedService) ExportConfigManifest(ctx context.Context, req *nodev1.ExportConfigManifestRequest) (*nodev1.ExportConfigManifestResponse, error) {
+	if s.identity.configManifest == nil {
+		return nil, status.Error(codes.Unavailable, "config manifest is not available")
+	}
+	m := proto.Clone(s.identity.configManifest).(*nodev1.GuardianConfigManifest)
+	gk := s.gk.Get()
+	m.Timestamp = time.Now().Unix()
+	m.GuardianAddr = ethcrypto.PubkeyToAddress(gk.PublicKey).Hex()
+	m.NodeName = s.identity.nodeName
+	m.Version = version.Version()
+
+	b, err := marshalConfigManifest(m)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to marshal config manifest: %v", err)
+	}
+
+	sig, err := ethcrypto.Sign(configManifestDigest(b).Bytes(), gk)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to sign config manifest: %v", err)
+	}
+
+	s.logger.Info("exported config manifest", zap.Int("chains", len(m.Chains)))
+	return &nodev1.ExportConfigManifestResponse{
+		Manifest:  b,
+		Signature: sig,
+	}, nil
+}
+
+// peerID returns the peer ID of the node's current node key.
+func (s *nodePrivilegedService) peerID() string {
+	s.nodeKeyMu.Lock()
+	defer s.nodeKeyMu.Unlock()
+	return s.identity.peerID
+}
+
+func (s *nodePrivilegedService) RotateNodeKey(ctx context.Context, req *nodev1.RotateNodeKeyRequest) (*nodev1.RotateNodeKeyResponse, error) {
+	if s.nodeKeyPath == "" || s.nodeKeyC == nil {
+		return nil, status.Error(codes.FailedPrecondition, "node key rotation requires a --nodeKey file")
+	}
+	s.nodeKeyMu.Lock()
+	defer s.nodeKeyMu.Unlock()
+
+	// The new key is written first, so that the node uses it after a restart even if it fails to rejoin the network now.
+	priv, backupPath, err := common.RotateNodeKey(s.nodeKeyPath)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to rotate node key: %v", err)
+	}
+	peerID, err := peer.IDFromPrivateKey(priv)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to derive peer ID: %v", err)
+	}
+	oldPeerID := s.identity.peerID
+	s.logger.Info("rotating node key", zap.String("old_peer_id", oldPeerID), zap.String("new_peer_id", peerID.Pretty()), zap.String("backup_path", backupPath))
+
+	rotation := &p2p.NodeKeyRotation{Key: priv, Err: make(chan error, 1)}
+	select {
+	case s.nodeKeyC <- rotation:
+	case <-ctx.Done():
+		return nil, status.Errorf(codes.DeadlineExceeded, "p2p node did not accept the new key, it takes effect at the next restart: %v", ctx.Err())
+	}
+	select {
+	case err := <-rotation.Err:
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to rejoin the network with the new key: %v", err)
+		}
+	case <-ctx.Done():
+		return nil, status.Errorf(codes.DeadlineExceeded, "p2p node is still restarting with the new key: %v", ctx.Err())
+	}
+	s.identity.peerID = peerID.Pretty()
+
+	s.logger.Info("rotated node key", zap.String("peer_id", peerID.Pretty()))
+	return &nodev1.RotateNodeKeyResponse{
+		OldPeerId:  oldPeerID,
+		NewPeerId:  peerID.Pretty(),
+		BackupPath: backupPath,
+	}, nil
+}
+
+// InjectSignedVAA stores a signed VAA that the node missed on gossip, after verifying it against the current guardian
+// set.
+func (s *nodePrivilegedService) InjectSignedVAA(ctx context.Context, req *nodev1.InjectSignedVAARequest) (*nodev1.InjectSignedVAAResponse, error) {
+	v, err := vaa.Unmarshal(req.VaaBytes)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse VAA: %v", err)
+	}
+	// PythNet VAAs are only kept in memory by the processor.
+	if v.EmitterChain == vaa.ChainIDPythNet {
+		return nil, status.Error(codes.InvalidArgument, "PythNet VAAs are not stored")
+	}
+
+	gs := s.gst.Get()
+	if gs == nil {
+		return nil, status.Error(codes.Unavailable, "guardian set not initialized yet")
+	}
+	if err := verifyStoredVAA(gs, v); errors.Is(err, db.ErrVAAUnverifiable) {
+		return nil, status.Errorf(codes.FailedPrecondition, "VAA is signed by guardian set %d, but the current guardian set is %d", v.GuardianSetIndex, gs.Index)
+	} else if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	resp := &nodev1.InjectSignedVAAResponse{
+		Digest:    v.HexDigest(),
+		MessageId: v.MessageID(),
+	}
+
+	// A stored VAA that failed verification is replaced, like the processor does for VAAs received from the network.
+	_, err = s.db.GetSignedVAABytes(*db.VaaIDFromVAA(v))
+	if err == nil {
+		resp.AlreadyStored = true
+		return resp, nil
+	} else if err != db.ErrVAANotFound && err != db.ErrVAACorrupt {
+		return nil, status.Errorf(codes.Internal, "failed to look up VAA: %v", err)
+	}
+
+	if err := s.db.StoreSignedVAA(v); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to store VAA: %v", err)
+	}
+
+	s.logger.Info("stored signed VAA injected through the admin service",
+		zap.String("digest", resp.Digest),
+		zap.String("message_id", resp.MessageId))
+
+	return resp, nil
+}
+
+// verifyStateBundle checks that a state bundle was signed by the guardian key it names and returns its contents.
+func verifyStateBundle(resp *nodev1.ExportStateBundleResponse) (*nodev1.GuardianStateBundle, error) {
+	var bundle nodev1.GuardianStateBundle
+	if err := proto.Unmarshal(resp.Bundle, &bundle); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal state bundle: %w", err)
+	}
+
+	pubKey, err := ethcrypto.SigToPub(stateBundleDigest(resp.Bundle).Bytes(), resp.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("failed to recover signer: %w", err)
+	}
+	if signer := ethcrypto.PubkeyToAddress(*pubKey); signer != ethcommon.HexToAddress(bundle.GuardianAddr) {
+		return nil, fmt.Errorf("state bundle of %s was signed by %s", bundle.GuardianAddr, signer.Hex())
+	}
+	return &bundle, nil
+}
@@ -5,13 +5,16 @@ import (
 	"fmt"
 	"io/ioutil"
 	"log"
+	"os"
 	"time"
 
 	"github.com/wormhole-foundation/wormhole/sdk/vaa"
 
 	"github.com/davecgh/go-spew/spew"
 	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
 
 	nodev1 "github.com/certusone/wormhole/node/pkg/proto/node/v1"
 	"github.com/status-im/keycard-go/hexutils"
@@ -24,6 +27,80 @@ var AdminClientGovernanceVAAVerifyCmd = &cobra.Command{
 	Args:  cobra.ExactArgs(1),
 }
 
+var AdminClientStateBundleVerifyCmd = &cobra.Command{
+	Use:   "state-bundle-verify [FILENAME]",
+	Short: "Verify the signature of a state bundle and print its contents as JSON (offline)",
+	Run:   runStateBundleVerify,
+	Args:  cobra.ExactArgs(1),
+}
+
+func runStateBundleVerify(cmd *cobra.Command, args []string) {
+	b, err := ioutil.ReadFile(args[0])
+	if err != nil {
+		log.Fatalf("failed to read file: %v", err)
+	}
+
+	var resp nodev1.ExportStateBundleResponse
+	if err := proto.Unmarshal(b, &resp); err != nil {
+		log.Fatalf("failed to deserialize: %v", err)
+	}
+
+	bundle, err := verifyStateBundle(&resp)
+	if err != nil {
+		log.Fatalf("invalid state bundle: %v", err)
+	}
+
+	out, err := protojson.MarshalOptions{Multiline: true, Indent: "  "}.Marshal(bundle)
+	if err != nil {
+		log.Fatalf("failed to marshal state bundle: %v", err)
+	}
+
+	log.Printf("State bundle signed by %s at %s", bundle.GuardianAddr, time.Unix(bundle.Timestamp, 0))
+	fmt.Println(string(out))
+}
+
+var AdminClientConfigManifestDiffCmd = &cobra.Command{
+	Use:   "config-manifest-diff [OURS] [THEIRS]",
+	Short: "Verify the signatures of two config manifests and print the configuration drift between them (offline)",
+	Long: "Verify the signatures of two config manifests and print the configuration drift between them (offline).\n" +
+		"Exits with status 1 if the configurations differ.",
+	Run:  runConfigManifestDiff,
+	Args: cobra.ExactArgs(2),
+}
+
+func readConfigManifest(path string) *nodev1.GuardianConfigManifest {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		log.Fatalf("failed to read file: %v", err)
+	}
+
+	var resp nodev1.ExportConfigManifestResponse
+	if err := proto.Unmarshal(b, &resp); err != nil {
+		log.Fatalf("failed to deserialize %s: %v", path, err)
+	}
+
+	m, err := verifyConfigManifest(&resp)
+	if err != nil {
+		log.Fatalf("invalid config manifest %s: %v", path, err)
+	}
+	log.Printf("Config manifest %s signed by %s (%s) at %s", path, m.GuardianAddr, m.NodeName, time.Unix(m.Timestamp, 0))
+	return m
+}
+
+func runConfigManifestDiff(cmd *cobra.Command, args []string) {
+	ours, theirs := readConfigManifest(args[0]), readConfigManifest(args[1])
+
+	diffs := diffConfigManifests(ours, theirs)
+	if len(diffs) == 0 {
+		fmt.Println("No configuration drift")
+		return
+	}
+	for _, d := range diffs {
+		fmt.Println(d)
+	}
+	os.Exit(1)
+}
+
 func runGovernanceVAAVerify(cmd *cobra.Command, args []string) {
 	path := args[0]
 
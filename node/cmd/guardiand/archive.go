@@ -0,0 +1,278 @@
+package guardiand
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/archive"
+	"github.com/certusone/wormhole/node/pkg/common"
+	"github.com/certusone/wormhole/node/pkg/db"
+	"github.com/certusone/wormhole/node/pkg/p2p"
+	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
+	"github.com/certusone/wormhole/node/pkg/supervisor"
+	eth_common "github.com/ethereum/go-ethereum/common"
+	"github.com/gorilla/mux"
+	ipfslog "github.com/ipfs/go-log/v2"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc"
+)
+
+var (
+	archiveNodeKeyPath  *string
+	archiveDataDir      *string
+	archiveP2PNetworkID *string
+	archiveP2PPort      *uint
+	archiveP2PBootstrap *string
+	archiveStatusAddr   *string
+	archiveLogLevel     *string
+
+	archiveEthRPC      *string
+	archiveEthContract *string
+
+	archiveGuardianSetInterval *time.Duration
+
+	archiveDBVerifySample *uint64
+	archiveVAARetention   *string
+
+	archivePublicRPC    *string
+	archivePublicWeb    *string
+	archivePublicSocket *string
+	archiveTLSHostname  *string
+	archiveTLSProdEnv   *bool
+)
+
+func init() {
+	archiveNodeKeyPath = ArchiveCmd.Flags().String("nodeKey", "", "Path to node key (will be generated if it doesn't exist)")
+	archiveDataDir = ArchiveCmd.Flags().String("dataDir", "", "Data directory")
+	archiveP2PNetworkID = ArchiveCmd.Flags().String("network", "/wormhole/dev", "P2P network identifier")
+	archiveP2PPort = ArchiveCmd.Flags().Uint("port", 8999, "P2P UDP listener port")
+	archiveP2PBootstrap = ArchiveCmd.Flags().String("bootstrap", "", "P2P bootstrap peers (comma-separated)")
+	archiveStatusAddr = ArchiveCmd.Flags().String("statusAddr", "[::]:6060", "Listen address for status server (disabled if blank)")
+	archiveLogLevel = ArchiveCmd.Flags().String("logLevel", "info", "Logging level (debug, info, warn, error, dpanic, panic, fatal)")
+
+	archiveEthRPC = ArchiveCmd.Flags().String("ethRPC", "", "Ethereum RPC URL, used to look up the current guardian set")
+	archiveEthContract = ArchiveCmd.Flags().String("ethContract", "", "Ethereum contract address")
+	archiveGuardianSetInterval = ArchiveCmd.Flags().Duration("guardianSetInterval", time.Minute, "How often to check the Ethereum contract for a new guardian set")
+
+	archiveDBVerifySample = ArchiveCmd.Flags().Uint64("dbVerifySample", 0, "Verify every Nth stored signed VAA against the current guardian set at startup, and stop serving the corrupt ones (1 verifies all of them, 0 disables verification)")
+	archiveVAARetention = ArchiveCmd.Flags().String("vaaRetention", "", "Comma-separated list of <chain>[:<emitter address>]=<max age> entries after which stored VAAs are deleted. Emitter entries take precedence over chain entries, a max age of 0 keeps VAAs forever, and VAAs without an entry are kept forever")
+
+	archivePublicRPC = ArchiveCmd.Flags().String("publicRPC", "", "Listen address for public gRPC interface")
+	archivePublicWeb = ArchiveCmd.Flags().String("publicWeb", "", "Listen address for public REST and gRPC Web interface")
+	archivePublicSocket = ArchiveCmd.Flags().String("publicSocket", "", "Public gRPC service UNIX domain socket path, used as the publicWeb upstream")
+	archiveTLSHostname = ArchiveCmd.Flags().String("tlsHostname", "", "If set, serve publicWeb as TLS with this hostname using Let's Encrypt")
+	archiveTLSProdEnv = ArchiveCmd.Flags().Bool("tlsProdEnv", false,
+		"Use the production Let's Encrypt environment instead of staging")
+}
+
+// ArchiveCmd runs a read-only node that stores the signed VAAs it receives from gossip and serves them on the public
+// API. It needs no guardian key and does not observe any chain.
+var ArchiveCmd = &cobra.Command{
+	Use:   "archive",
+	Short: "Run a read-only VAA archive node",
+	Run:   runArchive,
+}
+
+func runArchive(cmd *cobra.Command, args []string) {
+	common.SetRestrictiveUmask()
+
+	lvl, err := ipfslog.LevelFromString(*archiveLogLevel)
+	if err != nil {
+		fmt.Println("Invalid log level")
+		os.Exit(1)
+	}
+
+	logger := zap.New(zapcore.NewCore(
+		consoleEncoder{zapcore.NewConsoleEncoder(
+			zap.NewDevelopmentEncoderConfig())},
+		zapcore.AddSync(zapcore.Lock(os.Stderr)),
+		zap.NewAtomicLevelAt(zapcore.Level(lvl))))
+
+	// Override the default go-log config, which uses a magic environment variable.
+	ipfslog.SetAllLoggers(lvl)
+	ipfslog.SetPrimaryCore(logger.Core())
+
+	// Status server
+	if *archiveStatusAddr != "" {
+		router := mux.NewRouter()
+
+		router.Handle("/metrics", promhttp.Handler())
+
+		go func() {
+			logger.Info("status server listening", zap.String("addr", *archiveStatusAddr))
+			logger.Error("status server crashed", zap.Error(http.ListenAndServe(*archiveStatusAddr, router)))
+		}()
+	}
+
+	// Verify flags
+
+	if *archiveNodeKeyPath == "" {
+		logger.Fatal("Please specify --nodeKey")
+	}
+	if *archiveDataDir == "" {
+		logger.Fatal("Please specify --dataDir")
+	}
+	if *archiveP2PBootstrap == "" {
+		logger.Fatal("Please specify --bootstrap")
+	}
+	if *archiveEthRPC == "" {
+		logger.Fatal("Please specify --ethRPC")
+	}
+	if !eth_common.IsHexAddress(*archiveEthContract) {
+		logger.Fatal("Please specify a valid --ethContract")
+	}
+	if *archivePublicWeb != "" && *archivePublicSocket == "" {
+		logger.Fatal("--publicWeb requires --publicSocket")
+	}
+	retention, err := common.ParseVAARetention(*archiveVAARetention)
+	if err != nil {
+		logger.Fatal("invalid --vaaRetention", zap.Error(err))
+	}
+
+	// Node's main lifecycle context.
+	rootCtx, rootCtxCancel = context.WithCancel(context.Background())
+	defer rootCtxCancel()
+
+	// Outbound gossip message queue
+	sendC := make(chan []byte)
+
+	// Inbound observations
+	obsvC := make(chan *gossipv1.SignedObservation, 50)
+
+	// Inbound observation requests
+	obsvReqC := make(chan *gossipv1.ObservationRequest, 50)
+
+	// Inbound signed VAAs
+	signedInC := make(chan *gossipv1.SignedVAAWithQuorum, 50)
+
+	// Guardian set state managed by the guardian set poller
+	gst := common.NewGuardianSetState()
+	guardianSets := archive.NewGuardianSets(gst)
+
+	store, err := db.Open(path.Join(*archiveDataDir, "db"))
+	if err != nil {
+		logger.Fatal("failed to open database", zap.Error(err))
+	}
+	defer store.Close()
+
+	// Ignore observations
+	go func() {
+		for {
+			select {
+			case <-rootCtx.Done():
+				return
+			case <-obsvC:
+			}
+		}
+	}()
+
+	// Ignore observation requests
+	// Note: without this, the whole program hangs on observation requests
+	go func() {
+		for {
+			select {
+			case <-rootCtx.Done():
+				return
+			case <-obsvReqC:
+			}
+		}
+	}()
+
+	// Load p2p private key
+	var priv crypto.PrivKey
+	priv, err = common.GetOrCreateNodeKey(logger, *archiveNodeKeyPath)
+	if err != nil {
+		logger.Fatal("Failed to load node key", zap.Error(err))
+	}
+
+	var publicrpcService supervisor.Runnable
+	if *archivePublicRPC != "" {
+		publicrpcService, _, err = publicrpcServiceRunnable(logger, *archivePublicRPC, store, gst, nil, nil)
+		if err != nil {
+			logger.Fatal("failed to create publicrpc service", zap.Error(err))
+		}
+	}
+
+	var publicSocketService, publicwebService supervisor.Runnable
+	if *archivePublicSocket != "" {
+		var publicSocketServer *grpc.Server
+		publicSocketService, publicSocketServer, err = publicrpcSocketRunnable(logger, *archivePublicSocket, store, gst)
+		if err != nil {
+			logger.Fatal("failed to create publicrpc service socket", zap.Error(err))
+		}
+
+		if *archivePublicWeb != "" {
+			publicwebService, err = publicwebServiceRunnable(logger, *archivePublicWeb, *archivePublicSocket, publicSocketServer,
+				*archiveTLSHostname, *archiveTLSProdEnv, path.Join(*archiveDataDir, "autocert"))
+			if err != nil {
+				logger.Fatal("failed to create publicweb service", zap.Error(err))
+			}
+		}
+	}
+
+	ethContractAddr := eth_common.HexToAddress(*archiveEthContract)
+
+	// Run supervisor.
+	supervisor.New(rootCtx, logger, func(ctx context.Context) error {
+		if err := supervisor.Run(ctx, "guardianset",
+			archive.GuardianSetPoller(*archiveEthRPC, ethContractAddr, guardianSets, *archiveGuardianSetInterval)); err != nil {
+			return err
+		}
+
+		if err := supervisor.Run(ctx, "p2p", p2p.Run(obsvC, nil, obsvReqC, nil, sendC, nil, signedInC, priv, nil, gst, *archiveP2PPort, *archiveP2PNetworkID, *archiveP2PBootstrap, "", false, rootCtxCancel, nil, nil, nil)); err != nil {
+			return err
+		}
+
+		if err := supervisor.Run(ctx, "archive", archive.NewArchiver(store, guardianSets, signedInC).Run); err != nil {
+			return err
+		}
+
+		if *archiveDBVerifySample != 0 {
+			if err := supervisor.Run(ctx, "dbverify", dbVerifyRunnable(store, *archiveDBVerifySample, gst)); err != nil {
+				return err
+			}
+		}
+
+		if len(retention.Chains()) != 0 {
+			if err := supervisor.Run(ctx, "vaaprune", vaaPruneRunnable(store, retention)); err != nil {
+				return err
+			}
+		}
+
+		if publicrpcService != nil {
+			if err := supervisor.Run(ctx, "publicrpc", publicrpcService); err != nil {
+				return err
+			}
+		}
+		if publicSocketService != nil {
+			if err := supervisor.Run(ctx, "publicsocket", publicSocketService); err != nil {
+				return err
+			}
+		}
+		if publicwebService != nil {
+			if err := supervisor.Run(ctx, "publicweb", publicwebService); err != nil {
+				return err
+			}
+		}
+
+		logger.Info("Started internal services")
+
+		<-ctx.Done()
+		return nil
+	},
+		// It's safer to crash and restart the process in case we encounter a panic,
+		// rather than attempting to reschedule the runnable.
+		supervisor.WithPropagatePanic)
+
+	<-rootCtx.Done()
+	logger.Info("root context cancelled, exiting...")
+	// TODO: wait for things to shut down gracefully
+}
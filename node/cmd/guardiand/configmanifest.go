@@ -0,0 +1,236 @@
+package guardiand
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	nodev1 "github.com/certusone/wormhole/node/pkg/proto/node/v1"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/pflag"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"google.golang.org/protobuf/proto"
+)
+
+// configManifestChains maps the flag name prefix of each chain to its chain ID.
+var configManifestChains = map[string]vaa.ChainID{
+	"solana":     vaa.ChainIDSolana,
+	"eth":        vaa.ChainIDEthereum,
+	"terra":      vaa.ChainIDTerra,
+	"bsc":        vaa.ChainIDBSC,
+	"polygon":    vaa.ChainIDPolygon,
+	"avalanche":  vaa.ChainIDAvalanche,
+	"oasis":      vaa.ChainIDOasis,
+	"algorand":   vaa.ChainIDAlgorand,
+	"aurora":     vaa.ChainIDAurora,
+	"fantom":     vaa.ChainIDFantom,
+	"karura":     vaa.ChainIDKarura,
+	"acala":      vaa.ChainIDAcala,
+	"klaytn":     vaa.ChainIDKlaytn,
+	"celo":       vaa.ChainIDCelo,
+	"near":       vaa.ChainIDNear,
+	"moonbeam":   vaa.ChainIDMoonbeam,
+	"neon":       vaa.ChainIDNeon,
+	"terra2":     vaa.ChainIDTerra2,
+	"injective":  vaa.ChainIDInjective,
+	"aptos":      vaa.ChainIDAptos,
+	"arbitrum":   vaa.ChainIDArbitrum,
+	"pythnet":    vaa.ChainIDPythNet,
+	"xpla":       vaa.ChainIDXpla,
+	"wormchain":  vaa.ChainIDWormchain,
+	"ethRopsten": vaa.ChainIDEthereumRopsten,
+}
+
+// configManifestPublicSuffixes are the suffixes of chain flags whose values are public and exported as is. The values
+// of all other chain flags, like RPC endpoints and API tokens, are hashed.
+var configManifestPublicSuffixes = []string{"Contract", "Account", "Handle"}
+
+// flagChain returns the chain a flag configures. The longest matching prefix wins, so that ethRopstenRPC belongs to
+// Ropsten rather than Ethereum.
+func flagChain(name string) (vaa.ChainID, string, bool) {
+	var (
+		chainID vaa.ChainID
+		prefix  string
+	)
+	for p, c := range configManifestChains {
+		if len(name) <= len(p) || !strings.HasPrefix(name, p) || !unicode.IsUpper(rune(name[len(p)])) {
+			continue
+		}
+		if len(p) > len(prefix) {
+			chainID, prefix = c, p
+		}
+	}
+	return chainID, prefix, prefix != ""
+}
+
+// configManifestFromFlags returns the node-independent part of the config manifest: the network, the chains with at
+// least one flag set and the enabled boolean flags. Node-local flags like paths and listen addresses are left out, as
+// they are expected to differ between guardians.
+func configManifestFromFlags(flags *pflag.FlagSet) *nodev1.GuardianConfigManifest {
+	m := &nodev1.GuardianConfigManifest{Chains: []*nodev1.ManifestChainConfig{}, Features: []string{}}
+	chains := make(map[vaa.ChainID]*nodev1.ManifestChainConfig)
+
+	flags.VisitAll(func(f *pflag.Flag) {
+		value := f.Value.String()
+		if f.Name == "network" {
+			m.Network = value
+			return
+		}
+		if f.Value.Type() == "bool" {
+			if value == "true" {
+				m.Features = append(m.Features, f.Name)
+			}
+			return
+		}
+
+		chainID, prefix, ok := flagChain(f.Name)
+		if !ok || value == "" {
+			return
+		}
+		c, exists := chains[chainID]
+		if !exists {
+			c = &nodev1.ManifestChainConfig{
+				ChainId:        uint32(chainID),
+				ChainName:      chainID.String(),
+				Settings:       make(map[string]string),
+				EndpointHashes: make(map[string]string),
+			}
+			chains[chainID] = c
+			m.Chains = append(m.Chains, c)
+		}
+		for _, suffix := range configManifestPublicSuffixes {
+			if f.Name == prefix+suffix {
+				c.Settings[f.Name] = value
+				return
+			}
+		}
+		h := sha256.Sum256([]byte(value))
+		c.EndpointHashes[f.Name] = hex.EncodeToString(h[:])
+	})
+
+	sort.Slice(m.Chains, func(i, j int) bool { return m.Chains[i].ChainId < m.Chains[j].ChainId })
+	sort.Strings(m.Features)
+	return m
+}
+
+var configManifestPrefix = []byte("config_manifest|")
+
+func configManifestDigest(b []byte) ethcommon.Hash {
+	return ethcrypto.Keccak256Hash(append(configManifestPrefix, b...))
+}
+
+// marshalConfigManifest serializes a manifest deterministically, so that equal manifests have equal signatures.
+func marshalConfigManifest(m *nodev1.GuardianConfigManifest) ([]byte, error) {
+	return proto.MarshalOptions{Deterministic: true}.Marshal(m)
+}
+
+// verifyConfigManifest checks that a config manifest was signed by the guardian key it names and returns its contents.
+func verifyConfigManifest(resp *nodev1.ExportConfigManifestResponse) (*nodev1.GuardianConfigManifest, error) {
+	var m nodev1.GuardianConfigManifest
+	if err := proto.Unmarshal(resp.Manifest, &m); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config manifest: %w", err)
+	}
+
+	pubKey, err := ethcrypto.SigToPub(configManifestDigest(resp.Manifest).Bytes(), resp.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("failed to recover signer: %w", err)
+	}
+	if signer := ethcrypto.PubkeyToAddress(*pubKey); signer != ethcommon.HexToAddress(m.GuardianAddr) {
+		return nil, fmt.Errorf("config manifest of %s was signed by %s", m.GuardianAddr, signer.Hex())
+	}
+	return &m, nil
+}
+
+// diffConfigManifests returns a description of each difference between two config manifests that indicates
+// configuration drift: the version, the network, the configured chains and their public settings, the set of
+// configured endpoint flags and the enabled features. Endpoint hashes are not compared, as guardians are expected to
+// use their own endpoints.
+func diffConfigManifests(ours, theirs *nodev1.GuardianConfigManifest) []string {
+	var diffs []string
+	differ := func(what, a, b string) {
+		if a != b {
+			diffs = append(diffs, fmt.Sprintf("%s: %q (ours) != %q (theirs)", what, a, b))
+		}
+	}
+
+	differ("version", ours.Version, theirs.Version)
+	differ("network", ours.Network, theirs.Network)
+
+	byID := func(chains []*nodev1.ManifestChainConfig) map[uint32]*nodev1.ManifestChainConfig {
+		r := make(map[uint32]*nodev1.ManifestChainConfig)
+		for _, c := range chains {
+			r[c.ChainId] = c
+		}
+		return r
+	}
+	ourChains, theirChains := byID(ours.Chains), byID(theirs.Chains)
+	ids := make([]uint32, 0, len(ourChains)+len(theirChains))
+	for id := range ourChains {
+		ids = append(ids, id)
+	}
+	for id := range theirChains {
+		if _, exists := ourChains[id]; !exists {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	for _, id := range ids {
+		a, b := ourChains[id], theirChains[id]
+		switch {
+		case b == nil:
+			diffs = append(diffs, fmt.Sprintf("chain %s: configured (ours) != not configured (theirs)", a.ChainName))
+			continue
+		case a == nil:
+			diffs = append(diffs, fmt.Sprintf("chain %s: not configured (ours) != configured (theirs)", b.ChainName))
+			continue
+		}
+		for _, name := range mergedKeys(a.Settings, b.Settings) {
+			differ(fmt.Sprintf("chain %s: %s", a.ChainName, name), a.Settings[name], b.Settings[name])
+		}
+		for _, name := range mergedKeys(a.EndpointHashes, b.EndpointHashes) {
+			_, ourSet := a.EndpointHashes[name]
+			_, theirSet := b.EndpointHashes[name]
+			if ourSet != theirSet {
+				diffs = append(diffs, fmt.Sprintf("chain %s: %s: set=%t (ours) != set=%t (theirs)", a.ChainName, name, ourSet, theirSet))
+			}
+		}
+	}
+
+	ourFeatures, theirFeatures := featureSet(ours), featureSet(theirs)
+	for _, name := range mergedKeys(ourFeatures, theirFeatures) {
+		if ourFeatures[name] != theirFeatures[name] {
+			diffs = append(diffs, fmt.Sprintf("feature %s: enabled=%t (ours) != enabled=%t (theirs)", name, ourFeatures[name] != "", theirFeatures[name] != ""))
+		}
+	}
+
+	return diffs
+}
+
+// featureSet returns the enabled features of a manifest, in the shape mergedKeys expects.
+func featureSet(m *nodev1.GuardianConfigManifest) map[string]string {
+	r := make(map[string]string, len(m.Features))
+	for _, f := range m.Features {
+		r[f] = "enabled"
+	}
+	return r
+}
+
+// mergedKeys returns the keys of both maps, sorted.
+func mergedKeys(a, b map[string]string) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, exists := a[k]; !exists {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
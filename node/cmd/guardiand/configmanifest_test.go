@@ -0,0 +1,63 @@
+package guardiand
+
+import (
+	"testing"
+
+	nodev1 "github.com/certusone/wormhole/node/pkg/proto/node/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestFlagChain(t *testing.T) {
+	for name, expected := range map[string]vaa.ChainID{
+		"ethRPC":             vaa.ChainIDEthereum,
+		"ethRopstenContract": vaa.ChainIDEthereumRopsten,
+		"terraLCD":           vaa.ChainIDTerra,
+		"terra2WS":           vaa.ChainIDTerra2,
+		"algorandAlgodToken": vaa.ChainIDAlgorand,
+	} {
+		chainID, _, ok := flagChain(name)
+		assert.True(t, ok, name)
+		assert.Equal(t, expected, chainID, name)
+	}
+
+	for _, name := range []string{"eth", "ethereum", "nearly", "nodeName", "network"} {
+		_, _, ok := flagChain(name)
+		assert.False(t, ok, name)
+	}
+}
+
+func TestDiffConfigManifests(t *testing.T) {
+	ours := &nodev1.GuardianConfigManifest{
+		NodeName: "ours",
+		Version:  "v2.14.0",
+		Network:  "/wormhole/mainnet",
+		Chains: []*nodev1.ManifestChainConfig{
+			{ChainId: 2, ChainName: "ethereum", Settings: map[string]string{"ethContract": "0x01"}, EndpointHashes: map[string]string{"ethRPC": "aa"}},
+			{ChainId: 4, ChainName: "bsc", Settings: map[string]string{"bscContract": "0x02"}, EndpointHashes: map[string]string{"bscRPC": "bb"}},
+		},
+		Features: []string{"chainGovernorEnabled"},
+	}
+
+	// Manifests differing only in node identity and endpoints do not drift
+	theirs := proto.Clone(ours).(*nodev1.GuardianConfigManifest)
+	theirs.NodeName = "theirs"
+	theirs.Chains[0].EndpointHashes["ethRPC"] = "cc"
+	assert.Empty(t, diffConfigManifests(ours, theirs))
+
+	theirs.Version = "v2.13.0"
+	theirs.Chains[0].Settings["ethContract"] = "0x03"
+	delete(theirs.Chains[0].EndpointHashes, "ethRPC")
+	theirs.Chains = append(theirs.Chains[:1], &nodev1.ManifestChainConfig{ChainId: 5, ChainName: "polygon"})
+	theirs.Features = []string{"bigTablePersistenceEnabled", "chainGovernorEnabled"}
+
+	assert.Equal(t, []string{
+		`version: "v2.14.0" (ours) != "v2.13.0" (theirs)`,
+		`chain ethereum: ethContract: "0x01" (ours) != "0x03" (theirs)`,
+		`chain ethereum: ethRPC: set=true (ours) != set=false (theirs)`,
+		`chain bsc: configured (ours) != not configured (theirs)`,
+		`chain polygon: not configured (ours) != configured (theirs)`,
+		`feature bigTablePersistenceEnabled: enabled=false (ours) != enabled=true (theirs)`,
+	}, diffConfigManifests(ours, theirs))
+}
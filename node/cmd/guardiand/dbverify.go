@@ -0,0 +1,96 @@
+package guardiand
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	"github.com/certusone/wormhole/node/pkg/db"
+	"github.com/certusone/wormhole/node/pkg/processor"
+	"github.com/certusone/wormhole/node/pkg/supervisor"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+var (
+	dbVerifiedVAAs = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "wormhole_db_verified_vaas",
+			Help: "Number of stored signed VAAs checked by the startup database verification, by result (ok, skipped, corrupt)",
+		}, []string{"result"})
+	dbChecksumFailed = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "wormhole_db_checksum_failed",
+			Help: "Set to 1 if the startup database verification found a Badger table checksum mismatch",
+		})
+)
+
+// dbVerifyRunnable verifies every sampleEvery-th signed VAA in store once the current guardian set is known. Stored
+// VAAs are checked against the current guardian set only; VAAs signed by another set are skipped. VAAs that fail
+// verification are no longer served.
+func dbVerifyRunnable(store *db.Database, sampleEvery uint64, gst *common.GuardianSetState) supervisor.Runnable {
+	return func(ctx context.Context) error {
+		logger := supervisor.Logger(ctx)
+		supervisor.Signal(ctx, supervisor.SignalHealthy)
+
+		gs := gst.Get()
+		for gs == nil {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(time.Second):
+				gs = gst.Get()
+			}
+		}
+
+		logger.Info("verifying database", zap.Uint64("sample_every", sampleEvery), zap.Uint32("guardian_set_index", gs.Index))
+		start := time.Now()
+		res, err := store.VerifySignedVAAs(sampleEvery, func(v *vaa.VAA) error {
+			return verifyStoredVAA(gs, v)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to verify database: %w", err)
+		}
+
+		dbVerifiedVAAs.WithLabelValues("ok").Set(float64(res.Verified))
+		dbVerifiedVAAs.WithLabelValues("skipped").Set(float64(res.Skipped))
+		dbVerifiedVAAs.WithLabelValues("corrupt").Set(float64(res.Corrupt))
+		if res.ChecksumErr != nil {
+			dbChecksumFailed.Set(1)
+			logger.Error("database checksum verification failed", zap.Error(res.ChecksumErr))
+		}
+
+		fields := []zap.Field{
+			zap.Int("verified", res.Verified),
+			zap.Int("skipped", res.Skipped),
+			zap.Int("corrupt", res.Corrupt),
+			zap.Duration("took", time.Since(start)),
+		}
+		if res.Corrupt != 0 {
+			logger.Error("database verification found corrupt VAAs, they will not be served", fields...)
+		} else {
+			logger.Info("database verification finished", fields...)
+		}
+
+		supervisor.Signal(ctx, supervisor.SignalDone)
+		return nil
+	}
+}
+
+// verifyStoredVAA checks that v has a quorum of valid signatures of gs. VAAs of other guardian sets cannot be checked.
+func verifyStoredVAA(gs *common.GuardianSet, v *vaa.VAA) error {
+	if v.GuardianSetIndex != gs.Index {
+		return db.ErrVAAUnverifiable
+	}
+	if len(v.Signatures) < processor.CalculateQuorum(len(gs.Keys)) {
+		return errors.New("VAA has no quorum")
+	}
+	if !v.VerifySignatures(gs.Keys) {
+		return errors.New("invalid VAA signatures")
+	}
+	return nil
+}
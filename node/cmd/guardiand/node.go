@@ -2,6 +2,7 @@ package guardiand
 
 import (
 	"context"
+	"crypto/ecdsa"
 	"encoding/base64"
 	"fmt"
 	"log"
@@ -10,18 +11,22 @@ import (
 	"os"
 	"path"
 	"strings"
+	"time"
 
+	"github.com/certusone/wormhole/node/pkg/watchers"
 	"github.com/certusone/wormhole/node/pkg/watchers/cosmwasm"
 
 	"github.com/certusone/wormhole/node/pkg/watchers/algorand"
 	"github.com/certusone/wormhole/node/pkg/watchers/aptos"
 	"github.com/certusone/wormhole/node/pkg/watchers/evm"
+	"github.com/certusone/wormhole/node/pkg/watchers/evm/connectors"
 	"github.com/certusone/wormhole/node/pkg/watchers/near"
 	"github.com/certusone/wormhole/node/pkg/watchers/solana"
 
 	"github.com/benbjohnson/clock"
 	"github.com/certusone/wormhole/node/pkg/db"
 	"github.com/certusone/wormhole/node/pkg/notify/discord"
+	"github.com/certusone/wormhole/node/pkg/partition"
 	"github.com/certusone/wormhole/node/pkg/telemetry"
 	"github.com/certusone/wormhole/node/pkg/version"
 	"github.com/gagliardetto/solana-go/rpc"
@@ -34,6 +39,7 @@ import (
 	"github.com/certusone/wormhole/node/pkg/common"
 	"github.com/certusone/wormhole/node/pkg/devnet"
 	"github.com/certusone/wormhole/node/pkg/governor"
+	"github.com/certusone/wormhole/node/pkg/heightcheck"
 	"github.com/certusone/wormhole/node/pkg/p2p"
 	"github.com/certusone/wormhole/node/pkg/processor"
 	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
@@ -59,14 +65,19 @@ var (
 
 	nodeKeyPath *string
 
-	adminSocketPath *string
+	adminSocketPath   *string
+	adminAuditLogPath *string
 
 	dataDir *string
 
+	dbVerifySample *uint64
+	vaaRetention   *string
+
 	statusAddr *string
 
-	guardianKeyPath *string
-	solanaContract  *string
+	guardianKeyPath           *string
+	additionalGuardianKeyPath *string
+	solanaContract            *string
 
 	ethRPC      *string
 	ethContract *string
@@ -160,6 +171,10 @@ var (
 	publicRPC *string
 	publicWeb *string
 
+	publicObservationRequestsPerMinute   *uint
+	publicObservationRequestFee          *string
+	publicObservationRequestFeeRecipient *string
+
 	tlsHostname *string
 	tlsProdEnv  *bool
 
@@ -171,6 +186,12 @@ var (
 	discordToken   *string
 	discordChannel *string
 
+	notifyWebhookURL             *string
+	notifySlackWebhookURL        *string
+	notifyPagerDutyRoutingKey    *string
+	notifyEvents                 *string
+	notifyGovernorQueueThreshold *uint64
+
 	bigTablePersistenceEnabled *bool
 	bigTableGCPProject         *string
 	bigTableInstanceName       *string
@@ -179,6 +200,16 @@ var (
 	bigTableKeyPath            *string
 
 	chainGovernorEnabled *bool
+
+	emitterAllowlist     *string
+	emitterAllowlistMode *string
+
+	reobservationChains       *string
+	reobservationGuardians    *string
+	reobservationMaxPerMinute *uint
+
+	watcherStallTimeout *time.Duration
+	heightLagThreshold  *time.Duration
 )
 
 func init() {
@@ -191,13 +222,17 @@ func init() {
 	nodeKeyPath = NodeCmd.Flags().String("nodeKey", "", "Path to node key (will be generated if it doesn't exist)")
 
 	adminSocketPath = NodeCmd.Flags().String("adminSocket", "", "Admin gRPC service UNIX domain socket path")
+	adminAuditLogPath = NodeCmd.Flags().String("adminAuditLog", "", "Path of the append-only log of admin gRPC calls (defaults to admin_audit.log in --dataDir)")
 
 	dataDir = NodeCmd.Flags().String("dataDir", "", "Data directory")
+	dbVerifySample = NodeCmd.Flags().Uint64("dbVerifySample", 0, "Verify every Nth stored signed VAA against the current guardian set at startup, and stop serving the corrupt ones (1 verifies all of them, 0 disables verification)")
+	vaaRetention = NodeCmd.Flags().String("vaaRetention", "", "Comma-separated list of <chain>[:<emitter address>]=<max age> entries after which stored VAAs are deleted. Emitter entries take precedence over chain entries, a max age of 0 keeps VAAs forever, and VAAs without an entry are kept forever")
 
 	guardianKeyPath = NodeCmd.Flags().String("guardianKey", "", "Path to guardian key (required)")
+	additionalGuardianKeyPath = NodeCmd.Flags().String("additionalGuardianKey", "", "Path to a second guardian key to use during a guardian set transition. Observations are signed with it once it is part of the current guardian set and --guardianKey is not")
 	solanaContract = NodeCmd.Flags().String("solanaContract", "", "Address of the Solana program (required)")
 
-	ethRPC = NodeCmd.Flags().String("ethRPC", "", "Ethereum RPC URL")
+	ethRPC = NodeCmd.Flags().String("ethRPC", "", "Ethereum RPC URL. EVM RPC flags accept a comma-separated list of endpoints, which are weighted by their health")
 	ethContract = NodeCmd.Flags().String("ethContract", "", "Ethereum contract address")
 
 	bscRPC = NodeCmd.Flags().String("bscRPC", "", "Binance Smart Chain RPC URL")
@@ -289,6 +324,10 @@ func init() {
 	publicRPC = NodeCmd.Flags().String("publicRPC", "", "Listen address for public gRPC interface")
 	publicWeb = NodeCmd.Flags().String("publicWeb", "", "Listen address for public REST and gRPC Web interface")
 
+	publicObservationRequestsPerMinute = NodeCmd.Flags().Uint("publicObservationRequestsPerMinute", 0, "Maximum number of observation requests accepted on the public API per minute, for EVM chains with an RPC (disabled if zero)")
+	publicObservationRequestFee = NodeCmd.Flags().String("publicObservationRequestFee", "", "Fee in wei to pay on Ethereum for an observation request on the public API (no fee if blank)")
+	publicObservationRequestFeeRecipient = NodeCmd.Flags().String("publicObservationRequestFeeRecipient", "", "Ethereum address observation request fees must be paid to")
+
 	tlsHostname = NodeCmd.Flags().String("tlsHostname", "", "If set, serve publicWeb as TLS with this hostname using Let's Encrypt")
 	tlsProdEnv = NodeCmd.Flags().Bool("tlsProdEnv", false,
 		"Use the production Let's Encrypt environment instead of staging")
@@ -304,6 +343,12 @@ func init() {
 	discordToken = NodeCmd.Flags().String("discordToken", "", "Discord bot token (optional)")
 	discordChannel = NodeCmd.Flags().String("discordChannel", "", "Discord channel name (optional)")
 
+	notifyWebhookURL = NodeCmd.Flags().String("notifyWebhookURL", "", "URL to post critical events to as JSON (optional)")
+	notifySlackWebhookURL = NodeCmd.Flags().String("notifySlackWebhookURL", "", "Slack incoming webhook URL to send critical events to (optional)")
+	notifyPagerDutyRoutingKey = NodeCmd.Flags().String("notifyPagerDutyRoutingKey", "", "PagerDuty Events API v2 routing key to raise incidents for critical events with (optional)")
+	notifyEvents = NodeCmd.Flags().String("notifyEvents", "", "Comma-separated list of events to notify about: watcher_down, governor_queue, guardian_set_change (all if empty)")
+	notifyGovernorQueueThreshold = NodeCmd.Flags().Uint64("notifyGovernorQueueThreshold", 0, "Notional value in USD of the governor queue above which to notify (disabled if zero)")
+
 	bigTablePersistenceEnabled = NodeCmd.Flags().Bool("bigTablePersistenceEnabled", false, "Turn on forwarding events to BigTable")
 	bigTableGCPProject = NodeCmd.Flags().String("bigTableGCPProject", "", "Google Cloud project ID for storing events")
 	bigTableInstanceName = NodeCmd.Flags().String("bigTableInstanceName", "", "BigTable instance name for storing events")
@@ -312,6 +357,16 @@ func init() {
 	bigTableKeyPath = NodeCmd.Flags().String("bigTableKeyPath", "", "Path to json Service Account key")
 
 	chainGovernorEnabled = NodeCmd.Flags().Bool("chainGovernorEnabled", false, "Run the chain governor")
+
+	emitterAllowlist = NodeCmd.Flags().String("emitterAllowlist", "", "Comma-separated list of <chain>:<emitter address> to observe. Chains without entries are unrestricted")
+	emitterAllowlistMode = NodeCmd.Flags().String("emitterAllowlistMode", common.EmitterAllowlistModeIgnore, "Handling of messages from emitters not on the allowlist (ignore, count)")
+
+	reobservationChains = NodeCmd.Flags().String("reobservationChains", "", "Comma-separated list of chains to honor re-observation requests for (all chains if blank)")
+	reobservationGuardians = NodeCmd.Flags().String("reobservationGuardians", "", "Comma-separated list of guardian addresses to honor re-observation requests from (all guardians if blank)")
+	reobservationMaxPerMinute = NodeCmd.Flags().Uint("reobservationMaxPerMinute", 0, "Maximum number of re-observation requests processed per minute (unlimited if zero)")
+
+	watcherStallTimeout = NodeCmd.Flags().Duration("watcherStallTimeout", 0, "Restart a watcher if its reported height does not change for this long (disabled if zero)")
+	heightLagThreshold = NodeCmd.Flags().Duration("heightLagThreshold", 5*time.Minute, "Warn if a watcher's height lags behind the heights reported by other guardians for this long (disabled if zero)")
 }
 
 var (
@@ -443,6 +498,12 @@ func runNode(cmd *cobra.Command, args []string) {
 		readiness.RegisterComponent(common.ReadinessArbitrumSyncing)
 	}
 
+	// Guardian set state managed by processor
+	gst := common.NewGuardianSetState()
+
+	// Compares the guardians we hear from with the guardian set, exposed on the status server
+	partitionDetector := partition.NewDetector(logger, gst, processor.CalculateQuorum)
+
 	if *statusAddr != "" {
 		// Use a custom routing instead of using http.DefaultServeMux directly to avoid accidentally exposing packages
 		// that register themselves with it by default (like pprof).
@@ -462,6 +523,9 @@ func runNode(cmd *cobra.Command, args []string) {
 		// Prometheus metrics (safe to expose to untrusted clients)
 		router.Handle("/metrics", promhttp.Handler())
 
+		// Network partition status (safe to expose to untrusted clients)
+		router.HandleFunc("/partitionz", partitionDetector.Handler)
+
 		go func() {
 			logger.Info("status server listening on [::]:6060")
 			// SECURITY: If making changes, ensure that we always do `router := mux.NewRouter()` before this to avoid accidentally exposing pprof
@@ -815,7 +879,23 @@ func runNode(cmd *cobra.Command, args []string) {
 	logger.Info("Loaded guardian key", zap.String(
 		"address", guardianAddr))
 
-	p2p.DefaultRegistry.SetGuardianAddress(guardianAddr)
+	// Additional guardian key for guardian set transitions
+	var additionalGk *ecdsa.PrivateKey
+	if *additionalGuardianKeyPath != "" {
+		additionalGk, err = loadGuardianKey(*additionalGuardianKeyPath)
+		if err != nil {
+			logger.Fatal("failed to load additional guardian key", zap.Error(err))
+		}
+
+		additionalGuardianAddr := ethcrypto.PubkeyToAddress(additionalGk.PublicKey).String()
+		if additionalGuardianAddr == guardianAddr {
+			logger.Fatal("--additionalGuardianKey must be different from --guardianKey")
+		}
+		logger.Info("Loaded additional guardian key", zap.String(
+			"address", additionalGuardianAddr))
+	}
+	// Shared by all components signing as the guardian, so that they follow the processor's switch between the keys.
+	gks := common.NewGuardianKeyState(gk, additionalGk)
 
 	// Node's main lifecycle context.
 	rootCtx, rootCtxCancel = context.WithCancel(context.Background())
@@ -830,9 +910,15 @@ func runNode(cmd *cobra.Command, args []string) {
 	// Outbound gossip message queue
 	sendC := make(chan []byte)
 
+	// Outbound gossip message queue for governance messages, which is published first
+	govSendC := make(chan []byte)
+
 	// Inbound observations
 	obsvC := make(chan *gossipv1.SignedObservation, 50)
 
+	// Inbound governance observations, which are processed first
+	govObsvC := make(chan *gossipv1.SignedObservation, 50)
+
 	// Inbound signed VAAs
 	signedInC := make(chan *gossipv1.SignedVAAWithQuorum, 50)
 
@@ -845,8 +931,14 @@ func runNode(cmd *cobra.Command, args []string) {
 	// Injected VAAs (manually generated rather than created via observation)
 	injectC := make(chan *vaa.VAA)
 
-	// Guardian set state managed by processor
-	gst := common.NewGuardianSetState()
+	// Processor state dump requests from the admin service
+	stateDumpC := make(chan *processor.StateDumpRequest)
+
+	// Node key rotations from the admin service
+	nodeKeyC := make(chan *p2p.NodeKeyRotation)
+
+	// Per-chain observation counters, exposed by the admin service
+	chainCounters := common.NewChainCounters()
 
 	// Per-chain observation requests
 	chainObsvReqC := make(map[vaa.ChainID]chan *gossipv1.ObservationRequest)
@@ -883,7 +975,12 @@ func runNode(cmd *cobra.Command, args []string) {
 		chainObsvReqC[vaa.ChainIDInjective] = make(chan *gossipv1.ObservationRequest, observationRequestBufferSize)
 		chainObsvReqC[vaa.ChainIDArbitrum] = make(chan *gossipv1.ObservationRequest, observationRequestBufferSize)
 	}
-	go handleReobservationRequests(rootCtx, clock.New(), logger, obsvReqC, chainObsvReqC)
+
+	reobservationPolicy, err := common.ParseReobservationPolicy(*reobservationChains, *reobservationGuardians, *reobservationMaxPerMinute)
+	if err != nil {
+		logger.Fatal("failed to parse re-observation policy", zap.Error(err))
+	}
+	go handleReobservationRequests(rootCtx, clock.New(), logger, obsvReqC, chainObsvReqC, reobservationPolicy, chainCounters)
 
 	var notifier *discord.DiscordNotifier
 	if *discordToken != "" {
@@ -964,14 +1061,64 @@ func runNode(cmd *cobra.Command, args []string) {
 		logger.Info("chain governor is disabled")
 	}
 
-	publicrpcService, publicrpcServer, err := publicrpcServiceRunnable(logger, *publicRPC, db, gst, gov)
+	retention, err := common.ParseVAARetention(*vaaRetention)
+	if err != nil {
+		logger.Fatal("invalid --vaaRetention", zap.Error(err))
+	}
+
+	allowlist, err := common.ParseEmitterAllowlist(*emitterAllowlist, *emitterAllowlistMode)
+	if err != nil {
+		logger.Fatal("failed to parse emitter allowlist", zap.Error(err))
+	}
+	if allowlist.Chains() != 0 {
+		logger.Info("emitter allowlist is enabled", zap.Int("chains", allowlist.Chains()), zap.String("mode", *emitterAllowlistMode))
+	}
+
+	obsvReqGate, err := publicObservationRequestGate(logger, obsvReqSendC)
+	if err != nil {
+		logger.Fatal("failed to configure public observation requests", zap.Error(err))
+	}
+
+	notifyMonitor, err := notificationMonitor(logger, gst, gov)
+	if err != nil {
+		logger.Fatal("failed to configure notifications", zap.Error(err))
+	}
+
+	publicrpcService, publicrpcServer, err := publicrpcServiceRunnable(logger, *publicRPC, db, gst, gov, obsvReqGate)
 
 	if err != nil {
 		log.Fatal("failed to create publicrpc service socket", zap.Error(err))
 	}
 
 	// local admin service socket
-	adminService, err := adminServiceRunnable(logger, *adminSocketPath, injectC, signedInC, obsvReqSendC, db, gst, gov)
+	peerID, err := peer.IDFromPrivateKey(priv)
+	if err != nil {
+		logger.Fatal("failed to derive peer ID from node key", zap.Error(err))
+	}
+	identity := stateBundleIdentity{
+		nodeName:       *nodeName,
+		peerID:         peerID.Pretty(),
+		configHashes:   flagHashes(cmd.Flags()),
+		configManifest: configManifestFromFlags(cmd.Flags()),
+	}
+
+	// Devnet node keys are derived from the node index, so they cannot be rotated.
+	rotatableNodeKeyPath := *nodeKeyPath
+	if *unsafeDevMode {
+		rotatableNodeKeyPath = ""
+	}
+
+	auditLogPath := *adminAuditLogPath
+	if auditLogPath == "" {
+		auditLogPath = path.Join(*dataDir, "admin_audit.log")
+	}
+	auditLog, err := openAdminAuditLog(auditLogPath)
+	if err != nil {
+		logger.Fatal("failed to open admin audit log", zap.Error(err))
+	}
+	defer auditLog.Close()
+
+	adminService, err := adminServiceRunnable(logger, *adminSocketPath, injectC, signedInC, obsvReqSendC, db, gst, gov, stateDumpC, gks, identity, chainCounters, obsvReqGate, rotatableNodeKeyPath, nodeKeyC, auditLog)
 	if err != nil {
 		logger.Fatal("failed to create admin service socket", zap.Error(err))
 	}
@@ -985,17 +1132,17 @@ func runNode(cmd *cobra.Command, args []string) {
 	// Run supervisor.
 	supervisor.New(rootCtx, logger, func(ctx context.Context) error {
 		if err := supervisor.Run(ctx, "p2p", p2p.Run(
-			obsvC, obsvReqC, obsvReqSendC, sendC, signedInC, priv, gk, gst, *p2pPort, *p2pNetworkID, *p2pBootstrap, *nodeName, *disableHeartbeatVerify, rootCtxCancel, gov)); err != nil {
+			obsvC, govObsvC, obsvReqC, obsvReqSendC, sendC, govSendC, signedInC, priv, gks, gst, *p2pPort, *p2pNetworkID, *p2pBootstrap, *nodeName, *disableHeartbeatVerify, rootCtxCancel, gov, reobservationPolicy, nodeKeyC)); err != nil {
 			return err
 		}
 
 		if err := supervisor.Run(ctx, "ethwatch",
-			evm.NewEthWatcher(*ethRPC, ethContractAddr, "eth", common.ReadinessEthSyncing, vaa.ChainIDEthereum, lockC, setC, 1, chainObsvReqC[vaa.ChainIDEthereum], *unsafeDevMode).Run); err != nil {
+			watchers.WithWatchdog(vaa.ChainIDEthereum, *watcherStallTimeout, evm.NewEthWatcher(*ethRPC, ethContractAddr, "eth", common.ReadinessEthSyncing, vaa.ChainIDEthereum, lockC, setC, 1, chainObsvReqC[vaa.ChainIDEthereum], *unsafeDevMode).Run)); err != nil {
 			return err
 		}
 
 		if err := supervisor.Run(ctx, "bscwatch",
-			evm.NewEthWatcher(*bscRPC, bscContractAddr, "bsc", common.ReadinessBSCSyncing, vaa.ChainIDBSC, lockC, nil, 1, chainObsvReqC[vaa.ChainIDBSC], *unsafeDevMode).Run); err != nil {
+			watchers.WithWatchdog(vaa.ChainIDBSC, *watcherStallTimeout, evm.NewEthWatcher(*bscRPC, bscContractAddr, "bsc", common.ReadinessBSCSyncing, vaa.ChainIDBSC, lockC, nil, 1, chainObsvReqC[vaa.ChainIDBSC], *unsafeDevMode).Run)); err != nil {
 			return err
 		}
 
@@ -1005,7 +1152,7 @@ func runNode(cmd *cobra.Command, args []string) {
 		}
 
 		if err := supervisor.Run(ctx, "polygonwatch",
-			evm.NewEthWatcher(*polygonRPC, polygonContractAddr, "polygon", common.ReadinessPolygonSyncing, vaa.ChainIDPolygon, lockC, nil, polygonMinConfirmations, chainObsvReqC[vaa.ChainIDPolygon], *unsafeDevMode).Run); err != nil {
+			watchers.WithWatchdog(vaa.ChainIDPolygon, *watcherStallTimeout, evm.NewEthWatcher(*polygonRPC, polygonContractAddr, "polygon", common.ReadinessPolygonSyncing, vaa.ChainIDPolygon, lockC, nil, polygonMinConfirmations, chainObsvReqC[vaa.ChainIDPolygon], *unsafeDevMode).Run)); err != nil {
 			// Special case: Polygon can fork like PoW Ethereum, and it's not clear what the safe number of blocks is
 			//
 			// Hardcode the minimum number of confirmations to 512 regardless of what the smart contract specifies to protect
@@ -1014,53 +1161,53 @@ func runNode(cmd *cobra.Command, args []string) {
 			return err
 		}
 		if err := supervisor.Run(ctx, "avalanchewatch",
-			evm.NewEthWatcher(*avalancheRPC, avalancheContractAddr, "avalanche", common.ReadinessAvalancheSyncing, vaa.ChainIDAvalanche, lockC, nil, 1, chainObsvReqC[vaa.ChainIDAvalanche], *unsafeDevMode).Run); err != nil {
+			watchers.WithWatchdog(vaa.ChainIDAvalanche, *watcherStallTimeout, evm.NewEthWatcher(*avalancheRPC, avalancheContractAddr, "avalanche", common.ReadinessAvalancheSyncing, vaa.ChainIDAvalanche, lockC, nil, 1, chainObsvReqC[vaa.ChainIDAvalanche], *unsafeDevMode).Run)); err != nil {
 			return err
 		}
 		if err := supervisor.Run(ctx, "oasiswatch",
-			evm.NewEthWatcher(*oasisRPC, oasisContractAddr, "oasis", common.ReadinessOasisSyncing, vaa.ChainIDOasis, lockC, nil, 1, chainObsvReqC[vaa.ChainIDOasis], *unsafeDevMode).Run); err != nil {
+			watchers.WithWatchdog(vaa.ChainIDOasis, *watcherStallTimeout, evm.NewEthWatcher(*oasisRPC, oasisContractAddr, "oasis", common.ReadinessOasisSyncing, vaa.ChainIDOasis, lockC, nil, 1, chainObsvReqC[vaa.ChainIDOasis], *unsafeDevMode).Run)); err != nil {
 			return err
 		}
 		if err := supervisor.Run(ctx, "aurorawatch",
-			evm.NewEthWatcher(*auroraRPC, auroraContractAddr, "aurora", common.ReadinessAuroraSyncing, vaa.ChainIDAurora, lockC, nil, 1, chainObsvReqC[vaa.ChainIDAurora], *unsafeDevMode).Run); err != nil {
+			watchers.WithWatchdog(vaa.ChainIDAurora, *watcherStallTimeout, evm.NewEthWatcher(*auroraRPC, auroraContractAddr, "aurora", common.ReadinessAuroraSyncing, vaa.ChainIDAurora, lockC, nil, 1, chainObsvReqC[vaa.ChainIDAurora], *unsafeDevMode).Run)); err != nil {
 			return err
 		}
 		if err := supervisor.Run(ctx, "fantomwatch",
-			evm.NewEthWatcher(*fantomRPC, fantomContractAddr, "fantom", common.ReadinessFantomSyncing, vaa.ChainIDFantom, lockC, nil, 1, chainObsvReqC[vaa.ChainIDFantom], *unsafeDevMode).Run); err != nil {
+			watchers.WithWatchdog(vaa.ChainIDFantom, *watcherStallTimeout, evm.NewEthWatcher(*fantomRPC, fantomContractAddr, "fantom", common.ReadinessFantomSyncing, vaa.ChainIDFantom, lockC, nil, 1, chainObsvReqC[vaa.ChainIDFantom], *unsafeDevMode).Run)); err != nil {
 			return err
 		}
 		if err := supervisor.Run(ctx, "karurawatch",
-			evm.NewEthWatcher(*karuraRPC, karuraContractAddr, "karura", common.ReadinessKaruraSyncing, vaa.ChainIDKarura, lockC, nil, 1, chainObsvReqC[vaa.ChainIDKarura], *unsafeDevMode).Run); err != nil {
+			watchers.WithWatchdog(vaa.ChainIDKarura, *watcherStallTimeout, evm.NewEthWatcher(*karuraRPC, karuraContractAddr, "karura", common.ReadinessKaruraSyncing, vaa.ChainIDKarura, lockC, nil, 1, chainObsvReqC[vaa.ChainIDKarura], *unsafeDevMode).Run)); err != nil {
 			return err
 		}
 		if err := supervisor.Run(ctx, "acalawatch",
-			evm.NewEthWatcher(*acalaRPC, acalaContractAddr, "acala", common.ReadinessAcalaSyncing, vaa.ChainIDAcala, lockC, nil, 1, chainObsvReqC[vaa.ChainIDAcala], *unsafeDevMode).Run); err != nil {
+			watchers.WithWatchdog(vaa.ChainIDAcala, *watcherStallTimeout, evm.NewEthWatcher(*acalaRPC, acalaContractAddr, "acala", common.ReadinessAcalaSyncing, vaa.ChainIDAcala, lockC, nil, 1, chainObsvReqC[vaa.ChainIDAcala], *unsafeDevMode).Run)); err != nil {
 			return err
 		}
 		if err := supervisor.Run(ctx, "klaytnwatch",
-			evm.NewEthWatcher(*klaytnRPC, klaytnContractAddr, "klaytn", common.ReadinessKlaytnSyncing, vaa.ChainIDKlaytn, lockC, nil, 1, chainObsvReqC[vaa.ChainIDKlaytn], *unsafeDevMode).Run); err != nil {
+			watchers.WithWatchdog(vaa.ChainIDKlaytn, *watcherStallTimeout, evm.NewEthWatcher(*klaytnRPC, klaytnContractAddr, "klaytn", common.ReadinessKlaytnSyncing, vaa.ChainIDKlaytn, lockC, nil, 1, chainObsvReqC[vaa.ChainIDKlaytn], *unsafeDevMode).Run)); err != nil {
 			return err
 		}
 		if err := supervisor.Run(ctx, "celowatch",
-			evm.NewEthWatcher(*celoRPC, celoContractAddr, "celo", common.ReadinessCeloSyncing, vaa.ChainIDCelo, lockC, nil, 1, chainObsvReqC[vaa.ChainIDCelo], *unsafeDevMode).Run); err != nil {
+			watchers.WithWatchdog(vaa.ChainIDCelo, *watcherStallTimeout, evm.NewEthWatcher(*celoRPC, celoContractAddr, "celo", common.ReadinessCeloSyncing, vaa.ChainIDCelo, lockC, nil, 1, chainObsvReqC[vaa.ChainIDCelo], *unsafeDevMode).Run)); err != nil {
 			return err
 		}
 		if err := supervisor.Run(ctx, "moonbeamwatch",
-			evm.NewEthWatcher(*moonbeamRPC, moonbeamContractAddr, "moonbeam", common.ReadinessMoonbeamSyncing, vaa.ChainIDMoonbeam, lockC, nil, 1, chainObsvReqC[vaa.ChainIDMoonbeam], *unsafeDevMode).Run); err != nil {
+			watchers.WithWatchdog(vaa.ChainIDMoonbeam, *watcherStallTimeout, evm.NewEthWatcher(*moonbeamRPC, moonbeamContractAddr, "moonbeam", common.ReadinessMoonbeamSyncing, vaa.ChainIDMoonbeam, lockC, nil, 1, chainObsvReqC[vaa.ChainIDMoonbeam], *unsafeDevMode).Run)); err != nil {
 			return err
 		}
 
 		if *testnetMode {
 			if err := supervisor.Run(ctx, "ethropstenwatch",
-				evm.NewEthWatcher(*ethRopstenRPC, ethRopstenContractAddr, "ethropsten", common.ReadinessEthRopstenSyncing, vaa.ChainIDEthereumRopsten, lockC, nil, 1, chainObsvReqC[vaa.ChainIDEthereumRopsten], *unsafeDevMode).Run); err != nil {
+				watchers.WithWatchdog(vaa.ChainIDEthereumRopsten, *watcherStallTimeout, evm.NewEthWatcher(*ethRopstenRPC, ethRopstenContractAddr, "ethropsten", common.ReadinessEthRopstenSyncing, vaa.ChainIDEthereumRopsten, lockC, nil, 1, chainObsvReqC[vaa.ChainIDEthereumRopsten], *unsafeDevMode).Run)); err != nil {
 				return err
 			}
 			if err := supervisor.Run(ctx, "neonwatch",
-				evm.NewEthWatcher(*neonRPC, neonContractAddr, "neon", common.ReadinessNeonSyncing, vaa.ChainIDNeon, lockC, nil, 32, chainObsvReqC[vaa.ChainIDNeon], *unsafeDevMode).Run); err != nil {
+				watchers.WithWatchdog(vaa.ChainIDNeon, *watcherStallTimeout, evm.NewEthWatcher(*neonRPC, neonContractAddr, "neon", common.ReadinessNeonSyncing, vaa.ChainIDNeon, lockC, nil, 32, chainObsvReqC[vaa.ChainIDNeon], *unsafeDevMode).Run)); err != nil {
 				return err
 			}
 			if err := supervisor.Run(ctx, "arbitrumwatch",
-				evm.NewEthWatcher(*arbitrumRPC, arbitrumContractAddr, "arbitrum", common.ReadinessArbitrumSyncing, vaa.ChainIDArbitrum, lockC, nil, 1, chainObsvReqC[vaa.ChainIDArbitrum], *unsafeDevMode).Run); err != nil {
+				watchers.WithWatchdog(vaa.ChainIDArbitrum, *watcherStallTimeout, evm.NewEthWatcher(*arbitrumRPC, arbitrumContractAddr, "arbitrum", common.ReadinessArbitrumSyncing, vaa.ChainIDArbitrum, lockC, nil, 1, chainObsvReqC[vaa.ChainIDArbitrum], *unsafeDevMode).Run)); err != nil {
 				return err
 			}
 		}
@@ -1068,7 +1215,7 @@ func runNode(cmd *cobra.Command, args []string) {
 		if *terraWS != "" {
 			logger.Info("Starting Terra watcher")
 			if err := supervisor.Run(ctx, "terrawatch",
-				cosmwasm.NewWatcher(*terraWS, *terraLCD, *terraContract, lockC, chainObsvReqC[vaa.ChainIDTerra], common.ReadinessTerraSyncing, vaa.ChainIDTerra).Run); err != nil {
+				watchers.WithWatchdog(vaa.ChainIDTerra, *watcherStallTimeout, cosmwasm.NewWatcher(*terraWS, *terraLCD, *terraContract, lockC, chainObsvReqC[vaa.ChainIDTerra], common.ReadinessTerraSyncing, vaa.ChainIDTerra).Run)); err != nil {
 				return err
 			}
 		}
@@ -1076,7 +1223,7 @@ func runNode(cmd *cobra.Command, args []string) {
 		if *terra2WS != "" {
 			logger.Info("Starting Terra 2 watcher")
 			if err := supervisor.Run(ctx, "terra2watch",
-				cosmwasm.NewWatcher(*terra2WS, *terra2LCD, *terra2Contract, lockC, chainObsvReqC[vaa.ChainIDTerra2], common.ReadinessTerra2Syncing, vaa.ChainIDTerra2).Run); err != nil {
+				watchers.WithWatchdog(vaa.ChainIDTerra2, *watcherStallTimeout, cosmwasm.NewWatcher(*terra2WS, *terra2LCD, *terra2Contract, lockC, chainObsvReqC[vaa.ChainIDTerra2], common.ReadinessTerra2Syncing, vaa.ChainIDTerra2).Run)); err != nil {
 				return err
 			}
 		}
@@ -1084,27 +1231,27 @@ func runNode(cmd *cobra.Command, args []string) {
 		if *testnetMode {
 			logger.Info("Starting Injective watcher")
 			if err := supervisor.Run(ctx, "injectivewatch",
-				cosmwasm.NewWatcher(*injectiveWS, *injectiveLCD, *injectiveContract, lockC, chainObsvReqC[vaa.ChainIDInjective], common.ReadinessInjectiveSyncing, vaa.ChainIDInjective).Run); err != nil {
+				watchers.WithWatchdog(vaa.ChainIDInjective, *watcherStallTimeout, cosmwasm.NewWatcher(*injectiveWS, *injectiveLCD, *injectiveContract, lockC, chainObsvReqC[vaa.ChainIDInjective], common.ReadinessInjectiveSyncing, vaa.ChainIDInjective).Run)); err != nil {
 				return err
 			}
 		}
 		if *xplaWS != "" {
 			logger.Info("Starting XPLA watcher")
 			if err := supervisor.Run(ctx, "xplawatch",
-				cosmwasm.NewWatcher(*xplaWS, *xplaLCD, *xplaContract, lockC, chainObsvReqC[vaa.ChainIDXpla], common.ReadinessXplaSyncing, vaa.ChainIDXpla).Run); err != nil {
+				watchers.WithWatchdog(vaa.ChainIDXpla, *watcherStallTimeout, cosmwasm.NewWatcher(*xplaWS, *xplaLCD, *xplaContract, lockC, chainObsvReqC[vaa.ChainIDXpla], common.ReadinessXplaSyncing, vaa.ChainIDXpla).Run)); err != nil {
 				return err
 			}
 		}
 
 		if *algorandIndexerRPC != "" {
 			if err := supervisor.Run(ctx, "algorandwatch",
-				algorand.NewWatcher(*algorandIndexerRPC, *algorandIndexerToken, *algorandAlgodRPC, *algorandAlgodToken, *algorandAppID, lockC, setC, chainObsvReqC[vaa.ChainIDAlgorand]).Run); err != nil {
+				watchers.WithWatchdog(vaa.ChainIDAlgorand, *watcherStallTimeout, algorand.NewWatcher(*algorandIndexerRPC, *algorandIndexerToken, *algorandAlgodRPC, *algorandAlgodToken, *algorandAppID, lockC, setC, chainObsvReqC[vaa.ChainIDAlgorand]).Run)); err != nil {
 				return err
 			}
 		}
 		if *nearRPC != "" {
 			if err := supervisor.Run(ctx, "nearwatch",
-				near.NewWatcher(*nearRPC, *nearContract, lockC, chainObsvReqC[vaa.ChainIDNear], !(*unsafeDevMode || *testnetMode)).Run); err != nil {
+				watchers.WithWatchdog(vaa.ChainIDNear, *watcherStallTimeout, near.NewWatcher(*nearRPC, *nearContract, lockC, chainObsvReqC[vaa.ChainIDNear], !(*unsafeDevMode || *testnetMode)).Run)); err != nil {
 				return err
 			}
 		}
@@ -1113,37 +1260,37 @@ func runNode(cmd *cobra.Command, args []string) {
 		if *wormchainWS != "" && *wormchainLCD != "" {
 			logger.Info("Starting Wormchain watcher")
 			if err := supervisor.Run(ctx, "wormchainwatch",
-				wormchain.NewWatcher(*wormchainWS, *wormchainLCD, lockC, setC, chainObsvReqC[vaa.ChainIDWormchain]).Run); err != nil {
+				watchers.WithWatchdog(vaa.ChainIDWormchain, *watcherStallTimeout, wormchain.NewWatcher(*wormchainWS, *wormchainLCD, lockC, setC, chainObsvReqC[vaa.ChainIDWormchain], obsvReqC).Run)); err != nil {
 				return err
 			}
 		}
 		if *aptosRPC != "" {
 			if err := supervisor.Run(ctx, "aptoswatch",
-				aptos.NewWatcher(*aptosRPC, *aptosAccount, *aptosHandle, lockC, chainObsvReqC[vaa.ChainIDAptos]).Run); err != nil {
+				watchers.WithWatchdog(vaa.ChainIDAptos, *watcherStallTimeout, aptos.NewWatcher(*aptosRPC, *aptosAccount, *aptosHandle, lockC, chainObsvReqC[vaa.ChainIDAptos]).Run)); err != nil {
 				return err
 			}
 		}
 
 		if *solanaRPC != "" {
 			if err := supervisor.Run(ctx, "solwatch-confirmed",
-				solana.NewSolanaWatcher(*solanaRPC, solAddress, lockC, nil, rpc.CommitmentConfirmed, common.ReadinessSolanaSyncing, vaa.ChainIDSolana).Run); err != nil {
+				watchers.WithInstanceWatchdog(vaa.ChainIDSolana, string(rpc.CommitmentConfirmed), *watcherStallTimeout, solana.NewSolanaWatcher(*solanaRPC, solAddress, lockC, nil, rpc.CommitmentConfirmed, common.ReadinessSolanaSyncing, vaa.ChainIDSolana).Run)); err != nil {
 				return err
 			}
 
 			if err := supervisor.Run(ctx, "solwatch-finalized",
-				solana.NewSolanaWatcher(*solanaRPC, solAddress, lockC, chainObsvReqC[vaa.ChainIDSolana], rpc.CommitmentFinalized, common.ReadinessSolanaSyncing, vaa.ChainIDSolana).Run); err != nil {
+				watchers.WithInstanceWatchdog(vaa.ChainIDSolana, string(rpc.CommitmentFinalized), *watcherStallTimeout, solana.NewSolanaWatcher(*solanaRPC, solAddress, lockC, chainObsvReqC[vaa.ChainIDSolana], rpc.CommitmentFinalized, common.ReadinessSolanaSyncing, vaa.ChainIDSolana).Run)); err != nil {
 				return err
 			}
 		}
 
 		if *pythnetRPC != "" {
 			if err := supervisor.Run(ctx, "pythwatch-confirmed",
-				solana.NewSolanaWatcher(*pythnetRPC, pythnetAddress, lockC, nil, rpc.CommitmentConfirmed, common.ReadinessPythNetSyncing, vaa.ChainIDPythNet).Run); err != nil {
+				watchers.WithInstanceWatchdog(vaa.ChainIDPythNet, string(rpc.CommitmentConfirmed), *watcherStallTimeout, solana.NewSolanaWatcher(*pythnetRPC, pythnetAddress, lockC, nil, rpc.CommitmentConfirmed, common.ReadinessPythNetSyncing, vaa.ChainIDPythNet).Run)); err != nil {
 				return err
 			}
 
 			if err := supervisor.Run(ctx, "pythwatch-finalized",
-				solana.NewSolanaWatcher(*pythnetRPC, pythnetAddress, lockC, chainObsvReqC[vaa.ChainIDPythNet], rpc.CommitmentFinalized, common.ReadinessPythNetSyncing, vaa.ChainIDPythNet).Run); err != nil {
+				watchers.WithInstanceWatchdog(vaa.ChainIDPythNet, string(rpc.CommitmentFinalized), *watcherStallTimeout, solana.NewSolanaWatcher(*pythnetRPC, pythnetAddress, lockC, chainObsvReqC[vaa.ChainIDPythNet], rpc.CommitmentFinalized, common.ReadinessPythNetSyncing, vaa.ChainIDPythNet).Run)); err != nil {
 				return err
 			}
 		}
@@ -1160,23 +1307,50 @@ func runNode(cmd *cobra.Command, args []string) {
 			lockC,
 			setC,
 			sendC,
+			govSendC,
 			obsvC,
+			govObsvC,
 			obsvReqSendC,
 			injectC,
 			signedInC,
-			gk,
+			gks,
 			gst,
 			*unsafeDevMode,
 			*devNumGuardians,
-			*ethRPC,
+			firstEVMRPC(*ethRPC),
 			*wormchainLCD,
 			attestationEvents,
 			notifier,
 			gov,
+			allowlist,
+			stateDumpC,
+			chainCounters,
+			partitionDetector,
 		)
 		if err := supervisor.Run(ctx, "processor", p.Run); err != nil {
 			return err
 		}
+		if err := supervisor.Run(ctx, "partition", partitionDetector.Run); err != nil {
+			return err
+		}
+		if *heightLagThreshold != 0 {
+			checker := heightcheck.NewChecker(logger, gst, p2p.DefaultRegistry, gks, *heightLagThreshold)
+			if err := supervisor.Run(ctx, "heightcheck", checker.Run); err != nil {
+				return err
+			}
+		}
+
+		if *dbVerifySample != 0 {
+			if err := supervisor.Run(ctx, "dbverify", dbVerifyRunnable(db, *dbVerifySample, gst)); err != nil {
+				return err
+			}
+		}
+
+		if len(retention.Chains()) != 0 {
+			if err := supervisor.Run(ctx, "vaaprune", vaaPruneRunnable(db, retention)); err != nil {
+				return err
+			}
+		}
 
 		if err := supervisor.Run(ctx, "admin", adminService); err != nil {
 			return err
@@ -1191,6 +1365,11 @@ func runNode(cmd *cobra.Command, args []string) {
 				return err
 			}
 		}
+		if notifyMonitor != nil {
+			if err := supervisor.Run(ctx, "notify", notifyMonitor.Run); err != nil {
+				return err
+			}
+		}
 
 		if *bigTablePersistenceEnabled {
 			bigTableConnection := &reporter.BigTableConnectionConfig{
@@ -1238,3 +1417,12 @@ func decryptTelemetryServiceAccount() ([]byte, error) {
 
 	return creds, err
 }
+
+// firstEVMRPC returns the first endpoint of an EVM RPC flag, for the lookups that do not need the health-based
+// weighting of the watchers.
+func firstEVMRPC(rpc string) string {
+	if urls := connectors.SplitRPCURLs(rpc); len(urls) > 0 {
+		return urls[0]
+	}
+	return ""
+}
@@ -0,0 +1,49 @@
+package guardiand
+
+import (
+	"github.com/certusone/wormhole/node/pkg/common"
+	"github.com/certusone/wormhole/node/pkg/governor"
+	"github.com/certusone/wormhole/node/pkg/notify"
+	"github.com/certusone/wormhole/node/pkg/p2p"
+	"go.uber.org/zap"
+)
+
+// notificationMonitor returns the monitor sending critical events to the sinks configured with the --notify flags,
+// or nil if none is.
+func notificationMonitor(logger *zap.Logger, gst *common.GuardianSetState, gov *governor.ChainGovernor) (*notify.Monitor, error) {
+	var sinks []notify.Sink
+	if *notifyWebhookURL != "" {
+		sinks = append(sinks, notify.NewWebhookSink(*notifyWebhookURL))
+	}
+	if *notifySlackWebhookURL != "" {
+		sinks = append(sinks, notify.NewSlackSink(*notifySlackWebhookURL))
+	}
+	if *notifyPagerDutyRoutingKey != "" {
+		sinks = append(sinks, notify.NewPagerDutySink(*notifyPagerDutyRoutingKey, *nodeName))
+	}
+	if len(sinks) == 0 {
+		return nil, nil
+	}
+
+	kinds, err := notify.ParseEventKinds(*notifyEvents)
+	if err != nil {
+		return nil, err
+	}
+
+	sources := notify.Sources{
+		NetworkStats: p2p.DefaultRegistry.NetworkStats,
+		GuardianSet:  gst.Get,
+	}
+	if gov != nil {
+		sources.GovernorQueue = func() uint64 {
+			var total uint64
+			for _, e := range gov.GetEnqueuedVAAs() {
+				total += e.NotionalValue
+			}
+			return total
+		}
+	}
+
+	logger.Info("notifications are enabled", zap.Int("sinks", len(sinks)), zap.Any("events", kinds))
+	return notify.NewMonitor(logger, sinks, kinds, *notifyGovernorQueueThreshold, sources), nil
+}
@@ -0,0 +1,67 @@
+package guardiand
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/benbjohnson/clock"
+	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
+	"github.com/certusone/wormhole/node/pkg/publicrpc"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+// publicObservationRequestGate returns the gate for observation requests made on the public API, or nil if they are
+// disabled. Requests are accepted for the EVM chains the node has an RPC for, which is used to check that the
+// requested transaction exists.
+func publicObservationRequestGate(logger *zap.Logger, obsvReqSendC chan<- *gossipv1.ObservationRequest) (*publicrpc.ObservationRequestGate, error) {
+	if *publicObservationRequestsPerMinute == 0 {
+		return nil, nil
+	}
+
+	evmRPCs := map[vaa.ChainID]*string{
+		vaa.ChainIDEthereum:  ethRPC,
+		vaa.ChainIDBSC:       bscRPC,
+		vaa.ChainIDPolygon:   polygonRPC,
+		vaa.ChainIDAvalanche: avalancheRPC,
+		vaa.ChainIDOasis:     oasisRPC,
+		vaa.ChainIDAurora:    auroraRPC,
+		vaa.ChainIDFantom:    fantomRPC,
+		vaa.ChainIDKarura:    karuraRPC,
+		vaa.ChainIDAcala:     acalaRPC,
+		vaa.ChainIDKlaytn:    klaytnRPC,
+		vaa.ChainIDCelo:      celoRPC,
+		vaa.ChainIDMoonbeam:  moonbeamRPC,
+		vaa.ChainIDNeon:      neonRPC,
+		vaa.ChainIDArbitrum:  arbitrumRPC,
+	}
+	if *testnetMode {
+		evmRPCs[vaa.ChainIDEthereumRopsten] = ethRopstenRPC
+	}
+	txVerifiers := make(map[vaa.ChainID]publicrpc.TxVerifier)
+	for chainID, rpc := range evmRPCs {
+		if url := firstEVMRPC(*rpc); url != "" {
+			txVerifiers[chainID] = publicrpc.NewEVMTxVerifier(url)
+		}
+	}
+
+	var feeVerifier publicrpc.FeeVerifier
+	if *publicObservationRequestFee != "" {
+		fee, ok := new(big.Int).SetString(*publicObservationRequestFee, 10)
+		if !ok || fee.Sign() <= 0 {
+			return nil, fmt.Errorf("invalid --publicObservationRequestFee: %s", *publicObservationRequestFee)
+		}
+		if !ethcommon.IsHexAddress(*publicObservationRequestFeeRecipient) {
+			return nil, errors.New("--publicObservationRequestFee requires a valid --publicObservationRequestFeeRecipient")
+		}
+		feeVerifier = publicrpc.NewEVMFeeVerifier(firstEVMRPC(*ethRPC), ethcommon.HexToAddress(*publicObservationRequestFeeRecipient), fee)
+	}
+
+	logger.Info("public observation requests are enabled",
+		zap.Int("chains", len(txVerifiers)),
+		zap.Uint("max_per_minute", *publicObservationRequestsPerMinute),
+		zap.Bool("fee", feeVerifier != nil))
+	return publicrpc.NewObservationRequestGate(logger, clock.New(), obsvReqSendC, txVerifiers, feeVerifier, int(*publicObservationRequestsPerMinute)), nil
+}
@@ -14,7 +14,7 @@ import (
 	"google.golang.org/grpc"
 )
 
-func publicrpcServiceRunnable(logger *zap.Logger, listenAddr string, db *db.Database, gst *common.GuardianSetState, gov *governor.ChainGovernor) (supervisor.Runnable, *grpc.Server, error) {
+func publicrpcServiceRunnable(logger *zap.Logger, listenAddr string, db *db.Database, gst *common.GuardianSetState, gov *governor.ChainGovernor, obsvReqGate *publicrpc.ObservationRequestGate) (supervisor.Runnable, *grpc.Server, error) {
 	l, err := net.Listen("tcp", listenAddr)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to listen: %w", err)
@@ -23,6 +23,24 @@ func publicrpcServiceRunnable(logger *zap.Logger, listenAddr string, db *db.Data
 	logger.Info("publicrpc server listening", zap.String("addr", l.Addr().String()))
 
 	rpcServer := publicrpc.NewPublicrpcServer(logger, db, gst, gov)
+	rpcServer.SetObservationRequestGate(obsvReqGate)
+	grpcServer := common.NewInstrumentedGRPCServer(logger)
+	publicrpcv1.RegisterPublicRPCServiceServer(grpcServer, rpcServer)
+
+	return supervisor.GRPCServer(grpcServer, l, false), grpcServer, nil
+}
+
+// publicrpcSocketRunnable serves the public API on a UNIX socket. It stands in for the admin socket as the publicweb
+// upstream when the node runs without the admin service.
+func publicrpcSocketRunnable(logger *zap.Logger, socketPath string, db *db.Database, gst *common.GuardianSetState) (supervisor.Runnable, *grpc.Server, error) {
+	l, err := listenUnixSocket(socketPath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	logger.Info("publicrpc socket listening", zap.String("path", socketPath))
+
+	rpcServer := publicrpc.NewPublicrpcServer(logger, db, gst, nil)
 	grpcServer := common.NewInstrumentedGRPCServer(logger)
 	publicrpcv1.RegisterPublicRPCServiceServer(grpcServer, rpcServer)
 
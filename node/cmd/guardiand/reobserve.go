@@ -6,11 +6,22 @@ import (
 	"time"
 
 	"github.com/benbjohnson/clock"
+	"github.com/certusone/wormhole/node/pkg/common"
 	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
 	"github.com/wormhole-foundation/wormhole/sdk/vaa"
 	"go.uber.org/zap"
 )
 
+// messageIDReobservationChains are the chains whose watchers can find a message by its emitter address and sequence.
+// Requests by message ID for other chains are dropped, since their watchers can only look up transactions.
+var messageIDReobservationChains = map[vaa.ChainID]bool{
+	vaa.ChainIDTerra:     true,
+	vaa.ChainIDTerra2:    true,
+	vaa.ChainIDInjective: true,
+	vaa.ChainIDXpla:      true,
+	vaa.ChainIDWormchain: true,
+}
+
 // Multiplex observation requests to the appropriate chain
 func handleReobservationRequests(
 	ctx context.Context,
@@ -18,17 +29,23 @@ func handleReobservationRequests(
 	logger *zap.Logger,
 	obsvReqC <-chan *gossipv1.ObservationRequest,
 	chainObsvReqC map[vaa.ChainID]chan *gossipv1.ObservationRequest,
+	policy *common.ReobservationPolicy,
+	chainCounters *common.ChainCounters,
 ) {
 	// Due to the automatic re-observation requests sent out by the processor we may end
 	// up getting multiple requests to re-observe the same tx. Keep a cache of the
 	// requests received in the last 11 minutes so that we don't end up repeatedly
 	// re-observing the same transactions.
 	type cachedRequest struct {
-		chainId vaa.ChainID
-		txHash  string
+		chainId  vaa.ChainID
+		txHash   string
+		emitter  string
+		sequence uint64
 	}
 
 	cache := make(map[cachedRequest]time.Time)
+	// Times at which requests were sent to watchers in the last minute, for the policy's rate limit.
+	var processed []time.Time
 	ticker := clock.Ticker(7 * time.Minute)
 	for {
 		select {
@@ -42,9 +59,35 @@ func handleReobservationRequests(
 				}
 			}
 		case req := <-obsvReqC:
+			if err := common.ValidateObservationRequest(req); err != nil {
+				logger.Warn("dropping invalid re-observation request", zap.Error(err))
+				continue
+			}
+
 			r := cachedRequest{
-				chainId: vaa.ChainID(req.ChainId),
-				txHash:  hex.EncodeToString(req.TxHash),
+				chainId:  vaa.ChainID(req.ChainId),
+				txHash:   hex.EncodeToString(req.TxHash),
+				emitter:  hex.EncodeToString(req.EmitterAddress),
+				sequence: req.Sequence,
+			}
+
+			if r.emitter != "" && !messageIDReobservationChains[r.chainId] {
+				logger.Warn("dropping re-observation request by message ID for unsupported chain",
+					zap.Stringer("chain", r.chainId),
+					zap.String("emitter", r.emitter),
+					zap.Uint64("sequence", r.sequence),
+				)
+				continue
+			}
+
+			if !policy.AllowsChain(r.chainId) {
+				logger.Info("dropping re-observation request for chain not allowed by policy",
+					zap.Stringer("chain", r.chainId),
+					zap.String("tx_hash", r.txHash),
+					zap.String("emitter", r.emitter),
+					zap.Uint64("sequence", r.sequence),
+				)
+				continue
 			}
 
 			if _, ok := cache[r]; ok {
@@ -53,14 +96,35 @@ func handleReobservationRequests(
 				logger.Info("skipping duplicate re-observation request",
 					zap.Stringer("chain", r.chainId),
 					zap.String("tx_hash", r.txHash),
+					zap.String("emitter", r.emitter),
+					zap.Uint64("sequence", r.sequence),
 				)
 				continue
 			}
 
+			if limit := policy.MaxPerMinute(); limit != 0 {
+				now := clock.Now()
+				for len(processed) > 0 && now.Sub(processed[0]) >= time.Minute {
+					processed = processed[1:]
+				}
+				if uint(len(processed)) >= limit {
+					logger.Warn("dropping re-observation request over the per-minute limit",
+						zap.Stringer("chain", r.chainId),
+						zap.String("tx_hash", r.txHash),
+						zap.String("emitter", r.emitter),
+						zap.Uint64("sequence", r.sequence),
+						zap.Uint("limit", limit),
+					)
+					continue
+				}
+			}
+
 			if channel, ok := chainObsvReqC[r.chainId]; ok {
 				select {
 				case channel <- req:
 					cache[r] = clock.Now()
+					processed = append(processed, clock.Now())
+					chainCounters.Reobservation(r.chainId)
 
 				default:
 					logger.Warn("failed to send reobservation request to watcher",
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/benbjohnson/clock"
+	"github.com/certusone/wormhole/node/pkg/common"
 	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -21,6 +22,10 @@ type reobservationTestContext struct {
 }
 
 func setUpReobservationTest() (reobservationTestContext, func()) {
+	return setUpReobservationTestWithPolicy(nil)
+}
+
+func setUpReobservationTestWithPolicy(policy *common.ReobservationPolicy) (reobservationTestContext, func()) {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 
 	clock := clock.NewMock()
@@ -32,7 +37,7 @@ func setUpReobservationTest() (reobservationTestContext, func()) {
 		chainObsvReqC[vaa.ChainID(i)] = make(chan *gossipv1.ObservationRequest, 1)
 	}
 
-	go handleReobservationRequests(ctx, clock, zap.NewNop(), obsvReqC, chainObsvReqC)
+	go handleReobservationRequests(ctx, clock, zap.NewNop(), obsvReqC, chainObsvReqC, policy, nil)
 
 	tc := reobservationTestContext{
 		Context:       ctx,
@@ -127,6 +132,65 @@ func TestMultipleReobservations(t *testing.T) {
 	assert.Equal(t, req, actual)
 }
 
+func TestReobservationByMessageID(t *testing.T) {
+	ctx, cancel := setUpReobservationTest()
+	defer cancel()
+
+	req := &gossipv1.ObservationRequest{
+		ChainId:        uint32(vaa.ChainIDTerra),
+		EmitterAddress: vaa.Address{0x01}.Bytes(),
+		Sequence:       5,
+	}
+
+	ctx.obsvReqC <- req
+
+	actual, ok := readFromChannel(ctx, ctx.chainObsvReqC[vaa.ChainIDTerra])
+	require.True(t, ok)
+	assert.Equal(t, req, actual)
+
+	// A request for the same message is a duplicate.
+	ctx.obsvReqC <- req
+
+	_, ok = readFromChannel(ctx, ctx.chainObsvReqC[vaa.ChainIDTerra])
+	assert.False(t, ok)
+
+	// A different sequence of the same emitter is not.
+	req2 := &gossipv1.ObservationRequest{
+		ChainId:        uint32(vaa.ChainIDTerra),
+		EmitterAddress: vaa.Address{0x01}.Bytes(),
+		Sequence:       6,
+	}
+	ctx.obsvReqC <- req2
+
+	actual, ok = readFromChannel(ctx, ctx.chainObsvReqC[vaa.ChainIDTerra])
+	require.True(t, ok)
+	assert.Equal(t, req2, actual)
+}
+
+func TestReobservationByMessageIDUnsupportedChain(t *testing.T) {
+	ctx, cancel := setUpReobservationTest()
+	defer cancel()
+
+	ctx.obsvReqC <- &gossipv1.ObservationRequest{
+		ChainId:        uint32(vaa.ChainIDSolana),
+		EmitterAddress: vaa.Address{0x01}.Bytes(),
+		Sequence:       5,
+	}
+
+	_, ok := readFromChannel(ctx, ctx.chainObsvReqC[vaa.ChainIDSolana])
+	assert.False(t, ok)
+}
+
+func TestReobserveInvalidRequest(t *testing.T) {
+	ctx, cancel := setUpReobservationTest()
+	defer cancel()
+
+	ctx.obsvReqC <- &gossipv1.ObservationRequest{ChainId: 1}
+
+	_, ok := readFromChannel(ctx, ctx.chainObsvReqC[vaa.ChainID(1)])
+	assert.False(t, ok)
+}
+
 func TestReobserveUnknownChainId(t *testing.T) {
 	ctx, cancel := setUpReobservationTest()
 	defer cancel()
@@ -210,3 +274,46 @@ func TestBlockingSend(t *testing.T) {
 	_, ok = readFromChannel(ctx, ctx.chainObsvReqC[vaa.ChainID(req2.ChainId)])
 	assert.False(t, ok)
 }
+
+func TestReobservationPolicyChains(t *testing.T) {
+	policy, err := common.ParseReobservationPolicy("ethereum", "", 0)
+	require.NoError(t, err)
+	ctx, cancel := setUpReobservationTestWithPolicy(policy)
+	defer cancel()
+
+	txHash := []byte{0xe5, 0x9c, 0x1b, 0xe5, 0x0b, 0xe7, 0xe4, 0x7e}
+
+	ctx.obsvReqC <- &gossipv1.ObservationRequest{ChainId: uint32(vaa.ChainIDSolana), TxHash: txHash}
+	_, ok := readFromChannel(ctx, ctx.chainObsvReqC[vaa.ChainIDSolana])
+	assert.False(t, ok)
+
+	req := &gossipv1.ObservationRequest{ChainId: uint32(vaa.ChainIDEthereum), TxHash: txHash}
+	ctx.obsvReqC <- req
+	actual, ok := readFromChannel(ctx, ctx.chainObsvReqC[vaa.ChainIDEthereum])
+	require.True(t, ok)
+	assert.Equal(t, req, actual)
+}
+
+func TestReobservationPolicyRateLimit(t *testing.T) {
+	policy, err := common.ParseReobservationPolicy("", "", 2)
+	require.NoError(t, err)
+	ctx, cancel := setUpReobservationTestWithPolicy(policy)
+	defer cancel()
+
+	for i := byte(0); i < 2; i++ {
+		ctx.obsvReqC <- &gossipv1.ObservationRequest{ChainId: 1, TxHash: []byte{i}}
+		_, ok := readFromChannel(ctx, ctx.chainObsvReqC[vaa.ChainID(1)])
+		require.True(t, ok)
+	}
+
+	// The third request within a minute is dropped...
+	ctx.obsvReqC <- &gossipv1.ObservationRequest{ChainId: 1, TxHash: []byte{2}}
+	_, ok := readFromChannel(ctx, ctx.chainObsvReqC[vaa.ChainID(1)])
+	assert.False(t, ok)
+
+	// ...but is processed once the earlier requests are more than a minute old.
+	ctx.clock.Add(time.Minute)
+	ctx.obsvReqC <- &gossipv1.ObservationRequest{ChainId: 1, TxHash: []byte{2}}
+	_, ok = readFromChannel(ctx, ctx.chainObsvReqC[vaa.ChainID(1)])
+	assert.True(t, ok)
+}
@@ -0,0 +1,55 @@
+package guardiand
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	"github.com/certusone/wormhole/node/pkg/db"
+	"github.com/certusone/wormhole/node/pkg/supervisor"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+)
+
+// vaaPruneInterval is how often the VAAs that exceeded their retention are deleted.
+const vaaPruneInterval = time.Hour
+
+var dbVAAsPruned = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "wormhole_db_vaas_pruned_total",
+		Help: "Total number of signed VAAs deleted because they exceeded the retention of their emitter",
+	}, []string{"emitter_chain"})
+
+// vaaPruneRunnable periodically deletes the VAAs in store that are older than retention allows.
+func vaaPruneRunnable(store *db.Database, retention *common.VAARetention) supervisor.Runnable {
+	return func(ctx context.Context) error {
+		logger := supervisor.Logger(ctx)
+		supervisor.Signal(ctx, supervisor.SignalHealthy)
+
+		ticker := time.NewTicker(vaaPruneInterval)
+		defer ticker.Stop()
+
+		for {
+			start := time.Now()
+			deleted, err := store.PruneVAAs(retention, start)
+			if err != nil {
+				return fmt.Errorf("failed to prune VAAs: %w", err)
+			}
+
+			total := 0
+			for chainID, n := range deleted {
+				dbVAAsPruned.WithLabelValues(chainID.String()).Add(float64(n))
+				total += n
+			}
+			logger.Info("pruned VAAs", zap.Int("deleted", total), zap.Duration("took", time.Since(start)))
+
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-ticker.C:
+			}
+		}
+	}
+}
@@ -7,17 +7,25 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"path"
 	"sync"
+	"time"
 
+	"github.com/certusone/wormhole/node/pkg/archive"
 	"github.com/certusone/wormhole/node/pkg/common"
+	"github.com/certusone/wormhole/node/pkg/db"
+	"github.com/certusone/wormhole/node/pkg/natspub"
 	"github.com/certusone/wormhole/node/pkg/p2p"
 	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
 	spyv1 "github.com/certusone/wormhole/node/pkg/proto/spy/v1"
 	"github.com/certusone/wormhole/node/pkg/supervisor"
+	eth_common "github.com/ethereum/go-ethereum/common"
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 	ipfslog "github.com/ipfs/go-log/v2"
 	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/spf13/cobra"
 	"github.com/wormhole-foundation/wormhole/sdk/vaa"
@@ -32,6 +40,34 @@ var (
 	rootCtxCancel context.CancelFunc
 )
 
+var (
+	spySubscribers = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "wormhole_spy_subscribers",
+			Help: "Current number of connected spy subscribers",
+		})
+	spyMessagesDelivered = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "wormhole_spy_messages_delivered_total",
+			Help: "Total number of signed VAAs queued for delivery to subscribers, by whether the subscription is filtered",
+		}, []string{"filter"})
+	spyMessagesDropped = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "wormhole_spy_messages_dropped_total",
+			Help: "Total number of signed VAAs dropped because the subscriber's queue was full with --dropSlowSubscribers, by whether the subscription is filtered",
+		}, []string{"filter"})
+	spyGossipLag = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "wormhole_spy_gossip_lag_seconds",
+			Help:    "Time between a VAA's timestamp and its reception by the spy",
+			Buckets: []float64{1, 5, 15, 30, 60, 120, 300, 600, 1800, 3600},
+		})
+)
+
+// subscriptionQueueSize is the number of messages buffered per subscriber. Delivery to a subscriber that falls further
+// behind blocks, unless --dropSlowSubscribers is set, in which case its subscription is ended instead.
+const subscriptionQueueSize = 100
+
 var (
 	p2pNetworkID *string
 	p2pPort      *uint
@@ -44,6 +80,17 @@ var (
 	logLevel *string
 
 	spyRPC *string
+
+	dataDir *string
+
+	ethRPC              *string
+	ethContract         *string
+	guardianSetInterval *time.Duration
+
+	natsURL     *string
+	natsSubject *string
+
+	dropSlowSubscribers *bool
 )
 
 func init() {
@@ -58,6 +105,17 @@ func init() {
 	logLevel = SpyCmd.Flags().String("logLevel", "info", "Logging level (debug, info, warn, error, dpanic, panic, fatal)")
 
 	spyRPC = SpyCmd.Flags().String("spyRPC", "", "Listen address for gRPC interface")
+
+	dataDir = SpyCmd.Flags().String("dataDir", "", "Data directory to store signed VAAs in, used to resume subscriptions from a cursor (disabled if blank)")
+
+	ethRPC = SpyCmd.Flags().String("ethRPC", "", "Ethereum RPC URL, used to look up the guardian sets stored VAAs are verified against (requires --dataDir)")
+	ethContract = SpyCmd.Flags().String("ethContract", "", "Ethereum contract address (requires --dataDir)")
+	guardianSetInterval = SpyCmd.Flags().Duration("guardianSetInterval", time.Minute, "How often to check the Ethereum contract for a new guardian set")
+
+	natsURL = SpyCmd.Flags().String("natsURL", "", "NATS server URL (nats://[user:password@]host:port) to publish signed VAAs to JetStream (disabled if blank)")
+	natsSubject = SpyCmd.Flags().String("natsSubject", "wormhole.vaa", "Subject prefix of published VAAs, followed by the emitter chain ID")
+
+	dropSlowSubscribers = SpyCmd.Flags().Bool("dropSlowSubscribers", false, "End subscriptions with RESOURCE_EXHAUSTED when their queue is full, instead of blocking delivery to all subscribers")
 }
 
 // SpyCmd represents the node command
@@ -72,9 +130,18 @@ type spyServer struct {
 	logger *zap.Logger
 	subs   map[string]*subscription
 	subsMu sync.Mutex
+	// db stores all published VAAs so subscribers can resume from a cursor. It is nil if no data directory is configured.
+	db *db.Database
+	// sets verifies VAAs before they are stored in db.
+	sets *archive.GuardianSets
+	// nats publishes all VAAs to JetStream. It is nil if no NATS server is configured.
+	nats *natspub.Publisher
+	// dropSlowSubscribers ends subscriptions whose queue is full instead of blocking delivery.
+	dropSlowSubscribers bool
 }
 
 type message struct {
+	id       db.VAAID
 	vaaBytes []byte
 }
 
@@ -83,9 +150,27 @@ type filter struct {
 	emitterAddr vaa.Address
 }
 
+// Metrics labels for subscriptions with and without filters. Labelling by filter would make the number of series
+// depend on what subscribers ask for.
+const (
+	filteredLabel   = "filtered"
+	unfilteredLabel = "unfiltered"
+)
+
 type subscription struct {
 	filters []filter
 	ch      chan message
+	// done is closed when the subscriber goes away, so that a blocked delivery gives up.
+	done chan struct{}
+	// If dropSlow is set, messages are dropped once ch is full, and overflow is closed to end the subscription.
+	// overflowed is guarded by spyServer.subsMu.
+	dropSlow   bool
+	overflow   chan struct{}
+	overflowed bool
+	// While a subscription is backfilling from its cursors, live messages are appended to pending instead of ch, so
+	// that they are not dropped however long the backfill takes. Both are guarded by spyServer.subsMu.
+	backfilling bool
+	pending     []message
 }
 
 func subscriptionId() string {
@@ -108,26 +193,38 @@ func decodeEmitterAddr(hexAddr string) (vaa.Address, error) {
 }
 
 func (s *spyServer) Publish(vaaBytes []byte) error {
+	v, err := vaa.Unmarshal(vaaBytes)
+	if err != nil {
+		return err
+	}
+
+	spyGossipLag.Observe(time.Since(v.Timestamp).Seconds())
+
+	// Store the VAA before delivering it, so that a subscriber that is backfilling from a cursor either finds it
+	// in the store or receives it live. Only VAAs signed by their guardian set are stored, and a stored VAA is never
+	// replaced. Live delivery is not verified, as before.
+	if s.db != nil {
+		if _, err := archive.StoreVerifiedVAA(s.db, s.sets, v); err != nil {
+			s.logger.Debug("not storing signed VAA", zap.String("message_id", v.MessageID()), zap.Error(err))
+		}
+	}
+
+	if s.nats != nil {
+		s.nats.Publish(v, vaaBytes)
+	}
+
+	msg := message{id: *db.VaaIDFromVAA(v), vaaBytes: vaaBytes}
+
 	s.subsMu.Lock()
 	defer s.subsMu.Unlock()
 
-	var v *vaa.VAA
-
 	for _, sub := range s.subs {
 		if len(sub.filters) == 0 {
-			sub.ch <- message{vaaBytes: vaaBytes}
+			sub.deliver(msg, unfilteredLabel)
 		} else {
-			if v == nil {
-				var err error
-				v, err = vaa.Unmarshal(vaaBytes)
-				if err != nil {
-					return err
-				}
-			}
-
 			for _, fi := range sub.filters {
 				if fi.chainId == v.EmitterChain && fi.emitterAddr == v.EmitterAddress {
-					sub.ch <- message{vaaBytes: vaaBytes}
+					sub.deliver(msg, filteredLabel)
 				}
 			}
 		}
@@ -136,6 +233,37 @@ func (s *spyServer) Publish(vaaBytes []byte) error {
 	return nil
 }
 
+// deliver queues msg for the subscriber. If the subscriber's queue is full, it blocks until there is room or the
+// subscriber goes away, or with dropSlow, drops msg and ends the subscription. It must be called with
+// spyServer.subsMu held.
+func (sub *subscription) deliver(msg message, label string) {
+	if sub.backfilling {
+		sub.pending = append(sub.pending, msg)
+		spyMessagesDelivered.WithLabelValues(label).Inc()
+		return
+	}
+	if !sub.dropSlow {
+		select {
+		case sub.ch <- msg:
+			spyMessagesDelivered.WithLabelValues(label).Inc()
+		case <-sub.done:
+		}
+		return
+	}
+	if sub.overflowed {
+		spyMessagesDropped.WithLabelValues(label).Inc()
+		return
+	}
+	select {
+	case sub.ch <- msg:
+		spyMessagesDelivered.WithLabelValues(label).Inc()
+	default:
+		spyMessagesDropped.WithLabelValues(label).Inc()
+		sub.overflowed = true
+		close(sub.overflow)
+	}
+}
+
 func (s *spyServer) SubscribeSignedVAA(req *spyv1.SubscribeSignedVAARequest, resp spyv1.SpyRPCService_SubscribeSignedVAAServer) error {
 	var fi []filter
 	if req.Filters != nil {
@@ -156,39 +284,138 @@ func (s *spyServer) SubscribeSignedVAA(req *spyv1.SubscribeSignedVAARequest, res
 		}
 	}
 
+	var cursors []db.VAAID
+	for _, c := range req.Cursors {
+		addr, err := decodeEmitterAddr(c.EmitterAddress)
+		if err != nil {
+			return status.Error(codes.InvalidArgument, fmt.Sprintf("failed to decode cursor emitter address: %v", err))
+		}
+		cursor := db.VAAID{EmitterChain: vaa.ChainID(c.ChainId), EmitterAddress: addr, Sequence: c.LastSequence}
+		if !matchesFilters(fi, cursor.EmitterChain, cursor.EmitterAddress) {
+			return status.Error(codes.InvalidArgument, fmt.Sprintf("cursor for %d/%s does not match any filter", cursor.EmitterChain, cursor.EmitterAddress))
+		}
+		cursors = append(cursors, cursor)
+	}
+	if len(cursors) != 0 && s.db == nil {
+		return status.Error(codes.FailedPrecondition, "spy does not have a data directory configured, cursors are not supported")
+	}
+
 	s.subsMu.Lock()
 	id := subscriptionId()
 	sub := &subscription{
-		ch:      make(chan message, 1),
-		filters: fi,
+		ch:          make(chan message, subscriptionQueueSize),
+		done:        make(chan struct{}),
+		filters:     fi,
+		dropSlow:    s.dropSlowSubscribers,
+		overflow:    make(chan struct{}),
+		backfilling: len(cursors) != 0,
 	}
 	s.subs[id] = sub
 	s.subsMu.Unlock()
+	spySubscribers.Inc()
 
 	defer func() {
+		// Unblock a delivery to this subscriber, which holds the lock.
+		close(sub.done)
 		s.subsMu.Lock()
 		defer s.subsMu.Unlock()
 		delete(s.subs, id)
+		spySubscribers.Dec()
 	}()
 
+	// Fill the gaps from the local store. The subscription is registered first, so VAAs arriving in the meantime are
+	// held back until the backfill is done. Sequences are increasing per emitter, so a held back VAA is skipped if its
+	// sequence is not above the last one sent for its emitter.
+	backfilled := make(map[filter]uint64)
+	for _, cursor := range cursors {
+		var sendErr error
+		err := s.db.ForEachEmitterSignedVAAAfter(cursor, cursor.Sequence, func(b []byte) error {
+			v, err := vaa.Unmarshal(b)
+			if err != nil {
+				return fmt.Errorf("failed to unmarshal stored VAA: %w", err)
+			}
+			if sendErr = resp.Send(&spyv1.SubscribeSignedVAAResponse{VaaBytes: b}); sendErr != nil {
+				return sendErr
+			}
+			backfilled[filter{chainId: v.EmitterChain, emitterAddr: v.EmitterAddress}] = v.Sequence
+			return nil
+		})
+		if sendErr != nil {
+			return sendErr
+		}
+		if err != nil {
+			s.logger.Error("failed to read stored VAAs for cursor", zap.Stringer("emitter_chain", cursor.EmitterChain),
+				zap.Stringer("emitter_address", cursor.EmitterAddress), zap.Error(err))
+			return status.Error(codes.Internal, "failed to read stored VAAs")
+		}
+	}
+
+	send := func(msg message) error {
+		if last, ok := backfilled[filter{chainId: msg.id.EmitterChain, emitterAddr: msg.id.EmitterAddress}]; ok && msg.id.Sequence <= last {
+			return nil
+		}
+		return resp.Send(&spyv1.SubscribeSignedVAAResponse{
+			VaaBytes: msg.vaaBytes,
+		})
+	}
+
+	// Send the messages held back during the backfill, until none are left and live delivery can take over.
+	for sub.backfilling {
+		s.subsMu.Lock()
+		pending := sub.pending
+		sub.pending = nil
+		if len(pending) == 0 {
+			sub.backfilling = false
+		}
+		s.subsMu.Unlock()
+
+		for _, msg := range pending {
+			if err := send(msg); err != nil {
+				return err
+			}
+		}
+	}
+
 	for {
+		// Stop sending queued messages once some were dropped, the subscriber has to resume from a cursor anyway.
+		select {
+		case <-sub.overflow:
+			return status.Error(codes.ResourceExhausted, "subscriber fell behind and messages were dropped, resubscribe with cursors to resume")
+		default:
+		}
+
 		select {
 		case <-resp.Context().Done():
 			return resp.Context().Err()
+		case <-sub.overflow:
+			return status.Error(codes.ResourceExhausted, "subscriber fell behind and messages were dropped, resubscribe with cursors to resume")
 		case msg := <-sub.ch:
-			if err := resp.Send(&spyv1.SubscribeSignedVAAResponse{
-				VaaBytes: msg.vaaBytes,
-			}); err != nil {
+			if err := send(msg); err != nil {
 				return err
 			}
 		}
 	}
 }
 
-func newSpyServer(logger *zap.Logger) *spyServer {
+// matchesFilters returns true if the emitter matches one of filters, or if there are no filters.
+func matchesFilters(filters []filter, chainId vaa.ChainID, emitterAddr vaa.Address) bool {
+	if len(filters) == 0 {
+		return true
+	}
+	for _, fi := range filters {
+		if fi.chainId == chainId && fi.emitterAddr == emitterAddr {
+			return true
+		}
+	}
+	return false
+}
+
+func newSpyServer(logger *zap.Logger, db *db.Database, sets *archive.GuardianSets) *spyServer {
 	return &spyServer{
 		logger: logger.Named("spyserver"),
 		subs:   make(map[string]*subscription),
+		db:     db,
+		sets:   sets,
 	}
 }
 
@@ -226,7 +453,7 @@ func runSpy(cmd *cobra.Command, args []string) {
 		router.Handle("/metrics", promhttp.Handler())
 
 		go func() {
-			logger.Info("status server listening on [::]:6060")
+			logger.Info("status server listening", zap.String("addr", *statusAddr))
 			logger.Error("status server crashed", zap.Error(http.ListenAndServe(*statusAddr, router)))
 		}()
 	}
@@ -239,6 +466,14 @@ func runSpy(cmd *cobra.Command, args []string) {
 	if *p2pBootstrap == "" {
 		logger.Fatal("Please specify --bootstrap")
 	}
+	if *dataDir != "" {
+		if *ethRPC == "" {
+			logger.Fatal("--dataDir requires --ethRPC")
+		}
+		if !eth_common.IsHexAddress(*ethContract) {
+			logger.Fatal("--dataDir requires a valid --ethContract")
+		}
+	}
 
 	// Node's main lifecycle context.
 	rootCtx, rootCtxCancel = context.WithCancel(context.Background())
@@ -256,11 +491,29 @@ func runSpy(cmd *cobra.Command, args []string) {
 	// Inbound signed VAAs
 	signedInC := make(chan *gossipv1.SignedVAAWithQuorum, 50)
 
-	// Guardian set state managed by processor
+	// Guardian set state, and the guardian sets stored VAAs are verified against
 	gst := common.NewGuardianSetState()
+	guardianSets := archive.NewGuardianSets(gst)
+
+	// Signed VAA store, used to resume subscriptions from a cursor
+	var store *db.Database
+	if *dataDir != "" {
+		store, err = db.Open(path.Join(*dataDir, "db"))
+		if err != nil {
+			logger.Fatal("failed to open database", zap.Error(err))
+		}
+		defer store.Close()
+	}
 
 	// RPC server
-	s := newSpyServer(logger)
+	s := newSpyServer(logger, store, guardianSets)
+	s.dropSlowSubscribers = *dropSlowSubscribers
+	if *natsURL != "" {
+		s.nats, err = natspub.NewPublisher(logger, *natsURL, *natsSubject)
+		if err != nil {
+			logger.Fatal("failed to create NATS publisher", zap.Error(err))
+		}
+	}
 	rpcSvc, _, err := spyServerRunnable(s, logger, *spyRPC)
 	if err != nil {
 		logger.Fatal("failed to start RPC server", zap.Error(err))
@@ -314,7 +567,14 @@ func runSpy(cmd *cobra.Command, args []string) {
 
 	// Run supervisor.
 	supervisor.New(rootCtx, logger, func(ctx context.Context) error {
-		if err := supervisor.Run(ctx, "p2p", p2p.Run(obsvC, obsvReqC, nil, sendC, signedInC, priv, nil, gst, *p2pPort, *p2pNetworkID, *p2pBootstrap, "", false, rootCtxCancel, nil)); err != nil {
+		if store != nil {
+			if err := supervisor.Run(ctx, "guardianset",
+				archive.GuardianSetPoller(*ethRPC, eth_common.HexToAddress(*ethContract), guardianSets, *guardianSetInterval)); err != nil {
+				return err
+			}
+		}
+
+		if err := supervisor.Run(ctx, "p2p", p2p.Run(obsvC, nil, obsvReqC, nil, sendC, nil, signedInC, priv, nil, gst, *p2pPort, *p2pNetworkID, *p2pBootstrap, "", false, rootCtxCancel, nil, nil, nil)); err != nil {
 			return err
 		}
 
@@ -322,6 +582,12 @@ func runSpy(cmd *cobra.Command, args []string) {
 			return err
 		}
 
+		if s.nats != nil {
+			if err := supervisor.Run(ctx, "nats", s.nats.Run); err != nil {
+				return err
+			}
+		}
+
 		logger.Info("Started internal services")
 
 		<-ctx.Done()
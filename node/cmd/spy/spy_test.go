@@ -0,0 +1,258 @@
+package spy
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"testing"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/archive"
+	"github.com/certusone/wormhole/node/pkg/common"
+	"github.com/certusone/wormhole/node/pkg/db"
+	publicrpcv1 "github.com/certusone/wormhole/node/pkg/proto/publicrpc/v1"
+	spyv1 "github.com/certusone/wormhole/node/pkg/proto/spy/v1"
+	eth_common "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type mockSubscribeServer struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent chan []byte
+}
+
+func (m *mockSubscribeServer) Context() context.Context {
+	return m.ctx
+}
+
+func (m *mockSubscribeServer) Send(resp *spyv1.SubscribeSignedVAAResponse) error {
+	m.sent <- resp.VaaBytes
+	return nil
+}
+
+var testEmitter = vaa.Address{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 4}
+
+func signedVAA(t *testing.T, key *ecdsa.PrivateKey, seq uint64) []byte {
+	t.Helper()
+	v := &vaa.VAA{
+		Version:          vaa.SupportedVAAVersion,
+		GuardianSetIndex: 0,
+		Timestamp:        time.Unix(1000, 0),
+		Nonce:            1,
+		Sequence:         seq,
+		ConsistencyLevel: 1,
+		EmitterChain:     vaa.ChainIDSolana,
+		EmitterAddress:   testEmitter,
+		Payload:          []byte{1, 2, 3},
+	}
+	v.AddSignature(key, 0)
+	b, err := v.Marshal()
+	require.NoError(t, err)
+	return b
+}
+
+// guardianSets returns guardian sets with key as the only guardian of the current set 0.
+func guardianSets(key *ecdsa.PrivateKey) *archive.GuardianSets {
+	sets := archive.NewGuardianSets(common.NewGuardianSetState())
+	sets.Set(0, []eth_common.Address{crypto.PubkeyToAddress(key.PublicKey)}, time.Time{})
+	return sets
+}
+
+func sequenceOf(t *testing.T, b []byte) uint64 {
+	t.Helper()
+	v, err := vaa.Unmarshal(b)
+	require.NoError(t, err)
+	return v.Sequence
+}
+
+func TestSubscribeSignedVAAWithCursor(t *testing.T) {
+	d, err := db.Open(t.TempDir())
+	require.NoError(t, err)
+	defer d.Close()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	s := newSpyServer(zap.NewNop(), d, guardianSets(key))
+	for seq := uint64(1); seq <= 4; seq++ {
+		require.NoError(t, s.Publish(signedVAA(t, key, seq)))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream := &mockSubscribeServer{ctx: ctx, sent: make(chan []byte, 10)}
+
+	req := &spyv1.SubscribeSignedVAARequest{
+		Cursors: []*spyv1.EmitterCursor{{
+			ChainId:        publicrpcv1.ChainID(vaa.ChainIDSolana),
+			EmitterAddress: testEmitter.String(),
+			LastSequence:   2,
+		}},
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- s.SubscribeSignedVAA(req, stream) }()
+
+	// The gap is filled from the store in sequence order.
+	assert.Equal(t, uint64(3), sequenceOf(t, <-stream.sent))
+	assert.Equal(t, uint64(4), sequenceOf(t, <-stream.sent))
+
+	// Live messages are streamed afterwards.
+	require.Eventually(t, func() bool {
+		s.subsMu.Lock()
+		defer s.subsMu.Unlock()
+		return len(s.subs) == 1
+	}, time.Second, 10*time.Millisecond)
+	require.NoError(t, s.Publish(signedVAA(t, key, 5)))
+	assert.Equal(t, uint64(5), sequenceOf(t, <-stream.sent))
+
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+}
+
+func TestSubscribeSignedVAACursorWithoutStore(t *testing.T) {
+	s := newSpyServer(zap.NewNop(), nil, nil)
+	stream := &mockSubscribeServer{ctx: context.Background(), sent: make(chan []byte, 1)}
+
+	err := s.SubscribeSignedVAA(&spyv1.SubscribeSignedVAARequest{
+		Cursors: []*spyv1.EmitterCursor{{
+			ChainId:        publicrpcv1.ChainID(vaa.ChainIDSolana),
+			EmitterAddress: testEmitter.String(),
+		}},
+	}, stream)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestSubscribeSignedVAACursorNotMatchingFilter(t *testing.T) {
+	d, err := db.Open(t.TempDir())
+	require.NoError(t, err)
+	defer d.Close()
+
+	s := newSpyServer(zap.NewNop(), d, archive.NewGuardianSets(common.NewGuardianSetState()))
+	stream := &mockSubscribeServer{ctx: context.Background(), sent: make(chan []byte, 1)}
+
+	err = s.SubscribeSignedVAA(&spyv1.SubscribeSignedVAARequest{
+		Filters: []*spyv1.FilterEntry{{
+			Filter: &spyv1.FilterEntry_EmitterFilter{EmitterFilter: &spyv1.EmitterFilter{
+				ChainId:        publicrpcv1.ChainID(vaa.ChainIDEthereum),
+				EmitterAddress: testEmitter.String(),
+			}},
+		}},
+		Cursors: []*spyv1.EmitterCursor{{
+			ChainId:        publicrpcv1.ChainID(vaa.ChainIDSolana),
+			EmitterAddress: testEmitter.String(),
+		}},
+	}, stream)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestPublishStoresOnlyVerifiedVAAs(t *testing.T) {
+	d, err := db.Open(t.TempDir())
+	require.NoError(t, err)
+	defer d.Close()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	otherKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	s := newSpyServer(zap.NewNop(), d, guardianSets(key))
+
+	// A VAA that is not signed by the guardian set is not stored.
+	require.NoError(t, s.Publish(signedVAA(t, otherKey, 1)))
+	_, err = d.GetSignedVAABytes(db.VAAID{EmitterChain: vaa.ChainIDSolana, EmitterAddress: testEmitter, Sequence: 1})
+	assert.ErrorIs(t, err, db.ErrVAANotFound)
+
+	// A valid VAA is stored, and not replaced by a later copy.
+	valid := signedVAA(t, key, 2)
+	require.NoError(t, s.Publish(valid))
+	v, err := vaa.Unmarshal(valid)
+	require.NoError(t, err)
+	v.Payload = []byte{4, 5, 6}
+	v.Signatures = nil
+	v.AddSignature(key, 0)
+	replacement, err := v.Marshal()
+	require.NoError(t, err)
+	require.NoError(t, s.Publish(replacement))
+
+	stored, err := d.GetSignedVAABytes(db.VAAID{EmitterChain: vaa.ChainIDSolana, EmitterAddress: testEmitter, Sequence: 2})
+	require.NoError(t, err)
+	assert.Equal(t, valid, stored)
+}
+
+func TestDeliverHoldsBackMessagesWhileBackfilling(t *testing.T) {
+	sub := &subscription{ch: make(chan message, 1), done: make(chan struct{}), backfilling: true}
+	for seq := uint64(1); seq <= 3; seq++ {
+		sub.deliver(message{id: db.VAAID{Sequence: seq}}, unfilteredLabel)
+	}
+	assert.Len(t, sub.pending, 3)
+	assert.Len(t, sub.ch, 0)
+
+	// Once live, delivery beyond the queue size blocks until there is room or the subscriber goes away.
+	sub.backfilling = false
+	sub.deliver(message{id: db.VAAID{Sequence: 4}}, unfilteredLabel)
+	delivered := make(chan struct{})
+	go func() {
+		sub.deliver(message{id: db.VAAID{Sequence: 5}}, unfilteredLabel)
+		close(delivered)
+	}()
+	select {
+	case <-delivered:
+		t.Fatal("delivery to a full queue did not block")
+	case <-time.After(50 * time.Millisecond):
+	}
+	assert.Equal(t, uint64(4), (<-sub.ch).id.Sequence)
+	<-delivered
+	assert.Equal(t, uint64(5), (<-sub.ch).id.Sequence)
+
+	sub.deliver(message{id: db.VAAID{Sequence: 6}}, unfilteredLabel)
+	close(sub.done)
+	sub.deliver(message{id: db.VAAID{Sequence: 7}}, unfilteredLabel)
+	assert.Len(t, sub.ch, 1)
+}
+
+func TestSubscribeSignedVAADropSlowSubscribers(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	s := newSpyServer(zap.NewNop(), nil, nil)
+	s.dropSlowSubscribers = true
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream := &mockSubscribeServer{ctx: ctx, sent: make(chan []byte)}
+	errC := make(chan error, 1)
+	go func() {
+		errC <- s.SubscribeSignedVAA(&spyv1.SubscribeSignedVAARequest{}, stream)
+	}()
+	require.Eventually(t, func() bool {
+		s.subsMu.Lock()
+		defer s.subsMu.Unlock()
+		return len(s.subs) == 1
+	}, time.Second, time.Millisecond)
+
+	// The subscriber does not read while the spy publishes, so its queue fills up and messages are dropped.
+	for seq := uint64(1); seq <= subscriptionQueueSize+2; seq++ {
+		require.NoError(t, s.Publish(signedVAA(t, key, seq)))
+	}
+
+	received := 0
+	for {
+		select {
+		case <-stream.sent:
+			received++
+		case err := <-errC:
+			assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+			assert.LessOrEqual(t, received, 1)
+			return
+		case <-time.After(time.Second):
+			t.Fatal("subscription was not ended")
+		}
+	}
+}
@@ -0,0 +1,592 @@
+// This tool generates the list of tokens to be monitored by the chain governor from on-chain data.
+//
+// The candidate tokens are the ones in the current token list plus the ones in the include list. For every candidate
+// on a chain where an RPC endpoint is configured, the decimals and symbol are read from the token contract and the
+// amount locked in the token bridge is used to compute the notional value. Prices are queried from CoinGecko. Tokens
+// on chains without an RPC endpoint are carried over from the current list with a refreshed price.
+//
+// By default, the tool only prints the differences to the current token list. Pass --write to regenerate mainnet_tokens.go.
+//
+// Usage: go run ./tokengen --rpc 1=https://api.mainnet-beta.solana.com,2=https://rpc.ankr.com/eth [--write]
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"log"
+	"math"
+	"math/big"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/governor"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/mr-tron/base58"
+	"github.com/wormhole-foundation/wormhole/sdk"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+var (
+	includeFile  = flag.String("include", "include_list.csv", "CSV file of <originChain>,<nativeTokenAddress>[,<coinGeckoId>] entries to always include")
+	outputFile   = flag.String("output", "../../pkg/governor/mainnet_tokens.go", "Token list file to diff against and regenerate")
+	rpcList      = flag.String("rpc", "", "Comma-separated list of <chainID>=<rpcURL> endpoints to query token data from")
+	minNotional  = flag.Float64("minNotional", 0, "Tokens with a locked notional value at or below this are dropped unless they are in the include list")
+	coinGeckoURL = flag.String("coinGeckoURL", "https://api.coingecko.com/api/v3", "CoinGecko API base URL")
+	write        = flag.Bool("write", false, "Regenerate the token list file instead of only printing the differences")
+)
+
+// evmChains are the chains whose token contracts can be queried with eth_call.
+var evmChains = map[vaa.ChainID]bool{
+	vaa.ChainIDEthereum:  true,
+	vaa.ChainIDBSC:       true,
+	vaa.ChainIDPolygon:   true,
+	vaa.ChainIDAvalanche: true,
+	vaa.ChainIDOasis:     true,
+	vaa.ChainIDAurora:    true,
+	vaa.ChainIDFantom:    true,
+	vaa.ChainIDKarura:    true,
+	vaa.ChainIDAcala:     true,
+	vaa.ChainIDKlaytn:    true,
+	vaa.ChainIDCelo:      true,
+	vaa.ChainIDMoonbeam:  true,
+	vaa.ChainIDArbitrum:  true,
+}
+
+// coinGeckoPlatforms maps chains to CoinGecko asset platform IDs, used to look up tokens by contract address.
+var coinGeckoPlatforms = map[vaa.ChainID]string{
+	vaa.ChainIDSolana:    "solana",
+	vaa.ChainIDEthereum:  "ethereum",
+	vaa.ChainIDBSC:       "binance-smart-chain",
+	vaa.ChainIDPolygon:   "polygon-pos",
+	vaa.ChainIDAvalanche: "avalanche",
+	vaa.ChainIDOasis:     "oasis",
+	vaa.ChainIDAurora:    "aurora",
+	vaa.ChainIDFantom:    "fantom",
+	vaa.ChainIDKarura:    "karura",
+	vaa.ChainIDAcala:     "acala",
+	vaa.ChainIDKlaytn:    "klay-token",
+	vaa.ChainIDCelo:      "celo",
+	vaa.ChainIDMoonbeam:  "moonbeam",
+	vaa.ChainIDArbitrum:  "arbitrum-one",
+}
+
+type tokenKey struct {
+	chain vaa.ChainID
+	addr  vaa.Address
+}
+
+type token struct {
+	governor.TokenConfig
+	native   string
+	included bool
+	// notional is the USD value locked in the token bridge, or nil if it could not be determined.
+	notional *float64
+}
+
+func main() {
+	flag.Parse()
+	ctx := context.Background()
+
+	rpcs, err := parseRPCList(*rpcList)
+	if err != nil {
+		log.Fatalf("invalid --rpc: %v", err)
+	}
+
+	current, err := governor.MainnetTokenList()
+	if err != nil {
+		log.Fatalf("failed to load current token list: %v", err)
+	}
+
+	candidates := make(map[tokenKey]*token)
+	for _, tc := range current {
+		candidates[tokenKey{tc.Chain, tc.Addr}] = &token{TokenConfig: tc, native: nativeAddress(tc.Chain, tc.Addr)}
+	}
+
+	if err := loadIncludeList(*includeFile, candidates); err != nil {
+		log.Fatalf("failed to load include list: %v", err)
+	}
+
+	for _, t := range sortedTokens(candidates) {
+		rpc, exists := rpcs[t.Chain]
+		if !exists {
+			continue
+		}
+
+		var err error
+		if evmChains[t.Chain] {
+			err = queryEVMToken(ctx, rpc, t)
+		} else if t.Chain == vaa.ChainIDSolana {
+			err = querySolanaToken(ctx, rpc, t)
+		} else {
+			log.Printf("querying tokens on chain %s is not supported, keeping existing config", t.Chain)
+			continue
+		}
+		if err != nil {
+			log.Fatalf("failed to query token %s:%s: %v", t.Chain, t.native, err)
+		}
+	}
+
+	if err := queryPrices(ctx, candidates); err != nil {
+		log.Fatalf("failed to query prices: %v", err)
+	}
+
+	generated := make([]*token, 0, len(candidates))
+	for _, t := range sortedTokens(candidates) {
+		if t.CoinGeckoId == "" || t.Symbol == "" {
+			log.Printf("ignoring token %s:%s because it has no symbol or CoinGecko ID", t.Chain, t.native)
+			continue
+		}
+		if !t.included && t.notional != nil && *t.notional <= *minNotional {
+			continue
+		}
+		generated = append(generated, t)
+	}
+
+	printDiff(os.Stdout, current, generated)
+
+	if *write {
+		content, err := render(generated)
+		if err != nil {
+			log.Fatalf("failed to render token list: %v", err)
+		}
+		if err := os.WriteFile(*outputFile, content, 0644); err != nil { //nolint:gosec
+			log.Fatalf("failed to write %s: %v", *outputFile, err)
+		}
+		fmt.Printf("\nWrote %d tokens to %s. Please do \"go run check_query.go\" to verify the Coin Gecko query still works before doing a commit.\n", len(generated), *outputFile)
+	}
+}
+
+func parseRPCList(s string) (map[vaa.ChainID]string, error) {
+	rpcs := make(map[vaa.ChainID]string)
+	if s == "" {
+		return rpcs, nil
+	}
+
+	for _, entry := range strings.Split(s, ",") {
+		parts := strings.SplitN(strings.TrimSpace(entry), "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid entry %q (must be <chainID>=<url>)", entry)
+		}
+		chain, err := strconv.ParseUint(parts[0], 10, 16)
+		if err != nil {
+			return nil, fmt.Errorf("invalid chain ID in entry %q: %w", entry, err)
+		}
+		rpcs[vaa.ChainID(chain)] = parts[1]
+	}
+
+	return rpcs, nil
+}
+
+func loadIncludeList(path string, candidates map[tokenKey]*token) error {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.Comment = '#'
+	r.FieldsPerRecord = -1
+	records, err := r.ReadAll()
+	if err != nil {
+		return err
+	}
+
+	for _, fields := range records {
+		if len(fields) < 2 {
+			return fmt.Errorf("line in include list does not contain enough fields: %v", fields)
+		}
+
+		chainID, err := strconv.ParseUint(fields[0], 10, 16)
+		if err != nil {
+			return fmt.Errorf("invalid chain ID %q: %w", fields[0], err)
+		}
+		chain := vaa.ChainID(chainID)
+
+		addr, err := wormholeAddress(chain, fields[1])
+		if err != nil {
+			log.Printf("ignoring included token %s:%s: %v", chain, fields[1], err)
+			continue
+		}
+
+		key := tokenKey{chain, addr}
+		t, exists := candidates[key]
+		if !exists {
+			t = &token{TokenConfig: governor.TokenConfig{Chain: chain, Addr: addr}, native: fields[1]}
+			candidates[key] = t
+		}
+		t.included = true
+		if len(fields) > 2 && fields[2] != "" {
+			t.CoinGeckoId = fields[2]
+		}
+	}
+
+	return nil
+}
+
+// wormholeAddress converts a native token address to its 32 byte wormhole representation.
+func wormholeAddress(chain vaa.ChainID, native string) (vaa.Address, error) {
+	if evmChains[chain] {
+		if !ethcommon.IsHexAddress(native) {
+			return vaa.Address{}, fmt.Errorf("invalid EVM address")
+		}
+		return vaa.StringToAddress(native)
+	}
+
+	if chain == vaa.ChainIDSolana {
+		b, err := base58.Decode(native)
+		if err != nil {
+			return vaa.Address{}, err
+		}
+		if len(b) != 32 {
+			return vaa.Address{}, fmt.Errorf("invalid Solana address length %d", len(b))
+		}
+		var addr vaa.Address
+		copy(addr[:], b)
+		return addr, nil
+	}
+
+	return vaa.Address{}, fmt.Errorf("address conversion is not supported for this chain")
+}
+
+// nativeAddress converts a wormhole address back to the format used on its chain, where supported.
+func nativeAddress(chain vaa.ChainID, addr vaa.Address) string {
+	if evmChains[chain] {
+		return ethcommon.BytesToAddress(addr[12:]).Hex()
+	}
+	if chain == vaa.ChainIDSolana {
+		return base58.Encode(addr[:])
+	}
+	return addr.String()
+}
+
+func selector(signature string) []byte {
+	return crypto.Keccak256([]byte(signature))[:4]
+}
+
+func callUint(ctx context.Context, c *ethclient.Client, to ethcommon.Address, data []byte) (*big.Int, error) {
+	ret, err := c.CallContract(ctx, ethereum.CallMsg{To: &to, Data: data}, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(ret) < 32 {
+		return nil, fmt.Errorf("unexpected return data length %d", len(ret))
+	}
+	return new(big.Int).SetBytes(ret[:32]), nil
+}
+
+func callSymbol(ctx context.Context, c *ethclient.Client, to ethcommon.Address) (string, error) {
+	ret, err := c.CallContract(ctx, ethereum.CallMsg{To: &to, Data: selector("symbol()")}, nil)
+	if err != nil {
+		return "", err
+	}
+
+	// Some older tokens (e.g. MKR) return the symbol as bytes32.
+	if len(ret) == 32 {
+		return string(bytes.TrimRight(ret, "\x00")), nil
+	}
+
+	stringType, err := abi.NewType("string", "", nil)
+	if err != nil {
+		return "", err
+	}
+	values, err := abi.Arguments{{Type: stringType}}.Unpack(ret)
+	if err != nil {
+		return "", err
+	}
+	return values[0].(string), nil
+}
+
+func queryEVMToken(ctx context.Context, rpc string, t *token) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	c, err := ethclient.DialContext(ctx, rpc)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	tokenAddr := ethcommon.BytesToAddress(t.Addr[12:])
+
+	emitter, exists := sdk.MustGetAddressBook(sdk.MainNet).Emitter(t.Chain, sdk.EmitterTokenBridge)
+	if !exists {
+		return fmt.Errorf("no token bridge known for chain %s", t.Chain)
+	}
+	bridgeAddr := ethcommon.BytesToAddress(emitter[12:])
+
+	// Wrapped assets are governed on their origin chain, so they must not be listed here.
+	wrapped, err := callUint(ctx, c, bridgeAddr, append(selector("isWrappedAsset(address)"), ethcommon.LeftPadBytes(tokenAddr.Bytes(), 32)...))
+	if err != nil {
+		return fmt.Errorf("isWrappedAsset: %w", err)
+	}
+	if wrapped.Sign() != 0 {
+		return fmt.Errorf("token is a wormhole wrapped asset, it must be listed with its origin chain and address")
+	}
+
+	decimals, err := callUint(ctx, c, tokenAddr, selector("decimals()"))
+	if err != nil {
+		return fmt.Errorf("decimals: %w", err)
+	}
+	t.Decimals = normalizeDecimals(decimals.Int64())
+
+	symbol, err := callSymbol(ctx, c, tokenAddr)
+	if err != nil {
+		return fmt.Errorf("symbol: %w", err)
+	}
+	if t.Symbol == "" {
+		t.Symbol = symbol
+	} else if t.Symbol != symbol {
+		log.Printf("token %s:%s: on-chain symbol %q differs from configured symbol %q, keeping the configured one", t.Chain, t.native, symbol, t.Symbol)
+	}
+
+	locked, err := callUint(ctx, c, tokenAddr, append(selector("balanceOf(address)"), ethcommon.LeftPadBytes(bridgeAddr.Bytes(), 32)...))
+	if err != nil {
+		return fmt.Errorf("balanceOf: %w", err)
+	}
+	// The notional is stored as the locked amount for now and multiplied by the price once it is known.
+	amount, _ := new(big.Float).Quo(new(big.Float).SetInt(locked), big.NewFloat(math.Pow10(int(decimals.Int64())))).Float64()
+	t.notional = &amount
+
+	return nil
+}
+
+type solanaRPCResponse struct {
+	Result struct {
+		Value struct {
+			Decimals int64 `json:"decimals"`
+		} `json:"value"`
+	} `json:"result"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func querySolanaToken(ctx context.Context, rpc string, t *token) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "getTokenSupply",
+		"params":  []string{t.native},
+	})
+	if err != nil {
+		return err
+	}
+
+	var resp solanaRPCResponse
+	if err := doJSON(ctx, http.MethodPost, rpc, body, &resp); err != nil {
+		return err
+	}
+	if resp.Error != nil {
+		return fmt.Errorf("getTokenSupply: %s", resp.Error.Message)
+	}
+
+	t.Decimals = normalizeDecimals(resp.Result.Value.Decimals)
+	return nil
+}
+
+// normalizeDecimals caps the decimals at eight, since transfers have a maximum of eight decimal places.
+func normalizeDecimals(decimals int64) int64 {
+	if decimals > 8 {
+		return 8
+	}
+	return decimals
+}
+
+type coinGeckoContractResponse struct {
+	ID     string `json:"id"`
+	Symbol string `json:"symbol"`
+}
+
+func queryPrices(ctx context.Context, candidates map[tokenKey]*token) error {
+	// Look up tokens that are not known yet by their contract address.
+	for _, t := range sortedTokens(candidates) {
+		if t.CoinGeckoId != "" {
+			continue
+		}
+		platform, exists := coinGeckoPlatforms[t.Chain]
+		if !exists {
+			continue
+		}
+
+		var resp coinGeckoContractResponse
+		if err := doJSON(ctx, http.MethodGet, fmt.Sprintf("%s/coins/%s/contract/%s", *coinGeckoURL, platform, strings.ToLower(t.native)), nil, &resp); err != nil {
+			log.Printf("failed to look up token %s:%s on CoinGecko: %v", t.Chain, t.native, err)
+			continue
+		}
+		t.CoinGeckoId = resp.ID
+		if t.Symbol == "" {
+			t.Symbol = strings.ToUpper(resp.Symbol)
+		}
+	}
+
+	ids := make(map[string]bool)
+	for _, t := range candidates {
+		if t.CoinGeckoId != "" {
+			ids[t.CoinGeckoId] = true
+		}
+	}
+	idList := make([]string, 0, len(ids))
+	for id := range ids {
+		idList = append(idList, id)
+	}
+	sort.Strings(idList)
+
+	params := url.Values{}
+	params.Add("ids", strings.Join(idList, ","))
+	params.Add("vs_currencies", "usd")
+
+	var prices map[string]map[string]float64
+	if err := doJSON(ctx, http.MethodGet, *coinGeckoURL+"/simple/price?"+params.Encode(), nil, &prices); err != nil {
+		return err
+	}
+
+	for _, t := range candidates {
+		if t.CoinGeckoId == "" {
+			continue
+		}
+		price, exists := prices[t.CoinGeckoId]["usd"]
+		if !exists {
+			log.Printf("no price returned for %s, keeping configured price %v", t.CoinGeckoId, t.Price)
+		} else {
+			t.Price = price
+		}
+		if t.notional != nil {
+			*t.notional *= t.Price
+		}
+	}
+
+	return nil
+}
+
+func doJSON(ctx context.Context, method string, target string, body []byte, out interface{}) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned status %d", target, resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+func sortedTokens(tokens map[tokenKey]*token) []*token {
+	sorted := make([]*token, 0, len(tokens))
+	for _, t := range tokens {
+		sorted = append(sorted, t)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Chain != sorted[j].Chain {
+			return sorted[i].Chain < sorted[j].Chain
+		}
+		return sorted[i].native < sorted[j].native
+	})
+	return sorted
+}
+
+func printDiff(w io.Writer, current []governor.TokenConfig, generated []*token) {
+	old := make(map[tokenKey]governor.TokenConfig, len(current))
+	for _, tc := range current {
+		old[tokenKey{tc.Chain, tc.Addr}] = tc
+	}
+
+	var added, removed, changed int
+	for _, t := range generated {
+		key := tokenKey{t.Chain, t.Addr}
+		prev, exists := old[key]
+		delete(old, key)
+		if !exists {
+			added++
+			fmt.Fprintf(w, "+ %s %s %s (%s) decimals: %d, price: %v\n", t.Chain, t.native, t.Symbol, t.CoinGeckoId, t.Decimals, t.Price)
+			continue
+		}
+
+		var diffs []string
+		if prev.Symbol != t.Symbol {
+			diffs = append(diffs, fmt.Sprintf("symbol: %s -> %s", prev.Symbol, t.Symbol))
+		}
+		if prev.CoinGeckoId != t.CoinGeckoId {
+			diffs = append(diffs, fmt.Sprintf("coinGeckoId: %s -> %s", prev.CoinGeckoId, t.CoinGeckoId))
+		}
+		if prev.Decimals != t.Decimals {
+			diffs = append(diffs, fmt.Sprintf("decimals: %d -> %d", prev.Decimals, t.Decimals))
+		}
+		if prev.Price != t.Price {
+			diffs = append(diffs, fmt.Sprintf("price: %v -> %v", prev.Price, t.Price))
+		}
+		if len(diffs) != 0 {
+			changed++
+			fmt.Fprintf(w, "~ %s %s %s %s\n", t.Chain, t.native, t.Symbol, strings.Join(diffs, ", "))
+		}
+	}
+
+	for _, tc := range current {
+		if _, exists := old[tokenKey{tc.Chain, tc.Addr}]; exists {
+			removed++
+			fmt.Fprintf(w, "- %s %s %s (%s)\n", tc.Chain, nativeAddress(tc.Chain, tc.Addr), tc.Symbol, tc.CoinGeckoId)
+		}
+	}
+
+	fmt.Fprintf(w, "\n%d added, %d removed, %d changed, %d total\n", added, removed, changed, len(generated))
+}
+
+func render(tokens []*token) ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteString("// This file contains the token config to be used in the mainnet environment.\n")
+	b.WriteString("//\n")
+	fmt.Fprintf(&b, "// This file was generated: %s using a min notional of %v\n\n", time.Now().UTC().Format(time.RFC1123), *minNotional)
+	b.WriteString("package governor\n\n")
+	b.WriteString("func tokenList() []tokenConfigEntry {\n")
+	b.WriteString("\treturn []tokenConfigEntry{\n")
+
+	for _, t := range tokens {
+		notional := "unknown"
+		if t.notional != nil {
+			notional = strconv.FormatInt(int64(*t.notional), 10)
+		}
+		fmt.Fprintf(&b, "\t\ttokenConfigEntry{chain: %d, addr: %q, symbol: %q, coinGeckoId: %q, decimals: %d, price: %v}, // Addr: %s, Notional: %s\n",
+			t.Chain, hex.EncodeToString(t.Addr[:]), t.Symbol, t.CoinGeckoId, t.Decimals, t.Price, t.native, notional)
+	}
+
+	b.WriteString("\t}\n")
+	b.WriteString("}\n")
+
+	return format.Source(b.Bytes())
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/certusone/wormhole/node/pkg/governor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+var (
+	usdc = governor.TokenConfig{Chain: vaa.ChainIDEthereum, Addr: vaa.Address{31: 0x01}, Symbol: "USDC", CoinGeckoId: "usd-coin", Decimals: 6, Price: 1}
+	weth = governor.TokenConfig{Chain: vaa.ChainIDEthereum, Addr: vaa.Address{31: 0x02}, Symbol: "WETH", CoinGeckoId: "weth", Decimals: 8, Price: 1000}
+)
+
+func fixtureToken(tc governor.TokenConfig) *token {
+	return &token{TokenConfig: tc, native: nativeAddress(tc.Chain, tc.Addr)}
+}
+
+func TestPrintDiff(t *testing.T) {
+	repriced := weth
+	repriced.Price = 1100
+	renamed := weth
+	renamed.Symbol = "ETH"
+	renamed.Decimals = 18
+
+	tests := []struct {
+		label     string
+		current   []governor.TokenConfig
+		generated []governor.TokenConfig
+		expected  string
+	}{
+		{
+			label:     "unchanged",
+			current:   []governor.TokenConfig{usdc, weth},
+			generated: []governor.TokenConfig{usdc, weth},
+			expected:  "\n0 added, 0 removed, 0 changed, 2 total\n",
+		},
+		{
+			label:     "added",
+			current:   []governor.TokenConfig{usdc},
+			generated: []governor.TokenConfig{usdc, weth},
+			expected: "+ ethereum 0x0000000000000000000000000000000000000002 WETH (weth) decimals: 8, price: 1000\n" +
+				"\n1 added, 0 removed, 0 changed, 2 total\n",
+		},
+		{
+			label:     "removed",
+			current:   []governor.TokenConfig{usdc, weth},
+			generated: []governor.TokenConfig{usdc},
+			expected: "- ethereum 0x0000000000000000000000000000000000000002 WETH (weth)\n" +
+				"\n0 added, 1 removed, 0 changed, 1 total\n",
+		},
+		{
+			label:     "price changed",
+			current:   []governor.TokenConfig{usdc, weth},
+			generated: []governor.TokenConfig{usdc, repriced},
+			expected: "~ ethereum 0x0000000000000000000000000000000000000002 WETH price: 1000 -> 1100\n" +
+				"\n0 added, 0 removed, 1 changed, 2 total\n",
+		},
+		{
+			label:     "symbol and decimals changed",
+			current:   []governor.TokenConfig{weth},
+			generated: []governor.TokenConfig{renamed},
+			expected: "~ ethereum 0x0000000000000000000000000000000000000002 ETH symbol: WETH -> ETH, decimals: 8 -> 18\n" +
+				"\n0 added, 0 removed, 1 changed, 1 total\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.label, func(t *testing.T) {
+			generated := make([]*token, 0, len(tc.generated))
+			for _, g := range tc.generated {
+				generated = append(generated, fixtureToken(g))
+			}
+
+			var out bytes.Buffer
+			printDiff(&out, tc.current, generated)
+			assert.Equal(t, tc.expected, out.String())
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	notional := 1234.5
+	tokens := []*token{fixtureToken(usdc), fixtureToken(weth)}
+	tokens[0].notional = &notional
+
+	src, err := render(tokens)
+	require.NoError(t, err)
+
+	// Compare the entries with their whitespace collapsed, since gofmt aligns the trailing comments.
+	var entries []string
+	for _, line := range strings.Split(string(src), "\n") {
+		if line = strings.TrimSpace(line); strings.HasPrefix(line, "tokenConfigEntry{") {
+			entries = append(entries, strings.Join(strings.Fields(line), " "))
+		}
+	}
+	assert.Equal(t, []string{
+		`tokenConfigEntry{chain: 2, addr: "0000000000000000000000000000000000000000000000000000000000000001", symbol: "USDC", coinGeckoId: "usd-coin", decimals: 6, price: 1}, // Addr: 0x0000000000000000000000000000000000000001, Notional: 1234`,
+		`tokenConfigEntry{chain: 2, addr: "0000000000000000000000000000000000000000000000000000000000000002", symbol: "WETH", coinGeckoId: "weth", decimals: 8, price: 1000}, // Addr: 0x0000000000000000000000000000000000000002, Notional: unknown`,
+	}, entries)
+	assert.Contains(t, string(src), "package governor\n")
+	assert.Contains(t, string(src), "func tokenList() []tokenConfigEntry {\n")
+}
+
+// TestRenderMainnetTokenList checks that rendering the current token list produces a valid token list file without
+// any changes to the tokens.
+func TestRenderMainnetTokenList(t *testing.T) {
+	current, err := governor.MainnetTokenList()
+	require.NoError(t, err)
+
+	tokens := make([]*token, 0, len(current))
+	for _, tc := range current {
+		tokens = append(tokens, fixtureToken(tc))
+	}
+	_, err = render(tokens)
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	printDiff(&out, current, tokens)
+	assert.True(t, strings.HasPrefix(out.String(), "\n0 added, 0 removed, 0 changed"), out.String())
+}
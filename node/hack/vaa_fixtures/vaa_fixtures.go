@@ -0,0 +1,155 @@
+// vaa_fixtures fetches signed VAAs from the public API of mainnet guardians, re-signs them with the devnet guardian
+// set and writes them as test fixtures. The body of the VAAs, and so their digest, is preserved, which makes it easy
+// to reproduce mainnet edge cases like odd token symbols or maximum amounts in devnet and chain tests.
+//
+// Usage:
+//
+//	go run ./hack/vaa_fixtures -out ../wormhole_chain/x/tokenbridge/keeper/testdata 2/0000000000000000000000003ee18b2214aff97000d974cf647e7c347e8fa585/1
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/db"
+	"github.com/certusone/wormhole/node/pkg/devnet"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/wormhole-foundation/wormhole/sdk"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+var (
+	rpcs             = flag.String("rpcs", strings.Join(sdk.PublicRPCEndpoints, ","), "Comma separated public API endpoints to fetch VAAs from")
+	outDir           = flag.String("out", ".", "Directory to write the fixtures to")
+	numGuardians     = flag.Uint("guardians", 1, "Number of devnet guardians to sign with")
+	guardianSetIndex = flag.Uint("guardianSetIndex", 0, "Index of the devnet guardian set")
+)
+
+// fixture is the file written for each VAA.
+type fixture struct {
+	// ID is the <chain>/<emitter>/<sequence> ID of the VAA.
+	ID string `json:"id"`
+	// Source is the endpoint the VAA was fetched from.
+	Source string `json:"source"`
+	// OriginalGuardianSetIndex is the index of the guardian set that signed the VAA on mainnet.
+	OriginalGuardianSetIndex uint32 `json:"originalGuardianSetIndex"`
+	// Digest is the hex encoded digest of the VAA, which re-signing does not change.
+	Digest string `json:"digest"`
+	// VAA is the hex encoded VAA signed by the devnet guardians.
+	VAA string `json:"vaa"`
+}
+
+func fetchVAA(ctx context.Context, c *http.Client, endpoint string, id *db.VAAID) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf(
+		"%s/v1/signed_vaa/%s", endpoint, vaa.MessageID(*id)), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	var respBody struct {
+		VaaBytes string `json:"vaaBytes"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&respBody); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return base64.StdEncoding.DecodeString(respBody.VaaBytes)
+}
+
+// resign replaces the signatures of v with those of the first n devnet guardians.
+func resign(v *vaa.VAA, n uint, index uint32) {
+	v.GuardianSetIndex = index
+	v.Signatures = nil
+	for i := uint(0); i < n; i++ {
+		v.AddSignature(devnet.InsecureDeterministicEcdsaKeyByIndex(crypto.S256(), uint64(i)), uint8(i))
+	}
+}
+
+func makeFixture(ctx context.Context, c *http.Client, endpoints []string, id *db.VAAID) (*fixture, error) {
+	errs := make([]string, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		b, err := fetchVAA(ctx, c, endpoint, id)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", endpoint, err))
+			continue
+		}
+		v, err := vaa.Unmarshal(b)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse VAA from %s: %w", endpoint, err)
+		}
+
+		f := &fixture{
+			ID:                       vaa.MessageID(*id).String(),
+			Source:                   endpoint,
+			OriginalGuardianSetIndex: v.GuardianSetIndex,
+			Digest:                   v.HexDigest(),
+		}
+		resign(v, *numGuardians, uint32(*guardianSetIndex))
+		resigned, err := v.Marshal()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal VAA: %w", err)
+		}
+		f.VAA = hex.EncodeToString(resigned)
+		return f, nil
+	}
+	return nil, errors.New(strings.Join(errs, "; "))
+}
+
+func main() {
+	flag.Parse()
+	if flag.NArg() == 0 {
+		log.Fatal("usage: vaa_fixtures [flags] <chain>/<emitter>/<sequence>...")
+	}
+	if *numGuardians == 0 || *numGuardians > 19 {
+		log.Fatal("-guardians must be between 1 and 19")
+	}
+
+	ctx := context.Background()
+	c := &http.Client{}
+	endpoints := strings.Split(*rpcs, ",")
+	if err := os.MkdirAll(*outDir, 0755); err != nil {
+		log.Fatalf("failed to create output directory: %v", err)
+	}
+
+	for _, arg := range flag.Args() {
+		id, err := db.VaaIDFromString(arg)
+		if err != nil {
+			log.Fatalf("invalid VAA ID %s: %v", arg, err)
+		}
+		f, err := makeFixture(ctx, c, endpoints, id)
+		if err != nil {
+			log.Fatalf("failed to fetch VAA %s: %v", arg, err)
+		}
+
+		b, err := json.MarshalIndent(f, "", "  ")
+		if err != nil {
+			log.Fatalf("failed to marshal fixture: %v", err)
+		}
+		path := filepath.Join(*outDir, fmt.Sprintf("vaa_%d_%s_%d.json", id.EmitterChain, id.EmitterAddress, id.Sequence))
+		if err := os.WriteFile(path, append(b, '\n'), 0644); err != nil {
+			log.Fatalf("failed to write fixture: %v", err)
+		}
+		log.Printf("wrote %s (digest %s)", path, f.Digest)
+	}
+}
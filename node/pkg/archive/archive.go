@@ -0,0 +1,189 @@
+// Package archive implements a read-only VAA archive. It follows gossip like a guardian does, but instead of
+// observing chains and signing it only verifies the signed VAAs it receives and stores them.
+package archive
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/db"
+	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
+	"github.com/certusone/wormhole/node/pkg/supervisor"
+	"github.com/certusone/wormhole/node/pkg/watchers/evm/connectors"
+	"github.com/certusone/wormhole/node/pkg/watchers/evm/connectors/ethabi"
+	eth_common "github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+var (
+	vaasStored = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "wormhole_archive_vaas_stored_total",
+			Help: "Total number of signed VAAs stored by the archive",
+		})
+	vaasRejected = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "wormhole_archive_vaas_rejected_total",
+			Help: "Total number of signed VAAs received from gossip that the archive did not store",
+		}, []string{"reason"})
+)
+
+var errInvalidVAA = errors.New("invalid VAA")
+
+// Archiver stores the signed VAAs received from gossip that are valid for their guardian set.
+type Archiver struct {
+	db        *db.Database
+	sets      *GuardianSets
+	signedInC <-chan *gossipv1.SignedVAAWithQuorum
+}
+
+func NewArchiver(db *db.Database, sets *GuardianSets, signedInC <-chan *gossipv1.SignedVAAWithQuorum) *Archiver {
+	return &Archiver{
+		db:        db,
+		sets:      sets,
+		signedInC: signedInC,
+	}
+}
+
+func (a *Archiver) Run(ctx context.Context) error {
+	logger := supervisor.Logger(ctx)
+	supervisor.Signal(ctx, supervisor.SignalHealthy)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case m := <-a.signedInC:
+			v, stored, err := a.store(m)
+			if err != nil {
+				vaasRejected.WithLabelValues(err.Error()).Inc()
+				logger.Debug("dropping signed VAA", zap.Error(err), zap.Binary("vaa", m.Vaa))
+				continue
+			}
+			if stored {
+				vaasStored.Inc()
+				logger.Info("stored signed VAA",
+					zap.String("message_id", v.MessageID()),
+					zap.String("digest", hex.EncodeToString(v.SigningMsg().Bytes())))
+			}
+		}
+	}
+}
+
+// store verifies m against its guardian set and stores it, unless it is already stored.
+func (a *Archiver) store(m *gossipv1.SignedVAAWithQuorum) (*vaa.VAA, bool, error) {
+	v, err := vaa.Unmarshal(m.Vaa)
+	if err != nil {
+		return nil, false, errInvalidVAA
+	}
+
+	stored, err := StoreVerifiedVAA(a.db, a.sets, v)
+	if err != nil {
+		return nil, false, err
+	}
+	return v, stored, nil
+}
+
+// StoreVerifiedVAA stores v if it is valid for its guardian set. An already stored VAA is never replaced, so the
+// first valid copy of a VAA received from gossip is kept. It returns whether v was stored.
+func StoreVerifiedVAA(store *db.Database, sets *GuardianSets, v *vaa.VAA) (bool, error) {
+	if err := sets.Verify(v, time.Now()); err != nil {
+		return false, err
+	}
+
+	if _, err := store.GetSignedVAABytes(*db.VaaIDFromVAA(v)); err == nil {
+		return false, nil
+	} else if err != db.ErrVAANotFound && err != db.ErrVAACorrupt {
+		return false, fmt.Errorf("failed to look up VAA: %w", err)
+	}
+
+	if err := store.StoreSignedVAA(v); err != nil {
+		return false, fmt.Errorf("failed to store VAA: %w", err)
+	}
+	return true, nil
+}
+
+// guardianSetSource is the subset of the Ethereum connector used to look up the current guardian set.
+type guardianSetSource interface {
+	GetCurrentGuardianSetIndex(ctx context.Context) (uint32, error)
+	GetGuardianSet(ctx context.Context, index uint32) (ethabi.StructsGuardianSet, error)
+}
+
+// GuardianSetPoller returns a runnable that keeps sets up to date with the guardian sets of the Ethereum core contract
+// at contract, checking it every interval.
+func GuardianSetPoller(rpcURL string, contract eth_common.Address, sets *GuardianSets, interval time.Duration) supervisor.Runnable {
+	return func(ctx context.Context) error {
+		logger := supervisor.Logger(ctx)
+		conn, err := connectors.NewEthereumConnector(ctx, "eth", rpcURL, contract, logger)
+		if err != nil {
+			return fmt.Errorf("failed to connect to Ethereum: %w", err)
+		}
+		return pollGuardianSet(ctx, logger, conn, sets, interval)
+	}
+}
+
+func pollGuardianSet(ctx context.Context, logger *zap.Logger, source guardianSetSource, sets *GuardianSets, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	healthy := false
+	for {
+		if err := updateGuardianSet(ctx, logger, source, sets); err != nil {
+			return err
+		}
+		if !healthy {
+			supervisor.Signal(ctx, supervisor.SignalHealthy)
+			healthy = true
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func updateGuardianSet(ctx context.Context, logger *zap.Logger, source guardianSetSource, sets *GuardianSets) error {
+	timeout, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+
+	sets.prune(time.Now())
+
+	index, err := source.GetCurrentGuardianSetIndex(timeout)
+	if err != nil {
+		return fmt.Errorf("failed to get current guardian set index: %w", err)
+	}
+	if current, ok := sets.currentIndex(); ok && current == index {
+		return nil
+	}
+
+	gs, err := source.GetGuardianSet(timeout, index)
+	if err != nil {
+		return fmt.Errorf("failed to get guardian set %d: %w", index, err)
+	}
+
+	// The contract sets the expiration time of the previous set when it is replaced. Until then, VAAs it signed are
+	// still valid.
+	if index > 0 {
+		prev, err := source.GetGuardianSet(timeout, index-1)
+		if err != nil {
+			return fmt.Errorf("failed to get guardian set %d: %w", index-1, err)
+		}
+		expiration := time.Unix(int64(prev.ExpirationTime), 0)
+		if prev.ExpirationTime != 0 && time.Now().Before(expiration) {
+			logger.Info("keeping previous guardian set until it expires", zap.Uint32("index", index-1), zap.Time("expiration", expiration))
+			sets.Set(index-1, prev.Keys, expiration)
+		}
+	}
+
+	logger.Info("updated guardian set", zap.Uint32("index", index), zap.Int("guardians", len(gs.Keys)))
+	sets.Set(index, gs.Keys, time.Time{})
+	return nil
+}
@@ -0,0 +1,212 @@
+package archive
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"testing"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	"github.com/certusone/wormhole/node/pkg/db"
+	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
+	"github.com/certusone/wormhole/node/pkg/watchers/evm/connectors/ethabi"
+	eth_common "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+func newGuardianKeys(t *testing.T, n int) ([]*ecdsa.PrivateKey, []eth_common.Address) {
+	keys := make([]*ecdsa.PrivateKey, n)
+	addrs := make([]eth_common.Address, n)
+	for i := range keys {
+		k, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		keys[i] = k
+		addrs[i] = crypto.PubkeyToAddress(k.PublicKey)
+	}
+	return keys, addrs
+}
+
+func signedVAA(t *testing.T, sequence uint64, keys []*ecdsa.PrivateKey) *gossipv1.SignedVAAWithQuorum {
+	return signedVAAWithGuardianSet(t, 0, sequence, keys)
+}
+
+func signedVAAWithGuardianSet(t *testing.T, index uint32, sequence uint64, keys []*ecdsa.PrivateKey) *gossipv1.SignedVAAWithQuorum {
+	v := &vaa.VAA{
+		Version:          vaa.SupportedVAAVersion,
+		GuardianSetIndex: index,
+		Timestamp:        time.Unix(1000, 0),
+		Nonce:            1,
+		Sequence:         sequence,
+		ConsistencyLevel: 1,
+		EmitterChain:     vaa.ChainIDEthereum,
+		EmitterAddress:   vaa.Address{1},
+		Payload:          []byte{1, 2, 3},
+	}
+	for i, k := range keys {
+		v.AddSignature(k, uint8(i))
+	}
+	b, err := v.Marshal()
+	require.NoError(t, err)
+	return &gossipv1.SignedVAAWithQuorum{Vaa: b}
+}
+
+func TestArchiverStore(t *testing.T) {
+	keys, addrs := newGuardianKeys(t, 3)
+	_, otherAddrs := newGuardianKeys(t, 3)
+
+	store, err := db.Open(t.TempDir())
+	require.NoError(t, err)
+	defer store.Close()
+
+	gst := common.NewGuardianSetState()
+	sets := NewGuardianSets(gst)
+	a := NewArchiver(store, sets, nil)
+
+	// Nothing can be verified before the guardian set is known
+	_, _, err = a.store(signedVAA(t, 1, keys))
+	assert.ErrorIs(t, err, errNoGuardianSet)
+
+	sets.Set(0, addrs, time.Time{})
+	assert.Equal(t, &common.GuardianSet{Keys: addrs, Index: 0}, gst.Get())
+
+	_, _, err = a.store(&gossipv1.SignedVAAWithQuorum{Vaa: []byte{1}})
+	assert.ErrorIs(t, err, errInvalidVAA)
+
+	_, _, err = a.store(signedVAA(t, 1, keys[:2]))
+	assert.ErrorIs(t, err, errNoQuorum)
+
+	m := signedVAA(t, 1, keys)
+	v, stored, err := a.store(m)
+	require.NoError(t, err)
+	assert.True(t, stored)
+
+	b, err := store.GetSignedVAABytes(*db.VaaIDFromVAA(v))
+	require.NoError(t, err)
+	assert.Equal(t, m.Vaa, b)
+
+	// Gossip delivers the same VAA many times
+	_, stored, err = a.store(m)
+	require.NoError(t, err)
+	assert.False(t, stored)
+
+	// VAAs are verified against the set they name
+	sets.Set(1, otherAddrs, time.Time{})
+	_, _, err = a.store(signedVAA(t, 2, keys))
+	assert.ErrorIs(t, err, errNoGuardianSet)
+	_, _, err = a.store(signedVAAWithGuardianSet(t, 1, 2, keys))
+	assert.ErrorIs(t, err, errInvalidSignatures)
+}
+
+func TestArchiverStoreGuardianSetTransition(t *testing.T) {
+	oldKeys, oldAddrs := newGuardianKeys(t, 3)
+	newKeys, newAddrs := newGuardianKeys(t, 3)
+
+	store, err := db.Open(t.TempDir())
+	require.NoError(t, err)
+	defer store.Close()
+
+	sets := NewGuardianSets(common.NewGuardianSetState())
+	a := NewArchiver(store, sets, nil)
+
+	// The previous set remains valid until it expires
+	expiration := time.Now().Add(time.Hour)
+	sets.Set(0, oldAddrs, expiration)
+	sets.Set(1, newAddrs, time.Time{})
+
+	_, stored, err := a.store(signedVAAWithGuardianSet(t, 0, 1, oldKeys))
+	require.NoError(t, err)
+	assert.True(t, stored)
+	_, stored, err = a.store(signedVAAWithGuardianSet(t, 1, 2, newKeys))
+	require.NoError(t, err)
+	assert.True(t, stored)
+
+	v, err := vaa.Unmarshal(signedVAAWithGuardianSet(t, 0, 3, oldKeys).Vaa)
+	require.NoError(t, err)
+	assert.ErrorIs(t, sets.Verify(v, expiration.Add(time.Second)), errGuardianSetExpired)
+
+	sets.prune(expiration.Add(time.Second))
+	assert.ErrorIs(t, sets.Verify(v, expiration.Add(time.Second)), errNoGuardianSet)
+}
+
+func TestStoreVerifiedVAADoesNotOverwrite(t *testing.T) {
+	keys, addrs := newGuardianKeys(t, 1)
+
+	store, err := db.Open(t.TempDir())
+	require.NoError(t, err)
+	defer store.Close()
+
+	sets := NewGuardianSets(common.NewGuardianSetState())
+	sets.Set(0, addrs, time.Time{})
+
+	first, err := vaa.Unmarshal(signedVAA(t, 1, keys).Vaa)
+	require.NoError(t, err)
+	stored, err := StoreVerifiedVAA(store, sets, first)
+	require.NoError(t, err)
+	assert.True(t, stored)
+
+	// A different valid copy of the same message does not replace the stored one
+	second := *first
+	second.Nonce = 2
+	second.Signatures = nil
+	second.AddSignature(keys[0], 0)
+	stored, err = StoreVerifiedVAA(store, sets, &second)
+	require.NoError(t, err)
+	assert.False(t, stored)
+
+	b, err := store.GetSignedVAABytes(*db.VaaIDFromVAA(first))
+	require.NoError(t, err)
+	expected, err := first.Marshal()
+	require.NoError(t, err)
+	assert.Equal(t, expected, b)
+}
+
+type mockGuardianSetSource struct {
+	index   uint32
+	sets    map[uint32]ethabi.StructsGuardianSet
+	lookups int
+}
+
+func (s *mockGuardianSetSource) GetCurrentGuardianSetIndex(ctx context.Context) (uint32, error) {
+	return s.index, nil
+}
+
+func (s *mockGuardianSetSource) GetGuardianSet(ctx context.Context, index uint32) (ethabi.StructsGuardianSet, error) {
+	s.lookups++
+	return s.sets[index], nil
+}
+
+func TestUpdateGuardianSet(t *testing.T) {
+	_, addrs := newGuardianKeys(t, 2)
+	source := &mockGuardianSetSource{index: 3, sets: map[uint32]ethabi.StructsGuardianSet{
+		2: {Keys: addrs[1:], ExpirationTime: 1},
+		3: {Keys: addrs[:1]},
+		4: {Keys: addrs},
+	}}
+	gst := common.NewGuardianSetState()
+	sets := NewGuardianSets(gst)
+	ctx := context.Background()
+
+	// The current set and the previous one are looked up, an expired previous set is not kept
+	require.NoError(t, updateGuardianSet(ctx, zap.NewNop(), source, sets))
+	assert.Equal(t, &common.GuardianSet{Keys: addrs[:1], Index: 3}, gst.Get())
+	assert.Equal(t, 2, source.lookups)
+	assert.Len(t, sets.sets, 1)
+
+	// The sets are only fetched again once the index changes
+	require.NoError(t, updateGuardianSet(ctx, zap.NewNop(), source, sets))
+	assert.Equal(t, 2, source.lookups)
+
+	// The replaced set is kept until it expires
+	expiration := time.Now().Add(time.Hour).Truncate(time.Second)
+	source.sets[3] = ethabi.StructsGuardianSet{Keys: addrs[:1], ExpirationTime: uint32(expiration.Unix())}
+	source.index = 4
+	require.NoError(t, updateGuardianSet(ctx, zap.NewNop(), source, sets))
+	assert.Equal(t, &common.GuardianSet{Keys: addrs, Index: 4}, gst.Get())
+	assert.Equal(t, 4, source.lookups)
+	assert.Equal(t, guardianSet{keys: addrs[:1], expiration: expiration}, sets.sets[3])
+	assert.Equal(t, guardianSet{keys: addrs}, sets.sets[4])
+}
@@ -0,0 +1,106 @@
+package archive
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	"github.com/certusone/wormhole/node/pkg/processor"
+	eth_common "github.com/ethereum/go-ethereum/common"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+var (
+	errNoGuardianSet      = errors.New("no guardian set")
+	errGuardianSetExpired = errors.New("guardian set expired")
+	errNoQuorum           = errors.New("VAA has no quorum")
+	errInvalidSignatures  = errors.New("invalid VAA signatures")
+)
+
+type guardianSet struct {
+	keys []eth_common.Address
+	// expiration is the time after which the set no longer signs valid VAAs, or zero for the current set.
+	expiration time.Time
+}
+
+// GuardianSets holds the guardian sets VAAs can currently be verified against: the current set, and previous sets
+// until they expire. Like on the core contracts, a VAA signed by the previous set remains valid during a guardian set
+// transition. The current set is also published to a GuardianSetState.
+type GuardianSets struct {
+	mu      sync.Mutex
+	sets    map[uint32]guardianSet
+	current *uint32
+	gst     *common.GuardianSetState
+}
+
+func NewGuardianSets(gst *common.GuardianSetState) *GuardianSets {
+	return &GuardianSets{
+		sets: make(map[uint32]guardianSet),
+		gst:  gst,
+	}
+}
+
+// currentIndex returns the index of the current guardian set, if it is known.
+func (s *GuardianSets) currentIndex() (uint32, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.current == nil {
+		return 0, false
+	}
+	return *s.current, true
+}
+
+// Set stores a guardian set. A zero expiration makes it the current set, replacing any previous current set that was
+// not stored again with its expiration.
+func (s *GuardianSets) Set(index uint32, keys []eth_common.Address, expiration time.Time) {
+	s.mu.Lock()
+	isCurrent := expiration.IsZero()
+	if isCurrent {
+		for i, gs := range s.sets {
+			if gs.expiration.IsZero() {
+				delete(s.sets, i)
+			}
+		}
+		s.current = &index
+	}
+	s.sets[index] = guardianSet{keys: keys, expiration: expiration}
+	s.mu.Unlock()
+
+	if isCurrent {
+		s.gst.Set(&common.GuardianSet{Keys: keys, Index: index})
+	}
+}
+
+// prune removes the sets that expired before now.
+func (s *GuardianSets) prune(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for index, gs := range s.sets {
+		if !gs.expiration.IsZero() && now.After(gs.expiration) {
+			delete(s.sets, index)
+		}
+	}
+}
+
+// Verify checks that v has a quorum of valid signatures of the guardian set it names, and that the set had not
+// expired at now.
+func (s *GuardianSets) Verify(v *vaa.VAA, now time.Time) error {
+	s.mu.Lock()
+	gs, ok := s.sets[v.GuardianSetIndex]
+	s.mu.Unlock()
+
+	if !ok || len(gs.keys) == 0 {
+		return errNoGuardianSet
+	}
+	if !gs.expiration.IsZero() && now.After(gs.expiration) {
+		return errGuardianSetExpired
+	}
+	if len(v.Signatures) < processor.CalculateQuorum(len(gs.keys)) {
+		return errNoQuorum
+	}
+	if !v.VerifySignatures(gs.keys) {
+		return errInvalidSignatures
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package common
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// ChainCounterValues are the observation counters of a single chain since the node started.
+type ChainCounterValues struct {
+	// MessagesObserved is the number of messages observed by the chain's watcher.
+	MessagesObserved uint64
+	// ObservationsSigned is the number of observations signed and broadcast by this node.
+	ObservationsSigned uint64
+	// QuorumVAAs is the number of VAAs that reached quorum in this node's aggregation.
+	QuorumVAAs uint64
+	// Reobservations is the number of re-observation requests sent to the chain's watcher.
+	Reobservations uint64
+}
+
+// ChainCounters counts observations per emitter chain. Unlike the Prometheus counters of the same events, they can be
+// read in a structured form over the admin API. ChainCounters is safe for concurrent use; all methods are no-ops on a
+// nil ChainCounters.
+type ChainCounters struct {
+	mu     sync.Mutex
+	chains map[vaa.ChainID]*ChainCounterValues
+}
+
+func NewChainCounters() *ChainCounters {
+	return &ChainCounters{chains: make(map[vaa.ChainID]*ChainCounterValues)}
+}
+
+func (c *ChainCounters) add(chainID vaa.ChainID, f func(v *ChainCounterValues)) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	v, ok := c.chains[chainID]
+	if !ok {
+		v = &ChainCounterValues{}
+		c.chains[chainID] = v
+	}
+	f(v)
+}
+
+func (c *ChainCounters) MessageObserved(chainID vaa.ChainID) {
+	c.add(chainID, func(v *ChainCounterValues) { v.MessagesObserved++ })
+}
+
+func (c *ChainCounters) ObservationSigned(chainID vaa.ChainID) {
+	c.add(chainID, func(v *ChainCounterValues) { v.ObservationsSigned++ })
+}
+
+func (c *ChainCounters) QuorumVAA(chainID vaa.ChainID) {
+	c.add(chainID, func(v *ChainCounterValues) { v.QuorumVAAs++ })
+}
+
+func (c *ChainCounters) Reobservation(chainID vaa.ChainID) {
+	c.add(chainID, func(v *ChainCounterValues) { v.Reobservations++ })
+}
+
+// ChainCounterSnapshot are the counters of a chain at the time of a snapshot.
+type ChainCounterSnapshot struct {
+	ChainID vaa.ChainID
+	ChainCounterValues
+}
+
+// Snapshot returns a copy of the counters of every chain with at least one event, ordered by chain ID.
+func (c *ChainCounters) Snapshot() []ChainCounterSnapshot {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	snapshot := make([]ChainCounterSnapshot, 0, len(c.chains))
+	for chainID, v := range c.chains {
+		snapshot = append(snapshot, ChainCounterSnapshot{ChainID: chainID, ChainCounterValues: *v})
+	}
+	sort.Slice(snapshot, func(i, j int) bool { return snapshot[i].ChainID < snapshot[j].ChainID })
+	return snapshot
+}
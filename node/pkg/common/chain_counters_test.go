@@ -0,0 +1,28 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+func TestChainCounters(t *testing.T) {
+	c := NewChainCounters()
+	c.MessageObserved(vaa.ChainIDSolana)
+	c.MessageObserved(vaa.ChainIDEthereum)
+	c.ObservationSigned(vaa.ChainIDEthereum)
+	c.QuorumVAA(vaa.ChainIDEthereum)
+	c.Reobservation(vaa.ChainIDEthereum)
+	c.Reobservation(vaa.ChainIDEthereum)
+
+	assert.Equal(t, []ChainCounterSnapshot{
+		{ChainID: vaa.ChainIDSolana, ChainCounterValues: ChainCounterValues{MessagesObserved: 1}},
+		{ChainID: vaa.ChainIDEthereum, ChainCounterValues: ChainCounterValues{MessagesObserved: 1, ObservationsSigned: 1, QuorumVAAs: 1, Reobservations: 2}},
+	}, c.Snapshot())
+
+	// A nil ChainCounters ignores events
+	var nilCounters *ChainCounters
+	nilCounters.MessageObserved(vaa.ChainIDSolana)
+	assert.Empty(t, nilCounters.Snapshot())
+}
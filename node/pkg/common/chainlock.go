@@ -32,7 +32,12 @@ func (msg *MessagePublication) MessageID() []byte {
 }
 
 func (msg *MessagePublication) MessageIDString() string {
-	return fmt.Sprintf("%v/%v/%v", uint16(msg.EmitterChain), msg.EmitterAddress, msg.Sequence)
+	return msg.ID().String()
+}
+
+// ID returns the ID of the message, which is the same as the ID of the VAA it is signed in.
+func (msg *MessagePublication) ID() vaa.MessageID {
+	return vaa.MessageID{EmitterChain: msg.EmitterChain, EmitterAddress: msg.EmitterAddress, Sequence: msg.Sequence}
 }
 
 const minMsgLength = 88
@@ -0,0 +1,99 @@
+package common
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// EmitterAllowlist restricts the emitters a node will observe on a per-chain basis. Chains without any configured
+// entries are unrestricted.
+type EmitterAllowlist struct {
+	emitters map[vaa.ChainID]map[vaa.Address]struct{}
+	// countOnly specifies that messages from unknown emitters should be counted, but still be processed.
+	countOnly bool
+}
+
+const (
+	EmitterAllowlistModeIgnore = "ignore"
+	EmitterAllowlistModeCount  = "count"
+)
+
+// ParseEmitterAllowlist parses a comma-separated list of <chain>:<emitter address> entries, where chain is either a
+// numeric chain ID or a chain name (e.g. "ethereum:0x3ee18B2214AFF97000D974cf647E7C347E8fa585,2:0000...0004"). An empty
+// string returns an allowlist that allows everything.
+func ParseEmitterAllowlist(s string, mode string) (*EmitterAllowlist, error) {
+	l := &EmitterAllowlist{emitters: make(map[vaa.ChainID]map[vaa.Address]struct{})}
+
+	switch mode {
+	case EmitterAllowlistModeIgnore, "":
+	case EmitterAllowlistModeCount:
+		l.countOnly = true
+	default:
+		return nil, fmt.Errorf("invalid emitter allowlist mode %q (must be %q or %q)", mode, EmitterAllowlistModeIgnore, EmitterAllowlistModeCount)
+	}
+
+	if s == "" {
+		return l, nil
+	}
+
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		parts := strings.SplitN(entry, ":", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid emitter allowlist entry %q (must be <chain>:<address>)", entry)
+		}
+
+		chainID, err := parseChainID(parts[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid chain in emitter allowlist entry %q: %w", entry, err)
+		}
+
+		addr, err := vaa.StringToAddress(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid address in emitter allowlist entry %q: %w", entry, err)
+		}
+
+		if _, exists := l.emitters[chainID]; !exists {
+			l.emitters[chainID] = make(map[vaa.Address]struct{})
+		}
+		l.emitters[chainID][addr] = struct{}{}
+	}
+
+	return l, nil
+}
+
+func parseChainID(s string) (vaa.ChainID, error) {
+	if n, err := strconv.ParseUint(s, 10, 16); err == nil {
+		return vaa.ChainID(n), nil
+	}
+	return vaa.ChainIDFromString(s)
+}
+
+// Known returns true if the emitter is explicitly allowed or if there is no allowlist configured for its chain.
+func (l *EmitterAllowlist) Known(chainID vaa.ChainID, addr vaa.Address) bool {
+	if l == nil {
+		return true
+	}
+	emitters, exists := l.emitters[chainID]
+	if !exists {
+		return true
+	}
+	_, exists = emitters[addr]
+	return exists
+}
+
+// CountOnly returns true if messages from unknown emitters should still be processed.
+func (l *EmitterAllowlist) CountOnly() bool {
+	return l != nil && l.countOnly
+}
+
+// Chains returns the number of chains that have an allowlist configured.
+func (l *EmitterAllowlist) Chains() int {
+	if l == nil {
+		return 0
+	}
+	return len(l.emitters)
+}
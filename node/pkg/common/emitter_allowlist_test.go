@@ -0,0 +1,67 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+func TestParseEmitterAllowlist(t *testing.T) {
+	tokenBridge, err := vaa.StringToAddress("0x3ee18B2214AFF97000D974cf647E7C347E8fa585")
+	require.NoError(t, err)
+	nftBridge, err := vaa.StringToAddress("0x6FFd7EdE62328b3Af38FCD61461Bbfc52F5651fE")
+	require.NoError(t, err)
+	other, err := vaa.StringToAddress("0x0000000000000000000000000000000000000001")
+	require.NoError(t, err)
+
+	l, err := ParseEmitterAllowlist("ethereum:0x3ee18B2214AFF97000D974cf647E7C347E8fa585, 2:0x6FFd7EdE62328b3Af38FCD61461Bbfc52F5651fE", EmitterAllowlistModeIgnore)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, l.Chains())
+	assert.False(t, l.CountOnly())
+	assert.True(t, l.Known(vaa.ChainIDEthereum, tokenBridge))
+	assert.True(t, l.Known(vaa.ChainIDEthereum, nftBridge))
+	assert.False(t, l.Known(vaa.ChainIDEthereum, other))
+
+	// Chains without entries are unrestricted.
+	assert.True(t, l.Known(vaa.ChainIDSolana, other))
+}
+
+func TestParseEmitterAllowlistEmpty(t *testing.T) {
+	l, err := ParseEmitterAllowlist("", "")
+	require.NoError(t, err)
+	assert.Equal(t, 0, l.Chains())
+	assert.True(t, l.Known(vaa.ChainIDEthereum, vaa.Address{}))
+
+	var nilList *EmitterAllowlist
+	assert.True(t, nilList.Known(vaa.ChainIDEthereum, vaa.Address{}))
+	assert.False(t, nilList.CountOnly())
+}
+
+func TestParseEmitterAllowlistCountMode(t *testing.T) {
+	l, err := ParseEmitterAllowlist("ethereum:0x3ee18B2214AFF97000D974cf647E7C347E8fa585", EmitterAllowlistModeCount)
+	require.NoError(t, err)
+	assert.True(t, l.CountOnly())
+}
+
+func TestParseEmitterAllowlistInvalid(t *testing.T) {
+	tests := []struct {
+		label string
+		list  string
+		mode  string
+	}{
+		{label: "missing separator", list: "ethereum", mode: EmitterAllowlistModeIgnore},
+		{label: "unknown chain", list: "notachain:0x3ee18B2214AFF97000D974cf647E7C347E8fa585", mode: EmitterAllowlistModeIgnore},
+		{label: "invalid address", list: "ethereum:0xzz", mode: EmitterAllowlistModeIgnore},
+		{label: "invalid mode", list: "", mode: "drop"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.label, func(t *testing.T) {
+			_, err := ParseEmitterAllowlist(tc.list, tc.mode)
+			assert.Error(t, err)
+		})
+	}
+}
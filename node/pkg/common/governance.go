@@ -0,0 +1,17 @@
+package common
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// governanceMessageIDPrefix is the prefix of the <chain>/<emitter>/<sequence> message IDs of the governance emitter.
+var governanceMessageIDPrefix = fmt.Sprintf("%d/%s/", vaa.GovernanceChain, vaa.GovernanceEmitter)
+
+// IsGovernanceMessageID returns true if id is the message ID of a message emitted by the governance emitter, like
+// guardian set upgrades and contract upgrades.
+func IsGovernanceMessageID(id string) bool {
+	return strings.HasPrefix(id, governanceMessageIDPrefix)
+}
@@ -0,0 +1,21 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+func TestIsGovernanceMessageID(t *testing.T) {
+	governance := &vaa.VAA{EmitterChain: vaa.GovernanceChain, EmitterAddress: vaa.GovernanceEmitter, Sequence: 12}
+	assert.True(t, IsGovernanceMessageID(governance.MessageID()))
+
+	otherChain := &vaa.VAA{EmitterChain: vaa.ChainIDEthereum, EmitterAddress: vaa.GovernanceEmitter, Sequence: 12}
+	assert.False(t, IsGovernanceMessageID(otherChain.MessageID()))
+
+	otherEmitter := &vaa.VAA{EmitterChain: vaa.GovernanceChain, EmitterAddress: vaa.Address{31: 0x05}, Sequence: 12}
+	assert.False(t, IsGovernanceMessageID(otherEmitter.MessageID()))
+
+	assert.False(t, IsGovernanceMessageID(""))
+}
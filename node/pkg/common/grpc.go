@@ -9,8 +9,10 @@ import (
 	"google.golang.org/grpc"
 )
 
-func NewInstrumentedGRPCServer(logger *zap.Logger) *grpc.Server {
-	server := grpc.NewServer(
+// NewInstrumentedGRPCServer returns a gRPC server with logging and metrics interceptors. Further unary interceptors can
+// be added to opts with grpc.ChainUnaryInterceptor, and run after these.
+func NewInstrumentedGRPCServer(logger *zap.Logger, opts ...grpc.ServerOption) *grpc.Server {
+	opts = append([]grpc.ServerOption{
 		grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(
 			grpc_ctxtags.StreamServerInterceptor(),
 			grpc_prometheus.StreamServerInterceptor,
@@ -21,7 +23,8 @@ func NewInstrumentedGRPCServer(logger *zap.Logger) *grpc.Server {
 			grpc_prometheus.UnaryServerInterceptor,
 			grpc_zap.UnaryServerInterceptor(logger),
 		)),
-	)
+	}, opts...)
+	server := grpc.NewServer(opts...)
 
 	grpc_prometheus.EnableHandlingTimeHistogram()
 	grpc_prometheus.Register(server)
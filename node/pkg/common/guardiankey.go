@@ -0,0 +1,57 @@
+package common
+
+import (
+	"crypto/ecdsa"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// GuardianKeyState holds the guardian key the node signs with. It is shared by every component that signs as the
+// guardian, so that they all follow a switch to the additional guardian key during guardian set transitions.
+type GuardianKeyState struct {
+	mu         sync.Mutex
+	key        *ecdsa.PrivateKey
+	additional *ecdsa.PrivateKey
+}
+
+// NewGuardianKeyState returns a state signing with key. additional is an optional second guardian key, or nil.
+func NewGuardianKeyState(key *ecdsa.PrivateKey, additional *ecdsa.PrivateKey) *GuardianKeyState {
+	return &GuardianKeyState{key: key, additional: additional}
+}
+
+// Get returns the active guardian key.
+func (st *GuardianKeyState) Get() *ecdsa.PrivateKey {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	return st.key
+}
+
+// Address returns the address of the active guardian key.
+func (st *GuardianKeyState) Address() common.Address {
+	return crypto.PubkeyToAddress(st.Get().PublicKey)
+}
+
+// Update switches to the additional guardian key when it is part of gs while the active key is not, and returns
+// whether it switched. The previous key is kept as the additional key, so that a later guardian set can switch back.
+// If both keys are part of gs, the active key is kept and conflict is true: one operator must never hold two votes of
+// the same guardian set.
+func (st *GuardianKeyState) Update(gs *GuardianSet) (switched bool, conflict bool) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	if st.additional == nil {
+		return false, false
+	}
+	_, additionalActive := gs.KeyIndex(crypto.PubkeyToAddress(st.additional.PublicKey))
+	if _, ok := gs.KeyIndex(crypto.PubkeyToAddress(st.key.PublicKey)); ok {
+		return false, additionalActive
+	}
+	if !additionalActive {
+		return false, false
+	}
+	st.key, st.additional = st.additional, st.key
+	return true, false
+}
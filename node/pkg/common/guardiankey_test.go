@@ -0,0 +1,46 @@
+package common
+
+import (
+	"crypto/ecdsa"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGuardianKeyStateUpdate(t *testing.T) {
+	oldKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	newKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	addr := func(k *ecdsa.PrivateKey) common.Address { return crypto.PubkeyToAddress(k.PublicKey) }
+
+	st := NewGuardianKeyState(oldKey, newKey)
+	assert.Equal(t, addr(oldKey), st.Address())
+
+	// Both keys active keeps the active key
+	switched, conflict := st.Update(&GuardianSet{Keys: []common.Address{addr(newKey), addr(oldKey)}})
+	assert.False(t, switched)
+	assert.True(t, conflict)
+	assert.Equal(t, oldKey, st.Get())
+
+	switched, conflict = st.Update(&GuardianSet{Keys: []common.Address{addr(newKey)}})
+	assert.True(t, switched)
+	assert.False(t, conflict)
+	assert.Equal(t, newKey, st.Get())
+	assert.Equal(t, addr(newKey), st.Address())
+
+	// A later guardian set can switch back
+	switched, _ = st.Update(&GuardianSet{Keys: []common.Address{addr(oldKey)}})
+	assert.True(t, switched)
+	assert.Equal(t, oldKey, st.Get())
+
+	// Without an additional key, nothing switches
+	st = NewGuardianKeyState(oldKey, nil)
+	switched, conflict = st.Update(&GuardianSet{Keys: []common.Address{addr(newKey)}})
+	assert.False(t, switched)
+	assert.False(t, conflict)
+	assert.Equal(t, oldKey, st.Get())
+}
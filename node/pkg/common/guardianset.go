@@ -82,11 +82,26 @@ type GuardianSetState struct {
 	// Last heartbeat message received per guardian per p2p node. Maintained
 	// across guardian set updates - these values don't change.
 	lastHeartbeats map[common.Address]map[peer.ID]*gossipv1.Heartbeat
+
+	// Most recent signed heartbeat per guardian across all of its p2p nodes.
+	lastSignedHeartbeats map[common.Address]*SignedHeartbeat
+}
+
+// SignedHeartbeat is a heartbeat together with the signed envelope it was received in, so that it can be exported
+// and verified by third parties.
+type SignedHeartbeat struct {
+	// The p2p node that sent the heartbeat.
+	PeerID peer.ID
+	// The signed envelope as received from the network.
+	Signed *gossipv1.SignedHeartbeat
+	// The heartbeat decoded from the envelope.
+	Heartbeat *gossipv1.Heartbeat
 }
 
 func NewGuardianSetState() *GuardianSetState {
 	return &GuardianSetState{
-		lastHeartbeats: map[common.Address]map[peer.ID]*gossipv1.Heartbeat{},
+		lastHeartbeats:       map[common.Address]map[peer.ID]*gossipv1.Heartbeat{},
+		lastSignedHeartbeats: map[common.Address]*SignedHeartbeat{},
 	}
 }
 
@@ -139,6 +154,30 @@ func (st *GuardianSetState) SetHeartbeat(addr common.Address, peerId peer.ID, hb
 	return nil
 }
 
+// SetSignedHeartbeat stores a signed heartbeat whose signature was verified to belong to the guardian at addr. It
+// replaces the guardian's stored heartbeat unless that one is newer.
+func (st *GuardianSetState) SetSignedHeartbeat(addr common.Address, hb *SignedHeartbeat) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	if last, ok := st.lastSignedHeartbeats[addr]; ok && last.Heartbeat.Timestamp > hb.Heartbeat.Timestamp {
+		return
+	}
+	st.lastSignedHeartbeats[addr] = hb
+}
+
+// LastSignedHeartbeats returns the most recent signed heartbeat stored for each guardian.
+func (st *GuardianSetState) LastSignedHeartbeats() map[common.Address]*SignedHeartbeat {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	ret := make(map[common.Address]*SignedHeartbeat, len(st.lastSignedHeartbeats))
+	for addr, hb := range st.lastSignedHeartbeats {
+		ret[addr] = hb
+	}
+	return ret
+}
+
 // GetAll returns all stored heartbeats.
 func (st *GuardianSetState) GetAll() map[common.Address]map[peer.ID]*gossipv1.Heartbeat {
 	st.mu.Lock()
@@ -170,4 +209,10 @@ func (st *GuardianSetState) Cleanup() {
 			}
 		}
 	}
+
+	for addr, hb := range st.lastSignedHeartbeats {
+		if time.Since(time.Unix(0, hb.Heartbeat.Timestamp)) > MaxStateAge {
+			delete(st.lastSignedHeartbeats, addr)
+		}
+	}
 }
@@ -2,7 +2,9 @@ package common
 
 import (
 	"testing"
+	"time"
 
+	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/assert"
 )
@@ -92,3 +94,22 @@ func TestGet(t *testing.T) {
 	gss.Set(&gs)
 	assert.Equal(t, gss.Get(), &gs)
 }
+
+func TestSetSignedHeartbeat(t *testing.T) {
+	addr := common.HexToAddress("0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed")
+	now := time.Now()
+	older := &SignedHeartbeat{PeerID: "a", Heartbeat: &gossipv1.Heartbeat{Timestamp: now.Add(-time.Second).UnixNano()}}
+	newer := &SignedHeartbeat{PeerID: "b", Heartbeat: &gossipv1.Heartbeat{Timestamp: now.UnixNano()}}
+
+	gss := NewGuardianSetState()
+	assert.Empty(t, gss.LastSignedHeartbeats())
+
+	gss.SetSignedHeartbeat(addr, newer)
+	gss.SetSignedHeartbeat(addr, older)
+	assert.Equal(t, map[common.Address]*SignedHeartbeat{addr: newer}, gss.LastSignedHeartbeats())
+
+	expired := &SignedHeartbeat{PeerID: "c", Heartbeat: &gossipv1.Heartbeat{Timestamp: now.Add(-2 * MaxStateAge).UnixNano()}}
+	gss.lastSignedHeartbeats[addr] = expired
+	gss.Cleanup()
+	assert.Empty(t, gss.LastSignedHeartbeats())
+}
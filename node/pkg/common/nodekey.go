@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"time"
 
 	"github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/libp2p/go-libp2p/core/peer"
@@ -53,3 +54,38 @@ func GetOrCreateNodeKey(logger *zap.Logger, path string) (crypto.PrivKey, error)
 
 	return priv, nil
 }
+
+// RotateNodeKey generates a new node key and stores it at path, keeping a copy of the previous key at the returned
+// backup path. The new key is written to a temporary file and renamed, so path always holds a complete key.
+func RotateNodeKey(path string) (priv crypto.PrivKey, backupPath string, err error) {
+	old, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read node key: %w", err)
+	}
+	if _, err := crypto.UnmarshalPrivateKey(old); err != nil {
+		return nil, "", fmt.Errorf("failed to unmarshal node key: %w", err)
+	}
+
+	priv, _, err = crypto.GenerateKeyPair(crypto.Ed25519, -1)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to generate node key: %w", err)
+	}
+	s, err := crypto.MarshalPrivateKey(priv)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to marshal node key: %w", err)
+	}
+
+	backupPath = fmt.Sprintf("%s.%d.old", path, time.Now().UnixNano())
+	if err := ioutil.WriteFile(backupPath, old, 0600); err != nil {
+		return nil, "", fmt.Errorf("failed to back up node key: %w", err)
+	}
+	tmpPath := path + ".new"
+	if err := ioutil.WriteFile(tmpPath, s, 0600); err != nil {
+		return nil, "", fmt.Errorf("failed to write node key: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return nil, "", fmt.Errorf("failed to replace node key: %w", err)
+	}
+
+	return priv, backupPath, nil
+}
@@ -5,10 +5,12 @@ import (
 	"io/ioutil"
 	"math/rand"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 )
 
@@ -50,3 +52,33 @@ func TestGetOrCreateNodeKeyWithPreExistingPath(t *testing.T) {
 	// Make sure we got the same key
 	assert.Equal(t, privKey1, privKey2)
 }
+
+func TestRotateNodeKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "node.key")
+	logger := zap.NewNop()
+	privKey1, err := GetOrCreateNodeKey(logger, path)
+	require.NoError(t, err)
+
+	privKey2, backupPath, err := RotateNodeKey(path)
+	require.NoError(t, err)
+	assert.False(t, privKey1.Equals(privKey2))
+
+	// The new key is loaded from now on, and the previous one is kept.
+	loaded, err := GetOrCreateNodeKey(logger, path)
+	require.NoError(t, err)
+	assert.True(t, privKey2.Equals(loaded))
+	b, err := ioutil.ReadFile(backupPath)
+	require.NoError(t, err)
+	backup, err := crypto.UnmarshalPrivateKey(b)
+	require.NoError(t, err)
+	assert.True(t, privKey1.Equals(backup))
+	_, err = os.Stat(path + ".new")
+	assert.True(t, os.IsNotExist(err))
+
+	// A missing or corrupt key file is not rotated.
+	_, _, err = RotateNodeKey(filepath.Join(t.TempDir(), "missing.key"))
+	assert.Error(t, err)
+	require.NoError(t, ioutil.WriteFile(path, []byte("garbage"), 0600))
+	_, _, err = RotateNodeKey(path)
+	assert.Error(t, err)
+}
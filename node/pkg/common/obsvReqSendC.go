@@ -2,8 +2,10 @@ package common
 
 import (
 	"errors"
+	"fmt"
 
 	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
 )
 
 const ObsvReqChannelSize = 50
@@ -18,3 +20,36 @@ func PostObservationRequest(obsvReqSendC chan<- *gossipv1.ObservationRequest, re
 		return ErrChanFull
 	}
 }
+
+// ValidateObservationRequest checks that req identifies either a transaction by its hash or a message by its emitter
+// address and sequence, but not both.
+func ValidateObservationRequest(req *gossipv1.ObservationRequest) error {
+	if len(req.EmitterAddress) == 0 {
+		if len(req.TxHash) == 0 {
+			return errors.New("observation request has neither a tx hash nor an emitter address")
+		}
+		if req.Sequence != 0 {
+			return errors.New("observation request by tx hash must not have a sequence")
+		}
+		return nil
+	}
+
+	if len(req.TxHash) != 0 {
+		return errors.New("observation request must not have both a tx hash and an emitter address")
+	}
+	if len(req.EmitterAddress) != 32 {
+		return fmt.Errorf("emitter address must be 32 bytes, got %d", len(req.EmitterAddress))
+	}
+	return nil
+}
+
+// ObservationRequestMessageID returns the emitter address and sequence of the message req asks to re-observe. It returns
+// false for requests that identify a transaction by its hash.
+func ObservationRequestMessageID(req *gossipv1.ObservationRequest) (vaa.Address, uint64, bool) {
+	if len(req.EmitterAddress) != 32 {
+		return vaa.Address{}, 0, false
+	}
+	var emitter vaa.Address
+	copy(emitter[:], req.EmitterAddress)
+	return emitter, req.Sequence, true
+}
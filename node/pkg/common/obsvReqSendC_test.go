@@ -1,6 +1,7 @@
 package common
 
 import (
+	"bytes"
 	"testing"
 	"time"
 
@@ -42,3 +43,44 @@ func TestObsvReqSendLimitEnforced(t *testing.T) {
 	case <-done:
 	}
 }
+
+func TestValidateObservationRequest(t *testing.T) {
+	emitter := bytes.Repeat([]byte{0x01}, 32)
+
+	tests := []struct {
+		label string
+		req   *gossipv1.ObservationRequest
+		valid bool
+	}{
+		{label: "tx hash", req: &gossipv1.ObservationRequest{ChainId: 1, TxHash: []byte{0x01}}, valid: true},
+		{label: "message id", req: &gossipv1.ObservationRequest{ChainId: 1, EmitterAddress: emitter, Sequence: 5}, valid: true},
+		{label: "message id with sequence zero", req: &gossipv1.ObservationRequest{ChainId: 1, EmitterAddress: emitter}, valid: true},
+		{label: "empty", req: &gossipv1.ObservationRequest{ChainId: 1}},
+		{label: "tx hash with sequence", req: &gossipv1.ObservationRequest{ChainId: 1, TxHash: []byte{0x01}, Sequence: 5}},
+		{label: "both", req: &gossipv1.ObservationRequest{ChainId: 1, TxHash: []byte{0x01}, EmitterAddress: emitter}},
+		{label: "short emitter", req: &gossipv1.ObservationRequest{ChainId: 1, EmitterAddress: emitter[1:]}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.label, func(t *testing.T) {
+			err := ValidateObservationRequest(tc.req)
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestObservationRequestMessageID(t *testing.T) {
+	emitter := vaa.Address{0x01, 0x02}
+
+	addr, sequence, ok := ObservationRequestMessageID(&gossipv1.ObservationRequest{ChainId: 1, EmitterAddress: emitter.Bytes(), Sequence: 5})
+	assert.True(t, ok)
+	assert.Equal(t, emitter, addr)
+	assert.Equal(t, uint64(5), sequence)
+
+	_, _, ok = ObservationRequestMessageID(&gossipv1.ObservationRequest{ChainId: 1, TxHash: []byte{0x01}})
+	assert.False(t, ok)
+}
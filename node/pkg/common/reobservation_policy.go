@@ -0,0 +1,78 @@
+package common
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// ReobservationPolicy restricts the re-observation requests a node honors, to balance helping other guardians against
+// the RPC cost of re-observing. A nil policy honors every request.
+type ReobservationPolicy struct {
+	// chains whose requests are honored. All chains are honored if empty.
+	chains map[vaa.ChainID]struct{}
+	// guardians whose requests received from gossip are honored. All guardians are honored if empty.
+	guardians map[common.Address]struct{}
+	// maxPerMinute is the maximum number of requests processed per minute, or zero for no limit.
+	maxPerMinute uint
+}
+
+// ParseReobservationPolicy parses a comma-separated list of chains (numeric chain IDs or chain names) and a
+// comma-separated list of guardian addresses. Empty lists do not restrict requests.
+func ParseReobservationPolicy(chains string, guardians string, maxPerMinute uint) (*ReobservationPolicy, error) {
+	p := &ReobservationPolicy{
+		chains:       make(map[vaa.ChainID]struct{}),
+		guardians:    make(map[common.Address]struct{}),
+		maxPerMinute: maxPerMinute,
+	}
+
+	if chains != "" {
+		for _, entry := range strings.Split(chains, ",") {
+			chainID, err := parseChainID(strings.TrimSpace(entry))
+			if err != nil {
+				return nil, fmt.Errorf("invalid re-observation chain %q: %w", entry, err)
+			}
+			p.chains[chainID] = struct{}{}
+		}
+	}
+
+	if guardians != "" {
+		for _, entry := range strings.Split(guardians, ",") {
+			entry = strings.TrimSpace(entry)
+			if !common.IsHexAddress(entry) {
+				return nil, fmt.Errorf("invalid re-observation guardian address %q", entry)
+			}
+			p.guardians[common.HexToAddress(entry)] = struct{}{}
+		}
+	}
+
+	return p, nil
+}
+
+// AllowsChain returns true if requests for the given chain are honored.
+func (p *ReobservationPolicy) AllowsChain(chainID vaa.ChainID) bool {
+	if p == nil || len(p.chains) == 0 {
+		return true
+	}
+	_, exists := p.chains[chainID]
+	return exists
+}
+
+// AllowsGuardian returns true if requests signed by the given guardian are honored.
+func (p *ReobservationPolicy) AllowsGuardian(addr common.Address) bool {
+	if p == nil || len(p.guardians) == 0 {
+		return true
+	}
+	_, exists := p.guardians[addr]
+	return exists
+}
+
+// MaxPerMinute returns the maximum number of requests processed per minute, or zero if there is no limit.
+func (p *ReobservationPolicy) MaxPerMinute() uint {
+	if p == nil {
+		return 0
+	}
+	return p.maxPerMinute
+}
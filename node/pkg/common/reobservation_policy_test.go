@@ -0,0 +1,46 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+func TestParseReobservationPolicy(t *testing.T) {
+	guardian := common.HexToAddress("0xbeFA429d57cD18b7F8A4d91A2da9AB4AF05d0FBe")
+	other := common.HexToAddress("0x88D7D8B32a9105d228100E72dFFe2Fae0705D31c")
+
+	p, err := ParseReobservationPolicy("ethereum, 4", "0xbeFA429d57cD18b7F8A4d91A2da9AB4AF05d0FBe", 10)
+	require.NoError(t, err)
+
+	assert.True(t, p.AllowsChain(vaa.ChainIDEthereum))
+	assert.True(t, p.AllowsChain(vaa.ChainIDBSC))
+	assert.False(t, p.AllowsChain(vaa.ChainIDSolana))
+	assert.True(t, p.AllowsGuardian(guardian))
+	assert.False(t, p.AllowsGuardian(other))
+	assert.Equal(t, uint(10), p.MaxPerMinute())
+}
+
+func TestParseReobservationPolicyEmpty(t *testing.T) {
+	p, err := ParseReobservationPolicy("", "", 0)
+	require.NoError(t, err)
+	assert.True(t, p.AllowsChain(vaa.ChainIDSolana))
+	assert.True(t, p.AllowsGuardian(common.Address{}))
+	assert.Equal(t, uint(0), p.MaxPerMinute())
+
+	var nilPolicy *ReobservationPolicy
+	assert.True(t, nilPolicy.AllowsChain(vaa.ChainIDSolana))
+	assert.True(t, nilPolicy.AllowsGuardian(common.Address{}))
+	assert.Equal(t, uint(0), nilPolicy.MaxPerMinute())
+}
+
+func TestParseReobservationPolicyInvalid(t *testing.T) {
+	_, err := ParseReobservationPolicy("notachain", "", 0)
+	assert.Error(t, err)
+
+	_, err = ParseReobservationPolicy("", "0x1234", 0)
+	assert.Error(t, err)
+}
@@ -0,0 +1,112 @@
+package common
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// VAARetention configures how long the signed VAAs of an emitter are stored. A policy is set either for all emitters
+// of a chain or for a single emitter, which takes precedence. VAAs of emitters without a policy are kept forever.
+type VAARetention struct {
+	chains   map[vaa.ChainID]time.Duration
+	emitters map[vaa.ChainID]map[vaa.Address]time.Duration
+}
+
+// ParseVAARetention parses a comma-separated list of <chain>[:<emitter address>]=<max age> entries, where chain is
+// either a numeric chain ID or a chain name and max age is a duration. A max age of 0 keeps the VAAs forever, which
+// exempts an emitter from the policy of its chain (e.g. "pythnet=24h,solana=720h,solana:ec7372...a4f5=0"). An empty
+// string keeps all VAAs forever.
+func ParseVAARetention(s string) (*VAARetention, error) {
+	r := &VAARetention{
+		chains:   make(map[vaa.ChainID]time.Duration),
+		emitters: make(map[vaa.ChainID]map[vaa.Address]time.Duration),
+	}
+
+	if s == "" {
+		return r, nil
+	}
+
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid VAA retention entry %q (must be <chain>[:<address>]=<max age>)", entry)
+		}
+
+		maxAge, err := time.ParseDuration(parts[1])
+		if err != nil || maxAge < 0 {
+			return nil, fmt.Errorf("invalid max age in VAA retention entry %q", entry)
+		}
+
+		emitter := strings.SplitN(parts[0], ":", 2)
+		chainID, err := parseChainID(emitter[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid chain in VAA retention entry %q: %w", entry, err)
+		}
+
+		if len(emitter) == 1 {
+			if _, exists := r.chains[chainID]; exists {
+				return nil, fmt.Errorf("duplicate VAA retention entry for chain %s", chainID)
+			}
+			r.chains[chainID] = maxAge
+			continue
+		}
+
+		addr, err := vaa.StringToAddress(emitter[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid address in VAA retention entry %q: %w", entry, err)
+		}
+		if _, exists := r.emitters[chainID]; !exists {
+			r.emitters[chainID] = make(map[vaa.Address]time.Duration)
+		}
+		if _, exists := r.emitters[chainID][addr]; exists {
+			return nil, fmt.Errorf("duplicate VAA retention entry for emitter %s:%s", chainID, addr)
+		}
+		r.emitters[chainID][addr] = maxAge
+	}
+
+	return r, nil
+}
+
+// MaxAge returns how long the VAAs of an emitter are stored, or false if they are kept forever.
+func (r *VAARetention) MaxAge(chainID vaa.ChainID, addr vaa.Address) (time.Duration, bool) {
+	if r == nil {
+		return 0, false
+	}
+	maxAge, exists := r.emitters[chainID][addr]
+	if !exists {
+		maxAge, exists = r.chains[chainID]
+	}
+	return maxAge, exists && maxAge != 0
+}
+
+// Chains returns the chains that have emitters whose VAAs are not kept forever, in chain ID order.
+func (r *VAARetention) Chains() []vaa.ChainID {
+	if r == nil {
+		return nil
+	}
+	set := make(map[vaa.ChainID]struct{})
+	for chainID, maxAge := range r.chains {
+		if maxAge != 0 {
+			set[chainID] = struct{}{}
+		}
+	}
+	for chainID, emitters := range r.emitters {
+		for _, maxAge := range emitters {
+			if maxAge != 0 {
+				set[chainID] = struct{}{}
+			}
+		}
+	}
+
+	chains := make([]vaa.ChainID, 0, len(set))
+	for chainID := range set {
+		chains = append(chains, chainID)
+	}
+	sort.Slice(chains, func(i, j int) bool { return chains[i] < chains[j] })
+	return chains
+}
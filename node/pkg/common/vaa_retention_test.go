@@ -0,0 +1,79 @@
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+func TestParseVAARetention(t *testing.T) {
+	tokenBridge, err := vaa.StringToAddress("ec7372995d5cc8732397fb0ad35c0121e0eaa90d26f828a534cab54391b3a4f5")
+	require.NoError(t, err)
+	nftBridge, err := vaa.StringToAddress("0x6FFd7EdE62328b3Af38FCD61461Bbfc52F5651fE")
+	require.NoError(t, err)
+	other, err := vaa.StringToAddress("0x0000000000000000000000000000000000000001")
+	require.NoError(t, err)
+
+	r, err := ParseVAARetention("pythnet=24h, solana=720h, solana:ec7372995d5cc8732397fb0ad35c0121e0eaa90d26f828a534cab54391b3a4f5=0, 2:0x6FFd7EdE62328b3Af38FCD61461Bbfc52F5651fE=1h")
+	require.NoError(t, err)
+
+	assert.Equal(t, []vaa.ChainID{vaa.ChainIDSolana, vaa.ChainIDEthereum, vaa.ChainIDPythNet}, r.Chains())
+
+	maxAge, pruned := r.MaxAge(vaa.ChainIDPythNet, other)
+	assert.True(t, pruned)
+	assert.Equal(t, 24*time.Hour, maxAge)
+
+	maxAge, pruned = r.MaxAge(vaa.ChainIDSolana, other)
+	assert.True(t, pruned)
+	assert.Equal(t, 720*time.Hour, maxAge)
+
+	// An emitter policy takes precedence over the policy of its chain.
+	_, pruned = r.MaxAge(vaa.ChainIDSolana, tokenBridge)
+	assert.False(t, pruned)
+
+	maxAge, pruned = r.MaxAge(vaa.ChainIDEthereum, nftBridge)
+	assert.True(t, pruned)
+	assert.Equal(t, time.Hour, maxAge)
+
+	// Emitters without a policy are kept forever.
+	_, pruned = r.MaxAge(vaa.ChainIDEthereum, other)
+	assert.False(t, pruned)
+}
+
+func TestParseVAARetentionEmpty(t *testing.T) {
+	r, err := ParseVAARetention("")
+	require.NoError(t, err)
+	assert.Empty(t, r.Chains())
+	_, pruned := r.MaxAge(vaa.ChainIDPythNet, vaa.Address{})
+	assert.False(t, pruned)
+
+	var nilRetention *VAARetention
+	assert.Empty(t, nilRetention.Chains())
+	_, pruned = nilRetention.MaxAge(vaa.ChainIDPythNet, vaa.Address{})
+	assert.False(t, pruned)
+}
+
+func TestParseVAARetentionInvalid(t *testing.T) {
+	tests := []struct {
+		label     string
+		retention string
+	}{
+		{label: "missing max age", retention: "pythnet"},
+		{label: "invalid max age", retention: "pythnet=1d"},
+		{label: "negative max age", retention: "pythnet=-1h"},
+		{label: "unknown chain", retention: "notachain=24h"},
+		{label: "invalid address", retention: "ethereum:0xzz=24h"},
+		{label: "duplicate chain", retention: "pythnet=24h,26=1h"},
+		{label: "duplicate emitter", retention: "ethereum:0x01=24h,2:0x01=1h"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.label, func(t *testing.T) {
+			_, err := ParseVAARetention(tc.retention)
+			assert.Error(t, err)
+		})
+	}
+}
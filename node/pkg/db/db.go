@@ -3,8 +3,10 @@ package db
 import (
 	"errors"
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/dgraph-io/badger/v3"
 	"github.com/wormhole-foundation/wormhole/sdk/vaa"
@@ -12,8 +14,20 @@ import (
 
 type Database struct {
 	db *badger.DB
+
+	// corrupt holds the keys of the VAAs that failed verification, which are not served.
+	corruptMu sync.RWMutex
+	corrupt   map[string]bool
+
+	// recent is a ring buffer of the IDs of the last stored VAAs, next the index of the slot to write to.
+	recentMu   sync.Mutex
+	recent     []VAAID
+	recentNext int
 }
 
+// RecentVAAsSize is the number of recently stored VAAs returned by RecentSignedVAAs at most.
+const RecentVAAsSize = 1000
+
 type VAAID struct {
 	EmitterChain   vaa.ChainID
 	EmitterAddress vaa.Address
@@ -22,33 +36,12 @@ type VAAID struct {
 
 // VaaIDFromString parses a <chain>/<address>/<sequence> string into a VAAID.
 func VaaIDFromString(s string) (*VAAID, error) {
-	parts := strings.Split(s, "/")
-	if len(parts) != 3 {
-		return nil, errors.New("invalid message id")
-	}
-
-	emitterChain, err := strconv.ParseUint(parts[0], 10, 16)
+	id, err := vaa.ParseMessageID(s)
 	if err != nil {
-		return nil, fmt.Errorf("invalid emitter chain: %s", err)
-	}
-
-	emitterAddress, err := vaa.StringToAddress(parts[1])
-	if err != nil {
-		return nil, fmt.Errorf("invalid emitter address: %s", err)
-	}
-
-	sequence, err := strconv.ParseUint(parts[2], 10, 64)
-	if err != nil {
-		return nil, fmt.Errorf("invalid sequence: %s", err)
-	}
-
-	msgId := &VAAID{
-		EmitterChain:   vaa.ChainID(emitterChain),
-		EmitterAddress: emitterAddress,
-		Sequence:       sequence,
+		return nil, err
 	}
-
-	return msgId, nil
+	msgId := VAAID(id)
+	return &msgId, nil
 }
 
 func VaaIDFromVAA(v *vaa.VAA) *VAAID {
@@ -65,7 +58,7 @@ var (
 )
 
 func (i *VAAID) Bytes() []byte {
-	return []byte(fmt.Sprintf("signed/%d/%s/%d", i.EmitterChain, i.EmitterAddress, i.Sequence))
+	return []byte("signed/" + vaa.MessageID(*i).String())
 }
 
 func (i *VAAID) EmitterPrefixBytes() []byte {
@@ -81,7 +74,9 @@ func Open(path string) (*Database, error) {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
 	return &Database{
-		db: db,
+		db:      db,
+		corrupt: make(map[string]bool),
+		recent:  make([]VAAID, 0, RecentVAAsSize),
 	}, nil
 }
 
@@ -103,8 +98,9 @@ func (d *Database) StoreSignedVAA(v *vaa.VAA) error {
 	//
 	// TODO: panic on non-identical signing digest?
 
+	key := VaaIDFromVAA(v).Bytes()
 	err := d.db.Update(func(txn *badger.Txn) error {
-		if err := txn.Set(VaaIDFromVAA(v).Bytes(), b); err != nil {
+		if err := txn.Set(key, b); err != nil {
 			return err
 		}
 		return nil
@@ -114,10 +110,27 @@ func (d *Database) StoreSignedVAA(v *vaa.VAA) error {
 		return fmt.Errorf("failed to commit tx: %w", err)
 	}
 
+	// A VAA that failed verification is served again once it is replaced.
+	d.corruptMu.Lock()
+	delete(d.corrupt, string(key))
+	d.corruptMu.Unlock()
+
+	d.recentMu.Lock()
+	if len(d.recent) < RecentVAAsSize {
+		d.recent = append(d.recent, *VaaIDFromVAA(v))
+	} else {
+		d.recent[d.recentNext] = *VaaIDFromVAA(v)
+	}
+	d.recentNext = (d.recentNext + 1) % RecentVAAsSize
+	d.recentMu.Unlock()
+
 	return nil
 }
 
 func (d *Database) GetSignedVAABytes(id VAAID) (b []byte, err error) {
+	if d.isCorrupt(id.Bytes()) {
+		return nil, ErrVAACorrupt
+	}
 	if err := d.db.View(func(txn *badger.Txn) error {
 		item, err := txn.Get(id.Bytes())
 		if err != nil {
@@ -138,6 +151,38 @@ func (d *Database) GetSignedVAABytes(id VAAID) (b []byte, err error) {
 	return
 }
 
+// RecentSignedVAAs returns up to n of the VAAs most recently stored since the database was opened, newest first. VAAs
+// stored more than once are returned once, and VAAs that are no longer served are omitted.
+func (d *Database) RecentSignedVAAs(n int) ([][]byte, error) {
+	d.recentMu.Lock()
+	ids := make([]VAAID, 0, len(d.recent))
+	for i := 1; i <= len(d.recent); i++ {
+		ids = append(ids, d.recent[(d.recentNext-i+len(d.recent))%len(d.recent)])
+	}
+	d.recentMu.Unlock()
+
+	seen := make(map[VAAID]bool)
+	var vaas [][]byte
+	for _, id := range ids {
+		if len(vaas) >= n {
+			break
+		}
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		b, err := d.GetSignedVAABytes(id)
+		if err == ErrVAANotFound || err == ErrVAACorrupt {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		vaas = append(vaas, b)
+	}
+	return vaas, nil
+}
+
 func (d *Database) FindEmitterSequenceGap(prefix VAAID) (resp []uint64, firstSeq uint64, lastSeq uint64, err error) {
 	resp = make([]uint64, 0)
 	if err = d.db.View(func(txn *badger.Txn) error {
@@ -194,3 +239,64 @@ func (d *Database) FindEmitterSequenceGap(prefix VAAID) (resp []uint64, firstSeq
 	}
 	return
 }
+
+// HasEmitterSignedVAAs returns true if any VAA is stored for the emitter of prefix. It only seeks to the first key of
+// the emitter, so it is cheap regardless of how many VAAs the emitter has.
+func (d *Database) HasEmitterSignedVAAs(prefix VAAID) (found bool, err error) {
+	err = d.db.View(func(txn *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.PrefetchValues = false
+		it := txn.NewIterator(opts)
+		defer it.Close()
+		prefix := append(prefix.EmitterPrefixBytes(), '/')
+
+		it.Seek(prefix)
+		found = it.ValidForPrefix(prefix)
+		return nil
+	})
+	return
+}
+
+// ForEachEmitterSignedVAAAfter calls fn, in sequence order, for every VAA stored for the emitter of prefix with a
+// sequence greater than afterSeq. It stops at the first error returned by fn.
+//
+// Sequences are stored in decimal, so keys sort lexicographically rather than numerically. Keys with the same number
+// of digits do sort numerically, so the emitter is iterated once per number of digits, reading values only for the
+// keys of that length. This keeps memory usage constant regardless of how many VAAs the emitter has.
+func (d *Database) ForEachEmitterSignedVAAAfter(prefix VAAID, afterSeq uint64, fn func(vaaBytes []byte) error) error {
+	if afterSeq == math.MaxUint64 {
+		return nil
+	}
+	first := strconv.FormatUint(afterSeq+1, 10)
+	maxLen := len(strconv.FormatUint(math.MaxUint64, 10))
+
+	return d.db.View(func(txn *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.PrefetchValues = false
+		it := txn.NewIterator(opts)
+		defer it.Close()
+		prefix := append(prefix.EmitterPrefixBytes(), '/')
+
+		for l := len(first); l <= maxLen; l++ {
+			start := first
+			if l > len(first) {
+				start = "1" + strings.Repeat("0", l-1)
+			}
+
+			for it.Seek(append(prefix[:len(prefix):len(prefix)], start...)); it.ValidForPrefix(prefix); it.Next() {
+				item := it.Item()
+				if len(item.Key())-len(prefix) != l {
+					continue
+				}
+				b, err := item.ValueCopy(nil)
+				if err != nil {
+					return err
+				}
+				if err := fn(b); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
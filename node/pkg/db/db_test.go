@@ -3,6 +3,8 @@ package db
 import (
 	"crypto/ecdsa"
 	"crypto/rand"
+	"errors"
+	"math"
 	"os"
 
 	"github.com/ethereum/go-ethereum/crypto"
@@ -12,6 +14,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func getVAA() vaa.VAA {
@@ -164,3 +167,125 @@ func TestFindEmitterSequenceGap(t *testing.T) {
 	assert.Equal(t, uint64(0x1), lastSeq)
 	assert.NoError(t, err)
 }
+
+func TestHasEmitterSignedVAAs(t *testing.T) {
+	dbPath := t.TempDir()
+	db, err := Open(dbPath)
+	if err != nil {
+		t.Error("failed to open database")
+	}
+	defer db.Close()
+	defer os.Remove(dbPath)
+
+	testVaa := getVAA()
+	vaaID := VaaIDFromVAA(&testVaa)
+
+	found, err := db.HasEmitterSignedVAAs(*vaaID)
+	assert.NoError(t, err)
+	assert.False(t, found)
+
+	privKey, _ := ecdsa.GenerateKey(crypto.S256(), rand.Reader)
+	testVaa.AddSignature(privKey, 0)
+	testVaa.Sequence = 42
+	assert.NoError(t, db.StoreSignedVAA(&testVaa))
+
+	found, err = db.HasEmitterSignedVAAs(*vaaID)
+	assert.NoError(t, err)
+	assert.True(t, found)
+
+	// The emitter prefix must not match emitters on chains sharing a prefix
+	otherChain := *vaaID
+	otherChain.EmitterChain = testVaa.EmitterChain * 10
+	found, err = db.HasEmitterSignedVAAs(otherChain)
+	assert.NoError(t, err)
+	assert.False(t, found)
+}
+
+func TestForEachEmitterSignedVAAAfter(t *testing.T) {
+	dbPath := t.TempDir()
+	db, err := Open(dbPath)
+	if err != nil {
+		t.Error("failed to open database")
+	}
+	defer db.Close()
+	defer os.Remove(dbPath)
+
+	testVaa := getVAA()
+	vaaID := VaaIDFromVAA(&testVaa)
+
+	privKey, _ := ecdsa.GenerateKey(crypto.S256(), rand.Reader)
+	testVaa.AddSignature(privKey, 0)
+
+	// Stored out of order, and spanning several lengths so that the lexicographic key order differs from the sequence
+	// order.
+	for _, seq := range []uint64{42, 8, 100, 9, 10, 1000, 11, math.MaxUint64} {
+		testVaa.Sequence = seq
+		assert.NoError(t, db.StoreSignedVAA(&testVaa))
+	}
+
+	sequencesAfter := func(afterSeq uint64) []uint64 {
+		var seqs []uint64
+		assert.NoError(t, db.ForEachEmitterSignedVAAAfter(*vaaID, afterSeq, func(b []byte) error {
+			v, err := vaa.Unmarshal(b)
+			assert.NoError(t, err)
+			seqs = append(seqs, v.Sequence)
+			return nil
+		}))
+		return seqs
+	}
+
+	assert.Equal(t, []uint64{9, 10, 11, 42, 100, 1000, math.MaxUint64}, sequencesAfter(8))
+	assert.Equal(t, []uint64{11, 42, 100, 1000, math.MaxUint64}, sequencesAfter(10))
+	assert.Equal(t, []uint64{math.MaxUint64}, sequencesAfter(1000))
+	assert.Empty(t, sequencesAfter(math.MaxUint64))
+
+	// Iteration stops at the first error.
+	errStop := errors.New("stop")
+	calls := 0
+	err = db.ForEachEmitterSignedVAAAfter(*vaaID, 0, func([]byte) error {
+		calls++
+		return errStop
+	})
+	assert.ErrorIs(t, err, errStop)
+	assert.Equal(t, 1, calls)
+}
+
+func TestRecentSignedVAAs(t *testing.T) {
+	db, err := Open(t.TempDir())
+	require.NoError(t, err)
+	defer db.Close()
+
+	privKey, _ := ecdsa.GenerateKey(crypto.S256(), rand.Reader)
+	store := func(seq uint64) {
+		v := getVAA()
+		v.Sequence = seq
+		v.AddSignature(privKey, 0)
+		require.NoError(t, db.StoreSignedVAA(&v))
+	}
+	sequences := func(vaas [][]byte) []uint64 {
+		seqs := make([]uint64, 0, len(vaas))
+		for _, b := range vaas {
+			v, err := vaa.Unmarshal(b)
+			require.NoError(t, err)
+			seqs = append(seqs, v.Sequence)
+		}
+		return seqs
+	}
+
+	for seq := uint64(1); seq <= RecentVAAsSize+5; seq++ {
+		store(seq)
+	}
+	// Storing a VAA again makes it the most recent one without duplicating it
+	store(RecentVAAsSize)
+
+	vaas, err := db.RecentSignedVAAs(3)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{RecentVAAsSize, RecentVAAsSize + 5, RecentVAAsSize + 4}, sequences(vaas))
+
+	// Only the last RecentVAAsSize stores are remembered
+	vaas, err = db.RecentSignedVAAs(2 * RecentVAAsSize)
+	require.NoError(t, err)
+	seqs := sequences(vaas)
+	assert.Len(t, seqs, RecentVAAsSize-1)
+	assert.Equal(t, uint64(7), seqs[len(seqs)-1])
+}
@@ -2,8 +2,11 @@ package db
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/certusone/wormhole/node/pkg/common"
 	"github.com/dgraph-io/badger/v3"
 	"github.com/wormhole-foundation/wormhole/sdk/vaa"
 )
@@ -78,3 +81,114 @@ func (d *Database) PurgeVaas(prefix VAAID, oldestTime time.Time, logOnly bool) (
 
 	return ret, nil
 }
+
+// prunedKey returns the key storing the highest pruned sequence of the emitter of id.
+func prunedKey(id VAAID) []byte {
+	return []byte(fmt.Sprintf("pruned/%d/%s", id.EmitterChain, id.EmitterAddress))
+}
+
+// PrunedUpTo returns the highest sequence of the emitter of id that PruneVAAs deleted, or false if it deleted none.
+func (d *Database) PrunedUpTo(id VAAID) (seq uint64, found bool, err error) {
+	err = d.db.View(func(txn *badger.Txn) error {
+		item, err := txn.Get(prunedKey(id))
+		if err == badger.ErrKeyNotFound {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		return item.Value(func(val []byte) error {
+			seq, err = strconv.ParseUint(string(val), 10, 64)
+			found = err == nil
+			return err
+		})
+	})
+	return
+}
+
+// PruneVAAs deletes the VAAs that are older than the max age retention configures for their emitter, and returns the
+// number of deleted VAAs per chain. Only the values of emitters that are not kept forever are read. The highest
+// deleted sequence of each emitter is recorded, so that PrunedUpTo can tell pruned VAAs from missing ones.
+func (d *Database) PruneVAAs(retention *common.VAARetention, now time.Time) (map[vaa.ChainID]int, error) {
+	deleted := make(map[vaa.ChainID]int)
+	prunedUpTo := make(map[VAAID]uint64)
+
+	wb := d.db.NewWriteBatch()
+	defer wb.Cancel()
+
+	for _, chainID := range retention.Chains() {
+		err := d.db.View(func(txn *badger.Txn) error {
+			opts := badger.DefaultIteratorOptions
+			opts.PrefetchValues = false
+			it := txn.NewIterator(opts)
+			defer it.Close()
+			prefix := []byte(fmt.Sprintf("signed/%d/", chainID))
+
+			for it.Seek(prefix); it.ValidForPrefix(prefix); it.Next() {
+				item := it.Item()
+				key := item.KeyCopy(nil)
+
+				// Keys are signed/<chain>/<emitter>/<sequence>
+				parts := strings.Split(string(key[len(prefix):]), "/")
+				if len(parts) != 2 {
+					continue
+				}
+				addr, err := vaa.StringToAddress(parts[0])
+				if err != nil {
+					continue
+				}
+				seq, err := strconv.ParseUint(parts[1], 10, 64)
+				if err != nil {
+					continue
+				}
+				maxAge, pruned := retention.MaxAge(chainID, addr)
+				if !pruned {
+					continue
+				}
+
+				val, err := item.ValueCopy(nil)
+				if err != nil {
+					return err
+				}
+				v, err := vaa.Unmarshal(val)
+				if err != nil {
+					return fmt.Errorf("failed to unmarshal VAA for %s: %v", string(key), err)
+				}
+				if !v.Timestamp.Before(now.Add(-maxAge)) {
+					continue
+				}
+
+				if err := wb.Delete(key); err != nil {
+					return fmt.Errorf("failed to delete vaa for key [%v]: %w", string(key), err)
+				}
+				deleted[chainID]++
+				emitter := VAAID{EmitterChain: chainID, EmitterAddress: addr}
+				if seq > prunedUpTo[emitter] {
+					prunedUpTo[emitter] = seq
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for emitter, seq := range prunedUpTo {
+		prev, found, err := d.PrunedUpTo(emitter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read pruned sequence: %w", err)
+		}
+		if found && prev >= seq {
+			continue
+		}
+		if err := wb.Set(prunedKey(emitter), []byte(strconv.FormatUint(seq, 10))); err != nil {
+			return nil, fmt.Errorf("failed to record pruned sequence: %w", err)
+		}
+	}
+
+	if err := wb.Flush(); err != nil {
+		return nil, fmt.Errorf("failed to commit deletions: %w", err)
+	}
+	return deleted, nil
+}
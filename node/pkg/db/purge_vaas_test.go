@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/certusone/wormhole/node/pkg/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/wormhole-foundation/wormhole/sdk/vaa"
 
@@ -29,7 +30,8 @@ func countVAAs(d *Database, chainId vaa.ChainID) (numThisChain int, numOtherChai
 		opts.PrefetchSize = 10
 		it := txn.NewIterator(opts)
 		defer it.Close()
-		for it.Rewind(); it.Valid(); it.Next() {
+		prefix := []byte("signed/")
+		for it.Seek(prefix); it.ValidForPrefix(prefix); it.Next() {
 			item := it.Item()
 			key := item.Key()
 			err := item.Value(func(val []byte) error {
@@ -295,3 +297,70 @@ func TestPurgingVAAsForOneEmitterAddress(t *testing.T) {
 	assert.Equal(t, 200, numPythnet)
 	assert.Equal(t, 125, numOther)
 }
+
+func TestPruneVAAs(t *testing.T) {
+	pyth := vaa.Address{31: 1}
+	tokenBridge := vaa.Address{31: 2}
+	other := vaa.Address{31: 3}
+
+	db, err := Open(t.TempDir())
+	require.NoError(t, err)
+	defer db.Close()
+
+	now := time.Now()
+	seq := uint64(0)
+	store := func(chainID vaa.ChainID, emitter vaa.Address, age time.Duration) {
+		seq++
+		require.NoError(t, storeVAA(db, &vaa.VAA{
+			Version:          uint8(1),
+			GuardianSetIndex: uint32(1),
+			Timestamp:        now.Add(-age),
+			Sequence:         seq,
+			ConsistencyLevel: uint8(32),
+			EmitterChain:     chainID,
+			EmitterAddress:   emitter,
+			Payload:          []byte{97},
+		}))
+	}
+
+	for _, age := range []time.Duration{time.Hour, 23 * time.Hour, 25 * time.Hour, 48 * time.Hour} {
+		store(vaa.ChainIDPythNet, pyth, age)
+		store(vaa.ChainIDSolana, tokenBridge, age)
+		store(vaa.ChainIDSolana, other, age)
+		// Chain 2 shares the key prefix of chain 26 without the separator
+		store(vaa.ChainIDEthereum, other, age)
+	}
+
+	retention, err := common.ParseVAARetention("pythnet=24h,solana=36h,solana:" + tokenBridge.String() + "=0")
+	require.NoError(t, err)
+
+	deleted, err := db.PruneVAAs(retention, now)
+	require.NoError(t, err)
+	assert.Equal(t, map[vaa.ChainID]int{vaa.ChainIDPythNet: 2, vaa.ChainIDSolana: 1}, deleted)
+
+	numPythnet, numOther, err := countVAAs(db, vaa.ChainIDPythNet)
+	require.NoError(t, err)
+	assert.Equal(t, 2, numPythnet)
+	assert.Equal(t, 11, numOther)
+
+	numSolana, _, err := countVAAs(db, vaa.ChainIDSolana)
+	require.NoError(t, err)
+	assert.Equal(t, 7, numSolana)
+
+	// The highest deleted sequence is recorded per emitter
+	seq, found, err := db.PrunedUpTo(VAAID{EmitterChain: vaa.ChainIDPythNet, EmitterAddress: pyth})
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, uint64(13), seq)
+	_, found, err = db.PrunedUpTo(VAAID{EmitterChain: vaa.ChainIDSolana, EmitterAddress: tokenBridge})
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	// Pruning again deletes nothing
+	deleted, err = db.PruneVAAs(retention, now)
+	require.NoError(t, err)
+	assert.Empty(t, deleted)
+	seq, _, err = db.PrunedUpTo(VAAID{EmitterChain: vaa.ChainIDPythNet, EmitterAddress: pyth})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(13), seq)
+}
@@ -0,0 +1,102 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dgraph-io/badger/v3"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+var (
+	// ErrVAACorrupt is returned for stored VAAs that failed verification.
+	ErrVAACorrupt = errors.New("requested VAA is corrupt")
+	// ErrVAAUnverifiable can be returned by the verifier passed to VerifySignedVAAs for VAAs it cannot check, e.g.
+	// because their guardian set is unknown. Such VAAs are skipped rather than marked as corrupt.
+	ErrVAAUnverifiable = errors.New("VAA cannot be verified")
+)
+
+// VerifyResult summarizes a VerifySignedVAAs run.
+type VerifyResult struct {
+	// ChecksumErr is the error returned by the Badger table checksum verification, if any.
+	ChecksumErr error
+	// Verified, Skipped and Corrupt count the sampled VAAs by outcome.
+	Verified int
+	Skipped  int
+	Corrupt  int
+}
+
+// VerifySignedVAAs verifies the Badger table checksums and every sampleEvery-th stored signed VAA (all of them if
+// sampleEvery is 1). A sampled entry is corrupt if it cannot be read or unmarshaled, is stored under a key that does
+// not match its ID, or is rejected by verify. Corrupt entries are no longer served by GetSignedVAABytes until they are
+// stored again.
+func (d *Database) VerifySignedVAAs(sampleEvery uint64, verify func(v *vaa.VAA) error) (VerifyResult, error) {
+	if sampleEvery == 0 {
+		return VerifyResult{}, fmt.Errorf("sampleEvery must be at least 1")
+	}
+
+	res := VerifyResult{ChecksumErr: d.db.VerifyChecksum()}
+	var corrupt [][]byte
+
+	err := d.db.View(func(txn *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.PrefetchValues = false
+		it := txn.NewIterator(opts)
+		defer it.Close()
+		prefix := []byte("signed/")
+
+		var n uint64
+		for it.Seek(prefix); it.ValidForPrefix(prefix); it.Next() {
+			n++
+			if (n-1)%sampleEvery != 0 {
+				continue
+			}
+
+			item := it.Item()
+			key := item.KeyCopy(nil)
+			switch err := verifyEntry(item, key, verify); {
+			case err == nil:
+				res.Verified++
+			case errors.Is(err, ErrVAAUnverifiable):
+				res.Skipped++
+			default:
+				res.Corrupt++
+				corrupt = append(corrupt, key)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return res, err
+	}
+
+	d.corruptMu.Lock()
+	for _, key := range corrupt {
+		d.corrupt[string(key)] = true
+	}
+	d.corruptMu.Unlock()
+
+	return res, nil
+}
+
+func verifyEntry(item *badger.Item, key []byte, verify func(v *vaa.VAA) error) error {
+	b, err := item.ValueCopy(nil)
+	if err != nil {
+		return err
+	}
+	v, err := vaa.Unmarshal(b)
+	if err != nil {
+		return err
+	}
+	if string(VaaIDFromVAA(v).Bytes()) != string(key) {
+		return fmt.Errorf("VAA %s stored under key %s", v.MessageID(), string(key))
+	}
+	return verify(v)
+}
+
+// isCorrupt returns true if the VAA stored under key failed verification.
+func (d *Database) isCorrupt(key []byte) bool {
+	d.corruptMu.RLock()
+	defer d.corruptMu.RUnlock()
+	return d.corrupt[string(key)]
+}
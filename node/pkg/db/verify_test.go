@@ -0,0 +1,99 @@
+package db
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/dgraph-io/badger/v3"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+func TestVerifySignedVAAs(t *testing.T) {
+	db, err := Open(t.TempDir())
+	require.NoError(t, err)
+	defer db.Close()
+
+	guardianKey, _ := ecdsa.GenerateKey(crypto.S256(), rand.Reader)
+	otherKey, _ := ecdsa.GenerateKey(crypto.S256(), rand.Reader)
+	keys := []common.Address{crypto.PubkeyToAddress(guardianKey.PublicKey)}
+
+	store := func(seq uint64, guardianSetIndex uint32, key *ecdsa.PrivateKey) *vaa.VAA {
+		v := getVAA()
+		v.Sequence = seq
+		v.GuardianSetIndex = guardianSetIndex
+		v.AddSignature(key, 0)
+		require.NoError(t, db.StoreSignedVAA(&v))
+		return &v
+	}
+	valid := store(1, 1, guardianKey)
+	store(2, 2, guardianKey)
+	forged := store(3, 1, otherKey)
+
+	// An entry that is not a VAA
+	garbage := VAAID{EmitterChain: vaa.ChainIDEthereum, Sequence: 1}
+	require.NoError(t, db.db.Update(func(txn *badger.Txn) error {
+		return txn.Set(garbage.Bytes(), []byte{1, 2, 3})
+	}))
+
+	verify := func(v *vaa.VAA) error {
+		if v.GuardianSetIndex != 1 {
+			return ErrVAAUnverifiable
+		}
+		if !v.VerifySignatures(keys) {
+			return errors.New("invalid signatures")
+		}
+		return nil
+	}
+
+	res, err := db.VerifySignedVAAs(1, verify)
+	require.NoError(t, err)
+	assert.NoError(t, res.ChecksumErr)
+	assert.Equal(t, 1, res.Verified)
+	assert.Equal(t, 1, res.Skipped)
+	assert.Equal(t, 2, res.Corrupt)
+
+	// Corrupt entries are no longer served
+	_, err = db.GetSignedVAABytes(*VaaIDFromVAA(valid))
+	assert.NoError(t, err)
+	_, err = db.GetSignedVAABytes(*VaaIDFromVAA(forged))
+	assert.ErrorIs(t, err, ErrVAACorrupt)
+	_, err = db.GetSignedVAABytes(garbage)
+	assert.ErrorIs(t, err, ErrVAACorrupt)
+
+	// ...until they are replaced
+	store(3, 1, guardianKey)
+	_, err = db.GetSignedVAABytes(*VaaIDFromVAA(forged))
+	assert.NoError(t, err)
+}
+
+func TestVerifySignedVAAsSample(t *testing.T) {
+	db, err := Open(t.TempDir())
+	require.NoError(t, err)
+	defer db.Close()
+
+	key, _ := ecdsa.GenerateKey(crypto.S256(), rand.Reader)
+	for seq := uint64(1); seq <= 5; seq++ {
+		v := getVAA()
+		v.Sequence = seq
+		v.AddSignature(key, 0)
+		require.NoError(t, db.StoreSignedVAA(&v))
+	}
+
+	var checked int
+	res, err := db.VerifySignedVAAs(2, func(v *vaa.VAA) error {
+		checked++
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, checked)
+	assert.Equal(t, 3, res.Verified)
+
+	_, err = db.VerifySignedVAAs(0, func(v *vaa.VAA) error { return nil })
+	assert.Error(t, err)
+}
@@ -0,0 +1,178 @@
+//go:build e2e
+// +build e2e
+
+// Package e2e contains an end-to-end test harness that runs a single-guardian devnet against a wormhole chain node.
+// The guardian runs in-process and uses the production processor to sign observations with the devnet guardian key,
+// while the chain runs in a docker container built from Dockerfile.wormchain.
+//
+// The tests are excluded from regular builds and are run with:
+//
+//	docker build -f Dockerfile.wormchain -t wormhole-chaind-image .
+//	cd node && go test -tags e2e -v ./pkg/e2e/...
+package e2e
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"testing"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	"github.com/certusone/wormhole/node/pkg/db"
+	"github.com/certusone/wormhole/node/pkg/devnet"
+	"github.com/certusone/wormhole/node/pkg/processor"
+	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
+	"github.com/certusone/wormhole/node/pkg/reporter"
+	"github.com/certusone/wormhole/node/pkg/supervisor"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+// vaaTimeout is how long to wait for the guardian to reach quorum on a message.
+const vaaTimeout = 30 * time.Second
+
+// Guardian is a single devnet guardian. Messages published to it stand in for the observations of a chain watcher.
+type Guardian struct {
+	t   testing.TB
+	Key *ecdsa.PrivateKey
+
+	lockC   chan *common.MessagePublication
+	injectC chan *vaa.VAA
+	signed  <-chan *vaa.VAA
+}
+
+// StartGuardian starts the processor of guardian 0 of the devnet guardian set, which is the guardian set in the
+// wormhole chain devnet genesis. It is stopped when the test finishes.
+func StartGuardian(t testing.TB) *Guardian {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	// The processor outlives the test by a little, so it must not log through t.
+	logger, err := zap.NewDevelopment()
+	if err != nil {
+		t.Fatalf("failed to create logger: %v", err)
+	}
+
+	database, err := db.Open(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	t.Cleanup(func() { database.Close() })
+
+	g := &Guardian{
+		t:       t,
+		Key:     devnet.InsecureDeterministicEcdsaKeyByIndex(crypto.S256(), 0),
+		lockC:   make(chan *common.MessagePublication),
+		injectC: make(chan *vaa.VAA),
+	}
+
+	attestationEvents := reporter.EventListener(logger)
+	sub := attestationEvents.Subscribe()
+	t.Cleanup(func() { attestationEvents.Unsubscribe(sub.ClientId) })
+	g.signed = sub.Channels.VAAQuorumC
+
+	setC := make(chan *common.GuardianSet)
+	sendC := make(chan []byte)
+	obsvC := make(chan *gossipv1.SignedObservation, 50)
+	obsvReqSendC := make(chan *gossipv1.ObservationRequest)
+	signedInC := make(chan *gossipv1.SignedVAAWithQuorum)
+
+	// There is no p2p network, so whatever the processor gossips is dropped.
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-sendC:
+			case <-obsvReqSendC:
+			}
+		}
+	}()
+
+	supervisor.New(ctx, logger, func(ctx context.Context) error {
+		p := processor.NewProcessor(ctx,
+			database,
+			g.lockC,
+			setC,
+			sendC,
+			nil,
+			obsvC,
+			nil,
+			obsvReqSendC,
+			g.injectC,
+			signedInC,
+			common.NewGuardianKeyState(g.Key, nil),
+			common.NewGuardianSetState(),
+			false,
+			0,
+			"",
+			"",
+			attestationEvents,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+		)
+		if err := supervisor.Run(ctx, "processor", p.Run); err != nil {
+			return err
+		}
+		supervisor.Signal(ctx, supervisor.SignalHealthy)
+		<-ctx.Done()
+		return nil
+	}, supervisor.WithPropagatePanic)
+
+	select {
+	case setC <- &common.GuardianSet{Keys: []ethcommon.Address{crypto.PubkeyToAddress(g.Key.PublicKey)}, Index: 0}:
+	case <-time.After(vaaTimeout):
+		t.Fatal("processor did not accept the guardian set")
+	}
+
+	return g
+}
+
+// Publish hands a message to the guardian as if a watcher had observed it and returns the signed VAA.
+func (g *Guardian) Publish(msg *common.MessagePublication) *vaa.VAA {
+	g.t.Helper()
+
+	select {
+	case g.lockC <- msg:
+	case <-time.After(vaaTimeout):
+		g.t.Fatal("processor did not accept the message")
+	}
+	return g.waitForVAA(msg.EmitterChain, msg.EmitterAddress, msg.Sequence)
+}
+
+// Inject has the guardian sign v like an injected governance VAA and returns the signed VAA.
+func (g *Guardian) Inject(v *vaa.VAA) *vaa.VAA {
+	g.t.Helper()
+
+	select {
+	case g.injectC <- v:
+	case <-time.After(vaaTimeout):
+		g.t.Fatal("processor did not accept the injected VAA")
+	}
+	return g.waitForVAA(v.EmitterChain, v.EmitterAddress, v.Sequence)
+}
+
+func (g *Guardian) waitForVAA(chain vaa.ChainID, emitter vaa.Address, sequence uint64) *vaa.VAA {
+	g.t.Helper()
+
+	timeout := time.After(vaaTimeout)
+	for {
+		select {
+		case v := <-g.signed:
+			if v.EmitterChain == chain && v.EmitterAddress == emitter && v.Sequence == sequence {
+				return v
+			}
+		case <-timeout:
+			g.t.Fatalf("no quorum on %d/%s/%d", chain, emitter, sequence)
+			return nil
+		}
+	}
+}
@@ -0,0 +1,137 @@
+//go:build e2e
+// +build e2e
+
+package e2e
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+var (
+	// tokenBridgeModule is "TokenBridge" left-padded to 32 bytes.
+	tokenBridgeModule = [32]byte{21: 'T', 'o', 'k', 'e', 'n', 'B', 'r', 'i', 'd', 'g', 'e'}
+
+	// ethTokenBridge is the devnet token bridge on Ethereum.
+	ethTokenBridge = vaa.Address{12: 0x02, 0x90, 0xfb, 0x16, 0x72, 0x08, 0xaf, 0x45, 0x5b, 0xb1, 0x37, 0x78, 0x01, 0x63, 0xb7, 0xb7, 0xa9, 0xa1, 0x0c, 0x16}
+
+	// ethToken is an arbitrary ERC-20 on the devnet.
+	ethToken = vaa.Address{12: 0xde, 0xad, 0xbe, 0xef}
+
+	// recipient is the second funded account of the wormhole chain devnet genesis.
+	recipient    = ethcommon.HexToAddress("0x701c475b19a3f68d3fdebf09591487facef2d636")
+	recipientAcc = "wormhole1wqwywkce50mg6077huy4j9y8lt80943ks5udzr"
+)
+
+func registerChainVAA(chain vaa.ChainID, emitter vaa.Address) *vaa.VAA {
+	payload := make([]byte, 69)
+	copy(payload[:32], tokenBridgeModule[:])
+	payload[32] = 1 // RegisterChain
+	// Bytes 33-34 are the target chain, which is zero for all chains.
+	binary.BigEndian.PutUint16(payload[35:37], uint16(chain))
+	copy(payload[37:69], emitter[:])
+
+	return &vaa.VAA{
+		Version:          vaa.SupportedVAAVersion,
+		Timestamp:        time.Now(),
+		Nonce:            1,
+		Sequence:         uint64(time.Now().UnixNano()),
+		ConsistencyLevel: 32,
+		EmitterChain:     vaa.GovernanceChain,
+		EmitterAddress:   vaa.GovernanceEmitter,
+		Payload:          payload,
+	}
+}
+
+func assetMetaPayload(token vaa.Address, chain vaa.ChainID, decimals uint8, symbol, name string) []byte {
+	payload := make([]byte, 100)
+	payload[0] = 2 // AssetMeta
+	copy(payload[1:33], token[:])
+	binary.BigEndian.PutUint16(payload[33:35], uint16(chain))
+	payload[35] = decimals
+	copy(payload[36:68], symbol)
+	copy(payload[68:100], name)
+	return payload
+}
+
+func transferPayload(amount *big.Int, token vaa.Address, chain vaa.ChainID, to ethcommon.Address, toChain vaa.ChainID) []byte {
+	payload := make([]byte, 133)
+	payload[0] = 1 // Transfer
+	amount.FillBytes(payload[1:33])
+	copy(payload[33:65], token[:])
+	binary.BigEndian.PutUint16(payload[65:67], uint16(chain))
+	copy(payload[79:99], to.Bytes())
+	binary.BigEndian.PutUint16(payload[99:101], uint16(toChain))
+	return payload
+}
+
+func ethMessage(sequence uint64, payload []byte) *common.MessagePublication {
+	return &common.MessagePublication{
+		TxHash:           ethcommon.BigToHash(new(big.Int).SetUint64(sequence)),
+		Timestamp:        time.Now(),
+		Nonce:            uint32(sequence),
+		Sequence:         sequence,
+		ConsistencyLevel: 1,
+		EmitterChain:     vaa.ChainIDEthereum,
+		EmitterAddress:   ethTokenBridge,
+		Payload:          payload,
+	}
+}
+
+// TestTransferToWormchain registers the Ethereum token bridge, attests an ERC-20 and redeems a transfer of it on
+// wormhole chain, with every VAA signed by the guardian.
+func TestTransferToWormchain(t *testing.T) {
+	chain := StartWormchain(t)
+	guardian := StartGuardian(t)
+	ctx := context.Background()
+
+	registration := guardian.Inject(registerChainVAA(vaa.ChainIDEthereum, ethTokenBridge))
+	require.NoError(t, chain.ExecuteGovernanceVAA(registration))
+
+	attestation := guardian.Publish(ethMessage(1, assetMetaPayload(ethToken, vaa.ChainIDEthereum, 8, "E2E", "End-to-end token")))
+	require.NoError(t, chain.ExecuteVAA(attestation))
+
+	amount := big.NewInt(123456789)
+	transfer := guardian.Publish(ethMessage(2, transferPayload(amount, ethToken, vaa.ChainIDEthereum, recipient, vaa.ChainIDWormchain)))
+	require.Len(t, transfer.Signatures, 1)
+
+	// Clients can be notified of the redemption by recipient as well as by VAA digest.
+	subCtx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+	byRecipient, err := chain.Subscribe(subCtx, fmt.Sprintf("tm.event='Tx' AND transfer_redeemed.recipient='%s'", recipientAcc))
+	require.NoError(t, err)
+	byDigest, err := chain.Subscribe(subCtx, fmt.Sprintf("tm.event='Tx' AND transfer_redeemed.vaa_digest='%s'", transfer.HexDigest()))
+	require.NoError(t, err)
+
+	require.NoError(t, chain.ExecuteVAA(transfer))
+
+	denom := fmt.Sprintf("bwh/%05d/%x", uint16(vaa.ChainIDEthereum), ethToken.Bytes())
+	for _, eventsC := range []<-chan map[string][]string{byRecipient, byDigest} {
+		events, ok := <-eventsC
+		require.True(t, ok, "subscription ended without a redemption")
+		assert.Equal(t, []string{transfer.HexDigest()}, events["transfer_redeemed.vaa_digest"])
+		assert.Equal(t, []string{recipientAcc}, events["transfer_redeemed.recipient"])
+		assert.Equal(t, []string{denom}, events["transfer_redeemed.denom"])
+		assert.Equal(t, []string{amount.String()}, events["transfer_redeemed.amount"])
+		assert.Equal(t, []string{"0"}, events["transfer_redeemed.fee"])
+	}
+	balance, err := chain.Balance(ctx, recipientAcc, denom)
+	require.NoError(t, err)
+	assert.Equal(t, amount.String(), balance.String())
+
+	// Redeeming the same VAA twice must fail and leave the balance unchanged.
+	assert.Error(t, chain.ExecuteVAA(transfer))
+	balance, err = chain.Balance(ctx, recipientAcc, denom)
+	require.NoError(t, err)
+	assert.Equal(t, amount.String(), balance.String())
+}
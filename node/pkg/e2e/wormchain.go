@@ -0,0 +1,270 @@
+//go:build e2e
+// +build e2e
+
+package e2e
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+const (
+	// defaultWormchainImage is the image built by Tilt from Dockerfile.wormchain.
+	defaultWormchainImage = "wormhole-chaind-image"
+
+	// wormchainHome is the home of the devnet validator inside the image. Its keyring holds the funded tiltGuardian key.
+	wormchainHome = "/app/validators/first_validator"
+	wormchainBin  = "/app/build/wormhole-chaind"
+
+	wormchainStartupTimeout = 2 * time.Minute
+)
+
+// Wormchain is a single-validator wormhole chain devnet running in a docker container.
+type Wormchain struct {
+	t         testing.TB
+	container string
+
+	// RPC is the tendermint RPC endpoint and LCD the REST endpoint of the node, both reachable from the host.
+	RPC string
+	LCD string
+}
+
+// StartWormchain starts a wormhole chain node from the image named by $WORMCHAIN_IMAGE and waits for it to produce
+// blocks. The container is removed when the test finishes.
+func StartWormchain(t testing.TB) *Wormchain {
+	t.Helper()
+
+	image := os.Getenv("WORMCHAIN_IMAGE")
+	if image == "" {
+		image = defaultWormchainImage
+	}
+
+	out, err := docker("run", "-d", "--rm",
+		"-p", "127.0.0.1::26657",
+		"-p", "127.0.0.1::1317",
+		"--entrypoint", wormchainBin,
+		image,
+		"start", "--home", wormchainHome)
+	if err != nil {
+		t.Fatalf("failed to start wormchain container from %s: %v", image, err)
+	}
+	c := &Wormchain{t: t, container: strings.TrimSpace(string(out))}
+	t.Cleanup(func() {
+		if t.Failed() {
+			if logs, err := docker("logs", "--tail", "100", c.container); err == nil {
+				t.Logf("wormchain logs:\n%s", logs)
+			}
+		}
+		_, _ = docker("rm", "-f", c.container)
+	})
+
+	c.RPC = "http://" + c.hostPort(t, "26657/tcp")
+	c.LCD = "http://" + c.hostPort(t, "1317/tcp")
+
+	ctx, cancel := context.WithTimeout(context.Background(), wormchainStartupTimeout)
+	defer cancel()
+	if err := c.waitForBlocks(ctx); err != nil {
+		t.Fatalf("wormchain did not start: %v", err)
+	}
+	return c
+}
+
+func docker(args ...string) ([]byte, error) {
+	var stderr bytes.Buffer
+	cmd := exec.Command("docker", args...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("docker %s: %w: %s", args[0], err, stderr.String())
+	}
+	return out, nil
+}
+
+func (c *Wormchain) hostPort(t testing.TB, port string) string {
+	out, err := docker("port", c.container, port)
+	if err != nil {
+		t.Fatalf("failed to look up published port %s: %v", port, err)
+	}
+	// Only the first line is of interest if the port is published on several interfaces.
+	return strings.TrimSpace(strings.SplitN(string(out), "\n", 2)[0])
+}
+
+func (c *Wormchain) waitForBlocks(ctx context.Context) error {
+	for {
+		var status struct {
+			Block struct {
+				Header struct {
+					Height string `json:"height"`
+				} `json:"header"`
+			} `json:"block"`
+		}
+		err := c.getJSON(ctx, "/blocks/latest", &status)
+		if err == nil && status.Block.Header.Height != "" && status.Block.Header.Height != "0" {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w (last error: %v)", ctx.Err(), err)
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+func (c *Wormchain) getJSON(ctx context.Context, path string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.LCD+path, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: %s", path, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// tx broadcasts a transaction signed by the tiltGuardian key and waits for it to be included in a block.
+func (c *Wormchain) tx(args ...string) error {
+	args = append(append([]string{"exec", c.container, wormchainBin, "tx"}, args...),
+		"--from", "tiltGuardian",
+		"--home", wormchainHome,
+		"--broadcast-mode", "block",
+		"--output", "json",
+		"--yes")
+	out, err := docker(args...)
+	if err != nil {
+		return err
+	}
+
+	var res struct {
+		Code   uint32 `json:"code"`
+		RawLog string `json:"raw_log"`
+		TxHash string `json:"txhash"`
+	}
+	if err := json.Unmarshal(out, &res); err != nil {
+		return fmt.Errorf("failed to decode tx result %q: %w", out, err)
+	}
+	if res.Code != 0 {
+		return fmt.Errorf("tx %s failed with code %d: %s", res.TxHash, res.Code, res.RawLog)
+	}
+	return nil
+}
+
+// ExecuteVAA submits v in a MsgExecuteVAA to the token bridge.
+func (c *Wormchain) ExecuteVAA(v *vaa.VAA) error {
+	b, err := v.Marshal()
+	if err != nil {
+		return err
+	}
+	return c.tx("tokenbridge", "execute-vaa", hex.EncodeToString(b))
+}
+
+// ExecuteGovernanceVAA submits v in a MsgExecuteGovernanceVAA to the token bridge.
+func (c *Wormchain) ExecuteGovernanceVAA(v *vaa.VAA) error {
+	b, err := v.Marshal()
+	if err != nil {
+		return err
+	}
+	return c.tx("tokenbridge", "execute-governance-vaa", hex.EncodeToString(b))
+}
+
+// Balance returns the balance of denom held by the bech32 address.
+func (c *Wormchain) Balance(ctx context.Context, address string, denom string) (*big.Int, error) {
+	var res struct {
+		Balance struct {
+			Amount string `json:"amount"`
+		} `json:"balance"`
+	}
+	path := fmt.Sprintf("/cosmos/bank/v1beta1/balances/%s/by_denom?denom=%s", address, url.QueryEscape(denom))
+	if err := c.getJSON(ctx, path, &res); err != nil {
+		return nil, err
+	}
+	amount, ok := new(big.Int).SetString(res.Balance.Amount, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid amount %q", res.Balance.Amount)
+	}
+	return amount, nil
+}
+
+// Subscribe subscribes to the transactions matching the tendermint event query through the websocket of the RPC
+// endpoint. The events of each matching transaction are delivered keyed by "<type>.<attribute key>". The subscription
+// ends when ctx is done.
+func (c *Wormchain) Subscribe(ctx context.Context, query string) (<-chan map[string][]string, error) {
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, "ws"+strings.TrimPrefix(c.RPC, "http")+"/websocket", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	type response struct {
+		Result struct {
+			Events map[string][]string `json:"events"`
+		} `json:"result"`
+		Error *struct {
+			Message string `json:"message"`
+			Data    string `json:"data"`
+		} `json:"error"`
+	}
+
+	err = conn.WriteJSON(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"method":  "subscribe",
+		"id":      1,
+		"params":  map[string]string{"query": query},
+	})
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	var ack response
+	if err := conn.ReadJSON(&ack); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if ack.Error != nil {
+		conn.Close()
+		return nil, fmt.Errorf("subscribe to %q: %s: %s", query, ack.Error.Message, ack.Error.Data)
+	}
+
+	go func() {
+		<-ctx.Done()
+		conn.Close()
+	}()
+
+	eventsC := make(chan map[string][]string)
+	go func() {
+		defer close(eventsC)
+		for {
+			var res response
+			if err := conn.ReadJSON(&res); err != nil {
+				return
+			}
+			if res.Result.Events == nil {
+				continue
+			}
+			select {
+			case eventsC <- res.Result.Events:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return eventsC, nil
+}
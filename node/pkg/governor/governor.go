@@ -225,27 +225,19 @@ func (gov *ChainGovernor) initConfig() error {
 		return fmt.Errorf("no tokens are configured")
 	}
 
-	emitterMap := &sdk.KnownTokenbridgeEmitters
+	addressBook := sdk.MustGetAddressBook(sdk.MainNet)
 	if gov.env == TestNetMode {
-		emitterMap = &sdk.KnownTestnetTokenbridgeEmitters
+		addressBook = sdk.MustGetAddressBook(sdk.TestNet)
 	} else if gov.env == DevNetMode {
-		emitterMap = &sdk.KnownDevnetTokenbridgeEmitters
+		addressBook = sdk.MustGetAddressBook(sdk.DevNet)
 	}
 
 	for _, cc := range configChains {
-		var emitterAddr vaa.Address
-		var err error
-
-		emitterAddrBytes, exists := (*emitterMap)[cc.emitterChainID]
+		emitterAddr, exists := addressBook.Emitter(cc.emitterChainID, sdk.EmitterTokenBridge)
 		if !exists {
 			return fmt.Errorf("failed to look up token bridge emitter address for chain: %v", cc.emitterChainID)
 		}
 
-		emitterAddr, err = vaa.BytesToAddress(emitterAddrBytes)
-		if err != nil {
-			return fmt.Errorf("failed to convert emitter address for chain: %v", cc.emitterChainID)
-		}
-
 		ce := &chainEntry{
 			emitterChainId:          cc.emitterChainID,
 			emitterAddr:             emitterAddr,
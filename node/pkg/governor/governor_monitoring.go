@@ -147,13 +147,18 @@ func (gov *ChainGovernor) Reload() (string, error) {
 
 // Admin command to remove a VAA from the pending list and discard it.
 func (gov *ChainGovernor) DropPendingVAA(vaaId string) (string, error) {
+	id, err := vaa.ParseMessageID(vaaId)
+	if err != nil {
+		return "", fmt.Errorf("invalid vaa id: %w", err)
+	}
+
 	gov.mutex.Lock()
 	defer gov.mutex.Unlock()
 
 	for _, ce := range gov.chains {
 		for idx, pe := range ce.pending {
-			msgId := pe.dbData.Msg.MessageIDString()
-			if msgId == vaaId {
+			if pe.dbData.Msg.ID() == id {
+				msgId := pe.dbData.Msg.MessageIDString()
 				value, _ := computeValue(pe.amount, pe.token)
 				gov.logger.Info("cgov: dropping pending vaa",
 					zap.String("msgId", msgId),
@@ -177,13 +182,18 @@ func (gov *ChainGovernor) DropPendingVAA(vaaId string) (string, error) {
 
 // Admin command to remove a VAA from the pending list and publish it without regard to (or impact on) the daily limit.
 func (gov *ChainGovernor) ReleasePendingVAA(vaaId string) (string, error) {
+	id, err := vaa.ParseMessageID(vaaId)
+	if err != nil {
+		return "", fmt.Errorf("invalid vaa id: %w", err)
+	}
+
 	gov.mutex.Lock()
 	defer gov.mutex.Unlock()
 
 	for _, ce := range gov.chains {
 		for idx, pe := range ce.pending {
-			msgId := pe.dbData.Msg.MessageIDString()
-			if msgId == vaaId {
+			if pe.dbData.Msg.ID() == id {
+				msgId := pe.dbData.Msg.MessageIDString()
 				value, _ := computeValue(pe.amount, pe.token)
 				gov.logger.Info("cgov: releasing pending vaa, should be published soon",
 					zap.String("msgId", msgId),
@@ -216,13 +226,18 @@ func (gov *ChainGovernor) ResetReleaseTimer(vaaId string) (string, error) {
 }
 
 func (gov *ChainGovernor) resetReleaseTimerForTime(vaaId string, now time.Time) (string, error) {
+	id, err := vaa.ParseMessageID(vaaId)
+	if err != nil {
+		return "", fmt.Errorf("invalid vaa id: %w", err)
+	}
+
 	gov.mutex.Lock()
 	defer gov.mutex.Unlock()
 
 	for _, ce := range gov.chains {
 		for _, pe := range ce.pending {
-			msgId := pe.dbData.Msg.MessageIDString()
-			if msgId == vaaId {
+			if pe.dbData.Msg.ID() == id {
+				msgId := pe.dbData.Msg.MessageIDString()
 				pe.dbData.ReleaseTime = now.Add(maxEnqueuedTime)
 				gov.logger.Info("cgov: updating the release time due to admin command",
 					zap.String("msgId", msgId),
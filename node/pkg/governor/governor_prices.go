@@ -20,6 +20,7 @@ import (
 
 	"github.com/certusone/wormhole/node/pkg/db"
 	"github.com/certusone/wormhole/node/pkg/supervisor"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
 )
 
 // The CoinGecko API is documented here: https://www.coingecko.com/en/api/documentation
@@ -219,3 +220,36 @@ func CheckQuery(logger *zap.Logger) error {
 	logger.Info("Coin Gecko query complete.")
 	return nil
 }
+
+// TokenConfig is the exported form of a statically configured token. It is used by the token list generator.
+type TokenConfig struct {
+	Chain       vaa.ChainID
+	Addr        vaa.Address
+	Symbol      string
+	CoinGeckoId string
+	Decimals    int64
+	Price       float64
+}
+
+// MainnetTokenList returns the tokens statically configured for mainnet.
+func MainnetTokenList() ([]TokenConfig, error) {
+	entries := tokenList()
+	tokens := make([]TokenConfig, 0, len(entries))
+	for _, ct := range entries {
+		addr, err := vaa.StringToAddress(ct.addr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid address: %s", ct.addr)
+		}
+
+		tokens = append(tokens, TokenConfig{
+			Chain:       vaa.ChainID(ct.chain),
+			Addr:        addr,
+			Symbol:      ct.symbol,
+			CoinGeckoId: ct.coinGeckoId,
+			Decimals:    ct.decimals,
+			Price:       ct.price,
+		})
+	}
+
+	return tokens, nil
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math"
 	"math/big"
+	"strings"
 	"testing"
 	"time"
 
@@ -1127,7 +1128,11 @@ func TestLargeTransactionGetsEnqueuedAndReleasedWhenTheTimerExpires(t *testing.T
 	assert.Equal(t, uint64(177461), valuePending)
 
 	// // But then the operator resets the release time.
-	_, err = gov.resetReleaseTimerForTime(msg3.MessageIDString(), now)
+	_, err = gov.resetReleaseTimerForTime("2/not-an-address/1", now)
+	assert.Error(t, err)
+
+	// The VAA ID does not have to be canonical.
+	_, err = gov.resetReleaseTimerForTime(fmt.Sprintf("%d/0x%s/%d", msg3.EmitterChain, strings.ToUpper(msg3.EmitterAddress.String()), msg3.Sequence), now)
 	require.NoError(t, err)
 
 	// So now, 12 hours later the big transaction is enqueued, it still won't get released.
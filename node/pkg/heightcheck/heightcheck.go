@@ -0,0 +1,232 @@
+// Package heightcheck cross-checks the heights reported by the node's watchers with the heights other guardians
+// report in their heartbeats.
+//
+// A watcher whose RPC provider is stuck, or lags far behind the chain, keeps reporting a height that is not obviously
+// wrong. Its observations are missing or late, which only becomes visible once VAAs are missed. The checker compares
+// the height of each watcher with the median height reported by the other guardians, and flags the chain as lagging
+// once the network has been ahead of the node for longer than the threshold.
+package heightcheck
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
+	"github.com/certusone/wormhole/node/pkg/supervisor"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+var (
+	peerHeight = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "wormhole_height_check_peer_height",
+			Help: "Median height of the chain reported by the other guardians",
+		}, []string{"chain_name"})
+	lagBlocks = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "wormhole_height_check_lag_blocks",
+			Help: "Number of blocks the height reported by the node's watcher is behind the median height of the other guardians",
+		}, []string{"chain_name"})
+	lagSeconds = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "wormhole_height_check_lag_seconds",
+			Help: "Time since the other guardians reached the height reported by the node's watcher",
+		}, []string{"chain_name"})
+	lagging = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "wormhole_height_check_lagging",
+			Help: "Set to 1 while the node's watcher lags behind the other guardians for longer than the threshold",
+		}, []string{"chain_name"})
+)
+
+const (
+	// checkInterval is how often the checker compares heights.
+	checkInterval = 15 * time.Second
+	// historyWindow is how long the median peer heights are kept. Lags longer than the window are reported as the
+	// window.
+	historyWindow = time.Hour
+	// minPeers is the number of other guardians that must report a height for a chain before the node's height is
+	// compared with theirs, so that a single misbehaving peer cannot flag the node.
+	minPeers = 3
+)
+
+// networkStats is the subset of the p2p registry used by the checker.
+type networkStats interface {
+	NetworkStats() []*gossipv1.Heartbeat_Network
+}
+
+// ChainStatus is the result of the last comparison of the heights of a chain.
+type ChainStatus struct {
+	ChainID vaa.ChainID
+	// Height is the height reported by the node's watcher, and PeerHeight the median height reported by the Peers
+	// other guardians with a recent heartbeat.
+	Height     int64
+	PeerHeight int64
+	Peers      int
+	// LagBlocks is how far Height is behind PeerHeight and Lag how long ago the other guardians reached Height.
+	LagBlocks int64
+	Lag       time.Duration
+	Lagging   bool
+}
+
+type sample struct {
+	time   time.Time
+	height int64
+}
+
+// Checker periodically compares the heights of the node's watchers with the heights of the other guardians.
+type Checker struct {
+	logger   *zap.Logger
+	gst      *common.GuardianSetState
+	registry networkStats
+	gk       *common.GuardianKeyState
+	maxLag   time.Duration
+
+	mu sync.Mutex
+	// history holds the median peer height of each chain at each check within the history window.
+	history map[vaa.ChainID][]sample
+	lagging map[vaa.ChainID]bool
+}
+
+// NewChecker returns a checker comparing the heights in registry with the heartbeats in gst of the guardians other
+// than the one of the active key in gk. Chains are flagged as lagging once the other guardians have been ahead for longer than maxLag.
+func NewChecker(logger *zap.Logger, gst *common.GuardianSetState, registry networkStats, gk *common.GuardianKeyState, maxLag time.Duration) *Checker {
+	return &Checker{
+		logger:   logger.Named("heightcheck"),
+		gst:      gst,
+		registry: registry,
+		gk:       gk,
+		maxLag:   maxLag,
+		history:  map[vaa.ChainID][]sample{},
+		lagging:  map[vaa.ChainID]bool{},
+	}
+}
+
+func (c *Checker) Run(ctx context.Context) error {
+	supervisor.Signal(ctx, supervisor.SignalHealthy)
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			c.check(time.Now())
+		}
+	}
+}
+
+// peerHeights returns the heights of each chain reported in the most recent heartbeat of each other guardian of the
+// current set, ignoring heartbeats older than common.MaxStateAge and chains without height.
+func (c *Checker) peerHeights(now time.Time) map[vaa.ChainID][]int64 {
+	heights := map[vaa.ChainID][]int64{}
+	gs := c.gst.Get()
+	if gs == nil {
+		return heights
+	}
+	heartbeats := c.gst.GetAll()
+	ourAddr := c.gk.Address()
+	for _, k := range gs.Keys {
+		if k == ourAddr {
+			continue
+		}
+		// A guardian may run several nodes, use the most recent heartbeat.
+		var latest *gossipv1.Heartbeat
+		for _, hb := range heartbeats[k] {
+			if latest == nil || hb.Timestamp > latest.Timestamp {
+				latest = hb
+			}
+		}
+		if latest == nil || now.Sub(time.Unix(0, latest.Timestamp)) > common.MaxStateAge {
+			continue
+		}
+		for _, n := range latest.Networks {
+			if n.Height > 0 {
+				heights[vaa.ChainID(n.Id)] = append(heights[vaa.ChainID(n.Id)], n.Height)
+			}
+		}
+	}
+	return heights
+}
+
+// check compares the heights, updates the metrics and logs chains that start or stop lagging. It returns the status
+// of the chains the node has a watcher for, ordered by chain ID.
+func (c *Checker) check(now time.Time) []ChainStatus {
+	peers := c.peerHeights(now)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cutoff := now.Add(-historyWindow)
+	for chain, heights := range peers {
+		sort.Slice(heights, func(i, j int) bool { return heights[i] < heights[j] })
+		h := c.history[chain]
+		i := 0
+		for i < len(h) && h[i].time.Before(cutoff) {
+			i++
+		}
+		c.history[chain] = append(h[i:], sample{time: now, height: heights[len(heights)/2]})
+	}
+
+	stats := c.registry.NetworkStats()
+	statuses := make([]ChainStatus, 0, len(stats))
+	for _, n := range stats {
+		chain := vaa.ChainID(n.Id)
+		status := ChainStatus{ChainID: chain, Height: n.Height, Peers: len(peers[chain])}
+		if status.Peers >= minPeers {
+			h := c.history[chain]
+			status.PeerHeight = h[len(h)-1].height
+			if status.PeerHeight > status.Height {
+				status.LagBlocks = status.PeerHeight - status.Height
+				// The other guardians passed our height after the last check at which they had not reached it
+				// yet, or before the start of the history.
+				passed := h[0].time
+				for i := len(h) - 1; i > 0; i-- {
+					if h[i-1].height <= status.Height {
+						passed = h[i].time
+						break
+					}
+				}
+				status.Lag = now.Sub(passed)
+			}
+			status.Lagging = status.Lag > c.maxLag
+		}
+
+		if status.Lagging != c.lagging[chain] {
+			fields := []zap.Field{
+				zap.Stringer("chain", chain),
+				zap.Int64("height", status.Height),
+				zap.Int64("peer_height", status.PeerHeight),
+				zap.Int("peers", status.Peers),
+				zap.Int64("lag_blocks", status.LagBlocks),
+				zap.Duration("lag", status.Lag),
+			}
+			if status.Lagging {
+				c.logger.Warn("watcher height lags behind the other guardians, check the RPC provider", fields...)
+			} else {
+				c.logger.Info("watcher height caught up with the other guardians", fields...)
+			}
+			c.lagging[chain] = status.Lagging
+		}
+
+		name := chain.String()
+		peerHeight.WithLabelValues(name).Set(float64(status.PeerHeight))
+		lagBlocks.WithLabelValues(name).Set(float64(status.LagBlocks))
+		lagSeconds.WithLabelValues(name).Set(status.Lag.Seconds())
+		v := 0.0
+		if status.Lagging {
+			v = 1
+		}
+		lagging.WithLabelValues(name).Set(v)
+
+		statuses = append(statuses, status)
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].ChainID < statuses[j].ChainID })
+	return statuses
+}
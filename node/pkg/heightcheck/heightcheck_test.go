@@ -0,0 +1,138 @@
+package heightcheck
+
+import (
+	"testing"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+type mockRegistry struct {
+	heights map[vaa.ChainID]int64
+}
+
+func (r *mockRegistry) NetworkStats() []*gossipv1.Heartbeat_Network {
+	stats := make([]*gossipv1.Heartbeat_Network, 0, len(r.heights))
+	for chain, height := range r.heights {
+		stats = append(stats, &gossipv1.Heartbeat_Network{Id: uint32(chain), Height: height})
+	}
+	return stats
+}
+
+// newTestChecker returns a checker for a guardian set of numGuardians, of which the node is the first.
+func newTestChecker(t *testing.T, numGuardians int) (*Checker, *common.GuardianSetState, *mockRegistry, []ethcommon.Address) {
+	t.Helper()
+	gk, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	keys := make([]ethcommon.Address, numGuardians)
+	keys[0] = crypto.PubkeyToAddress(gk.PublicKey)
+	for i := 1; i < len(keys); i++ {
+		keys[i] = ethcommon.Address{byte(i + 1)}
+	}
+	gst := common.NewGuardianSetState()
+	gst.Set(&common.GuardianSet{Keys: keys, Index: 1})
+	registry := &mockRegistry{heights: map[vaa.ChainID]int64{}}
+	return NewChecker(zap.NewNop(), gst, registry, common.NewGuardianKeyState(gk, nil), 2*time.Minute), gst, registry, keys
+}
+
+// heartbeat stores a heartbeat of each of the guardians sent at ts, reporting height for chain.
+func heartbeat(t *testing.T, gst *common.GuardianSetState, ts time.Time, chain vaa.ChainID, height int64, guardians ...ethcommon.Address) {
+	t.Helper()
+	for _, g := range guardians {
+		require.NoError(t, gst.SetHeartbeat(g, peer.ID(g.Hex()), &gossipv1.Heartbeat{
+			Timestamp: ts.UnixNano(),
+			Networks:  []*gossipv1.Heartbeat_Network{{Id: uint32(chain), Height: height}},
+		}))
+	}
+}
+
+func TestCheckerCaughtUp(t *testing.T) {
+	c, gst, registry, keys := newTestChecker(t, 5)
+	now := time.Unix(1000000, 0)
+
+	registry.heights[vaa.ChainIDEthereum] = 100
+	heartbeat(t, gst, now, vaa.ChainIDEthereum, 98, keys[1])
+	heartbeat(t, gst, now, vaa.ChainIDEthereum, 100, keys[2])
+	heartbeat(t, gst, now, vaa.ChainIDEthereum, 101, keys[3:]...)
+
+	statuses := c.check(now)
+	require.Len(t, statuses, 1)
+	assert.Equal(t, ChainStatus{ChainID: vaa.ChainIDEthereum, Height: 100, PeerHeight: 101, Peers: 4, LagBlocks: 1}, statuses[0])
+
+	// Our heartbeat does not count as a peer.
+	heartbeat(t, gst, now, vaa.ChainIDEthereum, 1, keys[0])
+	assert.Equal(t, 4, c.check(now)[0].Peers)
+}
+
+func TestCheckerStuckWatcher(t *testing.T) {
+	c, gst, registry, keys := newTestChecker(t, 5)
+	now := time.Unix(1000000, 0)
+	registry.heights[vaa.ChainIDEthereum] = 100
+
+	// The other guardians keep advancing while our watcher is stuck at 100.
+	var status ChainStatus
+	for i := 0; i <= 12; i++ {
+		ts := now.Add(time.Duration(i) * checkInterval)
+		heartbeat(t, gst, ts, vaa.ChainIDEthereum, int64(99+i), keys[1:]...)
+		status = c.check(ts)[0]
+		if i < 10 {
+			assert.False(t, status.Lagging, "check %d", i)
+		}
+	}
+	// The network passed our height at the third check, 10 intervals ago.
+	assert.True(t, status.Lagging)
+	assert.Equal(t, int64(111), status.PeerHeight)
+	assert.Equal(t, int64(11), status.LagBlocks)
+	assert.Equal(t, 10*checkInterval, status.Lag)
+
+	// The watcher catches up.
+	registry.heights[vaa.ChainIDEthereum] = 111
+	status = c.check(now.Add(12 * checkInterval))[0]
+	assert.False(t, status.Lagging)
+	assert.Equal(t, time.Duration(0), status.Lag)
+}
+
+func TestCheckerNeverReachedHeight(t *testing.T) {
+	c, gst, registry, keys := newTestChecker(t, 5)
+	now := time.Unix(1000000, 0)
+	registry.heights[vaa.ChainIDSolana] = 0
+
+	heartbeat(t, gst, now, vaa.ChainIDSolana, 500, keys[1:]...)
+	assert.False(t, c.check(now)[0].Lagging)
+
+	later := now.Add(3 * time.Minute)
+	heartbeat(t, gst, later, vaa.ChainIDSolana, 900, keys[1:]...)
+	status := c.check(later)[0]
+	assert.True(t, status.Lagging)
+	assert.Equal(t, 3*time.Minute, status.Lag)
+}
+
+func TestCheckerTooFewPeers(t *testing.T) {
+	c, gst, registry, keys := newTestChecker(t, 5)
+	now := time.Unix(1000000, 0)
+	registry.heights[vaa.ChainIDEthereum] = 100
+
+	heartbeat(t, gst, now, vaa.ChainIDEthereum, 10000, keys[1:3]...)
+	later := now.Add(time.Hour)
+	heartbeat(t, gst, later, vaa.ChainIDEthereum, 20000, keys[1:3]...)
+	status := c.check(later)[0]
+	assert.Equal(t, 2, status.Peers)
+	assert.False(t, status.Lagging)
+	assert.Equal(t, int64(0), status.LagBlocks)
+
+	// Heartbeats older than the state age do not count either.
+	heartbeat(t, gst, now, vaa.ChainIDEthereum, 20000, keys[3:]...)
+	assert.Equal(t, 2, c.check(later)[0].Peers)
+
+	// Nor do heartbeats of guardians outside the set.
+	heartbeat(t, gst, later, vaa.ChainIDEthereum, 20000, ethcommon.Address{0xff})
+	assert.Equal(t, 2, c.check(later)[0].Peers)
+}
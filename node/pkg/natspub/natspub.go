@@ -0,0 +1,160 @@
+// Package natspub publishes signed VAAs to NATS JetStream, on one subject per emitter chain, so that indexers can ingest
+// them from a durable stream instead of consuming the spy's gRPC interface.
+package natspub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+var (
+	natsPublished = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "wormhole_spy_nats_published_total",
+			Help: "Total number of signed VAAs acknowledged by JetStream, by whether JetStream reported a duplicate",
+		}, []string{"duplicate"})
+	natsDropped = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "wormhole_spy_nats_dropped_total",
+			Help: "Total number of signed VAAs dropped because the NATS publish queue was full",
+		})
+	natsErrors = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "wormhole_spy_nats_errors_total",
+			Help: "Total number of failed attempts to publish a signed VAA to NATS",
+		})
+)
+
+const (
+	// queueSize is the number of VAAs buffered while the connection to NATS is down or slow. VAAs are dropped once it
+	// is full, so consumers relying on the stream should run the spy with a data directory to backfill gaps.
+	queueSize = 10000
+	// ackTimeout is how long JetStream may take to acknowledge a message.
+	ackTimeout = 10 * time.Second
+	// dialTimeout is how long connecting to NATS may take.
+	dialTimeout = 10 * time.Second
+)
+
+// Subject returns the subject VAAs emitted on chain are published to.
+func Subject(prefix string, chain vaa.ChainID) string {
+	return fmt.Sprintf("%s.%d", prefix, uint16(chain))
+}
+
+type message struct {
+	subject string
+	// msgID is the hex digest of the VAA. JetStream drops messages with an ID it has seen within the duplicate window
+	// of the stream, so VAAs gossiped more than once, possibly with different signatures, are stored once.
+	msgID    string
+	vaaBytes []byte
+}
+
+// Publisher publishes signed VAAs to NATS JetStream. The streams have to be created by the operator, on subjects
+// matching those returned by Subject.
+type Publisher struct {
+	logger  *zap.Logger
+	url     string
+	prefix  string
+	queue   chan message
+	timeout time.Duration
+	// pending is the message that failed to publish when the last run returned, to be published first by the next.
+	pending *message
+}
+
+// NewPublisher returns a publisher to the NATS server at rawURL, of the form nats://[user:password@]host:port, that
+// publishes VAAs on subjects below prefix.
+func NewPublisher(logger *zap.Logger, rawURL string, prefix string) (*Publisher, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid NATS URL: %w", err)
+	}
+	if u.Scheme != "nats" || u.Host == "" {
+		return nil, fmt.Errorf("invalid NATS URL %s: must be nats://host:port", rawURL)
+	}
+	if prefix == "" || strings.ContainsAny(prefix, " \t\r\n*>") {
+		return nil, fmt.Errorf("invalid subject prefix %q", prefix)
+	}
+	return &Publisher{
+		logger:  logger,
+		url:     rawURL,
+		prefix:  prefix,
+		queue:   make(chan message, queueSize),
+		timeout: ackTimeout,
+	}, nil
+}
+
+// Publish queues v for publication without blocking, dropping it if the queue is full.
+func (p *Publisher) Publish(v *vaa.VAA, vaaBytes []byte) {
+	msg := message{
+		subject:  Subject(p.prefix, v.EmitterChain),
+		msgID:    v.HexDigest(),
+		vaaBytes: vaaBytes,
+	}
+	select {
+	case p.queue <- msg:
+	default:
+		natsDropped.Inc()
+	}
+}
+
+// Run connects to NATS and publishes queued VAAs until ctx is cancelled. It returns on connection errors, keeping the
+// message being published for the next run, so that the supervisor restarts it. It must not run concurrently.
+func (p *Publisher) Run(ctx context.Context) error {
+	// Reconnecting is left to the supervisor, so that a lost connection does not hold the pending message back.
+	nc, err := nats.Connect(p.url, nats.Name("wormhole-spy"), nats.Timeout(dialTimeout), nats.NoReconnect())
+	if err != nil {
+		natsErrors.Inc()
+		return fmt.Errorf("failed to connect to NATS: %w", err)
+	}
+	defer nc.Close()
+	js, err := nc.JetStream()
+	if err != nil {
+		natsErrors.Inc()
+		return err
+	}
+	p.logger.Info("connected to NATS", zap.String("server", nc.ConnectedUrlRedacted()))
+
+	for {
+		if p.pending == nil {
+			select {
+			case <-ctx.Done():
+				return nil
+			case msg := <-p.queue:
+				p.pending = &msg
+			}
+		}
+
+		pending := p.pending
+		ack, err := js.Publish(pending.subject, pending.vaaBytes, nats.MsgId(pending.msgID), nats.AckWait(p.timeout))
+		var apiErr *nats.APIError
+		switch {
+		case errors.Is(err, nats.ErrNoStreamResponse):
+			natsErrors.Inc()
+			return fmt.Errorf("no JetStream stream for subject %s", pending.subject)
+		case errors.As(err, &apiErr):
+			natsErrors.Inc()
+			p.logger.Error("JetStream rejected signed VAA",
+				zap.String("subject", pending.subject), zap.String("digest", pending.msgID),
+				zap.Int("code", apiErr.Code), zap.String("description", apiErr.Description))
+		case err != nil:
+			natsErrors.Inc()
+			return fmt.Errorf("failed to publish VAA %s: %w", pending.msgID, err)
+		default:
+			natsPublished.WithLabelValues(strconv.FormatBool(ack.Duplicate)).Inc()
+			p.logger.Debug("published signed VAA",
+				zap.String("subject", pending.subject), zap.String("digest", pending.msgID),
+				zap.String("stream", ack.Stream), zap.Uint64("seq", ack.Sequence), zap.Bool("duplicate", ack.Duplicate))
+		}
+		p.pending = nil
+	}
+}
@@ -0,0 +1,234 @@
+// Package notify sends critical guardian events, like a watcher going down, to the sinks configured by the operator.
+package notify
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
+	"github.com/certusone/wormhole/node/pkg/supervisor"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+var (
+	notificationsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "wormhole_notifications_total",
+			Help: "Total number of notifications sent to each sink, by outcome",
+		}, []string{"sink", "status"})
+)
+
+const (
+	// monitorInterval is how often the monitor checks for critical conditions.
+	monitorInterval = 30 * time.Second
+	// sendTimeout is how long a sink may take to accept a notification.
+	sendTimeout = 10 * time.Second
+)
+
+type EventKind string
+
+const (
+	// EventWatcherDown is raised while the watchdog flags the watcher of a chain as stalled.
+	EventWatcherDown EventKind = "watcher_down"
+	// EventGovernorQueue is raised while the notional value of the transfers enqueued by the governor is above the
+	// configured threshold.
+	EventGovernorQueue EventKind = "governor_queue"
+	// EventGuardianSetChange is sent once when the node observes a new guardian set.
+	EventGuardianSetChange EventKind = "guardian_set_change"
+)
+
+var allEventKinds = []EventKind{EventWatcherDown, EventGovernorQueue, EventGuardianSetChange}
+
+type Severity string
+
+const (
+	SeverityCritical Severity = "critical"
+	SeverityWarning  Severity = "warning"
+	SeverityInfo     Severity = "info"
+)
+
+// Event is a notification about a critical condition. Conditions that persist, like a watcher being down, are sent
+// once when they are raised and once more with Resolved set when they clear.
+type Event struct {
+	Kind EventKind
+	// Key identifies the condition among those of the same kind, e.g. the chain of a watcher.
+	Key      string
+	Severity Severity
+	Summary  string
+	Details  map[string]string
+	Resolved bool
+	Time     time.Time
+}
+
+// DedupKey identifies the condition of the event across notifications.
+func (e *Event) DedupKey() string {
+	return string(e.Kind) + "/" + e.Key
+}
+
+// Sink delivers notifications to an external service.
+type Sink interface {
+	Name() string
+	Send(ctx context.Context, e *Event) error
+}
+
+// ParseEventKinds parses a comma-separated list of event kinds. An empty list enables all of them.
+func ParseEventKinds(s string) (map[EventKind]bool, error) {
+	kinds := make(map[EventKind]bool)
+	if strings.TrimSpace(s) == "" {
+		for _, k := range allEventKinds {
+			kinds[k] = true
+		}
+		return kinds, nil
+	}
+	for _, name := range strings.Split(s, ",") {
+		k := EventKind(strings.TrimSpace(name))
+		known := false
+		for _, a := range allEventKinds {
+			known = known || a == k
+		}
+		if !known {
+			return nil, fmt.Errorf("unknown notification event %q", name)
+		}
+		kinds[k] = true
+	}
+	return kinds, nil
+}
+
+// Sources are the node state the monitor checks. Nil sources are not checked.
+type Sources struct {
+	// NetworkStats returns the status of every watcher, like p2p.DefaultRegistry.NetworkStats.
+	NetworkStats func() []*gossipv1.Heartbeat_Network
+	// GovernorQueue returns the total notional value in USD of the transfers enqueued by the governor.
+	GovernorQueue func() uint64
+	// GuardianSet returns the current guardian set.
+	GuardianSet func() *common.GuardianSet
+}
+
+// Monitor periodically checks the node for critical conditions and sends events for them to its sinks.
+type Monitor struct {
+	logger                 *zap.Logger
+	sinks                  []Sink
+	kinds                  map[EventKind]bool
+	governorQueueThreshold uint64
+	sources                Sources
+
+	// Conditions that are currently raised, by dedup key.
+	active map[string]*Event
+	// Index of the last guardian set seen, nil before the first check.
+	guardianSetIndex *uint32
+}
+
+// NewMonitor returns a monitor for the event kinds in kinds. A zero governorQueueThreshold disables
+// EventGovernorQueue.
+func NewMonitor(logger *zap.Logger, sinks []Sink, kinds map[EventKind]bool, governorQueueThreshold uint64, sources Sources) *Monitor {
+	return &Monitor{
+		logger:                 logger.Named("notify"),
+		sinks:                  sinks,
+		kinds:                  kinds,
+		governorQueueThreshold: governorQueueThreshold,
+		sources:                sources,
+		active:                 make(map[string]*Event),
+	}
+}
+
+func (m *Monitor) Run(ctx context.Context) error {
+	supervisor.Signal(ctx, supervisor.SignalHealthy)
+	ticker := time.NewTicker(monitorInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			m.check(ctx, time.Now())
+		}
+	}
+}
+
+// check evaluates all conditions and sends an event for every condition that was raised or cleared since the last
+// check, and for guardian set changes.
+func (m *Monitor) check(ctx context.Context, now time.Time) {
+	raised := make(map[string]*Event)
+	if m.kinds[EventWatcherDown] && m.sources.NetworkStats != nil {
+		for _, n := range m.sources.NetworkStats() {
+			if !n.Stalled {
+				continue
+			}
+			chain := vaa.ChainID(n.Id)
+			e := &Event{
+				Kind:     EventWatcherDown,
+				Key:      chain.String(),
+				Severity: SeverityCritical,
+				Summary:  fmt.Sprintf("Watcher for %s is not making progress", chain),
+				Details:  map[string]string{"chain": chain.String(), "height": fmt.Sprint(n.Height)},
+			}
+			raised[e.DedupKey()] = e
+		}
+	}
+	if m.kinds[EventGovernorQueue] && m.sources.GovernorQueue != nil && m.governorQueueThreshold != 0 {
+		if queued := m.sources.GovernorQueue(); queued > m.governorQueueThreshold {
+			e := &Event{
+				Kind:     EventGovernorQueue,
+				Severity: SeverityWarning,
+				Summary:  fmt.Sprintf("Governor queue holds $%d, above the threshold of $%d", queued, m.governorQueueThreshold),
+				Details:  map[string]string{"notional_usd": fmt.Sprint(queued), "threshold_usd": fmt.Sprint(m.governorQueueThreshold)},
+			}
+			raised[e.DedupKey()] = e
+		}
+	}
+
+	for key, e := range raised {
+		if _, ok := m.active[key]; !ok {
+			e.Time = now
+			m.active[key] = e
+			m.send(ctx, e)
+		}
+	}
+	for key, e := range m.active {
+		if _, ok := raised[key]; !ok {
+			delete(m.active, key)
+			resolved := *e
+			resolved.Resolved = true
+			resolved.Time = now
+			m.send(ctx, &resolved)
+		}
+	}
+
+	if m.sources.GuardianSet != nil {
+		if gs := m.sources.GuardianSet(); gs != nil {
+			if m.guardianSetIndex != nil && *m.guardianSetIndex != gs.Index && m.kinds[EventGuardianSetChange] {
+				m.send(ctx, &Event{
+					Kind:     EventGuardianSetChange,
+					Key:      fmt.Sprint(gs.Index),
+					Severity: SeverityInfo,
+					Summary:  fmt.Sprintf("Guardian set changed from index %d to %d", *m.guardianSetIndex, gs.Index),
+					Details:  map[string]string{"index": fmt.Sprint(gs.Index), "guardians": fmt.Sprint(len(gs.Keys))},
+					Time:     now,
+				})
+			}
+			index := gs.Index
+			m.guardianSetIndex = &index
+		}
+	}
+}
+
+func (m *Monitor) send(ctx context.Context, e *Event) {
+	m.logger.Info("sending notification", zap.String("event", e.DedupKey()), zap.Bool("resolved", e.Resolved), zap.String("summary", e.Summary))
+	for _, s := range m.sinks {
+		sendCtx, cancel := context.WithTimeout(ctx, sendTimeout)
+		err := s.Send(sendCtx, e)
+		cancel()
+		if err != nil {
+			m.logger.Error("failed to send notification", zap.String("sink", s.Name()), zap.String("event", e.DedupKey()), zap.Error(err))
+			notificationsTotal.WithLabelValues(s.Name(), "error").Inc()
+			continue
+		}
+		notificationsTotal.WithLabelValues(s.Name(), "ok").Inc()
+	}
+}
@@ -0,0 +1,142 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+type recordingSink struct {
+	events []*Event
+	err    error
+}
+
+func (s *recordingSink) Name() string {
+	return "recording"
+}
+
+func (s *recordingSink) Send(_ context.Context, e *Event) error {
+	s.events = append(s.events, e)
+	return s.err
+}
+
+// testSources is node state that tests change between checks.
+type testSources struct {
+	networks      []*gossipv1.Heartbeat_Network
+	governorQueue uint64
+	guardianSet   *common.GuardianSet
+}
+
+func (s *testSources) sources() Sources {
+	return Sources{
+		NetworkStats:  func() []*gossipv1.Heartbeat_Network { return s.networks },
+		GovernorQueue: func() uint64 { return s.governorQueue },
+		GuardianSet:   func() *common.GuardianSet { return s.guardianSet },
+	}
+}
+
+func newTestMonitor(t *testing.T, events string, threshold uint64, sinks ...Sink) (*Monitor, *testSources) {
+	t.Helper()
+	kinds, err := ParseEventKinds(events)
+	require.NoError(t, err)
+	s := &testSources{guardianSet: &common.GuardianSet{Index: 1}}
+	return NewMonitor(zap.NewNop(), sinks, kinds, threshold, s.sources()), s
+}
+
+func TestMonitorWatcherDown(t *testing.T) {
+	sink := &recordingSink{}
+	m, s := newTestMonitor(t, "", 0, sink)
+	ctx, now := context.Background(), time.Unix(1000, 0)
+
+	s.networks = []*gossipv1.Heartbeat_Network{
+		{Id: uint32(vaa.ChainIDEthereum), Height: 100, Stalled: true},
+		{Id: uint32(vaa.ChainIDSolana), Height: 200},
+	}
+	m.check(ctx, now)
+	require.Len(t, sink.events, 1)
+	assert.Equal(t, EventWatcherDown, sink.events[0].Kind)
+	assert.Equal(t, "watcher_down/ethereum", sink.events[0].DedupKey())
+	assert.Equal(t, SeverityCritical, sink.events[0].Severity)
+	assert.False(t, sink.events[0].Resolved)
+
+	// A condition that stays raised is only sent once.
+	m.check(ctx, now.Add(time.Minute))
+	assert.Len(t, sink.events, 1)
+
+	s.networks[0].Stalled = false
+	m.check(ctx, now.Add(2*time.Minute))
+	require.Len(t, sink.events, 2)
+	assert.Equal(t, "watcher_down/ethereum", sink.events[1].DedupKey())
+	assert.True(t, sink.events[1].Resolved)
+	assert.Equal(t, now.Add(2*time.Minute), sink.events[1].Time)
+}
+
+func TestMonitorGovernorQueue(t *testing.T) {
+	sink := &recordingSink{}
+	m, s := newTestMonitor(t, "", 1000000, sink)
+	ctx, now := context.Background(), time.Unix(1000, 0)
+
+	s.governorQueue = 1000000
+	m.check(ctx, now)
+	assert.Empty(t, sink.events)
+
+	s.governorQueue = 1000001
+	m.check(ctx, now)
+	require.Len(t, sink.events, 1)
+	assert.Equal(t, EventGovernorQueue, sink.events[0].Kind)
+	assert.Equal(t, "1000001", sink.events[0].Details["notional_usd"])
+
+	s.governorQueue = 0
+	m.check(ctx, now)
+	require.Len(t, sink.events, 2)
+	assert.True(t, sink.events[1].Resolved)
+}
+
+func TestMonitorGuardianSetChange(t *testing.T) {
+	sink := &recordingSink{}
+	m, s := newTestMonitor(t, "", 0, sink)
+	ctx, now := context.Background(), time.Unix(1000, 0)
+
+	// The guardian set the node starts with is not a change.
+	m.check(ctx, now)
+	assert.Empty(t, sink.events)
+
+	s.guardianSet = &common.GuardianSet{Index: 2, Keys: make([]ethcommon.Address, 19)}
+	m.check(ctx, now)
+	m.check(ctx, now)
+	require.Len(t, sink.events, 1)
+	assert.Equal(t, "guardian_set_change/2", sink.events[0].DedupKey())
+	assert.Equal(t, "19", sink.events[0].Details["guardians"])
+}
+
+func TestMonitorEventKinds(t *testing.T) {
+	sink := &recordingSink{}
+	m, s := newTestMonitor(t, "governor_queue", 1, sink)
+	s.networks = []*gossipv1.Heartbeat_Network{{Id: uint32(vaa.ChainIDEthereum), Stalled: true}}
+	s.guardianSet = &common.GuardianSet{Index: 2}
+	m.check(context.Background(), time.Unix(1000, 0))
+	assert.Empty(t, sink.events)
+
+	_, err := ParseEventKinds("watcher_down,accountant_mismatch")
+	assert.Error(t, err)
+}
+
+func TestMonitorSinkFailure(t *testing.T) {
+	failing := &recordingSink{err: errors.New("503 service unavailable")}
+	working := &recordingSink{}
+	m, s := newTestMonitor(t, "", 0, failing, working)
+	s.networks = []*gossipv1.Heartbeat_Network{{Id: uint32(vaa.ChainIDEthereum), Stalled: true}}
+
+	m.check(context.Background(), time.Unix(1000, 0))
+	assert.Len(t, failing.events, 1)
+	assert.Len(t, working.events, 1)
+}
@@ -0,0 +1,160 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// pagerDutyEventsURL is the endpoint of the PagerDuty Events API v2.
+const pagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue"
+
+func postJSON(ctx context.Context, client *http.Client, url string, body interface{}) error {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+// WebhookSink posts every event as JSON to a URL.
+type WebhookSink struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhookSink(url string) *WebhookSink {
+	return &WebhookSink{url: url, client: &http.Client{Timeout: sendTimeout}}
+}
+
+func (s *WebhookSink) Name() string {
+	return "webhook"
+}
+
+type webhookEvent struct {
+	Kind     EventKind         `json:"kind"`
+	DedupKey string            `json:"dedupKey"`
+	Severity Severity          `json:"severity"`
+	Summary  string            `json:"summary"`
+	Details  map[string]string `json:"details,omitempty"`
+	Resolved bool              `json:"resolved"`
+	Time     time.Time         `json:"time"`
+}
+
+func (s *WebhookSink) Send(ctx context.Context, e *Event) error {
+	return postJSON(ctx, s.client, s.url, &webhookEvent{
+		Kind:     e.Kind,
+		DedupKey: e.DedupKey(),
+		Severity: e.Severity,
+		Summary:  e.Summary,
+		Details:  e.Details,
+		Resolved: e.Resolved,
+		Time:     e.Time,
+	})
+}
+
+// SlackSink posts events to a Slack incoming webhook.
+type SlackSink struct {
+	url    string
+	client *http.Client
+}
+
+func NewSlackSink(webhookURL string) *SlackSink {
+	return &SlackSink{url: webhookURL, client: &http.Client{Timeout: sendTimeout}}
+}
+
+func (s *SlackSink) Name() string {
+	return "slack"
+}
+
+func (s *SlackSink) Send(ctx context.Context, e *Event) error {
+	text := &strings.Builder{}
+	if e.Resolved {
+		fmt.Fprintf(text, ":white_check_mark: *Resolved:* %s", e.Summary)
+	} else {
+		fmt.Fprintf(text, ":rotating_light: *%s:* %s", strings.ToUpper(string(e.Severity)), e.Summary)
+	}
+	keys := make([]string, 0, len(e.Details))
+	for k := range e.Details {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(text, "\n• %s: `%s`", k, e.Details[k])
+	}
+	return postJSON(ctx, s.client, s.url, map[string]string{"text": text.String()})
+}
+
+// PagerDutySink triggers and resolves PagerDuty incidents using the Events API v2. Incidents are deduplicated by the
+// dedup key of the event, so a resolved event closes the incident opened for the same condition.
+type PagerDutySink struct {
+	url        string
+	routingKey string
+	source     string
+	client     *http.Client
+}
+
+// NewPagerDutySink returns a sink for the PagerDuty service integration with routingKey. source names the node in
+// incidents.
+func NewPagerDutySink(routingKey string, source string) *PagerDutySink {
+	return &PagerDutySink{url: pagerDutyEventsURL, routingKey: routingKey, source: source, client: &http.Client{Timeout: sendTimeout}}
+}
+
+func (s *PagerDutySink) Name() string {
+	return "pagerduty"
+}
+
+type pagerDutyPayload struct {
+	Summary       string            `json:"summary"`
+	Source        string            `json:"source"`
+	Severity      Severity          `json:"severity"`
+	Timestamp     string            `json:"timestamp"`
+	Component     string            `json:"component"`
+	CustomDetails map[string]string `json:"custom_details,omitempty"`
+}
+
+type pagerDutyEvent struct {
+	RoutingKey  string            `json:"routing_key"`
+	EventAction string            `json:"event_action"`
+	DedupKey    string            `json:"dedup_key"`
+	Payload     *pagerDutyPayload `json:"payload,omitempty"`
+}
+
+func (s *PagerDutySink) Send(ctx context.Context, e *Event) error {
+	ev := &pagerDutyEvent{
+		RoutingKey: s.routingKey,
+		DedupKey:   s.source + "/" + e.DedupKey(),
+	}
+	if e.Resolved {
+		ev.EventAction = "resolve"
+	} else {
+		ev.EventAction = "trigger"
+		ev.Payload = &pagerDutyPayload{
+			Summary:       e.Summary,
+			Source:        s.source,
+			Severity:      e.Severity,
+			Timestamp:     e.Time.UTC().Format(time.RFC3339),
+			Component:     string(e.Kind),
+			CustomDetails: e.Details,
+		}
+	}
+	return postJSON(ctx, s.client, s.url, ev)
+}
@@ -0,0 +1,93 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestServer records the JSON bodies posted to it and responds with status.
+func newTestServer(t *testing.T, status int) (*httptest.Server, *[]map[string]interface{}) {
+	t.Helper()
+	var bodies []map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		bodies = append(bodies, body)
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &bodies
+}
+
+var testEvent = &Event{
+	Kind:     EventWatcherDown,
+	Key:      "ethereum",
+	Severity: SeverityCritical,
+	Summary:  "Watcher for ethereum is not making progress",
+	Details:  map[string]string{"chain": "ethereum", "height": "100"},
+	Time:     time.Unix(1000, 0),
+}
+
+func TestWebhookSink(t *testing.T) {
+	srv, bodies := newTestServer(t, http.StatusOK)
+	require.NoError(t, NewWebhookSink(srv.URL).Send(context.Background(), testEvent))
+	require.Len(t, *bodies, 1)
+	body := (*bodies)[0]
+	assert.Equal(t, "watcher_down", body["kind"])
+	assert.Equal(t, "watcher_down/ethereum", body["dedupKey"])
+	assert.Equal(t, "critical", body["severity"])
+	assert.Equal(t, false, body["resolved"])
+	assert.Equal(t, map[string]interface{}{"chain": "ethereum", "height": "100"}, body["details"])
+}
+
+func TestWebhookSinkError(t *testing.T) {
+	srv, _ := newTestServer(t, http.StatusInternalServerError)
+	assert.Error(t, NewWebhookSink(srv.URL).Send(context.Background(), testEvent))
+}
+
+func TestSlackSink(t *testing.T) {
+	srv, bodies := newTestServer(t, http.StatusOK)
+	s := NewSlackSink(srv.URL)
+	require.NoError(t, s.Send(context.Background(), testEvent))
+	resolved := *testEvent
+	resolved.Resolved = true
+	require.NoError(t, s.Send(context.Background(), &resolved))
+
+	require.Len(t, *bodies, 2)
+	assert.Equal(t, ":rotating_light: *CRITICAL:* Watcher for ethereum is not making progress\n• chain: `ethereum`\n• height: `100`", (*bodies)[0]["text"])
+	assert.Contains(t, (*bodies)[1]["text"], ":white_check_mark: *Resolved:*")
+}
+
+func TestPagerDutySink(t *testing.T) {
+	srv, bodies := newTestServer(t, http.StatusAccepted)
+	s := NewPagerDutySink("routing-key", "guardian-1")
+	s.url = srv.URL
+	require.NoError(t, s.Send(context.Background(), testEvent))
+	resolved := *testEvent
+	resolved.Resolved = true
+	require.NoError(t, s.Send(context.Background(), &resolved))
+
+	require.Len(t, *bodies, 2)
+	trigger := (*bodies)[0]
+	assert.Equal(t, "routing-key", trigger["routing_key"])
+	assert.Equal(t, "trigger", trigger["event_action"])
+	assert.Equal(t, "guardian-1/watcher_down/ethereum", trigger["dedup_key"])
+	payload := trigger["payload"].(map[string]interface{})
+	assert.Equal(t, "critical", payload["severity"])
+	assert.Equal(t, "guardian-1", payload["source"])
+	assert.Equal(t, "1970-01-01T00:16:40Z", payload["timestamp"])
+
+	resolve := (*bodies)[1]
+	assert.Equal(t, "resolve", resolve["event_action"])
+	assert.Equal(t, "guardian-1/watcher_down/ethereum", resolve["dedup_key"])
+	assert.Nil(t, resolve["payload"])
+}
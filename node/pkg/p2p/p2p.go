@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	node_common "github.com/certusone/wormhole/node/pkg/common"
@@ -53,6 +54,11 @@ var (
 			Name: "wormhole_p2p_broadcast_messages_received_total",
 			Help: "Total number of p2p pubsub broadcast messages received",
 		}, []string{"type"})
+	p2pNodeKeyRotations = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "wormhole_p2p_node_key_rotations_total",
+			Help: "Total number of node key rotations",
+		})
 )
 
 var heartbeatMessagePrefix = []byte("heartbeat|")
@@ -67,107 +73,37 @@ func signedObservationRequestDigest(b []byte) common.Hash {
 	return ethcrypto.Keccak256Hash(append(signedObservationRequestPrefix, b...))
 }
 
-func Run(obsvC chan *gossipv1.SignedObservation, obsvReqC chan *gossipv1.ObservationRequest, obsvReqSendC chan *gossipv1.ObservationRequest, sendC chan []byte, signedInC chan *gossipv1.SignedVAAWithQuorum, priv crypto.PrivKey, gk *ecdsa.PrivateKey, gst *node_common.GuardianSetState, port uint, networkID string, bootstrapPeers string, nodeName string, disableHeartbeatVerify bool, rootCtxCancel context.CancelFunc, gov *governor.ChainGovernor) func(ctx context.Context) error {
+// NodeKeyRotation asks the p2p runnable to switch to a new node key. Err receives nil once the node has rejoined the
+// gossip network under the new peer ID, or the error that stopped it.
+type NodeKeyRotation struct {
+	Key crypto.PrivKey
+	Err chan error
+}
+
+// announceTimeout is how long a node waits for gossip peers after a node key rotation before it announces its new peer
+// ID regardless.
+const announceTimeout = 30 * time.Second
+
+// Run returns the p2p runnable. Governance observations signed by the current guardian set are delivered on govObsvC
+// and messages queued on govSendC are published before those on sendC, so that governance VAAs reach quorum quickly
+// even when the other channels are saturated. Both channels are optional: without govObsvC, governance observations
+// are delivered on obsvC.
+//
+// Node key rotations received on the optional nodeKeyC replace the libp2p host with one using the new key. Messages
+// queued on the send channels meanwhile are published by the new host, which announces the guardian's new peer ID with
+// a heartbeat as soon as it has rejoined the gossip mesh.
+func Run(obsvC chan *gossipv1.SignedObservation, govObsvC chan *gossipv1.SignedObservation, obsvReqC chan *gossipv1.ObservationRequest, obsvReqSendC chan *gossipv1.ObservationRequest, sendC chan []byte, govSendC chan []byte, signedInC chan *gossipv1.SignedVAAWithQuorum, priv crypto.PrivKey, gk *node_common.GuardianKeyState, gst *node_common.GuardianSetState, port uint, networkID string, bootstrapPeers string, nodeName string, disableHeartbeatVerify bool, rootCtxCancel context.CancelFunc, gov *governor.ChainGovernor, obsvReqPolicy *node_common.ReobservationPolicy, nodeKeyC <-chan *NodeKeyRotation) func(ctx context.Context) error {
 	return func(ctx context.Context) (re error) {
 		logger := supervisor.Logger(ctx)
 
-		mgr, err := connmgr.NewConnManager(
-			100, // LowWater
-			400, // HighWater,
-			connmgr.WithGracePeriod(time.Minute),
-		)
-		if err != nil {
-			return fmt.Errorf("failed to create p2p connection manager: %w", err)
-		}
-
-		h, err := libp2p.New(
-			// Use the keypair we generated
-			libp2p.Identity(priv),
-
-			// Multiple listen addresses
-			libp2p.ListenAddrStrings(
-				// Listen on QUIC only.
-				// https://github.com/libp2p/go-libp2p/issues/688
-				fmt.Sprintf("/ip4/0.0.0.0/udp/%d/quic", port),
-				fmt.Sprintf("/ip6/::/udp/%d/quic", port),
-			),
-
-			// Enable TLS security as the only security protocol.
-			libp2p.Security(libp2ptls.ID, libp2ptls.New),
-
-			// Enable QUIC transport as the only transport.
-			libp2p.Transport(libp2pquic.NewTransport),
-
-			// Let's prevent our peer from having too many
-			// connections by attaching a connection manager.
-			libp2p.ConnectionManager(mgr),
-
-			// Let this host use the DHT to find other hosts
-			libp2p.Routing(func(h host.Host) (routing.PeerRouting, error) {
-				logger.Info("Connecting to bootstrap peers", zap.String("bootstrap_peers", bootstrapPeers))
-				bootstrappers := make([]peer.AddrInfo, 0)
-				for _, addr := range strings.Split(bootstrapPeers, ",") {
-					if addr == "" {
-						continue
-					}
-					ma, err := multiaddr.NewMultiaddr(addr)
-					if err != nil {
-						logger.Error("Invalid bootstrap address", zap.String("peer", addr), zap.Error(err))
-						continue
-					}
-					pi, err := peer.AddrInfoFromP2pAddr(ma)
-					if err != nil {
-						logger.Error("Invalid bootstrap address", zap.String("peer", addr), zap.Error(err))
-						continue
-					}
-					if pi.ID == h.ID() {
-						logger.Info("We're a bootstrap node")
-						continue
-					}
-					bootstrappers = append(bootstrappers, *pi)
-				}
-				// TODO(leo): Persistent data store (i.e. address book)
-				idht, err := dht.New(ctx, h, dht.Mode(dht.ModeServer),
-					// This intentionally makes us incompatible with the global IPFS DHT
-					dht.ProtocolPrefix(protocol.ID("/"+networkID)),
-					dht.BootstrapPeers(bootstrappers...),
-				)
-				return idht, err
-			}),
-		)
-
-		if err != nil {
-			panic(err)
-		}
-
 		defer func() {
 			// TODO: libp2p cannot be cleanly restarted (https://github.com/libp2p/go-libp2p/issues/992)
 			logger.Error("p2p routine has exited, cancelling root context...", zap.Error(re))
 			rootCtxCancel()
 		}()
 
-		topic := fmt.Sprintf("%s/%s", networkID, "broadcast")
-
-		logger.Info("Subscribing pubsub topic", zap.String("topic", topic))
-		ps, err := pubsub.NewGossipSub(ctx, h)
-		if err != nil {
-			panic(err)
-		}
-
-		th, err := ps.Join(topic)
-		if err != nil {
-			return fmt.Errorf("failed to join topic: %w", err)
-		}
-
-		sub, err := th.Subscribe()
-		if err != nil {
-			return fmt.Errorf("failed to subscribe topic: %w", err)
-		}
-
-		logger.Info("Node has been started", zap.String("peer_id", h.ID().String()),
-			zap.String("addrs", fmt.Sprintf("%v", h.Addrs())))
-
 		bootTime := time.Now()
+		ctr := int64(0)
 
 		// Periodically run guardian state set cleanup.
 		go func() {
@@ -183,21 +119,148 @@ func Run(obsvC chan *gossipv1.SignedObservation, obsvReqC chan *gossipv1.Observa
 			}
 		}()
 
-		go func() {
-			// Disable heartbeat when no node name is provided (spy mode)
-			if nodeName == "" {
-				return
+		// runHost runs a libp2p host with the node key priv until ctx is cancelled, the host fails or a node key rotation
+		// is requested, which it returns. If the host replaces one whose key was rotated, the outcome is reported to rotated.
+		runHost := func(priv crypto.PrivKey, rotated *NodeKeyRotation) (next *NodeKeyRotation, re error) {
+			defer func() {
+				if rotated != nil {
+					rotated.Err <- re
+				}
+			}()
+
+			hostCtx, cancel := context.WithCancel(ctx)
+			var wg sync.WaitGroup
+			var h host.Host
+			defer func() {
+				cancel()
+				wg.Wait()
+				if h != nil {
+					if err := h.Close(); err != nil {
+						logger.Warn("failed to close p2p host", zap.Error(err))
+					}
+				}
+			}()
+
+			mgr, err := connmgr.NewConnManager(
+				100, // LowWater
+				400, // HighWater,
+				connmgr.WithGracePeriod(time.Minute),
+			)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create p2p connection manager: %w", err)
 			}
 
-			ctr := int64(0)
-			tick := time.NewTicker(15 * time.Second)
-			defer tick.Stop()
+			h, err = libp2p.New(
+				// Use the keypair we generated
+				libp2p.Identity(priv),
+
+				// Multiple listen addresses
+				libp2p.ListenAddrStrings(
+					// Listen on QUIC only.
+					// https://github.com/libp2p/go-libp2p/issues/688
+					fmt.Sprintf("/ip4/0.0.0.0/udp/%d/quic", port),
+					fmt.Sprintf("/ip6/::/udp/%d/quic", port),
+				),
+
+				// Enable TLS security as the only security protocol.
+				libp2p.Security(libp2ptls.ID, libp2ptls.New),
+
+				// Enable QUIC transport as the only transport.
+				libp2p.Transport(libp2pquic.NewTransport),
+
+				// Let's prevent our peer from having too many
+				// connections by attaching a connection manager.
+				libp2p.ConnectionManager(mgr),
+
+				// Let this host use the DHT to find other hosts
+				libp2p.Routing(func(h host.Host) (routing.PeerRouting, error) {
+					logger.Info("Connecting to bootstrap peers", zap.String("bootstrap_peers", bootstrapPeers))
+					bootstrappers := make([]peer.AddrInfo, 0)
+					for _, addr := range strings.Split(bootstrapPeers, ",") {
+						if addr == "" {
+							continue
+						}
+						ma, err := multiaddr.NewMultiaddr(addr)
+						if err != nil {
+							logger.Error("Invalid bootstrap address", zap.String("peer", addr), zap.Error(err))
+							continue
+						}
+						pi, err := peer.AddrInfoFromP2pAddr(ma)
+						if err != nil {
+							logger.Error("Invalid bootstrap address", zap.String("peer", addr), zap.Error(err))
+							continue
+						}
+						if pi.ID == h.ID() {
+							logger.Info("We're a bootstrap node")
+							continue
+						}
+						bootstrappers = append(bootstrappers, *pi)
+					}
+					// TODO(leo): Persistent data store (i.e. address book)
+					idht, err := dht.New(hostCtx, h, dht.Mode(dht.ModeServer),
+						// This intentionally makes us incompatible with the global IPFS DHT
+						dht.ProtocolPrefix(protocol.ID("/"+networkID)),
+						dht.BootstrapPeers(bootstrappers...),
+					)
+					return idht, err
+				}),
+			)
 
-			for {
+			if err != nil {
+				return nil, fmt.Errorf("failed to create p2p host: %w", err)
+			}
+
+			topic := fmt.Sprintf("%s/%s", networkID, "broadcast")
+
+			logger.Info("Subscribing pubsub topic", zap.String("topic", topic))
+			ps, err := pubsub.NewGossipSub(hostCtx, h)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create pubsub: %w", err)
+			}
+
+			th, err := ps.Join(topic)
+			if err != nil {
+				return nil, fmt.Errorf("failed to join topic: %w", err)
+			}
+
+			sub, err := th.Subscribe()
+			if err != nil {
+				return nil, fmt.Errorf("failed to subscribe topic: %w", err)
+			}
+
+			logger.Info("Node has been started", zap.String("peer_id", h.ID().String()),
+				zap.String("addrs", fmt.Sprintf("%v", h.Addrs())))
+
+			announce := rotated != nil
+			if announce {
+				p2pNodeKeyRotations.Inc()
+				rotated.Err <- nil
+				rotated = nil
+			}
+
+			rotationC := make(chan *NodeKeyRotation, 1)
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
 				select {
-				case <-ctx.Done():
+				case <-hostCtx.Done():
+				case r := <-nodeKeyC:
+					logger.Info("Rotating node key, restarting p2p host", zap.String("peer_id", h.ID().String()))
+					rotationC <- r
+					cancel()
+				}
+			}()
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				// Disable heartbeat when no node name is provided (spy mode)
+				if nodeName == "" {
 					return
-				case <-tick.C:
+				}
+
+				sendHeartbeat := func() {
 					DefaultRegistry.mu.Lock()
 					networks := make([]*gossipv1.Heartbeat_Network, 0, len(DefaultRegistry.networkStats))
 					for _, v := range DefaultRegistry.networkStats {
@@ -211,25 +274,28 @@ func Run(obsvC chan *gossipv1.SignedObservation, obsvReqC chan *gossipv1.Observa
 						features = append(features, "governor")
 					}
 
+					// The guardian key switches during guardian set transitions, so use the active key throughout.
+					key := gk.Get()
+					ourAddr := ethcrypto.PubkeyToAddress(key.PublicKey)
+
 					heartbeat := &gossipv1.Heartbeat{
 						NodeName:      nodeName,
 						Counter:       ctr,
 						Timestamp:     time.Now().UnixNano(),
 						Networks:      networks,
 						Version:       version.Version(),
-						GuardianAddr:  DefaultRegistry.guardianAddress,
+						GuardianAddr:  ourAddr.String(),
 						BootTimestamp: bootTime.UnixNano(),
 						Features:      features,
 					}
 
-					ourAddr := ethcrypto.PubkeyToAddress(gk.PublicKey)
 					if err := gst.SetHeartbeat(ourAddr, h.ID(), heartbeat); err != nil {
 						panic(err)
 					}
 					collectNodeMetrics(ourAddr, h.ID(), heartbeat)
 
 					if gov != nil {
-						gov.CollectMetrics(heartbeat, sendC, gk, ourAddr)
+						gov.CollectMetrics(heartbeat, sendC, key, ourAddr)
 					}
 
 					b, err := proto.Marshal(heartbeat)
@@ -240,25 +306,21 @@ func Run(obsvC chan *gossipv1.SignedObservation, obsvReqC chan *gossipv1.Observa
 					DefaultRegistry.mu.Unlock()
 
 					// Sign the heartbeat using our node's guardian key.
-					digest := heartbeatDigest(b)
-					sig, err := ethcrypto.Sign(digest.Bytes(), gk)
+					signed, err := signHeartbeat(b, key)
 					if err != nil {
 						panic(err)
 					}
+					gst.SetSignedHeartbeat(ourAddr, &node_common.SignedHeartbeat{PeerID: h.ID(), Signed: signed, Heartbeat: heartbeat})
 
 					msg := gossipv1.GossipMessage{Message: &gossipv1.GossipMessage_SignedHeartbeat{
-						SignedHeartbeat: &gossipv1.SignedHeartbeat{
-							Heartbeat:    b,
-							Signature:    sig,
-							GuardianAddr: ourAddr.Bytes(),
-						}}}
+						SignedHeartbeat: signed}}
 
 					b, err = proto.Marshal(&msg)
 					if err != nil {
 						panic(err)
 					}
 
-					err = th.Publish(ctx, b)
+					err = th.Publish(hostCtx, b)
 					if err != nil {
 						logger.Warn("failed to publish heartbeat message", zap.Error(err))
 					}
@@ -266,162 +328,243 @@ func Run(obsvC chan *gossipv1.SignedObservation, obsvReqC chan *gossipv1.Observa
 					p2pHeartbeatsSent.Inc()
 					ctr += 1
 				}
-			}
-		}()
 
-		go func() {
-			for {
-				select {
-				case <-ctx.Done():
-					return
-				case msg := <-sendC:
-					err := th.Publish(ctx, msg)
-					p2pMessagesSent.Inc()
-					if err != nil {
-						logger.Error("failed to publish message from queue", zap.Error(err))
+				tick := time.NewTicker(15 * time.Second)
+				defer tick.Stop()
+
+				if announce {
+					// Announce the new peer ID once the host has gossip peers again, rather than at the next tick.
+					deadline := time.Now().Add(announceTimeout)
+					for len(th.ListPeers()) == 0 && time.Now().Before(deadline) {
+						select {
+						case <-hostCtx.Done():
+							return
+						case <-time.After(time.Second):
+						}
 					}
-				case msg := <-obsvReqSendC:
-					b, err := proto.Marshal(msg)
-					if err != nil {
-						panic(err)
+					sendHeartbeat()
+				}
+
+				for {
+					select {
+					case <-hostCtx.Done():
+						return
+					case <-tick.C:
+						sendHeartbeat()
 					}
+				}
+			}()
 
-					// Sign the observation request using our node's guardian key.
-					digest := signedObservationRequestDigest(b)
-					sig, err := ethcrypto.Sign(digest.Bytes(), gk)
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				publish := func(msg []byte) {
+					err := th.Publish(hostCtx, msg)
+					p2pMessagesSent.Inc()
 					if err != nil {
-						panic(err)
+						logger.Error("failed to publish message from queue", zap.Error(err))
 					}
+				}
 
-					sReq := &gossipv1.SignedObservationRequest{
-						ObservationRequest: b,
-						Signature:          sig,
-						GuardianAddr:       ethcrypto.PubkeyToAddress(gk.PublicKey).Bytes(),
+				for {
+					// Governance messages jump the queue
+					select {
+					case msg := <-govSendC:
+						publish(msg)
+						continue
+					default:
 					}
 
-					envelope := &gossipv1.GossipMessage{
-						Message: &gossipv1.GossipMessage_SignedObservationRequest{
-							SignedObservationRequest: sReq}}
-
-					b, err = proto.Marshal(envelope)
-					if err != nil {
-						panic(err)
+					select {
+					case <-hostCtx.Done():
+						return
+					case msg := <-govSendC:
+						publish(msg)
+					case msg := <-sendC:
+						publish(msg)
+					case msg := <-obsvReqSendC:
+						b, err := proto.Marshal(msg)
+						if err != nil {
+							panic(err)
+						}
+
+						// Sign the observation request using our node's guardian key.
+						key := gk.Get()
+						digest := signedObservationRequestDigest(b)
+						sig, err := ethcrypto.Sign(digest.Bytes(), key)
+						if err != nil {
+							panic(err)
+						}
+
+						sReq := &gossipv1.SignedObservationRequest{
+							ObservationRequest: b,
+							Signature:          sig,
+							GuardianAddr:       ethcrypto.PubkeyToAddress(key.PublicKey).Bytes(),
+						}
+
+						envelope := &gossipv1.GossipMessage{
+							Message: &gossipv1.GossipMessage_SignedObservationRequest{
+								SignedObservationRequest: sReq}}
+
+						b, err = proto.Marshal(envelope)
+						if err != nil {
+							panic(err)
+						}
+
+						// Send to local observation request queue (the loopback message is ignored)
+						obsvReqC <- msg
+
+						err = th.Publish(hostCtx, b)
+						p2pMessagesSent.Inc()
+						if err != nil {
+							logger.Error("failed to publish observation request", zap.Error(err))
+						} else {
+							logger.Info("published signed observation request", zap.Any("signed_observation_request", sReq))
+						}
 					}
+				}
+			}()
 
-					// Send to local observation request queue (the loopback message is ignored)
-					obsvReqC <- msg
-
-					err = th.Publish(ctx, b)
-					p2pMessagesSent.Inc()
-					if err != nil {
-						logger.Error("failed to publish observation request", zap.Error(err))
-					} else {
-						logger.Info("published signed observation request", zap.Any("signed_observation_request", sReq))
+			for {
+				envelope, err := sub.Next(hostCtx)
+				if err != nil {
+					select {
+					case next := <-rotationC:
+						return next, nil
+					default:
 					}
+					return nil, fmt.Errorf("failed to receive pubsub message: %w", err)
 				}
-			}
-		}()
 
-		for {
-			envelope, err := sub.Next(ctx)
-			if err != nil {
-				return fmt.Errorf("failed to receive pubsub message: %w", err)
-			}
+				var msg gossipv1.GossipMessage
+				err = proto.Unmarshal(envelope.Data, &msg)
+				if err != nil {
+					logger.Info("received invalid message",
+						zap.Binary("data", envelope.Data),
+						zap.String("from", envelope.GetFrom().String()))
+					p2pMessagesReceived.WithLabelValues("invalid").Inc()
+					continue
+				}
 
-			var msg gossipv1.GossipMessage
-			err = proto.Unmarshal(envelope.Data, &msg)
-			if err != nil {
-				logger.Info("received invalid message",
-					zap.Binary("data", envelope.Data),
+				if envelope.GetFrom() == h.ID() {
+					logger.Debug("received message from ourselves, ignoring",
+						zap.Any("payload", msg.Message))
+					p2pMessagesReceived.WithLabelValues("loopback").Inc()
+					continue
+				}
+
+				logger.Debug("received message",
+					zap.Any("payload", msg.Message),
+					zap.Binary("raw", envelope.Data),
 					zap.String("from", envelope.GetFrom().String()))
-				p2pMessagesReceived.WithLabelValues("invalid").Inc()
-				continue
-			}
 
-			if envelope.GetFrom() == h.ID() {
-				logger.Debug("received message from ourselves, ignoring",
-					zap.Any("payload", msg.Message))
-				p2pMessagesReceived.WithLabelValues("loopback").Inc()
-				continue
-			}
+				switch m := msg.Message.(type) {
+				case *gossipv1.GossipMessage_SignedHeartbeat:
+					s := m.SignedHeartbeat
+					gs := gst.Get()
+					if gs == nil {
+						// No valid guardian set yet - dropping heartbeat
+						logger.Debug("skipping heartbeat - no guardian set",
+							zap.Any("value", s),
+							zap.String("from", envelope.GetFrom().String()))
+						break
+					}
+					if heartbeat, err := processSignedHeartbeat(envelope.GetFrom(), s, gs, gst, disableHeartbeatVerify); err != nil {
+						p2pMessagesReceived.WithLabelValues("invalid_heartbeat").Inc()
+						logger.Debug("invalid signed heartbeat received",
+							zap.Error(err),
+							zap.Any("payload", msg.Message),
+							zap.Any("value", s),
+							zap.Binary("raw", envelope.Data),
+							zap.String("from", envelope.GetFrom().String()))
+					} else {
+						p2pMessagesReceived.WithLabelValues("valid_heartbeat").Inc()
+						logger.Debug("valid signed heartbeat received",
+							zap.Any("value", heartbeat),
+							zap.String("from", envelope.GetFrom().String()))
+					}
+				case *gossipv1.GossipMessage_SignedObservation:
+					if govObsvC != nil && isGovernanceObservation(m.SignedObservation, gst.Get()) {
+						govObsvC <- m.SignedObservation
+						p2pMessagesReceived.WithLabelValues("governance_observation").Inc()
+					} else {
+						obsvC <- m.SignedObservation
+						p2pMessagesReceived.WithLabelValues("observation").Inc()
+					}
+				case *gossipv1.GossipMessage_SignedVaaWithQuorum:
+					signedInC <- m.SignedVaaWithQuorum
+					p2pMessagesReceived.WithLabelValues("signed_vaa_with_quorum").Inc()
+				case *gossipv1.GossipMessage_SignedObservationRequest:
+					s := m.SignedObservationRequest
+					gs := gst.Get()
+					if gs == nil {
+						logger.Debug("dropping SignedObservationRequest - no guardian set",
+							zap.Any("value", s),
+							zap.String("from", envelope.GetFrom().String()))
+						break
+					}
+					r, err := processSignedObservationRequest(s, gs)
+					if err != nil {
+						p2pMessagesReceived.WithLabelValues("invalid_signed_observation_request").Inc()
+						logger.Debug("invalid signed observation request received",
+							zap.Error(err),
+							zap.Any("payload", msg.Message),
+							zap.Any("value", s),
+							zap.Binary("raw", envelope.Data),
+							zap.String("from", envelope.GetFrom().String()))
+					} else if signer := common.BytesToAddress(s.GuardianAddr); !obsvReqPolicy.AllowsGuardian(signer) {
+						p2pMessagesReceived.WithLabelValues("ignored_signed_observation_request").Inc()
+						logger.Debug("ignoring signed observation request from guardian not allowed by policy",
+							zap.Any("value", r),
+							zap.String("guardian", signer.Hex()),
+							zap.String("from", envelope.GetFrom().String()))
+					} else {
+						p2pMessagesReceived.WithLabelValues("signed_observation_request").Inc()
+						logger.Info("valid signed observation request received",
+							zap.Any("value", r),
+							zap.String("from", envelope.GetFrom().String()))
 
-			logger.Debug("received message",
-				zap.Any("payload", msg.Message),
-				zap.Binary("raw", envelope.Data),
-				zap.String("from", envelope.GetFrom().String()))
-
-			switch m := msg.Message.(type) {
-			case *gossipv1.GossipMessage_SignedHeartbeat:
-				s := m.SignedHeartbeat
-				gs := gst.Get()
-				if gs == nil {
-					// No valid guardian set yet - dropping heartbeat
-					logger.Debug("skipping heartbeat - no guardian set",
-						zap.Any("value", s),
-						zap.String("from", envelope.GetFrom().String()))
-					break
-				}
-				if heartbeat, err := processSignedHeartbeat(envelope.GetFrom(), s, gs, gst, disableHeartbeatVerify); err != nil {
-					p2pMessagesReceived.WithLabelValues("invalid_heartbeat").Inc()
-					logger.Debug("invalid signed heartbeat received",
-						zap.Error(err),
+						obsvReqC <- r
+					}
+				case *gossipv1.GossipMessage_SignedChainGovernorConfig:
+					logger.Debug("cgov: received config message")
+				case *gossipv1.GossipMessage_SignedChainGovernorStatus:
+					logger.Debug("cgov: received status message")
+				default:
+					p2pMessagesReceived.WithLabelValues("unknown").Inc()
+					logger.Warn("received unknown message type (running outdated software?)",
 						zap.Any("payload", msg.Message),
-						zap.Any("value", s),
 						zap.Binary("raw", envelope.Data),
 						zap.String("from", envelope.GetFrom().String()))
-				} else {
-					p2pMessagesReceived.WithLabelValues("valid_heartbeat").Inc()
-					logger.Debug("valid signed heartbeat received",
-						zap.Any("value", heartbeat),
-						zap.String("from", envelope.GetFrom().String()))
 				}
-			case *gossipv1.GossipMessage_SignedObservation:
-				obsvC <- m.SignedObservation
-				p2pMessagesReceived.WithLabelValues("observation").Inc()
-			case *gossipv1.GossipMessage_SignedVaaWithQuorum:
-				signedInC <- m.SignedVaaWithQuorum
-				p2pMessagesReceived.WithLabelValues("signed_vaa_with_quorum").Inc()
-			case *gossipv1.GossipMessage_SignedObservationRequest:
-				s := m.SignedObservationRequest
-				gs := gst.Get()
-				if gs == nil {
-					logger.Debug("dropping SignedObservationRequest - no guardian set",
-						zap.Any("value", s),
-						zap.String("from", envelope.GetFrom().String()))
-					break
-				}
-				r, err := processSignedObservationRequest(s, gs)
-				if err != nil {
-					p2pMessagesReceived.WithLabelValues("invalid_signed_observation_request").Inc()
-					logger.Debug("invalid signed observation request received",
-						zap.Error(err),
-						zap.Any("payload", msg.Message),
-						zap.Any("value", s),
-						zap.Binary("raw", envelope.Data),
-						zap.String("from", envelope.GetFrom().String()))
-				} else {
-					p2pMessagesReceived.WithLabelValues("signed_observation_request").Inc()
-					logger.Info("valid signed observation request received",
-						zap.Any("value", r),
-						zap.String("from", envelope.GetFrom().String()))
+			}
+		}
 
-					obsvReqC <- r
-				}
-			case *gossipv1.GossipMessage_SignedChainGovernorConfig:
-				logger.Debug("cgov: received config message")
-			case *gossipv1.GossipMessage_SignedChainGovernorStatus:
-				logger.Debug("cgov: received status message")
-			default:
-				p2pMessagesReceived.WithLabelValues("unknown").Inc()
-				logger.Warn("received unknown message type (running outdated software?)",
-					zap.Any("payload", msg.Message),
-					zap.Binary("raw", envelope.Data),
-					zap.String("from", envelope.GetFrom().String()))
+		var rotated *NodeKeyRotation
+		for {
+			next, err := runHost(priv, rotated)
+			if err != nil {
+				return err
 			}
+			priv, rotated = next.Key, next
 		}
 	}
 }
 
+// signHeartbeat signs the marshalled heartbeat b with the guardian key gk.
+func signHeartbeat(b []byte, gk *ecdsa.PrivateKey) (*gossipv1.SignedHeartbeat, error) {
+	sig, err := ethcrypto.Sign(heartbeatDigest(b).Bytes(), gk)
+	if err != nil {
+		return nil, err
+	}
+	return &gossipv1.SignedHeartbeat{
+		Heartbeat:    b,
+		Signature:    sig,
+		GuardianAddr: ethcrypto.PubkeyToAddress(gk.PublicKey).Bytes(),
+	}, nil
+}
+
 func processSignedHeartbeat(from peer.ID, s *gossipv1.SignedHeartbeat, gs *node_common.GuardianSet, gst *node_common.GuardianSetState, disableVerify bool) (*gossipv1.Heartbeat, error) {
 	envelopeAddr := common.BytesToAddress(s.GuardianAddr)
 	idx, ok := gs.KeyIndex(envelopeAddr)
@@ -457,11 +600,27 @@ func processSignedHeartbeat(from peer.ID, s *gossipv1.SignedHeartbeat, gs *node_
 		return nil, fmt.Errorf("failed to store in guardian set state: %w", err)
 	}
 
+	// Only heartbeats signed by a member of the guardian set are exported with their signature.
+	if ok && pk == signerAddr {
+		gst.SetSignedHeartbeat(signerAddr, &node_common.SignedHeartbeat{PeerID: from, Signed: s, Heartbeat: &h})
+	}
+
 	collectNodeMetrics(signerAddr, from, &h)
 
 	return &h, nil
 }
 
+// isGovernanceObservation returns true if o is an observation of a governance message that claims to be from a member
+// of gs. It only classifies observations by their message ID and address, so that it stays cheap on the receive path.
+// The signature is verified by the processor, like for any other observation.
+func isGovernanceObservation(o *gossipv1.SignedObservation, gs *node_common.GuardianSet) bool {
+	if gs == nil || !node_common.IsGovernanceMessageID(o.MessageId) {
+		return false
+	}
+	_, ok := gs.KeyIndex(common.BytesToAddress(o.Addr))
+	return ok
+}
+
 func processSignedObservationRequest(s *gossipv1.SignedObservationRequest, gs *node_common.GuardianSet) (*gossipv1.ObservationRequest, error) {
 	envelopeAddr := common.BytesToAddress(s.GuardianAddr)
 	idx, ok := gs.KeyIndex(envelopeAddr)
@@ -0,0 +1,127 @@
+package p2p
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"net"
+	"testing"
+	"time"
+
+	node_common "github.com/certusone/wormhole/node/pkg/common"
+	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
+	"github.com/certusone/wormhole/node/pkg/supervisor"
+	"github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestIsGovernanceObservation(t *testing.T) {
+	gk, err := ethcrypto.GenerateKey()
+	require.NoError(t, err)
+	outsider, err := ethcrypto.GenerateKey()
+	require.NoError(t, err)
+	gs := &node_common.GuardianSet{Keys: []common.Address{ethcrypto.PubkeyToAddress(gk.PublicKey)}}
+
+	governance := &vaa.VAA{EmitterChain: vaa.GovernanceChain, EmitterAddress: vaa.GovernanceEmitter, Sequence: 1}
+	other := &vaa.VAA{EmitterChain: vaa.ChainIDEthereum, EmitterAddress: vaa.Address{31: 0x01}, Sequence: 1}
+
+	observation := func(v *vaa.VAA, signer *ecdsa.PrivateKey, addr common.Address) *gossipv1.SignedObservation {
+		digest := v.SigningMsg()
+		sig, err := ethcrypto.Sign(digest.Bytes(), signer)
+		require.NoError(t, err)
+		return &gossipv1.SignedObservation{Addr: addr.Bytes(), Hash: digest.Bytes(), Signature: sig, MessageId: v.MessageID()}
+	}
+	guardianAddr := ethcrypto.PubkeyToAddress(gk.PublicKey)
+
+	assert.True(t, isGovernanceObservation(observation(governance, gk, guardianAddr), gs))
+	assert.False(t, isGovernanceObservation(observation(governance, gk, guardianAddr), nil))
+	assert.False(t, isGovernanceObservation(observation(other, gk, guardianAddr), gs))
+	// Observations by peers outside the guardian set are not prioritized
+	assert.False(t, isGovernanceObservation(observation(governance, outsider, ethcrypto.PubkeyToAddress(outsider.PublicKey)), gs))
+	// Signatures are left to the processor
+	assert.True(t, isGovernanceObservation(observation(governance, outsider, guardianAddr), gs))
+}
+
+func TestHeartbeatSignedByActiveGuardianKey(t *testing.T) {
+	oldKey, err := ethcrypto.GenerateKey()
+	require.NoError(t, err)
+	newKey, err := ethcrypto.GenerateKey()
+	require.NoError(t, err)
+	gk := node_common.NewGuardianKeyState(oldKey, newKey)
+	gs := &node_common.GuardianSet{Keys: []common.Address{ethcrypto.PubkeyToAddress(newKey.PublicKey)}, Index: 1}
+	gst := node_common.NewGuardianSetState()
+	from := peer.ID("peer")
+
+	b, err := proto.Marshal(&gossipv1.Heartbeat{NodeName: "test"})
+	require.NoError(t, err)
+
+	// Until the switch, heartbeats are signed with the old key and rejected by the new guardian set
+	signed, err := signHeartbeat(b, gk.Get())
+	require.NoError(t, err)
+	_, err = processSignedHeartbeat(from, signed, gs, gst, false)
+	assert.Error(t, err)
+
+	// The processor switches the shared key on the guardian set update
+	switched, _ := gk.Update(gs)
+	require.True(t, switched)
+	signed, err = signHeartbeat(b, gk.Get())
+	require.NoError(t, err)
+	assert.Equal(t, ethcrypto.PubkeyToAddress(newKey.PublicKey).Bytes(), signed.GuardianAddr)
+	hb, err := processSignedHeartbeat(from, signed, gs, gst, false)
+	require.NoError(t, err)
+	assert.Equal(t, "test", hb.NodeName)
+}
+
+func TestRunRotatesNodeKey(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The rotated host listens on the same port again.
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := uint(conn.LocalAddr().(*net.UDPAddr).Port)
+	require.NoError(t, conn.Close())
+
+	priv, _, err := crypto.GenerateKeyPair(crypto.Ed25519, -1)
+	require.NoError(t, err)
+	nodeKeyC := make(chan *NodeKeyRotation)
+	errC := make(chan error, 1)
+	supervisor.New(ctx, zap.NewNop(), func(ctx context.Context) error {
+		errC <- Run(make(chan *gossipv1.SignedObservation), nil, make(chan *gossipv1.ObservationRequest), nil, make(chan []byte), nil,
+			make(chan *gossipv1.SignedVAAWithQuorum), priv, nil, node_common.NewGuardianSetState(), port, "/wormhole/test", "", "", false,
+			func() {}, nil, nil, nodeKeyC)(ctx)
+		<-ctx.Done()
+		return nil
+	})
+
+	for i := 0; i < 2; i++ {
+		key, _, err := crypto.GenerateKeyPair(crypto.Ed25519, -1)
+		require.NoError(t, err)
+		rotation := &NodeKeyRotation{Key: key, Err: make(chan error, 1)}
+		select {
+		case nodeKeyC <- rotation:
+		case err := <-errC:
+			t.Fatalf("p2p exited: %v", err)
+		case <-time.After(10 * time.Second):
+			t.Fatal("rotation was not accepted")
+		}
+		select {
+		case err := <-rotation.Err:
+			require.NoError(t, err)
+		case <-time.After(10 * time.Second):
+			t.Fatal("node did not rejoin with the new key")
+		}
+	}
+
+	select {
+	case err := <-errC:
+		t.Fatalf("p2p exited: %v", err)
+	default:
+	}
+}
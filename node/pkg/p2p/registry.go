@@ -1,10 +1,13 @@
 package p2p
 
 import (
+	"sort"
 	"sync"
+	"time"
 
 	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
 	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"google.golang.org/protobuf/proto"
 )
 
 // The p2p package implements a simple global metrics registry singleton for node status values transmitted on-chain.
@@ -15,17 +18,34 @@ type registry struct {
 	// Mapping of chain IDs to network status messages.
 	networkStats map[vaa.ChainID]*gossipv1.Heartbeat_Network
 
+	// Last height reported by each watcher instance, and the time at which it last changed.
+	progress map[watcherInstance]progress
+
+	// Watcher instances flagged as stalled by the watchdog.
+	stalled map[watcherInstance]bool
+
 	// Per-chain error counters
 	errorCounters  map[vaa.ChainID]uint64
 	errorCounterMu sync.Mutex
+}
+
+// watcherInstance identifies one of the possibly several watchers observing a chain, like the confirmed and the
+// finalized Solana watchers. Chains with a single watcher use the empty instance name.
+type watcherInstance struct {
+	chain vaa.ChainID
+	name  string
+}
 
-	// Value of Heartbeat.guardian_addr.
-	guardianAddress string
+type progress struct {
+	height int64
+	at     time.Time
 }
 
 func NewRegistry() *registry {
 	return &registry{
 		networkStats:  map[vaa.ChainID]*gossipv1.Heartbeat_Network{},
+		progress:      map[watcherInstance]progress{},
+		stalled:       map[watcherInstance]bool{},
 		errorCounters: map[vaa.ChainID]uint64{},
 	}
 }
@@ -34,23 +54,85 @@ var (
 	DefaultRegistry = NewRegistry()
 )
 
-// SetGuardianAddress stores the node's guardian address to broadcast in Heartbeat messages.
-// This should be called once during startup, when the guardian key is loaded.
-func (r *registry) SetGuardianAddress(addr string) {
-	r.mu.Lock()
-	r.guardianAddress = addr
-	r.mu.Unlock()
-}
-
 // SetNetworkStats sets the current network status to be broadcast in Heartbeat messages.
 // The "Id" field is automatically set to the specified chain ID.
 func (r *registry) SetNetworkStats(chain vaa.ChainID, data *gossipv1.Heartbeat_Network) {
+	r.SetInstanceNetworkStats(chain, "", data)
+}
+
+// SetInstanceNetworkStats is like SetNetworkStats for chains observed by several watchers, tracking the progress of
+// each watcher instance separately. The Heartbeat carries the status last reported by any of them.
+func (r *registry) SetInstanceNetworkStats(chain vaa.ChainID, instance string, data *gossipv1.Heartbeat_Network) {
 	r.mu.Lock()
 	data.Id = uint32(chain)
+	key := watcherInstance{chain, instance}
+	if prev, exists := r.progress[key]; !exists || prev.height != data.Height {
+		r.progress[key] = progress{height: data.Height, at: time.Now()}
+	}
+	data.Stalled = r.chainStalled(chain)
 	r.networkStats[chain] = data
 	r.mu.Unlock()
 }
 
+// LastProgress returns the time at which the height reported by the given watcher instance of chain last changed, or
+// the zero time if it did not report any network status yet.
+func (r *registry) LastProgress(chain vaa.ChainID, instance string) time.Time {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.progress[watcherInstance{chain, instance}].at
+}
+
+// SetStalled flags the given watcher instance of chain as stalled (or recovered). Heartbeat messages flag the chain
+// as stalled as long as any of its watcher instances is.
+func (r *registry) SetStalled(chain vaa.ChainID, instance string, stalled bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := watcherInstance{chain, instance}
+	if stalled {
+		r.stalled[key] = true
+	} else {
+		delete(r.stalled, key)
+	}
+	if data, exists := r.networkStats[chain]; exists {
+		data.Stalled = r.chainStalled(chain)
+	}
+}
+
+// chainStalled reports whether any watcher instance of chain is flagged as stalled. r.mu must be held.
+func (r *registry) chainStalled(chain vaa.ChainID) bool {
+	for key := range r.stalled {
+		if key.chain == chain {
+			return true
+		}
+	}
+	return false
+}
+
+// ContractAddresses returns the contract address reported by the watcher of each chain.
+func (r *registry) ContractAddresses() map[vaa.ChainID]string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	addrs := make(map[vaa.ChainID]string, len(r.networkStats))
+	for chain, data := range r.networkStats {
+		if data.ContractAddress != "" {
+			addrs[chain] = data.ContractAddress
+		}
+	}
+	return addrs
+}
+
+// NetworkStats returns a copy of the network status of each chain, ordered by chain ID.
+func (r *registry) NetworkStats() []*gossipv1.Heartbeat_Network {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stats := make([]*gossipv1.Heartbeat_Network, 0, len(r.networkStats))
+	for _, data := range r.networkStats {
+		stats = append(stats, proto.Clone(data).(*gossipv1.Heartbeat_Network))
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Id < stats[j].Id })
+	return stats
+}
+
 func (r *registry) AddErrorCount(chain vaa.ChainID, delta uint64) {
 	r.errorCounterMu.Lock()
 	defer r.errorCounterMu.Unlock()
@@ -8,22 +8,16 @@ import (
 	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
 	"github.com/mr-tron/base58"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/wormhole-foundation/wormhole/sdk/vaa"
 )
 
 func TestNewRegistry(t *testing.T) {
 	registry := NewRegistry()
-	assert.Equal(t, "", registry.guardianAddress)
 	assert.Equal(t, 0, len(registry.errorCounters))
 	assert.Equal(t, 0, len(registry.networkStats))
 }
 
-func TestSetGuardianAddress(t *testing.T) {
-	registry := NewRegistry()
-	registry.SetGuardianAddress("foo")
-	assert.Equal(t, "foo", registry.guardianAddress)
-}
-
 func TestSetNetworkStats(t *testing.T) {
 	registry := NewRegistry()
 
@@ -41,6 +35,31 @@ func TestSetNetworkStats(t *testing.T) {
 	assert.Equal(t, expect, registry.networkStats)
 }
 
+func TestContractAddresses(t *testing.T) {
+	registry := NewRegistry()
+	registry.SetNetworkStats(vaa.ChainIDEthereum, &gossipv1.Heartbeat_Network{ContractAddress: "0x98f3c9e6E3fAce36bAAd05FE09d375Ef1464288B"})
+	// Chains that have not reported a contract address are omitted
+	registry.SetNetworkStats(vaa.ChainIDSolana, &gossipv1.Heartbeat_Network{Height: 1})
+
+	assert.Equal(t, map[vaa.ChainID]string{vaa.ChainIDEthereum: "0x98f3c9e6E3fAce36bAAd05FE09d375Ef1464288B"}, registry.ContractAddresses())
+}
+
+func TestNetworkStats(t *testing.T) {
+	registry := NewRegistry()
+	registry.SetNetworkStats(vaa.ChainIDEthereum, &gossipv1.Heartbeat_Network{Height: 2})
+	registry.SetNetworkStats(vaa.ChainIDSolana, &gossipv1.Heartbeat_Network{Height: 1})
+
+	stats := registry.NetworkStats()
+	require.Len(t, stats, 2)
+	assert.Equal(t, uint32(vaa.ChainIDSolana), stats[0].Id)
+	assert.Equal(t, int64(1), stats[0].Height)
+	assert.Equal(t, uint32(vaa.ChainIDEthereum), stats[1].Id)
+
+	// The returned stats are copies
+	stats[1].Height = 3
+	assert.Equal(t, int64(2), registry.networkStats[vaa.ChainIDEthereum].Height)
+}
+
 func TestAddErrorCount(t *testing.T) {
 	registry := NewRegistry()
 
@@ -60,3 +79,57 @@ func TestGetErrorCount(t *testing.T) {
 	assert.Equal(t, uint64(1), registry.GetErrorCount(vaa.ChainIDEthereum))
 	assert.Equal(t, uint64(0), registry.GetErrorCount(vaa.ChainIDSolana))
 }
+
+func TestLastProgress(t *testing.T) {
+	registry := NewRegistry()
+	assert.True(t, registry.LastProgress(vaa.ChainIDEthereum, "").IsZero())
+
+	registry.SetNetworkStats(vaa.ChainIDEthereum, &gossipv1.Heartbeat_Network{Height: 1})
+	first := registry.LastProgress(vaa.ChainIDEthereum, "")
+	assert.False(t, first.IsZero())
+
+	// Reporting the same height again is not progress.
+	registry.SetNetworkStats(vaa.ChainIDEthereum, &gossipv1.Heartbeat_Network{Height: 1})
+	assert.Equal(t, first, registry.LastProgress(vaa.ChainIDEthereum, ""))
+
+	registry.SetNetworkStats(vaa.ChainIDEthereum, &gossipv1.Heartbeat_Network{Height: 2})
+	assert.False(t, registry.LastProgress(vaa.ChainIDEthereum, "").Before(first))
+}
+
+func TestSetStalled(t *testing.T) {
+	registry := NewRegistry()
+	registry.SetNetworkStats(vaa.ChainIDEthereum, &gossipv1.Heartbeat_Network{Height: 1})
+
+	registry.SetStalled(vaa.ChainIDEthereum, "", true)
+	assert.True(t, registry.networkStats[vaa.ChainIDEthereum].Stalled)
+
+	// The flag survives new network stats until it is cleared.
+	registry.SetNetworkStats(vaa.ChainIDEthereum, &gossipv1.Heartbeat_Network{Height: 2})
+	assert.True(t, registry.networkStats[vaa.ChainIDEthereum].Stalled)
+
+	registry.SetStalled(vaa.ChainIDEthereum, "", false)
+	assert.False(t, registry.networkStats[vaa.ChainIDEthereum].Stalled)
+}
+
+func TestInstanceProgress(t *testing.T) {
+	registry := NewRegistry()
+
+	registry.SetInstanceNetworkStats(vaa.ChainIDSolana, "confirmed", &gossipv1.Heartbeat_Network{Height: 10})
+	registry.SetInstanceNetworkStats(vaa.ChainIDSolana, "finalized", &gossipv1.Heartbeat_Network{Height: 1})
+	finalized := registry.LastProgress(vaa.ChainIDSolana, "finalized")
+	assert.False(t, finalized.IsZero())
+
+	// Progress of one instance is not progress of the other, even though they alternate reporting different heights.
+	registry.SetInstanceNetworkStats(vaa.ChainIDSolana, "confirmed", &gossipv1.Heartbeat_Network{Height: 11})
+	registry.SetInstanceNetworkStats(vaa.ChainIDSolana, "finalized", &gossipv1.Heartbeat_Network{Height: 1})
+	assert.Equal(t, finalized, registry.LastProgress(vaa.ChainIDSolana, "finalized"))
+	assert.True(t, registry.LastProgress(vaa.ChainIDSolana, "").IsZero())
+
+	// The chain is stalled as long as any of its instances is.
+	registry.SetStalled(vaa.ChainIDSolana, "finalized", true)
+	registry.SetStalled(vaa.ChainIDSolana, "confirmed", false)
+	assert.True(t, registry.networkStats[vaa.ChainIDSolana].Stalled)
+
+	registry.SetStalled(vaa.ChainIDSolana, "finalized", false)
+	assert.False(t, registry.networkStats[vaa.ChainIDSolana].Stalled)
+}
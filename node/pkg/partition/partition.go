@@ -0,0 +1,271 @@
+// Package partition detects partial partitions of the guardian p2p network. It compares the guardians the node
+// receives heartbeats from, and the signatures its own observations collect, with the size of the guardian set.
+//
+// A node on the wrong side of a partition still observes messages and signs them, but its observations never reach
+// quorum. The detector makes this visible before VAAs go missing: it flags the network as degraded while some
+// guardians are unreachable and as partitioned once fewer guardians than a quorum are, or most observations fail.
+package partition
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	"github.com/certusone/wormhole/node/pkg/supervisor"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+)
+
+var (
+	reachableGuardians = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "wormhole_partition_reachable_guardians",
+			Help: "Number of guardians of the current set the node received a heartbeat from recently",
+		})
+	expectedGuardians = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "wormhole_partition_expected_guardians",
+			Help: "Number of guardians in the current guardian set",
+		})
+	observationQuorumRate = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "wormhole_partition_observation_quorum_rate",
+			Help: "Fraction of the node's recently settled observations that reached quorum",
+		})
+	networkState = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "wormhole_partition_state",
+			Help: "Set to 1 for the current state of the guardian network as seen by the node",
+		}, []string{"state"})
+)
+
+const (
+	// checkInterval is how often the detector updates the status.
+	checkInterval = 15 * time.Second
+	// observationWindow is how long settled observations count towards the quorum rate.
+	observationWindow = 10 * time.Minute
+	// maxObservations bounds the number of settled observations kept within the window.
+	maxObservations = 10000
+	// minObservations is the number of observations settled within the window below which the quorum rate is not
+	// used, so that a single unlucky observation does not flag the network.
+	minObservations = 5
+	// degradedQuorumRate and partitionedQuorumRate are the quorum rates below which the network is considered
+	// degraded and partitioned.
+	degradedQuorumRate    = 0.9
+	partitionedQuorumRate = 0.5
+)
+
+type State string
+
+const (
+	// StateUnknown is reported before the node knows the guardian set.
+	StateUnknown State = "unknown"
+	// StateHealthy is reported while all guardians are reachable and observations reach quorum.
+	StateHealthy State = "healthy"
+	// StateDegraded is reported while some guardians are unreachable or some observations fail to reach quorum, but
+	// enough guardians are reachable to reach it.
+	StateDegraded State = "degraded"
+	// StatePartitioned is reported while fewer guardians than a quorum are reachable, or most observations fail to
+	// reach quorum.
+	StatePartitioned State = "partitioned"
+)
+
+var allStates = []State{StateUnknown, StateHealthy, StateDegraded, StatePartitioned}
+
+// Status is the view of the guardian network at the last check.
+type Status struct {
+	State            State  `json:"state"`
+	GuardianSetIndex uint32 `json:"guardianSetIndex"`
+	// ExpectedGuardians is the size of the guardian set and Quorum the number of signatures a VAA needs.
+	ExpectedGuardians int `json:"expectedGuardians"`
+	Quorum            int `json:"quorum"`
+	// ReachableGuardians is the number of guardians of the set with a heartbeat younger than common.MaxStateAge,
+	// including the node itself. Unreachable lists the addresses of the others.
+	ReachableGuardians int      `json:"reachableGuardians"`
+	Unreachable        []string `json:"unreachable"`
+	// Observations is the number of the node's own observations settled within the observation window, of which
+	// QuorumRate reached quorum. MedianSignatures is the median number of signatures they collected.
+	Observations     int       `json:"observations"`
+	QuorumRate       float64   `json:"quorumRate"`
+	MedianSignatures int       `json:"medianSignatures"`
+	CheckedAt        time.Time `json:"checkedAt"`
+}
+
+type settledObservation struct {
+	time       time.Time
+	signatures int
+	quorum     bool
+}
+
+// Detector periodically evaluates the reachability of the guardian set. It is safe for concurrent use.
+type Detector struct {
+	logger *zap.Logger
+	gst    *common.GuardianSetState
+	quorum func(numGuardians int) int
+
+	mu      sync.Mutex
+	settled []settledObservation
+	status  Status
+}
+
+// NewDetector returns a detector for the guardian sets in gst, with quorum computing the number of signatures a VAA
+// needs for a guardian set of the given size.
+func NewDetector(logger *zap.Logger, gst *common.GuardianSetState, quorum func(numGuardians int) int) *Detector {
+	return &Detector{
+		logger: logger.Named("partition"),
+		gst:    gst,
+		quorum: quorum,
+		status: Status{State: StateUnknown},
+	}
+}
+
+// ObservationSettled records how many signatures one of the node's own observations collected by the time it
+// settled, and whether that was a quorum.
+func (d *Detector) ObservationSettled(signatures int, quorum bool) {
+	d.observationSettled(time.Now(), signatures, quorum)
+}
+
+func (d *Detector) observationSettled(now time.Time, signatures int, quorum bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if len(d.settled) >= maxObservations {
+		d.settled = d.settled[1:]
+	}
+	d.settled = append(d.settled, settledObservation{time: now, signatures: signatures, quorum: quorum})
+}
+
+// Status returns the status computed by the last check.
+func (d *Detector) Status() Status {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.status
+}
+
+func (d *Detector) Run(ctx context.Context) error {
+	supervisor.Signal(ctx, supervisor.SignalHealthy)
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			d.check(time.Now())
+		}
+	}
+}
+
+// check updates the status and metrics, and logs state changes.
+func (d *Detector) check(now time.Time) Status {
+	status := Status{State: StateUnknown, CheckedAt: now, Unreachable: []string{}}
+
+	if gs := d.gst.Get(); gs != nil && len(gs.Keys) != 0 {
+		status.GuardianSetIndex = gs.Index
+		status.ExpectedGuardians = len(gs.Keys)
+		status.Quorum = d.quorum(len(gs.Keys))
+
+		heartbeats := d.gst.GetAll()
+		for _, k := range gs.Keys {
+			reachable := false
+			for _, hb := range heartbeats[k] {
+				if now.Sub(time.Unix(0, hb.Timestamp)) <= common.MaxStateAge {
+					reachable = true
+					break
+				}
+			}
+			if reachable {
+				status.ReachableGuardians++
+			} else {
+				status.Unreachable = append(status.Unreachable, k.Hex())
+			}
+		}
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	// Drop observations that left the window.
+	cutoff := now.Add(-observationWindow)
+	i := 0
+	for i < len(d.settled) && d.settled[i].time.Before(cutoff) {
+		i++
+	}
+	d.settled = d.settled[i:]
+
+	status.QuorumRate = 1
+	if len(d.settled) != 0 {
+		status.Observations = len(d.settled)
+		quorums := 0
+		signatures := make([]int, len(d.settled))
+		for i, o := range d.settled {
+			if o.quorum {
+				quorums++
+			}
+			signatures[i] = o.signatures
+		}
+		status.QuorumRate = float64(quorums) / float64(len(d.settled))
+		sort.Ints(signatures)
+		status.MedianSignatures = signatures[len(signatures)/2]
+	}
+
+	if status.ExpectedGuardians != 0 {
+		enoughObservations := status.Observations >= minObservations
+		switch {
+		case status.ReachableGuardians < status.Quorum || (enoughObservations && status.QuorumRate < partitionedQuorumRate):
+			status.State = StatePartitioned
+		case status.ReachableGuardians < status.ExpectedGuardians || (enoughObservations && status.QuorumRate < degradedQuorumRate):
+			status.State = StateDegraded
+		default:
+			status.State = StateHealthy
+		}
+	}
+
+	if status.State != d.status.State {
+		fields := []zap.Field{
+			zap.String("previous", string(d.status.State)),
+			zap.String("state", string(status.State)),
+			zap.Int("reachable_guardians", status.ReachableGuardians),
+			zap.Int("expected_guardians", status.ExpectedGuardians),
+			zap.Int("quorum", status.Quorum),
+			zap.Float64("quorum_rate", status.QuorumRate),
+			zap.Strings("unreachable", status.Unreachable),
+		}
+		if status.State == StatePartitioned || status.State == StateDegraded {
+			d.logger.Warn("guardian network state changed", fields...)
+		} else {
+			d.logger.Info("guardian network state changed", fields...)
+		}
+	}
+	d.status = status
+
+	reachableGuardians.Set(float64(status.ReachableGuardians))
+	expectedGuardians.Set(float64(status.ExpectedGuardians))
+	observationQuorumRate.Set(status.QuorumRate)
+	for _, s := range allStates {
+		v := 0.0
+		if s == status.State {
+			v = 1
+		}
+		networkState.WithLabelValues(string(s)).Set(v)
+	}
+
+	return status
+}
+
+// Handler serves the status as JSON. It responds with 503 Service Unavailable while the network is partitioned and
+// 200 OK otherwise, so that it can be used as a health check.
+func (d *Detector) Handler(w http.ResponseWriter, r *http.Request) {
+	status := d.Status()
+	w.Header().Set("Content-Type", "application/json")
+	if status.State == StatePartitioned {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	} else {
+		w.WriteHeader(http.StatusOK)
+	}
+	_ = json.NewEncoder(w).Encode(status)
+}
@@ -0,0 +1,139 @@
+package partition
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// quorum is the quorum of the processor, duplicated to avoid the import cycle.
+func quorum(numGuardians int) int {
+	return ((numGuardians * 2) / 3) + 1
+}
+
+func newTestDetector(t *testing.T, numGuardians int) (*Detector, *common.GuardianSetState, []ethcommon.Address) {
+	t.Helper()
+	keys := make([]ethcommon.Address, numGuardians)
+	for i := range keys {
+		keys[i] = ethcommon.Address{byte(i + 1)}
+	}
+	gst := common.NewGuardianSetState()
+	gst.Set(&common.GuardianSet{Keys: keys, Index: 3})
+	return NewDetector(zap.NewNop(), gst, quorum), gst, keys
+}
+
+// heartbeat stores a heartbeat of each of the guardians sent at ts.
+func heartbeat(t *testing.T, gst *common.GuardianSetState, ts time.Time, guardians ...ethcommon.Address) {
+	t.Helper()
+	for _, g := range guardians {
+		require.NoError(t, gst.SetHeartbeat(g, peer.ID(g.Hex()), &gossipv1.Heartbeat{Timestamp: ts.UnixNano()}))
+	}
+}
+
+func TestDetectorUnknownWithoutGuardianSet(t *testing.T) {
+	d := NewDetector(zap.NewNop(), common.NewGuardianSetState(), quorum)
+	assert.Equal(t, StateUnknown, d.Status().State)
+	assert.Equal(t, StateUnknown, d.check(time.Now()).State)
+}
+
+func TestDetectorHeartbeatCoverage(t *testing.T) {
+	d, gst, keys := newTestDetector(t, 19)
+	now := time.Unix(1000000, 0)
+
+	heartbeat(t, gst, now.Add(-10*time.Second), keys...)
+	status := d.check(now)
+	assert.Equal(t, StateHealthy, status.State)
+	assert.Equal(t, 19, status.ReachableGuardians)
+	assert.Equal(t, 13, status.Quorum)
+	assert.Equal(t, uint32(3), status.GuardianSetIndex)
+	assert.Empty(t, status.Unreachable)
+
+	// Heartbeats older than the state age do not count.
+	status = d.check(now.Add(common.MaxStateAge))
+	assert.Equal(t, StatePartitioned, status.State)
+	assert.Equal(t, 0, status.ReachableGuardians)
+
+	// Only 9 of 19 guardians are reachable, too few for a quorum.
+	later := now.Add(time.Hour)
+	heartbeat(t, gst, later, keys[:9]...)
+	status = d.check(later)
+	assert.Equal(t, StatePartitioned, status.State)
+	assert.Equal(t, 9, status.ReachableGuardians)
+	assert.Len(t, status.Unreachable, 10)
+	assert.Equal(t, keys[9].Hex(), status.Unreachable[0])
+
+	// Enough guardians for a quorum, but not all of them.
+	heartbeat(t, gst, later, keys[9:15]...)
+	status = d.check(later)
+	assert.Equal(t, StateDegraded, status.State)
+	assert.Equal(t, 15, status.ReachableGuardians)
+	assert.Equal(t, status, d.Status())
+}
+
+func TestDetectorQuorumRate(t *testing.T) {
+	d, gst, keys := newTestDetector(t, 19)
+	now := time.Unix(1000000, 0)
+	heartbeat(t, gst, now, keys...)
+
+	// Too few observations to judge.
+	for i := 0; i < minObservations-1; i++ {
+		d.observationSettled(now, 9, false)
+	}
+	status := d.check(now)
+	assert.Equal(t, StateHealthy, status.State)
+	assert.Equal(t, 0.0, status.QuorumRate)
+
+	// Most observations fail even though all guardians send heartbeats.
+	d.observationSettled(now, 19, true)
+	status = d.check(now)
+	assert.Equal(t, StatePartitioned, status.State)
+	assert.Equal(t, minObservations, status.Observations)
+	assert.Equal(t, 0.2, status.QuorumRate)
+	assert.Equal(t, 9, status.MedianSignatures)
+
+	for i := 0; i < 20; i++ {
+		d.observationSettled(now, 19, true)
+	}
+	status = d.check(now)
+	assert.Equal(t, StateDegraded, status.State)
+	assert.Equal(t, 19, status.MedianSignatures)
+
+	// Failed observations leave the window.
+	later := now.Add(observationWindow + time.Second)
+	heartbeat(t, gst, later, keys...)
+	d.observationSettled(later, 19, true)
+	status = d.check(later)
+	assert.Equal(t, StateHealthy, status.State)
+	assert.Equal(t, 1, status.Observations)
+	assert.Equal(t, 1.0, status.QuorumRate)
+}
+
+func TestDetectorHandler(t *testing.T) {
+	d, gst, keys := newTestDetector(t, 4)
+	now := time.Now()
+	heartbeat(t, gst, now, keys...)
+	d.check(now)
+
+	rec := httptest.NewRecorder()
+	d.Handler(rec, httptest.NewRequest(http.MethodGet, "/partitionz", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var status Status
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &status))
+	assert.Equal(t, StateHealthy, status.State)
+	assert.Equal(t, 4, status.ReachableGuardians)
+
+	d.check(now.Add(2 * common.MaxStateAge))
+	rec = httptest.NewRecorder()
+	d.Handler(rec, httptest.NewRequest(http.MethodGet, "/partitionz", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+}
@@ -9,8 +9,10 @@ import (
 
 	ethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
+	"go.uber.org/zap"
 	"google.golang.org/protobuf/proto"
 
+	"github.com/certusone/wormhole/node/pkg/common"
 	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
 	"github.com/wormhole-foundation/wormhole/sdk/vaa"
 )
@@ -23,6 +25,31 @@ var (
 		})
 )
 
+// updateGuardianKey switches to the additional guardian key when it is part of the current guardian set while the
+// active key is not. The key is shared with the other components signing as the guardian.
+func (p *Processor) updateGuardianKey() {
+	switched, conflict := p.gk.Update(p.gs)
+	if conflict {
+		p.logger.Error("both guardian keys are part of the guardian set, signing with the active key only",
+			zap.Stringer("guardian_addr", p.gk.Address()),
+			zap.Uint32("index", p.gs.Index))
+	}
+	if switched {
+		p.logger.Info("switched to the additional guardian key",
+			zap.Stringer("guardian_addr", p.gk.Address()),
+			zap.Uint32("index", p.gs.Index))
+	}
+}
+
+// signDigest signs digest with our guardian key.
+func (p *Processor) signDigest(digest ethcommon.Hash) []byte {
+	s, err := crypto.Sign(digest.Bytes(), p.gk.Get())
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
 func (p *Processor) broadcastSignature(
 	o Observation,
 	signature []byte,
@@ -30,7 +57,7 @@ func (p *Processor) broadcastSignature(
 ) {
 	digest := o.SigningMsg()
 	obsv := gossipv1.SignedObservation{
-		Addr:      crypto.PubkeyToAddress(p.gk.PublicKey).Bytes(),
+		Addr:      p.gk.Address().Bytes(),
 		Hash:      digest.Bytes(),
 		Signature: signature,
 		TxHash:    txhash,
@@ -44,7 +71,17 @@ func (p *Processor) broadcastSignature(
 		panic(err)
 	}
 
-	p.sendC <- msg
+	sendC, obsvC := p.sendC, p.obsvC
+	if common.IsGovernanceMessageID(o.MessageID()) {
+		if p.govSendC != nil {
+			sendC = p.govSendC
+		}
+		if p.govObsvC != nil {
+			obsvC = p.govObsvC
+		}
+	}
+
+	sendC <- msg
 
 	// Store our VAA in case we're going to submit it to Solana
 	hash := hex.EncodeToString(digest.Bytes())
@@ -64,7 +101,7 @@ func (p *Processor) broadcastSignature(
 	p.state.signatures[hash].gs = p.gs // guaranteed to match ourObservation - there's no concurrent access to p.gs
 
 	// Fast path for our own signature
-	go func() { p.obsvC <- &obsv }()
+	go func() { obsvC <- &obsv }()
 
 	observationsBroadcastTotal.Inc()
 }
@@ -84,5 +121,9 @@ func (p *Processor) broadcastSignedVAA(v *vaa.VAA) {
 		panic(err)
 	}
 
+	if p.govSendC != nil && common.IsGovernanceMessageID(v.MessageID()) {
+		p.govSendC <- msg
+		return
+	}
 	p.sendC <- msg
 }
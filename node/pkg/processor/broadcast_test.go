@@ -0,0 +1,109 @@
+package processor
+
+import (
+	"crypto/ecdsa"
+	"testing"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+func TestUpdateGuardianKey(t *testing.T) {
+	oldKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	newKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	otherKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	addr := func(k *ecdsa.PrivateKey) ethcommon.Address { return crypto.PubkeyToAddress(k.PublicKey) }
+
+	tests := []struct {
+		label        string
+		additionalGk *ecdsa.PrivateKey
+		gs           *common.GuardianSet
+		expected     *ecdsa.PrivateKey
+	}{
+		{label: "single key", gs: &common.GuardianSet{Keys: []ethcommon.Address{addr(otherKey)}}, expected: oldKey},
+		{label: "old key active", additionalGk: newKey, gs: &common.GuardianSet{Keys: []ethcommon.Address{addr(otherKey), addr(oldKey)}}, expected: oldKey},
+		{label: "new key active", additionalGk: newKey, gs: &common.GuardianSet{Keys: []ethcommon.Address{addr(otherKey), addr(newKey)}}, expected: newKey},
+		{label: "both keys active", additionalGk: newKey, gs: &common.GuardianSet{Keys: []ethcommon.Address{addr(newKey), addr(oldKey)}}, expected: oldKey},
+		{label: "neither key active", additionalGk: newKey, gs: &common.GuardianSet{Keys: []ethcommon.Address{addr(otherKey)}}, expected: oldKey},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.label, func(t *testing.T) {
+			p := &Processor{gk: common.NewGuardianKeyState(oldKey, tc.additionalGk), gs: tc.gs, logger: zap.NewNop()}
+			p.updateGuardianKey()
+			assert.Equal(t, tc.expected, p.gk.Get())
+		})
+	}
+}
+
+func TestSignDigest(t *testing.T) {
+	gk, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	p := &Processor{gk: common.NewGuardianKeyState(gk, nil)}
+
+	v := getVAA()
+	digest := v.SigningMsg()
+	pubKey, err := crypto.Ecrecover(digest.Bytes(), p.signDigest(digest))
+	require.NoError(t, err)
+	signer, err := crypto.UnmarshalPubkey(pubKey)
+	require.NoError(t, err)
+	assert.Equal(t, crypto.PubkeyToAddress(gk.PublicKey), crypto.PubkeyToAddress(*signer))
+}
+
+func TestBroadcastSignatureGovernanceLane(t *testing.T) {
+	gk, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	governance := getVAA()
+	other := getVAA()
+	other.EmitterChain = vaa.ChainIDEthereum
+
+	tests := []struct {
+		label      string
+		v          vaa.VAA
+		governance bool
+	}{
+		{label: "governance", v: governance, governance: true},
+		{label: "other", v: other, governance: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.label, func(t *testing.T) {
+			p := &Processor{
+				gk:       common.NewGuardianKeyState(gk, nil),
+				gs:       &common.GuardianSet{Keys: []ethcommon.Address{crypto.PubkeyToAddress(gk.PublicKey)}},
+				state:    &aggregationState{observationMap{}},
+				sendC:    make(chan []byte, 1),
+				govSendC: make(chan []byte, 1),
+				obsvC:    make(chan *gossipv1.SignedObservation, 1),
+				govObsvC: make(chan *gossipv1.SignedObservation, 1),
+			}
+
+			o := &VAA{VAA: tc.v}
+			p.broadcastSignature(o, p.signDigest(o.SigningMsg()), nil)
+
+			sendC, obsvC := p.sendC, p.obsvC
+			if tc.governance {
+				sendC, obsvC = p.govSendC, p.govObsvC
+			}
+			assert.Len(t, sendC, 1)
+			select {
+			case obsv := <-obsvC:
+				assert.Equal(t, tc.v.MessageID(), obsv.MessageId)
+			case <-time.After(time.Second):
+				t.Fatal("observation was not looped back")
+			}
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/certusone/wormhole/node/pkg/db"
 	"github.com/certusone/wormhole/node/pkg/notify/discord"
 	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
+	ethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/wormhole-foundation/wormhole/sdk/vaa"
@@ -59,6 +60,18 @@ const (
 	retryTime      = time.Minute * 5
 )
 
+// guardianName returns the node name of a guardian from its last heartbeat, or its hex-encoded address if unknown.
+func (p *Processor) guardianName(k ethcommon.Address) string {
+	name := hex.EncodeToString(k.Bytes())
+	h := p.gst.LastHeartbeat(k)
+	// Pick first node if there are multiple peers.
+	for _, hb := range h {
+		name = hb.NodeName
+		break
+	}
+	return name
+}
+
 // handleCleanup handles periodic retransmissions and cleanup of observations
 func (p *Processor) handleCleanup(ctx context.Context) {
 	p.logger.Info("aggregation state summary", zap.Int("cached", len(p.state.signatures)))
@@ -114,6 +127,10 @@ func (p *Processor) handleCleanup(ctx context.Context) {
 			if s.ourObservation != nil {
 				chain = s.ourObservation.GetEmitterChain()
 
+				if p.partitionDetector != nil {
+					p.partitionDetector.ObservationSettled(hasSigs, quorum)
+				}
+
 				// If a notifier is configured, send a notification for any missing signatures.
 				//
 				// Only send a notification if we have a observation. Otherwise, bogus observations
@@ -124,14 +141,7 @@ func (p *Processor) handleCleanup(ctx context.Context) {
 					missing := make([]string, 0, len(gs.Keys))
 					for _, k := range gs.Keys {
 						if s.signatures[k] == nil {
-							name := hex.EncodeToString(k.Bytes())
-							h := p.gst.LastHeartbeat(k)
-							// Pick first node if there are multiple peers.
-							for _, hb := range h {
-								name = hb.NodeName
-								break
-							}
-							missing = append(missing, name)
+							missing = append(missing, p.guardianName(k))
 						}
 					}
 
@@ -0,0 +1,76 @@
+package processor
+
+import (
+	"sort"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	nodev1 "github.com/certusone/wormhole/node/pkg/proto/node/v1"
+)
+
+// StateDumpRequest asks the processor for a snapshot of its aggregation state. The processor replies on ResponseC,
+// which must be buffered.
+type StateDumpRequest struct {
+	IncludeSubmitted bool
+	ResponseC        chan []*nodev1.PendingObservation
+}
+
+// handleStateDump answers a StateDumpRequest. Like all other access to the aggregation state, it runs on the
+// processor goroutine.
+func (p *Processor) handleStateDump(req *StateDumpRequest) {
+	req.ResponseC <- p.dumpState(req.IncludeSubmitted)
+}
+
+func (p *Processor) dumpState(includeSubmitted bool) []*nodev1.PendingObservation {
+	now := time.Now()
+	resp := make([]*nodev1.PendingObservation, 0, len(p.state.signatures))
+	firstObserved := make(map[*nodev1.PendingObservation]time.Time, len(p.state.signatures))
+
+	for hash, s := range p.state.signatures {
+		if s.submitted && !includeSubmitted {
+			continue
+		}
+
+		// Use either the stored (if we made the observation) or the most recent guardian set, like the cleanup does.
+		var gs *common.GuardianSet
+		if s.gs != nil {
+			gs = s.gs
+		} else {
+			gs = p.gs
+		}
+
+		o := &nodev1.PendingObservation{
+			Digest:     hash,
+			AgeSeconds: uint64(now.Sub(s.firstObserved).Seconds()),
+			Submitted:  s.submitted,
+			RetryCount: uint32(s.retryCount),
+			Source:     s.source,
+		}
+
+		if s.ourObservation != nil {
+			o.MessageId = s.ourObservation.MessageID()
+			o.EmitterChain = uint32(s.ourObservation.GetEmitterChain())
+		}
+
+		if gs != nil {
+			o.GuardianSetIndex = gs.Index
+			o.Quorum = uint32(CalculateQuorum(len(gs.Keys)))
+			for _, k := range gs.Keys {
+				if _, ok := s.signatures[k]; ok {
+					o.Signatures++
+				} else {
+					o.MissingGuardians = append(o.MissingGuardians, p.guardianName(k))
+				}
+			}
+		}
+
+		resp = append(resp, o)
+		firstObserved[o] = s.firstObserved
+	}
+
+	sort.Slice(resp, func(i, j int) bool {
+		return firstObserved[resp[i]].Before(firstObserved[resp[j]])
+	})
+
+	return resp
+}
@@ -0,0 +1,67 @@
+package processor
+
+import (
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDumpState(t *testing.T) {
+	keys := []ethcommon.Address{
+		ethcommon.HexToAddress("0x0000000000000000000000000000000000000001"),
+		ethcommon.HexToAddress("0x0000000000000000000000000000000000000002"),
+		ethcommon.HexToAddress("0x0000000000000000000000000000000000000003"),
+	}
+	gs := &common.GuardianSet{Keys: keys, Index: 4}
+
+	v := getVAA()
+	ours := &VAA{VAA: v}
+
+	p := &Processor{
+		gs:  gs,
+		gst: common.NewGuardianSetState(),
+		state: &aggregationState{observationMap{
+			"pending": &state{
+				firstObserved:  time.Now().Add(-time.Minute),
+				ourObservation: ours,
+				signatures:     map[ethcommon.Address][]byte{keys[0]: {1}, keys[2]: {3}},
+				source:         "solana",
+				gs:             gs,
+			},
+			"unobserved": &state{
+				firstObserved: time.Now().Add(-2 * time.Minute),
+				signatures:    map[ethcommon.Address][]byte{keys[1]: {2}},
+			},
+			"submitted": &state{
+				firstObserved: time.Now(),
+				signatures:    map[ethcommon.Address][]byte{},
+				submitted:     true,
+			},
+		}},
+	}
+
+	dump := p.dumpState(false)
+	require.Len(t, dump, 2)
+
+	// Oldest first.
+	assert.Equal(t, "unobserved", dump[0].Digest)
+	assert.Equal(t, "", dump[0].MessageId)
+	assert.Equal(t, uint32(1), dump[0].Signatures)
+
+	assert.Equal(t, "pending", dump[1].Digest)
+	assert.Equal(t, ours.MessageID(), dump[1].MessageId)
+	assert.Equal(t, uint32(v.EmitterChain), dump[1].EmitterChain)
+	assert.Equal(t, uint32(4), dump[1].GuardianSetIndex)
+	assert.Equal(t, uint32(2), dump[1].Signatures)
+	assert.Equal(t, uint32(CalculateQuorum(len(keys))), dump[1].Quorum)
+	assert.Equal(t, []string{hex.EncodeToString(keys[1].Bytes())}, dump[1].MissingGuardians)
+	assert.Equal(t, "solana", dump[1].Source)
+	assert.GreaterOrEqual(t, dump[1].AgeSeconds, uint64(60))
+
+	assert.Len(t, p.dumpState(true), 3)
+}
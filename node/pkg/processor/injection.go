@@ -7,7 +7,6 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 
-	"github.com/ethereum/go-ethereum/crypto"
 	"go.uber.org/zap"
 
 	"github.com/certusone/wormhole/node/pkg/supervisor"
@@ -32,10 +31,7 @@ func (p *Processor) handleInjection(ctx context.Context, v *vaa.VAA) {
 		zap.String("digest", hex.EncodeToString(digest.Bytes())))
 
 	// Sign the digest using our node's guardian key.
-	s, err := crypto.Sign(digest.Bytes(), p.gk)
-	if err != nil {
-		panic(err)
-	}
+	s := p.signDigest(digest)
 
 	p.logger.Info("observed and signed injected VAA",
 		zap.String("digest", hex.EncodeToString(digest.Bytes())),
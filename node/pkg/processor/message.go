@@ -10,7 +10,6 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 
-	"github.com/ethereum/go-ethereum/crypto"
 	"go.uber.org/zap"
 
 	"github.com/certusone/wormhole/node/pkg/common"
@@ -36,8 +35,45 @@ var (
 			Help: "Total number of message observations that were successfully signed",
 		},
 		[]string{"emitter_chain"})
+
+	messagesUnknownEmitterTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "wormhole_message_observations_unknown_emitter_total",
+			Help: "Total number of messages observed from emitters not on the emitter allowlist",
+		},
+		[]string{"emitter_chain"})
 )
 
+// checkEmitterAllowlist returns whether a message should be processed according to the emitter allowlist.
+// Messages from unknown emitters are counted, and dropped unless the allowlist is in count-only mode.
+func (p *Processor) checkEmitterAllowlist(k *common.MessagePublication) bool {
+	if p.emitterAllowlist.Known(k.EmitterChain, k.EmitterAddress) {
+		return true
+	}
+
+	messagesUnknownEmitterTotal.With(prometheus.Labels{
+		"emitter_chain": k.EmitterChain.String(),
+	}).Add(1)
+
+	if p.emitterAllowlist.CountOnly() {
+		p.logger.Warn("observed message from emitter not on the allowlist",
+			zap.Stringer("emitter_chain", k.EmitterChain),
+			zap.Stringer("emitter_address", k.EmitterAddress),
+			zap.Uint64("sequence", k.Sequence),
+			zap.Stringer("txhash", k.TxHash),
+		)
+		return true
+	}
+
+	p.logger.Info("ignoring message from emitter not on the allowlist",
+		zap.Stringer("emitter_chain", k.EmitterChain),
+		zap.Stringer("emitter_address", k.EmitterAddress),
+		zap.Uint64("sequence", k.Sequence),
+		zap.Stringer("txhash", k.TxHash),
+	)
+	return false
+}
+
 // handleMessage processes a message received from a chain and instantiates our deterministic copy of the VAA. An
 // event may be received multiple times and must be handled in an idempotent fashion.
 func (p *Processor) handleMessage(ctx context.Context, k *common.MessagePublication) {
@@ -63,6 +99,7 @@ func (p *Processor) handleMessage(ctx context.Context, k *common.MessagePublicat
 	messagesObservedTotal.With(prometheus.Labels{
 		"emitter_chain": k.EmitterChain.String(),
 	}).Add(1)
+	p.chainCounters.MessageObserved(k.EmitterChain)
 
 	// All nodes will create the exact same VAA and sign its digest.
 	// Consensus is established on this digest.
@@ -132,10 +169,7 @@ func (p *Processor) handleMessage(ctx context.Context, k *common.MessagePublicat
 	digest := v.SigningMsg()
 
 	// Sign the digest using our node's guardian key.
-	s, err := crypto.Sign(digest.Bytes(), p.gk)
-	if err != nil {
-		panic(err)
-	}
+	s := p.signDigest(digest)
 
 	p.logger.Info("observed and signed confirmed message publication",
 		zap.Stringer("source_chain", k.EmitterChain),
@@ -153,6 +187,7 @@ func (p *Processor) handleMessage(ctx context.Context, k *common.MessagePublicat
 
 	messagesSignedTotal.With(prometheus.Labels{
 		"emitter_chain": k.EmitterChain.String()}).Add(1)
+	p.chainCounters.ObservationSigned(k.EmitterChain)
 
 	p.attestationEvents.ReportMessagePublication(&reporter.MessagePublication{VAA: v.VAA, InitiatingTxID: k.TxHash})
 
@@ -285,14 +285,14 @@ func (p *Processor) handleInboundSignedVAAWithQuorum(ctx context.Context, m *gos
 	//  - the signature's addresses match the node's current guardian set
 	//  - enough signatures are present for the VAA to reach quorum
 
-	// Check if we already store this VAA
+	// Check if we already store this VAA. A stored VAA that failed verification is replaced.
 	_, err = p.getSignedVAA(*db.VaaIDFromVAA(v))
 	if err == nil {
 		p.logger.Debug("ignored SignedVAAWithQuorum message for VAA we already store",
 			zap.String("digest", hash),
 		)
 		return
-	} else if err != db.ErrVAANotFound {
+	} else if err != db.ErrVAANotFound && err != db.ErrVAACorrupt {
 		p.logger.Error("failed to look up VAA in database",
 			zap.String("digest", hash),
 			zap.Error(err),
@@ -2,17 +2,16 @@ package processor
 
 import (
 	"context"
-	"crypto/ecdsa"
 	"fmt"
 	"time"
 
 	"github.com/certusone/wormhole/node/pkg/notify/discord"
+	"github.com/certusone/wormhole/node/pkg/partition"
 
 	"github.com/certusone/wormhole/node/pkg/db"
 	"github.com/certusone/wormhole/node/pkg/governor"
 
 	ethcommon "github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/crypto"
 	"go.uber.org/zap"
 
 	"github.com/certusone/wormhole/node/pkg/common"
@@ -87,8 +86,14 @@ type Processor struct {
 
 	// sendC is a channel of outbound messages to broadcast on p2p
 	sendC chan []byte
+	// govSendC is a channel of outbound governance messages, which are broadcast before those on sendC. If it is nil,
+	// governance messages are sent on sendC.
+	govSendC chan []byte
 	// obsvC is a channel of inbound decoded observations from p2p
 	obsvC chan *gossipv1.SignedObservation
+	// govObsvC is a channel of inbound governance observations, which are handled before any other event. If it is
+	// nil, our own governance observations are looped back on obsvC.
+	govObsvC chan *gossipv1.SignedObservation
 
 	// obsvReqSendC is a send-only channel of outbound re-observation requests to broadcast on p2p
 	obsvReqSendC chan<- *gossipv1.ObservationRequest
@@ -99,8 +104,9 @@ type Processor struct {
 	// injectC is a channel of VAAs injected locally.
 	injectC chan *vaa.VAA
 
-	// gk is the node's guardian private key
-	gk *ecdsa.PrivateKey
+	// gk holds the node's guardian private key. With an additional guardian key, it switches to the key that is part
+	// of the current guardian set during guardian set transitions.
+	gk *common.GuardianKeyState
 
 	// devnetMode specified whether to submit transactions to the hardcoded Ethereum devnet
 	devnetMode         bool
@@ -125,14 +131,24 @@ type Processor struct {
 
 	// state is the current runtime VAA view
 	state *aggregationState
-	// gk pk as eth address
-	ourAddr ethcommon.Address
 	// cleanup triggers periodic state cleanup
 	cleanup *time.Ticker
 
 	notifier    *discord.DiscordNotifier
 	governor    *governor.ChainGovernor
 	pythnetVaas map[string]PythNetVaaEntry
+
+	// emitterAllowlist restricts the emitters we observe per chain
+	emitterAllowlist *common.EmitterAllowlist
+
+	// stateDumpC is a channel of requests for snapshots of the aggregation state
+	stateDumpC <-chan *StateDumpRequest
+
+	// chainCounters counts observations per chain for the admin API
+	chainCounters *common.ChainCounters
+
+	// partitionDetector, if set, is told how many signatures our observations collected when they settled
+	partitionDetector *partition.Detector
 }
 
 func NewProcessor(
@@ -141,11 +157,13 @@ func NewProcessor(
 	lockC chan *common.MessagePublication,
 	setC chan *common.GuardianSet,
 	sendC chan []byte,
+	govSendC chan []byte,
 	obsvC chan *gossipv1.SignedObservation,
+	govObsvC chan *gossipv1.SignedObservation,
 	obsvReqSendC chan<- *gossipv1.ObservationRequest,
 	injectC chan *vaa.VAA,
 	signedInC chan *gossipv1.SignedVAAWithQuorum,
-	gk *ecdsa.PrivateKey,
+	gk *common.GuardianKeyState,
 	gst *common.GuardianSetState,
 	devnetMode bool,
 	devnetNumGuardians uint,
@@ -154,13 +172,19 @@ func NewProcessor(
 	attestationEvents *reporter.AttestationEventReporter,
 	notifier *discord.DiscordNotifier,
 	g *governor.ChainGovernor,
+	emitterAllowlist *common.EmitterAllowlist,
+	stateDumpC <-chan *StateDumpRequest,
+	chainCounters *common.ChainCounters,
+	partitionDetector *partition.Detector,
 ) *Processor {
 
 	return &Processor{
 		lockC:              lockC,
 		setC:               setC,
 		sendC:              sendC,
+		govSendC:           govSendC,
 		obsvC:              obsvC,
+		govObsvC:           govObsvC,
 		obsvReqSendC:       obsvReqSendC,
 		signedInC:          signedInC,
 		injectC:            injectC,
@@ -179,9 +203,14 @@ func NewProcessor(
 
 		logger:      supervisor.Logger(ctx),
 		state:       &aggregationState{observationMap{}},
-		ourAddr:     crypto.PubkeyToAddress(gk.PublicKey),
 		governor:    g,
 		pythnetVaas: make(map[string]PythNetVaaEntry),
+
+		emitterAllowlist: emitterAllowlist,
+		stateDumpC:       stateDumpC,
+		chainCounters:    chainCounters,
+
+		partitionDetector: partitionDetector,
 	}
 }
 
@@ -192,15 +221,30 @@ func (p *Processor) Run(ctx context.Context) error {
 	govTimer := time.NewTimer(time.Minute)
 
 	for {
+		// Governance observations jump the queue, so that guardian set upgrades and other governance actions reach
+		// quorum even when the other channels are saturated.
+		select {
+		case m := <-p.govObsvC:
+			p.handleObservation(ctx, m)
+			continue
+		default:
+		}
+
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
+		case m := <-p.govObsvC:
+			p.handleObservation(ctx, m)
 		case p.gs = <-p.setC:
 			p.logger.Info("guardian set updated",
 				zap.Strings("set", p.gs.KeysAsHexStrings()),
 				zap.Uint32("index", p.gs.Index))
 			p.gst.Set(p.gs)
+			p.updateGuardianKey()
 		case k := <-p.lockC:
+			if !p.checkEmitterAllowlist(k) {
+				continue
+			}
 			if p.governor != nil {
 				if !p.governor.ProcessMsg(k) {
 					continue
@@ -215,6 +259,8 @@ func (p *Processor) Run(ctx context.Context) error {
 			p.handleInboundSignedVAAWithQuorum(ctx, m)
 		case <-p.cleanup.C:
 			p.handleCleanup(ctx)
+		case req := <-p.stateDumpC:
+			p.handleStateDump(req)
 		case <-govTimer.C:
 			if p.governor != nil {
 				toBePublished, err := p.governor.CheckPending()
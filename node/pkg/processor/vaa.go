@@ -44,6 +44,7 @@ func (v *VAA) HandleQuorum(sigs []*vaa.Signature, hash string, p *Processor) {
 
 	p.broadcastSignedVAA(signed)
 	p.attestationEvents.ReportVAAQuorum(signed)
+	p.chainCounters.QuorumVAA(signed.EmitterChain)
 	p.state.signatures[hash].submitted = true
 }
 
@@ -0,0 +1,105 @@
+package publicrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// evmClient connects to an EVM RPC node on first use, so that an unavailable node only fails the requests that need
+// it.
+type evmClient struct {
+	rpcURL string
+
+	mu     sync.Mutex
+	client *ethclient.Client
+}
+
+func (c *evmClient) get(ctx context.Context) (*ethclient.Client, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.client == nil {
+		client, err := ethclient.DialContext(ctx, c.rpcURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to dial %s: %w", c.rpcURL, err)
+		}
+		c.client = client
+	}
+	return c.client, nil
+}
+
+// successfulReceipt returns the receipt of a transaction, or ErrTxNotFound if it is not mined or reverted.
+func (c *evmClient) successfulReceipt(ctx context.Context, txHash []byte) (*ethtypes.Receipt, error) {
+	if len(txHash) != ethcommon.HashLength {
+		return nil, ErrTxNotFound
+	}
+	client, err := c.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	receipt, err := client.TransactionReceipt(ctx, ethcommon.BytesToHash(txHash))
+	if errors.Is(err, ethereum.NotFound) {
+		return nil, ErrTxNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if receipt.Status != ethtypes.ReceiptStatusSuccessful {
+		return nil, ErrTxNotFound
+	}
+	return receipt, nil
+}
+
+// EVMTxVerifier checks that a transaction succeeded on an EVM chain.
+type EVMTxVerifier struct {
+	client *evmClient
+}
+
+func NewEVMTxVerifier(rpcURL string) *EVMTxVerifier {
+	return &EVMTxVerifier{client: &evmClient{rpcURL: rpcURL}}
+}
+
+func (v *EVMTxVerifier) VerifyTx(ctx context.Context, txHash []byte) error {
+	_, err := v.client.successfulReceipt(ctx, txHash)
+	return err
+}
+
+// EVMFeeVerifier checks that a transaction transferred at least a minimum amount of the native token of an EVM chain
+// to a recipient.
+type EVMFeeVerifier struct {
+	client    *evmClient
+	recipient ethcommon.Address
+	minFee    *big.Int
+}
+
+func NewEVMFeeVerifier(rpcURL string, recipient ethcommon.Address, minFee *big.Int) *EVMFeeVerifier {
+	return &EVMFeeVerifier{client: &evmClient{rpcURL: rpcURL}, recipient: recipient, minFee: minFee}
+}
+
+func (v *EVMFeeVerifier) VerifyFee(ctx context.Context, feeTxHash []byte) error {
+	if _, err := v.client.successfulReceipt(ctx, feeTxHash); err != nil {
+		if errors.Is(err, ErrTxNotFound) {
+			return ErrFeeNotPaid
+		}
+		return err
+	}
+	client, err := v.client.get(ctx)
+	if err != nil {
+		return err
+	}
+	tx, _, err := client.TransactionByHash(ctx, ethcommon.BytesToHash(feeTxHash))
+	if err != nil {
+		return err
+	}
+	if tx.To() == nil || *tx.To() != v.recipient || tx.Value().Cmp(v.minFee) < 0 {
+		return ErrFeeNotPaid
+	}
+	return nil
+}
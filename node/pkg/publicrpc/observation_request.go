@@ -0,0 +1,225 @@
+package publicrpc
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/certusone/wormhole/node/pkg/common"
+	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	publicObservationRequestsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "wormhole_public_observation_requests_total",
+			Help: "Total number of observation requests received on the public API, by outcome",
+		}, []string{"status"})
+)
+
+const (
+	// requestClientLimit is the number of observation requests a single client may make per requestClientWindow.
+	requestClientLimit  = 10
+	requestClientWindow = time.Hour
+	// requestDedupWindow is how long requests for the same transaction are dropped after it was requested.
+	requestDedupWindow = 24 * time.Hour
+)
+
+var (
+	// ErrTxNotFound is returned by a TxVerifier for transactions that do not exist or did not succeed.
+	ErrTxNotFound = errors.New("transaction not found or failed")
+	// ErrFeeNotPaid is returned by a FeeVerifier for transactions that do not pay the fee.
+	ErrFeeNotPaid = errors.New("transaction does not pay the observation request fee")
+)
+
+// TxVerifier checks that a transaction exists and succeeded on its chain.
+type TxVerifier interface {
+	VerifyTx(ctx context.Context, txHash []byte) error
+}
+
+// FeeVerifier checks that a transaction paid the fee of an observation request.
+type FeeVerifier interface {
+	VerifyFee(ctx context.Context, feeTxHash []byte) error
+}
+
+type requestedTx struct {
+	chainID vaa.ChainID
+	txHash  string
+}
+
+// ObservationRequestGate decides which observation requests made on the public API are broadcast to the guardian
+// network. Requests are only accepted for chains with a TxVerifier, and are rate limited per client and overall.
+// Requests for a transaction requested in the last requestDedupWindow are dropped, as are requests for transactions
+// that do not exist. If a FeeVerifier is set, every request has to reference a distinct transaction paying the fee.
+type ObservationRequestGate struct {
+	logger       *zap.Logger
+	clock        clock.Clock
+	obsvReqSendC chan<- *gossipv1.ObservationRequest
+	txVerifiers  map[vaa.ChainID]TxVerifier
+	feeVerifier  FeeVerifier
+	maxPerMinute int
+
+	mu sync.Mutex
+	// Times of the requests accepted for verification in the last minute, and per client in the last
+	// requestClientWindow.
+	recent  []time.Time
+	clients map[string][]time.Time
+	// Transactions requested in the last requestDedupWindow, including those being verified.
+	requested map[requestedTx]time.Time
+	// Fee transactions used by requests, including those being verified. Entries are never removed, since each of
+	// them paid a fee.
+	usedFees map[string]bool
+}
+
+func NewObservationRequestGate(
+	logger *zap.Logger,
+	clock clock.Clock,
+	obsvReqSendC chan<- *gossipv1.ObservationRequest,
+	txVerifiers map[vaa.ChainID]TxVerifier,
+	feeVerifier FeeVerifier,
+	maxPerMinute int,
+) *ObservationRequestGate {
+	return &ObservationRequestGate{
+		logger:       logger.Named("observationrequests"),
+		clock:        clock,
+		obsvReqSendC: obsvReqSendC,
+		txVerifiers:  txVerifiers,
+		feeVerifier:  feeVerifier,
+		maxPerMinute: maxPerMinute,
+		clients:      make(map[string][]time.Time),
+		requested:    make(map[requestedTx]time.Time),
+		usedFees:     make(map[string]bool),
+	}
+}
+
+// pruneTimes drops the times in ts older than window.
+func pruneTimes(ts []time.Time, now time.Time, window time.Duration) []time.Time {
+	for len(ts) > 0 && now.Sub(ts[0]) >= window {
+		ts = ts[1:]
+	}
+	return ts
+}
+
+// reserve applies the rate limits and dedup checks, and records the request while it is verified.
+func (g *ObservationRequestGate) reserve(client string, tx requestedTx, feeTxHash string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := g.clock.Now()
+	g.recent = pruneTimes(g.recent, now, time.Minute)
+	for c, ts := range g.clients {
+		if ts = pruneTimes(ts, now, requestClientWindow); len(ts) == 0 {
+			delete(g.clients, c)
+		} else {
+			g.clients[c] = ts
+		}
+	}
+	for r, t := range g.requested {
+		if now.Sub(t) >= requestDedupWindow {
+			delete(g.requested, r)
+		}
+	}
+
+	if len(g.clients[client]) >= requestClientLimit {
+		return status.Error(codes.ResourceExhausted, "too many observation requests from this client")
+	}
+	if len(g.recent) >= g.maxPerMinute {
+		return status.Error(codes.ResourceExhausted, "too many observation requests, try again later")
+	}
+	if _, ok := g.requested[tx]; ok {
+		return status.Error(codes.AlreadyExists, "transaction was requested recently")
+	}
+	if feeTxHash != "" && g.usedFees[feeTxHash] {
+		return status.Error(codes.AlreadyExists, "fee transaction was already used")
+	}
+
+	g.recent = append(g.recent, now)
+	g.clients[client] = append(g.clients[client], now)
+	g.requested[tx] = now
+	if feeTxHash != "" {
+		g.usedFees[feeTxHash] = true
+	}
+	return nil
+}
+
+// release allows a request that failed, and its fee transaction, to be used again. It still counts towards the rate
+// limits.
+func (g *ObservationRequestGate) release(tx requestedTx, feeTxHash string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	delete(g.requested, tx)
+	if feeTxHash != "" {
+		delete(g.usedFees, feeTxHash)
+	}
+}
+
+// Submit broadcasts a request to re-observe txHash on chainID made by client, if the request passes the gate.
+// Errors are gRPC status errors for the caller.
+func (g *ObservationRequestGate) Submit(ctx context.Context, client string, chainID vaa.ChainID, txHash []byte, feeTxHash []byte) error {
+	err := g.submit(ctx, client, chainID, txHash, feeTxHash)
+	publicObservationRequestsTotal.WithLabelValues(status.Code(err).String()).Inc()
+	return err
+}
+
+func (g *ObservationRequestGate) submit(ctx context.Context, client string, chainID vaa.ChainID, txHash []byte, feeTxHash []byte) error {
+	txVerifier, ok := g.txVerifiers[chainID]
+	if !ok {
+		return status.Errorf(codes.InvalidArgument, "observation requests are not supported for chain %s", chainID)
+	}
+	if len(txHash) == 0 {
+		return status.Error(codes.InvalidArgument, "no tx hash specified")
+	}
+	var fee string
+	if g.feeVerifier != nil {
+		if len(feeTxHash) == 0 {
+			return status.Error(codes.FailedPrecondition, "observation requests require a fee transaction")
+		}
+		fee = hex.EncodeToString(feeTxHash)
+	}
+
+	tx := requestedTx{chainID: chainID, txHash: hex.EncodeToString(txHash)}
+	if err := g.reserve(client, tx, fee); err != nil {
+		return err
+	}
+
+	if g.feeVerifier != nil {
+		if err := g.feeVerifier.VerifyFee(ctx, feeTxHash); err != nil {
+			g.release(tx, fee)
+			if errors.Is(err, ErrFeeNotPaid) {
+				return status.Error(codes.FailedPrecondition, err.Error())
+			}
+			g.logger.Warn("failed to verify fee transaction", zap.String("fee_tx_hash", fee), zap.Error(err))
+			return status.Error(codes.Unavailable, "failed to verify the fee transaction")
+		}
+	}
+	if err := txVerifier.VerifyTx(ctx, txHash); err != nil {
+		g.release(tx, fee)
+		if errors.Is(err, ErrTxNotFound) {
+			return status.Error(codes.NotFound, err.Error())
+		}
+		g.logger.Warn("failed to verify transaction", zap.Stringer("chain", chainID), zap.String("tx_hash", tx.txHash), zap.Error(err))
+		return status.Error(codes.Unavailable, "failed to verify the transaction")
+	}
+
+	req := &gossipv1.ObservationRequest{ChainId: uint32(chainID), TxHash: txHash}
+	if err := common.PostObservationRequest(g.obsvReqSendC, req); err != nil {
+		g.release(tx, fee)
+		return status.Error(codes.Unavailable, "observation request queue is full")
+	}
+
+	g.logger.Info("sent public observation request",
+		zap.String("client", client),
+		zap.Stringer("chain", chainID),
+		zap.String("tx_hash", tx.txHash),
+		zap.String("fee_tx_hash", fee))
+	return nil
+}
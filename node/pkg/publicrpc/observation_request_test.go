@@ -0,0 +1,138 @@
+package publicrpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeVerifier accepts the transactions in txs and fails for all others with errNotFound.
+type fakeVerifier struct {
+	txs         map[string]bool
+	errNotFound error
+}
+
+func (v *fakeVerifier) verify(txHash []byte) error {
+	if !v.txs[string(txHash)] {
+		return v.errNotFound
+	}
+	return nil
+}
+
+func (v *fakeVerifier) VerifyTx(_ context.Context, txHash []byte) error { return v.verify(txHash) }
+
+func (v *fakeVerifier) VerifyFee(_ context.Context, feeTxHash []byte) error {
+	return v.verify(feeTxHash)
+}
+
+func newTestGate(t *testing.T, feeVerifier FeeVerifier, maxPerMinute int) (*ObservationRequestGate, *clock.Mock, chan *gossipv1.ObservationRequest) {
+	t.Helper()
+	clk := clock.NewMock()
+	obsvReqC := make(chan *gossipv1.ObservationRequest, 100)
+	txs := &fakeVerifier{txs: map[string]bool{}, errNotFound: ErrTxNotFound}
+	for i := byte(0); i < 50; i++ {
+		txs.txs[string([]byte{i})] = true
+	}
+	gate := NewObservationRequestGate(zap.NewNop(), clk, obsvReqC, map[vaa.ChainID]TxVerifier{vaa.ChainIDEthereum: txs}, feeVerifier, maxPerMinute)
+	return gate, clk, obsvReqC
+}
+
+func TestObservationRequestGate(t *testing.T) {
+	ctx := context.Background()
+	gate, clk, obsvReqC := newTestGate(t, nil, 100)
+
+	require.NoError(t, gate.Submit(ctx, "a", vaa.ChainIDEthereum, []byte{1}, nil))
+	require.Len(t, obsvReqC, 1)
+	assert.Equal(t, &gossipv1.ObservationRequest{ChainId: uint32(vaa.ChainIDEthereum), TxHash: []byte{1}}, <-obsvReqC)
+
+	err := gate.Submit(ctx, "a", vaa.ChainIDSolana, []byte{2}, nil)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// Requests for a recently requested transaction are dropped, whoever makes them.
+	err = gate.Submit(ctx, "b", vaa.ChainIDEthereum, []byte{1}, nil)
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+
+	// A transaction that does not exist can be requested again once it does.
+	err = gate.Submit(ctx, "a", vaa.ChainIDEthereum, []byte{100}, nil)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	gate.txVerifiers[vaa.ChainIDEthereum].(*fakeVerifier).txs[string([]byte{100})] = true
+	require.NoError(t, gate.Submit(ctx, "a", vaa.ChainIDEthereum, []byte{100}, nil))
+
+	clk.Add(requestDedupWindow)
+	require.NoError(t, gate.Submit(ctx, "b", vaa.ChainIDEthereum, []byte{1}, nil))
+	assert.Len(t, obsvReqC, 2)
+}
+
+func TestObservationRequestGateClientLimit(t *testing.T) {
+	ctx := context.Background()
+	gate, clk, _ := newTestGate(t, nil, 100)
+
+	for i := 0; i < requestClientLimit; i++ {
+		require.NoError(t, gate.Submit(ctx, "a", vaa.ChainIDEthereum, []byte{byte(i)}, nil))
+		clk.Add(time.Minute)
+	}
+	err := gate.Submit(ctx, "a", vaa.ChainIDEthereum, []byte{requestClientLimit}, nil)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// Other clients are not affected.
+	require.NoError(t, gate.Submit(ctx, "b", vaa.ChainIDEthereum, []byte{requestClientLimit}, nil))
+
+	clk.Add(requestClientWindow)
+	require.NoError(t, gate.Submit(ctx, "a", vaa.ChainIDEthereum, []byte{requestClientLimit + 1}, nil))
+}
+
+func TestObservationRequestGateGlobalLimit(t *testing.T) {
+	ctx := context.Background()
+	gate, clk, _ := newTestGate(t, nil, 2)
+
+	require.NoError(t, gate.Submit(ctx, "a", vaa.ChainIDEthereum, []byte{1}, nil))
+	require.NoError(t, gate.Submit(ctx, "b", vaa.ChainIDEthereum, []byte{2}, nil))
+	err := gate.Submit(ctx, "c", vaa.ChainIDEthereum, []byte{3}, nil)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	clk.Add(time.Minute)
+	require.NoError(t, gate.Submit(ctx, "c", vaa.ChainIDEthereum, []byte{3}, nil))
+}
+
+func TestObservationRequestGateFee(t *testing.T) {
+	ctx := context.Background()
+	fees := &fakeVerifier{txs: map[string]bool{"fee1": true, "fee2": true}, errNotFound: ErrFeeNotPaid}
+	gate, _, _ := newTestGate(t, fees, 100)
+
+	err := gate.Submit(ctx, "a", vaa.ChainIDEthereum, []byte{1}, nil)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	err = gate.Submit(ctx, "a", vaa.ChainIDEthereum, []byte{1}, []byte("unpaid"))
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	require.NoError(t, gate.Submit(ctx, "a", vaa.ChainIDEthereum, []byte{1}, []byte("fee1")))
+
+	// Each fee pays for a single request.
+	err = gate.Submit(ctx, "a", vaa.ChainIDEthereum, []byte{2}, []byte("fee1"))
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+
+	// A fee used for a request that failed can be used again.
+	err = gate.Submit(ctx, "a", vaa.ChainIDEthereum, []byte{100}, []byte("fee2"))
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	require.NoError(t, gate.Submit(ctx, "a", vaa.ChainIDEthereum, []byte{2}, []byte("fee2")))
+}
+
+func TestObservationRequestGateVerifierUnavailable(t *testing.T) {
+	ctx := context.Background()
+	gate, _, obsvReqC := newTestGate(t, nil, 100)
+	gate.txVerifiers[vaa.ChainIDEthereum].(*fakeVerifier).errNotFound = errors.New("connection refused")
+
+	err := gate.Submit(ctx, "a", vaa.ChainIDEthereum, []byte{100}, nil)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Len(t, obsvReqC, 0)
+}
@@ -4,24 +4,49 @@ import (
 	"context"
 	"encoding/hex"
 	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/certusone/wormhole/node/pkg/common"
 	"github.com/certusone/wormhole/node/pkg/db"
 	"github.com/certusone/wormhole/node/pkg/governor"
+	"github.com/certusone/wormhole/node/pkg/p2p"
 	publicrpcv1 "github.com/certusone/wormhole/node/pkg/proto/publicrpc/v1"
 	"github.com/wormhole-foundation/wormhole/sdk/vaa"
 	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/runtime/protoiface"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
+const (
+	// Suggested delays before retrying GetSignedVAA for a missing VAA.
+	notYetQuorumRetryAfter     = 15 * time.Second
+	governorEnqueuedRetryAfter = 1 * time.Hour
+	unknownEmitterRetryAfter   = 1 * time.Minute
+)
+
+// contractRegistry is the subset of the p2p registry used to look up the contract addresses the watchers run with.
+type contractRegistry interface {
+	ContractAddresses() map[vaa.ChainID]string
+}
+
 // PublicrpcServer implements the publicrpc gRPC service.
 type PublicrpcServer struct {
 	publicrpcv1.UnsafePublicRPCServiceServer
-	logger *zap.Logger
-	db     *db.Database
-	gst    *common.GuardianSetState
-	gov    *governor.ChainGovernor
+	logger    *zap.Logger
+	db        *db.Database
+	gst       *common.GuardianSetState
+	gov       *governor.ChainGovernor
+	contracts contractRegistry
+	// obsvReqGate accepts observation requests, which are rejected if it is nil.
+	obsvReqGate *ObservationRequestGate
 }
 
 func NewPublicrpcServer(
@@ -31,13 +56,19 @@ func NewPublicrpcServer(
 	gov *governor.ChainGovernor,
 ) *PublicrpcServer {
 	return &PublicrpcServer{
-		logger: logger.Named("publicrpcserver"),
-		db:     db,
-		gst:    gst,
-		gov:    gov,
+		logger:    logger.Named("publicrpcserver"),
+		db:        db,
+		gst:       gst,
+		gov:       gov,
+		contracts: p2p.DefaultRegistry,
 	}
 }
 
+// SetObservationRequestGate enables RequestObservation, with g deciding which requests to broadcast.
+func (s *PublicrpcServer) SetObservationRequestGate(g *ObservationRequestGate) {
+	s.obsvReqGate = g
+}
+
 func (s *PublicrpcServer) GetLastHeartbeats(ctx context.Context, req *publicrpcv1.GetLastHeartbeatsRequest) (*publicrpcv1.GetLastHeartbeatsResponse, error) {
 	gs := s.gst.Get()
 	if gs == nil {
@@ -63,6 +94,34 @@ func (s *PublicrpcServer) GetLastHeartbeats(ctx context.Context, req *publicrpcv
 	return resp, nil
 }
 
+func (s *PublicrpcServer) GetSignedHeartbeats(ctx context.Context, req *publicrpcv1.GetSignedHeartbeatsRequest) (*publicrpcv1.GetSignedHeartbeatsResponse, error) {
+	gs := s.gst.Get()
+	if gs == nil {
+		return nil, status.Error(codes.Unavailable, "guardian set not fetched from chain yet")
+	}
+
+	heartbeats := s.gst.LastSignedHeartbeats()
+
+	resp := &publicrpcv1.GetSignedHeartbeatsResponse{
+		GuardianSetIndex: gs.Index,
+		Entries:          make([]*publicrpcv1.GetSignedHeartbeatsResponse_Entry, 0, len(gs.Keys)),
+	}
+	for i, addr := range gs.Keys {
+		entry := &publicrpcv1.GetSignedHeartbeatsResponse_Entry{
+			GuardianIndex: uint32(i),
+			GuardianAddr:  addr.Hex(),
+		}
+		if hb, ok := heartbeats[addr]; ok {
+			entry.P2PNodeAddr = hb.PeerID.Pretty()
+			entry.SignedHeartbeat = hb.Signed
+			entry.Heartbeat = hb.Heartbeat
+		}
+		resp.Entries = append(resp.Entries, entry)
+	}
+
+	return resp, nil
+}
+
 func (s *PublicrpcServer) GetSignedVAA(ctx context.Context, req *publicrpcv1.GetSignedVAARequest) (*publicrpcv1.GetSignedVAAResponse, error) {
 	if req.MessageId == nil {
 		return nil, status.Error(codes.InvalidArgument, "no message ID specified")
@@ -94,7 +153,14 @@ func (s *PublicrpcServer) GetSignedVAA(ctx context.Context, req *publicrpcv1.Get
 
 	if err != nil {
 		if err == db.ErrVAANotFound {
-			return nil, status.Error(codes.NotFound, err.Error())
+			return nil, s.vaaNotFoundError(req.MessageId, db.VAAID{
+				EmitterChain:   chainID,
+				EmitterAddress: addr,
+				Sequence:       req.MessageId.Sequence,
+			})
+		}
+		if err == db.ErrVAACorrupt {
+			return nil, status.Error(codes.DataLoss, err.Error())
 		}
 		s.logger.Error("failed to fetch VAA", zap.Error(err), zap.Any("request", req))
 		return nil, status.Error(codes.Internal, "internal server error")
@@ -105,6 +171,56 @@ func (s *PublicrpcServer) GetSignedVAA(ctx context.Context, req *publicrpcv1.Get
 	}, nil
 }
 
+// vaaNotFoundError builds a NOT_FOUND status for a missing VAA, with details on why it is missing and when to retry.
+func (s *PublicrpcServer) vaaNotFoundError(msgId *publicrpcv1.MessageID, id db.VAAID) error {
+	reason := publicrpcv1.SignedVAANotFound_REASON_NOT_YET_QUORUM
+	retryAfter := notYetQuorumRetryAfter
+
+	enqueued := false
+	if s.gov != nil {
+		var err error
+		if enqueued, err = s.gov.IsVAAEnqueued(msgId); err == nil && enqueued {
+			retryAfter = governorEnqueuedRetryAfter
+		}
+	}
+
+	// Sequences up to the highest one deleted by the retention policy are reported as pruned, and retrying is
+	// pointless. A node that joined late may simply not have received older VAAs of a known emitter.
+	pruned := false
+	if !enqueued {
+		prunedUpTo, found, err := s.db.PrunedUpTo(id)
+		if err != nil {
+			s.logger.Error("failed to look up pruned VAAs", zap.Error(err), zap.Any("message_id", msgId))
+		} else if found && id.Sequence <= prunedUpTo {
+			pruned = true
+			reason = publicrpcv1.SignedVAANotFound_REASON_PRUNED
+			retryAfter = 0
+		}
+	}
+	if !enqueued && !pruned {
+		found, err := s.db.HasEmitterSignedVAAs(id)
+		if err != nil {
+			s.logger.Error("failed to look up emitter", zap.Error(err), zap.Any("message_id", msgId))
+		} else if !found {
+			reason = publicrpcv1.SignedVAANotFound_REASON_UNKNOWN_EMITTER
+			retryAfter = unknownEmitterRetryAfter
+		}
+	}
+
+	st := status.New(codes.NotFound, db.ErrVAANotFound.Error())
+	details := []protoiface.MessageV1{&publicrpcv1.SignedVAANotFound{Reason: reason}}
+	if retryAfter != 0 {
+		details = append(details, &errdetails.RetryInfo{RetryDelay: durationpb.New(retryAfter)})
+	}
+
+	withDetails, err := st.WithDetails(details...)
+	if err != nil {
+		s.logger.Error("failed to attach status details", zap.Error(err))
+		return st.Err()
+	}
+	return withDetails.Err()
+}
+
 func (s *PublicrpcServer) GetSignedBatchVAA(ctx context.Context, req *publicrpcv1.GetSignedBatchVAARequest) (*publicrpcv1.GetSignedBatchVAAResponse, error) {
 	// TEMP - noop implementaion to satisfy inclusion requirement
 	return nil, status.Error(codes.Unimplemented, "not yet implemented")
@@ -130,6 +246,32 @@ func (s *PublicrpcServer) GetCurrentGuardianSet(ctx context.Context, req *public
 	return resp, nil
 }
 
+func (s *PublicrpcServer) GetNetworkConfig(ctx context.Context, req *publicrpcv1.GetNetworkConfigRequest) (*publicrpcv1.GetNetworkConfigResponse, error) {
+	current, err := s.GetCurrentGuardianSet(ctx, &publicrpcv1.GetCurrentGuardianSetRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &publicrpcv1.GetNetworkConfigResponse{
+		GuardianSet:       current.GuardianSet,
+		GovernanceChain:   publicrpcv1.ChainID(vaa.GovernanceChain),
+		GovernanceEmitter: hex.EncodeToString(vaa.GovernanceEmitter.Bytes()),
+	}
+
+	addrs := s.contracts.ContractAddresses()
+	for chain, addr := range addrs {
+		resp.Contracts = append(resp.Contracts, &publicrpcv1.ChainContract{
+			ChainId:         publicrpcv1.ChainID(chain),
+			ContractAddress: addr,
+		})
+	}
+	sort.Slice(resp.Contracts, func(i, j int) bool {
+		return resp.Contracts[i].ChainId < resp.Contracts[j].ChainId
+	})
+
+	return resp, nil
+}
+
 func (s *PublicrpcServer) GovernorGetAvailableNotionalByChain(ctx context.Context, req *publicrpcv1.GovernorGetAvailableNotionalByChainRequest) (*publicrpcv1.GovernorGetAvailableNotionalByChainResponse, error) {
 	resp := &publicrpcv1.GovernorGetAvailableNotionalByChainResponse{}
 
@@ -181,3 +323,43 @@ func (s *PublicrpcServer) GovernorGetTokenList(ctx context.Context, req *publicr
 
 	return resp, nil
 }
+
+// requestClient identifies the client making a request for rate limiting: the first address of the X-Forwarded-For
+// header set by the publicweb gateway, or the host of the peer address.
+func requestClient(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if forwarded := md.Get("x-forwarded-for"); len(forwarded) > 0 {
+			return strings.TrimSpace(strings.Split(forwarded[0], ",")[0])
+		}
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+			return host
+		}
+		return p.Addr.String()
+	}
+	return ""
+}
+
+func (s *PublicrpcServer) RequestObservation(ctx context.Context, req *publicrpcv1.RequestObservationRequest) (*publicrpcv1.RequestObservationResponse, error) {
+	if s.obsvReqGate == nil {
+		return nil, status.Error(codes.Unimplemented, "observation requests are not enabled on this node")
+	}
+
+	txHash, err := hex.DecodeString(strings.TrimPrefix(req.TxHash, "0x"))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid tx hash")
+	}
+	var feeTxHash []byte
+	if req.FeeTxHash != "" {
+		feeTxHash, err = hex.DecodeString(strings.TrimPrefix(req.FeeTxHash, "0x"))
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid fee tx hash")
+		}
+	}
+
+	if err := s.obsvReqGate.Submit(ctx, requestClient(ctx), vaa.ChainID(req.ChainId), txHash, feeTxHash); err != nil {
+		return nil, err
+	}
+	return &publicrpcv1.RequestObservationResponse{}, nil
+}
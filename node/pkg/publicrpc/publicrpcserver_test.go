@@ -3,10 +3,19 @@ package publicrpc
 import (
 	"context"
 	"testing"
+	"time"
 
+	"github.com/certusone/wormhole/node/pkg/common"
+	"github.com/certusone/wormhole/node/pkg/db"
+	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
 	publicrpcv1 "github.com/certusone/wormhole/node/pkg/proto/publicrpc/v1"
+	eth_common "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
 	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -63,3 +72,165 @@ func TestGetSignedVAABadAddress(t *testing.T) {
 	expected_err := status.Error(codes.InvalidArgument, "address must be 32 bytes")
 	assert.Equal(t, expected_err, err)
 }
+
+func TestGetSignedVAANotFoundDetails(t *testing.T) {
+	d, err := db.Open(t.TempDir())
+	require.NoError(t, err)
+	defer d.Close()
+
+	emitter := vaa.Address{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 4}
+	v := &vaa.VAA{
+		Version:          vaa.SupportedVAAVersion,
+		Timestamp:        time.Unix(0, 0),
+		Sequence:         5,
+		EmitterChain:     vaa.ChainIDSolana,
+		EmitterAddress:   emitter,
+		Payload:          []byte{1},
+		ConsistencyLevel: 1,
+	}
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	v.AddSignature(key, 0)
+	require.NoError(t, d.StoreSignedVAA(v))
+
+	// All VAAs of another emitter were deleted by the retention policy
+	prunedEmitter := vaa.Address{31: 9}
+	pruned := *v
+	pruned.EmitterAddress = prunedEmitter
+	pruned.Sequence = 3
+	require.NoError(t, d.StoreSignedVAA(&pruned))
+	retention, err := common.ParseVAARetention("solana:" + prunedEmitter.String() + "=24h")
+	require.NoError(t, err)
+	_, err = d.PruneVAAs(retention, time.Now())
+	require.NoError(t, err)
+
+	logger, _ := zap.NewDevelopment()
+	server := NewPublicrpcServer(logger, d, nil, nil)
+
+	tests := []struct {
+		label      string
+		emitter    string
+		sequence   uint64
+		reason     publicrpcv1.SignedVAANotFound_Reason
+		retryAfter time.Duration
+	}{
+		{label: "NotYetQuorum", emitter: emitter.String(), sequence: 6, reason: publicrpcv1.SignedVAANotFound_REASON_NOT_YET_QUORUM, retryAfter: notYetQuorumRetryAfter},
+		{label: "BeforeFirstStored", emitter: emitter.String(), sequence: 4, reason: publicrpcv1.SignedVAANotFound_REASON_NOT_YET_QUORUM, retryAfter: notYetQuorumRetryAfter},
+		{label: "UnknownEmitter", emitter: vaa.Address{1}.String(), sequence: 5, reason: publicrpcv1.SignedVAANotFound_REASON_UNKNOWN_EMITTER, retryAfter: unknownEmitterRetryAfter},
+		{label: "Pruned", emitter: prunedEmitter.String(), sequence: 3, reason: publicrpcv1.SignedVAANotFound_REASON_PRUNED},
+		{label: "BeforePruned", emitter: prunedEmitter.String(), sequence: 1, reason: publicrpcv1.SignedVAANotFound_REASON_PRUNED},
+		{label: "AfterPruned", emitter: prunedEmitter.String(), sequence: 4, reason: publicrpcv1.SignedVAANotFound_REASON_UNKNOWN_EMITTER, retryAfter: unknownEmitterRetryAfter},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.label, func(t *testing.T) {
+			resp, err := server.GetSignedVAA(context.Background(), &publicrpcv1.GetSignedVAARequest{
+				MessageId: &publicrpcv1.MessageID{
+					EmitterChain:   publicrpcv1.ChainID(vaa.ChainIDSolana),
+					EmitterAddress: tc.emitter,
+					Sequence:       tc.sequence,
+				},
+			})
+			assert.Nil(t, resp)
+
+			st, ok := status.FromError(err)
+			require.True(t, ok)
+			assert.Equal(t, codes.NotFound, st.Code())
+
+			var reason *publicrpcv1.SignedVAANotFound
+			var retry *errdetails.RetryInfo
+			for _, d := range st.Details() {
+				switch d := d.(type) {
+				case *publicrpcv1.SignedVAANotFound:
+					reason = d
+				case *errdetails.RetryInfo:
+					retry = d
+				}
+			}
+
+			require.NotNil(t, reason)
+			assert.Equal(t, tc.reason, reason.Reason)
+			if tc.retryAfter == 0 {
+				assert.Nil(t, retry)
+			} else {
+				require.NotNil(t, retry)
+				assert.Equal(t, tc.retryAfter, retry.RetryDelay.AsDuration())
+			}
+		})
+	}
+}
+
+func TestGetSignedHeartbeats(t *testing.T) {
+	gst := common.NewGuardianSetState()
+	logger, _ := zap.NewProduction()
+	server := &PublicrpcServer{logger: logger, gst: gst}
+	ctx := context.Background()
+
+	_, err := server.GetSignedHeartbeats(ctx, &publicrpcv1.GetSignedHeartbeatsRequest{})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+
+	guardians := []eth_common.Address{
+		eth_common.HexToAddress("0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed"),
+		eth_common.HexToAddress("0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaee"),
+	}
+	gst.Set(&common.GuardianSet{Keys: guardians, Index: 2})
+
+	hb := &common.SignedHeartbeat{
+		PeerID:    "peer",
+		Signed:    &gossipv1.SignedHeartbeat{Heartbeat: []byte{1}, Signature: []byte{2}, GuardianAddr: guardians[1].Bytes()},
+		Heartbeat: &gossipv1.Heartbeat{Timestamp: time.Now().UnixNano(), Version: "v1"},
+	}
+	gst.SetSignedHeartbeat(guardians[1], hb)
+	// Heartbeats of guardians outside the current set are not exported
+	gst.SetSignedHeartbeat(eth_common.HexToAddress("0x01"), hb)
+
+	resp, err := server.GetSignedHeartbeats(ctx, &publicrpcv1.GetSignedHeartbeatsRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2), resp.GuardianSetIndex)
+	require.Len(t, resp.Entries, 2)
+
+	assert.Equal(t, uint32(0), resp.Entries[0].GuardianIndex)
+	assert.Equal(t, guardians[0].Hex(), resp.Entries[0].GuardianAddr)
+	assert.Nil(t, resp.Entries[0].SignedHeartbeat)
+
+	assert.Equal(t, uint32(1), resp.Entries[1].GuardianIndex)
+	assert.Equal(t, guardians[1].Hex(), resp.Entries[1].GuardianAddr)
+	assert.Equal(t, hb.PeerID.Pretty(), resp.Entries[1].P2PNodeAddr)
+	assert.Equal(t, hb.Signed, resp.Entries[1].SignedHeartbeat)
+	assert.Equal(t, hb.Heartbeat, resp.Entries[1].Heartbeat)
+}
+
+type mockContractRegistry map[vaa.ChainID]string
+
+func (m mockContractRegistry) ContractAddresses() map[vaa.ChainID]string {
+	return m
+}
+
+func TestGetNetworkConfig(t *testing.T) {
+	gst := common.NewGuardianSetState()
+	logger, _ := zap.NewProduction()
+	server := &PublicrpcServer{logger: logger, gst: gst, contracts: mockContractRegistry{
+		vaa.ChainIDEthereum: "0x98f3c9e6E3fAce36bAAd05FE09d375Ef1464288B",
+		vaa.ChainIDSolana:   "worm2ZoG2kUd4vFXhvjh93UUH596ayRfgQ2MgjNMTth",
+	}}
+	ctx := context.Background()
+
+	_, err := server.GetNetworkConfig(ctx, &publicrpcv1.GetNetworkConfigRequest{})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+
+	guardian := eth_common.HexToAddress("0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed")
+	gst.Set(&common.GuardianSet{Keys: []eth_common.Address{guardian}, Index: 3})
+
+	resp, err := server.GetNetworkConfig(ctx, &publicrpcv1.GetNetworkConfigRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, uint32(3), resp.GuardianSet.Index)
+	assert.Equal(t, []string{guardian.Hex()}, resp.GuardianSet.Addresses)
+	assert.Equal(t, publicrpcv1.ChainID_CHAIN_ID_SOLANA, resp.GovernanceChain)
+	assert.Equal(t, "0000000000000000000000000000000000000000000000000000000000000004", resp.GovernanceEmitter)
+
+	require.Len(t, resp.Contracts, 2)
+	assert.Equal(t, publicrpcv1.ChainID_CHAIN_ID_SOLANA, resp.Contracts[0].ChainId)
+	assert.Equal(t, "worm2ZoG2kUd4vFXhvjh93UUH596ayRfgQ2MgjNMTth", resp.Contracts[0].ContractAddress)
+	assert.Equal(t, publicrpcv1.ChainID_CHAIN_ID_ETHEREUM, resp.Contracts[1].ChainId)
+	assert.Equal(t, "0x98f3c9e6E3fAce36bAAd05FE09d375Ef1464288B", resp.Contracts[1].ContractAddress)
+}
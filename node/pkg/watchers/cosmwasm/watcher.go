@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"strconv"
 	"time"
 
@@ -223,14 +224,34 @@ func (e *Watcher) Run(ctx context.Context) error {
 					panic("invalid chain ID")
 				}
 
-				tx := hex.EncodeToString(r.TxHash)
-
-				logger.Info("received observation request", zap.String("network", networkName), zap.String("tx_hash", tx))
-
 				client := &http.Client{
 					Timeout: time.Second * 5,
 				}
 
+				if emitter, sequence, ok := common.ObservationRequestMessageID(r); ok {
+					logger.Info("received observation request by message ID", zap.String("network", networkName),
+						zap.Stringer("emitter", emitter), zap.Uint64("sequence", sequence))
+
+					msgs, err := e.messagesBySequence(client, emitter, sequence, logger)
+					if err != nil {
+						logger.Error("query txs by sequence error", zap.String("network", networkName), zap.Error(err))
+						continue
+					}
+					if len(msgs) == 0 {
+						logger.Warn("no message found for observation request", zap.String("network", networkName),
+							zap.Stringer("emitter", emitter), zap.Uint64("sequence", sequence))
+					}
+					for _, msg := range msgs {
+						e.msgChan <- msg
+						messagesConfirmed.WithLabelValues(networkName).Inc()
+					}
+					continue
+				}
+
+				tx := hex.EncodeToString(r.TxHash)
+
+				logger.Info("received observation request", zap.String("network", networkName), zap.String("tx_hash", tx))
+
 				// Query for tx by hash
 				resp, err := client.Get(fmt.Sprintf("%s/cosmos/tx/v1beta1/txs/%s", e.urlLCD, tx))
 				if err != nil {
@@ -320,6 +341,38 @@ func (e *Watcher) Run(ctx context.Context) error {
 	}
 }
 
+// messagesBySequence searches the LCD for transactions of the core contract that posted a message with the given
+// sequence and returns the messages among them that were posted by emitter.
+func (e *Watcher) messagesBySequence(client *http.Client, emitter vaa.Address, sequence uint64, logger *zap.Logger) ([]*common.MessagePublication, error) {
+	query := url.Values{"events": {
+		fmt.Sprintf("%s='%s'", e.contractAddressFilterKey, e.contract),
+		fmt.Sprintf("wasm.message.sequence='%d'", sequence),
+	}}
+	resp, err := client.Get(fmt.Sprintf("%s/cosmos/tx/v1beta1/txs?%s", e.urlLCD, query.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("query txs: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read txs: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("query txs: %s: %s", resp.Status, body)
+	}
+
+	var msgs []*common.MessagePublication
+	for _, tx := range gjson.GetBytes(body, "tx_responses").Array() {
+		txHash := tx.Get("txhash").String()
+		for _, msg := range EventsToMessagePublications(e.contract, txHash, tx.Get("events").Array(), logger, e.chainID, e.contractAddressLogKey) {
+			if msg.EmitterAddress == emitter && msg.Sequence == sequence {
+				msgs = append(msgs, msg)
+			}
+		}
+	}
+	return msgs, nil
+}
+
 func EventsToMessagePublications(contract string, txHash string, events []gjson.Result, logger *zap.Logger, chainID vaa.ChainID, contractAddressKey string) []*common.MessagePublication {
 	networkName := vaa.ChainID(chainID).String()
 	msgs := make([]*common.MessagePublication, 0, len(events))
@@ -0,0 +1,117 @@
+package evm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/watchers/evm/connectors"
+	ethAbi "github.com/certusone/wormhole/node/pkg/watchers/evm/connectors/ethabi"
+	abi "github.com/ethereum/go-ethereum/accounts/abi"
+	eth_common "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+var (
+	testContract  = eth_common.HexToAddress("0x98f3c9e6E3fAce36bAAd05FE09d375Ef1464288B")
+	testSender    = eth_common.HexToAddress("0x3ee18B2214AFF97000D974cf647E7C347E8fa585")
+	testBlockHash = eth_common.HexToHash("0x5c7b1b2ee8d5e6e4a8b1d43fbe6e2b1f8d6b60dd0f5f2f4c9b8a7e6d5c4b3a29")
+	testTxHash    = eth_common.HexToHash("0x9d7c5a0d34e1e8c3d1a7f6b5e4d3c2b1a0f9e8d7c6b5a4f3e2d1c0b9a8f7e6d5")
+)
+
+// receiptConnector returns a receipt decoded from the JSON returned by a node.
+type receiptConnector struct {
+	connectors.Connector
+	receipt  string
+	filterer *ethAbi.AbiFilterer
+}
+
+func (c *receiptConnector) TransactionReceipt(ctx context.Context, txHash eth_common.Hash) (*types.Receipt, error) {
+	var r types.Receipt
+	if err := json.Unmarshal([]byte(c.receipt), &r); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+func (c *receiptConnector) TimeOfBlockByHash(ctx context.Context, hash eth_common.Hash) (uint64, error) {
+	return 1710338135, nil
+}
+
+func (c *receiptConnector) ParseLogMessagePublished(log types.Log) (*ethAbi.AbiLogMessagePublished, error) {
+	return c.filterer.ParseLogMessagePublished(log)
+}
+
+func logMessagePublishedJSON(t *testing.T, contract eth_common.Address, sequence uint64, payload []byte) string {
+	t.Helper()
+	parsed, err := abi.JSON(strings.NewReader(ethAbi.AbiABI))
+	require.NoError(t, err)
+	event := parsed.Events["LogMessagePublished"]
+	data, err := event.Inputs.NonIndexed().Pack(sequence, uint32(42), payload, uint8(1))
+	require.NoError(t, err)
+	b, err := json.Marshal(&types.Log{
+		Address:     contract,
+		Topics:      []eth_common.Hash{event.ID, eth_common.BytesToHash(testSender.Bytes())},
+		Data:        data,
+		BlockNumber: 19531250,
+		TxHash:      testTxHash,
+		BlockHash:   testBlockHash,
+	})
+	require.NoError(t, err)
+	return string(b)
+}
+
+// blobTxReceipt returns the receipt of an EIP-4844 blob transaction, with the blob gas fields go-ethereum v1.10 does
+// not know about.
+func blobTxReceipt(status string, logs ...string) string {
+	return fmt.Sprintf(`{
+		"type": "0x3",
+		"status": "%s",
+		"cumulativeGasUsed": "0x5208",
+		"logsBloom": "0x%s",
+		"logs": [%s],
+		"transactionHash": "%s",
+		"contractAddress": null,
+		"gasUsed": "0x5208",
+		"effectiveGasPrice": "0x3b9aca07",
+		"blobGasUsed": "0x20000",
+		"blobGasPrice": "0x1",
+		"blockHash": "%s",
+		"blockNumber": "0x12a05f2",
+		"transactionIndex": "0x0"
+	}`, status, strings.Repeat("0", 512), strings.Join(logs, ","), testTxHash.Hex(), testBlockHash.Hex())
+}
+
+func TestMessageEventsForBlobTransaction(t *testing.T) {
+	filterer, err := ethAbi.NewAbiFilterer(testContract, nil)
+	require.NoError(t, err)
+	c := &receiptConnector{
+		receipt: blobTxReceipt("0x1",
+			logMessagePublishedJSON(t, testContract, 7, []byte("hello")),
+			// Logs of other contracts are ignored
+			logMessagePublishedJSON(t, testSender, 8, []byte("spoofed")),
+		),
+		filterer: filterer,
+	}
+
+	blockNumber, msgs, err := MessageEventsForTransaction(context.Background(), c, testContract, vaa.ChainIDEthereum, testTxHash)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(19531250), blockNumber)
+	require.Len(t, msgs, 1)
+	assert.Equal(t, testTxHash, msgs[0].TxHash)
+	assert.Equal(t, time.Unix(1710338135, 0), msgs[0].Timestamp)
+	assert.Equal(t, uint64(7), msgs[0].Sequence)
+	assert.Equal(t, uint32(42), msgs[0].Nonce)
+	assert.Equal(t, PadAddress(testSender), msgs[0].EmitterAddress)
+	assert.Equal(t, []byte("hello"), msgs[0].Payload)
+
+	c.receipt = blobTxReceipt("0x0", logMessagePublishedJSON(t, testContract, 7, []byte("hello")))
+	_, _, err = MessageEventsForTransaction(context.Background(), c, testContract, vaa.ChainIDEthereum, testTxHash)
+	assert.Error(t, err)
+}
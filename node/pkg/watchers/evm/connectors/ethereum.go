@@ -2,6 +2,8 @@ package connectors
 
 import (
 	"context"
+	"fmt"
+	"math/big"
 	"time"
 
 	ethAbi "github.com/certusone/wormhole/node/pkg/watchers/evm/connectors/ethabi"
@@ -11,6 +13,7 @@ import (
 	ethereum "github.com/ethereum/go-ethereum"
 	ethBind "github.com/ethereum/go-ethereum/accounts/abi/bind"
 	ethCommon "github.com/ethereum/go-ethereum/common"
+	ethHexUtils "github.com/ethereum/go-ethereum/common/hexutil"
 	ethTypes "github.com/ethereum/go-ethereum/core/types"
 	ethClient "github.com/ethereum/go-ethereum/ethclient"
 	ethEvent "github.com/ethereum/go-ethereum/event"
@@ -84,22 +87,40 @@ func (e *EthereumConnector) TransactionReceipt(ctx context.Context, txHash ethCo
 	return e.client.TransactionReceipt(ctx, txHash)
 }
 
+// TimeOfBlockByHash only decodes the timestamp of the block. The go-ethereum client decodes all transactions of a
+// block, which fails for blocks with transaction types it does not know about, like EIP-4844 blob transactions.
 func (e *EthereumConnector) TimeOfBlockByHash(ctx context.Context, hash ethCommon.Hash) (uint64, error) {
-	block, err := e.client.BlockByHash(ctx, hash)
+	var m *struct {
+		Time *ethHexUtils.Uint64 `json:"timestamp"`
+	}
+	err := e.rawClient.CallContext(ctx, &m, "eth_getBlockByHash", hash, false)
 	if err != nil {
 		return 0, err
 	}
+	if m == nil {
+		return 0, ethereum.NotFound
+	}
+	if m.Time == nil {
+		return 0, fmt.Errorf("block %s has no timestamp", hash)
+	}
 
-	return block.Time(), err
+	return uint64(*m.Time), nil
 }
 
 func (e *EthereumConnector) ParseLogMessagePublished(log ethTypes.Log) (*ethAbi.AbiLogMessagePublished, error) {
 	return e.filterer.ParseLogMessagePublished(log)
 }
 
+// newHead is the part of a newHeads notification the connector needs. The hash is taken as reported by the node,
+// since go-ethereum cannot compute it for headers with fields added after its release.
+type newHead struct {
+	Number *ethHexUtils.Big `json:"number"`
+	Hash   ethCommon.Hash   `json:"hash"`
+}
+
 func (e *EthereumConnector) SubscribeForBlocks(ctx context.Context, sink chan<- *NewBlock) (ethereum.Subscription, error) {
-	headSink := make(chan *ethTypes.Header, 2)
-	headerSubscription, err := e.client.SubscribeNewHead(ctx, headSink)
+	headSink := make(chan *newHead, 2)
+	headerSubscription, err := e.rawClient.EthSubscribe(ctx, headSink, "newHeads")
 	if err != nil {
 		return nil, err
 	}
@@ -120,8 +141,8 @@ func (e *EthereumConnector) SubscribeForBlocks(ctx context.Context, sink chan<-
 					continue
 				}
 				sink <- &NewBlock{
-					Number: ev.Number,
-					Hash:   ev.Hash(),
+					Number: (*big.Int)(ev.Number),
+					Hash:   ev.Hash,
 				}
 			}
 		}
@@ -0,0 +1,239 @@
+package connectors
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	ethAbi "github.com/certusone/wormhole/node/pkg/watchers/evm/connectors/ethabi"
+	abi "github.com/ethereum/go-ethereum/accounts/abi"
+	ethCommon "github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	ethRpc "github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+var (
+	testContract   = ethCommon.HexToAddress("0x98f3c9e6E3fAce36bAAd05FE09d375Ef1464288B")
+	testSender     = ethCommon.HexToAddress("0x3ee18B2214AFF97000D974cf647E7C347E8fa585")
+	testBlockHash  = ethCommon.HexToHash("0x5c7b1b2ee8d5e6e4a8b1d43fbe6e2b1f8d6b60dd0f5f2f4c9b8a7e6d5c4b3a29")
+	testBlobTxHash = ethCommon.HexToHash("0x9d7c5a0d34e1e8c3d1a7f6b5e4d3c2b1a0f9e8d7c6b5a4f3e2d1c0b9a8f7e6d5")
+)
+
+// cancunHeader holds the header fields of a block after the Cancun upgrade, which added the blob gas fields and the
+// parent beacon block root.
+const cancunHeader = `
+	"number": "0x12a05f2",
+	"hash": "%s",
+	"parentHash": "0x1f7c2c6d1c3e8a8e5b1b0a8d7e6c5b4a39281706f5e4d3c2b1a0f9e8d7c6b5a4",
+	"nonce": "0x0000000000000000",
+	"sha3Uncles": "0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347",
+	"logsBloom": "0x%s",
+	"transactionsRoot": "0x0d9f3e7d6b2a1c8e5f4a3b2c1d0e9f8a7b6c5d4e3f2a1b0c9d8e7f6a5b4c3d2e",
+	"stateRoot": "0x2b6e1f3a4c5d6e7f8a9b0c1d2e3f4a5b6c7d8e9f0a1b2c3d4e5f6a7b8c9d0e1f",
+	"receiptsRoot": "0x3c7f2a4b5d6e7f8a9b0c1d2e3f4a5b6c7d8e9f0a1b2c3d4e5f6a7b8c9d0e1f2a",
+	"miner": "0x95222290dd7278aa3ddd389cc1e1d165cc4bafe5",
+	"difficulty": "0x0",
+	"totalDifficulty": "0xc70d815d562d3cfa955",
+	"extraData": "0x",
+	"size": "0x2a1",
+	"gasLimit": "0x1c9c380",
+	"gasUsed": "0x5208",
+	"timestamp": "0x65f1b057",
+	"mixHash": "0x4d8e3b5c6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b1c2d3e4f5a6b7c8d9e0f1a2b3c",
+	"baseFeePerGas": "0x7",
+	"withdrawalsRoot": "0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421",
+	"blobGasUsed": "0x20000",
+	"excessBlobGas": "0x0",
+	"parentBeaconBlockRoot": "0x6e9f4c5d6e7f8a9b0c1d2e3f4a5b6c7d8e9f0a1b2c3d4e5f6a7b8c9d0e1f2a3b"`
+
+// blobTx is an EIP-4844 transaction, which go-ethereum v1.10 cannot decode.
+const blobTx = `{
+	"type": "0x3",
+	"chainId": "0x1",
+	"nonce": "0x1",
+	"to": "0x98f3c9e6e3face36baad05fe09d375ef1464288b",
+	"gas": "0x186a0",
+	"maxPriorityFeePerGas": "0x3b9aca00",
+	"maxFeePerGas": "0x77359400",
+	"maxFeePerBlobGas": "0x1",
+	"value": "0x0",
+	"input": "0x",
+	"accessList": [],
+	"blobVersionedHashes": ["0x01a915e4d060149eb4365960e6a7a45f334393093061116b197e3240065ff2d8"],
+	"v": "0x0",
+	"r": "0x1",
+	"s": "0x1",
+	"yParity": "0x0",
+	"hash": "%s",
+	"blockHash": "%s",
+	"blockNumber": "0x12a05f2",
+	"transactionIndex": "0x0",
+	"from": "0x3ee18b2214aff97000d974cf647e7c347e8fa585"
+}`
+
+// logMessagePublished returns a LogMessagePublished log of testContract.
+func logMessagePublished(t *testing.T, sequence uint64, payload []byte) *ethTypes.Log {
+	t.Helper()
+	parsed, err := abi.JSON(strings.NewReader(ethAbi.AbiABI))
+	require.NoError(t, err)
+	event := parsed.Events["LogMessagePublished"]
+	data, err := event.Inputs.NonIndexed().Pack(sequence, uint32(42), payload, uint8(1))
+	require.NoError(t, err)
+	return &ethTypes.Log{
+		Address:     testContract,
+		Topics:      []ethCommon.Hash{event.ID, ethCommon.BytesToHash(testSender.Bytes())},
+		Data:        data,
+		BlockNumber: 0x12a05f2,
+		TxHash:      testBlobTxHash,
+		BlockHash:   testBlockHash,
+	}
+}
+
+// fakeEthService serves a block with a blob transaction and its receipt over the eth namespace of a JSON-RPC server.
+type fakeEthService struct {
+	t     *testing.T
+	heads chan json.RawMessage
+}
+
+func (s *fakeEthService) header() string {
+	return fmt.Sprintf(cancunHeader, testBlockHash.Hex(), strings.Repeat("0", 512))
+}
+
+func (s *fakeEthService) GetBlockByHash(hash ethCommon.Hash, fullTx bool) (json.RawMessage, error) {
+	if hash != testBlockHash {
+		return nil, nil
+	}
+	tx := fmt.Sprintf("%q", testBlobTxHash.Hex())
+	if fullTx {
+		tx = fmt.Sprintf(blobTx, testBlobTxHash.Hex(), testBlockHash.Hex())
+	}
+	return json.RawMessage(fmt.Sprintf(`{%s, "uncles": [], "withdrawals": [], "transactions": [%s]}`, s.header(), tx)), nil
+}
+
+func (s *fakeEthService) GetTransactionReceipt(hash ethCommon.Hash) (json.RawMessage, error) {
+	if hash != testBlobTxHash {
+		return nil, nil
+	}
+	l, err := json.Marshal(logMessagePublished(s.t, 7, []byte("hello")))
+	if err != nil {
+		return nil, err
+	}
+	return json.RawMessage(fmt.Sprintf(`{
+		"type": "0x3",
+		"status": "0x1",
+		"cumulativeGasUsed": "0x5208",
+		"logsBloom": "0x%s",
+		"logs": [%s],
+		"transactionHash": "%s",
+		"contractAddress": null,
+		"gasUsed": "0x5208",
+		"effectiveGasPrice": "0x3b9aca07",
+		"blobGasUsed": "0x20000",
+		"blobGasPrice": "0x1",
+		"blockHash": "%s",
+		"blockNumber": "0x12a05f2",
+		"transactionIndex": "0x0",
+		"from": "0x3ee18b2214aff97000d974cf647e7c347e8fa585",
+		"to": "0x98f3c9e6e3face36baad05fe09d375ef1464288b"
+	}`, strings.Repeat("0", 512), l, testBlobTxHash.Hex(), testBlockHash.Hex())), nil
+}
+
+func (s *fakeEthService) NewHeads(ctx context.Context) (*ethRpc.Subscription, error) {
+	notifier, ok := ethRpc.NotifierFromContext(ctx)
+	if !ok {
+		return nil, ethRpc.ErrNotificationsUnsupported
+	}
+	sub := notifier.CreateSubscription()
+	go func() {
+		for {
+			select {
+			case head := <-s.heads:
+				if err := notifier.Notify(sub.ID, head); err != nil {
+					return
+				}
+			case <-sub.Err():
+				return
+			}
+		}
+	}()
+	return sub, nil
+}
+
+func newTestEthereumConnector(t *testing.T) (*EthereumConnector, *fakeEthService) {
+	t.Helper()
+	service := &fakeEthService{t: t, heads: make(chan json.RawMessage, 1)}
+	server := ethRpc.NewServer()
+	require.NoError(t, server.RegisterName("eth", service))
+	httpServer := httptest.NewServer(server.WebsocketHandler([]string{"*"}))
+	t.Cleanup(func() {
+		httpServer.Close()
+		server.Stop()
+	})
+
+	url := "ws" + strings.TrimPrefix(httpServer.URL, "http")
+	c, err := NewEthereumConnector(context.Background(), "test", url, testContract, zap.NewNop())
+	require.NoError(t, err)
+	return c, service
+}
+
+func TestEthereumConnectorTimeOfBlockWithBlobTransaction(t *testing.T) {
+	c, _ := newTestEthereumConnector(t)
+	ctx := context.Background()
+
+	// go-ethereum cannot decode the blob transaction in the block
+	_, err := c.Client().BlockByHash(ctx, testBlockHash)
+	require.Error(t, err)
+
+	blockTime, err := c.TimeOfBlockByHash(ctx, testBlockHash)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0x65f1b057), blockTime)
+
+	_, err = c.TimeOfBlockByHash(ctx, ethCommon.Hash{1})
+	assert.Error(t, err)
+}
+
+func TestEthereumConnectorBlobTransactionReceipt(t *testing.T) {
+	c, _ := newTestEthereumConnector(t)
+
+	receipt, err := c.TransactionReceipt(context.Background(), testBlobTxHash)
+	require.NoError(t, err)
+	assert.Equal(t, uint8(3), receipt.Type)
+	assert.Equal(t, uint64(1), receipt.Status)
+	assert.Equal(t, testBlockHash, receipt.BlockHash)
+	require.Len(t, receipt.Logs, 1)
+
+	ev, err := c.ParseLogMessagePublished(*receipt.Logs[0])
+	require.NoError(t, err)
+	assert.Equal(t, testSender, ev.Sender)
+	assert.Equal(t, uint64(7), ev.Sequence)
+	assert.Equal(t, []byte("hello"), ev.Payload)
+}
+
+func TestEthereumConnectorSubscribeForBlocksUsesReportedHash(t *testing.T) {
+	c, service := newTestEthereumConnector(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sink := make(chan *NewBlock, 1)
+	sub, err := c.SubscribeForBlocks(ctx, sink)
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	// go-ethereum would compute a different hash, since it does not know about the fields added by Cancun
+	service.heads <- json.RawMessage("{" + service.header() + "}")
+	select {
+	case block := <-sink:
+		assert.Equal(t, testBlockHash, block.Hash)
+		assert.Equal(t, big.NewInt(0x12a05f2), block.Number)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no block received")
+	}
+}
@@ -11,26 +11,66 @@ import (
 	"github.com/certusone/wormhole/node/pkg/supervisor"
 	ethereum "github.com/ethereum/go-ethereum"
 	ethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	ethClient "github.com/ethereum/go-ethereum/ethclient"
 	ethEvent "github.com/ethereum/go-ethereum/event"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 
 	"go.uber.org/zap"
 )
 
+const (
+	// logPollMaxChunk is the largest block range queried with a single eth_getLogs call.
+	logPollMaxChunk = 1000
+	// logPollGrowAfter is the number of consecutive successful queries after which a range that was shrunk is doubled
+	// again.
+	logPollGrowAfter = 10
+	// logPollQueueSize is the number of blocks that can be queued while logs are queried. Queued blocks are merged
+	// into a single range, so that catching up does not take one query per block.
+	logPollQueueSize = 1000
+)
+
+var (
+	logPollRemainingBlocks = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "wormhole_eth_log_poll_remaining_blocks",
+			Help: "Number of blocks the log poller still has to query logs for",
+		}, []string{"eth_network"})
+	logPollChunkSize = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "wormhole_eth_log_poll_chunk_size",
+			Help: "Current block range of the eth_getLogs queries of the log poller",
+		}, []string{"eth_network"})
+	logPollQueryErrors = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "wormhole_eth_log_poll_query_errors_total",
+			Help: "Total number of failed eth_getLogs queries of the log poller",
+		}, []string{"eth_network"})
+)
+
 // LogPollConnector pulls logs on each new block event when subscribing using WatchLogMessagePublished instead of using
 // a websocket connection. It can be used in conjunction with a BlockPollConnector and Finalizer to only return
 // finalized message log events.
+//
+// Logs are queried in ranges of up to logPollMaxChunk blocks. A range is halved whenever its query fails, since
+// providers limit the number of blocks or logs a query may cover, and grows back after logPollGrowAfter successful
+// queries.
 type LogPollConnector struct {
 	Connector
-	client      *ethClient.Client
+	filterLogs  func(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error)
 	messageFeed ethEvent.Feed
 	errFeed     ethEvent.Feed
 
-	prevBlockNum *big.Int
+	// lastBlock is the last block whose logs were published, valid once started is set.
+	lastBlock uint64
+	started   bool
+	chunkSize uint64
+	successes int
 }
 
 func NewLogPollConnector(ctx context.Context, baseConnector Connector, client *ethClient.Client) (*LogPollConnector, error) {
-	connector := &LogPollConnector{Connector: baseConnector, client: client}
+	connector := &LogPollConnector{Connector: baseConnector, filterLogs: client.FilterLogs, chunkSize: logPollMaxChunk}
 	// The supervisor will keep the poller running
 	err := supervisor.Run(ctx, "logPoller", connector.run)
 	if err != nil {
@@ -42,7 +82,7 @@ func NewLogPollConnector(ctx context.Context, baseConnector Connector, client *e
 func (l *LogPollConnector) run(ctx context.Context) error {
 	logger := supervisor.Logger(ctx).With(zap.String("eth_network", l.Connector.NetworkName()))
 
-	blockChan := make(chan *NewBlock)
+	blockChan := make(chan *NewBlock, logPollQueueSize)
 	sub, err := l.SubscribeForBlocks(ctx, blockChan)
 	if err != nil {
 		return err
@@ -57,6 +97,7 @@ func (l *LogPollConnector) run(ctx context.Context) error {
 		case err := <-sub.Err():
 			return err
 		case block := <-blockChan:
+			block = latestQueuedBlock(blockChan, block)
 			if err := l.processBlock(ctx, logger, block); err != nil {
 				l.errFeed.Send(err.Error())
 			}
@@ -93,43 +134,87 @@ func (l *LogPollConnector) WatchLogMessagePublished(ctx context.Context, sink ch
 	return sub, nil
 }
 
-var (
-	getLogsBigOne       = big.NewInt(1)
-	logsLogMessageTopic = ethCommon.HexToHash("0x6eb224fb001ed210e379b335e35efe88672a8ce935d981a6896b27ffdf52a3b2")
-)
+var logsLogMessageTopic = ethCommon.HexToHash("0x6eb224fb001ed210e379b335e35efe88672a8ce935d981a6896b27ffdf52a3b2")
 
-func (l *LogPollConnector) processBlock(ctx context.Context, logger *zap.Logger, block *NewBlock) error {
-	if l.prevBlockNum == nil {
-		l.prevBlockNum = new(big.Int).Set(block.Number)
-	} else {
-		l.prevBlockNum.Add(l.prevBlockNum, getLogsBigOne)
+// latestQueuedBlock drains the blocks queued on blockChan and returns the latest one, or block if none are queued.
+func latestQueuedBlock(blockChan <-chan *NewBlock, block *NewBlock) *NewBlock {
+	for {
+		select {
+		case b := <-blockChan:
+			block = b
+		default:
+			return block
+		}
 	}
+}
 
-	filter := ethereum.FilterQuery{
-		FromBlock: l.prevBlockNum,
-		ToBlock:   block.Number,
-		Addresses: []ethCommon.Address{l.ContractAddress()},
+// processBlock publishes the logs of all blocks after the last processed one up to and including block. The last
+// processed block only advances past ranges whose logs were published, so a range that cannot be queried is retried on
+// the next block.
+func (l *LogPollConnector) processBlock(ctx context.Context, logger *zap.Logger, block *NewBlock) error {
+	to := block.Number.Uint64()
+	if !l.started {
+		l.lastBlock = to - 1
+		l.started = true
 	}
 
-	*l.prevBlockNum = *block.Number
+	networkName := l.NetworkName()
+	defer logPollRemainingBlocks.WithLabelValues(networkName).Set(0)
 
-	tCtx, cancel := context.WithTimeout(ctx, time.Second*10)
-	defer cancel()
-	logs, err := l.client.FilterLogs(tCtx, filter)
-	if err != nil {
-		logger.Error("GetLogsQuery: query of eth_getLogs failed",
-			zap.Stringer("FromBlock", filter.FromBlock),
-			zap.Stringer("ToBlock", filter.ToBlock),
-			zap.Error(err),
-		)
+	for l.lastBlock < to {
+		from := l.lastBlock + 1
+		end := to
+		if end-from+1 > l.chunkSize {
+			end = from + l.chunkSize - 1
+		}
+		logPollRemainingBlocks.WithLabelValues(networkName).Set(float64(to - l.lastBlock))
+		logPollChunkSize.WithLabelValues(networkName).Set(float64(l.chunkSize))
 
-		return fmt.Errorf("GetLogsQuery: failed to query for log messages: %w", err)
-	}
+		filter := ethereum.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(from),
+			ToBlock:   new(big.Int).SetUint64(end),
+			Addresses: []ethCommon.Address{l.ContractAddress()},
+		}
 
-	if len(logs) == 0 {
-		return nil
+		tCtx, cancel := context.WithTimeout(ctx, time.Second*10)
+		logs, err := l.filterLogs(tCtx, filter)
+		cancel()
+		if err != nil {
+			logPollQueryErrors.WithLabelValues(networkName).Inc()
+			logger.Error("GetLogsQuery: query of eth_getLogs failed",
+				zap.Stringer("FromBlock", filter.FromBlock),
+				zap.Stringer("ToBlock", filter.ToBlock),
+				zap.Uint64("chunkSize", l.chunkSize),
+				zap.Error(err),
+			)
+
+			l.successes = 0
+			if l.chunkSize == 1 {
+				return fmt.Errorf("GetLogsQuery: failed to query for log messages: %w", err)
+			}
+			l.chunkSize /= 2
+			continue
+		}
+
+		l.publishLogs(logger, filter, logs)
+		l.lastBlock = end
+
+		if l.chunkSize < logPollMaxChunk {
+			l.successes++
+			if l.successes >= logPollGrowAfter {
+				l.chunkSize *= 2
+				if l.chunkSize > logPollMaxChunk {
+					l.chunkSize = logPollMaxChunk
+				}
+				l.successes = 0
+			}
+		}
 	}
 
+	return nil
+}
+
+func (l *LogPollConnector) publishLogs(logger *zap.Logger, filter ethereum.FilterQuery, logs []types.Log) {
 	for _, log := range logs {
 		if log.Topics[0] != logsLogMessageTopic {
 			continue
@@ -142,12 +227,10 @@ func (l *LogPollConnector) processBlock(ctx context.Context, logger *zap.Logger,
 				zap.Error(err),
 			)
 
-			l.errFeed.Send(fmt.Errorf("failed to parse log message: %w", err))
+			l.errFeed.Send(fmt.Sprintf("failed to parse log message: %v", err))
 			continue
 		}
 
 		l.messageFeed.Send(ev)
 	}
-
-	return nil
 }
@@ -0,0 +1,127 @@
+package connectors
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/certusone/wormhole/node/pkg/watchers/evm/connectors/ethabi"
+	ethereum "github.com/ethereum/go-ethereum"
+	ethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type mockLogConnector struct {
+	Connector
+}
+
+func (mockLogConnector) NetworkName() string {
+	return "mock"
+}
+
+func (mockLogConnector) ContractAddress() ethCommon.Address {
+	return ethCommon.Address{}
+}
+
+func (mockLogConnector) ParseLogMessagePublished(log types.Log) (*ethabi.AbiLogMessagePublished, error) {
+	return &ethabi.AbiLogMessagePublished{Raw: log}, nil
+}
+
+// mockLogProvider returns one message log per block and rejects queries covering more than maxRange blocks.
+type mockLogProvider struct {
+	maxRange uint64
+	queries  [][2]uint64
+}
+
+func (p *mockLogProvider) filterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	from, to := q.FromBlock.Uint64(), q.ToBlock.Uint64()
+	p.queries = append(p.queries, [2]uint64{from, to})
+	if to-from+1 > p.maxRange {
+		return nil, errors.New("block range too large")
+	}
+	var logs []types.Log
+	for b := from; b <= to; b++ {
+		logs = append(logs, types.Log{BlockNumber: b, Topics: []ethCommon.Hash{logsLogMessageTopic}})
+	}
+	return logs, nil
+}
+
+func newTestLogPollConnector(t *testing.T, p *mockLogProvider) (*LogPollConnector, chan *ethabi.AbiLogMessagePublished) {
+	l := &LogPollConnector{Connector: mockLogConnector{}, filterLogs: p.filterLogs, chunkSize: logPollMaxChunk}
+	sink := make(chan *ethabi.AbiLogMessagePublished, 10000)
+	sub := l.messageFeed.Subscribe(sink)
+	t.Cleanup(sub.Unsubscribe)
+	return l, sink
+}
+
+func TestLogPollProcessesEachBlockOnce(t *testing.T) {
+	p := &mockLogProvider{maxRange: logPollMaxChunk}
+	l, sink := newTestLogPollConnector(t, p)
+
+	require.NoError(t, l.processBlock(context.Background(), zap.NewNop(), &NewBlock{Number: big.NewInt(100)}))
+	require.NoError(t, l.processBlock(context.Background(), zap.NewNop(), &NewBlock{Number: big.NewInt(101)}))
+	// Blocks merged while catching up are queried as one range
+	require.NoError(t, l.processBlock(context.Background(), zap.NewNop(), &NewBlock{Number: big.NewInt(150)}))
+
+	assert.Equal(t, [][2]uint64{{100, 100}, {101, 101}, {102, 150}}, p.queries)
+	require.Len(t, sink, 51)
+	for b := uint64(100); b <= 150; b++ {
+		assert.Equal(t, b, (<-sink).Raw.BlockNumber)
+	}
+}
+
+func TestLogPollShrinksAndGrowsChunks(t *testing.T) {
+	p := &mockLogProvider{maxRange: 300}
+	l, sink := newTestLogPollConnector(t, p)
+
+	require.NoError(t, l.processBlock(context.Background(), zap.NewNop(), &NewBlock{Number: big.NewInt(1)}))
+	require.NoError(t, l.processBlock(context.Background(), zap.NewNop(), &NewBlock{Number: big.NewInt(2001)}))
+
+	// The range is halved until the provider accepts it
+	assert.Equal(t, [][2]uint64{{1, 1}, {2, 1001}, {2, 501}, {2, 251}}, p.queries[:4])
+	assert.Equal(t, uint64(250), l.chunkSize)
+	assert.Equal(t, uint64(2001), l.lastBlock)
+	assert.Len(t, sink, 2001)
+
+	// Once the provider limit is lifted, the range grows back after enough successful queries
+	p.maxRange = logPollMaxChunk
+	to := uint64(2001)
+	for l.chunkSize == 250 {
+		to += 250
+		require.NoError(t, l.processBlock(context.Background(), zap.NewNop(), &NewBlock{Number: new(big.Int).SetUint64(to)}))
+	}
+	assert.Equal(t, uint64(500), l.chunkSize)
+	// 8 successful queries while catching up, 2 afterwards
+	assert.Equal(t, uint64(2501), to)
+}
+
+func TestLogPollRetriesFailedRange(t *testing.T) {
+	p := &mockLogProvider{maxRange: 0}
+	l, sink := newTestLogPollConnector(t, p)
+
+	require.Error(t, l.processBlock(context.Background(), zap.NewNop(), &NewBlock{Number: big.NewInt(10)}))
+	assert.Equal(t, uint64(1), l.chunkSize)
+	assert.Equal(t, uint64(9), l.lastBlock)
+	assert.Len(t, sink, 0)
+
+	// The failed block is queried again with the next one
+	p.maxRange = logPollMaxChunk
+	require.NoError(t, l.processBlock(context.Background(), zap.NewNop(), &NewBlock{Number: big.NewInt(11)}))
+	assert.Equal(t, uint64(11), l.lastBlock)
+	assert.Len(t, sink, 2)
+}
+
+func TestLatestQueuedBlock(t *testing.T) {
+	blockChan := make(chan *NewBlock, 3)
+	first := &NewBlock{Number: big.NewInt(1)}
+	assert.Equal(t, first, latestQueuedBlock(blockChan, first))
+
+	blockChan <- &NewBlock{Number: big.NewInt(2)}
+	blockChan <- &NewBlock{Number: big.NewInt(3)}
+	assert.Equal(t, big.NewInt(3), latestQueuedBlock(blockChan, first).Number)
+	assert.Len(t, blockChan, 0)
+}
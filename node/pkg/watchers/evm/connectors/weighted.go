@@ -0,0 +1,352 @@
+package connectors
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/supervisor"
+	ethAbi "github.com/certusone/wormhole/node/pkg/watchers/evm/connectors/ethabi"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	ethCommon "github.com/ethereum/go-ethereum/common"
+	ethHexUtils "github.com/ethereum/go-ethereum/common/hexutil"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	ethEvent "github.com/ethereum/go-ethereum/event"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+)
+
+var (
+	rpcEndpointWeight = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "wormhole_eth_rpc_endpoint_weight",
+			Help: "Share of the requests sent to each RPC endpoint of an EVM network, by position in the RPC flag",
+		}, []string{"eth_network", "endpoint"})
+	rpcEndpointLag = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "wormhole_eth_rpc_endpoint_lag",
+			Help: "Number of blocks each RPC endpoint of an EVM network is behind the most recent one, by position in the RPC flag",
+		}, []string{"eth_network", "endpoint"})
+)
+
+const (
+	// weightedProbeInterval is how often every endpoint is asked for its latest block.
+	weightedProbeInterval = 5 * time.Second
+	weightedProbeTimeout  = 5 * time.Second
+	// weightedMaxLag is how many blocks an endpoint may be behind the most recent endpoint before it gets no requests.
+	weightedMaxLag = 100
+	// weightedLatencySmoothing is the weight of a new latency sample in the moving average of an endpoint.
+	weightedLatencySmoothing = 0.3
+)
+
+// SplitRPCURLs splits an RPC flag holding a comma-separated list of endpoints.
+func SplitRPCURLs(rawUrls string) []string {
+	var urls []string
+	for _, u := range strings.Split(rawUrls, ",") {
+		if u = strings.TrimSpace(u); u != "" {
+			urls = append(urls, u)
+		}
+	}
+	return urls
+}
+
+type weightedEndpoint struct {
+	Connector
+
+	// Fields below are guarded by WeightedConnector.mu.
+	healthy bool
+	height  uint64
+	latency time.Duration
+}
+
+// WeightedConnector spreads requests over several RPC endpoints of the same network. Every endpoint is probed for its
+// latest block every weightedProbeInterval, and requests are sent to a random endpoint weighted by how fast it
+// responds and how close it is to the most recent block seen on any endpoint. A request that fails is retried on the
+// other endpoints, and the endpoint that failed gets no requests until it passes a probe again. Subscriptions are made
+// on the best endpoint at the time, since the watcher restarts when they fail.
+type WeightedConnector struct {
+	endpoints []*weightedEndpoint
+	logger    *zap.Logger
+
+	mu   sync.Mutex
+	rand *rand.Rand
+}
+
+func NewWeightedConnector(ctx context.Context, endpoints []Connector, logger *zap.Logger) (*WeightedConnector, error) {
+	w := newWeightedConnector(endpoints, logger)
+	w.probe(ctx)
+	if err := supervisor.Run(ctx, "rpcScorer", w.run); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func newWeightedConnector(endpoints []Connector, logger *zap.Logger) *WeightedConnector {
+	w := &WeightedConnector{
+		logger: logger.With(zap.String("eth_network", endpoints[0].NetworkName())),
+		rand:   rand.New(rand.NewSource(time.Now().UnixNano())), //#nosec G404 only used for load balancing
+	}
+	for _, e := range endpoints {
+		w.endpoints = append(w.endpoints, &weightedEndpoint{Connector: e, healthy: true})
+	}
+	return w
+}
+
+func (w *WeightedConnector) run(ctx context.Context) error {
+	supervisor.Signal(ctx, supervisor.SignalHealthy)
+	ticker := time.NewTicker(weightedProbeInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			w.probe(ctx)
+		}
+	}
+}
+
+// probe asks every endpoint for its latest block concurrently and updates their scores.
+func (w *WeightedConnector) probe(ctx context.Context) {
+	var wg sync.WaitGroup
+	for i, e := range w.endpoints {
+		wg.Add(1)
+		go func(i int, e *weightedEndpoint) {
+			defer wg.Done()
+			timeout, cancel := context.WithTimeout(ctx, weightedProbeTimeout)
+			defer cancel()
+
+			var height ethHexUtils.Uint64
+			start := time.Now()
+			err := e.RawCallContext(timeout, &height, "eth_blockNumber")
+			latency := time.Since(start)
+
+			w.mu.Lock()
+			defer w.mu.Unlock()
+			if err != nil {
+				if e.healthy {
+					w.logger.Warn("rpc endpoint failed health probe", zap.Int("endpoint", i), zap.Error(err))
+				}
+				e.healthy = false
+				return
+			}
+			e.healthy = true
+			e.height = uint64(height)
+			w.recordLatency(e, latency)
+		}(i, e)
+	}
+	wg.Wait()
+
+	weights := w.weights()
+	w.mu.Lock()
+	maxHeight := w.maxHeight()
+	for i, e := range w.endpoints {
+		endpoint := strconv.Itoa(i)
+		rpcEndpointWeight.WithLabelValues(w.NetworkName(), endpoint).Set(weights[i])
+		if e.height <= maxHeight {
+			rpcEndpointLag.WithLabelValues(w.NetworkName(), endpoint).Set(float64(maxHeight - e.height))
+		}
+	}
+	w.mu.Unlock()
+}
+
+// recordLatency adds a latency sample to the moving average of e. It must be called with w.mu held.
+func (w *WeightedConnector) recordLatency(e *weightedEndpoint, latency time.Duration) {
+	if e.latency == 0 {
+		e.latency = latency
+	} else {
+		e.latency = time.Duration(weightedLatencySmoothing*float64(latency) + (1-weightedLatencySmoothing)*float64(e.latency))
+	}
+}
+
+// maxHeight returns the most recent block seen on a healthy endpoint. It must be called with w.mu held.
+func (w *WeightedConnector) maxHeight() uint64 {
+	var maxHeight uint64
+	for _, e := range w.endpoints {
+		if e.healthy && e.height > maxHeight {
+			maxHeight = e.height
+		}
+	}
+	return maxHeight
+}
+
+// weights returns the share of the requests each endpoint gets. Healthy endpoints are weighted by the inverse of
+// their latency, divided by one plus the number of blocks they are behind. If no endpoint qualifies, all of them get
+// the same share so that requests are still attempted.
+func (w *WeightedConnector) weights() []float64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	maxHeight := w.maxHeight()
+	weights := make([]float64, len(w.endpoints))
+	var total float64
+	for i, e := range w.endpoints {
+		lag := maxHeight - e.height
+		if !e.healthy || lag > weightedMaxLag {
+			continue
+		}
+		latency := e.latency
+		if latency < time.Millisecond {
+			latency = time.Millisecond
+		}
+		weights[i] = 1 / (latency.Seconds() * float64(1+lag))
+		total += weights[i]
+	}
+	for i := range weights {
+		if total == 0 {
+			weights[i] = 1 / float64(len(weights))
+		} else {
+			weights[i] /= total
+		}
+	}
+	return weights
+}
+
+// order returns the endpoints in the order they should be tried for a request: a weighted random sample without
+// replacement, followed by the endpoints that currently get no requests.
+func (w *WeightedConnector) order() []*weightedEndpoint {
+	weights := w.weights()
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	order := make([]*weightedEndpoint, 0, len(w.endpoints))
+	remaining := 1.0
+	for len(order) < len(w.endpoints) {
+		next := -1
+		if remaining > 0 {
+			r := w.rand.Float64() * remaining
+			for i, weight := range weights {
+				if weight == 0 {
+					continue
+				}
+				next = i
+				if r < weight {
+					break
+				}
+				r -= weight
+			}
+		}
+		if next == -1 {
+			for i, e := range w.endpoints {
+				if !containsEndpoint(order, e) {
+					next = i
+					break
+				}
+			}
+		}
+		order = append(order, w.endpoints[next])
+		remaining -= weights[next]
+		weights[next] = 0
+	}
+	return order
+}
+
+func containsEndpoint(endpoints []*weightedEndpoint, e *weightedEndpoint) bool {
+	for _, o := range endpoints {
+		if o == e {
+			return true
+		}
+	}
+	return false
+}
+
+// best returns the endpoint with the highest weight.
+func (w *WeightedConnector) best() Connector {
+	weights := w.weights()
+	best := 0
+	for i := range weights {
+		if weights[i] > weights[best] {
+			best = i
+		}
+	}
+	return w.endpoints[best]
+}
+
+// call runs f on the endpoints in weighted order until it succeeds.
+func (w *WeightedConnector) call(ctx context.Context, f func(c Connector) error) error {
+	var err error
+	for _, e := range w.order() {
+		start := time.Now()
+		err = f(e)
+		w.mu.Lock()
+		if err == nil {
+			w.recordLatency(e, time.Since(start))
+		} else if ctx.Err() == nil && !errors.Is(err, ethereum.NotFound) {
+			// Errors caused by the caller are not the endpoint's fault.
+			e.healthy = false
+		}
+		w.mu.Unlock()
+		if err == nil || ctx.Err() != nil {
+			return err
+		}
+	}
+	return err
+}
+
+func (w *WeightedConnector) NetworkName() string {
+	return w.endpoints[0].NetworkName()
+}
+
+func (w *WeightedConnector) ContractAddress() ethCommon.Address {
+	return w.endpoints[0].ContractAddress()
+}
+
+func (w *WeightedConnector) GetCurrentGuardianSetIndex(ctx context.Context) (uint32, error) {
+	var index uint32
+	err := w.call(ctx, func(c Connector) (err error) {
+		index, err = c.GetCurrentGuardianSetIndex(ctx)
+		return err
+	})
+	return index, err
+}
+
+func (w *WeightedConnector) GetGuardianSet(ctx context.Context, index uint32) (ethAbi.StructsGuardianSet, error) {
+	var gs ethAbi.StructsGuardianSet
+	err := w.call(ctx, func(c Connector) (err error) {
+		gs, err = c.GetGuardianSet(ctx, index)
+		return err
+	})
+	return gs, err
+}
+
+func (w *WeightedConnector) WatchLogMessagePublished(ctx context.Context, sink chan<- *ethAbi.AbiLogMessagePublished) (ethEvent.Subscription, error) {
+	return w.best().WatchLogMessagePublished(ctx, sink)
+}
+
+func (w *WeightedConnector) TransactionReceipt(ctx context.Context, txHash ethCommon.Hash) (*ethTypes.Receipt, error) {
+	var receipt *ethTypes.Receipt
+	err := w.call(ctx, func(c Connector) (err error) {
+		receipt, err = c.TransactionReceipt(ctx, txHash)
+		return err
+	})
+	return receipt, err
+}
+
+func (w *WeightedConnector) TimeOfBlockByHash(ctx context.Context, hash ethCommon.Hash) (uint64, error) {
+	var blockTime uint64
+	err := w.call(ctx, func(c Connector) (err error) {
+		blockTime, err = c.TimeOfBlockByHash(ctx, hash)
+		return err
+	})
+	return blockTime, err
+}
+
+func (w *WeightedConnector) ParseLogMessagePublished(log ethTypes.Log) (*ethAbi.AbiLogMessagePublished, error) {
+	return w.endpoints[0].ParseLogMessagePublished(log)
+}
+
+func (w *WeightedConnector) SubscribeForBlocks(ctx context.Context, sink chan<- *NewBlock) (ethereum.Subscription, error) {
+	return w.best().SubscribeForBlocks(ctx, sink)
+}
+
+func (w *WeightedConnector) RawCallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	return w.call(ctx, func(c Connector) error {
+		return c.RawCallContext(ctx, result, method, args...)
+	})
+}
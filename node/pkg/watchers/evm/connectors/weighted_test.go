@@ -0,0 +1,131 @@
+package connectors
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	ethHexUtils "github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// mockRPCConnector reports height to health probes and fails other calls with err.
+type mockRPCConnector struct {
+	Connector
+	height   uint64
+	probeErr error
+	err      error
+	calls    int
+}
+
+func (mockRPCConnector) NetworkName() string {
+	return "mock"
+}
+
+func (c *mockRPCConnector) RawCallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	if method == "eth_blockNumber" {
+		if c.probeErr != nil {
+			return c.probeErr
+		}
+		*result.(*ethHexUtils.Uint64) = ethHexUtils.Uint64(c.height)
+		return nil
+	}
+	c.calls++
+	return c.err
+}
+
+func newTestWeightedConnector(t *testing.T, endpoints ...*mockRPCConnector) *WeightedConnector {
+	t.Helper()
+	var conns []Connector
+	for _, e := range endpoints {
+		conns = append(conns, e)
+	}
+	w := newWeightedConnector(conns, zap.NewNop())
+	w.probe(context.Background())
+	return w
+}
+
+func setLatencies(w *WeightedConnector, latencies ...time.Duration) {
+	for i, l := range latencies {
+		w.endpoints[i].latency = l
+	}
+}
+
+func TestWeightedConnectorWeights(t *testing.T) {
+	w := newTestWeightedConnector(t,
+		&mockRPCConnector{height: 1000},
+		&mockRPCConnector{height: 1000},
+		&mockRPCConnector{height: 999},
+		&mockRPCConnector{height: 1000 - weightedMaxLag - 1},
+		&mockRPCConnector{height: 1000, probeErr: errors.New("connection refused")},
+	)
+	setLatencies(w, 10*time.Millisecond, 40*time.Millisecond, 10*time.Millisecond, time.Millisecond, time.Millisecond)
+
+	weights := w.weights()
+	// Four times faster endpoints get four times the requests, as do endpoints one block behind an equally fast one.
+	assert.Less(t, math.Abs(4*weights[1]-weights[0]), 1e-9)
+	assert.Less(t, math.Abs(2*weights[2]-weights[0]), 1e-9)
+	// Endpoints that are stuck or failing get none.
+	assert.Equal(t, 0.0, weights[3])
+	assert.Equal(t, 0.0, weights[4])
+	assert.Less(t, math.Abs(1-weights[0]-weights[1]-weights[2]), 1e-9)
+
+	order := w.order()
+	require.Len(t, order, 5)
+	assert.ElementsMatch(t, w.endpoints[3:], order[3:])
+}
+
+func TestWeightedConnectorWeightsAllUnhealthy(t *testing.T) {
+	w := newTestWeightedConnector(t,
+		&mockRPCConnector{probeErr: errors.New("connection refused")},
+		&mockRPCConnector{probeErr: errors.New("connection refused")},
+	)
+	assert.Equal(t, []float64{0.5, 0.5}, w.weights())
+}
+
+func TestWeightedConnectorRetriesFailedEndpoint(t *testing.T) {
+	failing := &mockRPCConnector{height: 1000, err: errors.New("503 service unavailable")}
+	slow := &mockRPCConnector{height: 1000}
+	w := newTestWeightedConnector(t, failing, slow)
+	// The failing endpoint is so much faster that it is tried first.
+	setLatencies(w, time.Nanosecond, time.Hour)
+
+	require.NoError(t, w.RawCallContext(context.Background(), nil, "eth_getBlockByNumber", "latest", false))
+	assert.Equal(t, 1, failing.calls)
+	assert.Equal(t, 1, slow.calls)
+
+	// The failing endpoint gets no requests until it passes a probe again.
+	assert.Equal(t, []float64{0, 1}, w.weights())
+	require.NoError(t, w.RawCallContext(context.Background(), nil, "eth_getBlockByNumber", "latest", false))
+	assert.Equal(t, 1, failing.calls)
+	assert.Equal(t, 2, slow.calls)
+
+	w.probe(context.Background())
+	assert.Greater(t, w.weights()[0], 0.0)
+}
+
+func TestWeightedConnectorNotFoundIsHealthy(t *testing.T) {
+	a := &mockRPCConnector{height: 1000, err: ethereum.NotFound}
+	b := &mockRPCConnector{height: 1000, err: ethereum.NotFound}
+	w := newTestWeightedConnector(t, a, b)
+
+	err := w.RawCallContext(context.Background(), nil, "eth_getTransactionReceipt")
+	assert.ErrorIs(t, err, ethereum.NotFound)
+	// Both endpoints were asked, since the other one may be ahead, but neither is penalized.
+	assert.Equal(t, 1, a.calls)
+	assert.Equal(t, 1, b.calls)
+	for _, weight := range w.weights() {
+		assert.Greater(t, weight, 0.0)
+	}
+}
+
+func TestSplitRPCURLs(t *testing.T) {
+	assert.Equal(t, []string{"ws://a:8545", "https://b.example.com"}, SplitRPCURLs(" ws://a:8545, https://b.example.com,"))
+	assert.Equal(t, []string{"ws://a:8545"}, SplitRPCURLs("ws://a:8545"))
+	assert.Empty(t, SplitRPCURLs(""))
+}
@@ -63,7 +63,7 @@ var (
 
 type (
 	Watcher struct {
-		// Ethereum RPC url
+		// Ethereum RPC url, or a comma-separated list of them
 		url string
 		// Address of the Eth contract
 		contract eth_common.Address
@@ -165,6 +165,12 @@ func (w *Watcher) Run(ctx context.Context) error {
 	timeout, cancel := context.WithTimeout(ctx, 15*time.Second)
 	defer cancel()
 
+	// These connectors need a client of their own, so they only work with a single endpoint.
+	singleEndpoint := w.chainID == vaa.ChainIDNeon || (!w.unsafeDevMode && (w.chainID == vaa.ChainIDCelo || w.chainID == vaa.ChainIDArbitrum))
+	if singleEndpoint && len(connectors.SplitRPCURLs(w.url)) > 1 {
+		return fmt.Errorf("multiple RPC endpoints are not supported for %s", w.networkName)
+	}
+
 	var err error
 	if w.chainID == vaa.ChainIDCelo && !w.unsafeDevMode {
 		// When we are running in mainnet or testnet, we need to use the Celo ethereum library rather than go-ethereum.
@@ -176,7 +182,7 @@ func (w *Watcher) Run(ctx context.Context) error {
 			return fmt.Errorf("dialing eth client failed: %w", err)
 		}
 	} else if w.chainID == vaa.ChainIDEthereum && !w.unsafeDevMode {
-		baseConnector, err := connectors.NewEthereumConnector(timeout, w.networkName, w.url, w.contract, logger)
+		baseConnector, err := w.dialEthereum(ctx, timeout, logger)
 		if err != nil {
 			ethConnectionErrors.WithLabelValues(w.networkName, "dial_error").Inc()
 			p2p.DefaultRegistry.AddErrorCount(w.chainID, 1)
@@ -189,7 +195,7 @@ func (w *Watcher) Run(ctx context.Context) error {
 			return fmt.Errorf("creating block poll connector failed: %w", err)
 		}
 	} else if w.chainID == vaa.ChainIDMoonbeam && !w.unsafeDevMode {
-		baseConnector, err := connectors.NewEthereumConnector(timeout, w.networkName, w.url, w.contract, logger)
+		baseConnector, err := w.dialEthereum(ctx, timeout, logger)
 		if err != nil {
 			ethConnectionErrors.WithLabelValues(w.networkName, "dial_error").Inc()
 			p2p.DefaultRegistry.AddErrorCount(w.chainID, 1)
@@ -229,20 +235,14 @@ func (w *Watcher) Run(ctx context.Context) error {
 			return fmt.Errorf("dialing eth client failed: %w", err)
 		}
 		finalizer := finalizers.NewArbitrumFinalizer(logger, baseConnector, baseConnector.Client())
-		pollConnector, err := connectors.NewBlockPollConnector(ctx, baseConnector, finalizer, 250*time.Millisecond, false)
+		w.ethConn, err = connectors.NewBlockPollConnector(ctx, baseConnector, finalizer, 250*time.Millisecond, false)
 		if err != nil {
 			ethConnectionErrors.WithLabelValues(w.networkName, "dial_error").Inc()
 			p2p.DefaultRegistry.AddErrorCount(w.chainID, 1)
 			return fmt.Errorf("creating block poll connector failed: %w", err)
 		}
-		w.ethConn, err = connectors.NewArbitrumConnector(ctx, pollConnector)
-		if err != nil {
-			ethConnectionErrors.WithLabelValues(w.networkName, "dial_error").Inc()
-			p2p.DefaultRegistry.AddErrorCount(w.chainID, 1)
-			return fmt.Errorf("creating arbitrum connector failed: %w", err)
-		}
 	} else {
-		w.ethConn, err = connectors.NewEthereumConnector(timeout, w.networkName, w.url, w.contract, logger)
+		w.ethConn, err = w.dialEthereum(ctx, timeout, logger)
 		if err != nil {
 			ethConnectionErrors.WithLabelValues(w.networkName, "dial_error").Inc()
 			p2p.DefaultRegistry.AddErrorCount(w.chainID, 1)
@@ -702,23 +702,56 @@ func fetchCurrentGuardianSet(ctx context.Context, ethConn connectors.Connector)
 	return currentIndex, &gs, nil
 }
 
+// dialEthereum connects to the RPC endpoints in w.url, which may be a comma-separated list. Multiple endpoints are
+// combined into a WeightedConnector that sends requests to the healthiest ones.
+func (w *Watcher) dialEthereum(ctx context.Context, timeout context.Context, logger *zap.Logger) (connectors.Connector, error) {
+	urls := connectors.SplitRPCURLs(w.url)
+	if len(urls) <= 1 {
+		return connectors.NewEthereumConnector(timeout, w.networkName, w.url, w.contract, logger)
+	}
+
+	var endpoints []connectors.Connector
+	for i, url := range urls {
+		endpoint, err := connectors.NewEthereumConnector(timeout, w.networkName, url, w.contract, logger)
+		if err != nil {
+			// The endpoint may come back later, but the weighted connector only works with the endpoints it starts with.
+			logger.Warn("failed to dial rpc endpoint", zap.String("eth_network", w.networkName), zap.Int("endpoint", i), zap.Error(err))
+			continue
+		}
+		endpoints = append(endpoints, endpoint)
+	}
+	if len(endpoints) == 0 {
+		return nil, fmt.Errorf("failed to dial any of the %d rpc endpoints", len(urls))
+	}
+	return connectors.NewWeightedConnector(ctx, endpoints, logger)
+}
+
 func (w *Watcher) checkForSafeMode(ctx context.Context) error {
+	for _, url := range connectors.SplitRPCURLs(w.url) {
+		if err := checkForSafeMode(ctx, url); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkForSafeMode(ctx context.Context, url string) error {
 	timeout, cancel := context.WithTimeout(ctx, 15*time.Second)
 	defer cancel()
 
-	c, err := rpc.DialContext(timeout, w.url)
+	c, err := rpc.DialContext(timeout, url)
 	if err != nil {
-		return fmt.Errorf("failed to connect to url %s to check for safe mode: %w", w.url, err)
+		return fmt.Errorf("failed to connect to url %s to check for safe mode: %w", url, err)
 	}
 
 	var safe bool
 	err = c.CallContext(ctx, &safe, "net_isSafeMode")
 	if err != nil {
-		return fmt.Errorf("check for safe mode for url %s failed: %w", w.url, err)
+		return fmt.Errorf("check for safe mode for url %s failed: %w", url, err)
 	}
 
 	if !safe {
-		return fmt.Errorf("url %s is not using safe mode", w.url)
+		return fmt.Errorf("url %s is not using safe mode", url)
 	}
 
 	return nil
@@ -133,7 +133,7 @@ func NewSolanaWatcher(
 func (s *SolanaWatcher) Run(ctx context.Context) error {
 	// Initialize gossip metrics (we want to broadcast the address even if we're not yet syncing)
 	contractAddr := base58.Encode(s.contract[:])
-	p2p.DefaultRegistry.SetNetworkStats(s.chainID, &gossipv1.Heartbeat_Network{
+	p2p.DefaultRegistry.SetInstanceNetworkStats(s.chainID, string(s.commitment), &gossipv1.Heartbeat_Network{
 		ContractAddress: contractAddr,
 	})
 
@@ -179,7 +179,7 @@ func (s *SolanaWatcher) Run(ctx context.Context) error {
 				}
 				currentSolanaHeight.WithLabelValues(s.networkName, string(s.commitment)).Set(float64(slot))
 				readiness.SetReady(s.readiness)
-				p2p.DefaultRegistry.SetNetworkStats(s.chainID, &gossipv1.Heartbeat_Network{
+				p2p.DefaultRegistry.SetInstanceNetworkStats(s.chainID, string(s.commitment), &gossipv1.Heartbeat_Network{
 					Height:          int64(slot),
 					ContractAddress: contractAddr,
 				})
@@ -0,0 +1,103 @@
+// Package watchers contains functionality shared by the chain watchers.
+package watchers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/p2p"
+	"github.com/certusone/wormhole/node/pkg/supervisor"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+var (
+	watcherStalls = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "wormhole_watcher_stalls_total",
+			Help: "Total number of times a watcher was restarted by the watchdog because it stopped making progress",
+		}, []string{"chain_name"})
+)
+
+// watchdogShutdownTimeout is how long the watchdog waits for a stalled watcher to exit after cancelling it.
+const watchdogShutdownTimeout = 10 * time.Second
+
+// progressRegistry is the subset of the p2p registry used by the watchdog.
+type progressRegistry interface {
+	LastProgress(chain vaa.ChainID, instance string) time.Time
+	SetStalled(chain vaa.ChainID, instance string, stalled bool)
+}
+
+// WithWatchdog wraps the runnable of the watcher for chainID such that it fails, and is thereby restarted by the
+// supervisor, if the height reported by the watcher does not change within timeout. The chain is flagged as stalled
+// in heartbeats until the watcher makes progress again. A zero timeout disables the watchdog.
+func WithWatchdog(chainID vaa.ChainID, timeout time.Duration, runnable supervisor.Runnable) supervisor.Runnable {
+	return WithInstanceWatchdog(chainID, "", timeout, runnable)
+}
+
+// WithInstanceWatchdog is like WithWatchdog for chains observed by several watchers, like the confirmed and the
+// finalized Solana watchers. It only tracks the heights the watcher reports for instance, so that one watcher making
+// progress does not hide another one being stuck.
+func WithInstanceWatchdog(chainID vaa.ChainID, instance string, timeout time.Duration, runnable supervisor.Runnable) supervisor.Runnable {
+	if timeout == 0 {
+		return runnable
+	}
+	return withWatchdog(p2p.DefaultRegistry, chainID, instance, timeout, runnable)
+}
+
+func withWatchdog(registry progressRegistry, chainID vaa.ChainID, instance string, timeout time.Duration, runnable supervisor.Runnable) supervisor.Runnable {
+	return func(ctx context.Context) error {
+		logger := supervisor.Logger(ctx)
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		start := time.Now()
+		errC := make(chan error, 1)
+		go func() {
+			errC <- runnable(ctx)
+		}()
+
+		ticker := time.NewTicker(timeout / 10)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case err := <-errC:
+				return err
+			case <-ticker.C:
+				last := registry.LastProgress(chainID, instance)
+				if last.After(start) {
+					registry.SetStalled(chainID, instance, false)
+				} else {
+					// Give the (re)started watcher the full window to report its first height.
+					last = start
+				}
+
+				if time.Since(last) < timeout {
+					continue
+				}
+
+				logger.Error("watcher made no progress, restarting it",
+					zap.Stringer("chain", chainID),
+					zap.String("instance", instance),
+					zap.Time("last_progress", last),
+					zap.Duration("timeout", timeout))
+				watcherStalls.WithLabelValues(chainID.String()).Inc()
+				registry.SetStalled(chainID, instance, true)
+
+				cancel()
+				select {
+				case <-errC:
+				case <-time.After(watchdogShutdownTimeout):
+					logger.Warn("stalled watcher did not exit after being cancelled", zap.Stringer("chain", chainID))
+				}
+
+				return fmt.Errorf("watcher for %s made no progress since %s", chainID, last)
+			}
+		}
+	}
+}
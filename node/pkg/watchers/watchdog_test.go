@@ -0,0 +1,157 @@
+package watchers
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/p2p"
+	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
+	"github.com/certusone/wormhole/node/pkg/supervisor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+type mockRegistry struct {
+	mu           sync.Mutex
+	lastProgress time.Time
+	stalled      bool
+}
+
+func (r *mockRegistry) LastProgress(chain vaa.ChainID, instance string) time.Time {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.lastProgress
+}
+
+func (r *mockRegistry) SetStalled(chain vaa.ChainID, instance string, stalled bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stalled = stalled
+}
+
+func (r *mockRegistry) progress() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lastProgress = time.Now()
+}
+
+func (r *mockRegistry) isStalled() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.stalled
+}
+
+// runUnderSupervisor runs runnable once in a supervision tree and returns its result.
+func runUnderSupervisor(ctx context.Context, runnable supervisor.Runnable) <-chan error {
+	errC := make(chan error, 1)
+	supervisor.New(ctx, zap.NewNop(), func(ctx context.Context) error {
+		errC <- runnable(ctx)
+		<-ctx.Done()
+		return nil
+	})
+	return errC
+}
+
+func TestWatchdogRestartsStalledWatcher(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	reg := &mockRegistry{}
+	innerCancelled := make(chan struct{})
+	watcher := func(ctx context.Context) error {
+		<-ctx.Done()
+		close(innerCancelled)
+		return ctx.Err()
+	}
+
+	errC := runUnderSupervisor(ctx, withWatchdog(reg, vaa.ChainIDEthereum, "", 100*time.Millisecond, watcher))
+
+	select {
+	case err := <-errC:
+		assert.Error(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("watchdog did not fail the stalled watcher")
+	}
+	<-innerCancelled
+	assert.True(t, reg.isStalled())
+}
+
+func TestWatchdogKeepsProgressingWatcher(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	reg := &mockRegistry{stalled: true}
+	watcher := func(ctx context.Context) error {
+		ticker := time.NewTicker(10 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-ticker.C:
+				reg.progress()
+			}
+		}
+	}
+
+	watcherCtx, watcherCancel := context.WithCancel(ctx)
+	errC := runUnderSupervisor(watcherCtx, withWatchdog(reg, vaa.ChainIDEthereum, "", 100*time.Millisecond, watcher))
+
+	time.Sleep(300 * time.Millisecond)
+	select {
+	case err := <-errC:
+		t.Fatalf("watchdog failed a progressing watcher: %v", err)
+	default:
+	}
+
+	// Progress clears a previously set stalled flag.
+	assert.False(t, reg.isStalled())
+
+	watcherCancel()
+	require.ErrorIs(t, <-errC, context.Canceled)
+}
+
+func TestWatchdogTracksInstance(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The confirmed watcher keeps progressing, the finalized one is stuck.
+	reg := p2p.NewRegistry()
+	watcher := func(ctx context.Context) error {
+		ticker := time.NewTicker(10 * time.Millisecond)
+		defer ticker.Stop()
+		for height := int64(0); ; height++ {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-ticker.C:
+				reg.SetInstanceNetworkStats(vaa.ChainIDSolana, "confirmed", &gossipv1.Heartbeat_Network{Height: height})
+				reg.SetInstanceNetworkStats(vaa.ChainIDSolana, "finalized", &gossipv1.Heartbeat_Network{Height: 1})
+			}
+		}
+	}
+
+	errC := runUnderSupervisor(ctx, withWatchdog(reg, vaa.ChainIDSolana, "finalized", 100*time.Millisecond, watcher))
+
+	select {
+	case err := <-errC:
+		assert.Error(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("watchdog did not fail the stalled watcher instance")
+	}
+}
+
+func TestWatchdogDisabled(t *testing.T) {
+	called := false
+	runnable := func(ctx context.Context) error {
+		called = true
+		return nil
+	}
+
+	require.NoError(t, WithWatchdog(vaa.ChainIDEthereum, 0, runnable)(context.Background()))
+	assert.True(t, called)
+}
@@ -6,7 +6,9 @@ import (
 	"encoding/hex"
 	"fmt"
 	"io/ioutil"
+	"math"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 	"time"
@@ -40,6 +42,9 @@ type (
 		// Incoming re-observation requests from the network. Pre-filtered to only
 		// include requests for our chainID.
 		obsvReqC chan *gossipv1.ObservationRequest
+
+		// Outgoing re-observation requests for any chain, made by governance proposals that passed on wormchain.
+		chainObsvReqC chan<- *gossipv1.ObservationRequest
 	}
 )
 
@@ -54,6 +59,11 @@ var (
 			Name: "wormhole_wormchain_messages_confirmed_total",
 			Help: "Total number of verified wormchain messages found",
 		})
+	wormchainObservationRequests = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "wormhole_wormchain_observation_requests_total",
+			Help: "Total number of observation requests made by wormchain governance",
+		})
 	currentWormchainHeight = promauto.NewGauge(
 		prometheus.GaugeOpts{
 			Name: "wormhole_wormchain_current_height",
@@ -61,6 +71,16 @@ var (
 		})
 )
 
+// postedMessageEventType is the type of the event the wormhole module emits for every posted message.
+const postedMessageEventType = "certusone.wormholechain.wormhole.EventPostedMessage"
+
+// observationRequestEventType is the type of the event the wormhole module emits at the end of a block for every
+// observation request proposal that passed. Transactions cannot emit it, since only end block events are read.
+const observationRequestEventType = "wormhole_foundation.wormholechain.wormhole.EventObservationRequest"
+
+// newBlockEventDataType is the type of the data of NewBlock events sent by the websocket.
+const newBlockEventDataType = "tendermint/event/NewBlock"
+
 type clientRequest struct {
 	JSONRPC string `json:"jsonrpc"`
 	// A String containing the name of the method to be invoked.
@@ -78,8 +98,9 @@ func NewWatcher(
 	urlLCD string,
 	lockEvents chan *common.MessagePublication,
 	setEvents chan *common.GuardianSet,
-	obsvReqC chan *gossipv1.ObservationRequest) *Watcher {
-	return &Watcher{urlWS: urlWS, urlLCD: urlLCD, msgChan: lockEvents, setChan: setEvents, obsvReqC: obsvReqC}
+	obsvReqC chan *gossipv1.ObservationRequest,
+	chainObsvReqC chan<- *gossipv1.ObservationRequest) *Watcher {
+	return &Watcher{urlWS: urlWS, urlLCD: urlLCD, msgChan: lockEvents, setChan: setEvents, obsvReqC: obsvReqC, chainObsvReqC: chainObsvReqC}
 }
 
 func (e *Watcher) Run(ctx context.Context) error {
@@ -99,7 +120,7 @@ func (e *Watcher) Run(ctx context.Context) error {
 	defer c.Close()
 
 	// Subscribe transactions which cause EventPostedMessage
-	params := [...]string{"tm.event='Tx' AND certusone.wormholechain.wormhole.EventPostedMessage.sequence EXISTS"}
+	params := [...]string{fmt.Sprintf("tm.event='Tx' AND %s.sequence EXISTS", postedMessageEventType)}
 	// alternately, "tm.event='Tx' AND certusone.wormholechain.wormhole.EventPostedMessage.sequence >= 0"
 	command := &clientRequest{
 		JSONRPC: "2.0",
@@ -123,6 +144,21 @@ func (e *Watcher) Run(ctx context.Context) error {
 	}
 	logger.Info("subscribed to new transaction events")
 
+	// Subscribe blocks whose end block events include EventObservationRequest. The success response is skipped by the
+	// read loop below, since transaction events may arrive before it.
+	command = &clientRequest{
+		JSONRPC: "2.0",
+		Method:  "subscribe",
+		Params:  [...]string{fmt.Sprintf("tm.event='NewBlock' AND %s.chain_id EXISTS", observationRequestEventType)},
+		ID:      2,
+	}
+	err = c.WriteJSON(command)
+	if err != nil {
+		p2p.DefaultRegistry.AddErrorCount(vaa.ChainIDWormchain, 1)
+		wormchainConnectionErrors.WithLabelValues("websocket_subscription_error").Inc()
+		return fmt.Errorf("websocket subscription failed: %w", err)
+	}
+
 	readiness.SetReady(common.ReadinessWormchainSyncing)
 
 	go func() {
@@ -170,15 +206,35 @@ func (e *Watcher) Run(ctx context.Context) error {
 					panic("invalid chain ID")
 				}
 
+				client := &http.Client{
+					Timeout: time.Second * 5,
+				}
+
+				if emitter, sequence, ok := common.ObservationRequestMessageID(r); ok {
+					logger.Info("received observation request for wormchain message",
+						zap.Stringer("emitter", emitter),
+						zap.Uint64("sequence", sequence))
+
+					msgs, err := messagesBySequence(client, e.urlLCD, emitter, sequence, logger)
+					if err != nil {
+						logger.Error("failed to search for message", zap.Stringer("emitter", emitter), zap.Uint64("sequence", sequence), zap.Error(err))
+						continue
+					}
+					if len(msgs) == 0 {
+						logger.Warn("message not found", zap.Stringer("emitter", emitter), zap.Uint64("sequence", sequence))
+					}
+					for _, msg := range msgs {
+						e.msgChan <- msg
+						wormchainMessagesConfirmed.Inc()
+					}
+					continue
+				}
+
 				tx := hex.EncodeToString(r.TxHash)
 
 				logger.Info("received observation request for wormchain",
 					zap.String("tx_hash", tx))
 
-				client := &http.Client{
-					Timeout: time.Second * 5,
-				}
-
 				// Query for tx by hash
 				resp, err := client.Get(fmt.Sprintf("%s/cosmos/tx/v1beta1/txs/%s", e.urlLCD, tx))
 				if err != nil {
@@ -233,6 +289,23 @@ func (e *Watcher) Run(ctx context.Context) error {
 
 			// Received a message from the blockchain
 			json := string(message)
+			data := gjson.Get(json, "result.data")
+			if !data.Exists() {
+				// Subscription success response
+				continue
+			}
+			if data.Get("type").String() == newBlockEventDataType {
+				height := data.Get("value.block.header.height").String()
+				events := data.Get("value.result_end_block.events")
+				for _, req := range EventsToObservationRequests(events.Array(), logger.With(zap.String("height", height))) {
+					wormchainObservationRequests.Inc()
+					if err := common.PostObservationRequest(e.chainObsvReqC, req); err != nil {
+						logger.Error("failed to post observation request", zap.String("height", height), zap.Error(err))
+					}
+				}
+				continue
+			}
+
 			txHashRaw := gjson.Get(json, "result.events.tx\\.hash.0")
 			if !txHashRaw.Exists() {
 				logger.Warn("wormchain message does not have tx hash", zap.String("payload", json))
@@ -275,7 +348,7 @@ func EventsToMessagePublications(txHash string, events []gjson.Result, logger *z
 			continue
 		}
 		eventType := gjson.Get(event.String(), "type")
-		if eventType.String() != "certusone.wormholechain.wormhole.EventPostedMessage" {
+		if eventType.String() != postedMessageEventType {
 			continue
 		}
 
@@ -284,41 +357,7 @@ func EventsToMessagePublications(txHash string, events []gjson.Result, logger *z
 			logger.Warn("wormchain message event has no attributes", zap.String("tx_hash", txHash), zap.String("event", event.String()))
 			continue
 		}
-		mappedAttributes := map[string]string{}
-		for _, attribute := range attributes.Array() {
-			if !attribute.IsObject() {
-				logger.Warn("wormchain event attribute is invalid", zap.String("tx_hash", txHash), zap.String("attribute", attribute.String()))
-				continue
-			}
-			keyBase := gjson.Get(attribute.String(), "key")
-			if !keyBase.Exists() {
-				logger.Warn("wormchain event attribute does not have key", zap.String("tx_hash", txHash), zap.String("attribute", attribute.String()))
-				continue
-			}
-			valueBase := gjson.Get(attribute.String(), "value")
-			if !valueBase.Exists() {
-				logger.Warn("wormchain event attribute does not have value", zap.String("tx_hash", txHash), zap.String("attribute", attribute.String()))
-				continue
-			}
-
-			key, err := base64.StdEncoding.DecodeString(keyBase.String())
-			if err != nil {
-				logger.Warn("wormchain event key attribute is invalid", zap.String("tx_hash", txHash), zap.String("key", keyBase.String()))
-				continue
-			}
-			value, err := base64.StdEncoding.DecodeString(valueBase.String())
-			if err != nil {
-				logger.Warn("wormchain event value attribute is invalid", zap.String("tx_hash", txHash), zap.String("key", keyBase.String()), zap.String("value", valueBase.String()))
-				continue
-			}
-
-			if _, ok := mappedAttributes[string(key)]; ok {
-				logger.Debug("duplicate key in events", zap.String("tx_hash", txHash), zap.String("key", keyBase.String()), zap.String("value", valueBase.String()))
-				continue
-			}
-
-			mappedAttributes[string(key)] = string(value)
-		}
+		mappedAttributes := decodeAttributes(attributes.Array(), logger.With(zap.String("tx_hash", txHash)))
 
 		payload, ok := mappedAttributes["payload"]
 		if !ok {
@@ -412,6 +451,159 @@ func EventsToMessagePublications(txHash string, events []gjson.Result, logger *z
 	return msgs
 }
 
+// EventsToObservationRequests returns the observation requests of the EventObservationRequest events among the end
+// block events of a wormchain block.
+func EventsToObservationRequests(events []gjson.Result, logger *zap.Logger) []*gossipv1.ObservationRequest {
+	var reqs []*gossipv1.ObservationRequest
+	for _, event := range events {
+		if !event.IsObject() {
+			logger.Warn("wormchain block event is invalid", zap.String("event", event.String()))
+			continue
+		}
+		if event.Get("type").String() != observationRequestEventType {
+			continue
+		}
+
+		attributes := event.Get("attributes")
+		if !attributes.Exists() {
+			logger.Warn("wormchain observation request event has no attributes", zap.String("event", event.String()))
+			continue
+		}
+		mappedAttributes := decodeAttributes(attributes.Array(), logger)
+
+		// Attributes of typed events are JSON encoded: numbers may be quoted, bytes are quoted base64 and empty bytes
+		// may be null.
+		chainIDValue, ok := mappedAttributes["chain_id"]
+		if !ok {
+			logger.Error("observation request event does not have a chain_id field", zap.String("attributes", attributes.String()))
+			continue
+		}
+		chainID, err := stringToUint(chainIDValue)
+		if err != nil || chainID > math.MaxUint16 {
+			logger.Error("observation request chain_id is invalid", zap.String("value", chainIDValue))
+			continue
+		}
+		txHash, err := optionalBytes(mappedAttributes["tx_hash"])
+		if err != nil {
+			logger.Error("observation request tx_hash is invalid", zap.String("value", mappedAttributes["tx_hash"]))
+			continue
+		}
+		emitterAddress, err := optionalBytes(mappedAttributes["emitter_address"])
+		if err != nil {
+			logger.Error("observation request emitter_address is invalid", zap.String("value", mappedAttributes["emitter_address"]))
+			continue
+		}
+		var sequence uint64
+		if sequenceValue, ok := mappedAttributes["sequence"]; ok {
+			sequence, err = stringToUint(sequenceValue)
+			if err != nil {
+				logger.Error("observation request sequence is invalid", zap.String("value", sequenceValue))
+				continue
+			}
+		}
+
+		logger.Info("observation request made by wormchain governance",
+			zap.Stringer("chain", vaa.ChainID(chainID)),
+			zap.String("tx_hash", hex.EncodeToString(txHash)),
+			zap.String("emitter", hex.EncodeToString(emitterAddress)),
+			zap.Uint64("sequence", sequence),
+		)
+		reqs = append(reqs, &gossipv1.ObservationRequest{
+			ChainId:        uint32(chainID),
+			TxHash:         txHash,
+			EmitterAddress: emitterAddress,
+			Sequence:       sequence,
+		})
+	}
+	return reqs
+}
+
+// decodeAttributes returns the base64 encoded attributes of a wormchain event by key. Attributes that cannot be decoded
+// and repeated keys are skipped.
+func decodeAttributes(attributes []gjson.Result, logger *zap.Logger) map[string]string {
+	mappedAttributes := map[string]string{}
+	for _, attribute := range attributes {
+		if !attribute.IsObject() {
+			logger.Warn("wormchain event attribute is invalid", zap.String("attribute", attribute.String()))
+			continue
+		}
+		keyBase := gjson.Get(attribute.String(), "key")
+		if !keyBase.Exists() {
+			logger.Warn("wormchain event attribute does not have key", zap.String("attribute", attribute.String()))
+			continue
+		}
+		valueBase := gjson.Get(attribute.String(), "value")
+		if !valueBase.Exists() {
+			logger.Warn("wormchain event attribute does not have value", zap.String("attribute", attribute.String()))
+			continue
+		}
+
+		key, err := base64.StdEncoding.DecodeString(keyBase.String())
+		if err != nil {
+			logger.Warn("wormchain event key attribute is invalid", zap.String("key", keyBase.String()))
+			continue
+		}
+		value, err := base64.StdEncoding.DecodeString(valueBase.String())
+		if err != nil {
+			logger.Warn("wormchain event value attribute is invalid", zap.String("key", keyBase.String()), zap.String("value", valueBase.String()))
+			continue
+		}
+
+		if _, ok := mappedAttributes[string(key)]; ok {
+			logger.Debug("duplicate key in events", zap.String("key", keyBase.String()), zap.String("value", valueBase.String()))
+			continue
+		}
+
+		mappedAttributes[string(key)] = string(value)
+	}
+	return mappedAttributes
+}
+
+// optionalBytes decodes a JSON encoded bytes attribute, which is empty if missing or null.
+func optionalBytes(value string) ([]byte, error) {
+	if value == "" || value == "null" {
+		return nil, nil
+	}
+	res, err := secondDecode(value)
+	if err != nil {
+		return nil, err
+	}
+	if len(res) == 0 {
+		return nil, nil
+	}
+	return res, nil
+}
+
+// messagesBySequence searches the LCD for transactions that posted a message with the given sequence and returns the
+// messages among them that were posted by emitter.
+func messagesBySequence(client *http.Client, urlLCD string, emitter vaa.Address, sequence uint64, logger *zap.Logger) ([]*common.MessagePublication, error) {
+	// Attributes of typed events are JSON encoded, so the sequence is a quoted string.
+	query := url.Values{"events": {fmt.Sprintf("%s.sequence='\"%d\"'", postedMessageEventType, sequence)}}
+	resp, err := client.Get(fmt.Sprintf("%s/cosmos/tx/v1beta1/txs?%s", urlLCD, query.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("query txs: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read txs: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("query txs: %s: %s", resp.Status, body)
+	}
+
+	var msgs []*common.MessagePublication
+	for _, tx := range gjson.GetBytes(body, "tx_responses").Array() {
+		txHash := tx.Get("txhash").String()
+		for _, msg := range EventsToMessagePublications(txHash, tx.Get("events").Array(), logger) {
+			if msg.EmitterAddress == emitter && msg.Sequence == sequence {
+				msgs = append(msgs, msg)
+			}
+		}
+	}
+	return msgs, nil
+}
+
 // TODO this encoding comes out of the logs oddly, and probably requires a change on the chain
 // StringToAddress convert string into address
 func StringToAddress(value string) (vaa.Address, error) {
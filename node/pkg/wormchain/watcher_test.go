@@ -1,11 +1,18 @@
 package wormchain
 
 import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	eth_common "github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
 	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
 )
 
 func TestStringToUInt(t *testing.T) {
@@ -116,3 +123,82 @@ func TestStringToHash(t *testing.T) {
 		})
 	}
 }
+
+// postedMessageTx returns a tx response as served by the LCD containing a single posted message event.
+func postedMessageTx(emitter vaa.Address, sequence uint64) string {
+	attr := func(key, value string) string {
+		return fmt.Sprintf(`{"key":%q,"value":%q}`, base64.StdEncoding.EncodeToString([]byte(key)), base64.StdEncoding.EncodeToString([]byte(value)))
+	}
+	return fmt.Sprintf(`{"txhash":"4fae136bb1fd782fe1b5180ba735cdc83bcece3f9b7fd0e5e35300a61c8acd8f","events":[{"type":%q,"attributes":[%s,%s,%s,%s]}]}`,
+		postedMessageEventType,
+		attr("emitter", fmt.Sprintf("%q", base64.StdEncoding.EncodeToString(emitter[:]))),
+		attr("sequence", fmt.Sprintf("%q", fmt.Sprint(sequence))),
+		attr("nonce", "1"),
+		attr("payload", fmt.Sprintf("%q", base64.StdEncoding.EncodeToString([]byte{0x01, 0x02}))),
+	)
+}
+
+func TestMessagesBySequence(t *testing.T) {
+	emitter := vaa.Address{0x01}
+	other := vaa.Address{0x02}
+
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query().Get("events")
+		fmt.Fprintf(w, `{"tx_responses":[%s,%s,%s]}`, postedMessageTx(emitter, 5), postedMessageTx(other, 5), postedMessageTx(emitter, 6))
+	}))
+	defer server.Close()
+
+	msgs, err := messagesBySequence(server.Client(), server.URL, emitter, 5, zap.NewNop())
+	require.NoError(t, err)
+	assert.Equal(t, postedMessageEventType+`.sequence='"5"'`, query)
+	require.Len(t, msgs, 1)
+	assert.Equal(t, emitter, msgs[0].EmitterAddress)
+	assert.Equal(t, uint64(5), msgs[0].Sequence)
+	assert.Equal(t, []byte{0x01, 0x02}, msgs[0].Payload)
+}
+
+func TestMessagesBySequenceError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "internal", http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	_, err := messagesBySequence(server.Client(), server.URL, vaa.Address{0x01}, 5, zap.NewNop())
+	assert.Error(t, err)
+}
+
+func TestEventsToObservationRequests(t *testing.T) {
+	attr := func(key, value string) string {
+		return fmt.Sprintf(`{"key":%q,"value":%q}`, base64.StdEncoding.EncodeToString([]byte(key)), base64.StdEncoding.EncodeToString([]byte(value)))
+	}
+	emitter := vaa.Address{0x01}
+	txHash := []byte{0xab, 0xcd}
+	// End block events as sent with a NewBlock event, with the typed event attributes JSON encoded.
+	events := gjson.Parse(fmt.Sprintf(`[{"type":"transfer","attributes":[%s]},{"type":%q,"attributes":[%s,%s,%s,%s]},{"type":%q,"attributes":[%s,%s,%s,%s]},{"type":%q,"attributes":[%s]}]`,
+		attr("amount", "1uworm"),
+		observationRequestEventType,
+		attr("chain_id", "2"),
+		attr("tx_hash", "null"),
+		attr("emitter_address", fmt.Sprintf("%q", base64.StdEncoding.EncodeToString(emitter[:]))),
+		attr("sequence", `"7"`),
+		observationRequestEventType,
+		attr("chain_id", "1"),
+		attr("tx_hash", fmt.Sprintf("%q", base64.StdEncoding.EncodeToString(txHash))),
+		attr("emitter_address", `""`),
+		attr("sequence", `"0"`),
+		observationRequestEventType,
+		attr("chain_id", "65536"),
+	)).Array()
+
+	reqs := EventsToObservationRequests(events, zap.NewNop())
+	require.Len(t, reqs, 2)
+	assert.Equal(t, uint32(vaa.ChainIDEthereum), reqs[0].ChainId)
+	assert.Nil(t, reqs[0].TxHash)
+	assert.Equal(t, emitter[:], reqs[0].EmitterAddress)
+	assert.Equal(t, uint64(7), reqs[0].Sequence)
+	assert.Equal(t, uint32(vaa.ChainIDSolana), reqs[1].ChainId)
+	assert.Equal(t, txHash, reqs[1].TxHash)
+	assert.Nil(t, reqs[1].EmitterAddress)
+	assert.Equal(t, uint64(0), reqs[1].Sequence)
+}
@@ -0,0 +1,111 @@
+package sdk
+
+import (
+	"fmt"
+
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// AddressBookVersion is incremented whenever an emitter is added to, changed in or removed from the address book, so
+// that consumers can tell which revision they were built with.
+const AddressBookVersion = 1
+
+// Environment is a wormhole network with its own set of contracts.
+type Environment string
+
+const (
+	MainNet Environment = "mainnet"
+	TestNet Environment = "testnet"
+	DevNet  Environment = "devnet"
+)
+
+// ParseEnvironment returns the environment named s.
+func ParseEnvironment(s string) (Environment, error) {
+	switch env := Environment(s); env {
+	case MainNet, TestNet, DevNet:
+		return env, nil
+	default:
+		return "", fmt.Errorf("unknown environment: %q", s)
+	}
+}
+
+// AddressBook holds the canonical core bridge, token bridge and NFT bridge emitters of an environment.
+type AddressBook struct {
+	Environment Environment
+	emitters    map[EmitterType]map[vaa.ChainID]vaa.Address
+}
+
+// knownCoreEmitters are the core bridge emitters of every environment. The only one is the governance emitter, whose
+// VAAs the core bridge of every chain executes.
+var knownCoreEmitters = map[vaa.ChainID]string{
+	vaa.GovernanceChain: vaa.GovernanceEmitter.String(),
+}
+
+var addressBooks = map[Environment]*AddressBook{
+	MainNet: newAddressBook(MainNet, knownCoreEmitters, knownTokenbridgeEmitters, knownNFTBridgeEmitters),
+	TestNet: newAddressBook(TestNet, knownCoreEmitters, knownTestnetTokenbridgeEmitters, knownTestnetNFTBridgeEmitters),
+	DevNet:  newAddressBook(DevNet, knownCoreEmitters, knownDevnetTokenbridgeEmitters, knownDevnetNFTBridgeEmitters),
+}
+
+func newAddressBook(env Environment, coreEmitters, tokenEmitters, nftEmitters map[vaa.ChainID]string) *AddressBook {
+	b := &AddressBook{Environment: env, emitters: make(map[EmitterType]map[vaa.ChainID]vaa.Address)}
+	for emitterType, hexmap := range map[EmitterType]map[vaa.ChainID]string{
+		EmitterCoreBridge:  coreEmitters,
+		EmitterTokenBridge: tokenEmitters,
+		EmitterNFTBridge:   nftEmitters,
+	} {
+		b.emitters[emitterType] = make(map[vaa.ChainID]vaa.Address, len(hexmap))
+		for id, emitter := range hexmap {
+			addr, err := vaa.StringToAddress(emitter)
+			if err != nil {
+				panic(fmt.Sprintf("Failed to decode %s %s emitter address %v: %v", env, emitterType, emitter, err))
+			}
+			b.emitters[emitterType][id] = addr
+		}
+	}
+	return b
+}
+
+// GetAddressBook returns the address book of env.
+func GetAddressBook(env Environment) (*AddressBook, error) {
+	b, ok := addressBooks[env]
+	if !ok {
+		return nil, fmt.Errorf("unknown environment: %q", env)
+	}
+	return b, nil
+}
+
+// MustGetAddressBook is like GetAddressBook, but panics if env is unknown.
+func MustGetAddressBook(env Environment) *AddressBook {
+	b, err := GetAddressBook(env)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// Emitter returns the emitter of the given type on chainID, and false if there is none.
+func (b *AddressBook) Emitter(chainID vaa.ChainID, emitterType EmitterType) (vaa.Address, bool) {
+	addr, ok := b.emitters[emitterType][chainID]
+	return addr, ok
+}
+
+// Emitters returns the emitters of the given type by chain. The map is a copy and may be modified.
+func (b *AddressBook) Emitters(emitterType EmitterType) map[vaa.ChainID]vaa.Address {
+	out := make(map[vaa.ChainID]vaa.Address, len(b.emitters[emitterType]))
+	for id, addr := range b.emitters[emitterType] {
+		out[id] = addr
+	}
+	return out
+}
+
+// EmitterType returns the type of the emitter with address addr on chainID, or EmitterTypeUnset if it is not in the
+// address book.
+func (b *AddressBook) EmitterType(chainID vaa.ChainID, addr vaa.Address) EmitterType {
+	for _, emitterType := range []EmitterType{EmitterCoreBridge, EmitterTokenBridge, EmitterNFTBridge} {
+		if known, ok := b.emitters[emitterType][chainID]; ok && known == addr {
+			return emitterType
+		}
+	}
+	return EmitterTypeUnset
+}
@@ -0,0 +1,74 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+func TestParseEnvironment(t *testing.T) {
+	for _, env := range []Environment{MainNet, TestNet, DevNet} {
+		parsed, err := ParseEnvironment(string(env))
+		require.NoError(t, err)
+		assert.Equal(t, env, parsed)
+	}
+	_, err := ParseEnvironment("localnet")
+	assert.EqualError(t, err, `unknown environment: "localnet"`)
+}
+
+func TestAddressBook(t *testing.T) {
+	mainnet := MustGetAddressBook(MainNet)
+	tokenBridge, err := vaa.StringToAddress("0000000000000000000000003ee18b2214aff97000d974cf647e7c347e8fa585")
+	require.NoError(t, err)
+
+	addr, ok := mainnet.Emitter(vaa.ChainIDEthereum, EmitterTokenBridge)
+	require.True(t, ok)
+	assert.Equal(t, tokenBridge, addr)
+	assert.Equal(t, EmitterTokenBridge, mainnet.EmitterType(vaa.ChainIDEthereum, tokenBridge))
+	// The same address on another chain is not a known emitter.
+	assert.Equal(t, EmitterTypeUnset, mainnet.EmitterType(vaa.ChainIDBSC, tokenBridge))
+	_, ok = mainnet.Emitter(vaa.ChainIDWormchain, EmitterTokenBridge)
+	assert.False(t, ok)
+
+	for _, env := range []Environment{MainNet, TestNet, DevNet} {
+		b, err := GetAddressBook(env)
+		require.NoError(t, err)
+		assert.Equal(t, env, b.Environment)
+		addr, ok := b.Emitter(vaa.GovernanceChain, EmitterCoreBridge)
+		require.True(t, ok, env)
+		assert.Equal(t, vaa.GovernanceEmitter, addr, env)
+	}
+
+	devnetWormchain, ok := MustGetAddressBook(DevNet).Emitter(vaa.ChainIDWormchain, EmitterTokenBridge)
+	require.True(t, ok)
+	assert.Equal(t, WormchainModuleEmitter("tokenbridge"), devnetWormchain.String())
+
+	// Emitters returns a copy
+	emitters := MustGetAddressBook(TestNet).Emitters(EmitterNFTBridge)
+	assert.Len(t, emitters, len(knownTestnetNFTBridgeEmitters))
+	delete(emitters, vaa.ChainIDSolana)
+	_, ok = MustGetAddressBook(TestNet).Emitter(vaa.ChainIDSolana, EmitterNFTBridge)
+	assert.True(t, ok)
+
+	_, err = GetAddressBook("localnet")
+	assert.Error(t, err)
+}
+
+func TestAddressBookMatchesKnownEmitters(t *testing.T) {
+	for env, known := range map[Environment][]EmitterInfo{
+		MainNet: KnownEmitters,
+		TestNet: KnownTestnetEmitters,
+		DevNet:  KnownDevnetEmitters,
+	} {
+		b := MustGetAddressBook(env)
+		for _, e := range known {
+			addr, ok := b.Emitter(e.ChainID, e.BridgeType)
+			require.True(t, ok, "%s %s %s", env, e.ChainID, e.BridgeType)
+			expected, err := vaa.StringToAddress(e.Emitter)
+			require.NoError(t, err)
+			assert.Equal(t, expected, addr, "%s %s %s", env, e.ChainID, e.BridgeType)
+		}
+	}
+}
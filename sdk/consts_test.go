@@ -0,0 +1,26 @@
+package sdk
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+func TestWormchainModuleEmitter(t *testing.T) {
+	// Address of the tokenbridge module account on wormhole chain.
+	expected := "0000000000000000000000001711cd63b2c545ee6545415d3cc0bda6425c43c4"
+	assert.Equal(t, expected, WormchainModuleEmitter("tokenbridge"))
+
+	// Wormhole chain is not live on mainnet yet, so it is not a known mainnet emitter (nor governed).
+	_, found := KnownTokenbridgeEmitters[vaa.ChainIDWormchain]
+	assert.False(t, found)
+
+	for name, emitters := range map[string]map[vaa.ChainID][]byte{
+		"testnet": KnownTestnetTokenbridgeEmitters,
+		"devnet":  KnownDevnetTokenbridgeEmitters,
+	} {
+		assert.Equal(t, expected, hex.EncodeToString(emitters[vaa.ChainIDWormchain]), name)
+	}
+}
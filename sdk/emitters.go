@@ -0,0 +1,128 @@
+package sdk
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// EVMEmitterAddress returns the emitter address of an EVM contract, which is its 20-byte address left-padded with
+// zeros.
+func EVMEmitterAddress(contract string) (vaa.Address, error) {
+	var out vaa.Address
+	if !common.IsHexAddress(contract) {
+		return out, fmt.Errorf("invalid EVM address: %q", contract)
+	}
+	copy(out[12:], common.HexToAddress(contract).Bytes())
+	return out, nil
+}
+
+// SolanaEmitterAddress returns the emitter address of a Solana program, which is the program derived address for the
+// seed "emitter". programID is base58 encoded.
+func SolanaEmitterAddress(programID string) (vaa.Address, error) {
+	var out vaa.Address
+	program, err := decodeBase58(programID)
+	if err != nil {
+		return out, err
+	}
+	if len(program) != 32 {
+		return out, fmt.Errorf("invalid Solana program ID length: %d", len(program))
+	}
+	return findProgramAddress([][]byte{[]byte("emitter")}, program)
+}
+
+// CosmosModuleEmitterAddress returns the emitter address of a Cosmos SDK module, which is its module account address
+// (the first 20 bytes of the SHA-256 hash of the module name) left-padded with zeros.
+func CosmosModuleEmitterAddress(module string) vaa.Address {
+	var out vaa.Address
+	h := sha256.Sum256([]byte(module))
+	copy(out[12:], h[:20])
+	return out
+}
+
+// findProgramAddress implements Solana's find_program_address: the address is the first hash of the seeds, a bump
+// seed counting down from 255, the program ID and a fixed marker that is not a valid ed25519 public key.
+func findProgramAddress(seeds [][]byte, program []byte) (vaa.Address, error) {
+	for bump := 255; bump >= 0; bump-- {
+		h := sha256.New()
+		for _, seed := range seeds {
+			h.Write(seed)
+		}
+		h.Write([]byte{byte(bump)})
+		h.Write(program)
+		h.Write([]byte("ProgramDerivedAddress"))
+
+		var addr vaa.Address
+		copy(addr[:], h.Sum(nil))
+		if !isOnEd25519Curve(addr) {
+			return addr, nil
+		}
+	}
+	return vaa.Address{}, errors.New("no viable bump seed for program address")
+}
+
+var (
+	ed25519P = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 255), big.NewInt(19))
+	// ed25519D is -121665/121666 mod p.
+	ed25519D = new(big.Int).Mod(new(big.Int).Mul(big.NewInt(-121665), new(big.Int).ModInverse(big.NewInt(121666), ed25519P)), ed25519P)
+)
+
+// isOnEd25519Curve reports whether b decompresses to a point on the ed25519 curve, i.e. whether x^2 = (y^2 - 1) /
+// (d*y^2 + 1) has a solution for the encoded y. Like Solana, the sign bit is ignored and y is reduced mod p.
+func isOnEd25519Curve(b [32]byte) bool {
+	le := b
+	le[31] &= 0x7f
+	for i, j := 0, len(le)-1; i < j; i, j = i+1, j-1 {
+		le[i], le[j] = le[j], le[i]
+	}
+	y := new(big.Int).SetBytes(le[:])
+	y.Mod(y, ed25519P)
+
+	y2 := new(big.Int).Mul(y, y)
+	u := new(big.Int).Sub(y2, big.NewInt(1))
+	u.Mod(u, ed25519P)
+	v := new(big.Int).Mul(ed25519D, y2)
+	v.Add(v, big.NewInt(1))
+	v.Mod(v, ed25519P)
+
+	// v is never zero since -1/d is not a square mod p.
+	x2 := new(big.Int).Mul(u, new(big.Int).ModInverse(v, ed25519P))
+	x2.Mod(x2, ed25519P)
+	if x2.Sign() == 0 {
+		return true
+	}
+	// Euler's criterion
+	exp := new(big.Int).Rsh(new(big.Int).Sub(ed25519P, big.NewInt(1)), 1)
+	return new(big.Int).Exp(x2, exp, ed25519P).Cmp(big.NewInt(1)) == 0
+}
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// decodeBase58 decodes a string in the bitcoin base58 alphabet used by Solana.
+func decodeBase58(s string) ([]byte, error) {
+	n := new(big.Int)
+	radix := big.NewInt(58)
+	zeros := 0
+	for i, c := range s {
+		if c == '1' && zeros == i {
+			zeros++
+		}
+		idx := -1
+		for j, a := range base58Alphabet {
+			if a == c {
+				idx = j
+				break
+			}
+		}
+		if idx < 0 {
+			return nil, fmt.Errorf("invalid base58 character %q", c)
+		}
+		n.Mul(n, radix)
+		n.Add(n, big.NewInt(int64(idx)))
+	}
+	return append(make([]byte, zeros), n.Bytes()...), nil
+}
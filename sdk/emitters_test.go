@@ -0,0 +1,63 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+func TestEVMEmitterAddress(t *testing.T) {
+	tests := []struct {
+		label    string
+		contract string
+		expected []byte
+	}{
+		{label: "ethereum token bridge", contract: "0x3ee18B2214AFF97000D974cf647E7C347E8fa585", expected: KnownTokenbridgeEmitters[vaa.ChainIDEthereum]},
+		{label: "ethereum nft bridge", contract: "0x6FFd7EdE62328b3Af38FCD61461Bbfc52F5651fE", expected: KnownNFTBridgeEmitters[vaa.ChainIDEthereum]},
+		{label: "bsc token bridge without prefix", contract: "B6F6D86a8f9879A9c87f643768d9efc38c1Da6E7", expected: KnownTokenbridgeEmitters[vaa.ChainIDBSC]},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.label, func(t *testing.T) {
+			addr, err := EVMEmitterAddress(tc.contract)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, addr.Bytes())
+		})
+	}
+
+	_, err := EVMEmitterAddress("0x3ee18B2214AFF97000D974cf647E7C347E8fa5")
+	assert.Error(t, err)
+}
+
+func TestSolanaEmitterAddress(t *testing.T) {
+	tests := []struct {
+		label     string
+		programID string
+		expected  []byte
+	}{
+		{label: "token bridge", programID: "wormDTUJ6AWPNvk59vGQbDvGJmqbDTdgWgAqcLBCgUb", expected: KnownTokenbridgeEmitters[vaa.ChainIDSolana]},
+		{label: "nft bridge", programID: "WnFt12ZrnzZrFZkt2xsNsaNWoQribnuQ5B5FrDbwDhD", expected: KnownNFTBridgeEmitters[vaa.ChainIDSolana]},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.label, func(t *testing.T) {
+			addr, err := SolanaEmitterAddress(tc.programID)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, addr.Bytes())
+		})
+	}
+
+	_, err := SolanaEmitterAddress("wormDTUJ6AWPNvk59vGQbDvGJmqbDTdgWgAqcLBCg0b")
+	assert.Error(t, err)
+	_, err = SolanaEmitterAddress("wormDTUJ6AWPNvk59vGQbDvGJmqbDTdgWgAqcLB")
+	assert.Error(t, err)
+}
+
+func TestCosmosModuleEmitterAddress(t *testing.T) {
+	// The tokenbridge module account is wormhole1zugu6cajc4z7ue29g9wnes9a5ep9cs7yu7rn3z.
+	assert.Equal(t, "0000000000000000000000001711cd63b2c545ee6545415d3cc0bda6425c43c4", CosmosModuleEmitterAddress("tokenbridge").String())
+	// The wormhole module account is wormhole1ap5vgur5zlgys8whugfegnn43emka567dtq0jl.
+	assert.Equal(t, "000000000000000000000000e868c4707417d0481dd7e213944e758e776ed35e", CosmosModuleEmitterAddress("wormhole").String())
+}
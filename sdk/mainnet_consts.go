@@ -93,6 +93,12 @@ func buildEmitterMap(hexmap map[vaa.ChainID]string) map[vaa.ChainID][]byte {
 	return out
 }
 
+// WormchainModuleEmitter returns the hex-encoded emitter address of a wormhole chain module. This is the same on every
+// network, so wormhole chain emitters never need to be configured per environment.
+func WormchainModuleEmitter(module string) string {
+	return CosmosModuleEmitterAddress(module).String()
+}
+
 // KnownTokenbridgeEmitters is a list of well-known mainnet emitters for the tokenbridge.
 var KnownTokenbridgeEmitters = buildEmitterMap(knownTokenbridgeEmitters)
 var knownTokenbridgeEmitters = map[vaa.ChainID]string{
@@ -135,16 +141,11 @@ var knownNFTBridgeEmitters = map[vaa.ChainID]string{
 	vaa.ChainIDMoonbeam:  "000000000000000000000000453cfBe096C0f8D763E8C5F24B441097d577bdE2",
 }
 
+// GetEmitterAddressForChain returns the mainnet emitter of the given type on chainID. Use the AddressBook of an
+// environment for other networks.
 func GetEmitterAddressForChain(chainID vaa.ChainID, emitterType EmitterType) (vaa.Address, error) {
-	for _, emitter := range KnownEmitters {
-		if emitter.ChainID == chainID && emitter.BridgeType == emitterType {
-			emitterAddr, err := vaa.StringToAddress(emitter.Emitter)
-			if err != nil {
-				return vaa.Address{}, err
-			}
-
-			return emitterAddr, nil
-		}
+	if emitterAddr, ok := MustGetAddressBook(MainNet).Emitter(chainID, emitterType); ok {
+		return emitterAddr, nil
 	}
 
 	return vaa.Address{}, fmt.Errorf("lookup failed")
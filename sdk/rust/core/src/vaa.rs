@@ -376,4 +376,53 @@ mod testing {
     #[test]
     fn test_invalid_vaa() {
     }
+
+    // Parse the test vectors shared with the Go SDK and the Ethereum contracts, and check that
+    // every field and the digest match the values recorded by the Go implementation.
+    #[test]
+    fn test_vectors() {
+        use sha3::Digest;
+
+        let corpus: serde_json::Value =
+            serde_json::from_str(include_str!("../../../vaa/testdata/vectors.json")).unwrap();
+
+        for vector in corpus["vectors"].as_array().unwrap() {
+            let name = vector["name"].as_str().unwrap();
+            let hex_field = |field: &str| hex::decode(vector[field].as_str().unwrap()).unwrap();
+            let int_field = |field: &str| vector[field].as_u64().unwrap();
+
+            let vaa = VAA::from_bytes(hex_field("bytes")).unwrap();
+            assert_eq!(vaa.version as u64, int_field("version"), "{}", name);
+            assert_eq!(vaa.guardian_set_index as u64, int_field("guardian_set_index"), "{}", name);
+            assert_eq!(vaa.timestamp as u64, int_field("timestamp"), "{}", name);
+            assert_eq!(vaa.nonce as u64, int_field("nonce"), "{}", name);
+            assert_eq!(vaa.emitter_chain.clone() as u64, int_field("emitter_chain"), "{}", name);
+            assert_eq!(vaa.emitter_address[..], hex_field("emitter_address")[..], "{}", name);
+            assert_eq!(
+                vaa.sequence.to_string(),
+                vector["sequence"].as_str().unwrap(),
+                "{}",
+                name
+            );
+            assert_eq!(vaa.consistency_level as u64, int_field("consistency_level"), "{}", name);
+            assert_eq!(vaa.payload, hex_field("payload"), "{}", name);
+
+            let signatures = vector["signatures"].as_array().unwrap();
+            assert_eq!(vaa.signatures.len(), signatures.len(), "{}", name);
+            for (parsed, expected) in vaa.signatures.iter().zip(signatures) {
+                assert_eq!(parsed[0] as u64, expected["guardian_index"].as_u64().unwrap());
+                assert_eq!(
+                    parsed[1..],
+                    hex::decode(expected["signature"].as_str().unwrap()).unwrap()[..]
+                );
+            }
+
+            // The digest is the body and its hash, guardians sign the hash of the hash.
+            let digest = vaa.digest().unwrap();
+            assert_eq!(digest.digest, hex_field("body"), "{}", name);
+            assert_eq!(digest.hash[..], hex_field("body_hash")[..], "{}", name);
+            let signed: [u8; 32] = sha3::Keccak256::digest(&digest.hash).into();
+            assert_eq!(signed[..], hex_field("digest")[..], "{}", name);
+        }
+    }
 }
@@ -0,0 +1,235 @@
+package vaa
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// CompactFormat is the first byte of a VAA in the compact encoding, chosen so that it cannot be mistaken for a VAA
+// version.
+//
+// The compact encoding is meant for relayers submitting VAAs to chains where calldata is expensive, and is decoded
+// back into a regular VAA by DecodeCompact before it is verified. It is laid out as:
+//
+//   - format (1 byte, CompactFormat)
+//   - version (1 byte)
+//   - guardian set index (4 bytes)
+//   - signer bitmap length (1 byte)
+//   - signer bitmap, where bit i%8 of byte i/8 is set if guardian i signed
+//   - signatures in order of guardian index (64 bytes each, EIP-2098 r and yParity|s)
+//   - body, as in the regular encoding
+//
+// Compared to the regular encoding, this saves the index byte and the recovery byte of every signature.
+const CompactFormat = 0xc0
+
+const (
+	signatureLength        = 65
+	compactSignatureLength = 64
+	// maxGuardians is the number of guardians signature indexes can address.
+	maxGuardians = 256
+)
+
+// secp256k1HalfN is half the order of the secp256k1 curve. Signatures with a larger s are malleable and cannot be
+// encoded in the EIP-2098 form.
+var secp256k1HalfN = new(big.Int).Rsh(crypto.S256().Params().N, 1)
+
+// CalculateQuorum returns the minimum number of guardians that need to sign a VAA for a given guardian set.
+func CalculateQuorum(numGuardians int) int {
+	return ((numGuardians * 2) / 3) + 1
+}
+
+// splitVAA splits the regular encoding of a VAA into its header fields, signatures and body. Unlike Unmarshal, it does
+// not decode the body, so payloads of any size are kept.
+func splitVAA(data []byte) (version uint8, guardianSetIndex uint32, signatures []Signature, body []byte, err error) {
+	r := &byteReader{data: data}
+	b, err := r.next(6)
+	if err != nil {
+		return 0, 0, nil, nil, fmt.Errorf("failed to read VAA header: %w", err)
+	}
+	version = b[0]
+	guardianSetIndex = binary.BigEndian.Uint32(b[1:5])
+
+	signatures = make([]Signature, b[5])
+	for i := range signatures {
+		b, err = r.next(1 + signatureLength)
+		if err != nil {
+			return 0, 0, nil, nil, fmt.Errorf("failed to read signature [%d]: %w", i, err)
+		}
+		signatures[i].Index = b[0]
+		copy(signatures[i].Signature[:], b[1:])
+	}
+
+	body = data[r.offset:]
+	if len(body) < minVAALength-6 {
+		return 0, 0, nil, nil, fmt.Errorf("VAA body is too short")
+	}
+	return version, guardianSetIndex, signatures, body, nil
+}
+
+// joinVAA returns the regular encoding of a VAA.
+func joinVAA(version uint8, guardianSetIndex uint32, signatures []Signature, body []byte) []byte {
+	buf := new(bytes.Buffer)
+	MustWrite(buf, binary.BigEndian, version)
+	MustWrite(buf, binary.BigEndian, guardianSetIndex)
+	MustWrite(buf, binary.BigEndian, uint8(len(signatures)))
+	for _, sig := range signatures {
+		MustWrite(buf, binary.BigEndian, sig.Index)
+		buf.Write(sig.Signature[:])
+	}
+	buf.Write(body)
+	return buf.Bytes()
+}
+
+// StripToQuorum returns the VAA vaaBytes, signed by the guardian set with the given addresses, keeping only as many
+// valid signatures as a quorum of the set requires. The signatures of the lowest guardian indexes are kept, and invalid
+// ones are dropped. The body, and so the digest, is unchanged. It fails if the VAA has fewer valid signatures than a
+// quorum.
+func StripToQuorum(vaaBytes []byte, addresses []common.Address) ([]byte, error) {
+	version, guardianSetIndex, signatures, body, err := splitVAA(vaaBytes)
+	if err != nil {
+		return nil, err
+	}
+	digest := crypto.Keccak256Hash(crypto.Keccak256Hash(body).Bytes())
+
+	quorum := CalculateQuorum(len(addresses))
+	kept := make([]Signature, 0, quorum)
+	lastIndex := -1
+	for _, sig := range signatures {
+		if len(kept) == quorum {
+			break
+		}
+		// Keep indexes strictly increasing, which also rules out duplicate signers.
+		if int(sig.Index) <= lastIndex || int(sig.Index) >= len(addresses) {
+			continue
+		}
+		pubKey, err := crypto.Ecrecover(digest.Bytes(), sig.Signature[:])
+		if err != nil {
+			continue
+		}
+		if common.BytesToAddress(crypto.Keccak256(pubKey[1:])[12:]) != addresses[sig.Index] {
+			continue
+		}
+		kept = append(kept, sig)
+		lastIndex = int(sig.Index)
+	}
+	if len(kept) < quorum {
+		return nil, fmt.Errorf("VAA has %d valid signatures, but a quorum of %d guardians requires %d", len(kept), len(addresses), quorum)
+	}
+
+	return joinVAA(version, guardianSetIndex, kept, body), nil
+}
+
+// EncodeCompact re-encodes the VAA vaaBytes in the compact encoding described at CompactFormat. Signatures must be in
+// strictly increasing order of guardian index, as the guardian contracts require, and have a low s value, as produced
+// by the guardians.
+func EncodeCompact(vaaBytes []byte) ([]byte, error) {
+	version, guardianSetIndex, signatures, body, err := splitVAA(vaaBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	var bitmap []byte
+	lastIndex := -1
+	for _, sig := range signatures {
+		if int(sig.Index) <= lastIndex {
+			return nil, fmt.Errorf("signature index %d is not greater than the previous index %d", sig.Index, lastIndex)
+		}
+		lastIndex = int(sig.Index)
+		for len(bitmap) <= int(sig.Index)/8 {
+			bitmap = append(bitmap, 0)
+		}
+		bitmap[sig.Index/8] |= 1 << (sig.Index % 8)
+	}
+
+	buf := new(bytes.Buffer)
+	MustWrite(buf, binary.BigEndian, uint8(CompactFormat))
+	MustWrite(buf, binary.BigEndian, version)
+	MustWrite(buf, binary.BigEndian, guardianSetIndex)
+	MustWrite(buf, binary.BigEndian, uint8(len(bitmap)))
+	buf.Write(bitmap)
+	for _, sig := range signatures {
+		compact, err := compactSignature(sig.Signature)
+		if err != nil {
+			return nil, fmt.Errorf("signature of guardian %d: %w", sig.Index, err)
+		}
+		buf.Write(compact[:])
+	}
+	buf.Write(body)
+
+	return buf.Bytes(), nil
+}
+
+// DecodeCompact restores the regular encoding of a VAA from its compact encoding, byte for byte as it was before
+// EncodeCompact.
+func DecodeCompact(data []byte) ([]byte, error) {
+	r := &byteReader{data: data}
+	b, err := r.next(7)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read compact VAA header: %w", err)
+	}
+	if b[0] != CompactFormat {
+		return nil, fmt.Errorf("not a compact VAA: format %#x", b[0])
+	}
+	version := b[1]
+	guardianSetIndex := binary.BigEndian.Uint32(b[2:6])
+	bitmapLength := int(b[6])
+	if bitmapLength > maxGuardians/8 {
+		return nil, fmt.Errorf("signer bitmap of %d bytes is too long", bitmapLength)
+	}
+	bitmap, err := r.next(bitmapLength)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signer bitmap: %w", err)
+	}
+	if bitmapLength > 0 && bitmap[bitmapLength-1] == 0 {
+		// EncodeCompact never writes trailing zero bytes, so each VAA has exactly one compact encoding.
+		return nil, fmt.Errorf("signer bitmap has trailing zero bytes")
+	}
+
+	var signatures []Signature
+	for i := 0; i < bitmapLength*8; i++ {
+		if bitmap[i/8]&(1<<(i%8)) == 0 {
+			continue
+		}
+		b, err := r.next(compactSignatureLength)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read signature of guardian %d: %w", i, err)
+		}
+		var compact [compactSignatureLength]byte
+		copy(compact[:], b)
+		signatures = append(signatures, Signature{Index: uint8(i), Signature: expandSignature(compact)})
+	}
+
+	body := data[r.offset:]
+	if len(body) < minVAALength-6 {
+		return nil, fmt.Errorf("VAA body is too short")
+	}
+	return joinVAA(version, guardianSetIndex, signatures, body), nil
+}
+
+// compactSignature returns the EIP-2098 form of a [r || s || v] signature, which stores v in the top bit of s.
+func compactSignature(sig SignatureData) ([compactSignatureLength]byte, error) {
+	var compact [compactSignatureLength]byte
+	if sig[64] > 1 {
+		return compact, fmt.Errorf("invalid recovery id %d", sig[64])
+	}
+	if new(big.Int).SetBytes(sig[32:64]).Cmp(secp256k1HalfN) > 0 {
+		return compact, fmt.Errorf("signature has a high s value")
+	}
+	copy(compact[:], sig[:64])
+	compact[32] |= sig[64] << 7
+	return compact, nil
+}
+
+// expandSignature is the inverse of compactSignature.
+func expandSignature(compact [compactSignatureLength]byte) SignatureData {
+	var sig SignatureData
+	copy(sig[:64], compact[:])
+	sig[64] = compact[32] >> 7
+	sig[32] &= 0x7f
+	return sig
+}
@@ -0,0 +1,191 @@
+package vaa
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// signedTestVAA returns a VAA with a payload of payloadSize bytes, signed by all of n guardians, and their addresses.
+func signedTestVAA(t *testing.T, n int, payloadSize int) (*VAA, []common.Address) {
+	t.Helper()
+	v := &VAA{
+		Version:          SupportedVAAVersion,
+		GuardianSetIndex: 3,
+		Timestamp:        time.Unix(2837, 0),
+		Nonce:            10,
+		Sequence:         42,
+		ConsistencyLevel: 1,
+		EmitterChain:     ChainIDEthereum,
+		EmitterAddress:   Address{0xab},
+		Payload:          bytes.Repeat([]byte{0x5a}, payloadSize),
+	}
+	addresses := make([]common.Address, n)
+	for i := range addresses {
+		key, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		addresses[i] = crypto.PubkeyToAddress(key.PublicKey)
+		v.AddSignature(key, uint8(i))
+	}
+	return v, addresses
+}
+
+func TestCalculateQuorum(t *testing.T) {
+	for numGuardians, quorum := range map[int]int{0: 1, 1: 1, 2: 2, 3: 3, 4: 3, 7: 5, 13: 9, 19: 13, 20: 14} {
+		assert.Equal(t, quorum, CalculateQuorum(numGuardians), "%d guardians", numGuardians)
+	}
+}
+
+func TestStripToQuorum(t *testing.T) {
+	v, addresses := signedTestVAA(t, 19, 100)
+	// An invalid signature is skipped in favor of the next valid one.
+	v.Signatures[1].Signature[10] ^= 0xff
+	vaaBytes, err := v.Marshal()
+	require.NoError(t, err)
+
+	stripped, err := StripToQuorum(vaaBytes, addresses)
+	require.NoError(t, err)
+	assert.Len(t, stripped, len(vaaBytes)-6*(1+signatureLength))
+
+	s, err := Unmarshal(stripped)
+	require.NoError(t, err)
+	assert.Equal(t, v.HexDigest(), s.HexDigest())
+	require.Len(t, s.Signatures, 13)
+	assert.Equal(t, uint8(0), s.Signatures[0].Index)
+	assert.Equal(t, uint8(2), s.Signatures[1].Index)
+	assert.Equal(t, uint8(13), s.Signatures[12].Index)
+	assert.True(t, s.VerifySignatures(addresses))
+
+	// Stripping is idempotent.
+	again, err := StripToQuorum(stripped, addresses)
+	require.NoError(t, err)
+	assert.Equal(t, stripped, again)
+
+	// A VAA verified against another guardian set has no valid signatures.
+	_, others := signedTestVAA(t, 19, 1)
+	_, err = StripToQuorum(vaaBytes, others)
+	assert.EqualError(t, err, "VAA has 0 valid signatures, but a quorum of 19 guardians requires 13")
+
+	_, err = StripToQuorum(vaaBytes[:40], addresses)
+	assert.Error(t, err)
+}
+
+func TestCompactRoundTrip(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		guardians   int
+		signers     []int
+		payloadSize int
+	}{
+		{name: "quorum of 19", guardians: 19, signers: []int{0, 2, 3, 5, 6, 7, 8, 10, 11, 12, 15, 17, 18}, payloadSize: 133},
+		{name: "single guardian", guardians: 1, signers: []int{0}, payloadSize: 1},
+		{name: "payload over the unmarshal limit", guardians: 4, signers: []int{1, 2, 3}, payloadSize: 5000},
+		{name: "no signatures", guardians: 4, signers: nil, payloadSize: 10},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			v, addresses := signedTestVAA(t, tc.guardians, tc.payloadSize)
+			all := v.Signatures
+			v.Signatures = nil
+			for _, i := range tc.signers {
+				v.Signatures = append(v.Signatures, all[i])
+			}
+			vaaBytes, err := v.Marshal()
+			require.NoError(t, err)
+
+			compact, err := EncodeCompact(vaaBytes)
+			require.NoError(t, err)
+			assert.Equal(t, byte(CompactFormat), compact[0])
+			// One byte more for the format and bitmap length instead of the signature count, plus the bitmap, and two bytes
+			// less per signature.
+			bitmapLength := 0
+			if len(tc.signers) > 0 {
+				bitmapLength = tc.signers[len(tc.signers)-1]/8 + 1
+			}
+			assert.Len(t, compact, len(vaaBytes)+1+bitmapLength-2*len(tc.signers))
+
+			restored, err := DecodeCompact(compact)
+			require.NoError(t, err)
+			assert.Equal(t, vaaBytes, restored)
+
+			if len(tc.signers) > 0 && tc.payloadSize <= InternalTruncatedPayloadSafetyLimit {
+				r, err := Unmarshal(restored)
+				require.NoError(t, err)
+				assert.Equal(t, v.HexDigest(), r.HexDigest())
+				assert.True(t, r.VerifySignatures(addresses))
+			}
+		})
+	}
+}
+
+func TestEncodeCompactRejects(t *testing.T) {
+	v, _ := signedTestVAA(t, 3, 10)
+
+	unordered := *v
+	unordered.Signatures = []*Signature{v.Signatures[1], v.Signatures[0]}
+	b, err := unordered.Marshal()
+	require.NoError(t, err)
+	_, err = EncodeCompact(b)
+	assert.EqualError(t, err, "signature index 0 is not greater than the previous index 1")
+
+	// The malleable twin of a valid signature, with s replaced by N-s and the recovery id flipped.
+	highS := *v
+	sig := *v.Signatures[0]
+	s := new(big.Int).Sub(crypto.S256().Params().N, new(big.Int).SetBytes(sig.Signature[32:64]))
+	s.FillBytes(sig.Signature[32:64])
+	sig.Signature[64] ^= 1
+	highS.Signatures = []*Signature{&sig}
+	b, err = highS.Marshal()
+	require.NoError(t, err)
+	_, err = EncodeCompact(b)
+	assert.EqualError(t, err, "signature of guardian 0: signature has a high s value")
+}
+
+func TestDecodeCompactRejects(t *testing.T) {
+	v, _ := signedTestVAA(t, 9, 10)
+	b, err := v.Marshal()
+	require.NoError(t, err)
+	compact, err := EncodeCompact(b)
+	require.NoError(t, err)
+
+	_, err = DecodeCompact(b)
+	assert.EqualError(t, err, "not a compact VAA: format 0x1")
+
+	trailingZero := append([]byte{}, compact[:6]...)
+	trailingZero = append(trailingZero, 3, compact[7], compact[8], 0)
+	trailingZero = append(trailingZero, compact[9:]...)
+	_, err = DecodeCompact(trailingZero)
+	assert.EqualError(t, err, "signer bitmap has trailing zero bytes")
+
+	// Truncated within the signatures or the body
+	_, err = DecodeCompact(compact[:9+5*compactSignatureLength])
+	assert.Error(t, err)
+	_, err = DecodeCompact(compact[:len(compact)-20])
+	assert.EqualError(t, err, "VAA body is too short")
+}
+
+func TestCompactSignatureRoundTrip(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		key, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		digest := crypto.Keccak256([]byte{byte(i)})
+		raw, err := crypto.Sign(digest, key)
+		require.NoError(t, err)
+		var sig SignatureData
+		copy(sig[:], raw)
+
+		compact, err := compactSignature(sig)
+		require.NoError(t, err)
+		expanded := expandSignature(compact)
+		assert.Equal(t, sig, expanded)
+
+		pubKey, err := crypto.SigToPub(digest, expanded[:])
+		require.NoError(t, err)
+		assert.Equal(t, crypto.PubkeyToAddress(key.PublicKey), crypto.PubkeyToAddress(*pubKey))
+	}
+}
@@ -0,0 +1,116 @@
+package vaa
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MessageID identifies a message by its emitter and sequence. It is the same for every VAA of the message, no matter
+// which guardians signed it, so it can be used as an idempotency key.
+//
+// MessageID is comparable and can be used as a map key. Its text form is <chain>/<emitter>/<sequence>, e.g.
+// "2/0000000000000000000000003ee18b2214aff97000d974cf647e7c347e8fa585/12345", which is also used when it is
+// marshaled to JSON, including as a map key.
+type MessageID struct {
+	EmitterChain   ChainID
+	EmitterAddress Address
+	Sequence       uint64
+}
+
+// MessageIDLength is the length of the binary encoding of a MessageID returned by MessageID.Bytes.
+const MessageIDLength = 2 + 32 + 8
+
+// ParseMessageID parses the text form of a MessageID. The emitter address is hex encoded and may have a 0x prefix or
+// omit leading zeros.
+func ParseMessageID(s string) (MessageID, error) {
+	var id MessageID
+	parts := strings.Split(s, "/")
+	if len(parts) != 3 {
+		return id, fmt.Errorf("invalid message id %q: expected <chain>/<emitter>/<sequence>", s)
+	}
+
+	chain, err := strconv.ParseUint(parts[0], 10, 16)
+	if err != nil {
+		return id, fmt.Errorf("invalid emitter chain: %w", err)
+	}
+
+	emitter, err := StringToAddress(parts[1])
+	if err != nil {
+		return id, fmt.Errorf("invalid emitter address: %w", err)
+	}
+
+	sequence, err := strconv.ParseUint(parts[2], 10, 64)
+	if err != nil {
+		return id, fmt.Errorf("invalid sequence: %w", err)
+	}
+
+	return MessageID{EmitterChain: ChainID(chain), EmitterAddress: emitter, Sequence: sequence}, nil
+}
+
+func (id MessageID) String() string {
+	return fmt.Sprintf("%d/%s/%d", id.EmitterChain, id.EmitterAddress, id.Sequence)
+}
+
+// Compare orders message IDs by emitter chain, emitter address and sequence. It returns -1 if id sorts before other,
+// 1 if it sorts after it and 0 if they are equal.
+func (id MessageID) Compare(other MessageID) int {
+	if id.EmitterChain != other.EmitterChain {
+		if id.EmitterChain < other.EmitterChain {
+			return -1
+		}
+		return 1
+	}
+	if c := bytes.Compare(id.EmitterAddress[:], other.EmitterAddress[:]); c != 0 {
+		return c
+	}
+	if id.Sequence != other.Sequence {
+		if id.Sequence < other.Sequence {
+			return -1
+		}
+		return 1
+	}
+	return 0
+}
+
+// Bytes returns a fixed-length binary encoding of id. Encodings sort in the same order as Compare, so they can be
+// used as keys of ordered stores.
+func (id MessageID) Bytes() []byte {
+	b := make([]byte, MessageIDLength)
+	binary.BigEndian.PutUint16(b[:2], uint16(id.EmitterChain))
+	copy(b[2:34], id.EmitterAddress[:])
+	binary.BigEndian.PutUint64(b[34:], id.Sequence)
+	return b
+}
+
+// MessageIDFromBytes decodes a MessageID encoded by MessageID.Bytes.
+func MessageIDFromBytes(b []byte) (MessageID, error) {
+	var id MessageID
+	if len(b) != MessageIDLength {
+		return id, fmt.Errorf("message id must be %d bytes, is %d", MessageIDLength, len(b))
+	}
+	id.EmitterChain = ChainID(binary.BigEndian.Uint16(b[:2]))
+	copy(id.EmitterAddress[:], b[2:34])
+	id.Sequence = binary.BigEndian.Uint64(b[34:])
+	return id, nil
+}
+
+func (id MessageID) MarshalText() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+func (id *MessageID) UnmarshalText(text []byte) error {
+	parsed, err := ParseMessageID(string(text))
+	if err != nil {
+		return err
+	}
+	*id = parsed
+	return nil
+}
+
+// ID returns the ID of the message signed by the VAA.
+func (v *VAA) ID() MessageID {
+	return MessageID{EmitterChain: v.EmitterChain, EmitterAddress: v.EmitterAddress, Sequence: v.Sequence}
+}
@@ -0,0 +1,103 @@
+package vaa
+
+import (
+	"encoding/json"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMessageID(t *testing.T) {
+	emitter, err := StringToAddress("0000000000000000000000003ee18b2214aff97000d974cf647e7c347e8fa585")
+	require.NoError(t, err)
+	expected := MessageID{EmitterChain: ChainIDEthereum, EmitterAddress: emitter, Sequence: 12345}
+
+	type test struct {
+		input  string
+		output MessageID
+		err    bool
+	}
+
+	tests := []test{
+		{input: "2/0000000000000000000000003ee18b2214aff97000d974cf647e7c347e8fa585/12345", output: expected},
+		{input: "2/0x0000000000000000000000003EE18B2214AFF97000D974CF647E7C347E8FA585/12345", output: expected},
+		{input: "2/3ee18b2214aff97000d974cf647e7c347e8fa585/12345", output: expected},
+		{input: "2/0000000000000000000000003ee18b2214aff97000d974cf647e7c347e8fa585", err: true},
+		{input: "2/0000000000000000000000003ee18b2214aff97000d974cf647e7c347e8fa585/12345/1", err: true},
+		{input: "65536/0000000000000000000000003ee18b2214aff97000d974cf647e7c347e8fa585/12345", err: true},
+		{input: "2/xyz/12345", err: true},
+		{input: "2/0000000000000000000000003ee18b2214aff97000d974cf647e7c347e8fa585/-1", err: true},
+		{input: "", err: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			id, err := ParseMessageID(tc.input)
+			if tc.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.output, id)
+			assert.Equal(t, "2/0000000000000000000000003ee18b2214aff97000d974cf647e7c347e8fa585/12345", id.String())
+		})
+	}
+}
+
+func TestMessageIDCompare(t *testing.T) {
+	ids := []MessageID{
+		{EmitterChain: ChainIDEthereum, EmitterAddress: Address{1}, Sequence: 1},
+		{EmitterChain: ChainIDSolana, EmitterAddress: Address{2}, Sequence: 5},
+		{EmitterChain: ChainIDEthereum, EmitterAddress: Address{1}, Sequence: 256},
+		{EmitterChain: ChainIDEthereum, EmitterAddress: Address{0, 1}, Sequence: 9},
+		{EmitterChain: ChainIDSolana, EmitterAddress: Address{2}, Sequence: 4},
+	}
+	expected := []MessageID{ids[4], ids[1], ids[3], ids[0], ids[2]}
+
+	sorted := append([]MessageID(nil), ids...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Compare(sorted[j]) < 0 })
+	assert.Equal(t, expected, sorted)
+
+	// The binary encoding sorts the same way.
+	sort.Slice(ids, func(i, j int) bool { return string(ids[i].Bytes()) < string(ids[j].Bytes()) })
+	assert.Equal(t, expected, ids)
+
+	assert.Equal(t, 0, ids[0].Compare(ids[0]))
+}
+
+func TestMessageIDBytes(t *testing.T) {
+	id := MessageID{EmitterChain: ChainIDEthereum, EmitterAddress: Address{0xff, 1}, Sequence: 12345}
+	b := id.Bytes()
+	assert.Len(t, b, MessageIDLength)
+
+	decoded, err := MessageIDFromBytes(b)
+	require.NoError(t, err)
+	assert.Equal(t, id, decoded)
+
+	_, err = MessageIDFromBytes(b[1:])
+	assert.Error(t, err)
+}
+
+func TestMessageIDJSON(t *testing.T) {
+	id := MessageID{EmitterChain: ChainIDSolana, EmitterAddress: Address{31: 4}, Sequence: 7}
+	seen := map[MessageID]bool{id: true}
+
+	b, err := json.Marshal(seen)
+	require.NoError(t, err)
+	assert.Equal(t, `{"1/0000000000000000000000000000000000000000000000000000000000000004/7":true}`, string(b))
+
+	var decoded map[MessageID]bool
+	require.NoError(t, json.Unmarshal(b, &decoded))
+	assert.Equal(t, seen, decoded)
+
+	var invalid MessageID
+	assert.Error(t, json.Unmarshal([]byte(`"1/4"`), &invalid))
+}
+
+func TestVAAID(t *testing.T) {
+	v := getVaa()
+	assert.Equal(t, MessageID{EmitterChain: v.EmitterChain, EmitterAddress: v.EmitterAddress, Sequence: v.Sequence}, v.ID())
+	assert.Equal(t, v.MessageID(), v.ID().String())
+}
@@ -301,84 +301,127 @@ const (
 // WARNING: Unmarshall will truncate payloads at 1000 bytes, this is done mainly to avoid denial of service
 //   - If you need to access the full payload, consider parsing VAA from Bytes instead of Unmarshal
 func Unmarshal(data []byte) (*VAA, error) {
+	return UnmarshalVersions(data, func(version uint8) bool { return version == SupportedVAAVersion })
+}
+
+// UnmarshalVersions is like Unmarshal, but accepts every version for which accepts returns true rather than only
+// SupportedVAAVersion. VAAs of all accepted versions are decoded with the version 1 layout.
+func UnmarshalVersions(data []byte, accepts func(version uint8) bool) (*VAA, error) {
 	if len(data) < minVAALength {
 		return nil, fmt.Errorf("VAA is too short")
 	}
 	v := &VAA{}
 
 	v.Version = data[0]
-	if v.Version != SupportedVAAVersion {
+	if !accepts(v.Version) {
 		return nil, fmt.Errorf("unsupported VAA version: %d", v.Version)
 	}
 
-	reader := bytes.NewReader(data[1:])
+	r := &byteReader{data: data, offset: 1}
 
-	if err := binary.Read(reader, binary.BigEndian, &v.GuardianSetIndex); err != nil {
+	b, err := r.next(4)
+	if err != nil {
 		return nil, fmt.Errorf("failed to read guardian set index: %w", err)
 	}
+	v.GuardianSetIndex = binary.BigEndian.Uint32(b)
 
-	lenSignatures, er := reader.ReadByte()
-	if er != nil {
+	b, err = r.next(1)
+	if err != nil {
 		return nil, fmt.Errorf("failed to read signature length")
 	}
+	lenSignatures := int(b[0])
 
+	// Allocate all signatures at once rather than one by one.
+	signatures := make([]Signature, lenSignatures)
 	v.Signatures = make([]*Signature, lenSignatures)
-	for i := 0; i < int(lenSignatures); i++ {
-		index, err := reader.ReadByte()
+	for i := range signatures {
+		b, err = r.next(1)
 		if err != nil {
 			return nil, fmt.Errorf("failed to read validator index [%d]", i)
 		}
+		signatures[i].Index = b[0]
 
-		signature := [65]byte{}
-		if n, err := reader.Read(signature[:]); err != nil || n != 65 {
+		b, err = r.next(65)
+		if err != nil {
 			return nil, fmt.Errorf("failed to read signature [%d]: %w", i, err)
 		}
+		copy(signatures[i].Signature[:], b)
 
-		v.Signatures[i] = &Signature{
-			Index:     index,
-			Signature: signature,
-		}
+		v.Signatures[i] = &signatures[i]
 	}
 
-	unixSeconds := uint32(0)
-	if err := binary.Read(reader, binary.BigEndian, &unixSeconds); err != nil {
+	b, err = r.next(4)
+	if err != nil {
 		return nil, fmt.Errorf("failed to read timestamp: %w", err)
 	}
-	v.Timestamp = time.Unix(int64(unixSeconds), 0)
+	v.Timestamp = time.Unix(int64(binary.BigEndian.Uint32(b)), 0)
 
-	if err := binary.Read(reader, binary.BigEndian, &v.Nonce); err != nil {
+	b, err = r.next(4)
+	if err != nil {
 		return nil, fmt.Errorf("failed to read nonce: %w", err)
 	}
+	v.Nonce = binary.BigEndian.Uint32(b)
 
-	if err := binary.Read(reader, binary.BigEndian, &v.EmitterChain); err != nil {
+	b, err = r.next(2)
+	if err != nil {
 		return nil, fmt.Errorf("failed to read emitter chain: %w", err)
 	}
+	v.EmitterChain = ChainID(binary.BigEndian.Uint16(b))
 
-	emitterAddress := Address{}
-	if n, err := reader.Read(emitterAddress[:]); err != nil || n != 32 {
-		return nil, fmt.Errorf("failed to read emitter address [%d]: %w", n, err)
+	b, err = r.next(32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read emitter address [%d]: %w", r.remaining(), err)
 	}
-	v.EmitterAddress = emitterAddress
+	copy(v.EmitterAddress[:], b)
 
-	if err := binary.Read(reader, binary.BigEndian, &v.Sequence); err != nil {
+	b, err = r.next(8)
+	if err != nil {
 		return nil, fmt.Errorf("failed to read sequence: %w", err)
 	}
+	v.Sequence = binary.BigEndian.Uint64(b)
 
-	if err := binary.Read(reader, binary.BigEndian, &v.ConsistencyLevel); err != nil {
+	b, err = r.next(1)
+	if err != nil {
 		return nil, fmt.Errorf("failed to read commitment: %w", err)
 	}
+	v.ConsistencyLevel = b[0]
 
-	payload := make([]byte, InternalTruncatedPayloadSafetyLimit)
-	n, err := reader.Read(payload)
-	if err != nil || n == 0 {
-		return nil, fmt.Errorf("failed to read payload [%d]: %w", n, err)
+	n := r.remaining()
+	if n == 0 {
+		return nil, fmt.Errorf("failed to read payload [%d]: %w", n, io.EOF)
+	}
+	if n > InternalTruncatedPayloadSafetyLimit {
+		n = InternalTruncatedPayloadSafetyLimit
 	}
 
-	v.Payload = payload[:n]
+	// The payload is copied so that the VAA does not retain or alias the input buffer.
+	b, _ = r.next(n)
+	v.Payload = make([]byte, n)
+	copy(v.Payload, b)
 
 	return v, nil
 }
 
+// byteReader reads consecutive fields from a byte slice without copying them.
+type byteReader struct {
+	data   []byte
+	offset int
+}
+
+// next returns the next n bytes, or io.ErrUnexpectedEOF if fewer are left. The returned slice aliases the data.
+func (r *byteReader) next(n int) ([]byte, error) {
+	if r.remaining() < n {
+		return nil, io.ErrUnexpectedEOF
+	}
+	b := r.data[r.offset : r.offset+n]
+	r.offset += n
+	return b, nil
+}
+
+func (r *byteReader) remaining() int {
+	return len(r.data) - r.offset
+}
+
 // signingBody returns the binary representation of the data that is relevant for signing and verifying the VAA
 func (v *VAA) signingBody() []byte {
 	return v.serializeBody()
@@ -460,7 +503,7 @@ func (v *VAA) Marshal() ([]byte, error) {
 
 // MessageID returns a human-readable emitter_chain/emitter_address/sequence tuple.
 func (v *VAA) MessageID() string {
-	return fmt.Sprintf("%d/%s/%d", v.EmitterChain, v.EmitterAddress, v.Sequence)
+	return v.ID().String()
 }
 
 // HexDigest returns the hex-encoded digest.
@@ -519,34 +562,19 @@ func DecodeTransferPayloadHdr(payload []byte) (*TransferPayloadHdr, error) {
 	p.Type = uint8(payload[0])
 
 	// Amount: payload[1] for 32
-	p.Amount = new(big.Int)
-	p.Amount.SetBytes(payload[1:33])
-
-	reader := bytes.NewReader(payload[33:])
+	p.Amount = new(big.Int).SetBytes(payload[1:33])
 
 	// Origin address: payload[33] for 32
-	err := binary.Read(reader, binary.BigEndian, &p.OriginAddress)
-	if err != nil {
-		return nil, err
-	}
+	copy(p.OriginAddress[:], payload[33:65])
 
 	// Origin chain ID: payload[65] for 2
-	err = binary.Read(reader, binary.BigEndian, &p.OriginChain)
-	if err != nil {
-		return nil, err
-	}
+	p.OriginChain = ChainID(binary.BigEndian.Uint16(payload[65:67]))
 
 	// Target address: payload[67] for 32
-	err = binary.Read(reader, binary.BigEndian, &p.TargetAddress)
-	if err != nil {
-		return nil, err
-	}
+	copy(p.TargetAddress[:], payload[67:99])
 
 	// Target chain ID: payload[99] for 2
-	err = binary.Read(reader, binary.BigEndian, &p.TargetChain)
-	if err != nil {
-		return nil, err
-	}
+	p.TargetChain = ChainID(binary.BigEndian.Uint16(payload[99:101]))
 
 	return p, nil
 }
@@ -282,6 +282,60 @@ func TestUnmarshalTooBig(t *testing.T) {
 	assert.Equal(t, marshalBytes[:1057], marshalBytes2)
 }
 
+func TestUnmarshalTruncated(t *testing.T) {
+	vaa := getVaa()
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	vaa.AddSignature(key, 0)
+	vaa.AddSignature(key, 1)
+
+	vaaBytes, err := vaa.Marshal()
+	require.NoError(t, err)
+
+	// Every field is required, so no prefix of the VAA is valid.
+	for i := 0; i < len(vaaBytes)-len(vaa.Payload); i++ {
+		_, err := Unmarshal(vaaBytes[:i])
+		assert.Error(t, err, "prefix of length %d", i)
+	}
+
+	_, err = Unmarshal(vaaBytes[:minVAALength+66])
+	assert.EqualError(t, err, "failed to read signature [1]: unexpected EOF")
+
+	vaa2, err := Unmarshal(vaaBytes)
+	require.NoError(t, err)
+	assert.Equal(t, &vaa, vaa2)
+}
+
+func TestUnmarshalCopiesPayload(t *testing.T) {
+	vaa := getVaa()
+	vaaBytes, err := vaa.Marshal()
+	require.NoError(t, err)
+
+	vaa2, err := Unmarshal(vaaBytes)
+	require.NoError(t, err)
+
+	// Modifying the input must not modify the parsed VAA.
+	vaaBytes[len(vaaBytes)-1] = 0
+	assert.Equal(t, vaa.Payload, vaa2.Payload)
+}
+
+func TestUnmarshalVersions(t *testing.T) {
+	vaa := getVaa()
+	vaa.Version = 2
+	vaaBytes, err := vaa.Marshal()
+	require.NoError(t, err)
+
+	_, err = Unmarshal(vaaBytes)
+	assert.EqualError(t, err, "unsupported VAA version: 2")
+
+	_, err = UnmarshalVersions(vaaBytes, func(version uint8) bool { return version == 3 })
+	assert.EqualError(t, err, "unsupported VAA version: 2")
+
+	vaa2, err := UnmarshalVersions(vaaBytes, func(version uint8) bool { return version == 1 || version == 2 })
+	require.NoError(t, err)
+	assert.Equal(t, &vaa, vaa2)
+}
+
 func TestVerifySignatures(t *testing.T) {
 	// Generate some random private keys to sign with
 	privKey1, _ := ecdsa.GenerateKey(crypto.S256(), rand.Reader)
@@ -743,3 +797,29 @@ func TestIsTransfer(t *testing.T) {
 		})
 	}
 }
+
+func BenchmarkUnmarshal(b *testing.B) {
+	vaaBytes, err := hex.DecodeString("01000000000100e424aef95296cb0f2185f351086c7c0b9cd031d1288f0537d04ab20d5fc709416224b2bd9a8010a81988aa9cb38b378eb915f88b67e32a765928d948dc02077e00000102584a8d000000020000000000000000000000000290fb167208af455bb137780163b7b7a9a10c16000000000000000f0f01000000000000000000000000000000000000000000000000000000002b369f40000000000000000000000000ddb64fe46a91d46ee29420539fc25fd07c5fea3e000221c175fcd8e3a19fe2e0deae96534f0f4e6a896f4df0e3ec5345fe27ac3f63f000010000000000000000000000000000000000000000000000000000000000000000")
+	require.NoError(b, err)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := Unmarshal(vaaBytes); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeTransferPayloadHdr(b *testing.B) {
+	payload, err := hex.DecodeString("01000000000000000000000000000000000000000000000000000000002b369f40000000000000000000000000ddb64fe46a91d46ee29420539fc25fd07c5fea3e000221c175fcd8e3a19fe2e0deae96534f0f4e6a896f4df0e3ec5345fe27ac3f63f000010000000000000000000000000000000000000000000000000000000000000000")
+	require.NoError(b, err)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := DecodeTransferPayloadHdr(payload); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,312 @@
+package vaa
+
+import (
+	"crypto/ecdsa"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"math"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The test vectors in testdata/vectors.json are shared with the Rust SDK and the Ethereum contract tests. They are
+// generated from vectorSources and can be regenerated with:
+//
+//	go test ./vaa -run TestVectors -update
+//
+// Changing an existing vector means the VAA encoding changed, which breaks every other implementation.
+var updateVectors = flag.Bool("update", false, "regenerate testdata/vectors.json")
+
+const vectorsFile = "testdata/vectors.json"
+
+// The guardian keys used by the Ethereum contract tests.
+var vectorGuardianKeys = []string{
+	"cfb12303a19cde580bb4dd771639b0d26bc68353645571a8cff516ab2ee113a0",
+	"892330666a850761e7370376430bb8c2aa1494072d3bfeaed0c4fa3d5a9135fe",
+	"87b45997ea577b93073568f06fc4838cffc1d01f90fc4d57f936957f3c4d99fb",
+}
+
+type vectorSignature struct {
+	GuardianIndex uint8  `json:"guardian_index"`
+	Signature     string `json:"signature"`
+}
+
+type vector struct {
+	Name             string            `json:"name"`
+	Description      string            `json:"description"`
+	Bytes            string            `json:"bytes"`
+	Version          uint8             `json:"version"`
+	GuardianSetIndex uint32            `json:"guardian_set_index"`
+	Signatures       []vectorSignature `json:"signatures"`
+	Timestamp        uint32            `json:"timestamp"`
+	Nonce            uint32            `json:"nonce"`
+	EmitterChain     uint16            `json:"emitter_chain"`
+	EmitterAddress   string            `json:"emitter_address"`
+	// Encoded as a string since not every JSON parser handles 64-bit integers.
+	Sequence         uint64   `json:"sequence,string"`
+	ConsistencyLevel uint8    `json:"consistency_level"`
+	Payload          string   `json:"payload"`
+	Body             string   `json:"body"`
+	BodyHash         string   `json:"body_hash"`
+	Digest           string   `json:"digest"`
+	GuardianSet      []string `json:"guardian_set"`
+	ValidSignatures  bool     `json:"valid_signatures"`
+}
+
+type vectorCorpus struct {
+	Vectors []vector `json:"vectors"`
+}
+
+type vectorSource struct {
+	name        string
+	description string
+	vaa         VAA
+	// Indices of vectorGuardianKeys that sign the VAA
+	signers []int
+	// Applied after signing
+	tamper func(v *VAA)
+}
+
+func vectorSources(t *testing.T) []vectorSource {
+	tokenBridge, err := StringToAddress("0x3ee18B2214AFF97000D974cf647E7C347E8fa585")
+	require.NoError(t, err)
+
+	transfer, err := hex.DecodeString("01" +
+		"00000000000000000000000000000000000000000000000000000000000f4240" +
+		"000000000000000000000000c02aaa39b223fe8d0a0e5c4f27ead9083c756cc2" +
+		"0002" +
+		"0000000000000000000000000000000000000000000000000000000000000eee" +
+		"0001" +
+		"0000000000000000000000000000000000000000000000000000000000000000")
+	require.NoError(t, err)
+
+	return []vectorSource{
+		{
+			name:        "core_contract_upgrade",
+			description: "Core contract upgrade governance VAA signed by the single devnet guardian",
+			vaa: VAA{
+				Version:          1,
+				GuardianSetIndex: 0,
+				Timestamp:        time.Unix(1, 0),
+				Nonce:            1,
+				EmitterChain:     ChainIDSolana,
+				EmitterAddress:   GovernanceEmitter,
+				Sequence:         20716538,
+				ConsistencyLevel: 0,
+				Payload:          BodyContractUpgrade{ChainID: ChainIDEthereum, NewContract: Address{31: 0x04}}.Serialize(),
+			},
+			signers: []int{0},
+		},
+		{
+			name:        "token_transfer",
+			description: "Token bridge transfer from Ethereum signed by three guardians",
+			vaa: VAA{
+				Version:          1,
+				GuardianSetIndex: 1,
+				Timestamp:        time.Unix(1660000000, 0),
+				Nonce:            42,
+				EmitterChain:     ChainIDEthereum,
+				EmitterAddress:   tokenBridge,
+				Sequence:         1,
+				ConsistencyLevel: 15,
+				Payload:          transfer,
+			},
+			signers: []int{0, 1, 2},
+		},
+		{
+			name:        "guardian_set_update",
+			description: "Guardian set update governance VAA signed by a quorum of two out of three guardians",
+			vaa: VAA{
+				Version:          1,
+				GuardianSetIndex: 1,
+				Timestamp:        time.Unix(1660000001, 0),
+				Nonce:            0,
+				EmitterChain:     ChainIDSolana,
+				EmitterAddress:   GovernanceEmitter,
+				Sequence:         2,
+				ConsistencyLevel: 32,
+				Payload: BodyGuardianSetUpdate{
+					Keys:     []common.Address{common.HexToAddress("0xbeFA429d57cD18b7F8A4d91A2da9AB4AF05d0FBe")},
+					NewIndex: 2,
+				}.Serialize(),
+			},
+			signers: []int{0, 2},
+		},
+		{
+			name:        "unsigned",
+			description: "VAA without signatures and with a single byte payload",
+			vaa: VAA{
+				Version:          1,
+				GuardianSetIndex: 0,
+				Timestamp:        time.Unix(0, 0),
+				EmitterChain:     ChainIDBSC,
+				EmitterAddress:   Address{0x01},
+				Payload:          []byte{0x00},
+			},
+		},
+		{
+			name:        "max_values",
+			description: "VAA with every integer field at its maximum",
+			vaa: VAA{
+				Version:          1,
+				GuardianSetIndex: math.MaxUint32,
+				Timestamp:        time.Unix(math.MaxUint32, 0),
+				Nonce:            math.MaxUint32,
+				EmitterChain:     ChainIDOasis,
+				EmitterAddress:   Address{0: 0xff, 31: 0xff},
+				Sequence:         math.MaxUint64,
+				ConsistencyLevel: math.MaxUint8,
+				Payload:          []byte{0xff},
+			},
+			signers: []int{2},
+		},
+		{
+			name:        "tampered_signature",
+			description: "Token transfer whose second signature was modified after signing, which must fail verification",
+			vaa: VAA{
+				Version:          1,
+				GuardianSetIndex: 1,
+				Timestamp:        time.Unix(1660000000, 0),
+				Nonce:            43,
+				EmitterChain:     ChainIDEthereum,
+				EmitterAddress:   tokenBridge,
+				Sequence:         3,
+				ConsistencyLevel: 15,
+				Payload:          transfer,
+			},
+			signers: []int{0, 1, 2},
+			tamper: func(v *VAA) {
+				v.Signatures[1].Signature[10] ^= 0x01
+			},
+		},
+	}
+}
+
+func vectorGuardians(t *testing.T) ([]*ecdsa.PrivateKey, []string) {
+	keys := make([]*ecdsa.PrivateKey, len(vectorGuardianKeys))
+	addrs := make([]string, len(vectorGuardianKeys))
+	for i, k := range vectorGuardianKeys {
+		key, err := crypto.HexToECDSA(k)
+		require.NoError(t, err)
+		keys[i] = key
+		addrs[i] = crypto.PubkeyToAddress(key.PublicKey).Hex()
+	}
+	return keys, addrs
+}
+
+func generateVectors(t *testing.T) vectorCorpus {
+	keys, addrs := vectorGuardians(t)
+
+	var corpus vectorCorpus
+	for _, src := range vectorSources(t) {
+		v := src.vaa
+		for _, i := range src.signers {
+			v.AddSignature(keys[i], uint8(i))
+		}
+		if src.tamper != nil {
+			src.tamper(&v)
+		}
+
+		b, err := v.Marshal()
+		require.NoError(t, err)
+		body := v.serializeBody()
+
+		sigs := make([]vectorSignature, 0, len(v.Signatures))
+		for _, sig := range v.Signatures {
+			sigs = append(sigs, vectorSignature{GuardianIndex: sig.Index, Signature: hex.EncodeToString(sig.Signature[:])})
+		}
+
+		corpus.Vectors = append(corpus.Vectors, vector{
+			Name:             src.name,
+			Description:      src.description,
+			Bytes:            hex.EncodeToString(b),
+			Version:          v.Version,
+			GuardianSetIndex: v.GuardianSetIndex,
+			Signatures:       sigs,
+			Timestamp:        uint32(v.Timestamp.Unix()),
+			Nonce:            v.Nonce,
+			EmitterChain:     uint16(v.EmitterChain),
+			EmitterAddress:   hex.EncodeToString(v.EmitterAddress[:]),
+			Sequence:         v.Sequence,
+			ConsistencyLevel: v.ConsistencyLevel,
+			Payload:          hex.EncodeToString(v.Payload),
+			Body:             hex.EncodeToString(body),
+			BodyHash:         hex.EncodeToString(crypto.Keccak256(body)),
+			Digest:           v.HexDigest(),
+			GuardianSet:      addrs,
+			ValidSignatures:  src.tamper == nil,
+		})
+	}
+	return corpus
+}
+
+func readVectors(t *testing.T) vectorCorpus {
+	b, err := os.ReadFile(vectorsFile)
+	require.NoError(t, err)
+	var corpus vectorCorpus
+	require.NoError(t, json.Unmarshal(b, &corpus))
+	return corpus
+}
+
+// TestVectors checks that the checked-in vectors match what this implementation produces.
+func TestVectors(t *testing.T) {
+	generated := generateVectors(t)
+
+	if *updateVectors {
+		b, err := json.MarshalIndent(generated, "", "  ")
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(vectorsFile, append(b, '\n'), 0644))
+	}
+
+	assert.Equal(t, generated, readVectors(t))
+}
+
+// TestVectorsRoundTrip parses every vector and checks the decoded fields, the re-encoding, the digests and the
+// signatures against the values in the corpus.
+func TestVectorsRoundTrip(t *testing.T) {
+	for _, tc := range readVectors(t).Vectors {
+		t.Run(tc.Name, func(t *testing.T) {
+			b, err := hex.DecodeString(tc.Bytes)
+			require.NoError(t, err)
+
+			v, err := Unmarshal(b)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.Version, v.Version)
+			assert.Equal(t, tc.GuardianSetIndex, v.GuardianSetIndex)
+			require.Len(t, v.Signatures, len(tc.Signatures))
+			for i, sig := range tc.Signatures {
+				assert.Equal(t, sig.GuardianIndex, v.Signatures[i].Index)
+				assert.Equal(t, sig.Signature, hex.EncodeToString(v.Signatures[i].Signature[:]))
+			}
+			assert.Equal(t, tc.Timestamp, uint32(v.Timestamp.Unix()))
+			assert.Equal(t, tc.Nonce, v.Nonce)
+			assert.Equal(t, tc.EmitterChain, uint16(v.EmitterChain))
+			assert.Equal(t, tc.EmitterAddress, hex.EncodeToString(v.EmitterAddress[:]))
+			assert.Equal(t, tc.Sequence, v.Sequence)
+			assert.Equal(t, tc.ConsistencyLevel, v.ConsistencyLevel)
+			assert.Equal(t, tc.Payload, hex.EncodeToString(v.Payload))
+
+			assert.Equal(t, tc.Body, hex.EncodeToString(v.serializeBody()))
+			assert.Equal(t, tc.BodyHash, hex.EncodeToString(crypto.Keccak256(v.serializeBody())))
+			assert.Equal(t, tc.Digest, v.HexDigest())
+
+			marshaled, err := v.Marshal()
+			require.NoError(t, err)
+			assert.Equal(t, tc.Bytes, hex.EncodeToString(marshaled))
+
+			guardians := make([]common.Address, len(tc.GuardianSet))
+			for i, addr := range tc.GuardianSet {
+				guardians[i] = common.HexToAddress(addr)
+			}
+			assert.Equal(t, tc.ValidSignatures, v.VerifySignatures(guardians))
+		})
+	}
+}
@@ -91,11 +91,14 @@ import (
 	"github.com/tendermint/spm/cosmoscmd"
 	"github.com/tendermint/spm/openapiconsole"
 
+	"github.com/wormhole-foundation/wormhole-chain/app/wasm_handlers"
 	"github.com/wormhole-foundation/wormhole-chain/docs"
 	tokenbridgemodule "github.com/wormhole-foundation/wormhole-chain/x/tokenbridge"
+	tokenbridgeante "github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/ante"
 	tokenbridgemodulekeeper "github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/keeper"
 	tokenbridgemoduletypes "github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
 	wormholemodule "github.com/wormhole-foundation/wormhole-chain/x/wormhole"
+	wormholeante "github.com/wormhole-foundation/wormhole-chain/x/wormhole/ante"
 	wormholeclient "github.com/wormhole-foundation/wormhole-chain/x/wormhole/client"
 	wormholemodulekeeper "github.com/wormhole-foundation/wormhole-chain/x/wormhole/keeper"
 	wormholemoduletypes "github.com/wormhole-foundation/wormhole-chain/x/wormhole/types"
@@ -120,6 +123,16 @@ func getGovProposalHandlers() []govclient.ProposalHandler {
 		upgradeclient.CancelProposalHandler,
 		wormholeclient.GuardianSetUpdateProposalHandler,
 		wormholeclient.WormholeGovernanceMessageProposalHandler,
+		wormholeclient.EmitterRateLimitProposalHandler,
+		wormholeclient.AcceptedVAAVersionsProposalHandler,
+		wormholeclient.MessageFeesProposalHandler,
+		wormholeclient.VAALimitsProposalHandler,
+		wormholeclient.MinGuardianSetIndexProposalHandler,
+		wormholeclient.VAAExecutionLimitProposalHandler,
+		wormholeclient.GovernanceSubmittersProposalHandler,
+		wormholeclient.FeeMarketProposalHandler,
+		wormholeclient.ObservationRequestProposalHandler,
+		wormholeclient.QuorumThresholdProposalHandler,
 		// this line is used by starport scaffolding # stargate/app/govProposalHandler
 	)
 
@@ -232,8 +245,10 @@ type App struct {
 	FeeGrantKeeper   feegrantkeeper.Keeper
 
 	// make scoped keepers public for test purposes
-	ScopedIBCKeeper      capabilitykeeper.ScopedKeeper
-	ScopedTransferKeeper capabilitykeeper.ScopedKeeper
+	ScopedIBCKeeper         capabilitykeeper.ScopedKeeper
+	ScopedTransferKeeper    capabilitykeeper.ScopedKeeper
+	ScopedWormholeKeeper    capabilitykeeper.ScopedKeeper
+	ScopedTokenbridgeKeeper capabilitykeeper.ScopedKeeper
 
 	WormholeKeeper wormholemodulekeeper.Keeper
 
@@ -244,6 +259,9 @@ type App struct {
 
 	// the module manager
 	mm *module.Manager
+
+	// configurator runs the module migrations of software upgrades
+	configurator module.Configurator
 }
 
 // New returns a reference to an initialized Gaia.
@@ -281,7 +299,7 @@ func New(
 		// this line is used by starport scaffolding # stargate/app/storeKey
 		wasm.StoreKey,
 	)
-	tkeys := sdk.NewTransientStoreKeys(paramstypes.TStoreKey)
+	tkeys := sdk.NewTransientStoreKeys(paramstypes.TStoreKey, wormholemoduletypes.TStoreKey)
 	memKeys := sdk.NewMemoryStoreKeys(capabilitytypes.MemStoreKey)
 
 	app := &App{
@@ -306,6 +324,8 @@ func New(
 	// grant capabilities for the ibc and ibc-transfer modules
 	scopedIBCKeeper := app.CapabilityKeeper.ScopeToModule(ibchost.ModuleName)
 	scopedTransferKeeper := app.CapabilityKeeper.ScopeToModule(ibctransfertypes.ModuleName)
+	scopedWormholeKeeper := app.CapabilityKeeper.ScopeToModule(wormholemoduletypes.ModuleName)
+	scopedTokenbridgeKeeper := app.CapabilityKeeper.ScopeToModule(tokenbridgemoduletypes.ModuleName)
 	// this line is used by starport scaffolding # stargate/app/scopedKeeper
 	scopedWasmKeeper := app.CapabilityKeeper.ScopeToModule(wasm.ModuleName)
 
@@ -321,9 +341,11 @@ func New(
 		appCodec,
 		keys[wormholemoduletypes.StoreKey],
 		keys[wormholemoduletypes.MemStoreKey],
+		tkeys[wormholemoduletypes.TStoreKey],
 
 		app.AccountKeeper,
 		app.BankKeeper,
+		scopedWormholeKeeper,
 	)
 
 	wormholeModule := wormholemodule.NewAppModule(appCodec, app.WormholeKeeper)
@@ -396,7 +418,16 @@ func New(
 		app.AccountKeeper,
 		app.BankKeeper,
 		app.WormholeKeeper,
+		app.UpgradeKeeper,
+		scopedTokenbridgeKeeper,
 	)
+	// The tokenbridge logs can be restricted with e.g. `log_level = "error"` in the [tokenbridge] section of app.toml
+	if level := cast.ToString(appOpts.Get("tokenbridge.log_level")); level != "" {
+		if err := app.TokenbridgeKeeper.SetLogLevel(level); err != nil {
+			panic(err)
+		}
+	}
+	app.TokenbridgeKeeper.SetIBCKeepers(app.TransferKeeper, app.IBCKeeper.ChannelKeeper)
 	tokenbridgeModule := tokenbridgemodule.NewAppModule(appCodec, app.TokenbridgeKeeper)
 
 	app.GovKeeper = govkeeper.NewKeeper(
@@ -408,6 +439,10 @@ func New(
 	// if we want to allow any custom callbacks
 	supportedFeatures := "iterator,staking,stargate"
 	wasmDir := filepath.Join(homePath, "data")
+	// Contracts can only send the stargate queries of the accept list, e.g. to simulate the redemption of a VAA
+	wasmOpts := append(GetWasmOpts(appOpts), wasmkeeper.WithQueryPlugins(&wasmkeeper.QueryPlugins{
+		Stargate: wasm_handlers.StargateQuerier(app.GRPCQueryRouter(), appCodec, wasm_handlers.StargateAcceptList),
+	}))
 	// Instantiate wasm keeper with stubs for other modules as we do not need
 	// wasm to be able to write to other modules.
 	app.wasmKeeper = wasm.NewKeeper(
@@ -428,7 +463,7 @@ func New(
 		wasm.DefaultWasmConfig(),
 		// wasmConfig.ToWasmConfig(),
 		supportedFeatures,
-		GetWasmOpts(appOpts)...,
+		wasmOpts...,
 	)
 	permissionedWasmKeeper := wasmkeeper.NewDefaultPermissionKeeper(app.wasmKeeper)
 	app.WormholeKeeper.SetWasmdKeeper(permissionedWasmKeeper)
@@ -437,7 +472,7 @@ func New(
 
 	// Create static IBC router, add transfer route, then set and seal it
 	ibcRouter := ibcporttypes.NewRouter()
-	ibcRouter.AddRoute(ibctransfertypes.ModuleName, transferIBCModule)
+	ibcRouter.AddRoute(ibctransfertypes.ModuleName, tokenbridgemodule.NewGatewayIBCModule(transferIBCModule, app.TokenbridgeKeeper))
 	// this line is used by starport scaffolding # ibc/app/router
 	app.IBCKeeper.SetRouter(ibcRouter)
 
@@ -505,9 +540,12 @@ func New(
 		wasm.ModuleName,
 	)
 
+	// NOTE: The wormhole module must occur before staking so that voting power
+	// follows changes to the consensus guardian set in the same block
 	app.mm.SetOrderEndBlockers(
 		crisistypes.ModuleName,
 		govtypes.ModuleName,
+		wormholemoduletypes.ModuleName,
 		stakingtypes.ModuleName,
 		capabilitytypes.ModuleName,
 		authtypes.ModuleName,
@@ -523,7 +561,6 @@ func New(
 		upgradetypes.ModuleName,
 		ibchost.ModuleName,
 		ibctransfertypes.ModuleName,
-		wormholemoduletypes.ModuleName,
 		tokenbridgemoduletypes.ModuleName,
 		// this line is used by starport scaffolding # stargate/app/endBlockers
 		wasm.ModuleName,
@@ -563,7 +600,9 @@ func New(
 
 	app.mm.RegisterInvariants(&app.CrisisKeeper)
 	app.mm.RegisterRoutes(app.Router(), app.QueryRouter(), encodingConfig.Amino)
-	app.mm.RegisterServices(module.NewConfigurator(app.appCodec, app.MsgServiceRouter(), app.GRPCQueryRouter()))
+	app.configurator = module.NewConfigurator(app.appCodec, app.MsgServiceRouter(), app.GRPCQueryRouter())
+	app.mm.RegisterServices(app.configurator)
+	app.setUpgradeHandlers()
 
 	// initialize stores
 	app.MountKVStores(keys)
@@ -587,7 +626,7 @@ func New(
 		panic(err)
 	}
 
-	app.SetAnteHandler(anteHandler)
+	app.SetAnteHandler(wormholeante.NewAnteHandler(app.WormholeKeeper, tokenbridgeante.NewAnteHandler(app.TokenbridgeKeeper, anteHandler)))
 	app.SetEndBlocker(app.EndBlocker)
 
 	if loadLatest {
@@ -598,6 +637,8 @@ func New(
 
 	app.ScopedIBCKeeper = scopedIBCKeeper
 	app.ScopedTransferKeeper = scopedTransferKeeper
+	app.ScopedWormholeKeeper = scopedWormholeKeeper
+	app.ScopedTokenbridgeKeeper = scopedTokenbridgeKeeper
 	// this line is used by starport scaffolding # stargate/app/beforeInitReturn
 	app.scopedWasmKeeper = scopedWasmKeeper
 
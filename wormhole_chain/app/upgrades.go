@@ -0,0 +1,39 @@
+package app
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/module"
+	upgradetypes "github.com/cosmos/cosmos-sdk/x/upgrade/types"
+)
+
+// upgrades are the names of the software upgrades this binary can apply, e.g.
+// when scheduled by a token bridge governance VAA. Each of them runs the
+// migrations of every module whose consensus version increased.
+var upgrades = []string{
+	// tokenbridge v3: custody balances for coins locked before they were tracked
+	"tokenbridge-custody",
+	// wormhole v3: upgrade records for guardian sets installed before they were recorded
+	"wormhole-guardian-set-upgrades",
+	// tokenbridge v4: legacy wrapped denoms merged into their canonical denom
+	"tokenbridge-legacy-denoms",
+}
+
+func (app *App) setUpgradeHandlers() {
+	for _, name := range upgrades {
+		app.UpgradeKeeper.SetUpgradeHandler(name, func(ctx sdk.Context, plan upgradetypes.Plan, fromVM module.VersionMap) (module.VersionMap, error) {
+			vm, err := app.mm.RunMigrations(ctx, app.configurator, fromVM)
+			if err != nil {
+				return nil, err
+			}
+			// chains started before emitters were bound at genesis have no token bridge emitter yet
+			if err := app.TokenbridgeKeeper.BindEmitter(ctx); err != nil {
+				return nil, err
+			}
+			// the quorum threshold set by governance has to be within the bounds of the new binary
+			if err := app.WormholeKeeper.ValidateQuorumThreshold(ctx); err != nil {
+				return nil, err
+			}
+			return vm, nil
+		})
+	}
+}
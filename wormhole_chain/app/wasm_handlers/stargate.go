@@ -0,0 +1,46 @@
+package wasm_handlers
+
+import (
+	"fmt"
+
+	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+	tokenbridgetypes "github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+)
+
+// StargateAcceptList maps the gRPC queries contracts may send as stargate queries to a constructor of their response.
+// Stargate queries are disabled by wasmd otherwise, as responses of arbitrary queries are not guaranteed to be
+// deterministic.
+var StargateAcceptList = map[string]func() codec.ProtoMarshaler{
+	"/wormhole_foundation.wormholechain.tokenbridge.Query/SimulateExecuteVAA": func() codec.ProtoMarshaler {
+		return &tokenbridgetypes.QuerySimulateExecuteVAAResponse{}
+	},
+}
+
+// StargateQuerier answers the stargate queries of contracts in acceptList through the query router. Responses are
+// JSON encoded, as contracts decode them with serde.
+func StargateQuerier(queryRouter *baseapp.GRPCQueryRouter, cdc codec.Codec, acceptList map[string]func() codec.ProtoMarshaler) func(ctx sdk.Context, request *wasmvmtypes.StargateQuery) ([]byte, error) {
+	return func(ctx sdk.Context, request *wasmvmtypes.StargateQuery) ([]byte, error) {
+		newResponse, accepted := acceptList[request.Path]
+		if !accepted {
+			return nil, wasmvmtypes.UnsupportedRequest{Kind: fmt.Sprintf("stargate query %s is not allowed", request.Path)}
+		}
+		route := queryRouter.Route(request.Path)
+		if route == nil {
+			return nil, wasmvmtypes.UnsupportedRequest{Kind: fmt.Sprintf("no route to stargate query %s", request.Path)}
+		}
+		res, err := route(ctx, abci.RequestQuery{Data: request.Data, Path: request.Path})
+		if err != nil {
+			return nil, err
+		}
+
+		response := newResponse()
+		if err := cdc.Unmarshal(res.Value, response); err != nil {
+			return nil, err
+		}
+		return cdc.MarshalJSON(response)
+	}
+}
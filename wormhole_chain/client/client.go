@@ -0,0 +1,182 @@
+// Package client is a Go client for relayers and other programs that submit VAAs and token bridge transfers to
+// wormhole chain. It signs and broadcasts transactions with the cosmos-sdk client/tx package, wraps the wormhole and
+// tokenbridge queries, fetches signed VAAs from a guardian's public API and waits for VAAs to be redeemed.
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	sdkclient "github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	tbkeeper "github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/keeper"
+	tbtypes "github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+	whkeeper "github.com/wormhole-foundation/wormhole-chain/x/wormhole/keeper"
+	whtypes "github.com/wormhole-foundation/wormhole-chain/x/wormhole/types"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// Client signs transactions with the key named by the From field of its client context, and sends queries to the
+// node of that context. It tracks the sequence of the signing account between transactions, so it must not be used
+// concurrently.
+type Client struct {
+	clientCtx sdkclient.Context
+	txf       tx.Factory
+
+	Wormhole    whtypes.QueryClient
+	Tokenbridge tbtypes.QueryClient
+
+	// RedemptionPollInterval is the interval at which WaitForRedemption polls for the redemption.
+	RedemptionPollInterval time.Duration
+}
+
+// New returns a client that broadcasts with clientCtx and builds transactions with txf. The account number and
+// sequence of txf are queried when they are not set, and the gas is simulated when txf has SimulateAndExecute set.
+func New(clientCtx sdkclient.Context, txf tx.Factory) *Client {
+	return &Client{
+		clientCtx:   clientCtx,
+		txf:         txf,
+		Wormhole:    whtypes.NewQueryClient(clientCtx),
+		Tokenbridge: tbtypes.NewQueryClient(clientCtx),
+
+		RedemptionPollInterval: DefaultRedemptionPollInterval,
+	}
+}
+
+// Address returns the address transactions are signed with.
+func (c *Client) Address() sdk.AccAddress {
+	return c.clientCtx.GetFromAddress()
+}
+
+// BroadcastMsgs signs msgs in a single transaction and broadcasts it with the broadcast mode of the client context.
+// An error is returned if the transaction is rejected, including when it fails in CheckTx or DeliverTx.
+func (c *Client) BroadcastMsgs(msgs ...sdk.Msg) (*sdk.TxResponse, error) {
+	for _, msg := range msgs {
+		if err := msg.ValidateBasic(); err != nil {
+			return nil, err
+		}
+	}
+
+	txf, err := c.txf.Prepare(c.clientCtx)
+	if err != nil {
+		return nil, err
+	}
+	if txf.SimulateAndExecute() {
+		_, adjusted, err := tx.CalculateGas(c.clientCtx, txf, msgs...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to simulate transaction: %w", err)
+		}
+		txf = txf.WithGas(adjusted)
+	}
+
+	unsignedTx, err := tx.BuildUnsignedTx(txf, msgs...)
+	if err != nil {
+		return nil, err
+	}
+	unsignedTx.SetFeeGranter(c.clientCtx.GetFeeGranterAddress())
+	if err := tx.Sign(txf, c.clientCtx.GetFromName(), unsignedTx, true); err != nil {
+		return nil, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+
+	txBytes, err := c.clientCtx.TxConfig.TxEncoder()(unsignedTx.GetTx())
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.clientCtx.BroadcastTx(txBytes)
+	if err == nil && res.Code != 0 {
+		err = fmt.Errorf("transaction %s failed with code %d (%s): %s", res.TxHash, res.Code, res.Codespace, res.RawLog)
+	}
+	if err != nil {
+		// The sequence may or may not have been used, so query it again for the next transaction.
+		c.txf = txf.WithSequence(0)
+		return res, err
+	}
+
+	c.txf = txf.WithSequence(txf.Sequence() + 1)
+	return res, nil
+}
+
+// SubmitVAA executes a signed VAA on wormhole chain. Token bridge and core governance VAAs are submitted with the
+// governance message of their module, and any other VAA with the token bridge ExecuteVAA message.
+func (c *Client) SubmitVAA(vaaBytes []byte) (*sdk.TxResponse, error) {
+	v, err := whkeeper.ParseVAA(vaaBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	signer := c.Address().String()
+	var msg sdk.Msg
+	switch {
+	case bytes.HasPrefix(v.Payload, tbkeeper.TokenBridgeModule[:]):
+		msg = tbtypes.NewMsgExecuteGovernanceVAA(signer, vaaBytes)
+	case bytes.HasPrefix(v.Payload, vaa.CoreModule):
+		msg = whtypes.NewMsgExecuteGovernanceVAA(vaaBytes, signer)
+	default:
+		msg = tbtypes.NewMsgExecuteVAA(signer, vaaBytes)
+	}
+	return c.BroadcastMsgs(msg)
+}
+
+// Transfer sends amount to toAddress on toChain through the token bridge, paying fee out of the amount to the
+// relayer on the target chain.
+func (c *Client) Transfer(amount sdk.Coin, toChain vaa.ChainID, toAddress vaa.Address, fee sdk.Coin) (*sdk.TxResponse, error) {
+	return c.BroadcastMsgs(tbtypes.NewMsgTransfer(c.Address().String(), amount, uint16(toChain), toAddress[:], fee))
+}
+
+// AttestToken publishes the metadata of denom, so that it can be created as a wrapped asset on other chains.
+func (c *Client) AttestToken(denom string) (*sdk.TxResponse, error) {
+	return c.BroadcastMsgs(tbtypes.NewMsgAttestToken(c.Address().String(), denom))
+}
+
+// WormholeConfig returns the config of the wormhole module.
+func (c *Client) WormholeConfig(ctx context.Context) (whtypes.Config, error) {
+	res, err := c.Wormhole.Config(ctx, &whtypes.QueryGetConfigRequest{})
+	if err != nil {
+		return whtypes.Config{}, err
+	}
+	return res.Config, nil
+}
+
+// GuardianSet returns the guardian set with the given index.
+func (c *Client) GuardianSet(ctx context.Context, index uint32) (whtypes.GuardianSet, error) {
+	res, err := c.Wormhole.GuardianSet(ctx, &whtypes.QueryGetGuardianSetRequest{Index: index})
+	if err != nil {
+		return whtypes.GuardianSet{}, err
+	}
+	return res.GuardianSet, nil
+}
+
+// LatestGuardianSet returns the guardian set with the highest index.
+func (c *Client) LatestGuardianSet(ctx context.Context) (whtypes.GuardianSet, error) {
+	res, err := c.Wormhole.LatestGuardianSetIndex(ctx, &whtypes.QueryLatestGuardianSetIndexRequest{})
+	if err != nil {
+		return whtypes.GuardianSet{}, err
+	}
+	return c.GuardianSet(ctx, res.LatestGuardianSetIndex)
+}
+
+// TokenbridgeConfig returns the config of the tokenbridge module.
+func (c *Client) TokenbridgeConfig(ctx context.Context) (tbtypes.Config, error) {
+	res, err := c.Tokenbridge.Config(ctx, &tbtypes.QueryGetConfigRequest{})
+	if err != nil {
+		return tbtypes.Config{}, err
+	}
+	return res.Config, nil
+}
+
+// ChainRegistration returns the token bridge emitter registered for chain.
+func (c *Client) ChainRegistration(ctx context.Context, chain vaa.ChainID) (tbtypes.ChainRegistration, error) {
+	res, err := c.Tokenbridge.ChainRegistration(ctx, &tbtypes.QueryGetChainRegistrationRequest{ChainID: uint32(chain)})
+	if err != nil {
+		return tbtypes.ChainRegistration{}, err
+	}
+	return res.ChainRegistration, nil
+}
+
+// CustodyBalances returns the balances held by the tokenbridge module account.
+func (c *Client) CustodyBalances(ctx context.Context) (*tbtypes.QueryCustodyBalancesResponse, error) {
+	return c.Tokenbridge.CustodyBalances(ctx, &tbtypes.QueryCustodyBalancesRequest{})
+}
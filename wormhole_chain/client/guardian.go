@@ -0,0 +1,90 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// ErrVAANotFound is returned by FetchSignedVAA when the guardian has not observed a quorum for the VAA yet.
+var ErrVAANotFound = errors.New("signed VAA not found")
+
+// GuardianClient fetches signed VAAs from the public REST API of a guardian, such as
+// https://wormhole-v2-mainnet-api.certus.one.
+type GuardianClient struct {
+	endpoint   string
+	httpClient *http.Client
+}
+
+// NewGuardianClient returns a client of the guardian public API at endpoint. A nil httpClient uses
+// http.DefaultClient.
+func NewGuardianClient(endpoint string, httpClient *http.Client) *GuardianClient {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &GuardianClient{
+		endpoint:   strings.TrimSuffix(endpoint, "/"),
+		httpClient: httpClient,
+	}
+}
+
+// FetchSignedVAA returns the signed VAA of the message emitted by emitter on chain with the given sequence.
+func (g *GuardianClient) FetchSignedVAA(ctx context.Context, chain vaa.ChainID, emitter vaa.Address, sequence uint64) ([]byte, error) {
+	id := vaa.MessageID{EmitterChain: chain, EmitterAddress: emitter, Sequence: sequence}
+	url := fmt.Sprintf("%s/v1/signed_vaa/%s", g.endpoint, id)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrVAANotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("guardian returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	var res struct {
+		VaaBytes []byte `json:"vaaBytes"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return nil, fmt.Errorf("failed to decode guardian response: %w", err)
+	}
+	if len(res.VaaBytes) == 0 {
+		return nil, errors.New("guardian returned an empty VAA")
+	}
+	return res.VaaBytes, nil
+}
+
+// PollSignedVAA fetches the signed VAA of a message every interval until the guardian returns it or ctx is done.
+// Errors other than ErrVAANotFound stop the polling.
+func (g *GuardianClient) PollSignedVAA(ctx context.Context, chain vaa.ChainID, emitter vaa.Address, sequence uint64, interval time.Duration) ([]byte, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		vaaBytes, err := g.FetchSignedVAA(ctx, chain, emitter, sequence)
+		if !errors.Is(err, ErrVAANotFound) {
+			return vaaBytes, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole-chain/client"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+func TestPollSignedVAA(t *testing.T) {
+	emitter := vaa.Address{31: 0x04}
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v1/signed_vaa/2/"+emitter.String()+"/7", r.URL.Path)
+		if atomic.AddInt32(&requests, 1) < 3 {
+			http.Error(w, `{"code":5,"message":"requested VAA not found in store"}`, http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"vaaBytes":"AQID"}`))
+	}))
+	defer srv.Close()
+
+	g := client.NewGuardianClient(srv.URL+"/", nil)
+	vaaBytes, err := g.PollSignedVAA(context.Background(), vaa.ChainIDEthereum, emitter, 7, time.Millisecond)
+	require.NoError(t, err)
+	require.Equal(t, []byte{1, 2, 3}, vaaBytes)
+	require.EqualValues(t, 3, atomic.LoadInt32(&requests))
+}
+
+func TestPollSignedVAAStops(t *testing.T) {
+	notFound := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	}))
+	defer notFound.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := client.NewGuardianClient(notFound.URL, nil).PollSignedVAA(ctx, vaa.ChainIDEthereum, vaa.Address{}, 1, time.Millisecond)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer failing.Close()
+
+	_, err = client.NewGuardianClient(failing.URL, nil).PollSignedVAA(context.Background(), vaa.ChainIDEthereum, vaa.Address{}, 1, time.Millisecond)
+	require.ErrorContains(t, err, "503")
+}
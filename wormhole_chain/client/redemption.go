@@ -0,0 +1,93 @@
+package client
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tbtypes "github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+	whtypes "github.com/wormhole-foundation/wormhole-chain/x/wormhole/types"
+)
+
+// ErrRedemptionTimeout is returned by WaitForRedemption when the VAA was not redeemed in time.
+var ErrRedemptionTimeout = errors.New("timed out waiting for redemption")
+
+const (
+	// DefaultRedemptionTimeout bounds WaitForRedemption when its context has no deadline.
+	DefaultRedemptionTimeout = 2 * time.Minute
+	// DefaultRedemptionPollInterval is the default interval at which WaitForRedemption queries the replay protection.
+	DefaultRedemptionPollInterval = 2 * time.Second
+)
+
+// WaitForRedemption blocks until the token bridge VAA with the given hex digest has been executed on wormhole chain,
+// by anyone. It subscribes to the EventVAAConsumed of the VAA over the websocket of the node, and also polls the replay
+// protection of the token bridge every RedemptionPollInterval, so it still returns when the node does not serve
+// events or the subscription drops. Without a deadline on ctx it gives up after DefaultRedemptionTimeout.
+func (c *Client) WaitForRedemption(ctx context.Context, digest string) error {
+	if b, err := hex.DecodeString(digest); err != nil || len(b) != 32 {
+		return fmt.Errorf("invalid VAA digest %q", digest)
+	}
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, DefaultRedemptionTimeout)
+		defer cancel()
+	}
+
+	// Subscribe before the first query, so that a redemption in between is not missed
+	events := c.subscribeVAAConsumed(ctx, digest)
+
+	ticker := time.NewTicker(c.RedemptionPollInterval)
+	defer ticker.Stop()
+	var lastErr error
+	for {
+		_, err := c.Tokenbridge.ReplayProtection(ctx, &tbtypes.QueryGetReplayProtectionRequest{Index: digest})
+		if err == nil {
+			return nil
+		}
+		// The query also fails while the VAA has not been redeemed, so errors are only reported on timeout.
+		lastErr = err
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: %s (last query error: %v)", ErrRedemptionTimeout, ctx.Err(), lastErr)
+		case _, ok := <-events:
+			if !ok {
+				events = nil
+			}
+		case <-ticker.C:
+		}
+	}
+}
+
+// subscribeVAAConsumed subscribes to the EventVAAConsumed of the VAA with the given digest until ctx is done. The
+// websocket of the node is connected if needed. A nil channel is returned if the node client does not support
+// subscriptions or subscribing fails, which leaves WaitForRedemption to polling.
+func (c *Client) subscribeVAAConsumed(ctx context.Context, digest string) <-chan ctypes.ResultEvent {
+	events, ok := c.clientCtx.Client.(rpcclient.EventsClient)
+	if !ok {
+		return nil
+	}
+	if node, ok := c.clientCtx.Client.(rpcclient.Client); ok && !node.IsRunning() {
+		if err := node.Start(); err != nil {
+			return nil
+		}
+	}
+
+	// Typed event attributes are JSON encoded, so the digest is matched with its quotes
+	query := fmt.Sprintf(`tm.event='Tx' AND %s.digest='"%s"'`, proto.MessageName(&whtypes.EventVAAConsumed{}), digest)
+	subscriber := "wormchain-client-redemption-" + digest
+	ch, err := events.Subscribe(ctx, subscriber, query)
+	if err != nil {
+		return nil
+	}
+	go func() {
+		<-ctx.Done()
+		_ = events.Unsubscribe(context.Background(), subscriber, query)
+	}()
+	return ch
+}
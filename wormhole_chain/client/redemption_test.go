@@ -0,0 +1,101 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	sdkclient "github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	"github.com/stretchr/testify/require"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	"github.com/wormhole-foundation/wormhole-chain/client"
+	tbtypes "github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+	"google.golang.org/grpc"
+)
+
+const testDigest = "0102030405060708091011121314151617181920212223242526272829303132"
+
+// replayProtectionClient reports VAAs as redeemed once redeemed is set.
+type replayProtectionClient struct {
+	tbtypes.QueryClient
+	redeemed int32
+	queries  int32
+}
+
+func (q *replayProtectionClient) ReplayProtection(ctx context.Context, req *tbtypes.QueryGetReplayProtectionRequest, opts ...grpc.CallOption) (*tbtypes.QueryGetReplayProtectionResponse, error) {
+	atomic.AddInt32(&q.queries, 1)
+	if atomic.LoadInt32(&q.redeemed) == 0 {
+		return nil, errors.New("not found")
+	}
+	return &tbtypes.QueryGetReplayProtectionResponse{}, nil
+}
+
+// eventsNode delivers the events sent on its channel to the single subscriber.
+type eventsNode struct {
+	rpcclient.Client
+	events chan ctypes.ResultEvent
+	query  string
+}
+
+func (n *eventsNode) IsRunning() bool { return true }
+
+func (n *eventsNode) Subscribe(ctx context.Context, subscriber, query string, outCapacity ...int) (<-chan ctypes.ResultEvent, error) {
+	n.query = query
+	return n.events, nil
+}
+
+func (n *eventsNode) Unsubscribe(ctx context.Context, subscriber, query string) error { return nil }
+
+func TestWaitForRedemptionPolling(t *testing.T) {
+	c := client.New(sdkclient.Context{}, tx.Factory{})
+	q := &replayProtectionClient{}
+	c.Tokenbridge = q
+	c.RedemptionPollInterval = time.Millisecond
+
+	go func() {
+		for atomic.LoadInt32(&q.queries) < 3 {
+			time.Sleep(time.Millisecond)
+		}
+		atomic.StoreInt32(&q.redeemed, 1)
+	}()
+	require.NoError(t, c.WaitForRedemption(context.Background(), testDigest))
+
+	require.Error(t, c.WaitForRedemption(context.Background(), "0102"))
+}
+
+func TestWaitForRedemptionEvent(t *testing.T) {
+	node := &eventsNode{events: make(chan ctypes.ResultEvent)}
+	c := client.New(sdkclient.Context{}.WithClient(node), tx.Factory{})
+	q := &replayProtectionClient{}
+	c.Tokenbridge = q
+	// Never poll, so that only the event triggers the query
+	c.RedemptionPollInterval = time.Hour
+
+	done := make(chan error)
+	go func() { done <- c.WaitForRedemption(context.Background(), testDigest) }()
+
+	for atomic.LoadInt32(&q.queries) < 1 {
+		time.Sleep(time.Millisecond)
+	}
+	require.True(t, strings.HasSuffix(node.query, `EventVAAConsumed.digest='"`+testDigest+`"'`), node.query)
+	atomic.StoreInt32(&q.redeemed, 1)
+	node.events <- ctypes.ResultEvent{}
+	require.NoError(t, <-done)
+}
+
+func TestWaitForRedemptionTimeout(t *testing.T) {
+	c := client.New(sdkclient.Context{}, tx.Factory{})
+	c.Tokenbridge = &replayProtectionClient{}
+	c.RedemptionPollInterval = time.Millisecond
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err := c.WaitForRedemption(ctx, testDigest)
+	require.ErrorIs(t, err, client.ErrRedemptionTimeout)
+	require.Contains(t, err.Error(), "not found")
+}
@@ -0,0 +1,358 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	crisistypes "github.com/cosmos/cosmos-sdk/x/crisis/types"
+	genutiltypes "github.com/cosmos/cosmos-sdk/x/genutil/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	minttypes "github.com/cosmos/cosmos-sdk/x/mint/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	tmtypes "github.com/tendermint/tendermint/types"
+	"github.com/wormhole-foundation/wormhole-chain/app"
+	tbtypes "github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+	wormholetypes "github.com/wormhole-foundation/wormhole-chain/x/wormhole/types"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	wormDenom = "uworm"
+	// guardianSetExpiration is the number of seconds a guardian set stays valid after it was replaced.
+	guardianSetExpiration = 86400
+)
+
+// Config describes a devnet genesis. See devnet/genesis.yaml for an example.
+type Config struct {
+	ChainID string `yaml:"chainId"`
+	// GenesisTime is an RFC 3339 timestamp. The genesis starts at the time of generation if it is empty.
+	GenesisTime string `yaml:"genesisTime"`
+	// Guardians make up guardian set 0. Each guardian with a validator runs that validator.
+	Guardians  []Guardian `yaml:"guardians"`
+	Governance Governance `yaml:"governance"`
+	// TokenBridges maps chain names or IDs to the hex-encoded emitter address of the token bridge on that chain.
+	TokenBridges map[string]string `yaml:"tokenBridges"`
+	Accounts     []Account         `yaml:"accounts"`
+	// WormSupply is the total supply of uworm. The part not held by the accounts goes to Treasury. Unless it is set,
+	// the supply is the sum of the balances.
+	WormSupply string `yaml:"wormSupply"`
+	Treasury   string `yaml:"treasury"`
+	// GenTxDir is a directory of gentx files, relative to the config file, whose validators are created at genesis.
+	GenTxDir string `yaml:"genTxDir"`
+}
+
+type Guardian struct {
+	// Key is the address of the guardian key.
+	Key string `yaml:"key"`
+	// Validator is the operator address of the guardian's validator.
+	Validator string `yaml:"validator"`
+}
+
+type Governance struct {
+	Chain   uint16 `yaml:"chain"`
+	Emitter string `yaml:"emitter"`
+}
+
+type Account struct {
+	Address string `yaml:"address"`
+	// Coins is a comma-separated list of coins, like "1000000uworm,10000utest".
+	Coins string `yaml:"coins"`
+}
+
+func loadConfig(path string) (*Config, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var cfg Config
+	if err := yaml.UnmarshalStrict(b, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if cfg.GenTxDir != "" && !filepath.IsAbs(cfg.GenTxDir) {
+		cfg.GenTxDir = filepath.Join(filepath.Dir(path), cfg.GenTxDir)
+	}
+	return &cfg, nil
+}
+
+// denomMetadata describes the denoms of the devnet.
+var denomMetadata = []banktypes.Metadata{
+	{
+		Description: "Wormholechain's native test asset",
+		DenomUnits: []*banktypes.DenomUnit{
+			{Denom: "utest", Exponent: 0, Aliases: []string{}},
+			{Denom: "test", Exponent: 6, Aliases: []string{}},
+		},
+		Base:    "utest",
+		Display: "test",
+		Name:    "Test Coin",
+		Symbol:  "TEST",
+	},
+	{
+		Description: "Wormholechain's native staking asset",
+		DenomUnits: []*banktypes.DenomUnit{
+			{Denom: wormDenom, Exponent: 0, Aliases: []string{}},
+			{Denom: "worm", Exponent: 6, Aliases: []string{}},
+		},
+		Base:    wormDenom,
+		Display: "worm",
+		Name:    "Worm Coin",
+		Symbol:  "WORM",
+	},
+}
+
+// buildGenesis returns the genesis described by cfg. Modules that cfg does not cover keep their default genesis.
+func buildGenesis(cdc codec.Codec, txConfig client.TxEncodingConfig, cfg *Config) (*tmtypes.GenesisDoc, error) {
+	genesisTime := time.Now().UTC()
+	if cfg.GenesisTime != "" {
+		t, err := time.Parse(time.RFC3339, cfg.GenesisTime)
+		if err != nil {
+			return nil, fmt.Errorf("invalid genesis time: %w", err)
+		}
+		genesisTime = t
+	}
+
+	appState := app.ModuleBasics.DefaultGenesis(cdc)
+	if err := setWormholeGenesis(cdc, cfg, appState); err != nil {
+		return nil, err
+	}
+	if err := setTokenBridgeGenesis(cdc, cfg, appState); err != nil {
+		return nil, err
+	}
+	if err := setAccounts(cdc, cfg, appState); err != nil {
+		return nil, err
+	}
+	setDenoms(cdc, appState)
+	if err := setGenTxs(cdc, cfg, appState); err != nil {
+		return nil, err
+	}
+	if err := app.ModuleBasics.ValidateGenesis(cdc, txConfig, appState); err != nil {
+		return nil, fmt.Errorf("invalid genesis: %w", err)
+	}
+
+	appStateJSON, err := json.MarshalIndent(appState, "", " ")
+	if err != nil {
+		return nil, err
+	}
+	genDoc := &tmtypes.GenesisDoc{
+		GenesisTime: genesisTime,
+		ChainID:     cfg.ChainID,
+		AppState:    appStateJSON,
+	}
+	if err := genDoc.ValidateAndComplete(); err != nil {
+		return nil, err
+	}
+	return genDoc, nil
+}
+
+func setWormholeGenesis(cdc codec.Codec, cfg *Config, appState map[string]json.RawMessage) error {
+	if len(cfg.Guardians) == 0 {
+		return fmt.Errorf("no guardians")
+	}
+	governanceEmitter, err := vaa.StringToAddress(cfg.Governance.Emitter)
+	if err != nil {
+		return fmt.Errorf("invalid governance emitter: %w", err)
+	}
+
+	gs := wormholetypes.DefaultGenesis()
+	gs.Config = &wormholetypes.Config{
+		GuardianSetExpiration: guardianSetExpiration,
+		GovernanceEmitter:     governanceEmitter.Bytes(),
+		GovernanceChain:       uint32(cfg.Governance.Chain),
+		ChainId:               uint32(vaa.ChainIDWormchain),
+	}
+
+	guardianSet := wormholetypes.GuardianSet{Index: 0}
+	for i, g := range cfg.Guardians {
+		if !ethcommon.IsHexAddress(g.Key) {
+			return fmt.Errorf("invalid key of guardian %d: %s", i, g.Key)
+		}
+		key := ethcommon.HexToAddress(g.Key)
+		guardianSet.Keys = append(guardianSet.Keys, key.Bytes())
+
+		if g.Validator == "" {
+			continue
+		}
+		validator, err := sdk.ValAddressFromBech32(g.Validator)
+		if err != nil {
+			return fmt.Errorf("invalid validator of guardian %d: %w", i, err)
+		}
+		gs.GuardianValidatorList = append(gs.GuardianValidatorList, wormholetypes.GuardianValidator{
+			GuardianKey:   key.Bytes(),
+			ValidatorAddr: validator,
+		})
+	}
+	gs.GuardianSetList = []wormholetypes.GuardianSet{guardianSet}
+	gs.ConsensusGuardianSetIndex = &wormholetypes.ConsensusGuardianSetIndex{Index: 0}
+
+	appState[wormholetypes.ModuleName] = cdc.MustMarshalJSON(gs)
+	return nil
+}
+
+func setTokenBridgeGenesis(cdc codec.Codec, cfg *Config, appState map[string]json.RawMessage) error {
+	gs := tbtypes.DefaultGenesis()
+	for chain, emitter := range cfg.TokenBridges {
+		chainID, err := parseChain(chain)
+		if err != nil {
+			return err
+		}
+		emitterAddress, err := vaa.StringToAddress(emitter)
+		if err != nil {
+			return fmt.Errorf("invalid token bridge emitter on %s: %w", chain, err)
+		}
+		gs.ChainRegistrationList = append(gs.ChainRegistrationList, tbtypes.ChainRegistration{
+			ChainID:        uint32(chainID),
+			EmitterAddress: emitterAddress.Bytes(),
+		})
+	}
+	sort.Slice(gs.ChainRegistrationList, func(i, j int) bool {
+		return gs.ChainRegistrationList[i].ChainID < gs.ChainRegistrationList[j].ChainID
+	})
+
+	appState[tbtypes.ModuleName] = cdc.MustMarshalJSON(gs)
+	return nil
+}
+
+// parseChain accepts chain names as well as IDs.
+func parseChain(s string) (vaa.ChainID, error) {
+	if id, err := strconv.ParseUint(s, 10, 16); err == nil {
+		return vaa.ChainID(id), nil
+	}
+	id, err := vaa.ChainIDFromString(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid chain %s: %w", s, err)
+	}
+	return id, nil
+}
+
+// setAccounts creates the accounts with their balances and allocates the WORM supply.
+func setAccounts(cdc codec.Codec, cfg *Config, appState map[string]json.RawMessage) error {
+	balances := make([]banktypes.Balance, 0, len(cfg.Accounts)+1)
+	for _, a := range cfg.Accounts {
+		if _, err := sdk.AccAddressFromBech32(a.Address); err != nil {
+			return fmt.Errorf("invalid account %s: %w", a.Address, err)
+		}
+		coins, err := sdk.ParseCoinsNormalized(a.Coins)
+		if err != nil {
+			return fmt.Errorf("invalid coins of %s: %w", a.Address, err)
+		}
+		balances = append(balances, banktypes.Balance{Address: a.Address, Coins: coins})
+	}
+
+	if cfg.WormSupply != "" {
+		supply, ok := sdk.NewIntFromString(cfg.WormSupply)
+		if !ok || supply.IsNegative() {
+			return fmt.Errorf("invalid WORM supply: %s", cfg.WormSupply)
+		}
+		allocated := sdk.ZeroInt()
+		for _, b := range balances {
+			allocated = allocated.Add(b.Coins.AmountOf(wormDenom))
+		}
+		switch remainder := supply.Sub(allocated); {
+		case remainder.IsNegative():
+			return fmt.Errorf("accounts hold %s%s, more than the WORM supply of %s%s", allocated, wormDenom, supply, wormDenom)
+		case remainder.IsPositive():
+			if _, err := sdk.AccAddressFromBech32(cfg.Treasury); err != nil {
+				return fmt.Errorf("invalid treasury %q for the unallocated WORM supply: %w", cfg.Treasury, err)
+			}
+			balances = addBalance(balances, cfg.Treasury, sdk.NewCoins(sdk.NewCoin(wormDenom, remainder)))
+		}
+	}
+	balances = banktypes.SanitizeGenesisBalances(balances)
+
+	accounts := make(authtypes.GenesisAccounts, 0, len(balances))
+	supply := sdk.NewCoins()
+	for _, b := range balances {
+		accounts = append(accounts, authtypes.NewBaseAccount(b.GetAddress(), nil, 0, 0))
+		supply = supply.Add(b.Coins...)
+	}
+	packed, err := authtypes.PackAccounts(authtypes.SanitizeGenesisAccounts(accounts))
+	if err != nil {
+		return err
+	}
+
+	authGenesis := authtypes.GetGenesisStateFromAppState(cdc, appState)
+	authGenesis.Accounts = packed
+	appState[authtypes.ModuleName] = cdc.MustMarshalJSON(&authGenesis)
+
+	bankGenesis := banktypes.GetGenesisStateFromAppState(cdc, appState)
+	bankGenesis.Balances = balances
+	bankGenesis.Supply = supply
+	bankGenesis.DenomMetadata = denomMetadata
+	appState[banktypes.ModuleName] = cdc.MustMarshalJSON(bankGenesis)
+	return nil
+}
+
+// addBalance adds coins to the balance of addr, which is created unless it exists.
+func addBalance(balances []banktypes.Balance, addr string, coins sdk.Coins) []banktypes.Balance {
+	for i, b := range balances {
+		if b.Address == addr {
+			balances[i].Coins = b.Coins.Add(coins...)
+			return balances
+		}
+	}
+	return append(balances, banktypes.Balance{Address: addr, Coins: coins})
+}
+
+// setDenoms makes uworm the staking, governance and fee denom and switches off inflation.
+func setDenoms(cdc codec.Codec, appState map[string]json.RawMessage) {
+	staking := stakingtypes.GetGenesisStateFromAppState(cdc, appState)
+	staking.Params.BondDenom = wormDenom
+	appState[stakingtypes.ModuleName] = cdc.MustMarshalJSON(staking)
+
+	var mint minttypes.GenesisState
+	cdc.MustUnmarshalJSON(appState[minttypes.ModuleName], &mint)
+	mint.Params.MintDenom = wormDenom
+	mint.Params.InflationMax = sdk.ZeroDec()
+	mint.Params.InflationMin = sdk.ZeroDec()
+	mint.Params.InflationRateChange = sdk.ZeroDec()
+	mint.Minter = minttypes.NewMinter(sdk.ZeroDec(), sdk.ZeroDec())
+	appState[minttypes.ModuleName] = cdc.MustMarshalJSON(&mint)
+
+	var gov govtypes.GenesisState
+	cdc.MustUnmarshalJSON(appState[govtypes.ModuleName], &gov)
+	gov.DepositParams.MinDeposit = sdk.NewCoins(sdk.NewInt64Coin(wormDenom, 1000000))
+	appState[govtypes.ModuleName] = cdc.MustMarshalJSON(&gov)
+
+	var crisis crisistypes.GenesisState
+	cdc.MustUnmarshalJSON(appState[crisistypes.ModuleName], &crisis)
+	crisis.ConstantFee = sdk.NewInt64Coin(wormDenom, 1000)
+	appState[crisistypes.ModuleName] = cdc.MustMarshalJSON(&crisis)
+}
+
+// setGenTxs adds the gentx files of cfg.GenTxDir, in the order of their names.
+func setGenTxs(cdc codec.Codec, cfg *Config, appState map[string]json.RawMessage) error {
+	if cfg.GenTxDir == "" {
+		return nil
+	}
+	files, err := filepath.Glob(filepath.Join(cfg.GenTxDir, "*.json"))
+	if err != nil {
+		return err
+	}
+	sort.Strings(files)
+
+	genutil := genutiltypes.GetGenesisStateFromAppState(cdc, appState)
+	for _, file := range files {
+		b, err := ioutil.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		if !json.Valid(b) {
+			return fmt.Errorf("invalid gentx %s", file)
+		}
+		genutil.GenTxs = append(genutil.GenTxs, json.RawMessage(b))
+	}
+	appState[genutiltypes.ModuleName] = cdc.MustMarshalJSON(genutil)
+	return nil
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/spm/cosmoscmd"
+	"github.com/wormhole-foundation/wormhole-chain/app"
+	tbtypes "github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+	wormholetypes "github.com/wormhole-foundation/wormhole-chain/x/wormhole/types"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+func TestMain(m *testing.M) {
+	// The prefixes can only be set once, as setting them seals the SDK config
+	cosmoscmd.SetPrefixes(app.AccountAddressPrefix)
+	os.Exit(m.Run())
+}
+
+func TestBuildDevnetGenesis(t *testing.T) {
+	encodingConfig := cosmoscmd.MakeEncodingConfig(app.ModuleBasics)
+	cdc := encodingConfig.Marshaler
+
+	cfg, err := loadConfig("../../devnet/genesis.yaml")
+	require.NoError(t, err)
+	cfg.GenesisTime = "2022-09-27T22:08:43Z"
+
+	genDoc, err := buildGenesis(cdc, encodingConfig.TxConfig, cfg)
+	require.NoError(t, err)
+	assert.Equal(t, "wormholechain", genDoc.ChainID)
+	assert.Equal(t, int64(1664316523), genDoc.GenesisTime.Unix())
+
+	var appState map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(genDoc.AppState, &appState))
+
+	var wormhole wormholetypes.GenesisState
+	cdc.MustUnmarshalJSON(appState[wormholetypes.ModuleName], &wormhole)
+	guardian := ethcommon.HexToAddress("0xbeFA429d57cD18b7F8A4d91A2da9AB4AF05d0FBe").Bytes()
+	require.Len(t, wormhole.GuardianSetList, 1)
+	assert.Equal(t, [][]byte{guardian}, wormhole.GuardianSetList[0].Keys)
+	require.Len(t, wormhole.GuardianValidatorList, 1)
+	assert.Equal(t, guardian, wormhole.GuardianValidatorList[0].GuardianKey)
+	assert.Equal(t, uint32(vaa.ChainIDWormchain), wormhole.Config.ChainId)
+	assert.Equal(t, uint32(1), wormhole.Config.GovernanceChain)
+
+	var tokenbridge tbtypes.GenesisState
+	cdc.MustUnmarshalJSON(appState[tbtypes.ModuleName], &tokenbridge)
+	require.Len(t, tokenbridge.ChainRegistrationList, 2)
+	assert.Equal(t, uint32(vaa.ChainIDSolana), tokenbridge.ChainRegistrationList[0].ChainID)
+	assert.Equal(t, uint32(vaa.ChainIDEthereum), tokenbridge.ChainRegistrationList[1].ChainID)
+	assert.Equal(t, ethcommon.HexToHash("0290fb167208af455bb137780163b7b7a9a10c16").Bytes(), tokenbridge.ChainRegistrationList[1].EmitterAddress)
+
+	// The treasury holds the WORM the accounts do not
+	bank := banktypes.GetGenesisStateFromAppState(cdc, appState)
+	assert.Equal(t, "10000000000000", bank.Supply.AmountOf(wormDenom).String())
+	assert.Equal(t, "30000", bank.Supply.AmountOf("utest").String())
+	for _, b := range bank.Balances {
+		if b.Address == cfg.Treasury {
+			assert.Equal(t, "9999000000000", b.Coins.AmountOf(wormDenom).String())
+		}
+	}
+
+	staking := stakingtypes.GetGenesisStateFromAppState(cdc, appState)
+	assert.Equal(t, wormDenom, staking.Params.BondDenom)
+}
+
+func TestBuildDevnetGenesisErrors(t *testing.T) {
+	encodingConfig := cosmoscmd.MakeEncodingConfig(app.ModuleBasics)
+
+	tests := []struct {
+		label  string
+		modify func(cfg *Config)
+	}{
+		{label: "no guardians", modify: func(cfg *Config) { cfg.Guardians = nil }},
+		{label: "invalid guardian key", modify: func(cfg *Config) { cfg.Guardians[0].Key = "0x01" }},
+		{label: "unknown chain", modify: func(cfg *Config) { cfg.TokenBridges["unknown"] = "01" }},
+		{label: "supply too low", modify: func(cfg *Config) { cfg.WormSupply = "1000" }},
+		{label: "no treasury", modify: func(cfg *Config) { cfg.Treasury = "" }},
+		{label: "invalid coins", modify: func(cfg *Config) { cfg.Accounts[0].Coins = "-1uworm" }},
+	}
+	for _, tc := range tests {
+		t.Run(tc.label, func(t *testing.T) {
+			cfg, err := loadConfig("../../devnet/genesis.yaml")
+			require.NoError(t, err)
+			tc.modify(cfg)
+			_, err = buildGenesis(encodingConfig.Marshaler, encodingConfig.TxConfig, cfg)
+			assert.Error(t, err)
+		})
+	}
+
+	// Without a WORM supply, the supply is what the accounts hold
+	cfg, err := loadConfig("../../devnet/genesis.yaml")
+	require.NoError(t, err)
+	cfg.WormSupply = ""
+	cfg.Treasury = ""
+	genDoc, err := buildGenesis(encodingConfig.Marshaler, encodingConfig.TxConfig, cfg)
+	require.NoError(t, err)
+	var appState map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(genDoc.AppState, &appState))
+	bank := banktypes.GetGenesisStateFromAppState(encodingConfig.Marshaler, appState)
+	assert.Equal(t, sdk.NewInt(2000000000), bank.Supply.AmountOf(wormDenom))
+}
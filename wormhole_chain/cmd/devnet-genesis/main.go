@@ -0,0 +1,61 @@
+// Command devnet-genesis generates the genesis of a wormhole chain devnet from a small YAML file, see
+// devnet/genesis.yaml: the guardian set and its validators, the token bridge registrations of other chains, funded
+// accounts and the WORM supply. All other modules start from their default genesis.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/tendermint/spm/cosmoscmd"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	"github.com/wormhole-foundation/wormhole-chain/app"
+)
+
+const flagOutput = "output"
+
+func main() {
+	if err := newDevnetGenesisCmd().Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+func newDevnetGenesisCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "devnet-genesis [config]",
+		Short: "Generate a devnet genesis from a YAML config",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runDevnetGenesis,
+	}
+	cmd.Flags().StringP(flagOutput, "o", "", "File to write the genesis to, instead of stdout")
+	return cmd
+}
+
+func runDevnetGenesis(cmd *cobra.Command, args []string) error {
+	cosmoscmd.SetPrefixes(app.AccountAddressPrefix)
+	encodingConfig := cosmoscmd.MakeEncodingConfig(app.ModuleBasics)
+
+	cfg, err := loadConfig(args[0])
+	if err != nil {
+		return err
+	}
+	genDoc, err := buildGenesis(encodingConfig.Marshaler, encodingConfig.TxConfig, cfg)
+	if err != nil {
+		return err
+	}
+
+	output, err := cmd.Flags().GetString(flagOutput)
+	if err != nil {
+		return err
+	}
+	if output != "" {
+		return genDoc.SaveAs(output)
+	}
+	b, err := tmjson.MarshalIndent(genDoc, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(cmd.OutOrStdout(), string(b))
+	return err
+}
@@ -0,0 +1,186 @@
+// Command relayer is a reference relayer for wormhole chain. It subscribes to the signed VAAs of a guardian spy, and
+// redeems the token bridge transfers to wormhole chain that pay a high enough relayer fee.
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	sdkclient "github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/spf13/cobra"
+	"github.com/tendermint/spm/cosmoscmd"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/wormhole-foundation/wormhole-chain/app"
+	"github.com/wormhole-foundation/wormhole-chain/client"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+const (
+	flagSpyRPC         = "spyRPC"
+	flagStatusAddr     = "statusAddr"
+	flagMinFee         = "minFee"
+	flagTokens         = "tokens"
+	flagMaxAttempts    = "maxAttempts"
+	flagRetryDelay     = "retryDelay"
+	flagReconnectDelay = "reconnectDelay"
+)
+
+func main() {
+	if err := newRelayerCmd().Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+func newRelayerCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "relayer --spyRPC [address] --from [key]",
+		Short: "Relay token bridge transfers from a guardian spy to wormhole chain",
+		Args:  cobra.NoArgs,
+		RunE:  runRelayer,
+	}
+
+	cmd.Flags().String(flagSpyRPC, "", "Address of the gRPC interface of the guardian spy")
+	cmd.Flags().String(flagStatusAddr, "[::]:6060", "Listen address for the metrics server (disabled if blank)")
+	cmd.Flags().String(flagMinFee, "0", "Minimum relayer fee of a transfer to relay it, in the 8 decimals units of the transfer payload")
+	cmd.Flags().String(flagTokens, "", "Comma-separated <chain>/<hex address> tokens to relay, if only some tokens should be relayed")
+	cmd.Flags().Int(flagMaxAttempts, 5, "Number of times a transfer is submitted before giving up on a transient failure")
+	cmd.Flags().Duration(flagRetryDelay, 2*time.Second, "Delay before the first resubmission of a transfer, doubled for every following attempt")
+	cmd.Flags().Duration(flagReconnectDelay, 5*time.Second, "Delay before reconnecting to the spy after the stream fails")
+	cmd.Flags().String(flags.FlagHome, app.DefaultNodeHome, "The application home directory, which holds the keyring")
+	flags.AddTxFlagsToCmd(cmd)
+	_ = cmd.MarkFlagRequired(flagSpyRPC)
+	_ = cmd.MarkFlagRequired(flags.FlagFrom)
+
+	return cmd
+}
+
+func runRelayer(cmd *cobra.Command, _ []string) error {
+	cosmoscmd.SetPrefixes(app.AccountAddressPrefix)
+	encodingConfig := cosmoscmd.MakeEncodingConfig(app.ModuleBasics)
+	initClientCtx := sdkclient.Context{}.
+		WithCodec(encodingConfig.Marshaler).
+		WithInterfaceRegistry(encodingConfig.InterfaceRegistry).
+		WithTxConfig(encodingConfig.TxConfig).
+		WithLegacyAmino(encodingConfig.Amino).
+		WithInput(os.Stdin).
+		WithAccountRetriever(authtypes.AccountRetriever{}).
+		WithBroadcastMode(flags.BroadcastBlock).
+		WithHomeDir(app.DefaultNodeHome)
+	if err := sdkclient.SetCmdClientContextHandler(initClientCtx, cmd); err != nil {
+		return err
+	}
+	clientCtx, err := sdkclient.GetClientTxContext(cmd)
+	if err != nil {
+		return err
+	}
+
+	minFee, ok := new(big.Int).SetString(mustGetString(cmd, flagMinFee), 10)
+	if !ok || minFee.Sign() < 0 {
+		return fmt.Errorf("invalid minimum fee: %s", mustGetString(cmd, flagMinFee))
+	}
+	tokens, err := parseTokens(mustGetString(cmd, flagTokens))
+	if err != nil {
+		return err
+	}
+	maxAttempts, err := cmd.Flags().GetInt(flagMaxAttempts)
+	if err != nil {
+		return err
+	}
+	retryDelay, err := cmd.Flags().GetDuration(flagRetryDelay)
+	if err != nil {
+		return err
+	}
+	reconnectDelay, err := cmd.Flags().GetDuration(flagReconnectDelay)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	logger := log.NewTMLogger(log.NewSyncWriter(os.Stderr))
+	c := client.New(clientCtx, tx.NewFactoryCLI(clientCtx, cmd.Flags()))
+	config, err := c.WormholeConfig(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to query the wormhole config: %w", err)
+	}
+
+	r := &relayer{
+		logger:      logger,
+		client:      c,
+		chainID:     vaa.ChainID(config.ChainId),
+		minFee:      minFee,
+		tokens:      tokens,
+		maxAttempts: maxAttempts,
+		retryDelay:  retryDelay,
+		seen:        make(map[string]bool),
+	}
+
+	if statusAddr := mustGetString(cmd, flagStatusAddr); statusAddr != "" {
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", promhttp.Handler())
+		go func() {
+			logger.Error("metrics server crashed", "err", http.ListenAndServe(statusAddr, mux))
+		}()
+	}
+
+	spyRPC := mustGetString(cmd, flagSpyRPC)
+	logger.Info("relaying transfers", "spy", spyRPC, "relayer", c.Address().String(), "min_fee", minFee.String())
+	for {
+		err := subscribeSignedVAAs(ctx, spyRPC, func(vaaBytes []byte) { r.handle(ctx, vaaBytes) })
+		if ctx.Err() != nil {
+			return nil
+		}
+		logger.Error("spy subscription failed", "err", err)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(reconnectDelay):
+		}
+	}
+}
+
+// parseTokens parses a comma-separated list of <chain>/<hex address> tokens.
+func parseTokens(s string) (map[tokenID]bool, error) {
+	tokens := make(map[tokenID]bool)
+	if s == "" {
+		return tokens, nil
+	}
+	for _, entry := range strings.Split(s, ",") {
+		parts := strings.Split(strings.TrimSpace(entry), "/")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid token %q, expected <chain>/<hex address>", entry)
+		}
+		chain, err := strconv.ParseUint(parts[0], 10, 16)
+		if err != nil {
+			return nil, fmt.Errorf("invalid token chain %q: %w", parts[0], err)
+		}
+		address, err := vaa.StringToAddress(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid token address %q: %w", parts[1], err)
+		}
+		tokens[tokenID{chain: vaa.ChainID(chain), address: address}] = true
+	}
+	return tokens, nil
+}
+
+func mustGetString(cmd *cobra.Command, name string) string {
+	s, err := cmd.Flags().GetString(name)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"math/big"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/wormhole-foundation/wormhole-chain/client"
+	tbtypes "github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+	whtypes "github.com/wormhole-foundation/wormhole-chain/x/wormhole/types"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+var (
+	vaasReceived = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "wormchain_relayer_vaas_received_total",
+			Help: "Total number of signed VAAs received from the spy",
+		})
+	vaasSkipped = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "wormchain_relayer_vaas_skipped_total",
+			Help: "Total number of signed VAAs not relayed, by reason",
+		}, []string{"reason"})
+	redemptions = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "wormchain_relayer_redemptions_total",
+			Help: "Total number of transfers submitted to wormhole chain, by outcome",
+		}, []string{"status"})
+	submitRetries = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "wormchain_relayer_submit_retries_total",
+			Help: "Total number of resubmissions of a transfer after a transient failure",
+		})
+)
+
+// transferPayloadLength is the length of a token bridge transfer payload (payload ID 1), including the payload ID.
+const transferPayloadLength = 133
+
+// seenDigestsSize bounds the number of digests remembered to skip VAAs that were already handled.
+const seenDigestsSize = 10000
+
+var (
+	errNotTransfer     = errors.New("not_transfer")
+	errOtherChain      = errors.New("other_chain")
+	errFeeTooLow       = errors.New("fee_too_low")
+	errTokenNotAllowed = errors.New("token_not_allowed")
+)
+
+// tokenID identifies a token by its origin chain and address.
+type tokenID struct {
+	chain   vaa.ChainID
+	address vaa.Address
+}
+
+// transfer holds the fields of a transfer payload the relayer filters on.
+type transfer struct {
+	token   tokenID
+	toChain vaa.ChainID
+	fee     *big.Int
+}
+
+// parseTransfer decodes a token bridge transfer payload, returning errNotTransfer for any other payload.
+func parseTransfer(payload []byte) (*transfer, error) {
+	if len(payload) != transferPayloadLength || payload[0] != 1 {
+		return nil, errNotTransfer
+	}
+	t := &transfer{
+		token:   tokenID{chain: vaa.ChainID(binary.BigEndian.Uint16(payload[65:67]))},
+		toChain: vaa.ChainID(binary.BigEndian.Uint16(payload[99:101])),
+		fee:     new(big.Int).SetBytes(payload[101:133]),
+	}
+	copy(t.token.address[:], payload[33:65])
+	return t, nil
+}
+
+// relayer submits the token bridge transfers to wormhole chain that pay at least minFee, and are of one of the allowed
+// tokens if any are set.
+type relayer struct {
+	logger log.Logger
+	client *client.Client
+
+	chainID vaa.ChainID
+	minFee  *big.Int
+	tokens  map[tokenID]bool
+
+	maxAttempts int
+	retryDelay  time.Duration
+
+	seen map[string]bool
+}
+
+// check returns the reason for not relaying v, or nil if it should be relayed.
+func (r *relayer) check(v *vaa.VAA) error {
+	t, err := parseTransfer(v.Payload)
+	if err != nil {
+		return err
+	}
+	if t.toChain != r.chainID {
+		return errOtherChain
+	}
+	if t.fee.Cmp(r.minFee) < 0 {
+		return errFeeTooLow
+	}
+	if len(r.tokens) != 0 && !r.tokens[t.token] {
+		return errTokenNotAllowed
+	}
+	return nil
+}
+
+// handle relays a VAA received from the spy if it passes the filters and was not executed yet.
+func (r *relayer) handle(ctx context.Context, vaaBytes []byte) {
+	vaasReceived.Inc()
+
+	v, err := vaa.Unmarshal(vaaBytes)
+	if err != nil {
+		vaasSkipped.WithLabelValues("invalid").Inc()
+		return
+	}
+	if err := r.check(v); err != nil {
+		vaasSkipped.WithLabelValues(err.Error()).Inc()
+		return
+	}
+
+	// The spy delivers a VAA once per guardian that broadcasts it, so skip the ones that were already handled.
+	digest := v.HexDigest()
+	if r.seen[digest] {
+		vaasSkipped.WithLabelValues("duplicate").Inc()
+		return
+	}
+	if len(r.seen) >= seenDigestsSize {
+		r.seen = make(map[string]bool)
+	}
+	r.seen[digest] = true
+
+	if _, err := r.client.Tokenbridge.ReplayProtection(ctx, &tbtypes.QueryGetReplayProtectionRequest{Index: digest}); err == nil {
+		redemptions.WithLabelValues("already_executed").Inc()
+		return
+	}
+
+	logger := r.logger.With("digest", digest, "emitter_chain", v.EmitterChain.String(), "sequence", v.Sequence)
+	for attempt := 1; ; attempt++ {
+		res, err := r.client.SubmitVAA(vaaBytes)
+		if err == nil {
+			logger.Info("relayed transfer", "tx_hash", res.TxHash)
+			redemptions.WithLabelValues("success").Inc()
+			return
+		}
+		if res != nil && res.Codespace == tbtypes.ModuleName && res.Code == tbtypes.ErrVAAAlreadyExecuted.ABCICode() {
+			redemptions.WithLabelValues("already_executed").Inc()
+			return
+		}
+		if !isTransient(res) || attempt >= r.maxAttempts {
+			logger.Error("failed to relay transfer", "attempts", attempt, "err", err)
+			redemptions.WithLabelValues("failed").Inc()
+			return
+		}
+
+		logger.Info("retrying transfer", "attempt", attempt, "err", err)
+		submitRetries.Inc()
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(r.retryDelay << (attempt - 1)):
+		}
+	}
+}
+
+// isTransient returns true if a transaction that failed with res may succeed when submitted again. Transactions that
+// were not broadcast, that used a stale account sequence or that exceeded the VAA executions allowed per block are
+// transient failures, and any other rejection is final.
+func isTransient(res *sdk.TxResponse) bool {
+	if res == nil || res.Code == 0 {
+		return true
+	}
+	if res.Codespace == whtypes.ModuleName && res.Code == whtypes.ErrVAAExecutionLimitExceeded.ABCICode() {
+		return true
+	}
+	return res.Codespace == sdkerrors.RootCodespace && res.Code == sdkerrors.ErrWrongSequence.ABCICode()
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"encoding/binary"
+	"math/big"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stretchr/testify/require"
+	tbtypes "github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+	whtypes "github.com/wormhole-foundation/wormhole-chain/x/wormhole/types"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+func transferPayload(token tokenID, toChain vaa.ChainID, fee int64) []byte {
+	payload := make([]byte, transferPayloadLength)
+	payload[0] = 1
+	big.NewInt(1000).FillBytes(payload[1:33])
+	copy(payload[33:65], token.address[:])
+	binary.BigEndian.PutUint16(payload[65:67], uint16(token.chain))
+	binary.BigEndian.PutUint16(payload[99:101], uint16(toChain))
+	big.NewInt(fee).FillBytes(payload[101:133])
+	return payload
+}
+
+func TestRelayerCheck(t *testing.T) {
+	usdc := tokenID{chain: vaa.ChainIDEthereum, address: vaa.Address{31: 0x01}}
+	weth := tokenID{chain: vaa.ChainIDEthereum, address: vaa.Address{31: 0x02}}
+
+	tests := []struct {
+		label   string
+		tokens  map[tokenID]bool
+		payload []byte
+		err     error
+	}{
+		{label: "relayed", payload: transferPayload(usdc, vaa.ChainIDWormchain, 10)},
+		{label: "fee equal to minimum", payload: transferPayload(usdc, vaa.ChainIDWormchain, 5)},
+		{label: "fee too low", payload: transferPayload(usdc, vaa.ChainIDWormchain, 4), err: errFeeTooLow},
+		{label: "other chain", payload: transferPayload(usdc, vaa.ChainIDSolana, 10), err: errOtherChain},
+		{label: "asset meta", payload: []byte{2, 0, 0}, err: errNotTransfer},
+		{label: "short transfer", payload: transferPayload(usdc, vaa.ChainIDWormchain, 10)[:100], err: errNotTransfer},
+		{label: "allowed token", tokens: map[tokenID]bool{usdc: true}, payload: transferPayload(usdc, vaa.ChainIDWormchain, 10)},
+		{label: "token not allowed", tokens: map[tokenID]bool{usdc: true}, payload: transferPayload(weth, vaa.ChainIDWormchain, 10), err: errTokenNotAllowed},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.label, func(t *testing.T) {
+			r := &relayer{chainID: vaa.ChainIDWormchain, minFee: big.NewInt(5), tokens: tc.tokens}
+			require.Equal(t, tc.err, r.check(&vaa.VAA{Payload: tc.payload}))
+		})
+	}
+}
+
+func TestParseTokens(t *testing.T) {
+	tokens, err := parseTokens("2/0x01, 1/0000000000000000000000000000000000000000000000000000000000000002")
+	require.NoError(t, err)
+	require.Equal(t, map[tokenID]bool{
+		{chain: vaa.ChainIDEthereum, address: vaa.Address{31: 0x01}}: true,
+		{chain: vaa.ChainIDSolana, address: vaa.Address{31: 0x02}}:   true,
+	}, tokens)
+
+	tokens, err = parseTokens("")
+	require.NoError(t, err)
+	require.Empty(t, tokens)
+
+	_, err = parseTokens("2")
+	require.Error(t, err)
+	_, err = parseTokens("70000/0x01")
+	require.Error(t, err)
+}
+
+func TestIsTransient(t *testing.T) {
+	require.True(t, isTransient(nil))
+	require.True(t, isTransient(&sdk.TxResponse{Codespace: sdkerrors.RootCodespace, Code: sdkerrors.ErrWrongSequence.ABCICode()}))
+	require.True(t, isTransient(&sdk.TxResponse{Codespace: whtypes.ModuleName, Code: whtypes.ErrVAAExecutionLimitExceeded.ABCICode()}))
+	require.False(t, isTransient(&sdk.TxResponse{Codespace: tbtypes.ModuleName, Code: tbtypes.ErrUnregisteredEmitter.ABCICode()}))
+}
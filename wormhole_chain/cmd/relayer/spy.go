@@ -0,0 +1,44 @@
+package main
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"google.golang.org/grpc"
+)
+
+// subscribeSignedVAAMethod is the streaming method of the spy's SpyRPCService.
+const subscribeSignedVAAMethod = "/spy.v1.SpyRPCService/SubscribeSignedVAA"
+
+// subscribeSignedVAAs streams all signed VAAs from the spy at addr to h until the stream fails or ctx is done.
+//
+// The generated spy client lives in the guardian node module, which this module does not depend on. The messages are
+// simple enough to be encoded with the well-known types instead: an empty SubscribeSignedVAARequest (no filters and no
+// cursors) has the encoding of Empty, and SubscribeSignedVAAResponse has a single bytes field 1, like BytesValue.
+func subscribeSignedVAAs(ctx context.Context, addr string, h func(vaaBytes []byte)) error {
+	conn, err := grpc.DialContext(ctx, addr, grpc.WithInsecure())
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	stream, err := conn.NewStream(ctx, &grpc.StreamDesc{ServerStreams: true}, subscribeSignedVAAMethod)
+	if err != nil {
+		return err
+	}
+	if err := stream.SendMsg(&empty.Empty{}); err != nil {
+		return err
+	}
+	if err := stream.CloseSend(); err != nil {
+		return err
+	}
+
+	for {
+		var res wrappers.BytesValue
+		if err := stream.RecvMsg(&res); err != nil {
+			return err
+		}
+		h(res.Value)
+	}
+}
@@ -0,0 +1,49 @@
+package docs
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	rpcRegexp   = regexp.MustCompile(`rpc\s+(\w+)\s*\(`)
+	routeRegexp = regexp.MustCompile(`option \(google\.api\.http\)\.get\s*=\s*"([^"]+)"`)
+)
+
+// TestQueryRoutes checks that every query of the wormhole chain modules has a REST route in the OpenAPI spec, since
+// many integrators cannot use gRPC.
+func TestQueryRoutes(t *testing.T) {
+	spec, err := Docs.ReadFile("static/openapi.yml")
+	require.NoError(t, err)
+
+	files, err := filepath.Glob("../proto/*/query.proto")
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+	for _, file := range files {
+		src, err := os.ReadFile(file)
+		require.NoError(t, err)
+
+		// Each rpc is followed by its route
+		rpcs := rpcRegexp.FindAllSubmatchIndex(src, -1)
+		for i, rpc := range rpcs {
+			end := len(src)
+			if i+1 < len(rpcs) {
+				end = rpcs[i+1][0]
+			}
+			name := string(src[rpc[2]:rpc[3]])
+			route := routeRegexp.FindSubmatch(src[rpc[1]:end])
+			if !assert.NotNil(t, route, "%s: %s has no REST route", file, name) {
+				continue
+			}
+			path := string(route[1])
+			documented := strings.Contains(string(spec), "\n  "+path+":\n") || strings.Contains(string(spec), "\n  '"+path+"':\n")
+			assert.True(t, documented, "%s: route %s of %s is not in the OpenAPI spec", file, path, name)
+		}
+	}
+}
@@ -4,6 +4,8 @@ go 1.16
 
 require (
 	github.com/CosmWasm/wasmd v0.28.0
+	github.com/CosmWasm/wasmvm v1.0.0
+	github.com/armon/go-metrics v0.4.0
 	github.com/cosmos/cosmos-sdk v0.45.8
 	github.com/cosmos/ibc-go/v3 v3.3.0
 	github.com/dgraph-io/ristretto v0.1.0 // indirect
@@ -25,6 +27,7 @@ require (
 	golang.org/x/crypto v0.0.0-20220525230936-793ad666bf5e
 	google.golang.org/genproto v0.0.0-20220822174746-9e6da59bd2fc
 	google.golang.org/grpc v1.48.0
+	gopkg.in/yaml.v2 v2.4.0
 	nhooyr.io/websocket v1.8.7 // indirect
 )
 
@@ -8,15 +8,28 @@ import (
 	"github.com/cosmos/cosmos-sdk/store"
 	storetypes "github.com/cosmos/cosmos-sdk/store/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	authkeeper "github.com/cosmos/cosmos-sdk/x/auth/keeper"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	bankkeeper "github.com/cosmos/cosmos-sdk/x/bank/keeper"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	paramskeeper "github.com/cosmos/cosmos-sdk/x/params/keeper"
+	paramstypes "github.com/cosmos/cosmos-sdk/x/params/types"
 	"github.com/stretchr/testify/require"
+	"github.com/tendermint/spm/cosmoscmd"
 	"github.com/tendermint/tendermint/libs/log"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	tmdb "github.com/tendermint/tm-db"
+	"github.com/wormhole-foundation/wormhole-chain/app"
 	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/keeper"
 	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
 )
 
 func TokenbridgeKeeper(t testing.TB) (*keeper.Keeper, sdk.Context) {
+	return TokenbridgeKeeperWithDeps(t, nil, nil, nil, nil, nil)
+}
+
+// TokenbridgeKeeperWithDeps creates a tokenbridge keeper backed by the given (usually mocked) keepers.
+func TokenbridgeKeeperWithDeps(t testing.TB, accountKeeper types.AccountKeeper, bankKeeper types.BankKeeper, wormholeKeeper types.WormholeKeeper, upgradeKeeper types.UpgradeKeeper, scopedKeeper types.ScopedKeeper) (*keeper.Keeper, sdk.Context) {
 	storeKey := sdk.NewKVStoreKey(types.StoreKey)
 	memStoreKey := storetypes.NewMemoryStoreKey(types.MemStoreKey)
 
@@ -31,11 +44,66 @@ func TokenbridgeKeeper(t testing.TB) (*keeper.Keeper, sdk.Context) {
 		codec.NewProtoCodec(registry),
 		storeKey,
 		memStoreKey,
-		nil,
+		accountKeeper,
+		bankKeeper,
+		wormholeKeeper,
+		upgradeKeeper,
+		scopedKeeper,
+	)
+
+	ctx := sdk.NewContext(stateStore, tmproto.Header{}, false, log.NewNopLogger())
+	return k, ctx
+}
+
+// TokenbridgeKeeperWithBank creates a tokenbridge keeper backed by real auth and bank keepers, which block sends to the
+// module accounts in blockedModules, and the given (usually mocked) wormhole keeper.
+func TokenbridgeKeeperWithBank(t testing.TB, wormholeKeeper types.WormholeKeeper, blockedModules ...string) (*keeper.Keeper, sdk.Context, authkeeper.AccountKeeper, bankkeeper.Keeper) {
+	keys := sdk.NewKVStoreKeys(authtypes.StoreKey, banktypes.StoreKey, paramstypes.StoreKey, types.StoreKey)
+	tkeys := sdk.NewTransientStoreKeys(paramstypes.TStoreKey)
+	memStoreKey := storetypes.NewMemoryStoreKey(types.MemStoreKey)
+
+	db := tmdb.NewMemDB()
+	stateStore := store.NewCommitMultiStore(db)
+	for _, key := range keys {
+		stateStore.MountStoreWithDB(key, sdk.StoreTypeIAVL, db)
+	}
+	stateStore.MountStoreWithDB(tkeys[paramstypes.TStoreKey], sdk.StoreTypeTransient, nil)
+	stateStore.MountStoreWithDB(memStoreKey, sdk.StoreTypeMemory, nil)
+	require.NoError(t, stateStore.LoadLatestVersion())
+
+	encodingConfig := cosmoscmd.MakeEncodingConfig(app.ModuleBasics)
+	appCodec := encodingConfig.Marshaler
+
+	maccPerms := map[string][]string{types.ModuleName: {authtypes.Minter, authtypes.Burner}}
+	blockedAddrs := make(map[string]bool)
+	for _, name := range blockedModules {
+		if _, found := maccPerms[name]; !found {
+			maccPerms[name] = nil
+		}
+		blockedAddrs[authtypes.NewModuleAddress(name).String()] = true
+	}
+
+	paramsKeeper := paramskeeper.NewKeeper(appCodec, encodingConfig.Amino, keys[paramstypes.StoreKey], tkeys[paramstypes.TStoreKey])
+	paramsKeeper.Subspace(authtypes.ModuleName)
+	paramsKeeper.Subspace(banktypes.ModuleName)
+	authSubspace, _ := paramsKeeper.GetSubspace(authtypes.ModuleName)
+	bankSubspace, _ := paramsKeeper.GetSubspace(banktypes.ModuleName)
+	accountKeeper := authkeeper.NewAccountKeeper(appCodec, keys[authtypes.StoreKey], authSubspace, authtypes.ProtoBaseAccount, maccPerms)
+	bankKeeper := bankkeeper.NewBaseKeeper(appCodec, keys[banktypes.StoreKey], accountKeeper, bankSubspace, blockedAddrs)
+
+	k := keeper.NewKeeper(
+		appCodec,
+		keys[types.StoreKey],
+		memStoreKey,
+		accountKeeper,
+		bankKeeper,
+		wormholeKeeper,
 		nil,
 		nil,
 	)
 
 	ctx := sdk.NewContext(stateStore, tmproto.Header{}, false, log.NewNopLogger())
-	return k, ctx
+	accountKeeper.SetParams(ctx, authtypes.DefaultParams())
+	bankKeeper.SetParams(ctx, banktypes.DefaultParams())
+	return k, ctx, accountKeeper, bankKeeper
 }
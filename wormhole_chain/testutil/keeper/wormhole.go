@@ -31,6 +31,11 @@ import (
 )
 
 func WormholeKeeper(t testing.TB) (*keeper.Keeper, sdk.Context) {
+	return WormholeKeeperWithBank(t, nil)
+}
+
+// WormholeKeeperWithBank creates a wormhole keeper that uses bankKeeper to charge message fees.
+func WormholeKeeperWithBank(t testing.TB, bankKeeper types.BankKeeper) (*keeper.Keeper, sdk.Context) {
 	keys := sdk.NewKVStoreKeys(
 		authtypes.StoreKey,
 		paramstypes.StoreKey,
@@ -38,7 +43,7 @@ func WormholeKeeper(t testing.TB) (*keeper.Keeper, sdk.Context) {
 		types.StoreKey,
 		wasmtypes.StoreKey,
 	)
-	tkeys := sdk.NewTransientStoreKeys(paramstypes.TStoreKey)
+	tkeys := sdk.NewTransientStoreKeys(paramstypes.TStoreKey, types.TStoreKey)
 	memKeys := sdk.NewMemoryStoreKeys(capabilitytypes.MemStoreKey, types.MemStoreKey)
 	maccPerms := map[string][]string{}
 
@@ -50,7 +55,9 @@ func WormholeKeeper(t testing.TB) (*keeper.Keeper, sdk.Context) {
 	stateStore.MountStoreWithDB(keys[types.StoreKey], sdk.StoreTypeIAVL, db)
 	stateStore.MountStoreWithDB(keys[wasmtypes.StoreKey], sdk.StoreTypeIAVL, db)
 	stateStore.MountStoreWithDB(memKeys[types.MemStoreKey], sdk.StoreTypeMemory, nil)
+	stateStore.MountStoreWithDB(memKeys[capabilitytypes.MemStoreKey], sdk.StoreTypeMemory, nil)
 	stateStore.MountStoreWithDB(tkeys[paramstypes.TStoreKey], sdk.StoreTypeTransient, nil)
+	stateStore.MountStoreWithDB(tkeys[types.TStoreKey], sdk.StoreTypeTransient, nil)
 	require.NoError(t, stateStore.LoadLatestVersion())
 
 	encodingConfig := cosmoscmd.MakeEncodingConfig(app.ModuleBasics)
@@ -78,18 +85,22 @@ func WormholeKeeper(t testing.TB) (*keeper.Keeper, sdk.Context) {
 		BaseApp: bApp,
 	}
 
+	appapp.CapabilityKeeper = capabilitykeeper.NewKeeper(appCodec, keys[capabilitytypes.StoreKey], memKeys[capabilitytypes.MemStoreKey])
+	scopedWormholeKeeper := appapp.CapabilityKeeper.ScopeToModule(types.ModuleName)
+
 	k := keeper.NewKeeper(
 		appCodec,
 		keys[types.StoreKey],
 		memKeys[types.MemStoreKey],
+		tkeys[types.TStoreKey],
 		accountKeeper,
-		nil,
+		bankKeeper,
+		scopedWormholeKeeper,
 	)
 
 	supportedFeatures := "iterator,staking,stargate"
 	appapp.WormholeKeeper = *k
 
-	appapp.CapabilityKeeper = capabilitykeeper.NewKeeper(appCodec, keys[capabilitytypes.StoreKey], memKeys[capabilitytypes.MemStoreKey])
 	scopedWasmKeeper := appapp.CapabilityKeeper.ScopeToModule(wasm.ModuleName)
 
 	wasmDir, err := ioutil.TempDir("", "")
@@ -0,0 +1,187 @@
+package ante
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/keeper"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+	whkeeper "github.com/wormhole-foundation/wormhole-chain/x/wormhole/keeper"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// VAAExecutionLimitDecorator limits the token bridge VAAs each sender can
+// execute per block to the per-sender limit of the wormhole config, so that a
+// single relayer cannot crowd out the redemptions of everyone else. In CheckTx
+// the counts accumulate until the next commit, which also bounds the share of
+// the mempool a sender can take. Counts of rejected transactions are reverted
+// with the rest of their state.
+type VAAExecutionLimitDecorator struct {
+	k keeper.Keeper
+}
+
+func NewVAAExecutionLimitDecorator(k keeper.Keeper) VAAExecutionLimitDecorator {
+	return VAAExecutionLimitDecorator{k: k}
+}
+
+func (d VAAExecutionLimitDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (sdk.Context, error) {
+	counts := make(map[string]uint32)
+	var senders []string
+	for _, msg := range tx.GetMsgs() {
+		var creator string
+		var n uint32
+		switch msg := msg.(type) {
+		case *types.MsgExecuteVAA:
+			creator, n = msg.Creator, 1
+		case *types.MsgExecuteVAAs:
+			creator, n = msg.Creator, uint32(len(msg.Vaas))
+		default:
+			continue
+		}
+		if _, seen := counts[creator]; !seen {
+			senders = append(senders, creator)
+		}
+		counts[creator] += n
+	}
+
+	for _, creator := range senders {
+		sender, err := sdk.AccAddressFromBech32(creator)
+		if err != nil {
+			return ctx, err
+		}
+		if err := d.k.CountVAAExecutions(ctx, sender, counts[creator]); err != nil {
+			return ctx, err
+		}
+	}
+
+	return next(ctx, tx, simulate)
+}
+
+// VAAPrecheckDecorator rejects transactions executing token bridge VAAs that
+// are already executed or come from an unregistered emitter. It runs ahead of
+// the default ante handler, so such transactions fail before fees are deducted
+// and signatures are verified. These checks only read state and are cheap.
+// The VAAs of a MsgExecuteVAAs batch may fail one by one, so a batch is only
+// rejected if none of its VAAs passes.
+type VAAPrecheckDecorator struct {
+	k keeper.Keeper
+}
+
+func NewVAAPrecheckDecorator(k keeper.Keeper) VAAPrecheckDecorator {
+	return VAAPrecheckDecorator{k: k}
+}
+
+func (d VAAPrecheckDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (sdk.Context, error) {
+	for _, msg := range tx.GetMsgs() {
+		switch msg := msg.(type) {
+		case *types.MsgExecuteVAA:
+			v, err := whkeeper.ParseVAA(msg.Vaa)
+			if err != nil {
+				return ctx, err
+			}
+			if err := d.k.PrecheckVAA(ctx, v); err != nil {
+				return ctx, err
+			}
+		case *types.MsgExecuteVAAs:
+			if _, err := precheckBatch(ctx, d.k, msg); err != nil {
+				return ctx, err
+			}
+		}
+	}
+
+	return next(ctx, tx, simulate)
+}
+
+// VAAVerifyDecorator verifies the signatures of the token bridge VAAs executed
+// by a transaction in CheckTx, which keeps VAAs with invalid signatures out of
+// the mempool. Verifying signatures is expensive, so it must run after the
+// default ante handler has verified the transaction signatures and deducted
+// the fees. Verified VAAs are remembered by the wormhole keeper, so their
+// signatures are not verified again in DeliverTx. Like the precheck, it only
+// rejects a batch of MsgExecuteVAAs if none of the VAAs that pass the precheck
+// is signed by a quorum.
+type VAAVerifyDecorator struct {
+	k keeper.Keeper
+}
+
+func NewVAAVerifyDecorator(k keeper.Keeper) VAAVerifyDecorator {
+	return VAAVerifyDecorator{k: k}
+}
+
+func (d VAAVerifyDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (sdk.Context, error) {
+	if !ctx.IsCheckTx() || simulate {
+		return next(ctx, tx, simulate)
+	}
+
+	for _, msg := range tx.GetMsgs() {
+		switch msg := msg.(type) {
+		case *types.MsgExecuteVAA:
+			v, err := whkeeper.ParseVAA(msg.Vaa)
+			if err != nil {
+				return ctx, err
+			}
+			if err := d.k.VerifyVAA(ctx, v); err != nil {
+				return ctx, err
+			}
+		case *types.MsgExecuteVAAs:
+			vaas, err := precheckBatch(ctx, d.k, msg)
+			if err != nil {
+				return ctx, err
+			}
+			for _, v := range vaas {
+				if err = d.k.VerifyVAA(ctx, v); err == nil {
+					break
+				}
+			}
+			if err != nil {
+				return ctx, err
+			}
+		}
+	}
+
+	return next(ctx, tx, simulate)
+}
+
+// precheckBatch parses and prechecks each VAA of msg and returns those that
+// pass. It fails with the error of the last VAA if none passes.
+func precheckBatch(ctx sdk.Context, k keeper.Keeper, msg *types.MsgExecuteVAAs) ([]*vaa.VAA, error) {
+	var passed []*vaa.VAA
+	var lastErr error
+	for _, bz := range msg.Vaas {
+		v, err := whkeeper.ParseVAA(bz)
+		if err == nil {
+			err = k.PrecheckVAA(ctx, v)
+		}
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		passed = append(passed, v)
+	}
+	if len(passed) == 0 && lastErr != nil {
+		return nil, lastErr
+	}
+	return passed, nil
+}
+
+// NewAnteHandler returns an ante handler that enforces the per-sender VAA
+// execution limit and runs the VAA precheck before next, and verifies the VAA
+// signatures after next succeeded.
+func NewAnteHandler(k keeper.Keeper, next sdk.AnteHandler) sdk.AnteHandler {
+	limit := NewVAAExecutionLimitDecorator(k)
+	precheck := NewVAAPrecheckDecorator(k)
+	verify := NewVAAVerifyDecorator(k)
+	done := func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, error) {
+		return ctx, nil
+	}
+	prechecked := func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, error) {
+		newCtx, err := next(ctx, tx, simulate)
+		if err != nil {
+			return newCtx, err
+		}
+		return verify.AnteHandle(newCtx, tx, simulate, done)
+	}
+	return func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, error) {
+		return limit.AnteHandle(ctx, tx, simulate, func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, error) {
+			return precheck.AnteHandle(ctx, tx, simulate, prechecked)
+		})
+	}
+}
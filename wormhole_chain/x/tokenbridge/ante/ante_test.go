@@ -0,0 +1,220 @@
+package ante_test
+
+import (
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	keepertest "github.com/wormhole-foundation/wormhole-chain/testutil/keeper"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/ante"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+	whtypes "github.com/wormhole-foundation/wormhole-chain/x/wormhole/types"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+type mockTx struct {
+	msgs []sdk.Msg
+}
+
+func (tx mockTx) GetMsgs() []sdk.Msg   { return tx.msgs }
+func (tx mockTx) ValidateBasic() error { return nil }
+
+func TestVAAPrecheckDecorator(t *testing.T) {
+	k, ctx := keepertest.TokenbridgeKeeperWithDeps(t, nil, nil, &mockWormholeKeeper{}, nil, nil)
+
+	emitter := vaa.Address{0x01}
+	k.SetChainRegistration(ctx, types.ChainRegistration{ChainID: uint32(vaa.ChainIDEthereum), EmitterAddress: emitter[:]})
+
+	newVAA := func(chain vaa.ChainID, emitter vaa.Address, sequence uint64) *vaa.VAA {
+		return &vaa.VAA{
+			Version:          vaa.SupportedVAAVersion,
+			Timestamp:        time.Unix(0, 0),
+			EmitterChain:     chain,
+			EmitterAddress:   emitter,
+			Sequence:         sequence,
+			ConsistencyLevel: 1,
+			Payload:          []byte{1},
+		}
+	}
+	executed := newVAA(vaa.ChainIDEthereum, emitter, 1)
+	k.SetReplayProtection(ctx, types.ReplayProtection{Index: executed.HexDigest()})
+
+	tests := []struct {
+		label string
+		msg   sdk.Msg
+		err   error
+	}{
+		{label: "new VAA", msg: executeVAAMsg(t, newVAA(vaa.ChainIDEthereum, emitter, 2))},
+		{label: "executed VAA", msg: executeVAAMsg(t, executed), err: types.ErrVAAAlreadyExecuted},
+		{label: "unregistered chain", msg: executeVAAMsg(t, newVAA(vaa.ChainIDSolana, emitter, 2)), err: types.ErrUnregisteredChain},
+		{label: "unregistered emitter", msg: executeVAAMsg(t, newVAA(vaa.ChainIDEthereum, vaa.Address{0x02}, 2)), err: types.ErrUnregisteredEmitter},
+		{label: "batch with a new VAA", msg: executeVAAsMsg(t, executed, newVAA(vaa.ChainIDEthereum, emitter, 2))},
+		{label: "batch without new VAAs", msg: executeVAAsMsg(t, newVAA(vaa.ChainIDSolana, emitter, 2), executed), err: types.ErrVAAAlreadyExecuted},
+		{label: "other message", msg: &types.MsgExecuteGovernanceVAA{Vaa: []byte{0x01}}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.label, func(t *testing.T) {
+			nextCalled := false
+			next := func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, error) {
+				nextCalled = true
+				return ctx, nil
+			}
+
+			_, err := ante.NewAnteHandler(*k, next)(ctx, mockTx{msgs: []sdk.Msg{tc.msg}}, false)
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				assert.False(t, nextCalled)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, nextCalled)
+		})
+	}
+}
+
+// mockWormholeKeeper counts VAA verifications and rejects VAAs without signatures. It also counts VAA executions per
+// sender and rejects those beyond limit, if set.
+type mockWormholeKeeper struct {
+	types.WormholeKeeper
+	verified   int
+	limit      uint32
+	executions map[string]uint32
+}
+
+func (w *mockWormholeKeeper) VerifyVAA(ctx sdk.Context, v *vaa.VAA) error {
+	w.verified++
+	if len(v.Signatures) == 0 {
+		return whtypes.ErrNoQuorum
+	}
+	return nil
+}
+
+func (w *mockWormholeKeeper) CountVAAExecutions(ctx sdk.Context, sender sdk.AccAddress, n uint32) error {
+	if w.executions == nil {
+		w.executions = make(map[string]uint32)
+	}
+	if w.limit != 0 && w.executions[sender.String()]+n > w.limit {
+		return whtypes.ErrVAAExecutionLimitExceeded
+	}
+	w.executions[sender.String()] += n
+	return nil
+}
+
+func TestVAAPrecheckDecoratorCheckTx(t *testing.T) {
+	wormhole := &mockWormholeKeeper{}
+	k, ctx := keepertest.TokenbridgeKeeperWithDeps(t, nil, nil, wormhole, nil, nil)
+
+	emitter := vaa.Address{0x01}
+	k.SetChainRegistration(ctx, types.ChainRegistration{ChainID: uint32(vaa.ChainIDEthereum), EmitterAddress: emitter[:]})
+
+	v := &vaa.VAA{
+		Version:          vaa.SupportedVAAVersion,
+		Timestamp:        time.Unix(0, 0),
+		EmitterChain:     vaa.ChainIDEthereum,
+		EmitterAddress:   emitter,
+		Sequence:         1,
+		ConsistencyLevel: 1,
+		Payload:          []byte{1},
+	}
+	next := func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, error) { return ctx, nil }
+	handler := ante.NewAnteHandler(*k, next)
+	tx := mockTx{msgs: []sdk.Msg{executeVAAMsg(t, v)}}
+
+	// Signatures are left to the msg server in DeliverTx and when simulating
+	_, err := handler(ctx, tx, false)
+	require.NoError(t, err)
+	_, err = handler(ctx.WithIsCheckTx(true), tx, true)
+	require.NoError(t, err)
+	assert.Zero(t, wormhole.verified)
+
+	_, err = handler(ctx.WithIsCheckTx(true), tx, false)
+	assert.ErrorIs(t, err, whtypes.ErrNoQuorum)
+	assert.Equal(t, 1, wormhole.verified)
+
+	// Signatures are only verified once the default ante handler accepted the
+	// transaction, so unpaid transactions cannot make validators verify them.
+	failing := func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, error) {
+		return ctx, sdkerrors.ErrInsufficientFee
+	}
+	_, err = ante.NewAnteHandler(*k, failing)(ctx.WithIsCheckTx(true), tx, false)
+	assert.ErrorIs(t, err, sdkerrors.ErrInsufficientFee)
+	assert.Equal(t, 1, wormhole.verified)
+
+	// A batch passes if any of its VAAs is signed, and VAAs that fail the
+	// precheck are not verified.
+	signed := *v
+	signed.Sequence = 2
+	signed.Signatures = []*vaa.Signature{{Index: 0}}
+	unregistered := *v
+	unregistered.EmitterChain = vaa.ChainIDSolana
+	_, err = handler(ctx.WithIsCheckTx(true), mockTx{msgs: []sdk.Msg{executeVAAsMsg(t, &unregistered, v)}}, false)
+	assert.ErrorIs(t, err, whtypes.ErrNoQuorum)
+	assert.Equal(t, 2, wormhole.verified)
+	_, err = handler(ctx.WithIsCheckTx(true), mockTx{msgs: []sdk.Msg{executeVAAsMsg(t, v, &unregistered, &signed)}}, false)
+	require.NoError(t, err)
+	assert.Equal(t, 4, wormhole.verified)
+}
+
+func TestVAAExecutionLimitDecorator(t *testing.T) {
+	wormhole := &mockWormholeKeeper{limit: 3}
+	k, ctx := keepertest.TokenbridgeKeeperWithDeps(t, nil, nil, wormhole, nil, nil)
+
+	emitter := vaa.Address{0x01}
+	k.SetChainRegistration(ctx, types.ChainRegistration{ChainID: uint32(vaa.ChainIDEthereum), EmitterAddress: emitter[:]})
+	msg := func(creator string, sequence uint64) sdk.Msg {
+		m := executeVAAMsg(t, &vaa.VAA{
+			Version:          vaa.SupportedVAAVersion,
+			Timestamp:        time.Unix(0, 0),
+			EmitterChain:     vaa.ChainIDEthereum,
+			EmitterAddress:   emitter,
+			Sequence:         sequence,
+			ConsistencyLevel: 1,
+			Payload:          []byte{1},
+		})
+		m.Creator = creator
+		return m
+	}
+	alice, bob := sdk.AccAddress{0x0a}.String(), sdk.AccAddress{0x0b}.String()
+	handler := ante.NewAnteHandler(*k, func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, error) { return ctx, nil })
+
+	// Executions are counted per sender across the messages of a transaction
+	_, err := handler(ctx, mockTx{msgs: []sdk.Msg{msg(alice, 1), msg(bob, 2), msg(alice, 3)}}, false)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2), wormhole.executions[alice])
+	assert.Equal(t, uint32(1), wormhole.executions[bob])
+
+	// Exceeding the limit rejects the whole transaction, without affecting other senders
+	_, err = handler(ctx, mockTx{msgs: []sdk.Msg{msg(alice, 4), msg(alice, 5)}}, false)
+	assert.ErrorIs(t, err, whtypes.ErrVAAExecutionLimitExceeded)
+	_, err = handler(ctx, mockTx{msgs: []sdk.Msg{msg(bob, 6), msg(bob, 7)}}, false)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(3), wormhole.executions[bob])
+
+	// Each VAA of a batch counts as an execution
+	carol := sdk.AccAddress{0x0c}.String()
+	batch := &types.MsgExecuteVAAs{Creator: carol, Vaas: [][]byte{{0x01}, {0x02}, {0x03}, {0x04}}}
+	_, err = handler(ctx, mockTx{msgs: []sdk.Msg{batch}}, false)
+	assert.ErrorIs(t, err, whtypes.ErrVAAExecutionLimitExceeded)
+
+	// Other messages are not limited
+	_, err = handler(ctx, mockTx{msgs: []sdk.Msg{&types.MsgExecuteGovernanceVAA{Vaa: []byte{0x01}}}}, false)
+	require.NoError(t, err)
+}
+
+func executeVAAMsg(t *testing.T, v *vaa.VAA) *types.MsgExecuteVAA {
+	bz, err := v.Marshal()
+	require.NoError(t, err)
+	return &types.MsgExecuteVAA{Creator: sdk.AccAddress{0x01}.String(), Vaa: bz}
+}
+
+func executeVAAsMsg(t *testing.T, vaas ...*vaa.VAA) *types.MsgExecuteVAAs {
+	msg := &types.MsgExecuteVAAs{Creator: sdk.AccAddress{0x01}.String()}
+	for _, v := range vaas {
+		msg.Vaas = append(msg.Vaas, executeVAAMsg(t, v).Vaa)
+	}
+	return msg
+}
@@ -31,6 +31,21 @@ func GetQueryCmd(queryRoute string) *cobra.Command {
 	cmd.AddCommand(CmdShowChainRegistration())
 	cmd.AddCommand(CmdListCoinMetaRollbackProtection())
 	cmd.AddCommand(CmdShowCoinMetaRollbackProtection())
+	cmd.AddCommand(CmdCustodyBalances())
+	cmd.AddCommand(CmdFeeBalances())
+	cmd.AddCommand(CmdOutdatedAttestations())
+	cmd.AddCommand(CmdLegacyDenoms())
+	cmd.AddCommand(CmdListPendingTransfer())
+	cmd.AddCommand(CmdListEscrowedTransfer())
+	cmd.AddCommand(CmdShowEscrowedTransfer())
+	cmd.AddCommand(CmdListRelayer())
+	cmd.AddCommand(CmdShowRelayer())
+	cmd.AddCommand(CmdWrappedAsset())
+	cmd.AddCommand(CmdDecodeVAA())
+	cmd.AddCommand(CmdListLedgerEntry())
+	cmd.AddCommand(CmdShowLedgerEntry())
+	cmd.AddCommand(CmdShowLedgerRedemption())
+	cmd.AddCommand(CmdListLedgerBalance())
 	// this line is used by starport scaffolding # 1
 
 	return cmd
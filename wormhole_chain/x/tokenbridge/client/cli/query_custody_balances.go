@@ -0,0 +1,34 @@
+package cli
+
+import (
+	"context"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/spf13/cobra"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+)
+
+func CmdCustodyBalances() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "custody-balances",
+		Short: "shows the module account balances and how much of each backs wrapped tokens on other chains",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			res, err := queryClient.CustodyBalances(context.Background(), &types.QueryCustodyBalancesRequest{})
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
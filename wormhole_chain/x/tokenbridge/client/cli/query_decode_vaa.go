@@ -0,0 +1,259 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/cobra"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/keeper"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+	whkeeper "github.com/wormhole-foundation/wormhole-chain/x/wormhole/keeper"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// DecodedVAA is the output of the decode-vaa command.
+type DecodedVAA struct {
+	Version          uint8              `json:"version"`
+	GuardianSetIndex uint32             `json:"guardian_set_index"`
+	Signatures       []DecodedSignature `json:"signatures"`
+	Timestamp        time.Time          `json:"timestamp"`
+	Nonce            uint32             `json:"nonce"`
+	Sequence         uint64             `json:"sequence"`
+	ConsistencyLevel uint8              `json:"consistency_level"`
+	EmitterChain     string             `json:"emitter_chain"`
+	EmitterAddress   string             `json:"emitter_address"`
+	Digest           string             `json:"digest"`
+	Payload          DecodedPayload     `json:"payload"`
+}
+
+type DecodedSignature struct {
+	Index     uint8  `json:"index"`
+	Signature string `json:"signature"`
+	// Signer is the address recovered from the signature. It is not checked against any guardian set.
+	Signer string `json:"signer,omitempty"`
+}
+
+// DecodedPayload holds the fields of a token bridge payload. Type is one of transfer, asset_meta, delivery_receipt,
+// governance or unknown; payloads that cannot be decoded are only returned raw.
+type DecodedPayload struct {
+	Type   string            `json:"type"`
+	Fields map[string]string `json:"fields,omitempty"`
+	Raw    string            `json:"raw"`
+}
+
+func CmdDecodeVAA() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "decode-vaa [vaa]",
+		Short: "Decode a hex or base64 encoded VAA without checking it against the chain state",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+
+			vaaBytes, err := parseVAAArg(args[0])
+			if err != nil {
+				return err
+			}
+
+			decoded, err := DecodeVAA(vaaBytes)
+			if err != nil {
+				return err
+			}
+
+			out, err := json.MarshalIndent(decoded, "", "  ")
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintBytes(out)
+		},
+	}
+
+	return cmd
+}
+
+// parseVAAArg decodes a VAA given as hex, with an optional 0x prefix, or as base64.
+func parseVAAArg(arg string) ([]byte, error) {
+	if b, err := hex.DecodeString(strings.TrimPrefix(arg, "0x")); err == nil {
+		return b, nil
+	}
+	b, err := base64.StdEncoding.DecodeString(arg)
+	if err != nil {
+		return nil, fmt.Errorf("VAA is neither hex nor base64 encoded")
+	}
+	return b, nil
+}
+
+// DecodeVAA parses a VAA and decodes its payload. Signers are recovered from the signatures, but neither they nor the
+// payload are validated.
+func DecodeVAA(data []byte) (*DecodedVAA, error) {
+	v, err := whkeeper.ParseVAA(data)
+	if err != nil {
+		return nil, err
+	}
+
+	digest := v.SigningMsg()
+	signatures := make([]DecodedSignature, 0, len(v.Signatures))
+	for _, sig := range v.Signatures {
+		decoded := DecodedSignature{
+			Index:     sig.Index,
+			Signature: hex.EncodeToString(sig.Signature[:]),
+		}
+		if pubKey, err := crypto.SigToPub(digest.Bytes(), sig.Signature[:]); err == nil {
+			decoded.Signer = crypto.PubkeyToAddress(*pubKey).Hex()
+		}
+		signatures = append(signatures, decoded)
+	}
+
+	return &DecodedVAA{
+		Version:          v.Version,
+		GuardianSetIndex: v.GuardianSetIndex,
+		Signatures:       signatures,
+		Timestamp:        v.Timestamp.UTC(),
+		Nonce:            v.Nonce,
+		Sequence:         v.Sequence,
+		ConsistencyLevel: v.ConsistencyLevel,
+		EmitterChain:     v.EmitterChain.String(),
+		EmitterAddress:   v.EmitterAddress.String(),
+		Digest:           v.HexDigest(),
+		Payload:          decodePayload(v.Payload),
+	}, nil
+}
+
+// decodePayload decodes the payload layouts handled by the ExecuteVAA and ExecuteGovernanceVAA messages.
+func decodePayload(payload []byte) DecodedPayload {
+	decoded := DecodedPayload{Type: "unknown", Raw: hex.EncodeToString(payload)}
+
+	if len(payload) >= 35 && bytes.Equal(payload[:32], keeper.TokenBridgeModule[:]) {
+		decoded.Type = "governance"
+		decoded.Fields = decodeGovernance(keeper.GovernanceAction(payload[32]), binary.BigEndian.Uint16(payload[33:35]), payload[35:])
+		return decoded
+	}
+	if len(payload) < 1 {
+		return decoded
+	}
+
+	body := payload[1:]
+	switch keeper.PayloadID(payload[0]) {
+	case keeper.PayloadIDTransfer:
+		if len(body) != 132 {
+			return decoded
+		}
+		toChain := vaa.ChainID(binary.BigEndian.Uint16(body[98:100]))
+		decoded.Type = "transfer"
+		decoded.Fields = map[string]string{
+			"amount":        new(big.Int).SetBytes(body[:32]).String(),
+			"token_address": hex.EncodeToString(body[32:64]),
+			"token_chain":   vaa.ChainID(binary.BigEndian.Uint16(body[64:66])).String(),
+			"to":            hex.EncodeToString(body[66:98]),
+			"to_chain":      toChain.String(),
+			"fee":           new(big.Int).SetBytes(body[100:132]).String(),
+		}
+		if toChain == vaa.ChainIDWormchain {
+			decoded.Fields["recipient"] = sdk.AccAddress(body[78:98]).String()
+		}
+	case keeper.PayloadIDAssetMeta:
+		if len(body) != 99 {
+			return decoded
+		}
+		decoded.Type = "asset_meta"
+		decoded.Fields = map[string]string{
+			"token_address": hex.EncodeToString(body[:32]),
+			"token_chain":   vaa.ChainID(binary.BigEndian.Uint16(body[32:34])).String(),
+			"decimals":      fmt.Sprint(body[34]),
+			"symbol":        strings.Trim(string(body[35:67]), "\x00"),
+			"name":          strings.Trim(string(body[67:99]), "\x00"),
+		}
+	case keeper.PayloadIDTransferWithPayload:
+		if len(body) < 132 {
+			return decoded
+		}
+		toChain := vaa.ChainID(binary.BigEndian.Uint16(body[98:100]))
+		decoded.Type = "transfer_with_payload"
+		decoded.Fields = map[string]string{
+			"amount":        new(big.Int).SetBytes(body[:32]).String(),
+			"token_address": hex.EncodeToString(body[32:64]),
+			"token_chain":   vaa.ChainID(binary.BigEndian.Uint16(body[64:66])).String(),
+			"to":            hex.EncodeToString(body[66:98]),
+			"to_chain":      toChain.String(),
+			"from":          hex.EncodeToString(body[100:132]),
+			"payload":       hex.EncodeToString(body[132:]),
+		}
+		if gateway, err := keeper.ParseGatewayPayload(body[132:]); err == nil && toChain == vaa.ChainIDWormchain {
+			decoded.Fields["gateway_chain_id"] = gateway.ChainID
+			decoded.Fields["gateway_receiver"] = gateway.Receiver
+			decoded.Fields["gateway_memo"] = gateway.Memo
+		}
+	case keeper.PayloadIDDeliveryReceipt:
+		receipt, err := keeper.ParseDeliveryReceipt(payload)
+		if err != nil {
+			return decoded
+		}
+		decoded.Type = "delivery_receipt"
+		decoded.Fields = map[string]string{
+			"digest":          hex.EncodeToString(receipt.Digest[:]),
+			"emitter_chain":   receipt.EmitterChain.String(),
+			"emitter_address": receipt.EmitterAddress.String(),
+			"sequence":        fmt.Sprint(receipt.Sequence),
+			"status":          fmt.Sprint(receipt.Status),
+			"block_height":    fmt.Sprint(receipt.BlockHeight),
+		}
+	}
+	return decoded
+}
+
+func decodeGovernance(action keeper.GovernanceAction, targetChain uint16, payload []byte) map[string]string {
+	fields := map[string]string{
+		"action":       fmt.Sprint(action),
+		"target_chain": vaa.ChainID(targetChain).String(),
+	}
+
+	switch {
+	case action == keeper.ActionRegisterChain && len(payload) == 34:
+		fields["action"] = "register_chain"
+		fields["chain"] = vaa.ChainID(binary.BigEndian.Uint16(payload[:2])).String()
+		fields["emitter_address"] = hex.EncodeToString(payload[2:34])
+	case action == keeper.ActionUpgradeContract && len(payload) == 32:
+		fields["action"] = "upgrade_contract"
+		fields["upgrade_name"] = strings.TrimLeft(string(payload), "\x00")
+	case action == keeper.ActionTransferFees && len(payload) == 96:
+		fields["action"] = "transfer_fees"
+		fields["amount"] = new(big.Int).SetBytes(payload[:32]).String()
+		fields["token_address"] = hex.EncodeToString(payload[32:64])
+		fields["recipient"] = sdk.AccAddress(payload[76:96]).String()
+	case action == keeper.ActionSweepFees && len(payload) == 32:
+		fields["action"] = "sweep_fees"
+		fields["token_address"] = hex.EncodeToString(payload)
+	case action == keeper.ActionRegisterIbcChannel && len(payload) == 128:
+		fields["action"] = "register_ibc_channel"
+		fields["chain_id"] = strings.TrimLeft(string(payload[:64]), "\x00")
+		fields["channel_id"] = strings.TrimLeft(string(payload[64:]), "\x00")
+	case action == keeper.ActionSetWrappedAssetFlags && len(payload) == 35:
+		fields["action"] = "set_wrapped_asset_flags"
+		fields["token_chain"] = vaa.ChainID(binary.BigEndian.Uint16(payload[:2])).String()
+		fields["token_address"] = hex.EncodeToString(payload[2:34])
+		fields["fee_on_transfer"] = fmt.Sprint(payload[34]&types.WrappedAssetFlagFeeOnTransfer != 0)
+		fields["rebasing"] = fmt.Sprint(payload[34]&types.WrappedAssetFlagRebasing != 0)
+	case action == keeper.ActionSetWrappedAssetLocalization:
+		localization, err := types.ParseWrappedAssetLocalization(payload)
+		if err != nil {
+			break
+		}
+		fields["action"] = "set_wrapped_asset_localization"
+		fields["token_chain"] = vaa.ChainID(localization.TokenChain).String()
+		fields["token_address"] = hex.EncodeToString(localization.TokenAddress)
+		fields["asset_list_id"] = localization.AssetListId
+		for _, name := range localization.LocalizedNames {
+			fields["name_"+name.Locale] = name.Name
+		}
+	}
+	return fields
+}
@@ -0,0 +1,188 @@
+package cli_test
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	clitestutil "github.com/cosmos/cosmos-sdk/testutil/cli"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/client/cli"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/keeper"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+func signedTestVAA(t *testing.T, payload []byte) ([]byte, string) {
+	t.Helper()
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	v := &vaa.VAA{
+		Version:          vaa.SupportedVAAVersion,
+		GuardianSetIndex: 1,
+		Timestamp:        time.Unix(1660000000, 0),
+		Nonce:            7,
+		Sequence:         42,
+		ConsistencyLevel: 1,
+		EmitterChain:     vaa.ChainIDEthereum,
+		EmitterAddress:   vaa.Address{31: 0x04},
+		Payload:          payload,
+	}
+	v.AddSignature(key, 3)
+	b, err := v.Marshal()
+	require.NoError(t, err)
+	return b, crypto.PubkeyToAddress(key.PublicKey).Hex()
+}
+
+func TestDecodeVAA(t *testing.T) {
+	recipient := make([]byte, 20)
+	recipient[19] = 0x09
+
+	transfer := make([]byte, 133)
+	transfer[0] = byte(keeper.PayloadIDTransfer)
+	big.NewInt(1000).FillBytes(transfer[1:33])
+	transfer[64] = 0x05
+	binary.BigEndian.PutUint16(transfer[65:67], uint16(vaa.ChainIDSolana))
+	copy(transfer[79:99], recipient)
+	binary.BigEndian.PutUint16(transfer[99:101], uint16(vaa.ChainIDWormchain))
+	big.NewInt(10).FillBytes(transfer[101:133])
+
+	assetMeta := make([]byte, 100)
+	assetMeta[0] = byte(keeper.PayloadIDAssetMeta)
+	binary.BigEndian.PutUint16(assetMeta[33:35], uint16(vaa.ChainIDSolana))
+	assetMeta[35] = 8
+	copy(assetMeta[36:], "SOL")
+	copy(assetMeta[68:], "Solana")
+
+	registration := append(append([]byte{}, keeper.TokenBridgeModule[:]...), byte(keeper.ActionRegisterChain), 0, 0)
+	registration = append(registration, 0, byte(vaa.ChainIDSolana))
+	registration = append(registration, make([]byte, 32)...)
+
+	flags := append(append([]byte{}, keeper.TokenBridgeModule[:]...), byte(keeper.ActionSetWrappedAssetFlags), 0x0c, 0x20)
+	flags = append(flags, 0, byte(vaa.ChainIDEthereum))
+	flags = append(flags, make([]byte, 32)...)
+	flags = append(flags, 0x01)
+
+	localization := append(append([]byte{}, keeper.TokenBridgeModule[:]...), byte(keeper.ActionSetWrappedAssetLocalization), 0x0c, 0x20)
+	localization = append(localization, types.WrappedAssetLocalization{
+		TokenChain:     uint32(vaa.ChainIDEthereum),
+		TokenAddress:   make([]byte, 32),
+		AssetListId:    "weth",
+		LocalizedNames: []types.LocalizedName{{Locale: "en", Name: "Wrapped Ether"}},
+	}.Serialize()...)
+
+	for _, tc := range []struct {
+		desc    string
+		payload []byte
+		typ     string
+		fields  map[string]string
+	}{
+		{
+			desc:    "transfer",
+			payload: transfer,
+			typ:     "transfer",
+			fields: map[string]string{
+				"amount":        "1000",
+				"token_address": "0000000000000000000000000000000000000000000000000000000000000005",
+				"token_chain":   "solana",
+				"to":            "0000000000000000000000000000000000000000000000000000000000000009",
+				"to_chain":      "wormholechain",
+				"fee":           "10",
+				"recipient":     sdk.AccAddress(recipient).String(),
+			},
+		},
+		{
+			desc:    "asset meta",
+			payload: assetMeta,
+			typ:     "asset_meta",
+			fields: map[string]string{
+				"token_address": "0000000000000000000000000000000000000000000000000000000000000000",
+				"token_chain":   "solana",
+				"decimals":      "8",
+				"symbol":        "SOL",
+				"name":          "Solana",
+			},
+		},
+		{
+			desc:    "register chain",
+			payload: registration,
+			typ:     "governance",
+			fields: map[string]string{
+				"action":          "register_chain",
+				"target_chain":    "unset",
+				"chain":           "solana",
+				"emitter_address": "0000000000000000000000000000000000000000000000000000000000000000",
+			},
+		},
+		{
+			desc:    "set wrapped asset flags",
+			payload: flags,
+			typ:     "governance",
+			fields: map[string]string{
+				"action":          "set_wrapped_asset_flags",
+				"target_chain":    "wormholechain",
+				"token_chain":     "ethereum",
+				"token_address":   "0000000000000000000000000000000000000000000000000000000000000000",
+				"fee_on_transfer": "true",
+				"rebasing":        "false",
+			},
+		},
+		{
+			desc:    "set wrapped asset localization",
+			payload: localization,
+			typ:     "governance",
+			fields: map[string]string{
+				"action":        "set_wrapped_asset_localization",
+				"target_chain":  "wormholechain",
+				"token_chain":   "ethereum",
+				"token_address": "0000000000000000000000000000000000000000000000000000000000000000",
+				"asset_list_id": "weth",
+				"name_en":       "Wrapped Ether",
+			},
+		},
+		{
+			desc:    "unknown",
+			payload: []byte{0xff, 0x01},
+			typ:     "unknown",
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			b, signer := signedTestVAA(t, tc.payload)
+
+			decoded, err := cli.DecodeVAA(b)
+			require.NoError(t, err)
+			require.Equal(t, tc.typ, decoded.Payload.Type)
+			require.Equal(t, tc.fields, decoded.Payload.Fields)
+			require.Equal(t, hex.EncodeToString(tc.payload), decoded.Payload.Raw)
+			require.Len(t, decoded.Signatures, 1)
+			require.Equal(t, uint8(3), decoded.Signatures[0].Index)
+			require.Equal(t, signer, decoded.Signatures[0].Signer)
+			require.Equal(t, uint64(42), decoded.Sequence)
+			require.Equal(t, "ethereum", decoded.EmitterChain)
+		})
+	}
+}
+
+func TestCmdDecodeVAA(t *testing.T) {
+	b, signer := signedTestVAA(t, []byte{0xff})
+
+	for _, arg := range []string{hex.EncodeToString(b), "0x" + hex.EncodeToString(b), base64.StdEncoding.EncodeToString(b)} {
+		out, err := clitestutil.ExecTestCLICmd(client.Context{}, cli.CmdDecodeVAA(), []string{arg})
+		require.NoError(t, err)
+
+		var decoded cli.DecodedVAA
+		require.NoError(t, json.Unmarshal(out.Bytes(), &decoded))
+		require.Equal(t, signer, decoded.Signatures[0].Signer)
+	}
+
+	_, err := clitestutil.ExecTestCLICmd(client.Context{}, cli.CmdDecodeVAA(), []string{"not a vaa!"})
+	require.Error(t, err)
+}
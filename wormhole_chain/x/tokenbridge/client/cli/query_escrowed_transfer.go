@@ -0,0 +1,72 @@
+package cli
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+)
+
+func CmdListEscrowedTransfer() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list-escrowed-transfer",
+		Short: "list all transfers escrowed until their asset is registered",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+
+			pageReq, err := client.ReadPageRequest(cmd.Flags())
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			params := &types.QueryAllEscrowedTransferRequest{
+				Pagination: pageReq,
+			}
+
+			res, err := queryClient.EscrowedTransferAll(context.Background(), params)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddPaginationFlagsToCmd(cmd, cmd.Use)
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
+
+func CmdShowEscrowedTransfer() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show-escrowed-transfer [digest]",
+		Short: "shows an escrowed transfer",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			params := &types.QueryGetEscrowedTransferRequest{
+				Digest: args[0],
+			}
+
+			res, err := queryClient.EscrowedTransfer(context.Background(), params)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
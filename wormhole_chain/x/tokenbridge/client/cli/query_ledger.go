@@ -0,0 +1,139 @@
+package cli
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+)
+
+func CmdListLedgerEntry() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list-ledger-entry",
+		Short: "list all entries of the redemption ledger",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+
+			pageReq, err := client.ReadPageRequest(cmd.Flags())
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			params := &types.QueryAllLedgerEntryRequest{
+				Pagination: pageReq,
+			}
+
+			res, err := queryClient.LedgerEntryAll(context.Background(), params)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddPaginationFlagsToCmd(cmd, cmd.Use)
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
+
+func CmdShowLedgerEntry() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show-ledger-entry [id]",
+		Short: "shows an entry of the redemption ledger",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			id, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			params := &types.QueryGetLedgerEntryRequest{
+				Id: id,
+			}
+
+			res, err := queryClient.LedgerEntry(context.Background(), params)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
+
+func CmdShowLedgerRedemption() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show-ledger-redemption [vaa-digest]",
+		Short: "shows the ledger entries of the redemption of a VAA, by its hex digest",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			params := &types.QueryLedgerRedemptionRequest{
+				Redemption: args[0],
+			}
+
+			res, err := queryClient.LedgerRedemption(context.Background(), params)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
+
+func CmdListLedgerBalance() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list-ledger-balance",
+		Short: "list the total debits and credits of every ledger account by denom",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+
+			pageReq, err := client.ReadPageRequest(cmd.Flags())
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			params := &types.QueryAllLedgerBalanceRequest{
+				Pagination: pageReq,
+			}
+
+			res, err := queryClient.LedgerBalanceAll(context.Background(), params)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddPaginationFlagsToCmd(cmd, cmd.Use)
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
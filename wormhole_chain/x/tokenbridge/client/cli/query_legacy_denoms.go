@@ -0,0 +1,34 @@
+package cli
+
+import (
+	"context"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/spf13/cobra"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+)
+
+func CmdLegacyDenoms() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "legacy-denoms",
+		Short: "lists the wrapped denoms in legacy formats that the tokenbridge v4 migration merges into their canonical denom",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			res, err := queryClient.LegacyDenoms(context.Background(), &types.QueryLegacyDenomsRequest{})
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
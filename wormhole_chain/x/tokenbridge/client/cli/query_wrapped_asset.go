@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+)
+
+func CmdWrappedAsset() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "wrapped-asset [token-chain] [token-address]",
+		Short: "shows the denom and metadata of the wrapped asset of a token, with the token address in hex",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			tokenChain, err := strconv.ParseUint(args[0], 10, 16)
+			if err != nil {
+				return err
+			}
+
+			params := &types.QueryWrappedAssetRequest{
+				TokenChain:   uint32(tokenChain),
+				TokenAddress: args[1],
+			}
+
+			res, err := queryClient.WrappedAsset(context.Background(), params)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
@@ -22,8 +22,13 @@ func GetTxCmd() *cobra.Command {
 
 	cmd.AddCommand(CmdExecuteGovernanceVAA())
 	cmd.AddCommand(CmdExecuteVAA())
+	cmd.AddCommand(CmdExecuteVAAs())
 	cmd.AddCommand(CmdAttestToken())
 	cmd.AddCommand(CmdTransfer())
+	cmd.AddCommand(CmdCancelTransfer())
+	cmd.AddCommand(CmdCompleteEscrowedTransfer())
+	cmd.AddCommand(CmdRegisterRelayer())
+	cmd.AddCommand(CmdDeregisterRelayer())
 	// this line is used by starport scaffolding # 1
 
 	return cmd
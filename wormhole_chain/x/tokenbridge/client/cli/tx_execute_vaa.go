@@ -15,6 +15,8 @@ import (
 
 var _ = strconv.Itoa(0)
 
+const flagPostReceipt = "post-receipt"
+
 func CmdExecuteVAA() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "execute-vaa [vaa]",
@@ -36,6 +38,10 @@ func CmdExecuteVAA() *cobra.Command {
 				clientCtx.GetFromAddress().String(),
 				vaaBytes,
 			)
+			msg.PostReceipt, err = cmd.Flags().GetBool(flagPostReceipt)
+			if err != nil {
+				return err
+			}
 			if err := msg.ValidateBasic(); err != nil {
 				return err
 			}
@@ -43,6 +49,7 @@ func CmdExecuteVAA() *cobra.Command {
 		},
 	}
 
+	cmd.Flags().Bool(flagPostReceipt, false, "Post a delivery receipt after redeeming a transfer, paying the message fee")
 	flags.AddTxFlagsToCmd(cmd)
 
 	return cmd
@@ -0,0 +1,53 @@
+package cli
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+)
+
+func CmdExecuteVAAs() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "execute-vaas [vaa] [vaa]...",
+		Short: "Broadcast message ExecuteVAAs, executing each VAA on its own",
+		Args:  cobra.RangeArgs(1, types.MaxExecuteVAAsBatchSize),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			vaas := make([][]byte, len(args))
+			for i, arg := range args {
+				vaas[i], err = hex.DecodeString(arg)
+				if err != nil {
+					return fmt.Errorf("invalid hex of vaa %d: %w", i, err)
+				}
+			}
+
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgExecuteVAAs(
+				clientCtx.GetFromAddress().String(),
+				vaas,
+			)
+			msg.PostReceipt, err = cmd.Flags().GetBool(flagPostReceipt)
+			if err != nil {
+				return err
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	cmd.Flags().Bool(flagPostReceipt, false, "Post a delivery receipt after redeeming each transfer, paying the message fees")
+	flags.AddTxFlagsToCmd(cmd)
+
+	return cmd
+}
@@ -25,6 +25,56 @@ func InitGenesis(ctx sdk.Context, k keeper.Keeper, genState types.GenesisState)
 	for _, elem := range genState.CoinMetaRollbackProtectionList {
 		k.SetCoinMetaRollbackProtection(ctx, elem)
 	}
+	// Set all the custodyBalance
+	for _, elem := range genState.CustodyBalanceList {
+		k.SetCustodyBalance(ctx, elem)
+	}
+	// Set all the ibcChannel
+	for _, elem := range genState.IbcChannelList {
+		k.SetIbcChannel(ctx, elem)
+	}
+	// Set all the gatewayTransfer
+	for _, elem := range genState.GatewayTransferList {
+		k.SetGatewayTransfer(ctx, elem)
+	}
+	// Set all the attestedAssetMeta
+	for _, elem := range genState.AttestedAssetMetaList {
+		k.SetAttestedAssetMeta(ctx, elem)
+	}
+	// Set all the pendingTransfer
+	for _, elem := range genState.PendingTransferList {
+		k.SetPendingTransfer(ctx, elem)
+	}
+	k.SetPendingTransferCount(ctx, genState.PendingTransferCount)
+	// Set all the registrationBounty
+	for _, elem := range genState.RegistrationBountyList {
+		k.SetRegistrationBounty(ctx, elem)
+	}
+	// Set all the escrowedTransfer
+	for _, elem := range genState.EscrowedTransferList {
+		k.SetEscrowedTransfer(ctx, elem)
+	}
+	// Set all the relayer
+	for _, elem := range genState.RelayerList {
+		k.SetRelayer(ctx, elem)
+	}
+	// Set all the wrappedAssetFlags
+	for _, elem := range genState.WrappedAssetFlagsList {
+		k.SetWrappedAssetFlags(ctx, elem)
+	}
+	// Set all the wrappedAssetLocalization
+	for _, elem := range genState.WrappedAssetLocalizationList {
+		k.SetWrappedAssetLocalization(ctx, elem)
+	}
+	// Set all the ledgerEntry
+	for _, elem := range genState.LedgerEntryList {
+		k.SetLedgerEntry(ctx, elem)
+	}
+	k.SetLedgerEntryCount(ctx, genState.LedgerEntryCount)
+	// Set all the ledgerBalance
+	for _, elem := range genState.LedgerBalanceList {
+		k.SetLedgerBalance(ctx, elem)
+	}
 	// this line is used by starport scaffolding # genesis/module/init
 }
 
@@ -40,6 +90,20 @@ func ExportGenesis(ctx sdk.Context, k keeper.Keeper) *types.GenesisState {
 	genesis.ReplayProtectionList = k.GetAllReplayProtection(ctx)
 	genesis.ChainRegistrationList = k.GetAllChainRegistration(ctx)
 	genesis.CoinMetaRollbackProtectionList = k.GetAllCoinMetaRollbackProtection(ctx)
+	genesis.CustodyBalanceList = k.GetAllCustodyBalance(ctx)
+	genesis.IbcChannelList = k.GetAllIbcChannel(ctx)
+	genesis.GatewayTransferList = k.GetAllGatewayTransfer(ctx)
+	genesis.AttestedAssetMetaList = k.GetAllAttestedAssetMeta(ctx)
+	genesis.PendingTransferList = k.GetAllPendingTransfer(ctx)
+	genesis.PendingTransferCount = k.GetPendingTransferCount(ctx)
+	genesis.RegistrationBountyList = k.GetAllRegistrationBounty(ctx)
+	genesis.EscrowedTransferList = k.GetAllEscrowedTransfer(ctx)
+	genesis.RelayerList = k.GetAllRelayer(ctx)
+	genesis.WrappedAssetFlagsList = k.GetAllWrappedAssetFlags(ctx)
+	genesis.WrappedAssetLocalizationList = k.GetAllWrappedAssetLocalization(ctx)
+	genesis.LedgerEntryList = k.GetAllLedgerEntry(ctx)
+	genesis.LedgerEntryCount = k.GetLedgerEntryCount(ctx)
+	genesis.LedgerBalanceList = k.GetAllLedgerBalance(ctx)
 	// this line is used by starport scaffolding # genesis/module/export
 
 	return genesis
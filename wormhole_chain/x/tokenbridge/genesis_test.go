@@ -3,6 +3,7 @@ package tokenbridge_test
 import (
 	"testing"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/stretchr/testify/require"
 	keepertest "github.com/wormhole-foundation/wormhole-chain/testutil/keeper"
 	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge"
@@ -36,6 +37,107 @@ func TestGenesis(t *testing.T) {
 				Index: "1",
 			},
 		},
+		CustodyBalanceList: []types.CustodyBalance{
+			{
+				Denom:  "uatom",
+				Amount: sdk.NewInt(1),
+			},
+			{
+				Denom:  "uosmo",
+				Amount: sdk.NewInt(2),
+			},
+		},
+		AttestedAssetMetaList: []types.AttestedAssetMeta{
+			{
+				Denom: "uatom",
+			},
+			{
+				Denom: "uosmo",
+			},
+		},
+		PendingTransferList: []types.PendingTransfer{
+			{
+				Id:     1,
+				Amount: sdk.NewInt64Coin("uatom", 1),
+				Fee:    sdk.ZeroInt(),
+			},
+		},
+		PendingTransferCount: 2,
+		RegistrationBountyList: []types.RegistrationBounty{
+			{
+				Denom: "b0",
+			},
+			{
+				Denom: "b1",
+			},
+		},
+		EscrowedTransferList: []types.EscrowedTransfer{
+			{
+				Digest: "0",
+				Amount: sdk.ZeroInt(),
+				Fee:    sdk.ZeroInt(),
+			},
+			{
+				Digest: "1",
+				Amount: sdk.ZeroInt(),
+				Fee:    sdk.ZeroInt(),
+			},
+		},
+		RelayerList: []types.Relayer{
+			{
+				Address: "0",
+			},
+			{
+				Address: "1",
+			},
+		},
+		WrappedAssetFlagsList: []types.WrappedAssetFlags{
+			{
+				Denom:         "0",
+				FeeOnTransfer: true,
+			},
+			{
+				Denom:    "1",
+				Rebasing: true,
+			},
+		},
+		WrappedAssetLocalizationList: []types.WrappedAssetLocalization{
+			{
+				Denom:       "0",
+				AssetListId: "usd-coin",
+			},
+			{
+				Denom:          "1",
+				LocalizedNames: []types.LocalizedName{{Locale: "en", Name: "Wrapped Ether"}},
+			},
+		},
+		LedgerEntryList: []types.LedgerEntry{
+			{
+				Id:     1,
+				Kind:   types.LedgerKindMint,
+				Amount: sdk.NewInt64Coin("uworm", 10),
+			},
+			{
+				Id:     2,
+				Kind:   types.PayoutKindPrincipal,
+				Amount: sdk.NewInt64Coin("uworm", 10),
+			},
+		},
+		LedgerEntryCount: 2,
+		LedgerBalanceList: []types.LedgerBalance{
+			{
+				Account: "0",
+				Denom:   "uworm",
+				Debits:  sdk.NewInt(10),
+				Credits: sdk.ZeroInt(),
+			},
+			{
+				Account: "1",
+				Denom:   "uworm",
+				Debits:  sdk.ZeroInt(),
+				Credits: sdk.NewInt(10),
+			},
+		},
 		// this line is used by starport scaffolding # genesis/test/state
 	}
 
@@ -51,5 +153,17 @@ func TestGenesis(t *testing.T) {
 	require.Subset(t, genesisState.ChainRegistrationList, got.ChainRegistrationList)
 	require.Len(t, got.CoinMetaRollbackProtectionList, len(genesisState.CoinMetaRollbackProtectionList))
 	require.Subset(t, genesisState.CoinMetaRollbackProtectionList, got.CoinMetaRollbackProtectionList)
+	require.Len(t, got.CustodyBalanceList, len(genesisState.CustodyBalanceList))
+	require.ElementsMatch(t, genesisState.AttestedAssetMetaList, got.AttestedAssetMetaList)
+	require.Equal(t, genesisState.PendingTransferList, got.PendingTransferList)
+	require.Equal(t, genesisState.PendingTransferCount, got.PendingTransferCount)
+	require.ElementsMatch(t, genesisState.RegistrationBountyList, got.RegistrationBountyList)
+	require.ElementsMatch(t, genesisState.EscrowedTransferList, got.EscrowedTransferList)
+	require.ElementsMatch(t, genesisState.RelayerList, got.RelayerList)
+	require.ElementsMatch(t, genesisState.WrappedAssetFlagsList, got.WrappedAssetFlagsList)
+	require.ElementsMatch(t, genesisState.WrappedAssetLocalizationList, got.WrappedAssetLocalizationList)
+	require.Equal(t, genesisState.LedgerEntryList, got.LedgerEntryList)
+	require.Equal(t, genesisState.LedgerEntryCount, got.LedgerEntryCount)
+	require.ElementsMatch(t, genesisState.LedgerBalanceList, got.LedgerBalanceList)
 	// this line is used by starport scaffolding # genesis/test/assert
 }
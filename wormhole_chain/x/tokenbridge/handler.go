@@ -23,12 +23,27 @@ func NewHandler(k keeper.Keeper) sdk.Handler {
 		case *types.MsgExecuteVAA:
 			res, err := msgServer.ExecuteVAA(sdk.WrapSDKContext(ctx), msg)
 			return sdk.WrapServiceResult(ctx, res, err)
+		case *types.MsgExecuteVAAs:
+			res, err := msgServer.ExecuteVAAs(sdk.WrapSDKContext(ctx), msg)
+			return sdk.WrapServiceResult(ctx, res, err)
 		case *types.MsgAttestToken:
 			res, err := msgServer.AttestToken(sdk.WrapSDKContext(ctx), msg)
 			return sdk.WrapServiceResult(ctx, res, err)
 		case *types.MsgTransfer:
 			res, err := msgServer.Transfer(sdk.WrapSDKContext(ctx), msg)
 			return sdk.WrapServiceResult(ctx, res, err)
+		case *types.MsgCancelTransfer:
+			res, err := msgServer.CancelTransfer(sdk.WrapSDKContext(ctx), msg)
+			return sdk.WrapServiceResult(ctx, res, err)
+		case *types.MsgCompleteEscrowedTransfer:
+			res, err := msgServer.CompleteEscrowedTransfer(sdk.WrapSDKContext(ctx), msg)
+			return sdk.WrapServiceResult(ctx, res, err)
+		case *types.MsgRegisterRelayer:
+			res, err := msgServer.RegisterRelayer(sdk.WrapSDKContext(ctx), msg)
+			return sdk.WrapServiceResult(ctx, res, err)
+		case *types.MsgDeregisterRelayer:
+			res, err := msgServer.DeregisterRelayer(sdk.WrapSDKContext(ctx), msg)
+			return sdk.WrapServiceResult(ctx, res, err)
 			// this line is used by starport scaffolding # 1
 		default:
 			errMsg := fmt.Sprintf("unrecognized %s message type: %T", types.ModuleName, msg)
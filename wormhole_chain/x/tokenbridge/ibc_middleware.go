@@ -0,0 +1,59 @@
+package tokenbridge
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	ibctransfertypes "github.com/cosmos/ibc-go/v3/modules/apps/transfer/types"
+	channeltypes "github.com/cosmos/ibc-go/v3/modules/core/04-channel/types"
+	porttypes "github.com/cosmos/ibc-go/v3/modules/core/05-port/types"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/keeper"
+)
+
+var _ porttypes.IBCModule = GatewayIBCModule{}
+
+// GatewayIBCModule wraps the ICS-20 transfer IBC module to refund the transfers forwarded by the gateway when they
+// fail on the target chain or time out.
+type GatewayIBCModule struct {
+	porttypes.IBCModule
+	keeper keeper.Keeper
+}
+
+// NewGatewayIBCModule wraps the transfer IBC module app.
+func NewGatewayIBCModule(app porttypes.IBCModule, k keeper.Keeper) GatewayIBCModule {
+	return GatewayIBCModule{
+		IBCModule: app,
+		keeper:    k,
+	}
+}
+
+// OnAcknowledgementPacket implements the IBCModule interface. The gateway transfer is refunded after the transfer
+// module has refunded the coins to the gateway account, if the acknowledgement is an error.
+func (im GatewayIBCModule) OnAcknowledgementPacket(
+	ctx sdk.Context,
+	packet channeltypes.Packet,
+	acknowledgement []byte,
+	relayer sdk.AccAddress,
+) error {
+	if err := im.IBCModule.OnAcknowledgementPacket(ctx, packet, acknowledgement, relayer); err != nil {
+		return err
+	}
+
+	var ack channeltypes.Acknowledgement
+	if err := ibctransfertypes.ModuleCdc.UnmarshalJSON(acknowledgement, &ack); err != nil {
+		return sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "cannot unmarshal ICS-20 transfer packet acknowledgement: %v", err)
+	}
+	return im.keeper.OnGatewayPacketResult(ctx, packet.SourcePort, packet.SourceChannel, packet.Sequence, ack.Success())
+}
+
+// OnTimeoutPacket implements the IBCModule interface. The gateway transfer is refunded after the transfer module has
+// refunded the coins to the gateway account.
+func (im GatewayIBCModule) OnTimeoutPacket(
+	ctx sdk.Context,
+	packet channeltypes.Packet,
+	relayer sdk.AccAddress,
+) error {
+	if err := im.IBCModule.OnTimeoutPacket(ctx, packet, relayer); err != nil {
+		return err
+	}
+	return im.keeper.OnGatewayPacketResult(ctx, packet.SourcePort, packet.SourceChannel, packet.Sequence, false)
+}
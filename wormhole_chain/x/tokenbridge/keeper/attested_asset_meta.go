@@ -0,0 +1,86 @@
+package keeper
+
+import (
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+)
+
+// SetAttestedAssetMeta set a specific attestedAssetMeta in the store from its index
+func (k Keeper) SetAttestedAssetMeta(ctx sdk.Context, attestedAssetMeta types.AttestedAssetMeta) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.AttestedAssetMetaKeyPrefix))
+	b := k.cdc.MustMarshal(&attestedAssetMeta)
+	store.Set(types.AttestedAssetMetaKey(
+		attestedAssetMeta.Denom,
+	), b)
+}
+
+// GetAttestedAssetMeta returns a attestedAssetMeta from its index
+func (k Keeper) GetAttestedAssetMeta(
+	ctx sdk.Context,
+	denom string,
+
+) (val types.AttestedAssetMeta, found bool) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.AttestedAssetMetaKeyPrefix))
+
+	b := store.Get(types.AttestedAssetMetaKey(
+		denom,
+	))
+	if b == nil {
+		return val, false
+	}
+
+	k.cdc.MustUnmarshal(b, &val)
+	return val, true
+}
+
+// RemoveAttestedAssetMeta removes a attestedAssetMeta from the store
+func (k Keeper) RemoveAttestedAssetMeta(
+	ctx sdk.Context,
+	denom string,
+
+) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.AttestedAssetMetaKeyPrefix))
+	store.Delete(types.AttestedAssetMetaKey(
+		denom,
+	))
+}
+
+// GetAllAttestedAssetMeta returns all attestedAssetMeta
+func (k Keeper) GetAllAttestedAssetMeta(ctx sdk.Context) (list []types.AttestedAssetMeta) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.AttestedAssetMetaKeyPrefix))
+	iterator := sdk.KVStorePrefixIterator(store, []byte{})
+
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var val types.AttestedAssetMeta
+		k.cdc.MustUnmarshal(iterator.Value(), &val)
+		list = append(list, val)
+	}
+
+	return
+}
+
+// attestationOutdated returns true if the symbol or name of meta differ from its last attestation. The decimals are
+// not compared, since changing them would not be picked up by wrapped assets either way.
+func attestationOutdated(attested types.AttestedAssetMeta, meta banktypes.Metadata) bool {
+	return attested.Symbol != meta.Symbol || attested.Name != meta.Name
+}
+
+// recommendReattestation emits an EventReattestationRecommended if meta changed since it was last attested. Denoms
+// that were never attested are ignored.
+func (k Keeper) recommendReattestation(ctx sdk.Context, meta banktypes.Metadata) error {
+	attested, found := k.GetAttestedAssetMeta(ctx, meta.Base)
+	if !found || !attestationOutdated(attested, meta) {
+		return nil
+	}
+	return ctx.EventManager().EmitTypedEvent(&types.EventReattestationRecommended{
+		Denom:          meta.Base,
+		AttestedSymbol: attested.Symbol,
+		AttestedName:   attested.Name,
+		Symbol:         meta.Symbol,
+		Name:           meta.Name,
+	})
+}
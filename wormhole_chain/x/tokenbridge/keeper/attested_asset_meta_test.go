@@ -0,0 +1,136 @@
+package keeper_test
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	btypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	keepertest "github.com/wormhole-foundation/wormhole-chain/testutil/keeper"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/keeper"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+func createNAttestedAssetMeta(keeper *keeper.Keeper, ctx sdk.Context, n int) []types.AttestedAssetMeta {
+	items := make([]types.AttestedAssetMeta, n)
+	for i := range items {
+		items[i].Denom = "denom" + strconv.Itoa(i)
+		items[i].Symbol = "SYM" + strconv.Itoa(i)
+
+		keeper.SetAttestedAssetMeta(ctx, items[i])
+	}
+	return items
+}
+
+func TestAttestedAssetMetaGet(t *testing.T) {
+	keeper, ctx := keepertest.TokenbridgeKeeper(t)
+	items := createNAttestedAssetMeta(keeper, ctx, 10)
+	for _, item := range items {
+		rst, found := keeper.GetAttestedAssetMeta(ctx,
+			item.Denom,
+		)
+		require.True(t, found)
+		require.Equal(t, item, rst)
+	}
+}
+func TestAttestedAssetMetaRemove(t *testing.T) {
+	keeper, ctx := keepertest.TokenbridgeKeeper(t)
+	items := createNAttestedAssetMeta(keeper, ctx, 10)
+	for _, item := range items {
+		keeper.RemoveAttestedAssetMeta(ctx,
+			item.Denom,
+		)
+		_, found := keeper.GetAttestedAssetMeta(ctx,
+			item.Denom,
+		)
+		require.False(t, found)
+	}
+}
+
+func TestAttestedAssetMetaGetAll(t *testing.T) {
+	keeper, ctx := keepertest.TokenbridgeKeeper(t)
+	items := createNAttestedAssetMeta(keeper, ctx, 10)
+	require.ElementsMatch(t, items, keeper.GetAllAttestedAssetMeta(ctx))
+}
+
+func TestReattestationRecommended(t *testing.T) {
+	msgServer, k, ctx, mocks := setupMockedMsgServer(t)
+	registerWrappedAsset(k, ctx, mocks.bank, vaa.ChainIDEthereum, testTokenAddress)
+	setMeta := func(symbol, name string) {
+		mocks.bank.SetDenomMetaData(ctx, btypes.Metadata{
+			DenomUnits: []*btypes.DenomUnit{
+				{Denom: "uatom", Exponent: 0},
+				{Denom: "atom", Exponent: 6},
+			},
+			Base:    "uatom",
+			Display: "atom",
+			Symbol:  symbol,
+			Name:    name,
+		})
+	}
+	user := sdk.AccAddress(bytes.Repeat([]byte{0xcc}, 20))
+	mocks.bank.balances[user.String()] = sdk.NewCoins(sdk.NewInt64Coin("uatom", 1000))
+
+	// transfer returns the reattestation events emitted by a transfer of uatom.
+	transfer := func() []*types.EventReattestationRecommended {
+		ctx = ctx.WithEventManager(sdk.NewEventManager())
+		_, err := msgServer.Transfer(sdk.WrapSDKContext(ctx), &types.MsgTransfer{
+			Creator:   user.String(),
+			Amount:    sdk.NewInt64Coin("uatom", 100),
+			ToChain:   uint32(vaa.ChainIDEthereum),
+			ToAddress: make([]byte, 32),
+			Fee:       sdk.NewInt64Coin("uatom", 0),
+		})
+		require.NoError(t, err)
+
+		var recommended []*types.EventReattestationRecommended
+		for _, event := range ctx.EventManager().Events() {
+			parsed, err := sdk.ParseTypedEvent(abci.Event(event))
+			if err != nil {
+				continue
+			}
+			if e, ok := parsed.(*types.EventReattestationRecommended); ok {
+				recommended = append(recommended, e)
+			}
+		}
+		return recommended
+	}
+	outdated := func() []types.OutdatedAttestation {
+		res, err := k.OutdatedAttestations(sdk.WrapSDKContext(ctx), &types.QueryOutdatedAttestationsRequest{})
+		require.NoError(t, err)
+		return res.Attestations
+	}
+
+	// Denoms that were never attested are not reported
+	setMeta("ATOM", "Cosmos Hub Atom")
+	assert.Empty(t, transfer())
+
+	_, err := msgServer.AttestToken(sdk.WrapSDKContext(ctx), &types.MsgAttestToken{Creator: user.String(), Denom: "uatom"})
+	require.NoError(t, err)
+	attested, found := k.GetAttestedAssetMeta(ctx, "uatom")
+	require.True(t, found)
+	assert.Equal(t, types.AttestedAssetMeta{Denom: "uatom", Symbol: "ATOM", Name: "Cosmos Hub Atom", Decimals: 6, Height: ctx.BlockHeight()}, attested)
+	assert.Empty(t, transfer())
+	assert.Empty(t, outdated())
+
+	setMeta("ATOM", "Atom")
+	assert.Equal(t, []*types.EventReattestationRecommended{{
+		Denom:          "uatom",
+		AttestedSymbol: "ATOM",
+		AttestedName:   "Cosmos Hub Atom",
+		Symbol:         "ATOM",
+		Name:           "Atom",
+	}}, transfer())
+	assert.Equal(t, []types.OutdatedAttestation{{Attested: attested, Symbol: "ATOM", Name: "Atom"}}, outdated())
+
+	// Attesting the new metadata clears the recommendation
+	_, err = msgServer.AttestToken(sdk.WrapSDKContext(ctx), &types.MsgAttestToken{Creator: user.String(), Denom: "uatom"})
+	require.NoError(t, err)
+	assert.Empty(t, transfer())
+	assert.Empty(t, outdated())
+}
@@ -0,0 +1,92 @@
+package keeper
+
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+)
+
+// SetCustodyBalance set a specific custodyBalance in the store from its index
+func (k Keeper) SetCustodyBalance(ctx sdk.Context, custodyBalance types.CustodyBalance) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.CustodyBalanceKeyPrefix))
+	b := k.cdc.MustMarshal(&custodyBalance)
+	store.Set(types.CustodyBalanceKey(
+		custodyBalance.Denom,
+	), b)
+}
+
+// GetCustodyBalance returns a custodyBalance from its index
+func (k Keeper) GetCustodyBalance(
+	ctx sdk.Context,
+	denom string,
+
+) (val types.CustodyBalance, found bool) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.CustodyBalanceKeyPrefix))
+
+	b := store.Get(types.CustodyBalanceKey(
+		denom,
+	))
+	if b == nil {
+		return val, false
+	}
+
+	k.cdc.MustUnmarshal(b, &val)
+	return val, true
+}
+
+// RemoveCustodyBalance removes a custodyBalance from the store
+func (k Keeper) RemoveCustodyBalance(
+	ctx sdk.Context,
+	denom string,
+
+) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.CustodyBalanceKeyPrefix))
+	store.Delete(types.CustodyBalanceKey(
+		denom,
+	))
+}
+
+// GetAllCustodyBalance returns all custodyBalance
+func (k Keeper) GetAllCustodyBalance(ctx sdk.Context) (list []types.CustodyBalance) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.CustodyBalanceKeyPrefix))
+	iterator := sdk.KVStorePrefixIterator(store, []byte{})
+
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var val types.CustodyBalance
+		k.cdc.MustUnmarshal(iterator.Value(), &val)
+		list = append(list, val)
+	}
+
+	return
+}
+
+// lockNativeCoin records that coin was locked in the module account when it was
+// sent to another chain.
+func (k Keeper) lockNativeCoin(ctx sdk.Context, coin sdk.Coin) {
+	custody, found := k.GetCustodyBalance(ctx, coin.Denom)
+	if !found {
+		custody = types.CustodyBalance{Denom: coin.Denom, Amount: sdk.ZeroInt()}
+	}
+	custody.Amount = custody.Amount.Add(coin.Amount)
+	k.SetCustodyBalance(ctx, custody)
+}
+
+// unlockNativeCoin releases coin from custody when it is redeemed from another
+// chain. It fails if more is redeemed than was ever locked.
+func (k Keeper) unlockNativeCoin(ctx sdk.Context, coin sdk.Coin) error {
+	custody, found := k.GetCustodyBalance(ctx, coin.Denom)
+	if !found || custody.Amount.LT(coin.Amount) {
+		return fmt.Errorf("%w: cannot unlock %s", types.ErrInsufficientCustody, coin)
+	}
+	custody.Amount = custody.Amount.Sub(coin.Amount)
+	if custody.Amount.IsZero() {
+		k.RemoveCustodyBalance(ctx, coin.Denom)
+	} else {
+		k.SetCustodyBalance(ctx, custody)
+	}
+	return nil
+}
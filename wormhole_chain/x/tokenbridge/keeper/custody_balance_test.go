@@ -0,0 +1,162 @@
+package keeper_test
+
+import (
+	"bytes"
+	"math/big"
+	"strconv"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	btypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	keepertest "github.com/wormhole-foundation/wormhole-chain/testutil/keeper"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/keeper"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+func createNCustodyBalance(keeper *keeper.Keeper, ctx sdk.Context, n int) []types.CustodyBalance {
+	items := make([]types.CustodyBalance, n)
+	for i := range items {
+		items[i].Denom = "denom" + strconv.Itoa(i)
+		items[i].Amount = sdk.NewInt(int64(i + 1))
+
+		keeper.SetCustodyBalance(ctx, items[i])
+	}
+	return items
+}
+
+func TestCustodyBalanceGet(t *testing.T) {
+	keeper, ctx := keepertest.TokenbridgeKeeper(t)
+	items := createNCustodyBalance(keeper, ctx, 10)
+	for _, item := range items {
+		rst, found := keeper.GetCustodyBalance(ctx,
+			item.Denom,
+		)
+		require.True(t, found)
+		require.Equal(t, item.Denom, rst.Denom)
+		require.True(t, item.Amount.Equal(rst.Amount))
+	}
+}
+func TestCustodyBalanceRemove(t *testing.T) {
+	keeper, ctx := keepertest.TokenbridgeKeeper(t)
+	items := createNCustodyBalance(keeper, ctx, 10)
+	for _, item := range items {
+		keeper.RemoveCustodyBalance(ctx,
+			item.Denom,
+		)
+		_, found := keeper.GetCustodyBalance(ctx,
+			item.Denom,
+		)
+		require.False(t, found)
+	}
+}
+
+func TestCustodyBalanceGetAll(t *testing.T) {
+	keeper, ctx := keepertest.TokenbridgeKeeper(t)
+	items := createNCustodyBalance(keeper, ctx, 10)
+	require.Len(t, keeper.GetAllCustodyBalance(ctx), len(items))
+}
+
+func TestNativeCustody(t *testing.T) {
+	msgServer, k, ctx, mocks := setupMockedMsgServer(t)
+	registerWrappedAsset(k, ctx, mocks.bank, vaa.ChainIDEthereum, testTokenAddress)
+	mocks.bank.SetDenomMetaData(ctx, btypes.Metadata{
+		DenomUnits: []*btypes.DenomUnit{
+			{Denom: "uatom", Exponent: 0},
+			{Denom: "atom", Exponent: 6},
+		},
+		Base:    "uatom",
+		Display: "atom",
+	})
+	tokenAddress, err := types.PadStringToByte32("uatom")
+	require.NoError(t, err)
+
+	user := sdk.AccAddress(bytes.Repeat([]byte{0xcc}, 20))
+	to := sdk.AccAddress(bytes.Repeat([]byte{0xaa}, 20))
+	mocks.bank.balances[user.String()] = sdk.NewCoins(sdk.NewInt64Coin("uatom", 1000))
+
+	redeem := func(amount int64) error {
+		payload := createTransferPayload(big.NewInt(amount), big.NewInt(0), uint16(vaa.ChainIDWormchain), tokenAddress, to, uint16(vaa.ChainIDWormchain))
+		_, err := msgServer.ExecuteVAA(sdk.WrapSDKContext(ctx), &types.MsgExecuteVAA{
+			Vaa: createTransferVAA(t, payload),
+		})
+		return err
+	}
+	invariant := keeper.CustodyBalancesInvariant(*k)
+
+	// Nothing was locked yet, so nothing can be redeemed
+	assert.ErrorIs(t, redeem(100), types.ErrInsufficientCustody)
+
+	_, err = msgServer.Transfer(sdk.WrapSDKContext(ctx), &types.MsgTransfer{
+		Creator:   user.String(),
+		Amount:    sdk.NewInt64Coin("uatom", 400),
+		ToChain:   uint32(vaa.ChainIDEthereum),
+		ToAddress: make([]byte, 32),
+		Fee:       sdk.NewInt64Coin("uatom", 0),
+	})
+	require.NoError(t, err)
+
+	custody, found := k.GetCustodyBalance(ctx, "uatom")
+	require.True(t, found)
+	assert.Equal(t, "400", custody.Amount.String())
+	_, broken := invariant(ctx)
+	assert.False(t, broken)
+
+	require.NoError(t, redeem(300))
+	custody, _ = k.GetCustodyBalance(ctx, "uatom")
+	assert.Equal(t, "100", custody.Amount.String())
+	assert.Equal(t, "300", mocks.bank.GetBalance(ctx, to, "uatom").Amount.String())
+
+	// Only 100 are left in custody
+	assert.ErrorIs(t, redeem(200), types.ErrInsufficientCustody)
+
+	// Coins leaving the module account without going through the bridge break the invariant
+	moduleAddress := mockAccountKeeper{}.GetModuleAddress(types.ModuleName)
+	require.NoError(t, mocks.bank.SendCoins(ctx, moduleAddress, user, sdk.NewCoins(sdk.NewInt64Coin("uatom", 50))))
+	_, broken = invariant(ctx)
+	assert.True(t, broken)
+}
+
+func TestMigrate2to3(t *testing.T) {
+	msgServer, k, ctx, mocks := setupMockedMsgServer(t)
+	wrapped := registerWrappedAsset(k, ctx, mocks.bank, vaa.ChainIDEthereum, testTokenAddress)
+	mocks.bank.SetDenomMetaData(ctx, btypes.Metadata{
+		DenomUnits: []*btypes.DenomUnit{
+			{Denom: "uatom", Exponent: 0},
+			{Denom: "atom", Exponent: 6},
+		},
+		Base:    "uatom",
+		Display: "atom",
+	})
+	tokenAddress, err := types.PadStringToByte32("uatom")
+	require.NoError(t, err)
+
+	// Coins locked before custody balances were tracked
+	moduleAddress := mockAccountKeeper{}.GetModuleAddress(types.ModuleName)
+	mocks.bank.balances[moduleAddress.String()] = sdk.NewCoins(sdk.NewInt64Coin("uatom", 500), sdk.NewInt64Coin(wrapped, 100))
+
+	to := sdk.AccAddress(bytes.Repeat([]byte{0xaa}, 20))
+	redeem := func(amount int64) error {
+		payload := createTransferPayload(big.NewInt(amount), big.NewInt(0), uint16(vaa.ChainIDWormchain), tokenAddress, to, uint16(vaa.ChainIDWormchain))
+		_, err := msgServer.ExecuteVAA(sdk.WrapSDKContext(ctx), &types.MsgExecuteVAA{
+			Vaa: createTransferVAA(t, payload),
+		})
+		return err
+	}
+	assert.ErrorIs(t, redeem(100), types.ErrInsufficientCustody)
+
+	require.NoError(t, keeper.NewMigrator(*k).Migrate2to3(ctx))
+
+	custody, found := k.GetCustodyBalance(ctx, "uatom")
+	require.True(t, found)
+	assert.Equal(t, "500", custody.Amount.String())
+	_, found = k.GetCustodyBalance(ctx, wrapped)
+	assert.False(t, found, "wrapped coins are minted and burned rather than held in custody")
+	_, broken := keeper.CustodyBalancesInvariant(*k)(ctx)
+	assert.False(t, broken)
+
+	require.NoError(t, redeem(100))
+	assert.Equal(t, "100", mocks.bank.GetBalance(ctx, to, "uatom").Amount.String())
+}
@@ -0,0 +1,85 @@
+package keeper
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// DeliveryStatus is the outcome of a redemption reported by a delivery receipt.
+type DeliveryStatus uint8
+
+var (
+	// DeliveryStatusRedeemed reports that the transfer was paid out on wormhole chain.
+	DeliveryStatusRedeemed DeliveryStatus = 1
+	// DeliveryStatusForwarded reports that the transfer was redeemed by the gateway and forwarded over IBC. The IBC
+	// transfer may still fail, in which case it is refunded to the sender.
+	DeliveryStatusForwarded DeliveryStatus = 2
+)
+
+// deliveryReceiptLength is the length of a delivery receipt payload, including the payload ID.
+const deliveryReceiptLength = 84
+
+// DeliveryReceipt is posted by the token bridge after redeeming a transfer for an ExecuteVAA message that requests
+// one, so that applications on the origin chain can confirm the delivery from the receipt VAA.
+type DeliveryReceipt struct {
+	// Digest, EmitterChain, EmitterAddress and Sequence identify the redeemed VAA.
+	Digest         [32]byte
+	EmitterChain   vaa.ChainID
+	EmitterAddress vaa.Address
+	Sequence       uint64
+	Status         DeliveryStatus
+	// BlockHeight is the height of the wormhole chain block the transfer was redeemed in.
+	BlockHeight uint64
+}
+
+// Serialize encodes the receipt as a token bridge payload with the PayloadIDDeliveryReceipt payload ID.
+func (r DeliveryReceipt) Serialize() []byte {
+	buf := new(bytes.Buffer)
+	buf.WriteByte(byte(PayloadIDDeliveryReceipt))
+	buf.Write(r.Digest[:])
+	MustWrite(buf, binary.BigEndian, uint16(r.EmitterChain))
+	buf.Write(r.EmitterAddress[:])
+	MustWrite(buf, binary.BigEndian, r.Sequence)
+	buf.WriteByte(byte(r.Status))
+	MustWrite(buf, binary.BigEndian, r.BlockHeight)
+	return buf.Bytes()
+}
+
+// ParseDeliveryReceipt decodes a delivery receipt payload.
+func ParseDeliveryReceipt(payload []byte) (*DeliveryReceipt, error) {
+	if len(payload) != deliveryReceiptLength || PayloadID(payload[0]) != PayloadIDDeliveryReceipt {
+		return nil, types.ErrVAAPayloadInvalid
+	}
+	r := &DeliveryReceipt{
+		EmitterChain: vaa.ChainID(binary.BigEndian.Uint16(payload[33:35])),
+		Sequence:     binary.BigEndian.Uint64(payload[67:75]),
+		Status:       DeliveryStatus(payload[75]),
+		BlockHeight:  binary.BigEndian.Uint64(payload[76:84]),
+	}
+	copy(r.Digest[:], payload[1:33])
+	copy(r.EmitterAddress[:], payload[35:67])
+	return r, nil
+}
+
+// postDeliveryReceipt posts a receipt for the redemption of v with the token bridge emitter. The message fee is paid
+// by payer.
+func (k Keeper) postDeliveryReceipt(ctx sdk.Context, v *vaa.VAA, status DeliveryStatus, payer sdk.AccAddress) error {
+	receipt := DeliveryReceipt{
+		EmitterChain:   v.EmitterChain,
+		EmitterAddress: v.EmitterAddress,
+		Sequence:       v.Sequence,
+		Status:         status,
+		BlockHeight:    uint64(ctx.BlockHeight()),
+	}
+	copy(receipt.Digest[:], v.SigningMsg().Bytes())
+
+	emitterAddress, emitterCap, err := k.emitterCapability(ctx)
+	if err != nil {
+		return err
+	}
+	return k.wormholeKeeper.PostMessage(ctx, emitterCap, emitterAddress, payer, 0, receipt.Serialize())
+}
@@ -0,0 +1,42 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	capabilitytypes "github.com/cosmos/cosmos-sdk/x/capability/types"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+	whtypes "github.com/wormhole-foundation/wormhole-chain/x/wormhole/types"
+)
+
+func (k Keeper) emitterAddress() whtypes.EmitterAddress {
+	return whtypes.EmitterAddressFromAccAddress(k.accountKeeper.GetModuleAddress(types.ModuleName))
+}
+
+// BindEmitter binds the emitter address of the token bridge and claims the
+// capability to post messages from it, unless it was claimed already, e.g. by
+// a genesis that was exported with it. It is called from InitGenesis and the
+// upgrade handlers, as the wormhole module does not bind emitters from within
+// a transaction.
+func (k Keeper) BindEmitter(ctx sdk.Context) error {
+	emitterAddress := k.emitterAddress()
+	name := whtypes.EmitterCapabilityName(emitterAddress)
+	if _, found := k.scopedKeeper.GetCapability(ctx, name); found {
+		return nil
+	}
+
+	capability, err := k.wormholeKeeper.BindEmitter(ctx, emitterAddress)
+	if err != nil {
+		return err
+	}
+	return k.scopedKeeper.ClaimCapability(ctx, capability, name)
+}
+
+// emitterCapability returns the emitter address of the token bridge and the
+// capability to post messages from it, which BindEmitter claimed.
+func (k Keeper) emitterCapability(ctx sdk.Context) (whtypes.EmitterAddress, *capabilitytypes.Capability, error) {
+	emitterAddress := k.emitterAddress()
+	capability, found := k.scopedKeeper.GetCapability(ctx, whtypes.EmitterCapabilityName(emitterAddress))
+	if !found {
+		return whtypes.EmitterAddress{}, nil, types.ErrEmitterNotBound
+	}
+	return emitterAddress, capability, nil
+}
@@ -0,0 +1,60 @@
+package keeper_test
+
+import (
+	"bytes"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	btypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+	whtypes "github.com/wormhole-foundation/wormhole-chain/x/wormhole/types"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+func TestBindEmitter(t *testing.T) {
+	msgServer, k, ctx, mocks := setupMockedMsgServer(t)
+	k.SetChainRegistration(ctx, types.ChainRegistration{ChainID: uint32(vaa.ChainIDEthereum), EmitterAddress: testEmitter[:]})
+	mocks.bank.SetDenomMetaData(ctx, btypes.Metadata{
+		DenomUnits: []*btypes.DenomUnit{
+			{Denom: "uatom", Exponent: 0},
+			{Denom: "atom", Exponent: 6},
+		},
+		Base:    "uatom",
+		Display: "atom",
+	})
+
+	user := sdk.AccAddress(bytes.Repeat([]byte{0xcc}, 20))
+	mocks.bank.balances[user.String()] = sdk.NewCoins(sdk.NewInt64Coin("uatom", 1000))
+	transfer := func() error {
+		_, err := msgServer.Transfer(sdk.WrapSDKContext(ctx), &types.MsgTransfer{
+			Creator:   user.String(),
+			Amount:    sdk.NewInt64Coin("uatom", 100),
+			ToChain:   uint32(vaa.ChainIDEthereum),
+			ToAddress: make([]byte, 32),
+			Fee:       sdk.NewInt64Coin("uatom", 0),
+		})
+		return err
+	}
+
+	// Binding again, e.g. in a later upgrade, keeps the claimed capability
+	require.NoError(t, k.BindEmitter(ctx))
+	for i := 0; i < 2; i++ {
+		require.NoError(t, transfer())
+	}
+	assert.Len(t, mocks.wormhole.messages, 2)
+
+	emitter := whtypes.EmitterAddressFromAccAddress(authtypes.NewModuleAddress(types.ModuleName))
+	name := whtypes.EmitterCapabilityName(emitter)
+	assert.Len(t, mocks.wormhole.capabilities, 1)
+	claimed, found := mocks.scoped.GetCapability(ctx, name)
+	require.True(t, found)
+	assert.Same(t, mocks.wormhole.capabilities[name], claimed)
+
+	// Transactions do not bind the emitter themselves
+	delete(mocks.scoped.capabilities, name)
+	assert.ErrorIs(t, transfer(), types.ErrEmitterNotBound)
+	assert.Len(t, mocks.wormhole.messages, 2)
+}
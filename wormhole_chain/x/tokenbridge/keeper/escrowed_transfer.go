@@ -0,0 +1,165 @@
+package keeper
+
+import (
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+	whtypes "github.com/wormhole-foundation/wormhole-chain/x/wormhole/types"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// SetEscrowedTransfer set a specific escrowedTransfer in the store from its index
+func (k Keeper) SetEscrowedTransfer(ctx sdk.Context, escrowedTransfer types.EscrowedTransfer) {
+	expiryStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.EscrowedTransferExpiryKeyPrefix))
+	if old, found := k.GetEscrowedTransfer(ctx, escrowedTransfer.Digest); found {
+		expiryStore.Delete(types.EscrowedTransferExpiryKey(old.ExpiryHeight, old.Digest))
+	}
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.EscrowedTransferKeyPrefix))
+	b := k.cdc.MustMarshal(&escrowedTransfer)
+	store.Set(types.EscrowedTransferKey(
+		escrowedTransfer.Digest,
+	), b)
+	expiryStore.Set(types.EscrowedTransferExpiryKey(escrowedTransfer.ExpiryHeight, escrowedTransfer.Digest), []byte(escrowedTransfer.Digest))
+}
+
+// GetEscrowedTransfer returns a escrowedTransfer from its index
+func (k Keeper) GetEscrowedTransfer(
+	ctx sdk.Context,
+	digest string,
+
+) (val types.EscrowedTransfer, found bool) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.EscrowedTransferKeyPrefix))
+
+	b := store.Get(types.EscrowedTransferKey(
+		digest,
+	))
+	if b == nil {
+		return val, false
+	}
+
+	k.cdc.MustUnmarshal(b, &val)
+	return val, true
+}
+
+// RemoveEscrowedTransfer removes a escrowedTransfer from the store
+func (k Keeper) RemoveEscrowedTransfer(
+	ctx sdk.Context,
+	digest string,
+
+) {
+	escrowed, found := k.GetEscrowedTransfer(ctx, digest)
+	if !found {
+		return
+	}
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.EscrowedTransferKeyPrefix))
+	store.Delete(types.EscrowedTransferKey(
+		digest,
+	))
+	expiryStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.EscrowedTransferExpiryKeyPrefix))
+	expiryStore.Delete(types.EscrowedTransferExpiryKey(escrowed.ExpiryHeight, digest))
+}
+
+// GetAllEscrowedTransfer returns all escrowedTransfer
+func (k Keeper) GetAllEscrowedTransfer(ctx sdk.Context) (list []types.EscrowedTransfer) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.EscrowedTransferKeyPrefix))
+	iterator := sdk.KVStorePrefixIterator(store, []byte{})
+
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var val types.EscrowedTransfer
+		k.cdc.MustUnmarshal(iterator.Value(), &val)
+		list = append(list, val)
+	}
+
+	return
+}
+
+// escrowTransfer escrows the transfer VAA v of a wrapped asset that is not registered, if the config enables it, and
+// returns ErrAssetNotRegistered otherwise. The VAA counts as executed while it is escrowed.
+func (k Keeper) escrowTransfer(ctx sdk.Context, logger log.Logger, v *vaa.VAA) error {
+	config, _ := k.GetConfig(ctx)
+	if config.UnregisteredTransferEscrowPeriod == 0 {
+		return types.ErrAssetNotRegistered
+	}
+
+	// The payload was validated by redeemTransfer
+	t, err := parseTransfer(v)
+	if err != nil {
+		return err
+	}
+	if types.IsWORMToken(t.tokenChain, t.tokenAddress) {
+		return types.ErrAssetNotRegistered
+	}
+
+	escrowed := types.EscrowedTransfer{
+		Digest:         t.digest,
+		Denom:          "b" + types.GetWrappedCoinIdentifier(t.tokenChain, t.tokenAddress),
+		ExpiryHeight:   ctx.BlockHeight() + int64(config.UnregisteredTransferEscrowPeriod),
+		EmitterChain:   uint32(t.emitterChain),
+		EmitterAddress: t.emitterAddress[:],
+		Sequence:       t.sequence,
+		TokenChain:     uint32(t.tokenChain),
+		TokenAddress:   t.tokenAddress[:],
+		To:             t.to[:],
+		Amount:         sdk.NewIntFromBigInt(t.amount),
+		Fee:            sdk.NewIntFromBigInt(t.fee),
+	}
+	k.SetEscrowedTransfer(ctx, escrowed)
+	logger.Info("escrowed transfer of unregistered asset", "denom", escrowed.Denom, "expiry_height", escrowed.ExpiryHeight)
+
+	return ctx.EventManager().EmitTypedEvent(&types.EventTransferEscrowed{
+		Digest:       escrowed.Digest,
+		Denom:        escrowed.Denom,
+		ExpiryHeight: escrowed.ExpiryHeight,
+	})
+}
+
+// escrowedTransfer returns the transfer of an escrowed transfer. The VAA was validated when it was escrowed, and only
+// transfers to this chain are escrowed.
+func escrowedTransfer(escrowed types.EscrowedTransfer, wormholeConfig whtypes.Config) transfer {
+	t := transfer{
+		digest:       escrowed.Digest,
+		emitterChain: vaa.ChainID(escrowed.EmitterChain),
+		sequence:     escrowed.Sequence,
+		amount:       escrowed.Amount.BigInt(),
+		tokenChain:   uint16(escrowed.TokenChain),
+		toChain:      uint16(wormholeConfig.ChainId),
+		fee:          escrowed.Fee.BigInt(),
+	}
+	copy(t.emitterAddress[:], escrowed.EmitterAddress)
+	copy(t.tokenAddress[:], escrowed.TokenAddress)
+	copy(t.to[:], escrowed.To)
+	return t
+}
+
+// ExpireEscrowedTransfers drops the escrowed transfers that expired. Their VAAs can be executed again, e.g. once the
+// asset is registered. Only the transfers that are due are read, from the index by expiry height.
+func (k Keeper) ExpireEscrowedTransfers(ctx sdk.Context) {
+	expiryStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.EscrowedTransferExpiryKeyPrefix))
+	iterator := expiryStore.Iterator(nil, types.EscrowedTransferExpiryHeightKey(ctx.BlockHeight()+1))
+	var due []string
+	for ; iterator.Valid(); iterator.Next() {
+		due = append(due, string(iterator.Value()))
+	}
+	iterator.Close()
+
+	for _, digest := range due {
+		escrowed, found := k.GetEscrowedTransfer(ctx, digest)
+		if !found {
+			continue
+		}
+		k.RemoveEscrowedTransfer(ctx, escrowed.Digest)
+		k.RemoveReplayProtection(ctx, escrowed.Digest)
+		k.Logger(ctx).Info("escrowed transfer expired", "digest", escrowed.Digest, "denom", escrowed.Denom)
+
+		err := ctx.EventManager().EmitTypedEvent(&types.EventEscrowedTransferExpired{
+			Digest: escrowed.Digest,
+			Denom:  escrowed.Denom,
+		})
+		if err != nil {
+			k.Logger(ctx).Error("failed to emit event", "error", err)
+		}
+	}
+}
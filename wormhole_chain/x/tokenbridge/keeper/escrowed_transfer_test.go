@@ -0,0 +1,146 @@
+package keeper_test
+
+import (
+	"bytes"
+	"math/big"
+	"strconv"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	keepertest "github.com/wormhole-foundation/wormhole-chain/testutil/keeper"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/keeper"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+func createNEscrowedTransfer(keeper *keeper.Keeper, ctx sdk.Context, n int) []types.EscrowedTransfer {
+	items := make([]types.EscrowedTransfer, n)
+	for i := range items {
+		items[i].Digest = strconv.Itoa(i)
+		items[i].ExpiryHeight = int64(i)
+		items[i].Amount = sdk.ZeroInt()
+		items[i].Fee = sdk.ZeroInt()
+
+		keeper.SetEscrowedTransfer(ctx, items[i])
+	}
+	return items
+}
+
+func TestEscrowedTransferGet(t *testing.T) {
+	keeper, ctx := keepertest.TokenbridgeKeeper(t)
+	items := createNEscrowedTransfer(keeper, ctx, 10)
+	for _, item := range items {
+		rst, found := keeper.GetEscrowedTransfer(ctx,
+			item.Digest,
+		)
+		require.True(t, found)
+		require.Equal(t, item, rst)
+	}
+}
+
+func TestEscrowedTransferRemove(t *testing.T) {
+	keeper, ctx := keepertest.TokenbridgeKeeper(t)
+	items := createNEscrowedTransfer(keeper, ctx, 10)
+	for _, item := range items {
+		keeper.RemoveEscrowedTransfer(ctx,
+			item.Digest,
+		)
+		_, found := keeper.GetEscrowedTransfer(ctx,
+			item.Digest,
+		)
+		require.False(t, found)
+	}
+}
+
+func TestEscrowedTransfer(t *testing.T) {
+	msgServer, k, ctx, mocks := setupMockedMsgServer(t)
+	ctx = ctx.WithBlockHeight(100)
+	k.SetConfig(ctx, types.Config{UnregisteredTransferEscrowPeriod: 10})
+	k.SetChainRegistration(ctx, types.ChainRegistration{ChainID: uint32(vaa.ChainIDEthereum), EmitterAddress: testEmitter[:]})
+
+	to := sdk.AccAddress(bytes.Repeat([]byte{0xaa}, 20))
+	relayer := sdk.AccAddress(bytes.Repeat([]byte{0xbb}, 20))
+	escrow := func(amount int64) string {
+		payload := createTransferPayload(big.NewInt(amount), big.NewInt(10), uint16(vaa.ChainIDEthereum), testTokenAddress, to, uint16(vaa.ChainIDWormchain))
+		vaaBz := createTransferVAA(t, payload)
+		_, err := msgServer.ExecuteVAA(sdk.WrapSDKContext(ctx), &types.MsgExecuteVAA{Creator: relayer.String(), Vaa: vaaBz})
+		require.NoError(t, err)
+		v, err := vaa.Unmarshal(vaaBz)
+		require.NoError(t, err)
+		return v.HexDigest()
+	}
+	complete := func(digest string) error {
+		_, err := msgServer.CompleteEscrowedTransfer(sdk.WrapSDKContext(ctx), &types.MsgCompleteEscrowedTransfer{Creator: relayer.String(), Digest: digest})
+		return err
+	}
+
+	digest := escrow(100)
+	escrowed, found := k.GetEscrowedTransfer(ctx, digest)
+	require.True(t, found)
+	assert.Equal(t, int64(110), escrowed.ExpiryHeight)
+	denom := "b" + types.GetWrappedCoinIdentifier(uint16(vaa.ChainIDEthereum), testTokenAddress)
+	assert.Equal(t, denom, escrowed.Denom)
+	assert.Equal(t, sdk.NewInt(100), escrowed.Amount)
+	assert.Equal(t, sdk.NewInt(10), escrowed.Fee)
+	assert.Equal(t, to, sdk.AccAddress(escrowed.To))
+	_, found = k.GetReplayProtection(ctx, digest)
+	assert.True(t, found)
+	assert.Zero(t, mocks.bank.sends)
+
+	// The transfer can only be completed once the asset is registered
+	assert.ErrorIs(t, complete(digest), types.ErrAssetNotRegistered)
+	registerWrappedAsset(k, ctx, mocks.bank, vaa.ChainIDEthereum, testTokenAddress)
+	require.NoError(t, complete(digest))
+	assert.Equal(t, "90", mocks.bank.GetBalance(ctx, to, denom).Amount.String())
+	assert.Equal(t, "10", mocks.bank.GetBalance(ctx, relayer, denom).Amount.String())
+	_, found = k.GetEscrowedTransfer(ctx, digest)
+	assert.False(t, found)
+	assert.ErrorIs(t, complete(digest), types.ErrUnknownEscrowedTransfer)
+
+	// Expired transfers are dropped and their VAA can be executed again
+	delete(mocks.bank.metadata, denom)
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	digest = escrow(200)
+	ctx = ctx.WithBlockHeight(109)
+	k.ExpireEscrowedTransfers(ctx)
+	_, found = k.GetEscrowedTransfer(ctx, digest)
+	assert.True(t, found)
+
+	ctx = ctx.WithBlockHeight(110)
+	assert.ErrorIs(t, complete(digest), types.ErrEscrowedTransferExpired)
+	k.ExpireEscrowedTransfers(ctx)
+	_, found = k.GetEscrowedTransfer(ctx, digest)
+	assert.False(t, found)
+	_, found = k.GetReplayProtection(ctx, digest)
+	assert.False(t, found)
+
+	var events []interface{}
+	for _, event := range ctx.EventManager().Events() {
+		parsed, err := sdk.ParseTypedEvent(abci.Event(event))
+		if err != nil {
+			continue
+		}
+		switch e := parsed.(type) {
+		case *types.EventTransferEscrowed, *types.EventEscrowedTransferExpired:
+			events = append(events, e)
+		}
+	}
+	assert.Equal(t, []interface{}{
+		&types.EventTransferEscrowed{Digest: digest, Denom: denom, ExpiryHeight: 110},
+		&types.EventEscrowedTransferExpired{Digest: digest, Denom: denom},
+	}, events)
+}
+
+func TestEscrowedTransferDisabled(t *testing.T) {
+	msgServer, k, ctx, _ := setupMockedMsgServer(t)
+	k.SetChainRegistration(ctx, types.ChainRegistration{ChainID: uint32(vaa.ChainIDEthereum), EmitterAddress: testEmitter[:]})
+
+	to := sdk.AccAddress(bytes.Repeat([]byte{0xaa}, 20))
+	payload := createTransferPayload(big.NewInt(100), big.NewInt(0), uint16(vaa.ChainIDEthereum), testTokenAddress, to, uint16(vaa.ChainIDWormchain))
+	_, err := msgServer.ExecuteVAA(sdk.WrapSDKContext(ctx), &types.MsgExecuteVAA{Vaa: createTransferVAA(t, payload)})
+	assert.ErrorIs(t, err, types.ErrAssetNotRegistered)
+	assert.Empty(t, k.GetAllEscrowedTransfer(ctx))
+}
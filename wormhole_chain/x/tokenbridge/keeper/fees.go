@@ -0,0 +1,39 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+)
+
+// FeeAddress returns the address of the account fees are collected in. It is kept apart from the module account so
+// that fee flows never mix with the coins held in custody.
+func (k Keeper) FeeAddress(ctx sdk.Context) sdk.AccAddress {
+	name := types.DefaultFeeAccountName
+	if config, found := k.GetConfig(ctx); found && config.FeeAccountName != "" {
+		name = config.FeeAccountName
+	}
+	return authtypes.NewModuleAddress(name)
+}
+
+// sweepFees moves the balance of a native denom held by the module account that neither backs wrapped tokens on
+// other chains nor pending transfers, like coins sent to the module account directly, to the fee account.
+func (k Keeper) sweepFees(ctx sdk.Context, denom string) (sdk.Coin, error) {
+	moduleAddress := k.accountKeeper.GetModuleAddress(types.ModuleName)
+	available := k.bankKeeper.GetBalance(ctx, moduleAddress, denom).Amount
+	if custody, found := k.GetCustodyBalance(ctx, denom); found {
+		available = available.Sub(custody.Amount)
+	}
+	available = available.Sub(k.GetPendingTransferAmount(ctx, denom))
+	if !available.IsPositive() {
+		return sdk.Coin{}, fmt.Errorf("%w: %s", types.ErrNoFeesToSweep, denom)
+	}
+
+	swept := sdk.NewCoin(denom, available)
+	if err := k.bankKeeper.SendCoins(ctx, moduleAddress, k.FeeAddress(ctx), sdk.NewCoins(swept)); err != nil {
+		return sdk.Coin{}, err
+	}
+	return swept, nil
+}
@@ -0,0 +1,272 @@
+package keeper
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	ibctransfertypes "github.com/cosmos/ibc-go/v3/modules/apps/transfer/types"
+	clienttypes "github.com/cosmos/ibc-go/v3/modules/core/02-client/types"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+	whtypes "github.com/wormhole-foundation/wormhole-chain/x/wormhole/types"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// GatewayTransferTimeout is how long an IBC transfer of the gateway can take to be received on the target chain
+// before it times out and is refunded.
+const GatewayTransferTimeout = time.Hour
+
+// GatewayAddress returns the address transfers with a gateway payload must be sent to, and that forwards them over
+// IBC.
+func GatewayAddress() sdk.AccAddress {
+	return authtypes.NewModuleAddress(types.GatewayAccountName)
+}
+
+// GatewayPayload is the payload of a transfer with payload (payload ID 3) that the gateway forwards over IBC to
+// Receiver on the chain with the given ChainID.
+//
+// It is encoded as the length (u8) and bytes of ChainID, the length (u8) and bytes of Receiver, and the length (u16)
+// and bytes of Memo. The memo is only emitted with the EventGatewayTransfer event, as the ICS-20 packets of this IBC
+// version do not have a memo.
+type GatewayPayload struct {
+	ChainID  string
+	Receiver string
+	Memo     string
+}
+
+// Serialize encodes the gateway payload.
+func (p GatewayPayload) Serialize() []byte {
+	buf := new(bytes.Buffer)
+	buf.WriteByte(uint8(len(p.ChainID)))
+	buf.WriteString(p.ChainID)
+	buf.WriteByte(uint8(len(p.Receiver)))
+	buf.WriteString(p.Receiver)
+	MustWrite(buf, binary.BigEndian, uint16(len(p.Memo)))
+	buf.WriteString(p.Memo)
+	return buf.Bytes()
+}
+
+// ParseGatewayPayload decodes a gateway payload, and checks that it names a chain and a bech32 receiver.
+func ParseGatewayPayload(data []byte) (*GatewayPayload, error) {
+	var p GatewayPayload
+	offset := 0
+
+	if len(data) < offset+1 || len(data) < offset+1+int(data[offset]) {
+		return nil, types.ErrInvalidGatewayPayload
+	}
+	p.ChainID = string(data[offset+1 : offset+1+int(data[offset])])
+	offset += 1 + len(p.ChainID)
+
+	if len(data) < offset+1 || len(data) < offset+1+int(data[offset]) {
+		return nil, types.ErrInvalidGatewayPayload
+	}
+	p.Receiver = string(data[offset+1 : offset+1+int(data[offset])])
+	offset += 1 + len(p.Receiver)
+
+	if len(data) < offset+2 {
+		return nil, types.ErrInvalidGatewayPayload
+	}
+	memoLength := int(binary.BigEndian.Uint16(data[offset : offset+2]))
+	offset += 2
+	if len(data) != offset+memoLength {
+		return nil, types.ErrInvalidGatewayPayload
+	}
+	p.Memo = string(data[offset:])
+
+	if p.ChainID == "" {
+		return nil, fmt.Errorf("%w: empty chain ID", types.ErrInvalidGatewayPayload)
+	}
+	if _, _, err := bech32.DecodeAndConvert(p.Receiver); err != nil {
+		return nil, fmt.Errorf("%w: invalid receiver: %s", types.ErrInvalidGatewayPayload, err)
+	}
+	return &p, nil
+}
+
+// executeGatewayTransfer redeems a transfer with a gateway payload, and forwards it over the IBC channel registered
+// for the target chain of the payload. The transfer is refunded to its sender on the origin chain if the IBC transfer
+// fails or times out.
+func (k Keeper) executeGatewayTransfer(ctx sdk.Context, logger log.Logger, msg *types.MsgExecuteVAA, v *vaa.VAA, wormholeConfig whtypes.Config, payload []byte) error {
+	if k.transferKeeper == nil || k.channelKeeper == nil {
+		return types.ErrGatewayDisabled
+	}
+
+	// Payload: amount (32) | token address (32) | token chain (2) | to (32) | to chain (2) | from (32) | payload
+	if len(payload) < 132 {
+		return types.ErrVAAPayloadInvalid
+	}
+	unnormalizedAmount := new(big.Int).SetBytes(payload[:32])
+	var tokenAddress [32]byte
+	copy(tokenAddress[:], payload[32:64])
+	tokenChain := binary.BigEndian.Uint16(payload[64:66])
+	to := payload[66:98]
+	toChain := binary.BigEndian.Uint16(payload[98:100])
+	from := payload[100:132]
+
+	if uint32(toChain) != wormholeConfig.ChainId {
+		return types.ErrInvalidTargetChain
+	}
+	gateway := GatewayAddress()
+	if !bytes.Equal(to[:12], make([]byte, 12)) || !bytes.Equal(to[12:], gateway) {
+		return types.ErrInvalidGatewayRecipient
+	}
+	gatewayPayload, err := ParseGatewayPayload(payload[132:])
+	if err != nil {
+		return err
+	}
+	channel, found := k.GetIbcChannel(ctx, gatewayPayload.ChainID)
+	if !found {
+		return fmt.Errorf("%w: %s", types.ErrUnknownIbcChain, gatewayPayload.ChainID)
+	}
+	sequence, found := k.channelKeeper.GetNextSequenceSend(ctx, ibctransfertypes.PortID, channel.ChannelID)
+	if !found {
+		return fmt.Errorf("%w: channel %s of %s does not exist", types.ErrUnknownIbcChain, channel.ChannelID, channel.ChainID)
+	}
+
+	identifier, wrapped, meta, err := k.redeemedDenom(ctx, logger, wormholeConfig, tokenChain, tokenAddress)
+	if err != nil {
+		return err
+	}
+	amount, err := redeemedAmount(identifier, unnormalizedAmount, meta)
+	if err != nil {
+		return err
+	}
+	if err := k.releaseRedeemedCoin(ctx, v.HexDigest(), amount, wrapped); err != nil {
+		return err
+	}
+
+	// The module account is not allowed to send IBC transfers, so they are sent from the gateway account.
+	moduleAddress := k.accountKeeper.GetModuleAddress(types.ModuleName)
+	if err := k.bankKeeper.SendCoins(ctx, moduleAddress, gateway, sdk.Coins{amount}); err != nil {
+		return fmt.Errorf("failed to send %s to the gateway account: %w", amount, err)
+	}
+	k.appendLedgerEntry(ctx, v.HexDigest(), types.LedgerKindGateway, gateway.String(), moduleAddress.String(), amount)
+	timeout := uint64(ctx.BlockTime().Add(GatewayTransferTimeout).UnixNano())
+	err = k.transferKeeper.SendTransfer(ctx, ibctransfertypes.PortID, channel.ChannelID, amount, gateway, gatewayPayload.Receiver, clienttypes.ZeroHeight(), timeout)
+	if err != nil {
+		return fmt.Errorf("failed to send IBC transfer: %w", err)
+	}
+
+	k.SetGatewayTransfer(ctx, types.GatewayTransfer{
+		ChannelID:    channel.ChannelID,
+		Sequence:     sequence,
+		Amount:       amount,
+		OriginChain:  uint32(v.EmitterChain),
+		OriginSender: from,
+		Payer:        msg.Creator,
+	})
+	logger.Info("forwarded transfer over IBC",
+		"chain_id", gatewayPayload.ChainID,
+		"channel", channel.ChannelID,
+		"sequence", sequence,
+		"receiver", gatewayPayload.Receiver,
+		"amount", amount.String())
+
+	err = ctx.EventManager().EmitTypedEvent(&types.EventGatewayTransfer{
+		ChainID:   gatewayPayload.ChainID,
+		ChannelID: channel.ChannelID,
+		Sequence:  sequence,
+		Receiver:  gatewayPayload.Receiver,
+		Memo:      gatewayPayload.Memo,
+		Amount:    amount.Amount.String(),
+		Denom:     amount.Denom,
+	})
+	if err != nil {
+		return err
+	}
+
+	if msg.PostReceipt {
+		payer, err := sdk.AccAddressFromBech32(msg.Creator)
+		if err != nil {
+			return err
+		}
+		if err := k.postDeliveryReceipt(ctx, v, DeliveryStatusForwarded, payer); err != nil {
+			return fmt.Errorf("failed to post delivery receipt: %w", err)
+		}
+	}
+	return nil
+}
+
+// OnGatewayPacketResult is called when an IBC transfer packet is acknowledged or times out. A failed transfer of the
+// gateway is refunded to its sender on the origin chain, by posting a transfer back with the token bridge emitter. If
+// the refund cannot be posted, the coins stay with the gateway account and the error is emitted with the
+// EventGatewayRefund event.
+func (k Keeper) OnGatewayPacketResult(ctx sdk.Context, portID, channelID string, sequence uint64, success bool) error {
+	if portID != ibctransfertypes.PortID {
+		return nil
+	}
+	transfer, found := k.GetGatewayTransfer(ctx, channelID, sequence)
+	if !found {
+		return nil
+	}
+	k.RemoveGatewayTransfer(ctx, channelID, sequence)
+	if success {
+		return nil
+	}
+
+	event := &types.EventGatewayRefund{
+		ChannelID:    transfer.ChannelID,
+		Sequence:     transfer.Sequence,
+		OriginChain:  transfer.OriginChain,
+		OriginSender: transfer.OriginSender,
+		Amount:       transfer.Amount.Amount.String(),
+		Denom:        transfer.Amount.Denom,
+	}
+	cacheCtx, write := ctx.CacheContext()
+	cacheCtx = cacheCtx.WithEventManager(sdk.NewEventManager())
+	if err := k.refundGatewayTransfer(cacheCtx, transfer); err != nil {
+		k.Logger(ctx).Error("failed to refund gateway transfer",
+			"channel", channelID,
+			"sequence", sequence,
+			"amount", transfer.Amount.String(),
+			"error", err)
+		event.Error = err.Error()
+	} else {
+		write()
+		ctx.EventManager().EmitEvents(cacheCtx.EventManager().Events())
+	}
+	return ctx.EventManager().EmitTypedEvent(event)
+}
+
+// refundGatewayTransfer moves the coins of a failed IBC transfer back to the module account, and posts a transfer of
+// them to the sender on the origin chain.
+func (k Keeper) refundGatewayTransfer(ctx sdk.Context, transfer types.GatewayTransfer) error {
+	wormholeConfig, ok := k.wormholeKeeper.GetConfig(ctx)
+	if !ok {
+		return whtypes.ErrNoConfig
+	}
+	meta, found := k.bankKeeper.GetDenomMetaData(ctx, transfer.Amount.Denom)
+	if !found {
+		return types.ErrNoDenomMetadata
+	}
+	amount, err := types.Truncate(transfer.Amount, meta)
+	if err != nil {
+		return err
+	}
+	var payer sdk.AccAddress
+	if transfer.Payer != "" {
+		if payer, err = sdk.AccAddressFromBech32(transfer.Payer); err != nil {
+			return err
+		}
+	}
+
+	moduleAddress := k.accountKeeper.GetModuleAddress(types.ModuleName)
+	if err := k.bankKeeper.SendCoins(ctx, GatewayAddress(), moduleAddress, sdk.Coins{transfer.Amount}); err != nil {
+		return err
+	}
+	if _, _, wrapped := types.GetWrappedCoinMeta(transfer.Amount.Denom); wrapped {
+		if err := k.bankKeeper.BurnCoins(ctx, types.ModuleName, sdk.Coins{transfer.Amount}); err != nil {
+			return err
+		}
+	} else {
+		k.lockNativeCoin(ctx, transfer.Amount)
+	}
+
+	_, err = k.postTransferMessage(ctx, wormholeConfig, payer, amount, sdk.ZeroInt(), uint16(transfer.OriginChain), transfer.OriginSender)
+	return err
+}
@@ -0,0 +1,190 @@
+package keeper_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math/big"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	ibctransfertypes "github.com/cosmos/ibc-go/v3/modules/apps/transfer/types"
+	clienttypes "github.com/cosmos/ibc-go/v3/modules/core/02-client/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/keeper"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// ibcEscrow stands in for the escrow account of the transfer module.
+var ibcEscrow = authtypes.NewModuleAddress("ibc-escrow")
+
+type sentTransfer struct {
+	channel  string
+	token    sdk.Coin
+	sender   sdk.AccAddress
+	receiver string
+}
+
+// mockTransferKeeper escrows the sent coins with the mocked bank keeper and records the transfers.
+type mockTransferKeeper struct {
+	bank      *mockBankKeeper
+	transfers []sentTransfer
+}
+
+func (m *mockTransferKeeper) SendTransfer(ctx sdk.Context, sourcePort, sourceChannel string, token sdk.Coin, sender sdk.AccAddress, receiver string, timeoutHeight clienttypes.Height, timeoutTimestamp uint64) error {
+	if err := m.bank.SendCoins(ctx, sender, ibcEscrow, sdk.Coins{token}); err != nil {
+		return err
+	}
+	m.transfers = append(m.transfers, sentTransfer{channel: sourceChannel, token: token, sender: sender, receiver: receiver})
+	return nil
+}
+
+// mockChannelKeeper knows the next send sequence of its channels.
+type mockChannelKeeper struct {
+	sequences map[string]uint64
+}
+
+func (m *mockChannelKeeper) GetNextSequenceSend(ctx sdk.Context, portID, channelID string) (uint64, bool) {
+	sequence, found := m.sequences[channelID]
+	return sequence, found
+}
+
+func createGatewayTransferPayload(amount *big.Int, tokenChain uint16, tokenAddress [32]byte, to sdk.AccAddress, from [32]byte, gatewayPayload []byte) []byte {
+	payload := make([]byte, 133, 133+len(gatewayPayload))
+	payload[0] = byte(keeper.PayloadIDTransferWithPayload)
+	amount.FillBytes(payload[1:33])
+	copy(payload[33:65], tokenAddress[:])
+	binary.BigEndian.PutUint16(payload[65:67], tokenChain)
+	copy(payload[79:99], to)
+	binary.BigEndian.PutUint16(payload[99:101], uint16(vaa.ChainIDWormchain))
+	copy(payload[101:133], from[:])
+	return append(payload, gatewayPayload...)
+}
+
+func testReceiver(t *testing.T) string {
+	receiver, err := bech32.ConvertAndEncode("osmo", bytes.Repeat([]byte{0xcc}, 20))
+	require.NoError(t, err)
+	return receiver
+}
+
+func TestGatewayPayloadRoundTrip(t *testing.T) {
+	p := keeper.GatewayPayload{ChainID: "osmosis-1", Receiver: testReceiver(t), Memo: "swap"}
+	parsed, err := keeper.ParseGatewayPayload(p.Serialize())
+	require.NoError(t, err)
+	assert.Equal(t, p, *parsed)
+
+	serialized := p.Serialize()
+	_, err = keeper.ParseGatewayPayload(serialized[:len(serialized)-1])
+	assert.ErrorIs(t, err, types.ErrInvalidGatewayPayload)
+	_, err = keeper.ParseGatewayPayload(append(serialized, 0))
+	assert.ErrorIs(t, err, types.ErrInvalidGatewayPayload)
+	_, err = keeper.ParseGatewayPayload(keeper.GatewayPayload{ChainID: "osmosis-1", Receiver: "invalid"}.Serialize())
+	assert.ErrorIs(t, err, types.ErrInvalidGatewayPayload)
+	_, err = keeper.ParseGatewayPayload(keeper.GatewayPayload{Receiver: testReceiver(t)}.Serialize())
+	assert.ErrorIs(t, err, types.ErrInvalidGatewayPayload)
+}
+
+func TestExecuteVAAGatewayTransfer(t *testing.T) {
+	from := [32]byte{31: 0xaa}
+	receiver := testReceiver(t)
+
+	tests := []struct {
+		label   string
+		to      sdk.AccAddress
+		chainID string
+		err     error
+	}{
+		{label: "forwarded", to: keeper.GatewayAddress(), chainID: "osmosis-1"},
+		{label: "unknown chain", to: keeper.GatewayAddress(), chainID: "cosmoshub-4", err: types.ErrUnknownIbcChain},
+		{label: "not the gateway", to: sdk.AccAddress(bytes.Repeat([]byte{0xaa}, 20)), chainID: "osmosis-1", err: types.ErrInvalidRedeemer},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.label, func(t *testing.T) {
+			msgServer, k, ctx, mocks := setupMockedMsgServer(t)
+			transferKeeper := &mockTransferKeeper{bank: mocks.bank}
+			k.SetIBCKeepers(transferKeeper, &mockChannelKeeper{sequences: map[string]uint64{"channel-0": 7}})
+			msgServer = keeper.NewMsgServerImpl(*k)
+			k.SetIbcChannel(ctx, types.IbcChannel{ChainID: "osmosis-1", ChannelID: "channel-0"})
+			denom := registerWrappedAsset(k, ctx, mocks.bank, vaa.ChainIDEthereum, testTokenAddress)
+
+			gatewayPayload := keeper.GatewayPayload{ChainID: tc.chainID, Receiver: receiver, Memo: "memo"}.Serialize()
+			payload := createGatewayTransferPayload(big.NewInt(100), uint16(vaa.ChainIDEthereum), testTokenAddress, tc.to, from, gatewayPayload)
+			_, err := msgServer.ExecuteVAA(sdk.WrapSDKContext(ctx), &types.MsgExecuteVAA{Vaa: createTransferVAA(t, payload)})
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				assert.Empty(t, transferKeeper.transfers)
+				return
+			}
+			require.NoError(t, err)
+
+			require.Len(t, transferKeeper.transfers, 1)
+			assert.Equal(t, sentTransfer{
+				channel:  "channel-0",
+				token:    sdk.NewInt64Coin(denom, 100),
+				sender:   keeper.GatewayAddress(),
+				receiver: receiver,
+			}, transferKeeper.transfers[0])
+			assert.Equal(t, int64(100), mocks.bank.GetBalance(ctx, ibcEscrow, denom).Amount.Int64())
+
+			transfer, found := k.GetGatewayTransfer(ctx, "channel-0", 7)
+			require.True(t, found)
+			assert.Equal(t, uint32(vaa.ChainIDEthereum), transfer.OriginChain)
+			assert.Equal(t, from[:], transfer.OriginSender)
+		})
+	}
+}
+
+func TestExecuteVAAGatewayDisabled(t *testing.T) {
+	msgServer, k, ctx, mocks := setupMockedMsgServer(t)
+	registerWrappedAsset(k, ctx, mocks.bank, vaa.ChainIDEthereum, testTokenAddress)
+
+	gatewayPayload := keeper.GatewayPayload{ChainID: "osmosis-1", Receiver: testReceiver(t)}.Serialize()
+	payload := createGatewayTransferPayload(big.NewInt(100), uint16(vaa.ChainIDEthereum), testTokenAddress, keeper.GatewayAddress(), [32]byte{}, gatewayPayload)
+	_, err := msgServer.ExecuteVAA(sdk.WrapSDKContext(ctx), &types.MsgExecuteVAA{Vaa: createTransferVAA(t, payload)})
+	assert.ErrorIs(t, err, types.ErrGatewayDisabled)
+}
+
+func TestOnGatewayPacketResult(t *testing.T) {
+	from := [32]byte{31: 0xaa}
+
+	for _, success := range []bool{true, false} {
+		msgServer, k, ctx, mocks := setupMockedMsgServer(t)
+		k.SetIBCKeepers(&mockTransferKeeper{bank: mocks.bank}, &mockChannelKeeper{sequences: map[string]uint64{"channel-0": 1}})
+		msgServer = keeper.NewMsgServerImpl(*k)
+		k.SetIbcChannel(ctx, types.IbcChannel{ChainID: "osmosis-1", ChannelID: "channel-0"})
+		denom := registerWrappedAsset(k, ctx, mocks.bank, vaa.ChainIDEthereum, testTokenAddress)
+
+		gatewayPayload := keeper.GatewayPayload{ChainID: "osmosis-1", Receiver: testReceiver(t)}.Serialize()
+		payload := createGatewayTransferPayload(big.NewInt(100), uint16(vaa.ChainIDEthereum), testTokenAddress, keeper.GatewayAddress(), from, gatewayPayload)
+		_, err := msgServer.ExecuteVAA(sdk.WrapSDKContext(ctx), &types.MsgExecuteVAA{Vaa: createTransferVAA(t, payload)})
+		require.NoError(t, err)
+
+		if !success {
+			// The transfer module refunds the gateway account before the hook runs
+			require.NoError(t, mocks.bank.SendCoins(ctx, ibcEscrow, keeper.GatewayAddress(), sdk.NewCoins(sdk.NewInt64Coin(denom, 100))))
+		}
+		require.NoError(t, k.OnGatewayPacketResult(ctx, ibctransfertypes.PortID, "channel-0", 1, success))
+
+		_, found := k.GetGatewayTransfer(ctx, "channel-0", 1)
+		assert.False(t, found)
+		if success {
+			assert.Empty(t, mocks.wormhole.messages)
+			continue
+		}
+
+		// The refund burns the wrapped coins and posts a transfer back to the sender
+		assert.True(t, mocks.bank.GetAllBalances(ctx, keeper.GatewayAddress()).IsZero())
+		assert.True(t, mocks.bank.GetAllBalances(ctx, authtypes.NewModuleAddress(types.ModuleName)).IsZero())
+		require.Len(t, mocks.wormhole.messages, 1)
+		refund := mocks.wormhole.messages[0]
+		require.Len(t, refund, 133)
+		assert.Equal(t, byte(keeper.PayloadIDTransfer), refund[0])
+		assert.Equal(t, int64(100), new(big.Int).SetBytes(refund[1:33]).Int64())
+		assert.Equal(t, from[:], refund[67:99])
+		assert.Equal(t, uint16(vaa.ChainIDEthereum), binary.BigEndian.Uint16(refund[99:101]))
+	}
+}
@@ -0,0 +1,65 @@
+package keeper
+
+import (
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+)
+
+// SetGatewayTransfer set a specific gatewayTransfer in the store from its index
+func (k Keeper) SetGatewayTransfer(ctx sdk.Context, gatewayTransfer types.GatewayTransfer) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.GatewayTransferKeyPrefix))
+	b := k.cdc.MustMarshal(&gatewayTransfer)
+	store.Set(types.GatewayTransferKey(
+		gatewayTransfer.ChannelID,
+		gatewayTransfer.Sequence,
+	), b)
+}
+
+// GetGatewayTransfer returns a gatewayTransfer from its index
+func (k Keeper) GetGatewayTransfer(
+	ctx sdk.Context,
+	channelID string,
+	sequence uint64,
+
+) (val types.GatewayTransfer, found bool) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.GatewayTransferKeyPrefix))
+
+	b := store.Get(types.GatewayTransferKey(channelID, sequence))
+	if b == nil {
+		return val, false
+	}
+
+	k.cdc.MustUnmarshal(b, &val)
+	return val, true
+}
+
+// RemoveGatewayTransfer removes a gatewayTransfer from the store
+func (k Keeper) RemoveGatewayTransfer(
+	ctx sdk.Context,
+	channelID string,
+	sequence uint64,
+
+) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.GatewayTransferKeyPrefix))
+	store.Delete(types.GatewayTransferKey(
+		channelID,
+		sequence,
+	))
+}
+
+// GetAllGatewayTransfer returns all gatewayTransfer
+func (k Keeper) GetAllGatewayTransfer(ctx sdk.Context) (list []types.GatewayTransfer) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.GatewayTransferKeyPrefix))
+	iterator := sdk.KVStorePrefixIterator(store, []byte{})
+
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var val types.GatewayTransfer
+		k.cdc.MustUnmarshal(iterator.Value(), &val)
+		list = append(list, val)
+	}
+
+	return
+}
@@ -0,0 +1,64 @@
+package keeper
+
+import (
+	"context"
+	"sort"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+	whtypes "github.com/wormhole-foundation/wormhole-chain/x/wormhole/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (k Keeper) CustodyBalances(c context.Context, req *types.QueryCustodyBalancesRequest) (*types.QueryCustodyBalancesResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+
+	wormholeConfig, ok := k.wormholeKeeper.GetConfig(ctx)
+	if !ok {
+		return nil, status.Error(codes.FailedPrecondition, whtypes.ErrNoConfig.Error())
+	}
+
+	moduleAddress := k.accountKeeper.GetModuleAddress(types.ModuleName)
+	balances := make(map[string]*types.ModuleBalance)
+	get := func(denom string) *types.ModuleBalance {
+		if b, found := balances[denom]; found {
+			return b
+		}
+		b := &types.ModuleBalance{Denom: denom, Balance: sdk.ZeroInt(), Locked: sdk.ZeroInt()}
+		tokenChain, tokenAddress, wrapped := types.GetWrappedCoinMeta(denom)
+		if !wrapped {
+			// Denoms that are too long to be attested have no token address
+			if padded, err := types.PadStringToByte32(denom); err == nil {
+				tokenChain, tokenAddress = uint16(wormholeConfig.ChainId), padded
+			}
+		}
+		b.Wrapped = wrapped
+		if tokenAddress != ([32]byte{}) {
+			b.TokenChain = uint32(tokenChain)
+			b.TokenAddress = tokenAddress[:]
+		}
+		balances[denom] = b
+		return b
+	}
+
+	for _, coin := range k.bankKeeper.GetAllBalances(ctx, moduleAddress) {
+		get(coin.Denom).Balance = coin.Amount
+	}
+	// Locked denoms are listed even if the module account does not hold them anymore
+	for _, custody := range k.GetAllCustodyBalance(ctx) {
+		get(custody.Denom).Locked = custody.Amount
+	}
+
+	res := &types.QueryCustodyBalancesResponse{ModuleAddress: moduleAddress.String()}
+	for _, b := range balances {
+		b.Unlocked = b.Balance.Sub(b.Locked)
+		res.Balances = append(res.Balances, *b)
+	}
+	sort.Slice(res.Balances, func(i, j int) bool { return res.Balances[i].Denom < res.Balances[j].Denom })
+
+	return res, nil
+}
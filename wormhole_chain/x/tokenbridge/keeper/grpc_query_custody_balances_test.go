@@ -0,0 +1,58 @@
+package keeper_test
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+func TestCustodyBalancesQuery(t *testing.T) {
+	_, k, ctx, mocks := setupMockedMsgServer(t)
+	wctx := sdk.WrapSDKContext(ctx)
+	moduleAddress := authtypes.NewModuleAddress(types.ModuleName)
+
+	wrappedDenom := types.GetWrappedCoinIdentifier(uint16(vaa.ChainIDEthereum), [32]byte{31: 0x01})
+	mocks.bank.balances[moduleAddress.String()] = sdk.NewCoins(
+		sdk.NewCoin("uusdc", sdk.NewInt(150)),
+		sdk.NewCoin(wrappedDenom, sdk.NewInt(5)),
+	)
+	k.SetCustodyBalance(ctx, types.CustodyBalance{Denom: "uusdc", Amount: sdk.NewInt(100)})
+	k.SetCustodyBalance(ctx, types.CustodyBalance{Denom: "uatom", Amount: sdk.NewInt(7)})
+
+	res, err := k.CustodyBalances(wctx, &types.QueryCustodyBalancesRequest{})
+	require.NoError(t, err)
+	require.Equal(t, moduleAddress.String(), res.ModuleAddress)
+	require.Len(t, res.Balances, 3)
+
+	// The module account does not hold the locked uatom, which breaks the custody invariant
+	uatom := res.Balances[0]
+	require.Equal(t, "uatom", uatom.Denom)
+	require.False(t, uatom.Wrapped)
+	require.True(t, uatom.Balance.IsZero())
+	require.Equal(t, sdk.NewInt(-7), uatom.Unlocked)
+	require.Equal(t, uint32(vaa.ChainIDWormchain), uatom.TokenChain)
+
+	uusdc := res.Balances[1]
+	require.Equal(t, "uusdc", uusdc.Denom)
+	require.Equal(t, sdk.NewInt(150), uusdc.Balance)
+	require.Equal(t, sdk.NewInt(100), uusdc.Locked)
+	require.Equal(t, sdk.NewInt(50), uusdc.Unlocked)
+	padded, err := types.PadStringToByte32("uusdc")
+	require.NoError(t, err)
+	require.Equal(t, padded[:], uusdc.TokenAddress)
+
+	wrapped := res.Balances[2]
+	require.Equal(t, wrappedDenom, wrapped.Denom)
+	require.True(t, wrapped.Wrapped)
+	require.True(t, wrapped.Locked.IsZero())
+	require.Equal(t, sdk.NewInt(5), wrapped.Unlocked)
+	require.Equal(t, uint32(vaa.ChainIDEthereum), wrapped.TokenChain)
+	require.Equal(t, []byte{31: 0x01}, wrapped.TokenAddress)
+
+	_, err = k.CustodyBalances(wctx, nil)
+	require.Error(t, err)
+}
@@ -0,0 +1,57 @@
+package keeper
+
+import (
+	"context"
+
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (k Keeper) EscrowedTransferAll(c context.Context, req *types.QueryAllEscrowedTransferRequest) (*types.QueryAllEscrowedTransferResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	var escrowedTransfers []types.EscrowedTransfer
+	ctx := sdk.UnwrapSDKContext(c)
+
+	store := ctx.KVStore(k.storeKey)
+	escrowedTransferStore := prefix.NewStore(store, types.KeyPrefix(types.EscrowedTransferKeyPrefix))
+
+	pageRes, err := query.Paginate(escrowedTransferStore, req.Pagination, func(key []byte, value []byte) error {
+		var escrowedTransfer types.EscrowedTransfer
+		if err := k.cdc.Unmarshal(value, &escrowedTransfer); err != nil {
+			return err
+		}
+
+		escrowedTransfers = append(escrowedTransfers, escrowedTransfer)
+		return nil
+	})
+
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &types.QueryAllEscrowedTransferResponse{EscrowedTransfer: escrowedTransfers, Pagination: pageRes}, nil
+}
+
+func (k Keeper) EscrowedTransfer(c context.Context, req *types.QueryGetEscrowedTransferRequest) (*types.QueryGetEscrowedTransferResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+
+	val, found := k.GetEscrowedTransfer(
+		ctx,
+		req.Digest,
+	)
+	if !found {
+		return nil, status.Error(codes.InvalidArgument, "not found")
+	}
+
+	return &types.QueryGetEscrowedTransferResponse{EscrowedTransfer: val}, nil
+}
@@ -0,0 +1,23 @@
+package keeper
+
+import (
+	"context"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (k Keeper) FeeBalances(c context.Context, req *types.QueryFeeBalancesRequest) (*types.QueryFeeBalancesResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+
+	feeAddress := k.FeeAddress(ctx)
+	return &types.QueryFeeBalancesResponse{
+		FeeAddress: feeAddress.String(),
+		Balances:   k.bankKeeper.GetAllBalances(ctx, feeAddress),
+	}, nil
+}
@@ -0,0 +1,96 @@
+package keeper
+
+import (
+	"context"
+
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (k Keeper) LedgerEntry(c context.Context, req *types.QueryGetLedgerEntryRequest) (*types.QueryGetLedgerEntryResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+
+	val, found := k.GetLedgerEntry(ctx, req.Id)
+	if !found {
+		return nil, status.Error(codes.InvalidArgument, "not found")
+	}
+
+	return &types.QueryGetLedgerEntryResponse{Entry: val}, nil
+}
+
+func (k Keeper) LedgerEntryAll(c context.Context, req *types.QueryAllLedgerEntryRequest) (*types.QueryAllLedgerEntryResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	var entries []types.LedgerEntry
+	ctx := sdk.UnwrapSDKContext(c)
+
+	store := ctx.KVStore(k.storeKey)
+	entryStore := prefix.NewStore(store, types.KeyPrefix(types.LedgerEntryKeyPrefix))
+
+	pageRes, err := query.Paginate(entryStore, req.Pagination, func(key []byte, value []byte) error {
+		var entry types.LedgerEntry
+		if err := k.cdc.Unmarshal(value, &entry); err != nil {
+			return err
+		}
+
+		entries = append(entries, entry)
+		return nil
+	})
+
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &types.QueryAllLedgerEntryResponse{Entries: entries, Pagination: pageRes}, nil
+}
+
+func (k Keeper) LedgerRedemption(c context.Context, req *types.QueryLedgerRedemptionRequest) (*types.QueryLedgerRedemptionResponse, error) {
+	if req == nil || req.Redemption == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+
+	entries := k.GetLedgerRedemption(ctx, req.Redemption)
+	if len(entries) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "not found")
+	}
+
+	return &types.QueryLedgerRedemptionResponse{Entries: entries}, nil
+}
+
+func (k Keeper) LedgerBalanceAll(c context.Context, req *types.QueryAllLedgerBalanceRequest) (*types.QueryAllLedgerBalanceResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	var balances []types.LedgerBalance
+	ctx := sdk.UnwrapSDKContext(c)
+
+	store := ctx.KVStore(k.storeKey)
+	balanceStore := prefix.NewStore(store, types.KeyPrefix(types.LedgerBalanceKeyPrefix))
+
+	pageRes, err := query.Paginate(balanceStore, req.Pagination, func(key []byte, value []byte) error {
+		var balance types.LedgerBalance
+		if err := k.cdc.Unmarshal(value, &balance); err != nil {
+			return err
+		}
+
+		balances = append(balances, balance)
+		return nil
+	})
+
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &types.QueryAllLedgerBalanceResponse{Balances: balances, Pagination: pageRes}, nil
+}
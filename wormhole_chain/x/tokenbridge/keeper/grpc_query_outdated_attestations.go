@@ -0,0 +1,32 @@
+package keeper
+
+import (
+	"context"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (k Keeper) OutdatedAttestations(c context.Context, req *types.QueryOutdatedAttestationsRequest) (*types.QueryOutdatedAttestationsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+
+	res := &types.QueryOutdatedAttestationsResponse{}
+	for _, attested := range k.GetAllAttestedAssetMeta(ctx) {
+		meta, found := k.bankKeeper.GetDenomMetaData(ctx, attested.Denom)
+		if !found || !attestationOutdated(attested, meta) {
+			continue
+		}
+		res.Attestations = append(res.Attestations, types.OutdatedAttestation{
+			Attested: attested,
+			Symbol:   meta.Symbol,
+			Name:     meta.Name,
+		})
+	}
+
+	return res, nil
+}
@@ -0,0 +1,40 @@
+package keeper
+
+import (
+	"context"
+
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (k Keeper) PendingTransferAll(c context.Context, req *types.QueryAllPendingTransferRequest) (*types.QueryAllPendingTransferResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	var pendingTransfers []types.PendingTransfer
+	ctx := sdk.UnwrapSDKContext(c)
+
+	store := ctx.KVStore(k.storeKey)
+	pendingTransferStore := prefix.NewStore(store, types.KeyPrefix(types.PendingTransferKeyPrefix))
+
+	pageRes, err := query.Paginate(pendingTransferStore, req.Pagination, func(key []byte, value []byte) error {
+		var pendingTransfer types.PendingTransfer
+		if err := k.cdc.Unmarshal(value, &pendingTransfer); err != nil {
+			return err
+		}
+
+		pendingTransfers = append(pendingTransfers, pendingTransfer)
+		return nil
+	})
+
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &types.QueryAllPendingTransferResponse{PendingTransfer: pendingTransfers, Pagination: pageRes}, nil
+}
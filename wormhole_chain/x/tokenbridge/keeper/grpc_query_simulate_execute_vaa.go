@@ -0,0 +1,67 @@
+package keeper
+
+import (
+	"context"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/gogo/protobuf/proto"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+	"github.com/wormhole-foundation/wormhole-chain/x/wormhole/keeper"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SimulateExecuteVAA executes a VAA on a branch of the state that is discarded. Contracts can send it as a stargate
+// query within a transaction, so neither state changes nor events of the simulation must leak into the transaction.
+func (k Keeper) SimulateExecuteVAA(c context.Context, req *types.QuerySimulateExecuteVAARequest) (*types.QuerySimulateExecuteVAAResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+
+	v, err := keeper.ParseVAA(req.Vaa)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	res := &types.QuerySimulateExecuteVAAResponse{}
+	if len(v.Payload) > 0 {
+		res.PayloadType = uint32(v.Payload[0])
+	}
+
+	cacheCtx, _ := ctx.CacheContext()
+	cacheCtx = cacheCtx.WithEventManager(sdk.NewEventManager()).WithValue(simulationKey{}, true)
+	msg := &types.MsgExecuteVAA{Creator: req.Creator, Vaa: req.Vaa, PostReceipt: req.PostReceipt}
+	logger := k.Logger(ctx).With("digest", v.HexDigest(), "simulation", true)
+	if _, err := (msgServer{Keeper: k}).executeVAA(cacheCtx, logger, msg, v); err != nil {
+		res.Codespace, res.Code, _ = sdkerrors.ABCIInfo(err, false)
+		res.Error = err.Error()
+		return res, nil
+	}
+	res.Success = true
+
+	for _, event := range cacheCtx.EventManager().Events() {
+		switch event.Type {
+		case proto.MessageName(&types.EventTransferReceived{}):
+			e, err := sdk.ParseTypedEvent(abci.Event(event))
+			if err != nil {
+				return nil, status.Error(codes.Internal, err.Error())
+			}
+			received := e.(*types.EventTransferReceived)
+			amount, ok := sdk.NewIntFromString(received.Amount)
+			fee, feeOk := sdk.NewIntFromString(received.Fee)
+			if !ok || !feeOk {
+				return nil, status.Error(codes.Internal, "invalid amount in transfer event")
+			}
+			res.Recipient = received.To
+			res.Amount = sdk.NewCoin(received.LocalDenom, amount)
+			res.Fee = sdk.NewCoin(received.LocalDenom, fee)
+		case proto.MessageName(&types.EventTransferEscrowed{}):
+			res.Escrowed = true
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,88 @@
+package keeper_test
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	capabilitytypes "github.com/cosmos/cosmos-sdk/x/capability/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	keepertest "github.com/wormhole-foundation/wormhole-chain/testutil/keeper"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/keeper"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+	whtypes "github.com/wormhole-foundation/wormhole-chain/x/wormhole/types"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+func TestSimulateExecuteVAA(t *testing.T) {
+	wormholeKeeper := &mockWormholeKeeper{
+		config:       whtypes.Config{ChainId: uint32(vaa.ChainIDWormchain)},
+		capabilities: map[string]*capabilitytypes.Capability{},
+	}
+	k, ctx, _, bk := keepertest.TokenbridgeKeeperWithBank(t, wormholeKeeper)
+	wctx := sdk.WrapSDKContext(ctx)
+	denom := registerWrappedAsset(k, ctx, bk, vaa.ChainIDEthereum, testTokenAddress)
+	to := sdk.AccAddress(bytes.Repeat([]byte{0xaa}, 20))
+	relayer := sdk.AccAddress(bytes.Repeat([]byte{0xbb}, 20))
+
+	payload := createTransferPayload(big.NewInt(100), big.NewInt(30), uint16(vaa.ChainIDEthereum), testTokenAddress, to, uint16(vaa.ChainIDWormchain))
+	vaaBz := createTransferVAA(t, payload)
+	req := &types.QuerySimulateExecuteVAARequest{Creator: relayer.String(), Vaa: vaaBz}
+	res, err := k.SimulateExecuteVAA(wctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, &types.QuerySimulateExecuteVAAResponse{
+		Success:     true,
+		PayloadType: uint32(keeper.PayloadIDTransfer),
+		Recipient:   to.String(),
+		Amount:      sdk.NewInt64Coin(denom, 100),
+		Fee:         sdk.NewInt64Coin(denom, 30),
+	}, res)
+
+	// Neither state changes nor events of the simulation are kept
+	assert.True(t, bk.GetBalance(ctx, to, denom).IsZero())
+	assert.True(t, bk.GetSupply(ctx, denom).IsZero())
+	assert.Empty(t, ctx.EventManager().Events())
+	v, err := vaa.Unmarshal(vaaBz)
+	require.NoError(t, err)
+	_, found := k.GetReplayProtection(ctx, v.HexDigest())
+	assert.False(t, found)
+
+	// The VAA can be executed as simulated, and simulating it again reports the replay
+	_, err = keeper.NewMsgServerImpl(*k).ExecuteVAA(wctx, &types.MsgExecuteVAA{Creator: relayer.String(), Vaa: vaaBz})
+	require.NoError(t, err)
+	assert.Equal(t, int64(70), bk.GetBalance(ctx, to, denom).Amount.Int64())
+	res, err = k.SimulateExecuteVAA(wctx, req)
+	require.NoError(t, err)
+	assert.False(t, res.Success)
+	assert.Equal(t, types.ModuleName, res.Codespace)
+	assert.Equal(t, types.ErrVAAAlreadyExecuted.ABCICode(), res.Code)
+	assert.Equal(t, types.ErrVAAAlreadyExecuted.Error(), res.Error)
+
+	// A failing redemption reports the error
+	payload = createTransferPayload(big.NewInt(100), big.NewInt(101), uint16(vaa.ChainIDEthereum), testTokenAddress, to, uint16(vaa.ChainIDWormchain))
+	res, err = k.SimulateExecuteVAA(wctx, &types.QuerySimulateExecuteVAARequest{Creator: relayer.String(), Vaa: createTransferVAA(t, payload)})
+	require.NoError(t, err)
+	assert.False(t, res.Success)
+	assert.Equal(t, types.ErrFeeTooHigh.ABCICode(), res.Code)
+
+	// Transfers of unregistered assets are escrowed if escrow is enabled
+	config, _ := k.GetConfig(ctx)
+	config.UnregisteredTransferEscrowPeriod = 100
+	k.SetConfig(ctx, config)
+	payload = createTransferPayload(big.NewInt(100), big.NewInt(0), uint16(vaa.ChainIDEthereum), [32]byte{0x03}, to, uint16(vaa.ChainIDWormchain))
+	res, err = k.SimulateExecuteVAA(wctx, &types.QuerySimulateExecuteVAARequest{Vaa: createTransferVAA(t, payload)})
+	require.NoError(t, err)
+	assert.True(t, res.Success)
+	assert.True(t, res.Escrowed)
+	assert.Empty(t, res.Recipient)
+
+	for _, req := range []*types.QuerySimulateExecuteVAARequest{nil, {Vaa: []byte{1, 2, 3}}} {
+		_, err := k.SimulateExecuteVAA(wctx, req)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	}
+}
@@ -0,0 +1,54 @@
+package keeper
+
+import (
+	"context"
+	"encoding/hex"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (k Keeper) WrappedAsset(c context.Context, req *types.QueryWrappedAssetRequest) (*types.QueryWrappedAssetResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+
+	addr, err := hex.DecodeString(req.TokenAddress)
+	if err != nil || len(addr) != 32 {
+		return nil, status.Error(codes.InvalidArgument, "token address must be 32 hex encoded bytes")
+	}
+	if req.TokenChain > 0xffff {
+		return nil, status.Error(codes.InvalidArgument, "invalid token chain")
+	}
+	var tokenAddress [32]byte
+	copy(tokenAddress[:], addr)
+	tokenChain := uint16(req.TokenChain)
+
+	wormholeConfig, ok := k.wormholeKeeper.GetConfig(ctx)
+	if ok && uint32(tokenChain) == wormholeConfig.ChainId {
+		return nil, status.Error(codes.InvalidArgument, "token is native to wormhole chain")
+	}
+
+	denom := "uworm"
+	if !types.IsWORMToken(tokenChain, tokenAddress) {
+		denom = "b" + types.GetWrappedCoinIdentifier(tokenChain, tokenAddress)
+	}
+	meta, found := k.bankKeeper.GetDenomMetaData(ctx, denom)
+	if !found {
+		return nil, status.Error(codes.InvalidArgument, "not found")
+	}
+
+	flags, _ := k.GetWrappedAssetFlags(ctx, denom)
+	localization, _ := k.GetWrappedAssetLocalization(ctx, denom)
+	return &types.QueryWrappedAssetResponse{
+		Denom:          denom,
+		Metadata:       meta,
+		FeeOnTransfer:  flags.FeeOnTransfer,
+		Rebasing:       flags.Rebasing,
+		AssetListId:    localization.AssetListId,
+		LocalizedNames: localization.LocalizedNames,
+	}, nil
+}
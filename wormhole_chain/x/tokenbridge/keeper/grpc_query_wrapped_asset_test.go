@@ -0,0 +1,78 @@
+package keeper_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestWrappedAssetQuery(t *testing.T) {
+	_, k, ctx, mocks := setupMockedMsgServer(t)
+	wctx := sdk.WrapSDKContext(ctx)
+	denom := registerWrappedAsset(k, ctx, mocks.bank, vaa.ChainIDEthereum, testTokenAddress)
+
+	res, err := k.WrappedAsset(wctx, &types.QueryWrappedAssetRequest{
+		TokenChain:   uint32(vaa.ChainIDEthereum),
+		TokenAddress: hex.EncodeToString(testTokenAddress[:]),
+	})
+	require.NoError(t, err)
+	require.Equal(t, denom, res.Denom)
+	require.Equal(t, denom, res.Metadata.Base)
+	require.False(t, res.FeeOnTransfer)
+
+	k.SetWrappedAssetFlags(ctx, types.WrappedAssetFlags{Denom: denom, TokenChain: uint32(vaa.ChainIDEthereum), TokenAddress: testTokenAddress[:], FeeOnTransfer: true})
+	res, err = k.WrappedAsset(wctx, &types.QueryWrappedAssetRequest{
+		TokenChain:   uint32(vaa.ChainIDEthereum),
+		TokenAddress: hex.EncodeToString(testTokenAddress[:]),
+	})
+	require.NoError(t, err)
+	require.True(t, res.FeeOnTransfer)
+	require.False(t, res.Rebasing)
+	require.Empty(t, res.AssetListId)
+
+	names := []types.LocalizedName{{Locale: "en", Name: "Wrapped Ether"}, {Locale: "ja", Name: "ラップドイーサ"}}
+	k.SetWrappedAssetLocalization(ctx, types.WrappedAssetLocalization{Denom: denom, TokenChain: uint32(vaa.ChainIDEthereum), TokenAddress: testTokenAddress[:], AssetListId: "weth", LocalizedNames: names})
+	res, err = k.WrappedAsset(wctx, &types.QueryWrappedAssetRequest{
+		TokenChain:   uint32(vaa.ChainIDEthereum),
+		TokenAddress: hex.EncodeToString(testTokenAddress[:]),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "weth", res.AssetListId)
+	require.Equal(t, names, res.LocalizedNames)
+
+	for _, tc := range []struct {
+		desc    string
+		request *types.QueryWrappedAssetRequest
+	}{
+		{
+			desc:    "unregistered",
+			request: &types.QueryWrappedAssetRequest{TokenChain: uint32(vaa.ChainIDSolana), TokenAddress: hex.EncodeToString(testTokenAddress[:])},
+		},
+		{
+			desc:    "native",
+			request: &types.QueryWrappedAssetRequest{TokenChain: uint32(vaa.ChainIDWormchain), TokenAddress: hex.EncodeToString(testTokenAddress[:])},
+		},
+		{
+			desc:    "short address",
+			request: &types.QueryWrappedAssetRequest{TokenChain: uint32(vaa.ChainIDEthereum), TokenAddress: hex.EncodeToString(testTokenAddress[1:])},
+		},
+		{
+			desc:    "invalid chain",
+			request: &types.QueryWrappedAssetRequest{TokenChain: 1 << 16, TokenAddress: hex.EncodeToString(testTokenAddress[:])},
+		},
+		{
+			desc: "nil request",
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := k.WrappedAsset(wctx, tc.request)
+			require.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	}
+}
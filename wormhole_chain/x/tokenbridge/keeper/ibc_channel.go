@@ -0,0 +1,61 @@
+package keeper
+
+import (
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+)
+
+// SetIbcChannel set a specific ibcChannel in the store from its index
+func (k Keeper) SetIbcChannel(ctx sdk.Context, ibcChannel types.IbcChannel) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.IbcChannelKeyPrefix))
+	b := k.cdc.MustMarshal(&ibcChannel)
+	store.Set(types.IbcChannelKey(
+		ibcChannel.ChainID,
+	), b)
+}
+
+// GetIbcChannel returns a ibcChannel from its index
+func (k Keeper) GetIbcChannel(
+	ctx sdk.Context,
+	chainID string,
+
+) (val types.IbcChannel, found bool) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.IbcChannelKeyPrefix))
+
+	b := store.Get(types.IbcChannelKey(chainID))
+	if b == nil {
+		return val, false
+	}
+
+	k.cdc.MustUnmarshal(b, &val)
+	return val, true
+}
+
+// RemoveIbcChannel removes a ibcChannel from the store
+func (k Keeper) RemoveIbcChannel(
+	ctx sdk.Context,
+	chainID string,
+
+) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.IbcChannelKeyPrefix))
+	store.Delete(types.IbcChannelKey(
+		chainID,
+	))
+}
+
+// GetAllIbcChannel returns all ibcChannel
+func (k Keeper) GetAllIbcChannel(ctx sdk.Context) (list []types.IbcChannel) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.IbcChannelKeyPrefix))
+	iterator := sdk.KVStorePrefixIterator(store, []byte{})
+
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var val types.IbcChannel
+		k.cdc.MustUnmarshal(iterator.Value(), &val)
+		list = append(list, val)
+	}
+
+	return
+}
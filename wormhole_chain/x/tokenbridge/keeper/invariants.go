@@ -0,0 +1,37 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+)
+
+// RegisterInvariants registers all tokenbridge invariants
+func RegisterInvariants(ir sdk.InvariantRegistry, k Keeper) {
+	ir.RegisterRoute(types.ModuleName, "custody-balances", CustodyBalancesInvariant(k))
+}
+
+// CustodyBalancesInvariant checks that the module account holds at least the
+// tracked custody balance of every native denom, i.e. that all wrapped tokens
+// outstanding on other chains can be redeemed.
+func CustodyBalancesInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		var (
+			msg    string
+			broken bool
+		)
+
+		moduleAddress := k.accountKeeper.GetModuleAddress(types.ModuleName)
+		for _, custody := range k.GetAllCustodyBalance(ctx) {
+			balance := k.bankKeeper.GetBalance(ctx, moduleAddress, custody.Denom)
+			if custody.Amount.IsNegative() || balance.Amount.LT(custody.Amount) {
+				broken = true
+				msg += fmt.Sprintf("\t%s: custody %s, module balance %s\n", custody.Denom, custody.Amount, balance.Amount)
+			}
+		}
+
+		return sdk.FormatInvariant(types.ModuleName, "custody-balances",
+			fmt.Sprintf("native denoms with insufficient module balance:\n%s", msg)), broken
+	}
+}
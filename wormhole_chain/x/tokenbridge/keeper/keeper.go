@@ -19,6 +19,20 @@ type (
 		accountKeeper  types.AccountKeeper
 		bankKeeper     types.BankKeeper
 		wormholeKeeper types.WormholeKeeper
+		upgradeKeeper  types.UpgradeKeeper
+		scopedKeeper   types.ScopedKeeper
+
+		// transferKeeper and channelKeeper are used by the IBC gateway, which is disabled if they are not set.
+		transferKeeper types.TransferKeeper
+		channelKeeper  types.ChannelKeeper
+
+		// payloadHandlers execute the payloads of token bridge VAAs, by payload ID.
+		payloadHandlers map[PayloadID]PayloadHandler
+		// payloadReceivers receive the transfers with payload sent to their bech32 address.
+		payloadReceivers map[string]TransferPayloadReceiver
+
+		// logLevel restricts the logs of the module, if set.
+		logLevel log.Option
 	}
 )
 
@@ -27,17 +41,41 @@ func NewKeeper(
 	storeKey,
 	memKey sdk.StoreKey,
 
-	accountKeeper types.AccountKeeper, bankKeeper types.BankKeeper, wormholeKeeper types.WormholeKeeper,
+	accountKeeper types.AccountKeeper, bankKeeper types.BankKeeper, wormholeKeeper types.WormholeKeeper, upgradeKeeper types.UpgradeKeeper, scopedKeeper types.ScopedKeeper,
 ) *Keeper {
 	return &Keeper{
 		cdc:      cdc,
 		storeKey: storeKey,
 		memKey:   memKey,
 
-		accountKeeper: accountKeeper, bankKeeper: bankKeeper, wormholeKeeper: wormholeKeeper,
+		accountKeeper: accountKeeper, bankKeeper: bankKeeper, wormholeKeeper: wormholeKeeper, upgradeKeeper: upgradeKeeper, scopedKeeper: scopedKeeper,
+
+		payloadHandlers:  defaultPayloadHandlers(),
+		payloadReceivers: map[string]TransferPayloadReceiver{},
 	}
 }
 
 func (k Keeper) Logger(ctx sdk.Context) log.Logger {
-	return ctx.Logger().With("module", fmt.Sprintf("x/%s", types.ModuleName))
+	logger := ctx.Logger().With("module", fmt.Sprintf("x/%s", types.ModuleName))
+	if k.logLevel != nil {
+		logger = log.NewFilter(logger, k.logLevel)
+	}
+	return logger
+}
+
+// SetIBCKeepers enables the IBC gateway, which forwards transfers with a gateway payload over IBC.
+func (k *Keeper) SetIBCKeepers(transferKeeper types.TransferKeeper, channelKeeper types.ChannelKeeper) {
+	k.transferKeeper = transferKeeper
+	k.channelKeeper = channelKeeper
+}
+
+// SetLogLevel restricts the logs of the module to the given level ("debug", "info", "error" or "none"). It does not
+// lift the log level of the node, so debug logs of the module require the node to log at the debug level.
+func (k *Keeper) SetLogLevel(level string) error {
+	option, err := log.AllowLevel(level)
+	if err != nil {
+		return err
+	}
+	k.logLevel = option
+	return nil
 }
@@ -0,0 +1,152 @@
+package keeper
+
+import (
+	"encoding/binary"
+
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+)
+
+// GetLedgerEntryCount get the total number of ledgerEntry, which is the ID of the last one
+func (k Keeper) GetLedgerEntryCount(ctx sdk.Context) uint64 {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), []byte{})
+	byteKey := types.KeyPrefix(types.LedgerEntryCountKey)
+	bz := store.Get(byteKey)
+
+	// Count doesn't exist
+	if bz == nil {
+		return 0
+	}
+
+	return binary.BigEndian.Uint64(bz)
+}
+
+// SetLedgerEntryCount set the total number of ledgerEntry
+func (k Keeper) SetLedgerEntryCount(ctx sdk.Context, count uint64) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), []byte{})
+	byteKey := types.KeyPrefix(types.LedgerEntryCountKey)
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, count)
+	store.Set(byteKey, bz)
+}
+
+// SetLedgerEntry set a specific ledgerEntry in the store, and indexes it by its redemption
+func (k Keeper) SetLedgerEntry(ctx sdk.Context, entry types.LedgerEntry) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.LedgerEntryKeyPrefix))
+	b := k.cdc.MustMarshal(&entry)
+	store.Set(types.LedgerEntryKey(entry.Id), b)
+
+	index := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.LedgerRedemptionKeyPrefix))
+	index.Set(append(types.LedgerRedemptionPrefix(entry.Redemption), types.LedgerEntryKey(entry.Id)...), []byte{})
+}
+
+// GetLedgerEntry returns a ledgerEntry from its id
+func (k Keeper) GetLedgerEntry(ctx sdk.Context, id uint64) (val types.LedgerEntry, found bool) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.LedgerEntryKeyPrefix))
+	b := store.Get(types.LedgerEntryKey(id))
+	if b == nil {
+		return val, false
+	}
+	k.cdc.MustUnmarshal(b, &val)
+	return val, true
+}
+
+// GetAllLedgerEntry returns all ledgerEntry
+func (k Keeper) GetAllLedgerEntry(ctx sdk.Context) (list []types.LedgerEntry) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.LedgerEntryKeyPrefix))
+	iterator := sdk.KVStorePrefixIterator(store, []byte{})
+
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var val types.LedgerEntry
+		k.cdc.MustUnmarshal(iterator.Value(), &val)
+		list = append(list, val)
+	}
+
+	return
+}
+
+// GetLedgerRedemption returns the ledgerEntry of a redemption in the order they were recorded
+func (k Keeper) GetLedgerRedemption(ctx sdk.Context, redemption string) (list []types.LedgerEntry) {
+	index := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.LedgerRedemptionKeyPrefix))
+	iterator := sdk.KVStorePrefixIterator(index, types.LedgerRedemptionPrefix(redemption))
+
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		idKey := iterator.Key()[len(types.LedgerRedemptionPrefix(redemption)):]
+		if val, found := k.GetLedgerEntry(ctx, binary.BigEndian.Uint64(idKey)); found {
+			list = append(list, val)
+		}
+	}
+
+	return
+}
+
+// SetLedgerBalance set a specific ledgerBalance in the store from its index
+func (k Keeper) SetLedgerBalance(ctx sdk.Context, balance types.LedgerBalance) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.LedgerBalanceKeyPrefix))
+	b := k.cdc.MustMarshal(&balance)
+	store.Set(types.LedgerBalanceKey(balance.Account, balance.Denom), b)
+}
+
+// GetLedgerBalance returns a ledgerBalance from its index
+func (k Keeper) GetLedgerBalance(ctx sdk.Context, account string, denom string) (val types.LedgerBalance, found bool) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.LedgerBalanceKeyPrefix))
+	b := store.Get(types.LedgerBalanceKey(account, denom))
+	if b == nil {
+		return val, false
+	}
+	k.cdc.MustUnmarshal(b, &val)
+	return val, true
+}
+
+// GetAllLedgerBalance returns all ledgerBalance
+func (k Keeper) GetAllLedgerBalance(ctx sdk.Context) (list []types.LedgerBalance) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.LedgerBalanceKeyPrefix))
+	iterator := sdk.KVStorePrefixIterator(store, []byte{})
+
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var val types.LedgerBalance
+		k.cdc.MustUnmarshal(iterator.Value(), &val)
+		list = append(list, val)
+	}
+
+	return
+}
+
+// appendLedgerEntry records amount moving from the credit account to the debit account as the next entry of the
+// journal, for the redemption of the VAA with the hex digest redemption.
+func (k Keeper) appendLedgerEntry(ctx sdk.Context, redemption string, kind string, debit string, credit string, amount sdk.Coin) {
+	id := k.GetLedgerEntryCount(ctx) + 1
+	k.SetLedgerEntry(ctx, types.LedgerEntry{
+		Id:         id,
+		Redemption: redemption,
+		Height:     ctx.BlockHeight(),
+		Kind:       kind,
+		Debit:      debit,
+		Credit:     credit,
+		Amount:     amount,
+	})
+	k.SetLedgerEntryCount(ctx, id)
+
+	debited := k.ledgerBalance(ctx, debit, amount.Denom)
+	debited.Debits = debited.Debits.Add(amount.Amount)
+	k.SetLedgerBalance(ctx, debited)
+	credited := k.ledgerBalance(ctx, credit, amount.Denom)
+	credited.Credits = credited.Credits.Add(amount.Amount)
+	k.SetLedgerBalance(ctx, credited)
+}
+
+// ledgerBalance returns the ledgerBalance of account in denom, which is zero if there is none yet.
+func (k Keeper) ledgerBalance(ctx sdk.Context, account string, denom string) types.LedgerBalance {
+	balance, found := k.GetLedgerBalance(ctx, account, denom)
+	if !found {
+		balance = types.LedgerBalance{Account: account, Denom: denom, Debits: sdk.ZeroInt(), Credits: sdk.ZeroInt()}
+	}
+	return balance
+}
@@ -0,0 +1,170 @@
+package keeper
+
+import (
+	"fmt"
+	"sort"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	btypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+)
+
+// GetLegacyDenoms returns the wrapped denoms in a legacy format that still have a supply, or whose metadata was not
+// carried over to their canonical denom yet, sorted by denom.
+func (k Keeper) GetLegacyDenoms(ctx sdk.Context) []types.LegacyDenom {
+	legacyDenoms := map[string]*types.LegacyDenom{}
+	get := func(denom, canonical string) *types.LegacyDenom {
+		if _, found := legacyDenoms[denom]; !found {
+			legacyDenoms[denom] = &types.LegacyDenom{Denom: denom, Canonical: canonical, Supply: sdk.ZeroInt()}
+		}
+		return legacyDenoms[denom]
+	}
+
+	k.bankKeeper.IterateTotalSupply(ctx, func(coin sdk.Coin) bool {
+		if canonical, legacy := types.CanonicalWrappedDenom(coin.Denom); legacy {
+			get(coin.Denom, canonical).Supply = coin.Amount
+		}
+		return false
+	})
+	k.bankKeeper.IterateAllDenomMetaData(ctx, func(meta btypes.Metadata) bool {
+		canonical, legacy := types.CanonicalWrappedDenom(meta.Base)
+		if !legacy {
+			return false
+		}
+		if _, found := k.bankKeeper.GetDenomMetaData(ctx, canonical); !found {
+			get(meta.Base, canonical).HasMetadata = true
+		}
+		return false
+	})
+	for denom, holders := range k.legacyDenomHolders(ctx) {
+		if d, found := legacyDenoms[denom]; found {
+			d.Holders = uint64(len(holders))
+		}
+	}
+
+	list := make([]types.LegacyDenom, 0, len(legacyDenoms))
+	for _, d := range legacyDenoms {
+		list = append(list, *d)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Denom < list[j].Denom })
+	return list
+}
+
+// legacyDenomHolders returns the accounts holding each wrapped denom in a legacy format, in the order of the bank
+// store.
+func (k Keeper) legacyDenomHolders(ctx sdk.Context) map[string][]sdk.AccAddress {
+	holders := map[string][]sdk.AccAddress{}
+	k.bankKeeper.IterateAllBalances(ctx, func(address sdk.AccAddress, coin sdk.Coin) bool {
+		if _, legacy := types.CanonicalWrappedDenom(coin.Denom); legacy && coin.IsPositive() {
+			holders[coin.Denom] = append(holders[coin.Denom], address)
+		}
+		return false
+	})
+	return holders
+}
+
+// migrateLegacyDenom replaces every coin of a legacy wrapped denom by the same amount of its canonical denom. The
+// coins are moved to the module account, burned there and minted again as the canonical denom, so the supply of a
+// canonical denom that was minted already is merged with the legacy one. Coins that cannot be moved, like those locked
+// in a vesting account, are left in place and logged, so that they do not block the upgrade; they are still listed by
+// the legacy denoms query. Pending transfers of the legacy denom, whose coins are held by the module account, are
+// moved to the canonical denom as well. The bank module offers no way to remove denom metadata, so legacy metadata is
+// copied to the canonical denom if it has none, and left in place.
+func (k Keeper) migrateLegacyDenom(ctx sdk.Context, legacyDenom types.LegacyDenom, holders []sdk.AccAddress) error {
+	moduleAddress := k.accountKeeper.GetModuleAddress(types.ModuleName)
+	collected := k.bankKeeper.GetBalance(ctx, moduleAddress, legacyDenom.Denom).Amount
+	var migrated []sdk.AccAddress
+	var balances []sdk.Coin
+	skipped := 0
+	for _, holder := range holders {
+		if holder.Equals(moduleAddress) {
+			continue
+		}
+		balance := k.bankKeeper.GetBalance(ctx, holder, legacyDenom.Denom)
+		if err := k.bankKeeper.SendCoins(ctx, holder, moduleAddress, sdk.NewCoins(balance)); err != nil {
+			k.Logger(ctx).Error("failed to collect legacy wrapped coins, leaving them in place",
+				"denom", legacyDenom.Denom,
+				"holder", holder.String(),
+				"amount", balance.Amount.String(),
+				"error", err)
+			skipped++
+			continue
+		}
+		collected = collected.Add(balance.Amount)
+		migrated = append(migrated, holder)
+		balances = append(balances, balance)
+	}
+
+	if collected.IsPositive() {
+		if err := k.bankKeeper.BurnCoins(ctx, types.ModuleName, sdk.NewCoins(sdk.NewCoin(legacyDenom.Denom, collected))); err != nil {
+			return fmt.Errorf("failed to burn %s: %w", legacyDenom.Denom, err)
+		}
+		if err := k.bankKeeper.MintCoins(ctx, types.ModuleName, sdk.NewCoins(sdk.NewCoin(legacyDenom.Canonical, collected))); err != nil {
+			return fmt.Errorf("failed to mint %s: %w", legacyDenom.Canonical, err)
+		}
+	}
+
+	for i, holder := range migrated {
+		coins := sdk.NewCoins(sdk.NewCoin(legacyDenom.Canonical, balances[i].Amount))
+		if err := k.bankKeeper.SendCoins(ctx, moduleAddress, holder, coins); err != nil {
+			return fmt.Errorf("failed to return %s to %s: %w", coins, holder, err)
+		}
+	}
+
+	for _, pending := range k.GetAllPendingTransfer(ctx) {
+		if pending.Amount.Denom == legacyDenom.Denom {
+			pending.Amount.Denom = legacyDenom.Canonical
+			k.SetPendingTransfer(ctx, pending)
+		}
+	}
+
+	if legacyDenom.HasMetadata {
+		meta, _ := k.bankKeeper.GetDenomMetaData(ctx, legacyDenom.Denom)
+		if err := k.copyLegacyMetadata(ctx, meta, legacyDenom.Canonical); err != nil {
+			return err
+		}
+	}
+
+	k.Logger(ctx).Info("migrated legacy wrapped denom",
+		"denom", legacyDenom.Denom,
+		"canonical", legacyDenom.Canonical,
+		"migrated", collected.String(),
+		"supply", legacyDenom.Supply.String(),
+		"holders", len(holders),
+		"skipped_holders", skipped)
+	return nil
+}
+
+// copyLegacyMetadata sets the metadata of the canonical denom to the name, symbol and decimals of legacy metadata.
+func (k Keeper) copyLegacyMetadata(ctx sdk.Context, meta btypes.Metadata, canonical string) error {
+	var decimals *uint32
+	for _, unit := range meta.DenomUnits {
+		if unit.Denom == meta.Display {
+			decimals = &unit.Exponent
+		}
+	}
+	if decimals == nil {
+		return fmt.Errorf("%w: %s", types.ErrDisplayUnitNotFound, meta.Base)
+	}
+
+	tokenChain, tokenAddress, _ := types.GetWrappedCoinMeta(canonical)
+	identifier := types.GetWrappedCoinIdentifier(tokenChain, tokenAddress)
+	k.bankKeeper.SetDenomMetaData(ctx, btypes.Metadata{
+		Description: fmt.Sprintf("Portal wrapped asset from chain %d with address %x", tokenChain, tokenAddress),
+		DenomUnits: []*btypes.DenomUnit{
+			{
+				Denom:    canonical,
+				Exponent: 0,
+			},
+			{
+				Denom:    identifier,
+				Exponent: *decimals,
+			},
+		},
+		Base:    canonical,
+		Display: identifier,
+		Name:    meta.Name,
+		Symbol:  meta.Symbol,
+	})
+	return nil
+}
@@ -0,0 +1,140 @@
+package keeper_test
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	vestingtypes "github.com/cosmos/cosmos-sdk/x/auth/vesting/types"
+	btypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	capabilitytypes "github.com/cosmos/cosmos-sdk/x/capability/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	keepertest "github.com/wormhole-foundation/wormhole-chain/testutil/keeper"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/keeper"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+	whtypes "github.com/wormhole-foundation/wormhole-chain/x/wormhole/types"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+func TestMigrate3to4(t *testing.T) {
+	wormholeKeeper := &mockWormholeKeeper{
+		config:       whtypes.Config{ChainId: uint32(vaa.ChainIDWormchain)},
+		capabilities: map[string]*capabilitytypes.Capability{},
+	}
+	k, ctx, ak, bk := keepertest.TokenbridgeKeeperWithBank(t, wormholeKeeper)
+	wctx := sdk.WrapSDKContext(ctx)
+	moduleAddress := ak.GetModuleAddress(types.ModuleName)
+	alice := sdk.AccAddress(bytes.Repeat([]byte{0xaa}, 20))
+	bob := sdk.AccAddress(bytes.Repeat([]byte{0xbb}, 20))
+	mint := func(to sdk.AccAddress, coin sdk.Coin) {
+		require.NoError(t, bk.MintCoins(ctx, types.ModuleName, sdk.NewCoins(coin)))
+		if !to.Equals(moduleAddress) {
+			require.NoError(t, bk.SendCoinsFromModuleToAccount(ctx, types.ModuleName, to, sdk.NewCoins(coin)))
+		}
+	}
+
+	// A registered asset that was also minted under its display denom
+	canonical := registerWrappedAsset(k, ctx, bk, vaa.ChainIDEthereum, testTokenAddress)
+	unprefixed := strings.TrimPrefix(canonical, "b")
+	mint(alice, sdk.NewInt64Coin(canonical, 50))
+	mint(alice, sdk.NewInt64Coin(unprefixed, 100))
+	mint(bob, sdk.NewInt64Coin(unprefixed, 20))
+	mint(moduleAddress, sdk.NewInt64Coin(unprefixed, 30))
+	k.SetPendingTransfer(ctx, types.PendingTransfer{Id: 1, Sender: alice.String(), Amount: sdk.NewInt64Coin(unprefixed, 30), Fee: sdk.ZeroInt()})
+
+	// An asset only known under an upper-case token address
+	tokenAddress := [32]byte{0xab, 0xcd}
+	upperCase := fmt.Sprintf("bwh/%05d/%064X", vaa.ChainIDEthereum, tokenAddress)
+	otherCanonical := "b" + types.GetWrappedCoinIdentifier(uint16(vaa.ChainIDEthereum), tokenAddress)
+	mint(bob, sdk.NewInt64Coin(upperCase, 40))
+	bk.SetDenomMetaData(ctx, btypes.Metadata{
+		DenomUnits: []*btypes.DenomUnit{
+			{Denom: upperCase, Exponent: 0},
+			{Denom: upperCase[1:], Exponent: 6},
+		},
+		Base:    upperCase,
+		Display: upperCase[1:],
+		Name:    "Legacy Token",
+		Symbol:  "LGCY",
+	})
+
+	res, err := k.LegacyDenoms(wctx, &types.QueryLegacyDenomsRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, []types.LegacyDenom{
+		{Denom: upperCase, Canonical: otherCanonical, Supply: sdk.NewInt(40), Holders: 1, HasMetadata: true},
+		{Denom: unprefixed, Canonical: canonical, Supply: sdk.NewInt(150), Holders: 3},
+	}, res.Denoms)
+
+	require.NoError(t, keeper.NewMigrator(*k).Migrate3to4(ctx))
+
+	// Balances are merged into the canonical denoms and the legacy denoms have no supply left
+	assert.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(canonical, 150)), bk.GetAllBalances(ctx, alice))
+	assert.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(canonical, 20), sdk.NewInt64Coin(otherCanonical, 40)), bk.GetAllBalances(ctx, bob))
+	assert.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(canonical, 30)), bk.GetAllBalances(ctx, moduleAddress))
+	assert.Equal(t, int64(200), bk.GetSupply(ctx, canonical).Amount.Int64())
+	assert.True(t, bk.GetSupply(ctx, unprefixed).IsZero())
+	assert.True(t, bk.GetSupply(ctx, upperCase).IsZero())
+
+	pending, found := k.GetPendingTransfer(ctx, 1)
+	require.True(t, found)
+	assert.Equal(t, sdk.NewInt64Coin(canonical, 30), pending.Amount)
+	assert.Equal(t, sdk.NewInt(30), k.GetPendingTransferAmount(ctx, canonical))
+	assert.True(t, k.GetPendingTransferAmount(ctx, unprefixed).IsZero())
+
+	// Legacy metadata is carried over to canonical denoms without metadata
+	meta, found := bk.GetDenomMetaData(ctx, otherCanonical)
+	require.True(t, found)
+	assert.Equal(t, otherCanonical[1:], meta.Display)
+	assert.Equal(t, "Legacy Token", meta.Name)
+	assert.Equal(t, "LGCY", meta.Symbol)
+	require.Len(t, meta.DenomUnits, 2)
+	assert.Equal(t, uint32(6), meta.DenomUnits[1].Exponent)
+
+	res, err = k.LegacyDenoms(wctx, &types.QueryLegacyDenomsRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, res.Denoms)
+
+	// Migrating again changes nothing
+	require.NoError(t, keeper.NewMigrator(*k).Migrate3to4(ctx))
+	assert.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(canonical, 150)), bk.GetAllBalances(ctx, alice))
+
+	_, err = k.LegacyDenoms(wctx, nil)
+	assert.Error(t, err)
+}
+
+func TestMigrate3to4VestingHolder(t *testing.T) {
+	wormholeKeeper := &mockWormholeKeeper{
+		config:       whtypes.Config{ChainId: uint32(vaa.ChainIDWormchain)},
+		capabilities: map[string]*capabilitytypes.Capability{},
+	}
+	k, ctx, ak, bk := keepertest.TokenbridgeKeeperWithBank(t, wormholeKeeper)
+	alice := sdk.AccAddress(bytes.Repeat([]byte{0xaa}, 20))
+	vesting := sdk.AccAddress(bytes.Repeat([]byte{0xcc}, 20))
+
+	canonical := registerWrappedAsset(k, ctx, bk, vaa.ChainIDEthereum, testTokenAddress)
+	unprefixed := strings.TrimPrefix(canonical, "b")
+	locked := sdk.NewCoins(sdk.NewInt64Coin(unprefixed, 60))
+	ak.SetAccount(ctx, vestingtypes.NewDelayedVestingAccount(authtypes.NewBaseAccountWithAddress(vesting), locked, ctx.BlockTime().Add(time.Hour).Unix()))
+	require.NoError(t, bk.MintCoins(ctx, types.ModuleName, sdk.NewCoins(sdk.NewInt64Coin(unprefixed, 160))))
+	require.NoError(t, bk.SendCoinsFromModuleToAccount(ctx, types.ModuleName, alice, sdk.NewCoins(sdk.NewInt64Coin(unprefixed, 100))))
+	require.NoError(t, bk.SendCoinsFromModuleToAccount(ctx, types.ModuleName, vesting, locked))
+
+	// The locked coins of the vesting account cannot be moved, but do not fail the migration
+	require.NoError(t, keeper.NewMigrator(*k).Migrate3to4(ctx))
+	assert.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(canonical, 100)), bk.GetAllBalances(ctx, alice))
+	assert.Equal(t, locked, bk.GetAllBalances(ctx, vesting))
+	assert.Equal(t, int64(100), bk.GetSupply(ctx, canonical).Amount.Int64())
+	assert.Equal(t, int64(60), bk.GetSupply(ctx, unprefixed).Amount.Int64())
+
+	res, err := k.LegacyDenoms(sdk.WrapSDKContext(ctx), &types.QueryLegacyDenomsRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, []types.LegacyDenom{
+		{Denom: unprefixed, Canonical: canonical, Supply: sdk.NewInt(60), Holders: 1},
+	}, res.Denoms)
+}
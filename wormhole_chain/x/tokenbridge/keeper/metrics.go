@@ -0,0 +1,92 @@
+package keeper
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/cosmos/cosmos-sdk/telemetry"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// Telemetry of the token bridge. The metrics are only emitted when transactions are delivered, not when they are
+// checked or simulated, and are exported with the other cosmos telemetry, e.g. on the Prometheus endpoint of the API
+// server if telemetry is enabled in app.toml:
+//
+//	tokenbridge_execute_vaa{payload_type, status}: VAAs executed, with status success or failure
+//	tokenbridge_execute_vaa_duration{payload_type}: milliseconds spent executing a VAA, whatever its outcome
+//	tokenbridge_redeemed{wrapped, amount_bucket}: transfers redeemed, by order of magnitude of the base amount
+//	tokenbridge_minted{denom}: amount of wrapped denoms minted by redemptions
+const (
+	metricExecuteVAA         = "execute_vaa"
+	metricExecuteVAADuration = "execute_vaa_duration"
+	metricRedeemed           = "redeemed"
+	metricMinted             = "minted"
+)
+
+// simulationKey marks the contexts of simulated executions, see SimulateExecuteVAA.
+type simulationKey struct{}
+
+// metricsEnabled returns false in CheckTx and simulations, so that only executions in blocks are counted.
+func metricsEnabled(ctx sdk.Context) bool {
+	return !ctx.IsCheckTx() && ctx.Value(simulationKey{}) == nil
+}
+
+// payloadTypeLabel names the payload type of v for metric labels.
+func payloadTypeLabel(v *vaa.VAA) string {
+	if len(v.Payload) == 0 {
+		return "empty"
+	}
+	switch PayloadID(v.Payload[0]) {
+	case PayloadIDTransfer:
+		return "transfer"
+	case PayloadIDAssetMeta:
+		return "asset_meta"
+	case PayloadIDTransferWithPayload:
+		return "transfer_with_payload"
+	default:
+		return "unknown"
+	}
+}
+
+// amountBucket returns the power of ten, a multiple of 3, below amount, e.g. "1e6" for amounts from 1,000,000 to
+// 999,999,999.
+func amountBucket(amount sdk.Int) string {
+	digits := len(amount.String())
+	return fmt.Sprintf("1e%d", (digits-1)/3*3)
+}
+
+// recordExecuteVAA counts the execution of v, which started at start. err is the result of the execution.
+func recordExecuteVAA(ctx sdk.Context, v *vaa.VAA, start time.Time, err error) {
+	if !metricsEnabled(ctx) {
+		return
+	}
+	status := "success"
+	if err != nil {
+		status = "failure"
+	}
+	payloadType := telemetry.NewLabel("payload_type", payloadTypeLabel(v))
+	telemetry.IncrCounterWithLabels([]string{types.ModuleName, metricExecuteVAA}, 1, []metrics.Label{
+		payloadType,
+		telemetry.NewLabel("status", status),
+	})
+	metrics.MeasureSinceWithLabels([]string{types.ModuleName, metricExecuteVAADuration}, start, []metrics.Label{payloadType})
+}
+
+// recordRedemption counts the redemption of amount, which was minted if it is wrapped.
+func recordRedemption(ctx sdk.Context, amount sdk.Coin, wrapped bool) {
+	if !metricsEnabled(ctx) {
+		return
+	}
+	telemetry.IncrCounterWithLabels([]string{types.ModuleName, metricRedeemed}, 1, []metrics.Label{
+		telemetry.NewLabel("wrapped", fmt.Sprint(wrapped)),
+		telemetry.NewLabel("amount_bucket", amountBucket(amount.Amount)),
+	})
+	if wrapped && amount.Amount.IsInt64() {
+		telemetry.IncrCounterWithLabels([]string{types.ModuleName, metricMinted}, float32(amount.Amount.Int64()), []metrics.Label{
+			telemetry.NewLabel("denom", amount.Denom),
+		})
+	}
+}
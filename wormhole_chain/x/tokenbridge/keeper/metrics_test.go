@@ -0,0 +1,30 @@
+package keeper
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+func TestAmountBucket(t *testing.T) {
+	for amount, bucket := range map[int64]string{
+		0:             "1e0",
+		999:           "1e0",
+		1000:          "1e3",
+		999_999:       "1e3",
+		1_000_000:     "1e6",
+		5_000_000_000: "1e9",
+	} {
+		assert.Equal(t, bucket, amountBucket(sdk.NewInt(amount)), "amount %d", amount)
+	}
+}
+
+func TestPayloadTypeLabel(t *testing.T) {
+	assert.Equal(t, "empty", payloadTypeLabel(&vaa.VAA{}))
+	assert.Equal(t, "transfer", payloadTypeLabel(&vaa.VAA{Payload: []byte{byte(PayloadIDTransfer)}}))
+	assert.Equal(t, "asset_meta", payloadTypeLabel(&vaa.VAA{Payload: []byte{byte(PayloadIDAssetMeta)}}))
+	assert.Equal(t, "transfer_with_payload", payloadTypeLabel(&vaa.VAA{Payload: []byte{byte(PayloadIDTransferWithPayload)}}))
+	assert.Equal(t, "unknown", payloadTypeLabel(&vaa.VAA{Payload: []byte{0xff}}))
+}
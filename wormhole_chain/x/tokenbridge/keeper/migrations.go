@@ -0,0 +1,49 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+)
+
+// Migrator handles in-place store migrations.
+type Migrator struct {
+	keeper Keeper
+}
+
+func NewMigrator(keeper Keeper) Migrator {
+	return Migrator{keeper: keeper}
+}
+
+// Migrate2to3 records custody balances for the native coins held by the
+// module account. Coins locked before custody balances were tracked have no
+// record, so redeeming them would fail with ErrInsufficientCustody. The module
+// account only ever received native coins through transfers, so its whole
+// balance of every native denom is taken as custody.
+func (m Migrator) Migrate2to3(ctx sdk.Context) error {
+	moduleAddress := m.keeper.accountKeeper.GetModuleAddress(types.ModuleName)
+	for _, coin := range m.keeper.bankKeeper.GetAllBalances(ctx, moduleAddress) {
+		if _, _, wrapped := types.GetWrappedCoinMeta(coin.Denom); wrapped {
+			continue
+		}
+		m.keeper.SetCustodyBalance(ctx, types.CustodyBalance{Denom: coin.Denom, Amount: coin.Amount})
+	}
+	return nil
+}
+
+// Migrate3to4 merges the wrapped denoms in legacy formats, which long-running
+// testnets minted before base denoms got the "b" prefix or with upper-case
+// token addresses, into their canonical denom. Transfers redeem and burn only
+// the canonical denom, so coins of a legacy denom could not leave the chain.
+// It also records the per-denom totals of the pending transfers, which
+// SetPendingTransfer and RemovePendingTransfer keep up to date from then on.
+func (m Migrator) Migrate3to4(ctx sdk.Context) error {
+	m.keeper.resetPendingTransferAmounts(ctx)
+
+	holders := m.keeper.legacyDenomHolders(ctx)
+	for _, legacyDenom := range m.keeper.GetLegacyDenoms(ctx) {
+		if err := m.keeper.migrateLegacyDenom(ctx, legacyDenom, holders[legacyDenom.Denom]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -77,12 +77,26 @@ func (k msgServer) AttestToken(goCtx context.Context, msg *types.MsgAttestToken)
 	buf.Write(nameBytes[:])
 
 	// Post message
-	moduleAddress := k.accountKeeper.GetModuleAddress(types.ModuleName)
-	emitterAddress := whtypes.EmitterAddressFromAccAddress(moduleAddress)
-	err = k.wormholeKeeper.PostMessage(ctx, emitterAddress, 0, buf.Bytes())
+	emitterAddress, emitterCap, err := k.emitterCapability(ctx)
 	if err != nil {
 		return nil, err
 	}
+	// The attester pays the message fee
+	creator, err := sdk.AccAddressFromBech32(msg.Creator)
+	if err != nil {
+		return nil, err
+	}
+	err = k.wormholeKeeper.PostMessage(ctx, emitterCap, emitterAddress, creator, 0, buf.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	k.SetAttestedAssetMeta(ctx, types.AttestedAssetMeta{
+		Denom:    meta.Base,
+		Symbol:   meta.Symbol,
+		Name:     meta.Name,
+		Decimals: uint32(exponent),
+		Height:   ctx.BlockHeight(),
+	})
 
 	return &types.MsgAttestTokenResponse{}, nil
 }
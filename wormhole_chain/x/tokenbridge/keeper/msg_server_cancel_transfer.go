@@ -0,0 +1,32 @@
+package keeper
+
+import (
+	"context"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+)
+
+func (k msgServer) CancelTransfer(goCtx context.Context, msg *types.MsgCancelTransfer) (*types.MsgCancelTransferResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	pending, found := k.GetPendingTransfer(ctx, msg.PendingTransferID)
+	if !found {
+		return nil, types.ErrUnknownPendingTransfer
+	}
+	if msg.Creator != pending.Sender {
+		return nil, types.ErrNotTransferSender
+	}
+	// Transfers are released at the end of their release height
+	if ctx.BlockHeight() >= pending.ReleaseHeight {
+		return nil, types.ErrCancelWindowClosed
+	}
+
+	k.RemovePendingTransfer(ctx, pending.Id)
+	if err := k.refundPendingTransfer(ctx, pending, ""); err != nil {
+		return nil, err
+	}
+	k.Logger(ctx).Info("cancelled pending transfer", "id", pending.Id, "sender", pending.Sender)
+
+	return &types.MsgCancelTransferResponse{}, nil
+}
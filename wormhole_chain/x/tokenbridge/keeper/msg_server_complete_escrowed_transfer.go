@@ -0,0 +1,37 @@
+package keeper
+
+import (
+	"context"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+	whtypes "github.com/wormhole-foundation/wormhole-chain/x/wormhole/types"
+)
+
+func (k msgServer) CompleteEscrowedTransfer(goCtx context.Context, msg *types.MsgCompleteEscrowedTransfer) (*types.MsgCompleteEscrowedTransferResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	escrowed, found := k.GetEscrowedTransfer(ctx, msg.Digest)
+	if !found {
+		return nil, types.ErrUnknownEscrowedTransfer
+	}
+	// Escrowed transfers are dropped at the end of their expiry height
+	if ctx.BlockHeight() >= escrowed.ExpiryHeight {
+		return nil, types.ErrEscrowedTransferExpired
+	}
+
+	wormholeConfig, ok := k.wormholeKeeper.GetConfig(ctx)
+	if !ok {
+		return nil, whtypes.ErrNoConfig
+	}
+	// The VAA was verified when it was escrowed. It is not verified again, since its guardian set may have expired.
+	logger := k.Logger(ctx).With("digest", escrowed.Digest, "denom", escrowed.Denom)
+	if err := k.payOutTransfer(ctx, logger, msg.Creator, escrowedTransfer(escrowed, wormholeConfig), wormholeConfig); err != nil {
+		logger.Info("failed to complete escrowed transfer", "error", err)
+		return nil, err
+	}
+	k.RemoveEscrowedTransfer(ctx, escrowed.Digest)
+	logger.Info("completed escrowed transfer")
+
+	return &types.MsgCompleteEscrowedTransferResponse{}, nil
+}
@@ -1,12 +1,18 @@
 package keeper
 
 import (
+	"bytes"
 	"context"
 	"encoding/binary"
+	"fmt"
+	"math/big"
+	"strings"
 
 	"github.com/wormhole-foundation/wormhole-chain/x/wormhole/keeper"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	upgradetypes "github.com/cosmos/cosmos-sdk/x/upgrade/types"
+	host "github.com/cosmos/ibc-go/v3/modules/core/24-host"
 	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
 	whtypes "github.com/wormhole-foundation/wormhole-chain/x/wormhole/types"
 )
@@ -18,7 +24,17 @@ type GovernanceAction uint8
 var TokenBridgeModule = [32]byte{00, 00, 00, 00, 00, 00, 00, 00, 00, 00, 00, 00, 00, 00, 00, 00, 00, 00, 00, 00, 00, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x42, 0x72, 0x69, 0x64, 0x67, 0x65}
 
 var (
-	ActionRegisterChain GovernanceAction = 1
+	ActionRegisterChain   GovernanceAction = 1
+	ActionUpgradeContract GovernanceAction = 2
+	ActionTransferFees    GovernanceAction = 3
+	// ActionRegisterIbcChannel sets the IBC channel the gateway forwards transfers to a chain over.
+	ActionRegisterIbcChannel GovernanceAction = 4
+	// ActionSweepFees moves the fees of a native denom held by the module account to the fee account.
+	ActionSweepFees GovernanceAction = 5
+	// ActionSetWrappedAssetFlags marks the wrapped asset of a token as fee-on-transfer or rebasing.
+	ActionSetWrappedAssetFlags GovernanceAction = 6
+	// ActionSetWrappedAssetLocalization sets the localized names and the asset list ID of the wrapped asset of a token.
+	ActionSetWrappedAssetLocalization GovernanceAction = 7
 )
 
 func (k msgServer) ExecuteGovernanceVAA(goCtx context.Context, msg *types.MsgExecuteGovernanceVAA) (*types.MsgExecuteGovernanceVAAResponse, error) {
@@ -31,7 +47,7 @@ func (k msgServer) ExecuteGovernanceVAA(goCtx context.Context, msg *types.MsgExe
 	}
 
 	// Verify VAA
-	action, payload, err := k.wormholeKeeper.VerifyGovernanceVAA(ctx, v, TokenBridgeModule)
+	action, targetChain, payload, err := k.wormholeKeeper.VerifyGovernanceVAA(ctx, v, TokenBridgeModule, sdk.MsgTypeURL(msg))
 	if err != nil {
 		return nil, err
 	}
@@ -44,6 +60,10 @@ func (k msgServer) ExecuteGovernanceVAA(goCtx context.Context, msg *types.MsgExe
 	// Execute action
 	switch GovernanceAction(action) {
 	case ActionRegisterChain:
+		// Registrations apply to all chains
+		if !whtypes.IsGovernanceTarget(targetChain, uint16(wormholeConfig.ChainId), true) {
+			return nil, types.ErrInvalidGovernanceTargetChain
+		}
 		if len(payload) != 34 {
 			return nil, types.ErrInvalidGovernancePayloadLength
 		}
@@ -70,6 +90,216 @@ func (k msgServer) ExecuteGovernanceVAA(goCtx context.Context, msg *types.MsgExe
 			EmitterAddress: bridgeEmitter,
 		})
 
+		if err != nil {
+			return nil, err
+		}
+	case ActionUpgradeContract:
+		// Upgrades can only target a specific chain
+		if !whtypes.IsGovernanceTarget(targetChain, uint16(wormholeConfig.ChainId), false) {
+			return nil, types.ErrInvalidGovernanceTargetChain
+		}
+		if len(payload) != 32 {
+			return nil, types.ErrInvalidGovernancePayloadLength
+		}
+
+		// There is no contract to replace on wormhole chain. Instead the new
+		// "contract address" is the left-padded name of the software upgrade
+		// the chain has to run. Scheduling it for the next block halts nodes
+		// unless their binary has an upgrade handler registered for it.
+		name := strings.TrimLeft(string(payload), "\x00")
+		if name == "" || strings.ContainsRune(name, 0) {
+			return nil, types.ErrInvalidUpgradeName
+		}
+
+		plan := upgradetypes.Plan{
+			Name:   name,
+			Height: ctx.BlockHeight() + 1,
+			Info:   fmt.Sprintf("tokenbridge governance VAA %s", v.HexDigest()),
+		}
+		if err := k.upgradeKeeper.ScheduleUpgrade(ctx, plan); err != nil {
+			return nil, fmt.Errorf("%w: %s", types.ErrInvalidUpgradeName, err)
+		}
+
+		err = ctx.EventManager().EmitTypedEvent(&types.EventUpgradeScheduled{
+			Name:   plan.Name,
+			Height: plan.Height,
+		})
+		if err != nil {
+			return nil, err
+		}
+	case ActionTransferFees:
+		// Fees can only be paid out by the chain holding them
+		if !whtypes.IsGovernanceTarget(targetChain, uint16(wormholeConfig.ChainId), false) {
+			return nil, types.ErrInvalidGovernanceTargetChain
+		}
+		if len(payload) != 96 {
+			return nil, types.ErrInvalidGovernancePayloadLength
+		}
+
+		// Payload: amount (32) | denom as token address (32) | recipient (32)
+		amount := sdk.NewIntFromBigInt(new(big.Int).SetBytes(payload[:32]))
+		if amount.IsZero() {
+			return nil, types.ErrZeroAmount
+		}
+		var tokenAddress [32]byte
+		copy(tokenAddress[:], payload[32:64])
+		denom, err := types.DenomFromTokenAddress(tokenAddress)
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(payload[64:76], make([]byte, 12)) {
+			return nil, types.ErrInvalidFeeRecipient
+		}
+		recipient := sdk.AccAddress(payload[76:96])
+
+		// Fees are paid out of the fee account, never out of the coins in
+		// custody.
+		feeAddress := k.FeeAddress(ctx)
+		available := k.bankKeeper.GetBalance(ctx, feeAddress, denom).Amount
+		if available.LT(amount) {
+			return nil, fmt.Errorf("%w: %s%s requested, %s%s available", types.ErrInsufficientFees, amount, denom, available, denom)
+		}
+
+		if err := k.bankKeeper.SendCoins(ctx, feeAddress, recipient, sdk.NewCoins(sdk.NewCoin(denom, amount))); err != nil {
+			return nil, err
+		}
+
+		err = ctx.EventManager().EmitTypedEvent(&types.EventFeesTransferred{
+			Recipient: recipient.String(),
+			Amount:    amount.String(),
+			Denom:     denom,
+		})
+		if err != nil {
+			return nil, err
+		}
+	case ActionRegisterIbcChannel:
+		// IBC channels are specific to wormhole chain
+		if !whtypes.IsGovernanceTarget(targetChain, uint16(wormholeConfig.ChainId), false) {
+			return nil, types.ErrInvalidGovernanceTargetChain
+		}
+		if len(payload) != 128 {
+			return nil, types.ErrInvalidGovernancePayloadLength
+		}
+
+		// Payload: chain ID (64) | channel ID (64), both left-padded with zeros. An empty channel ID removes the
+		// channel of the chain.
+		chainID := strings.TrimLeft(string(payload[:64]), "\x00")
+		channelID := strings.TrimLeft(string(payload[64:]), "\x00")
+		if chainID == "" || strings.ContainsRune(chainID, 0) {
+			return nil, fmt.Errorf("%w: invalid chain ID", types.ErrInvalidIbcChannel)
+		}
+		if channelID == "" {
+			k.RemoveIbcChannel(ctx, chainID)
+		} else {
+			if err := host.ChannelIdentifierValidator(channelID); err != nil {
+				return nil, fmt.Errorf("%w: %s", types.ErrInvalidIbcChannel, err)
+			}
+			k.SetIbcChannel(ctx, types.IbcChannel{
+				ChainID:   chainID,
+				ChannelID: channelID,
+			})
+		}
+
+		err = ctx.EventManager().EmitTypedEvent(&types.EventIbcChannelRegistered{
+			ChainID:   chainID,
+			ChannelID: channelID,
+		})
+		if err != nil {
+			return nil, err
+		}
+	case ActionSweepFees:
+		// Fees can only be swept by the chain holding them
+		if !whtypes.IsGovernanceTarget(targetChain, uint16(wormholeConfig.ChainId), false) {
+			return nil, types.ErrInvalidGovernanceTargetChain
+		}
+		if len(payload) != 32 {
+			return nil, types.ErrInvalidGovernancePayloadLength
+		}
+
+		// Payload: denom as token address (32)
+		var tokenAddress [32]byte
+		copy(tokenAddress[:], payload)
+		denom, err := types.DenomFromTokenAddress(tokenAddress)
+		if err != nil {
+			return nil, err
+		}
+		swept, err := k.sweepFees(ctx, denom)
+		if err != nil {
+			return nil, err
+		}
+
+		err = ctx.EventManager().EmitTypedEvent(&types.EventFeesSwept{
+			Amount: swept.Amount.String(),
+			Denom:  swept.Denom,
+		})
+		if err != nil {
+			return nil, err
+		}
+	case ActionSetWrappedAssetFlags:
+		// Wrapped assets are specific to wormhole chain
+		if !whtypes.IsGovernanceTarget(targetChain, uint16(wormholeConfig.ChainId), false) {
+			return nil, types.ErrInvalidGovernanceTargetChain
+		}
+		if len(payload) != 35 {
+			return nil, types.ErrInvalidGovernancePayloadLength
+		}
+
+		// Payload: token chain (2) | token address (32) | flags (1). Setting no flag clears the flags of the asset.
+		tokenChain := binary.BigEndian.Uint16(payload[:2])
+		var tokenAddress [32]byte
+		copy(tokenAddress[:], payload[2:34])
+		if uint32(tokenChain) == wormholeConfig.ChainId || types.IsWORMToken(tokenChain, tokenAddress) {
+			return nil, fmt.Errorf("%w: token is not a wrapped asset", types.ErrInvalidWrappedAssetFlags)
+		}
+		flags, err := types.NewWrappedAssetFlags(tokenChain, tokenAddress, payload[34])
+		if err != nil {
+			return nil, err
+		}
+		if flags.IsEmpty() {
+			k.RemoveWrappedAssetFlags(ctx, flags.Denom)
+		} else {
+			k.SetWrappedAssetFlags(ctx, flags)
+		}
+
+		err = ctx.EventManager().EmitTypedEvent(&types.EventWrappedAssetFlagsUpdated{
+			Denom:         flags.Denom,
+			TokenChain:    flags.TokenChain,
+			TokenAddress:  flags.TokenAddress,
+			FeeOnTransfer: flags.FeeOnTransfer,
+			Rebasing:      flags.Rebasing,
+		})
+		if err != nil {
+			return nil, err
+		}
+	case ActionSetWrappedAssetLocalization:
+		// Wrapped assets are specific to wormhole chain
+		if !whtypes.IsGovernanceTarget(targetChain, uint16(wormholeConfig.ChainId), false) {
+			return nil, types.ErrInvalidGovernanceTargetChain
+		}
+
+		// A localization without asset list ID and names clears the one of the asset.
+		localization, err := types.ParseWrappedAssetLocalization(payload)
+		if err != nil {
+			return nil, err
+		}
+		var tokenAddress [32]byte
+		copy(tokenAddress[:], localization.TokenAddress)
+		if localization.TokenChain == wormholeConfig.ChainId || types.IsWORMToken(uint16(localization.TokenChain), tokenAddress) {
+			return nil, fmt.Errorf("%w: token is not a wrapped asset", types.ErrInvalidAssetLocalization)
+		}
+		if localization.IsEmpty() {
+			k.RemoveWrappedAssetLocalization(ctx, localization.Denom)
+		} else {
+			k.SetWrappedAssetLocalization(ctx, localization)
+		}
+
+		err = ctx.EventManager().EmitTypedEvent(&types.EventWrappedAssetLocalizationUpdated{
+			Denom:          localization.Denom,
+			TokenChain:     localization.TokenChain,
+			TokenAddress:   localization.TokenAddress,
+			AssetListId:    localization.AssetListId,
+			LocalizedNames: localization.LocalizedNames,
+		})
 		if err != nil {
 			return nil, err
 		}
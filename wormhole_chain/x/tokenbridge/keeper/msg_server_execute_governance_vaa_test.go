@@ -0,0 +1,395 @@
+package keeper_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/keeper"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+	whtypes "github.com/wormhole-foundation/wormhole-chain/x/wormhole/types"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+func createGovernanceVAA(t *testing.T, action keeper.GovernanceAction, targetChain vaa.ChainID, payload []byte) []byte {
+	govMsg := whtypes.NewGovernanceMessage(keeper.TokenBridgeModule, byte(action), uint16(targetChain), payload)
+	v := &vaa.VAA{
+		Version:          vaa.SupportedVAAVersion,
+		Timestamp:        time.Unix(0, 0),
+		EmitterChain:     vaa.GovernanceChain,
+		EmitterAddress:   vaa.GovernanceEmitter,
+		Sequence:         1,
+		ConsistencyLevel: 1,
+		Payload:          govMsg.MarshalBinary(),
+	}
+	bz, err := v.Marshal()
+	require.NoError(t, err)
+	return bz
+}
+
+func TestExecuteGovernanceVAARegisterChain(t *testing.T) {
+	emitter := bytes.Repeat([]byte{0xee}, 32)
+	registerChain := func(chain vaa.ChainID) []byte {
+		payload := make([]byte, 2, 34)
+		binary.BigEndian.PutUint16(payload, uint16(chain))
+		return append(payload, emitter...)
+	}
+
+	tests := []struct {
+		label       string
+		targetChain vaa.ChainID
+		payload     []byte
+		err         error
+	}{
+		{label: "all chains", targetChain: 0, payload: registerChain(vaa.ChainIDEthereum)},
+		{label: "this chain", targetChain: vaa.ChainIDWormchain, payload: registerChain(vaa.ChainIDEthereum)},
+		{label: "other chain", targetChain: vaa.ChainIDSolana, payload: registerChain(vaa.ChainIDEthereum), err: types.ErrInvalidGovernanceTargetChain},
+		{label: "register wormchain", targetChain: 0, payload: registerChain(vaa.ChainIDWormchain), err: types.ErrRegisterWormholeChain},
+		{label: "short payload", targetChain: 0, payload: registerChain(vaa.ChainIDEthereum)[:33], err: types.ErrInvalidGovernancePayloadLength},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.label, func(t *testing.T) {
+			msgServer, k, ctx, _ := setupMockedMsgServer(t)
+
+			_, err := msgServer.ExecuteGovernanceVAA(sdk.WrapSDKContext(ctx), &types.MsgExecuteGovernanceVAA{
+				Vaa: createGovernanceVAA(t, keeper.ActionRegisterChain, tc.targetChain, tc.payload),
+			})
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				assert.Empty(t, k.GetAllChainRegistration(ctx))
+				return
+			}
+			require.NoError(t, err)
+
+			registration, found := k.GetChainRegistration(ctx, uint32(vaa.ChainIDEthereum))
+			require.True(t, found)
+			assert.Equal(t, emitter, registration.EmitterAddress)
+		})
+	}
+}
+
+func TestExecuteGovernanceVAAUpgradeContract(t *testing.T) {
+	name, err := types.PadStringToByte32("v2.0.0")
+	require.NoError(t, err)
+
+	tests := []struct {
+		label       string
+		targetChain vaa.ChainID
+		payload     []byte
+		err         error
+	}{
+		{label: "upgrade", targetChain: vaa.ChainIDWormchain, payload: name[:]},
+		{label: "all chains", targetChain: 0, payload: name[:], err: types.ErrInvalidGovernanceTargetChain},
+		{label: "other chain", targetChain: vaa.ChainIDEthereum, payload: name[:], err: types.ErrInvalidGovernanceTargetChain},
+		{label: "short payload", targetChain: vaa.ChainIDWormchain, payload: name[1:], err: types.ErrInvalidGovernancePayloadLength},
+		{label: "empty name", targetChain: vaa.ChainIDWormchain, payload: make([]byte, 32), err: types.ErrInvalidUpgradeName},
+		{label: "embedded zero byte", targetChain: vaa.ChainIDWormchain, payload: append([]byte("v2"), make([]byte, 30)...), err: types.ErrInvalidUpgradeName},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.label, func(t *testing.T) {
+			msgServer, _, ctx, mocks := setupMockedMsgServer(t)
+			ctx = ctx.WithBlockHeight(10)
+
+			_, err := msgServer.ExecuteGovernanceVAA(sdk.WrapSDKContext(ctx), &types.MsgExecuteGovernanceVAA{
+				Vaa: createGovernanceVAA(t, keeper.ActionUpgradeContract, tc.targetChain, tc.payload),
+			})
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				assert.Empty(t, mocks.upgrade.plans)
+				return
+			}
+			require.NoError(t, err)
+
+			require.Len(t, mocks.upgrade.plans, 1)
+			assert.Equal(t, "v2.0.0", mocks.upgrade.plans[0].Name)
+			assert.Equal(t, int64(11), mocks.upgrade.plans[0].Height)
+		})
+	}
+}
+
+func createTransferFeesPayload(amount *big.Int, denom string, recipient sdk.AccAddress) []byte {
+	tokenAddress, _ := types.PadStringToByte32(denom)
+	payload := make([]byte, 96)
+	amount.FillBytes(payload[:32])
+	copy(payload[32:64], tokenAddress[:])
+	copy(payload[76:96], recipient)
+	return payload
+}
+
+// unpaddedTransferFeesPayload sets a byte of the padding in front of the recipient of payload.
+func unpaddedTransferFeesPayload(payload []byte) []byte {
+	payload[64] = 0x01
+	return payload
+}
+
+func TestExecuteGovernanceVAATransferFees(t *testing.T) {
+	recipient := sdk.AccAddress(bytes.Repeat([]byte{0xcc}, 20))
+
+	tests := []struct {
+		label       string
+		targetChain vaa.ChainID
+		payload     []byte
+		err         error
+	}{
+		{label: "transfer fees", targetChain: vaa.ChainIDWormchain, payload: createTransferFeesPayload(big.NewInt(40), "uatom", recipient)},
+		{label: "all fees", targetChain: vaa.ChainIDWormchain, payload: createTransferFeesPayload(big.NewInt(50), "uatom", recipient)},
+		{label: "custody funds", targetChain: vaa.ChainIDWormchain, payload: createTransferFeesPayload(big.NewInt(51), "uatom", recipient), err: types.ErrInsufficientFees},
+		{label: "unknown denom", targetChain: vaa.ChainIDWormchain, payload: createTransferFeesPayload(big.NewInt(1), "uosmo", recipient), err: types.ErrInsufficientFees},
+		{label: "zero amount", targetChain: vaa.ChainIDWormchain, payload: createTransferFeesPayload(big.NewInt(0), "uatom", recipient), err: types.ErrZeroAmount},
+		{label: "invalid denom", targetChain: vaa.ChainIDWormchain, payload: createTransferFeesPayload(big.NewInt(1), "1uatom", recipient), err: types.ErrInvalidNativeDenom},
+		{label: "all chains", targetChain: 0, payload: createTransferFeesPayload(big.NewInt(40), "uatom", recipient), err: types.ErrInvalidGovernanceTargetChain},
+		{label: "other chain", targetChain: vaa.ChainIDEthereum, payload: createTransferFeesPayload(big.NewInt(40), "uatom", recipient), err: types.ErrInvalidGovernanceTargetChain},
+		{label: "short payload", targetChain: vaa.ChainIDWormchain, payload: make([]byte, 95), err: types.ErrInvalidGovernancePayloadLength},
+		{label: "unpadded recipient", targetChain: vaa.ChainIDWormchain, payload: unpaddedTransferFeesPayload(createTransferFeesPayload(big.NewInt(40), "uatom", recipient)), err: types.ErrInvalidFeeRecipient},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.label, func(t *testing.T) {
+			msgServer, k, ctx, mocks := setupMockedMsgServer(t)
+			moduleAddress := authtypes.NewModuleAddress(types.ModuleName)
+			feeAddress := k.FeeAddress(ctx)
+
+			// 100uatom in custody are held by the module, and 50uatom of fees by the fee account
+			require.NoError(t, mocks.bank.MintCoins(ctx, types.ModuleName, sdk.NewCoins(sdk.NewInt64Coin("uatom", 100))))
+			k.SetCustodyBalance(ctx, types.CustodyBalance{Denom: "uatom", Amount: sdk.NewInt(100)})
+			require.NoError(t, mocks.bank.MintCoins(ctx, types.DefaultFeeAccountName, sdk.NewCoins(sdk.NewInt64Coin("uatom", 50))))
+
+			_, err := msgServer.ExecuteGovernanceVAA(sdk.WrapSDKContext(ctx), &types.MsgExecuteGovernanceVAA{
+				Vaa: createGovernanceVAA(t, keeper.ActionTransferFees, tc.targetChain, tc.payload),
+			})
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				assert.Zero(t, mocks.bank.sends)
+				return
+			}
+			require.NoError(t, err)
+
+			amount := new(big.Int).SetBytes(tc.payload[:32]).Int64()
+			assert.Equal(t, amount, mocks.bank.GetBalance(ctx, recipient, "uatom").Amount.Int64())
+			assert.Equal(t, 50-amount, mocks.bank.GetBalance(ctx, feeAddress, "uatom").Amount.Int64())
+			assert.Equal(t, int64(100), mocks.bank.GetBalance(ctx, moduleAddress, "uatom").Amount.Int64())
+
+			var transferred []*types.EventFeesTransferred
+			for _, event := range ctx.EventManager().Events() {
+				parsed, err := sdk.ParseTypedEvent(abci.Event(event))
+				require.NoError(t, err)
+				if e, ok := parsed.(*types.EventFeesTransferred); ok {
+					transferred = append(transferred, e)
+				}
+			}
+			require.Len(t, transferred, 1)
+			assert.Equal(t, recipient.String(), transferred[0].Recipient)
+			assert.Equal(t, fmt.Sprint(amount), transferred[0].Amount)
+			assert.Equal(t, "uatom", transferred[0].Denom)
+		})
+	}
+}
+
+func TestExecuteGovernanceVAASweepFees(t *testing.T) {
+	sweepFeesPayload := func(denom string) []byte {
+		tokenAddress, _ := types.PadStringToByte32(denom)
+		return tokenAddress[:]
+	}
+
+	tests := []struct {
+		label       string
+		targetChain vaa.ChainID
+		payload     []byte
+		err         error
+	}{
+		{label: "sweep fees", targetChain: vaa.ChainIDWormchain, payload: sweepFeesPayload("uatom")},
+		{label: "no fees", targetChain: vaa.ChainIDWormchain, payload: sweepFeesPayload("uosmo"), err: types.ErrNoFeesToSweep},
+		{label: "invalid denom", targetChain: vaa.ChainIDWormchain, payload: sweepFeesPayload("1uatom"), err: types.ErrInvalidNativeDenom},
+		{label: "other chain", targetChain: vaa.ChainIDEthereum, payload: sweepFeesPayload("uatom"), err: types.ErrInvalidGovernanceTargetChain},
+		{label: "short payload", targetChain: vaa.ChainIDWormchain, payload: make([]byte, 31), err: types.ErrInvalidGovernancePayloadLength},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.label, func(t *testing.T) {
+			msgServer, k, ctx, mocks := setupMockedMsgServer(t)
+			moduleAddress := authtypes.NewModuleAddress(types.ModuleName)
+
+			// 100uatom are held by the module, 50uatom of which back tokens sent to other chains and 20uatom a
+			// pending transfer. 100uosmo are all in custody.
+			require.NoError(t, mocks.bank.MintCoins(ctx, types.ModuleName, sdk.NewCoins(sdk.NewInt64Coin("uatom", 100), sdk.NewInt64Coin("uosmo", 100))))
+			k.SetCustodyBalance(ctx, types.CustodyBalance{Denom: "uatom", Amount: sdk.NewInt(50)})
+			k.SetCustodyBalance(ctx, types.CustodyBalance{Denom: "uosmo", Amount: sdk.NewInt(100)})
+			k.SetPendingTransfer(ctx, types.PendingTransfer{Id: 1, Amount: sdk.NewInt64Coin("uatom", 20), Fee: sdk.ZeroInt()})
+
+			_, err := msgServer.ExecuteGovernanceVAA(sdk.WrapSDKContext(ctx), &types.MsgExecuteGovernanceVAA{
+				Vaa: createGovernanceVAA(t, keeper.ActionSweepFees, tc.targetChain, tc.payload),
+			})
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				assert.Zero(t, mocks.bank.sends)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, int64(70), mocks.bank.GetBalance(ctx, moduleAddress, "uatom").Amount.Int64())
+			res, err := k.FeeBalances(sdk.WrapSDKContext(ctx), &types.QueryFeeBalancesRequest{})
+			require.NoError(t, err)
+			assert.Equal(t, k.FeeAddress(ctx).String(), res.FeeAddress)
+			assert.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("uatom", 30)), res.Balances)
+		})
+	}
+}
+
+func TestExecuteGovernanceVAARegisterIbcChannel(t *testing.T) {
+	registerIbcChannel := func(chainID, channelID string) []byte {
+		payload := make([]byte, 128)
+		copy(payload[64-len(chainID):64], chainID)
+		copy(payload[128-len(channelID):], channelID)
+		return payload
+	}
+
+	tests := []struct {
+		label       string
+		targetChain vaa.ChainID
+		payload     []byte
+		err         error
+		expected    []types.IbcChannel
+	}{
+		{label: "register", targetChain: vaa.ChainIDWormchain, payload: registerIbcChannel("osmosis-1", "channel-1"), expected: []types.IbcChannel{{ChainID: "osmosis-1", ChannelID: "channel-1"}}},
+		{label: "remove", targetChain: vaa.ChainIDWormchain, payload: registerIbcChannel("osmosis-1", "")},
+		{label: "all chains", targetChain: 0, payload: registerIbcChannel("osmosis-1", "channel-1"), err: types.ErrInvalidGovernanceTargetChain},
+		{label: "empty chain ID", targetChain: vaa.ChainIDWormchain, payload: registerIbcChannel("", "channel-1"), err: types.ErrInvalidIbcChannel},
+		{label: "invalid channel", targetChain: vaa.ChainIDWormchain, payload: registerIbcChannel("osmosis-1", "channel/1"), err: types.ErrInvalidIbcChannel},
+		{label: "short payload", targetChain: vaa.ChainIDWormchain, payload: registerIbcChannel("osmosis-1", "channel-1")[:127], err: types.ErrInvalidGovernancePayloadLength},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.label, func(t *testing.T) {
+			msgServer, k, ctx, _ := setupMockedMsgServer(t)
+			k.SetIbcChannel(ctx, types.IbcChannel{ChainID: "osmosis-1", ChannelID: "channel-0"})
+
+			_, err := msgServer.ExecuteGovernanceVAA(sdk.WrapSDKContext(ctx), &types.MsgExecuteGovernanceVAA{
+				Vaa: createGovernanceVAA(t, keeper.ActionRegisterIbcChannel, tc.targetChain, tc.payload),
+			})
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				assert.Equal(t, []types.IbcChannel{{ChainID: "osmosis-1", ChannelID: "channel-0"}}, k.GetAllIbcChannel(ctx))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, k.GetAllIbcChannel(ctx))
+		})
+	}
+}
+
+func TestExecuteGovernanceVAASetWrappedAssetFlags(t *testing.T) {
+	setWrappedAssetFlags := func(chain vaa.ChainID, flags byte) []byte {
+		payload := make([]byte, 2, 35)
+		binary.BigEndian.PutUint16(payload, uint16(chain))
+		payload = append(payload, testTokenAddress[:]...)
+		return append(payload, flags)
+	}
+	denom := "b" + types.GetWrappedCoinIdentifier(uint16(vaa.ChainIDEthereum), testTokenAddress)
+	rebasing := types.WrappedAssetFlags{Denom: denom, TokenChain: uint32(vaa.ChainIDEthereum), TokenAddress: testTokenAddress[:], Rebasing: true}
+
+	tests := []struct {
+		label       string
+		targetChain vaa.ChainID
+		payload     []byte
+		err         error
+		expected    []types.WrappedAssetFlags
+	}{
+		{
+			label:       "fee on transfer",
+			targetChain: vaa.ChainIDWormchain,
+			payload:     setWrappedAssetFlags(vaa.ChainIDEthereum, types.WrappedAssetFlagFeeOnTransfer),
+			expected:    []types.WrappedAssetFlags{{Denom: denom, TokenChain: uint32(vaa.ChainIDEthereum), TokenAddress: testTokenAddress[:], FeeOnTransfer: true}},
+		},
+		{
+			label:       "both",
+			targetChain: vaa.ChainIDWormchain,
+			payload:     setWrappedAssetFlags(vaa.ChainIDEthereum, types.WrappedAssetFlagFeeOnTransfer|types.WrappedAssetFlagRebasing),
+			expected:    []types.WrappedAssetFlags{{Denom: denom, TokenChain: uint32(vaa.ChainIDEthereum), TokenAddress: testTokenAddress[:], FeeOnTransfer: true, Rebasing: true}},
+		},
+		{label: "clear", targetChain: vaa.ChainIDWormchain, payload: setWrappedAssetFlags(vaa.ChainIDEthereum, 0)},
+		{label: "unknown flag", targetChain: vaa.ChainIDWormchain, payload: setWrappedAssetFlags(vaa.ChainIDEthereum, 4), err: types.ErrInvalidWrappedAssetFlags},
+		{label: "native token", targetChain: vaa.ChainIDWormchain, payload: setWrappedAssetFlags(vaa.ChainIDWormchain, 1), err: types.ErrInvalidWrappedAssetFlags},
+		{label: "all chains", targetChain: 0, payload: setWrappedAssetFlags(vaa.ChainIDEthereum, 1), err: types.ErrInvalidGovernanceTargetChain},
+		{label: "short payload", targetChain: vaa.ChainIDWormchain, payload: setWrappedAssetFlags(vaa.ChainIDEthereum, 1)[:34], err: types.ErrInvalidGovernancePayloadLength},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.label, func(t *testing.T) {
+			msgServer, k, ctx, _ := setupMockedMsgServer(t)
+			k.SetWrappedAssetFlags(ctx, rebasing)
+
+			_, err := msgServer.ExecuteGovernanceVAA(sdk.WrapSDKContext(ctx), &types.MsgExecuteGovernanceVAA{
+				Vaa: createGovernanceVAA(t, keeper.ActionSetWrappedAssetFlags, tc.targetChain, tc.payload),
+			})
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				assert.Equal(t, []types.WrappedAssetFlags{rebasing}, k.GetAllWrappedAssetFlags(ctx))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, k.GetAllWrappedAssetFlags(ctx))
+		})
+	}
+}
+
+func TestExecuteGovernanceVAASetWrappedAssetLocalization(t *testing.T) {
+	localization := func(chain vaa.ChainID, assetListID string, names ...types.LocalizedName) types.WrappedAssetLocalization {
+		return types.WrappedAssetLocalization{
+			Denom:          "b" + types.GetWrappedCoinIdentifier(uint16(chain), testTokenAddress),
+			TokenChain:     uint32(chain),
+			TokenAddress:   testTokenAddress[:],
+			AssetListId:    assetListID,
+			LocalizedNames: names,
+		}
+	}
+	en := types.LocalizedName{Locale: "en", Name: "Wrapped Ether"}
+	existing := localization(vaa.ChainIDEthereum, "ethereum")
+
+	tests := []struct {
+		label       string
+		targetChain vaa.ChainID
+		payload     []byte
+		err         error
+		expected    []types.WrappedAssetLocalization
+	}{
+		{
+			label:       "replace",
+			targetChain: vaa.ChainIDWormchain,
+			payload:     localization(vaa.ChainIDEthereum, "weth", en).Serialize(),
+			expected:    []types.WrappedAssetLocalization{localization(vaa.ChainIDEthereum, "weth", en)},
+		},
+		{label: "clear", targetChain: vaa.ChainIDWormchain, payload: localization(vaa.ChainIDEthereum, "").Serialize()},
+		{label: "invalid name", targetChain: vaa.ChainIDWormchain, payload: localization(vaa.ChainIDEthereum, "", types.LocalizedName{Locale: "en"}).Serialize(), err: types.ErrInvalidAssetLocalization},
+		{label: "native token", targetChain: vaa.ChainIDWormchain, payload: localization(vaa.ChainIDWormchain, "weth").Serialize(), err: types.ErrInvalidAssetLocalization},
+		{label: "all chains", targetChain: 0, payload: localization(vaa.ChainIDEthereum, "weth").Serialize(), err: types.ErrInvalidGovernanceTargetChain},
+		{label: "short payload", targetChain: vaa.ChainIDWormchain, payload: localization(vaa.ChainIDEthereum, "weth").Serialize()[:35], err: types.ErrInvalidGovernancePayloadLength},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.label, func(t *testing.T) {
+			msgServer, k, ctx, _ := setupMockedMsgServer(t)
+			k.SetWrappedAssetLocalization(ctx, existing)
+
+			_, err := msgServer.ExecuteGovernanceVAA(sdk.WrapSDKContext(ctx), &types.MsgExecuteGovernanceVAA{
+				Vaa: createGovernanceVAA(t, keeper.ActionSetWrappedAssetLocalization, tc.targetChain, tc.payload),
+			})
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				assert.Equal(t, []types.WrappedAssetLocalization{existing}, k.GetAllWrappedAssetLocalization(ctx))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, k.GetAllWrappedAssetLocalization(ctx))
+		})
+	}
+}
@@ -1,16 +1,19 @@
 package keeper
 
 import (
-	"bytes"
 	"context"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"math/big"
 	"strings"
+	"time"
 
 	btypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/tendermint/tendermint/libs/log"
 	"github.com/wormhole-foundation/wormhole-chain/x/wormhole/keeper"
 	whtypes "github.com/wormhole-foundation/wormhole-chain/x/wormhole/types"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
@@ -21,19 +24,67 @@ type PayloadID uint8
 var (
 	PayloadIDTransfer  PayloadID = 1
 	PayloadIDAssetMeta PayloadID = 2
+	// PayloadIDTransferWithPayload transfers are forwarded over IBC if they are sent to the gateway, and can only be
+	// redeemed by their recipient otherwise.
+	PayloadIDTransferWithPayload PayloadID = 3
+	// PayloadIDDeliveryReceipt is only posted by wormhole chain, and is not a payload of the token bridges of other
+	// chains.
+	PayloadIDDeliveryReceipt PayloadID = 4
 )
 
+// messageType returns the message type the wormhole config can require a minimum guardian set index for.
+func (p PayloadID) messageType() string {
+	switch p {
+	case PayloadIDTransfer:
+		return whtypes.MessageTypeTokenTransfer
+	case PayloadIDAssetMeta:
+		return whtypes.MessageTypeAssetMeta
+	case PayloadIDTransferWithPayload:
+		return whtypes.MessageTypeTokenTransferWithPayload
+	}
+	return ""
+}
+
 func (k msgServer) ExecuteVAA(goCtx context.Context, msg *types.MsgExecuteVAA) (*types.MsgExecuteVAAResponse, error) {
 	ctx := sdk.UnwrapSDKContext(goCtx)
 
 	// Parse VAA
 	v, err := keeper.ParseVAA(msg.Vaa)
 	if err != nil {
+		k.Logger(ctx).Info("rejected VAA that failed to parse", "error", err)
+		return nil, err
+	}
+
+	logger := k.Logger(ctx).With(
+		"digest", v.HexDigest(),
+		"emitter_chain", v.EmitterChain,
+		"emitter", v.EmitterAddress,
+		"sequence", v.Sequence,
+	)
+	if len(v.Payload) > 0 {
+		logger = logger.With("payload_type", v.Payload[0])
+	}
+
+	start := time.Now()
+	res, err := k.executeVAA(ctx, logger, msg, v)
+	recordExecuteVAA(ctx, v, start, err)
+	if err != nil {
+		logger.Info("failed to execute VAA", "error", err)
+		return nil, err
+	}
+	logger.Info("executed VAA")
+
+	return res, nil
+}
+
+func (k msgServer) executeVAA(ctx sdk.Context, logger log.Logger, msg *types.MsgExecuteVAA, v *vaa.VAA) (*types.MsgExecuteVAAResponse, error) {
+	// Fail cheaply before verifying signatures
+	if err := k.PrecheckVAA(ctx, v); err != nil {
 		return nil, err
 	}
 
 	// Verify VAA
-	err = k.wormholeKeeper.VerifyVAA(ctx, v)
+	err := k.wormholeKeeper.VerifyVAA(ctx, v)
 	if err != nil {
 		return nil, err
 	}
@@ -43,215 +94,388 @@ func (k msgServer) ExecuteVAA(goCtx context.Context, msg *types.MsgExecuteVAA) (
 		return nil, whtypes.ErrNoConfig
 	}
 
-	// Replay protection
-	_, known := k.GetReplayProtection(ctx, v.HexDigest())
-	if known {
-		return nil, types.ErrVAAAlreadyExecuted
+	if len(v.Payload) < 1 {
+		return nil, types.ErrVAAPayloadInvalid
 	}
 
-	// Check if emitter is a registered chain
-	registration, found := k.GetChainRegistration(ctx, uint32(v.EmitterChain))
-	if !found {
-		return nil, types.ErrUnregisteredChain
+	payloadID := PayloadID(v.Payload[0])
+	payload := v.Payload[1:]
+
+	if err := wormholeConfig.ValidateGuardianSetIndex(v, payloadID.messageType()); err != nil {
+		return nil, err
+	}
+
+	handler, ok := k.payloadHandlers[payloadID]
+	if !ok {
+		return nil, types.ErrUnknownPayloadType
 	}
-	if !bytes.Equal(v.EmitterAddress[:], registration.EmitterAddress) {
-		return nil, types.ErrUnregisteredEmitter
+	if err := handler(k.Keeper, ctx, logger, msg, v, wormholeConfig, payload); err != nil {
+		return nil, err
 	}
 
-	if len(v.Payload) < 1 {
-		return nil, types.ErrVAAPayloadInvalid
+	// Prevent replay
+	k.SetReplayProtection(ctx, types.ReplayProtection{Index: v.HexDigest()})
+	err = ctx.EventManager().EmitTypedEvent(&whtypes.EventVAAConsumed{
+		Digest:      v.HexDigest(),
+		PayloadType: uint32(payloadID),
+		MsgType:     sdk.MsgTypeURL(msg),
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	payloadID := PayloadID(v.Payload[0])
-	payload := v.Payload[1:]
+	return &types.MsgExecuteVAAResponse{}, nil
+}
 
-	switch payloadID {
-	case PayloadIDTransfer:
-		if len(payload) != 132 {
-			return nil, types.ErrVAAPayloadInvalid
-		}
-		unnormalizedAmount := new(big.Int).SetBytes(payload[:32])
-		var tokenAddress [32]byte
-		copy(tokenAddress[:], payload[32:64])
-		tokenChain := binary.BigEndian.Uint16(payload[64:66])
-		var to [20]byte
-		copy(to[:], payload[78:98])
-		toChain := binary.BigEndian.Uint16(payload[98:100])
-		unnormalizedFee := new(big.Int).SetBytes(payload[100:132])
-
-		// Check that the transfer is to this chain
-		if uint32(toChain) != wormholeConfig.ChainId {
-			return nil, types.ErrInvalidTargetChain
-		}
+// executeTransfer redeems a transfer VAA, or escrows it if its wrapped asset is not registered yet.
+func (k Keeper) executeTransfer(ctx sdk.Context, logger log.Logger, msg *types.MsgExecuteVAA, v *vaa.VAA, wormholeConfig whtypes.Config, payload []byte) error {
+	err := k.redeemTransfer(ctx, logger, msg.Creator, msg.PostReceipt, v, wormholeConfig)
+	if errors.Is(err, types.ErrAssetNotRegistered) {
+		err = k.escrowTransfer(ctx, logger, v)
+	}
+	return err
+}
 
-		identifier := ""
-		var wrapped bool
-		if types.IsWORMToken(tokenChain, tokenAddress) {
-			identifier = "uworm"
-			// We mint wormhole tokens because they are not native to wormhole chain
-			wrapped = true
-		} else if uint32(tokenChain) != wormholeConfig.ChainId {
-			// Mint new wrapped assets if the coin is from another chain
-			identifier = "b" + types.GetWrappedCoinIdentifier(tokenChain, tokenAddress)
-			wrapped = true
-		} else {
-			// Recover the coin denom from the token address if it's a native coin
-			identifier = strings.TrimLeft(string(tokenAddress[:]), "\x00")
-			wrapped = false
-		}
+// executeAssetMeta registers or updates the wrapped asset attested by an asset meta VAA.
+func (k Keeper) executeAssetMeta(ctx sdk.Context, logger log.Logger, msg *types.MsgExecuteVAA, v *vaa.VAA, wormholeConfig whtypes.Config, payload []byte) error {
+	if len(payload) != 99 {
+		return types.ErrVAAPayloadInvalid
+	}
+	var tokenAddress [32]byte
+	copy(tokenAddress[:], payload[:32])
+	tokenChain := binary.BigEndian.Uint16(payload[32:34])
+	decimals := payload[34]
+	symbol := string(payload[35:67])
+	symbol = strings.Trim(symbol, "\x00")
+	name := string(payload[67:99])
+	name = strings.Trim(name, "\x00")
+
+	// Don't allow native assets to be registered as wrapped asset
+	if uint32(tokenChain) == wormholeConfig.ChainId {
+		return types.ErrNativeAssetRegistration
+	}
 
-		meta, found := k.bankKeeper.GetDenomMetaData(ctx, identifier)
-		if !found {
-			if !wrapped {
-				return nil, types.ErrNoDenomMetadata
-			} else {
-				return nil, types.ErrAssetNotRegistered
-			}
-		}
+	if types.IsWORMToken(tokenChain, tokenAddress) {
+		return types.ErrNativeAssetRegistration
+	}
 
-		amt := sdk.NewCoin(identifier, sdk.NewIntFromBigInt(unnormalizedAmount))
-		if err := amt.Validate(); err != nil {
-			return nil, fmt.Errorf("%w: %s", types.ErrInvalidAmount, err)
-		}
-		amount, err := types.Untruncate(amt, meta)
-		if err != nil {
-			return nil, fmt.Errorf("failed to untruncate amount: %w", err)
-		}
+	if _, found := k.GetChainRegistration(ctx, uint32(tokenChain)); !found {
+		return types.ErrUnregisteredEmitter
+	}
 
-		f := sdk.NewCoin(identifier, sdk.NewIntFromBigInt(unnormalizedFee))
-		if err := f.Validate(); err != nil {
-			return nil, fmt.Errorf("%w: %s", types.ErrInvalidFee, err)
-		}
-		fee, err := types.Untruncate(f, meta)
-		if err != nil {
-			return nil, fmt.Errorf("failed to untruncate fee: %w", err)
-		}
+	identifier := types.GetWrappedCoinIdentifier(tokenChain, tokenAddress)
+	baseDenom := "b" + identifier
+	rollBackProtection, found := k.GetCoinMetaRollbackProtection(ctx, identifier)
+	if found && rollBackProtection.LastUpdateSequence >= v.Sequence {
+		return types.ErrAssetMetaRollback
+	}
 
-		if amount.IsLT(fee) {
-			return nil, types.ErrFeeTooHigh
+	meta, registered := k.bankKeeper.GetDenomMetaData(ctx, baseDenom)
+	if registered {
+		if meta.Display != identifier {
+			return fmt.Errorf("mis-matched display denom; %s != %s", meta.Display, identifier)
 		}
 
-		if wrapped {
-			if err := k.bankKeeper.MintCoins(ctx, types.ModuleName, sdk.Coins{amount}); err != nil {
-				return nil, fmt.Errorf("failed to mint coins (%s): %w", amount, err)
+		for _, d := range meta.DenomUnits {
+			if d.Denom == identifier && d.Exponent != uint32(decimals) {
+				return types.ErrChangeDecimals
 			}
 		}
+	}
+
+	logger.Debug("updating wrapped asset metadata",
+		"denom", baseDenom,
+		"symbol", symbol,
+		"name", name,
+		"decimals", decimals)
+
+	k.bankKeeper.SetDenomMetaData(ctx, btypes.Metadata{
+		Description: fmt.Sprintf("Portal wrapped asset from chain %d with address %x", tokenChain, tokenAddress),
+		DenomUnits: []*btypes.DenomUnit{
+			{
+				Denom:    baseDenom,
+				Exponent: 0,
+			},
+			{
+				Denom:    identifier,
+				Exponent: uint32(decimals),
+			},
+		},
+		Base:    baseDenom,
+		Display: identifier,
+		Name:    name,
+		Symbol:  symbol,
+	})
+	k.SetCoinMetaRollbackProtection(ctx, types.CoinMetaRollbackProtection{
+		Index:              identifier,
+		LastUpdateSequence: v.Sequence,
+	})
+	if !registered {
+		k.recordRegistrationBounty(ctx, baseDenom, msg.Creator)
+	}
 
-		moduleAccount := k.accountKeeper.GetModuleAddress(types.ModuleName)
+	return ctx.EventManager().EmitTypedEvent(&types.EventAssetRegistrationUpdate{
+		TokenChain:   uint32(tokenChain),
+		TokenAddress: tokenAddress[:],
+		Name:         name,
+		Symbol:       symbol,
+		Decimals:     uint32(decimals),
+	})
+}
 
-		amtLessFees := amount.Sub(fee)
+// transfer is the payload of a transfer VAA (payload ID 1), together with the fields that identify the VAA.
+type transfer struct {
+	digest         string
+	emitterChain   vaa.ChainID
+	emitterAddress vaa.Address
+	sequence       uint64
+	// amount and fee are in the 8 decimals of the payload.
+	amount       *big.Int
+	tokenAddress [32]byte
+	tokenChain   uint16
+	to           [20]byte
+	toChain      uint16
+	fee          *big.Int
+}
 
-		if err := k.bankKeeper.SendCoins(ctx, moduleAccount, to[:], sdk.Coins{amtLessFees}); err != nil {
-			return nil, err
-		}
+// parseTransfer parses the transfer payload of v.
+func parseTransfer(v *vaa.VAA) (transfer, error) {
+	payload := v.Payload[1:]
+	if len(payload) != 132 {
+		return transfer{}, types.ErrVAAPayloadInvalid
+	}
+	t := transfer{
+		digest:         v.HexDigest(),
+		emitterChain:   v.EmitterChain,
+		emitterAddress: v.EmitterAddress,
+		sequence:       v.Sequence,
+		amount:         new(big.Int).SetBytes(payload[:32]),
+		tokenChain:     binary.BigEndian.Uint16(payload[64:66]),
+		toChain:        binary.BigEndian.Uint16(payload[98:100]),
+		fee:            new(big.Int).SetBytes(payload[100:132]),
+	}
+	copy(t.tokenAddress[:], payload[32:64])
+	copy(t.to[:], payload[78:98])
+	return t, nil
+}
 
-		txSender, err := sdk.AccAddressFromBech32(msg.Creator)
+// redeemTransfer pays out the transfer VAA v to its recipient, and its fee to creator. A delivery receipt paid for by
+// creator is posted if postReceipt is set. The VAA has to be verified, and replay protection is up to the caller.
+func (k Keeper) redeemTransfer(ctx sdk.Context, logger log.Logger, creator string, postReceipt bool, v *vaa.VAA, wormholeConfig whtypes.Config) error {
+	t, err := parseTransfer(v)
+	if err != nil {
+		return err
+	}
+	if err := k.payOutTransfer(ctx, logger, creator, t, wormholeConfig); err != nil {
+		return err
+	}
+
+	if postReceipt {
+		payer, err := sdk.AccAddressFromBech32(creator)
 		if err != nil {
-			return nil, err
+			return err
 		}
-		// Transfer fee to tx sender if it is not 0
-		if fee.IsPositive() {
-			if err := k.bankKeeper.SendCoins(ctx, moduleAccount, txSender, sdk.Coins{fee}); err != nil {
-				return nil, fmt.Errorf("failed to send fees (%s) to tx sender: %w", fee, err)
-			}
+		if err := k.postDeliveryReceipt(ctx, v, DeliveryStatusRedeemed, payer); err != nil {
+			return fmt.Errorf("failed to post delivery receipt: %w", err)
 		}
+	}
+	return nil
+}
 
-		err = ctx.EventManager().EmitTypedEvent(&types.EventTransferReceived{
-			TokenChain:   uint32(tokenChain),
-			TokenAddress: tokenAddress[:],
-			To:           sdk.AccAddress(to[:]).String(),
-			FeeRecipient: txSender.String(),
-			Amount:       amount.Amount.String(),
-			Fee:          fee.Amount.String(),
-			LocalDenom:   identifier,
-		})
-		if err != nil {
-			return nil, err
-		}
+// payOutTransfer pays out t to its recipient, and its fee to creator.
+func (k Keeper) payOutTransfer(ctx sdk.Context, logger log.Logger, creator string, t transfer, wormholeConfig whtypes.Config) error {
+	// Check that the transfer is to this chain
+	if uint32(t.toChain) != wormholeConfig.ChainId {
+		return types.ErrInvalidTargetChain
+	}
 
-	case PayloadIDAssetMeta:
-		if len(payload) != 99 {
-			return nil, types.ErrVAAPayloadInvalid
-		}
-		var tokenAddress [32]byte
-		copy(tokenAddress[:], payload[:32])
-		tokenChain := binary.BigEndian.Uint16(payload[32:34])
-		decimals := payload[34]
-		symbol := string(payload[35:67])
-		symbol = strings.Trim(symbol, "\x00")
-		name := string(payload[67:99])
-		name = strings.Trim(name, "\x00")
-
-		// Don't allow native assets to be registered as wrapped asset
-		if uint32(tokenChain) == wormholeConfig.ChainId {
-			return nil, types.ErrNativeAssetRegistration
-		}
+	// Recipients do not need an account, the bank keeper creates one without a public key on the first payout. Module
+	// accounts are blocked from receiving funds, as the bank keeper does not check that itself.
+	if k.bankKeeper.BlockedAddr(t.to[:]) {
+		return fmt.Errorf("%w: %s", types.ErrBlockedRecipient, sdk.AccAddress(t.to[:]))
+	}
 
-		if types.IsWORMToken(tokenChain, tokenAddress) {
-			return nil, types.ErrNativeAssetRegistration
-		}
+	identifier, wrapped, meta, err := k.redeemedDenom(ctx, logger, wormholeConfig, t.tokenChain, t.tokenAddress)
+	if err != nil {
+		return err
+	}
+	amount, err := redeemedAmount(identifier, t.amount, meta)
+	if err != nil {
+		return err
+	}
 
-		if _, found := k.GetChainRegistration(ctx, uint32(tokenChain)); !found {
-			return nil, types.ErrUnregisteredEmitter
-		}
+	f := sdk.NewCoin(identifier, sdk.NewIntFromBigInt(t.fee))
+	if err := f.Validate(); err != nil {
+		return fmt.Errorf("%w: %s", types.ErrInvalidFee, err)
+	}
+	fee, err := types.Untruncate(f, meta)
+	if err != nil {
+		return fmt.Errorf("failed to untruncate fee: %w", err)
+	}
 
-		identifier := types.GetWrappedCoinIdentifier(tokenChain, tokenAddress)
-		baseDenom := "b" + identifier
-		rollBackProtection, found := k.GetCoinMetaRollbackProtection(ctx, identifier)
-		if found && rollBackProtection.LastUpdateSequence >= v.Sequence {
-			return nil, types.ErrAssetMetaRollback
-		}
+	if amount.IsLT(fee) {
+		return types.ErrFeeTooHigh
+	}
 
-		if meta, found := k.bankKeeper.GetDenomMetaData(ctx, baseDenom); found {
-			if meta.Display != identifier {
-				return nil, fmt.Errorf("mis-matched display denom; %s != %s", meta.Display, identifier)
-			}
+	logger.Debug("redeeming transfer",
+		"to", sdk.AccAddress(t.to[:]).String(),
+		"amount", amount.String(),
+		"fee", fee.String(),
+		"fee_recipient", creator)
 
-			for _, d := range meta.DenomUnits {
-				if d.Denom == identifier && d.Exponent != uint32(decimals) {
-					return nil, types.ErrChangeDecimals
-				}
-			}
+	// The fee goes to the tx sender. Only require one if there is a fee to pay out.
+	var txSender sdk.AccAddress
+	if fee.IsPositive() {
+		txSender, err = sdk.AccAddressFromBech32(creator)
+		if err != nil {
+			return fmt.Errorf("%w: %s", types.ErrNoFeeRecipient, err)
 		}
+	}
 
-		k.bankKeeper.SetDenomMetaData(ctx, btypes.Metadata{
-			Description: fmt.Sprintf("Portal wrapped asset from chain %d with address %x", tokenChain, tokenAddress),
-			DenomUnits: []*btypes.DenomUnit{
-				{
-					Denom:    baseDenom,
-					Exponent: 0,
-				},
-				{
-					Denom:    identifier,
-					Exponent: uint32(decimals),
-				},
-			},
-			Base:    baseDenom,
-			Display: identifier,
-			Name:    name,
-			Symbol:  symbol,
-		})
-		k.SetCoinMetaRollbackProtection(ctx, types.CoinMetaRollbackProtection{
-			Index:              identifier,
-			LastUpdateSequence: v.Sequence,
-		})
-
-		err = ctx.EventManager().EmitTypedEvent(&types.EventAssetRegistrationUpdate{
-			TokenChain:   uint32(tokenChain),
-			TokenAddress: tokenAddress[:],
-			Name:         name,
-			Symbol:       symbol,
-			Decimals:     uint32(decimals),
-		})
+	// The digest of the VAA identifies the redemption in all of its events and journal entries.
+	correlationID := t.digest
+	if err := k.releaseRedeemedCoin(ctx, correlationID, amount, wrapped); err != nil {
+		return err
+	}
+
+	// Pay out the recipient and then the fee in a single bank operation, so the bank transfer events of the
+	// redemption always come in this order. Zero-coin outputs are skipped, e.g. when the fee takes up the entire
+	// amount.
+	var outputs []btypes.Output
+	var payoutKinds []string
+	if amtLessFees := amount.Sub(fee); amtLessFees.IsPositive() {
+		outputs = append(outputs, btypes.NewOutput(t.to[:], sdk.Coins{amtLessFees}))
+		payoutKinds = append(payoutKinds, types.PayoutKindPrincipal)
+	}
+	if fee.IsPositive() {
+		outputs = append(outputs, btypes.NewOutput(txSender, sdk.Coins{fee}))
+		payoutKinds = append(payoutKinds, types.PayoutKindFee)
+	}
+	moduleAccount := k.accountKeeper.GetModuleAddress(types.ModuleName)
+	inputs := []btypes.Input{btypes.NewInput(moduleAccount, sdk.Coins{amount})}
+	if err := k.bankKeeper.InputOutputCoins(ctx, inputs, outputs); err != nil {
+		return fmt.Errorf("failed to pay out %s: %w", amount, err)
+	}
+	for i, output := range outputs {
+		k.appendLedgerEntry(ctx, correlationID, payoutKinds[i], output.Address, moduleAccount.String(), sdk.NewCoin(identifier, output.Coins.AmountOf(identifier)))
+	}
+
+	err = ctx.EventManager().EmitTypedEvent(&types.EventTransferReceived{
+		TokenChain:    uint32(t.tokenChain),
+		TokenAddress:  t.tokenAddress[:],
+		To:            sdk.AccAddress(t.to[:]).String(),
+		FeeRecipient:  creator,
+		Amount:        amount.Amount.String(),
+		Fee:           fee.Amount.String(),
+		LocalDenom:    identifier,
+		CorrelationId: correlationID,
+		Payouts:       uint32(len(outputs)),
+	})
+	if err != nil {
+		return err
+	}
+	for i, output := range outputs {
+		ctx.EventManager().EmitEvent(sdk.NewEvent(
+			types.EventTypeRedemptionPayout,
+			sdk.NewAttribute(types.AttributeKeyCorrelationID, correlationID),
+			sdk.NewAttribute(types.AttributeKeyPayoutIndex, fmt.Sprint(i)),
+			sdk.NewAttribute(types.AttributeKeyPayoutKind, payoutKinds[i]),
+			sdk.NewAttribute(types.AttributeKeyRecipient, output.Address),
+			sdk.NewAttribute(types.AttributeKeyDenom, identifier),
+			sdk.NewAttribute(types.AttributeKeyAmount, output.Coins.AmountOf(identifier).String()),
+		))
+	}
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeTransferRedeemed,
+		sdk.NewAttribute(types.AttributeKeyVAADigest, t.digest),
+		sdk.NewAttribute(types.AttributeKeyCorrelationID, correlationID),
+		sdk.NewAttribute(types.AttributeKeyPayouts, fmt.Sprint(len(outputs))),
+		sdk.NewAttribute(types.AttributeKeyEmitterChain, fmt.Sprint(uint16(t.emitterChain))),
+		sdk.NewAttribute(types.AttributeKeyEmitterAddress, t.emitterAddress.String()),
+		sdk.NewAttribute(types.AttributeKeySequence, fmt.Sprint(t.sequence)),
+		sdk.NewAttribute(types.AttributeKeyRecipient, sdk.AccAddress(t.to[:]).String()),
+		sdk.NewAttribute(types.AttributeKeyDenom, identifier),
+		sdk.NewAttribute(types.AttributeKeyAmount, amount.Amount.String()),
+		sdk.NewAttribute(types.AttributeKeyFee, fee.Amount.String()),
+	))
+	k.recordRelayerRedemption(ctx, creator, fee)
+
+	return nil
+}
+
+// redeemedDenom returns the local denom of a token redeemed from a transfer, whether it is a wrapped asset minted by
+// the module, and its metadata.
+func (k Keeper) redeemedDenom(ctx sdk.Context, logger log.Logger, wormholeConfig whtypes.Config, tokenChain uint16, tokenAddress [32]byte) (identifier string, wrapped bool, meta btypes.Metadata, err error) {
+	if types.IsWORMToken(tokenChain, tokenAddress) {
+		identifier = "uworm"
+		// We mint wormhole tokens because they are not native to wormhole chain
+		wrapped = true
+	} else if uint32(tokenChain) != wormholeConfig.ChainId {
+		// Mint new wrapped assets if the coin is from another chain
+		identifier = "b" + types.GetWrappedCoinIdentifier(tokenChain, tokenAddress)
+		wrapped = true
+	} else {
+		// Recover the coin denom from the token address if it's a native coin
+		identifier, err = types.DenomFromTokenAddress(tokenAddress)
 		if err != nil {
-			return nil, err
+			return "", false, meta, err
 		}
-	default:
-		return nil, types.ErrUnknownPayloadType
+		wrapped = false
 	}
 
-	// Prevent replay
-	k.SetReplayProtection(ctx, types.ReplayProtection{Index: v.HexDigest()})
+	logger.Debug("resolved transfer asset",
+		"token_chain", tokenChain,
+		"token_address", fmt.Sprintf("%x", tokenAddress),
+		"denom", identifier,
+		"wrapped", wrapped)
 
-	return &types.MsgExecuteVAAResponse{}, nil
+	meta, found := k.bankKeeper.GetDenomMetaData(ctx, identifier)
+	if !found {
+		if !wrapped {
+			return "", false, meta, types.ErrNoDenomMetadata
+		} else {
+			return "", false, meta, types.ErrAssetNotRegistered
+		}
+	}
+	return identifier, wrapped, meta, nil
+}
+
+// redeemedAmount returns the amount of a transfer in the base denom of the token.
+func redeemedAmount(identifier string, unnormalizedAmount *big.Int, meta btypes.Metadata) (sdk.Coin, error) {
+	amt := sdk.NewCoin(identifier, sdk.NewIntFromBigInt(unnormalizedAmount))
+	if err := amt.Validate(); err != nil {
+		return sdk.Coin{}, fmt.Errorf("%w: %s", types.ErrInvalidAmount, err)
+	}
+	// Reject instead of minting/sending nothing and consuming the VAA
+	if amt.IsZero() {
+		return sdk.Coin{}, types.ErrZeroAmount
+	}
+	amount, err := types.Untruncate(amt, meta)
+	if err != nil {
+		return sdk.Coin{}, fmt.Errorf("failed to untruncate amount: %w", err)
+	}
+	return amount, nil
+}
+
+// releaseRedeemedCoin puts amount into the module account, minting wrapped assets and unlocking native ones, and
+// records it as the first journal entry of the redemption. The first redemption of a wrapped asset pays the bounty
+// for registering it.
+func (k Keeper) releaseRedeemedCoin(ctx sdk.Context, redemption string, amount sdk.Coin, wrapped bool) error {
+	moduleAddress := k.accountKeeper.GetModuleAddress(types.ModuleName)
+	if wrapped {
+		if err := k.bankKeeper.MintCoins(ctx, types.ModuleName, sdk.Coins{amount}); err != nil {
+			return fmt.Errorf("failed to mint coins (%s): %w", amount, err)
+		}
+		k.appendLedgerEntry(ctx, redemption, types.LedgerKindMint, moduleAddress.String(), types.LedgerAccountSupply, amount)
+		if err := k.payRegistrationBounty(ctx, amount.Denom); err != nil {
+			return fmt.Errorf("failed to pay registration bounty: %w", err)
+		}
+	} else {
+		if err := k.unlockNativeCoin(ctx, amount); err != nil {
+			return err
+		}
+		k.appendLedgerEntry(ctx, redemption, types.LedgerKindUnlock, moduleAddress.String(), types.LedgerAccountCustody, amount)
+	}
+	recordRedemption(ctx, amount, wrapped)
+	return nil
 }
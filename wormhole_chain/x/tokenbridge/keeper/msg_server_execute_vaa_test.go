@@ -0,0 +1,486 @@
+package keeper_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"math/big"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	btypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/keeper"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+	whtypes "github.com/wormhole-foundation/wormhole-chain/x/wormhole/types"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+var (
+	testEmitter      = [32]byte{0x01}
+	testTokenAddress = [32]byte{0x02}
+)
+
+func createTransferPayload(amount, fee *big.Int, tokenChain uint16, tokenAddress [32]byte, to sdk.AccAddress, toChain uint16) []byte {
+	payload := make([]byte, 133)
+	payload[0] = byte(keeper.PayloadIDTransfer)
+	amount.FillBytes(payload[1:33])
+	copy(payload[33:65], tokenAddress[:])
+	binary.BigEndian.PutUint16(payload[65:67], tokenChain)
+	copy(payload[79:99], to)
+	binary.BigEndian.PutUint16(payload[99:101], toChain)
+	fee.FillBytes(payload[101:133])
+	return payload
+}
+
+func createTransferVAA(t testing.TB, payload []byte) []byte {
+	v := &vaa.VAA{
+		Version:          vaa.SupportedVAAVersion,
+		Timestamp:        time.Unix(0, 0),
+		EmitterChain:     vaa.ChainIDEthereum,
+		EmitterAddress:   testEmitter,
+		Sequence:         1,
+		ConsistencyLevel: 1,
+		Payload:          payload,
+	}
+	bz, err := v.Marshal()
+	require.NoError(t, err)
+	return bz
+}
+
+// registerWrappedAsset registers the emitter for chainID and metadata for a wrapped asset with 8 decimals.
+func registerWrappedAsset(k *keeper.Keeper, ctx sdk.Context, bank types.BankKeeper, chainID vaa.ChainID, tokenAddress [32]byte) string {
+	k.SetChainRegistration(ctx, types.ChainRegistration{ChainID: uint32(chainID), EmitterAddress: testEmitter[:]})
+
+	identifier := types.GetWrappedCoinIdentifier(uint16(chainID), tokenAddress)
+	bank.SetDenomMetaData(ctx, btypes.Metadata{
+		DenomUnits: []*btypes.DenomUnit{
+			{Denom: "b" + identifier, Exponent: 0},
+			{Denom: identifier, Exponent: 8},
+		},
+		Base:    "b" + identifier,
+		Display: identifier,
+	})
+	return "b" + identifier
+}
+
+func TestExecuteVAATransferFees(t *testing.T) {
+	to := sdk.AccAddress(bytes.Repeat([]byte{0xaa}, 20))
+	relayer := sdk.AccAddress(bytes.Repeat([]byte{0xbb}, 20))
+	maxUint256 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+
+	tests := []struct {
+		label           string
+		amount          *big.Int
+		fee             *big.Int
+		creator         string
+		err             error
+		expectedTo      *big.Int
+		expectedRelayer *big.Int
+		expectedSends   int
+	}{
+		{label: "zero fee", amount: big.NewInt(100), fee: big.NewInt(0), creator: relayer.String(), expectedTo: big.NewInt(100), expectedRelayer: big.NewInt(0), expectedSends: 1},
+		{label: "zero fee without tx sender", amount: big.NewInt(100), fee: big.NewInt(0), creator: "", expectedTo: big.NewInt(100), expectedRelayer: big.NewInt(0), expectedSends: 1},
+		{label: "fee", amount: big.NewInt(100), fee: big.NewInt(30), creator: relayer.String(), expectedTo: big.NewInt(70), expectedRelayer: big.NewInt(30), expectedSends: 1},
+		{label: "fee equals amount", amount: big.NewInt(100), fee: big.NewInt(100), creator: relayer.String(), expectedTo: big.NewInt(0), expectedRelayer: big.NewInt(100), expectedSends: 1},
+		{label: "max fee", amount: maxUint256, fee: maxUint256, creator: relayer.String(), expectedTo: big.NewInt(0), expectedRelayer: maxUint256, expectedSends: 1},
+		{label: "fee above amount", amount: big.NewInt(100), fee: big.NewInt(101), creator: relayer.String(), err: types.ErrFeeTooHigh},
+		{label: "fee without tx sender", amount: big.NewInt(100), fee: big.NewInt(1), creator: "", err: types.ErrNoFeeRecipient},
+		{label: "zero amount", amount: big.NewInt(0), fee: big.NewInt(0), creator: relayer.String(), err: types.ErrZeroAmount},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.label, func(t *testing.T) {
+			msgServer, k, ctx, mocks := setupMockedMsgServer(t)
+			denom := registerWrappedAsset(k, ctx, mocks.bank, vaa.ChainIDEthereum, testTokenAddress)
+
+			payload := createTransferPayload(tc.amount, tc.fee, uint16(vaa.ChainIDEthereum), testTokenAddress, to, uint16(vaa.ChainIDWormchain))
+			vaaBz := createTransferVAA(t, payload)
+			_, err := msgServer.ExecuteVAA(sdk.WrapSDKContext(ctx), &types.MsgExecuteVAA{
+				Creator: tc.creator,
+				Vaa:     vaaBz,
+			})
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				assert.Zero(t, mocks.bank.sends)
+
+				// A rejected VAA can still be executed later
+				v, err := vaa.Unmarshal(vaaBz)
+				require.NoError(t, err)
+				_, found := k.GetReplayProtection(ctx, v.HexDigest())
+				assert.False(t, found)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expectedTo.String(), mocks.bank.GetBalance(ctx, to, denom).Amount.String())
+			assert.Equal(t, tc.expectedRelayer.String(), mocks.bank.GetBalance(ctx, relayer, denom).Amount.String())
+			assert.Equal(t, tc.expectedSends, mocks.bank.sends)
+		})
+	}
+}
+
+func TestExecuteVAAInvalidNativeDenom(t *testing.T) {
+	msgServer, k, ctx, mocks := setupMockedMsgServer(t)
+	registerWrappedAsset(k, ctx, mocks.bank, vaa.ChainIDEthereum, testTokenAddress)
+
+	// A native denom followed by a coin list separator must not be accepted
+	tokenAddress, err := types.PadStringToByte32("uatom,1uworm")
+	require.NoError(t, err)
+
+	to := sdk.AccAddress(bytes.Repeat([]byte{0xaa}, 20))
+	payload := createTransferPayload(big.NewInt(100), big.NewInt(0), uint16(vaa.ChainIDWormchain), tokenAddress, to, uint16(vaa.ChainIDWormchain))
+	_, err = msgServer.ExecuteVAA(sdk.WrapSDKContext(ctx), &types.MsgExecuteVAA{
+		Vaa: createTransferVAA(t, payload),
+	})
+	assert.ErrorIs(t, err, types.ErrInvalidNativeDenom)
+}
+
+func TestExecuteVAAMinGuardianSetIndex(t *testing.T) {
+	msgServer, k, ctx, mocks := setupMockedMsgServer(t)
+	registerWrappedAsset(k, ctx, mocks.bank, vaa.ChainIDEthereum, testTokenAddress)
+	// The test VAAs are signed by guardian set 0
+	mocks.wormhole.config.SetMinGuardianSetIndex(whtypes.MessageTypeAssetMeta, 1)
+
+	_, err := msgServer.ExecuteVAA(sdk.WrapSDKContext(ctx), &types.MsgExecuteVAA{
+		Vaa: createAssetMetaVAA(t, [32]byte{1}, 1),
+	})
+	assert.ErrorIs(t, err, whtypes.ErrGuardianSetTooOld)
+
+	// Other message types still accept any guardian set
+	to := sdk.AccAddress(bytes.Repeat([]byte{0xaa}, 20))
+	payload := createTransferPayload(big.NewInt(100), big.NewInt(0), uint16(vaa.ChainIDEthereum), testTokenAddress, to, uint16(vaa.ChainIDWormchain))
+	_, err = msgServer.ExecuteVAA(sdk.WrapSDKContext(ctx), &types.MsgExecuteVAA{
+		Vaa: createTransferVAA(t, payload),
+	})
+	assert.NoError(t, err)
+}
+
+func TestExecuteVAAEmitsVAAConsumed(t *testing.T) {
+	msgServer, k, ctx, mocks := setupMockedMsgServer(t)
+	registerWrappedAsset(k, ctx, mocks.bank, vaa.ChainIDEthereum, testTokenAddress)
+
+	to := sdk.AccAddress(bytes.Repeat([]byte{0xaa}, 20))
+	payload := createTransferPayload(big.NewInt(100), big.NewInt(0), uint16(vaa.ChainIDEthereum), testTokenAddress, to, uint16(vaa.ChainIDWormchain))
+	vaaBz := createTransferVAA(t, payload)
+	msg := &types.MsgExecuteVAA{Vaa: vaaBz}
+	_, err := msgServer.ExecuteVAA(sdk.WrapSDKContext(ctx), msg)
+	require.NoError(t, err)
+
+	v, err := vaa.Unmarshal(vaaBz)
+	require.NoError(t, err)
+
+	var consumed []*whtypes.EventVAAConsumed
+	for _, event := range ctx.EventManager().Events() {
+		abciEvent := abci.Event(event)
+		// Untyped events do not parse
+		parsed, err := sdk.ParseTypedEvent(abciEvent)
+		if err != nil {
+			continue
+		}
+		if e, ok := parsed.(*whtypes.EventVAAConsumed); ok {
+			consumed = append(consumed, e)
+		}
+	}
+	require.Len(t, consumed, 1)
+	assert.Equal(t, v.HexDigest(), consumed[0].Digest)
+	assert.Equal(t, uint32(keeper.PayloadIDTransfer), consumed[0].PayloadType)
+	assert.Equal(t, sdk.MsgTypeURL(msg), consumed[0].MsgType)
+}
+
+func TestExecuteVAAPayloadHandler(t *testing.T) {
+	msgServer, k, ctx, _ := setupMockedMsgServer(t)
+	k.SetChainRegistration(ctx, types.ChainRegistration{ChainID: uint32(vaa.ChainIDEthereum), EmitterAddress: testEmitter[:]})
+
+	const payloadID keeper.PayloadID = 0x10
+	vaaBz := createTransferVAA(t, []byte{byte(payloadID), 0xaa, 0xbb})
+	_, err := msgServer.ExecuteVAA(sdk.WrapSDKContext(ctx), &types.MsgExecuteVAA{Vaa: vaaBz})
+	require.ErrorIs(t, err, types.ErrUnknownPayloadType)
+
+	var executed [][]byte
+	k.RegisterPayloadHandler(payloadID, func(k keeper.Keeper, ctx sdk.Context, logger log.Logger, msg *types.MsgExecuteVAA, v *vaa.VAA, wormholeConfig whtypes.Config, payload []byte) error {
+		executed = append(executed, payload)
+		return nil
+	})
+	assert.Panics(t, func() { k.RegisterPayloadHandler(payloadID, nil) })
+	assert.Panics(t, func() { k.RegisterPayloadHandler(keeper.PayloadIDTransfer, nil) })
+
+	// Handlers registered after the msg server was created are used too
+	_, err = msgServer.ExecuteVAA(sdk.WrapSDKContext(ctx), &types.MsgExecuteVAA{Vaa: vaaBz})
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{{0xaa, 0xbb}}, executed)
+
+	// Replay protection is up to ExecuteVAA
+	_, err = msgServer.ExecuteVAA(sdk.WrapSDKContext(ctx), &types.MsgExecuteVAA{Vaa: vaaBz})
+	require.ErrorIs(t, err, types.ErrVAAAlreadyExecuted)
+	assert.Len(t, executed, 1)
+}
+
+func TestExecuteVAALogs(t *testing.T) {
+	msgServer, k, ctx, mocks := setupMockedMsgServer(t)
+	registerWrappedAsset(k, ctx, mocks.bank, vaa.ChainIDEthereum, testTokenAddress)
+	logs := &bytes.Buffer{}
+	ctx = ctx.WithLogger(log.NewTMLogger(logs))
+
+	to := sdk.AccAddress(bytes.Repeat([]byte{0xaa}, 20))
+	payload := createTransferPayload(big.NewInt(100), big.NewInt(101), uint16(vaa.ChainIDEthereum), testTokenAddress, to, uint16(vaa.ChainIDWormchain))
+	vaaBz := createTransferVAA(t, payload)
+	_, err := msgServer.ExecuteVAA(sdk.WrapSDKContext(ctx), &types.MsgExecuteVAA{Vaa: vaaBz})
+	require.ErrorIs(t, err, types.ErrFeeTooHigh)
+
+	v, err := vaa.Unmarshal(vaaBz)
+	require.NoError(t, err)
+	assert.Contains(t, logs.String(), "failed to execute VAA")
+	assert.Contains(t, logs.String(), v.HexDigest())
+
+	// Restricting the module to errors drops the log
+	require.Error(t, k.SetLogLevel("verbose"))
+	require.NoError(t, k.SetLogLevel("error"))
+	msgServer = keeper.NewMsgServerImpl(*k)
+	logs.Reset()
+	_, err = msgServer.ExecuteVAA(sdk.WrapSDKContext(ctx), &types.MsgExecuteVAA{Vaa: vaaBz})
+	require.ErrorIs(t, err, types.ErrFeeTooHigh)
+	assert.Empty(t, logs.String())
+}
+
+func TestExecuteVAAEmitsTransferRedeemed(t *testing.T) {
+	msgServer, k, ctx, mocks := setupMockedMsgServer(t)
+	denom := registerWrappedAsset(k, ctx, mocks.bank, vaa.ChainIDEthereum, testTokenAddress)
+
+	to := sdk.AccAddress(bytes.Repeat([]byte{0xaa}, 20))
+	relayer := sdk.AccAddress(bytes.Repeat([]byte{0xbb}, 20))
+	payload := createTransferPayload(big.NewInt(100), big.NewInt(30), uint16(vaa.ChainIDEthereum), testTokenAddress, to, uint16(vaa.ChainIDWormchain))
+	vaaBz := createTransferVAA(t, payload)
+	_, err := msgServer.ExecuteVAA(sdk.WrapSDKContext(ctx), &types.MsgExecuteVAA{Creator: relayer.String(), Vaa: vaaBz})
+	require.NoError(t, err)
+
+	v, err := vaa.Unmarshal(vaaBz)
+	require.NoError(t, err)
+
+	var redeemed []sdk.Event
+	for _, event := range ctx.EventManager().Events() {
+		if event.Type == types.EventTypeTransferRedeemed {
+			redeemed = append(redeemed, event)
+		}
+	}
+	require.Len(t, redeemed, 1)
+
+	attributes := map[string]string{}
+	for _, attribute := range redeemed[0].Attributes {
+		attributes[string(attribute.Key)] = string(attribute.Value)
+	}
+	assert.Equal(t, map[string]string{
+		types.AttributeKeyVAADigest:      v.HexDigest(),
+		types.AttributeKeyEmitterChain:   "2",
+		types.AttributeKeyEmitterAddress: hex.EncodeToString(testEmitter[:]),
+		types.AttributeKeySequence:       "1",
+		types.AttributeKeyRecipient:      to.String(),
+		types.AttributeKeyDenom:          denom,
+		types.AttributeKeyAmount:         "100",
+		types.AttributeKeyFee:            "30",
+		types.AttributeKeyCorrelationID:  v.HexDigest(),
+		types.AttributeKeyPayouts:        "2",
+	}, attributes)
+}
+
+func TestExecuteVAAEmitsOrderedPayouts(t *testing.T) {
+	to := sdk.AccAddress(bytes.Repeat([]byte{0xaa}, 20))
+	relayer := sdk.AccAddress(bytes.Repeat([]byte{0xbb}, 20))
+
+	for _, tc := range []struct {
+		name    string
+		fee     int64
+		payouts []map[string]string
+	}{
+		{
+			name: "principal and fee",
+			fee:  30,
+			payouts: []map[string]string{
+				{types.AttributeKeyPayoutIndex: "0", types.AttributeKeyPayoutKind: types.PayoutKindPrincipal, types.AttributeKeyRecipient: to.String(), types.AttributeKeyAmount: "70"},
+				{types.AttributeKeyPayoutIndex: "1", types.AttributeKeyPayoutKind: types.PayoutKindFee, types.AttributeKeyRecipient: relayer.String(), types.AttributeKeyAmount: "30"},
+			},
+		},
+		{
+			name: "no fee",
+			fee:  0,
+			payouts: []map[string]string{
+				{types.AttributeKeyPayoutIndex: "0", types.AttributeKeyPayoutKind: types.PayoutKindPrincipal, types.AttributeKeyRecipient: to.String(), types.AttributeKeyAmount: "100"},
+			},
+		},
+		{
+			name: "fee takes up the amount",
+			fee:  100,
+			payouts: []map[string]string{
+				{types.AttributeKeyPayoutIndex: "0", types.AttributeKeyPayoutKind: types.PayoutKindFee, types.AttributeKeyRecipient: relayer.String(), types.AttributeKeyAmount: "100"},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			msgServer, k, ctx, mocks := setupMockedMsgServer(t)
+			denom := registerWrappedAsset(k, ctx, mocks.bank, vaa.ChainIDEthereum, testTokenAddress)
+			payload := createTransferPayload(big.NewInt(100), big.NewInt(tc.fee), uint16(vaa.ChainIDEthereum), testTokenAddress, to, uint16(vaa.ChainIDWormchain))
+			vaaBz := createTransferVAA(t, payload)
+			_, err := msgServer.ExecuteVAA(sdk.WrapSDKContext(ctx), &types.MsgExecuteVAA{Creator: relayer.String(), Vaa: vaaBz})
+			require.NoError(t, err)
+			v, err := vaa.Unmarshal(vaaBz)
+			require.NoError(t, err)
+
+			var payouts []map[string]string
+			var received []*types.EventTransferReceived
+			for _, event := range ctx.EventManager().Events() {
+				if event.Type == types.EventTypeRedemptionPayout {
+					attributes := map[string]string{}
+					for _, attribute := range event.Attributes {
+						attributes[string(attribute.Key)] = string(attribute.Value)
+					}
+					assert.Equal(t, v.HexDigest(), attributes[types.AttributeKeyCorrelationID])
+					assert.Equal(t, denom, attributes[types.AttributeKeyDenom])
+					delete(attributes, types.AttributeKeyCorrelationID)
+					delete(attributes, types.AttributeKeyDenom)
+					payouts = append(payouts, attributes)
+					continue
+				}
+				parsed, err := sdk.ParseTypedEvent(abci.Event(event))
+				if err != nil {
+					continue
+				}
+				if e, ok := parsed.(*types.EventTransferReceived); ok {
+					received = append(received, e)
+				}
+			}
+			assert.Equal(t, tc.payouts, payouts)
+			require.Len(t, received, 1)
+			assert.Equal(t, v.HexDigest(), received[0].CorrelationId)
+			assert.Equal(t, uint32(len(tc.payouts)), received[0].Payouts)
+		})
+	}
+}
+
+func TestExecuteVAARecordsLedgerEntries(t *testing.T) {
+	to := sdk.AccAddress(bytes.Repeat([]byte{0xaa}, 20))
+	relayer := sdk.AccAddress(bytes.Repeat([]byte{0xbb}, 20))
+	moduleAddress := mockAccountKeeper{}.GetModuleAddress(types.ModuleName).String()
+
+	msgServer, k, ctx, mocks := setupMockedMsgServer(t)
+	denom := registerWrappedAsset(k, ctx, mocks.bank, vaa.ChainIDEthereum, testTokenAddress)
+	ctx = ctx.WithBlockHeight(7)
+	payload := createTransferPayload(big.NewInt(100), big.NewInt(30), uint16(vaa.ChainIDEthereum), testTokenAddress, to, uint16(vaa.ChainIDWormchain))
+	vaaBz := createTransferVAA(t, payload)
+	_, err := msgServer.ExecuteVAA(sdk.WrapSDKContext(ctx), &types.MsgExecuteVAA{Creator: relayer.String(), Vaa: vaaBz})
+	require.NoError(t, err)
+	v, err := vaa.Unmarshal(vaaBz)
+	require.NoError(t, err)
+
+	entry := func(id uint64, kind, debit, credit string, amount int64) types.LedgerEntry {
+		return types.LedgerEntry{Id: id, Redemption: v.HexDigest(), Height: 7, Kind: kind, Debit: debit, Credit: credit, Amount: sdk.NewInt64Coin(denom, amount)}
+	}
+	assert.Equal(t, []types.LedgerEntry{
+		entry(1, types.LedgerKindMint, moduleAddress, types.LedgerAccountSupply, 100),
+		entry(2, types.PayoutKindPrincipal, to.String(), moduleAddress, 70),
+		entry(3, types.PayoutKindFee, relayer.String(), moduleAddress, 30),
+	}, k.GetLedgerRedemption(ctx, v.HexDigest()))
+	assert.Equal(t, uint64(3), k.GetLedgerEntryCount(ctx))
+	assert.Empty(t, k.GetLedgerRedemption(ctx, "00"))
+
+	// The module account only passes the coins on, so its debits and credits cancel out.
+	module, found := k.GetLedgerBalance(ctx, moduleAddress, denom)
+	require.True(t, found)
+	assert.Equal(t, sdk.NewInt(100), module.Debits)
+	assert.Equal(t, sdk.NewInt(100), module.Credits)
+	supply, found := k.GetLedgerBalance(ctx, types.LedgerAccountSupply, denom)
+	require.True(t, found)
+	assert.True(t, supply.Debits.IsZero())
+	assert.Equal(t, sdk.NewInt(100), supply.Credits)
+	recipient, found := k.GetLedgerBalance(ctx, to.String(), denom)
+	require.True(t, found)
+	assert.Equal(t, sdk.NewInt(70), recipient.Debits)
+}
+
+func TestExecuteVAAPostsDeliveryReceipt(t *testing.T) {
+	to := sdk.AccAddress(bytes.Repeat([]byte{0xaa}, 20))
+	relayer := sdk.AccAddress(bytes.Repeat([]byte{0xbb}, 20))
+	payload := createTransferPayload(big.NewInt(100), big.NewInt(30), uint16(vaa.ChainIDEthereum), testTokenAddress, to, uint16(vaa.ChainIDWormchain))
+	vaaBz := createTransferVAA(t, payload)
+	v, err := vaa.Unmarshal(vaaBz)
+	require.NoError(t, err)
+
+	for _, postReceipt := range []bool{false, true} {
+		msgServer, k, ctx, mocks := setupMockedMsgServer(t)
+		registerWrappedAsset(k, ctx, mocks.bank, vaa.ChainIDEthereum, testTokenAddress)
+		ctx = ctx.WithBlockHeight(42)
+
+		_, err := msgServer.ExecuteVAA(sdk.WrapSDKContext(ctx), &types.MsgExecuteVAA{Creator: relayer.String(), Vaa: vaaBz, PostReceipt: postReceipt})
+		require.NoError(t, err)
+		if !postReceipt {
+			assert.Empty(t, mocks.wormhole.messages)
+			continue
+		}
+
+		require.Len(t, mocks.wormhole.messages, 1)
+		receipt, err := keeper.ParseDeliveryReceipt(mocks.wormhole.messages[0])
+		require.NoError(t, err)
+		assert.Equal(t, &keeper.DeliveryReceipt{
+			Digest:         v.SigningMsg(),
+			EmitterChain:   vaa.ChainIDEthereum,
+			EmitterAddress: testEmitter,
+			Sequence:       1,
+			Status:         keeper.DeliveryStatusRedeemed,
+			BlockHeight:    42,
+		}, receipt)
+	}
+}
+
+func TestParseDeliveryReceipt(t *testing.T) {
+	receipt := keeper.DeliveryReceipt{
+		Digest:         [32]byte{0x01},
+		EmitterChain:   vaa.ChainIDSolana,
+		EmitterAddress: vaa.Address{31: 0x02},
+		Sequence:       7,
+		Status:         keeper.DeliveryStatusRedeemed,
+		BlockHeight:    1000,
+	}
+	payload := receipt.Serialize()
+	require.Len(t, payload, 84)
+
+	parsed, err := keeper.ParseDeliveryReceipt(payload)
+	require.NoError(t, err)
+	assert.Equal(t, receipt, *parsed)
+
+	_, err = keeper.ParseDeliveryReceipt(payload[:83])
+	assert.ErrorIs(t, err, types.ErrVAAPayloadInvalid)
+	payload[0] = byte(keeper.PayloadIDTransfer)
+	_, err = keeper.ParseDeliveryReceipt(payload)
+	assert.ErrorIs(t, err, types.ErrVAAPayloadInvalid)
+}
+
+func BenchmarkExecuteVAATransfer(b *testing.B) {
+	to := sdk.AccAddress(bytes.Repeat([]byte{0xaa}, 20))
+	relayer := sdk.AccAddress(bytes.Repeat([]byte{0xbb}, 20))
+
+	msgServer, k, ctx, mocks := setupMockedMsgServer(b)
+	registerWrappedAsset(k, ctx, mocks.bank, vaa.ChainIDEthereum, testTokenAddress)
+
+	// Every redemption needs a distinct VAA to pass replay protection
+	payload := createTransferPayload(big.NewInt(100), big.NewInt(30), uint16(vaa.ChainIDEthereum), testTokenAddress, to, uint16(vaa.ChainIDWormchain))
+	msgs := make([]*types.MsgExecuteVAA, b.N)
+	for i := range msgs {
+		v, err := vaa.Unmarshal(createTransferVAA(b, payload))
+		require.NoError(b, err)
+		v.Sequence = uint64(i)
+		bz, err := v.Marshal()
+		require.NoError(b, err)
+		msgs[i] = &types.MsgExecuteVAA{Creator: relayer.String(), Vaa: bz}
+	}
+
+	goCtx := sdk.WrapSDKContext(ctx)
+	b.ResetTimer()
+	for _, msg := range msgs {
+		if _, err := msgServer.ExecuteVAA(goCtx, msg); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
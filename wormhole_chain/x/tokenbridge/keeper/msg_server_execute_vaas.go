@@ -0,0 +1,38 @@
+package keeper
+
+import (
+	"context"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+)
+
+// ExecuteVAAs executes each VAA of the batch like ExecuteVAA, in its own cached context. A VAA that fails is reverted
+// without its events and reported in the response, and does not fail the others.
+func (k msgServer) ExecuteVAAs(goCtx context.Context, msg *types.MsgExecuteVAAs) (*types.MsgExecuteVAAsResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	res := &types.MsgExecuteVAAsResponse{Results: make([]types.ExecuteVAAResult, len(msg.Vaas))}
+	var failed int
+	for i, bz := range msg.Vaas {
+		res.Results[i].Index = uint32(i)
+
+		cacheCtx, write := ctx.CacheContext()
+		cacheCtx = cacheCtx.WithEventManager(sdk.NewEventManager())
+		_, err := k.ExecuteVAA(sdk.WrapSDKContext(cacheCtx), &types.MsgExecuteVAA{
+			Creator:     msg.Creator,
+			Vaa:         bz,
+			PostReceipt: msg.PostReceipt,
+		})
+		if err != nil {
+			res.Results[i].Error = err.Error()
+			failed++
+			continue
+		}
+		write()
+		ctx.EventManager().EmitEvents(cacheCtx.EventManager().Events())
+	}
+	k.Logger(ctx).Info("executed VAA batch", "vaas", len(msg.Vaas), "failed", failed)
+
+	return res, nil
+}
@@ -0,0 +1,46 @@
+package keeper_test
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+func TestExecuteVAAs(t *testing.T) {
+	msgServer, k, ctx, mocks := setupMockedMsgServer(t)
+	denom := registerWrappedAsset(k, ctx, mocks.bank, vaa.ChainIDEthereum, testTokenAddress)
+
+	to := sdk.AccAddress(bytes.Repeat([]byte{0xaa}, 20))
+	transfer := createTransferVAA(t, createTransferPayload(big.NewInt(100), big.NewInt(0), uint16(vaa.ChainIDEthereum), testTokenAddress, to, uint16(vaa.ChainIDWormchain)))
+	otherChain := createTransferVAA(t, createTransferPayload(big.NewInt(100), big.NewInt(0), uint16(vaa.ChainIDEthereum), testTokenAddress, to, uint16(vaa.ChainIDSolana)))
+
+	res, err := msgServer.ExecuteVAAs(sdk.WrapSDKContext(ctx), &types.MsgExecuteVAAs{
+		Vaas: [][]byte{transfer, {0x01}, otherChain, transfer},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, res.Results, 4)
+	for i, result := range res.Results {
+		assert.Equal(t, uint32(i), result.Index)
+	}
+	assert.Empty(t, res.Results[0].Error)
+	assert.NotEmpty(t, res.Results[1].Error)
+	assert.Contains(t, res.Results[2].Error, types.ErrInvalidTargetChain.Error())
+	assert.Contains(t, res.Results[3].Error, types.ErrVAAAlreadyExecuted.Error())
+
+	// Only the first VAA paid out
+	assert.Equal(t, int64(100), mocks.bank.GetBalance(ctx, to, denom).Amount.Int64())
+	var redeemed int
+	for _, event := range ctx.EventManager().Events() {
+		if event.Type == types.EventTypeTransferRedeemed {
+			redeemed++
+		}
+	}
+	assert.Equal(t, 1, redeemed)
+}
@@ -0,0 +1,51 @@
+package keeper
+
+import (
+	"context"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+)
+
+func (k msgServer) RegisterRelayer(goCtx context.Context, msg *types.MsgRegisterRelayer) (*types.MsgRegisterRelayerResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	relayer, found := k.GetRelayer(ctx, msg.Creator)
+	if !found {
+		relayer = types.Relayer{
+			Address:          msg.Creator,
+			RegisteredHeight: ctx.BlockHeight(),
+		}
+	}
+	relayer.Endpoint = msg.Endpoint
+	relayer.Description = msg.Description
+	k.SetRelayer(ctx, relayer)
+
+	err := ctx.EventManager().EmitTypedEvent(&types.EventRelayerRegistered{
+		Address:  relayer.Address,
+		Endpoint: relayer.Endpoint,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.MsgRegisterRelayerResponse{}, nil
+}
+
+func (k msgServer) DeregisterRelayer(goCtx context.Context, msg *types.MsgDeregisterRelayer) (*types.MsgDeregisterRelayerResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	if _, found := k.GetRelayer(ctx, msg.Creator); !found {
+		return nil, types.ErrUnknownRelayer
+	}
+	k.RemoveRelayer(ctx, msg.Creator)
+
+	err := ctx.EventManager().EmitTypedEvent(&types.EventRelayerDeregistered{
+		Address: msg.Creator,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.MsgDeregisterRelayerResponse{}, nil
+}
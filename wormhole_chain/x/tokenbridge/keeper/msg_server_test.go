@@ -1,16 +1,276 @@
 package keeper_test
 
 import (
+	"bytes"
 	"context"
+	"encoding/binary"
+	"sort"
 	"testing"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	btypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	capabilitytypes "github.com/cosmos/cosmos-sdk/x/capability/types"
+	upgradetypes "github.com/cosmos/cosmos-sdk/x/upgrade/types"
+	"github.com/stretchr/testify/require"
 	keepertest "github.com/wormhole-foundation/wormhole-chain/testutil/keeper"
 	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/keeper"
 	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+	whtypes "github.com/wormhole-foundation/wormhole-chain/x/wormhole/types"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
 )
 
 func setupMsgServer(t testing.TB) (types.MsgServer, context.Context) {
 	k, ctx := keepertest.TokenbridgeKeeper(t)
 	return keeper.NewMsgServerImpl(*k), sdk.WrapSDKContext(ctx)
 }
+
+type mockAccountKeeper struct{}
+
+func (mockAccountKeeper) GetModuleAddress(moduleName string) sdk.AccAddress {
+	return authtypes.NewModuleAddress(moduleName)
+}
+
+// mockBankKeeper keeps balances and denom metadata in memory and counts the send operations it performs.
+type mockBankKeeper struct {
+	balances map[string]sdk.Coins
+	metadata map[string]btypes.Metadata
+	blocked  map[string]bool
+	sends    int
+}
+
+func newMockBankKeeper() *mockBankKeeper {
+	return &mockBankKeeper{
+		balances: map[string]sdk.Coins{},
+		metadata: map[string]btypes.Metadata{},
+	}
+}
+
+func (b *mockBankKeeper) MintCoins(ctx sdk.Context, moduleName string, amt sdk.Coins) error {
+	if !amt.IsValid() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, amt.String())
+	}
+	addr := authtypes.NewModuleAddress(moduleName).String()
+	b.balances[addr] = b.balances[addr].Add(amt...)
+	return nil
+}
+
+func (b *mockBankKeeper) BurnCoins(ctx sdk.Context, moduleName string, amt sdk.Coins) error {
+	if !amt.IsValid() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, amt.String())
+	}
+	addr := authtypes.NewModuleAddress(moduleName).String()
+	balance, negative := b.balances[addr].SafeSub(amt)
+	if negative {
+		return sdkerrors.ErrInsufficientFunds
+	}
+	b.balances[addr] = balance
+	return nil
+}
+
+func (b *mockBankKeeper) SendCoins(ctx sdk.Context, fromAddr sdk.AccAddress, toAddr sdk.AccAddress, amt sdk.Coins) error {
+	// Like the real bank keeper, reject empty and zero-amount sends
+	if !amt.IsValid() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, amt.String())
+	}
+	balance, negative := b.balances[fromAddr.String()].SafeSub(amt)
+	if negative {
+		return sdkerrors.ErrInsufficientFunds
+	}
+	b.balances[fromAddr.String()] = balance
+	b.balances[toAddr.String()] = b.balances[toAddr.String()].Add(amt...)
+	b.sends++
+	return nil
+}
+
+func (b *mockBankKeeper) InputOutputCoins(ctx sdk.Context, inputs []btypes.Input, outputs []btypes.Output) error {
+	if err := btypes.ValidateInputsOutputs(inputs, outputs); err != nil {
+		return err
+	}
+	for _, in := range inputs {
+		balance, negative := b.balances[in.Address].SafeSub(in.Coins)
+		if negative {
+			return sdkerrors.ErrInsufficientFunds
+		}
+		b.balances[in.Address] = balance
+	}
+	for _, out := range outputs {
+		b.balances[out.Address] = b.balances[out.Address].Add(out.Coins...)
+	}
+	b.sends++
+	return nil
+}
+
+func (b *mockBankKeeper) SetDenomMetaData(ctx sdk.Context, denomMetaData btypes.Metadata) {
+	b.metadata[denomMetaData.Base] = denomMetaData
+}
+
+func (b *mockBankKeeper) GetDenomMetaData(ctx sdk.Context, denom string) (btypes.Metadata, bool) {
+	meta, found := b.metadata[denom]
+	return meta, found
+}
+
+func (b *mockBankKeeper) GetBalance(ctx sdk.Context, addr sdk.AccAddress, denom string) sdk.Coin {
+	return sdk.NewCoin(denom, b.balances[addr.String()].AmountOf(denom))
+}
+
+func (b *mockBankKeeper) GetAllBalances(ctx sdk.Context, addr sdk.AccAddress) sdk.Coins {
+	return b.balances[addr.String()]
+}
+
+func (b *mockBankKeeper) IterateAllBalances(ctx sdk.Context, cb func(address sdk.AccAddress, coin sdk.Coin) (stop bool)) {
+	addresses := make([]string, 0, len(b.balances))
+	for address := range b.balances {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+	for _, address := range addresses {
+		for _, coin := range b.balances[address] {
+			if cb(sdk.MustAccAddressFromBech32(address), coin) {
+				return
+			}
+		}
+	}
+}
+
+func (b *mockBankKeeper) IterateTotalSupply(ctx sdk.Context, cb func(sdk.Coin) bool) {
+	var supply sdk.Coins
+	for _, balance := range b.balances {
+		supply = supply.Add(balance...)
+	}
+	for _, coin := range supply {
+		if cb(coin) {
+			return
+		}
+	}
+}
+
+func (b *mockBankKeeper) IterateAllDenomMetaData(ctx sdk.Context, cb func(btypes.Metadata) bool) {
+	denoms := make([]string, 0, len(b.metadata))
+	for denom := range b.metadata {
+		denoms = append(denoms, denom)
+	}
+	sort.Strings(denoms)
+	for _, denom := range denoms {
+		if cb(b.metadata[denom]) {
+			return
+		}
+	}
+}
+
+func (b *mockBankKeeper) BlockedAddr(addr sdk.AccAddress) bool {
+	return b.blocked[addr.String()]
+}
+
+// mockWormholeKeeper accepts every VAA, issues emitter capabilities and records posted messages. Posting fails with
+// postMessageErr if it is set.
+type mockWormholeKeeper struct {
+	config         whtypes.Config
+	capabilities   map[string]*capabilitytypes.Capability
+	messages       [][]byte
+	postMessageErr error
+}
+
+func (w *mockWormholeKeeper) BindEmitter(ctx sdk.Context, emitter whtypes.EmitterAddress) (*capabilitytypes.Capability, error) {
+	name := whtypes.EmitterCapabilityName(emitter)
+	if _, found := w.capabilities[name]; found {
+		return nil, whtypes.ErrEmitterAlreadyBound
+	}
+	capability := capabilitytypes.NewCapability(uint64(len(w.capabilities)))
+	w.capabilities[name] = capability
+	return capability, nil
+}
+
+func (w *mockWormholeKeeper) VerifyVAA(ctx sdk.Context, v *vaa.VAA) error {
+	return nil
+}
+
+// VerifyGovernanceVAA only checks the governance module; the emitter and the target chain are not verified.
+func (w *mockWormholeKeeper) VerifyGovernanceVAA(ctx sdk.Context, v *vaa.VAA, module [32]byte, msgType string) (action byte, targetChain uint16, payload []byte, err error) {
+	if len(v.Payload) < 35 {
+		return 0, 0, nil, whtypes.ErrGovernanceHeaderTooShort
+	}
+	if !bytes.Equal(v.Payload[:32], module[:]) {
+		return 0, 0, nil, whtypes.ErrUnknownGovernanceModule
+	}
+	return v.Payload[32], binary.BigEndian.Uint16(v.Payload[33:35]), v.Payload[35:], nil
+}
+
+func (w *mockWormholeKeeper) GetConfig(ctx sdk.Context) (whtypes.Config, bool) {
+	return w.config, true
+}
+
+func (w *mockWormholeKeeper) PostMessage(ctx sdk.Context, capability *capabilitytypes.Capability, emitter whtypes.EmitterAddress, payer sdk.AccAddress, nonce uint32, data []byte) error {
+	if capability == nil || w.capabilities[whtypes.EmitterCapabilityName(emitter)] != capability {
+		return whtypes.ErrInvalidEmitterCapability
+	}
+	if w.postMessageErr != nil {
+		return w.postMessageErr
+	}
+	w.messages = append(w.messages, data)
+	return nil
+}
+
+// GetSequenceCounter returns the number of posted messages as the sequence of every emitter.
+func (w *mockWormholeKeeper) GetSequenceCounter(ctx sdk.Context, index string) (whtypes.SequenceCounter, bool) {
+	return whtypes.SequenceCounter{Index: index, Sequence: uint64(len(w.messages))}, len(w.messages) != 0
+}
+
+func (w *mockWormholeKeeper) CountVAAExecutions(ctx sdk.Context, sender sdk.AccAddress, n uint32) error {
+	return nil
+}
+
+// mockUpgradeKeeper records scheduled upgrade plans.
+type mockUpgradeKeeper struct {
+	plans []upgradetypes.Plan
+}
+
+func (u *mockUpgradeKeeper) ScheduleUpgrade(ctx sdk.Context, plan upgradetypes.Plan) error {
+	if err := plan.ValidateBasic(); err != nil {
+		return err
+	}
+	u.plans = append(u.plans, plan)
+	return nil
+}
+
+// mockScopedKeeper records the capabilities claimed by the module.
+type mockScopedKeeper struct {
+	capabilities map[string]*capabilitytypes.Capability
+}
+
+func (s *mockScopedKeeper) GetCapability(ctx sdk.Context, name string) (*capabilitytypes.Capability, bool) {
+	capability, found := s.capabilities[name]
+	return capability, found
+}
+
+func (s *mockScopedKeeper) ClaimCapability(ctx sdk.Context, cap *capabilitytypes.Capability, name string) error {
+	if _, found := s.capabilities[name]; found {
+		return capabilitytypes.ErrOwnerClaimed
+	}
+	s.capabilities[name] = cap
+	return nil
+}
+
+type mockKeepers struct {
+	bank     *mockBankKeeper
+	wormhole *mockWormholeKeeper
+	upgrade  *mockUpgradeKeeper
+	scoped   *mockScopedKeeper
+}
+
+// setupMockedMsgServer creates a msg server whose external keeper dependencies are mocked.
+func setupMockedMsgServer(t testing.TB) (types.MsgServer, *keeper.Keeper, sdk.Context, mockKeepers) {
+	mocks := mockKeepers{
+		bank: newMockBankKeeper(),
+		wormhole: &mockWormholeKeeper{
+			config:       whtypes.Config{ChainId: uint32(vaa.ChainIDWormchain)},
+			capabilities: map[string]*capabilitytypes.Capability{},
+		},
+		upgrade: &mockUpgradeKeeper{},
+		scoped:  &mockScopedKeeper{capabilities: map[string]*capabilitytypes.Capability{}},
+	}
+	k, ctx := keepertest.TokenbridgeKeeperWithDeps(t, mockAccountKeeper{}, mocks.bank, mocks.wormhole, mocks.upgrade, mocks.scoped)
+	require.NoError(t, k.BindEmitter(ctx))
+	return keeper.NewMsgServerImpl(*k), k, ctx, mocks
+}
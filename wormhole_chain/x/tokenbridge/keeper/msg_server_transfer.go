@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/binary"
+	"encoding/hex"
 	"fmt"
 	"math/big"
 
@@ -67,48 +68,126 @@ func (k msgServer) Transfer(goCtx context.Context, msg *types.MsgTransfer) (*typ
 	}
 
 	_, _, wrapped := types.GetWrappedCoinMeta(msg.Amount.Denom)
-	if wrapped {
+	logger := k.Logger(ctx).With(
+		"sender", msg.Creator,
+		"denom", msg.Amount.Denom,
+		"amount", amount.Amount.String(),
+		"fee", fees.Amount.String(),
+		"to_chain", msg.ToChain,
+		"wrapped", wrapped,
+	)
+	if !wrapped {
+		if err := k.recommendReattestation(ctx, meta); err != nil {
+			return nil, err
+		}
+	}
+
+	// Collect coins in the module account, wrapped ones included, so that sendTransfer only burns what the sender paid.
+	if err := k.bankKeeper.SendCoins(ctx, userAcc, moduleAddress, sdk.Coins{amount}); err != nil {
+		return nil, sdkerrors.Wrap(err, "failed to send coins to module account")
+	}
+
+	if config, found := k.GetConfig(ctx); found && config.TransferCancelWindow > 0 {
+		// Hold the coins in the module account until the transfer is released or cancelled.
+		pending := types.PendingTransfer{
+			Id:            k.GetPendingTransferCount(ctx) + 1,
+			Sender:        msg.Creator,
+			Amount:        amount,
+			Fee:           fees.Amount,
+			ToChain:       msg.ToChain,
+			ToAddress:     msg.ToAddress,
+			ReleaseHeight: ctx.BlockHeight() + int64(config.TransferCancelWindow),
+		}
+		k.SetPendingTransfer(ctx, pending)
+		k.SetPendingTransferCount(ctx, pending.Id)
+		logger.Info("holding transfer until cancel window closes", "id", pending.Id, "release_height", pending.ReleaseHeight)
+
+		err = ctx.EventManager().EmitTypedEvent(&types.EventTransferPending{
+			PendingTransferID: pending.Id,
+			Sender:            pending.Sender,
+			Amount:            amount.Amount.String(),
+			Denom:             amount.Denom,
+			ReleaseHeight:     pending.ReleaseHeight,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &types.MsgTransferResponse{PendingTransferID: pending.Id}, nil
+	}
+
+	err = k.sendTransfer(ctx, wormholeConfig, userAcc, amount, fees.Amount, uint16(msg.ToChain), msg.ToAddress)
+	if err != nil {
+		logger.Info("failed to post transfer message", "error", err)
+		return nil, err
+	}
+	logger.Info("posted transfer message")
+
+	return &types.MsgTransferResponse{}, nil
+}
+
+// sendTransfer burns or locks amount, which has been collected in the module account, posts a transfer of it and
+// emits EventTransferSent. The message fee is paid by payer.
+func (k Keeper) sendTransfer(ctx sdk.Context, wormholeConfig whtypes.Config, payer sdk.AccAddress, amount sdk.Coin, fee sdk.Int, toChain uint16, toAddress []byte) error {
+	if _, _, wrapped := types.GetWrappedCoinMeta(amount.Denom); wrapped {
 		// We previously minted these coins so just burn them now.
 		if err := k.bankKeeper.BurnCoins(ctx, types.ModuleName, sdk.Coins{amount}); err != nil {
-			return nil, sdkerrors.Wrap(err, "failed to burn wrapped coins")
+			return sdkerrors.Wrap(err, "failed to burn wrapped coins")
 		}
 	} else {
-		// Collect coins in the module account.
-		if err := k.bankKeeper.SendCoins(ctx, userAcc, moduleAddress, sdk.Coins{amount}); err != nil {
-			return nil, sdkerrors.Wrap(err, "failed to send coins to module account")
-		}
+		k.lockNativeCoin(ctx, amount)
+	}
+	sequence, err := k.postTransferMessage(ctx, wormholeConfig, payer, amount, fee, toChain, toAddress)
+	if err != nil {
+		return err
 	}
 
+	return ctx.EventManager().EmitTypedEvent(&types.EventTransferSent{
+		Sender:    payer.String(),
+		Amount:    amount.Amount.String(),
+		Denom:     amount.Denom,
+		Fee:       fee.String(),
+		ToChain:   uint32(toChain),
+		ToAddress: toAddress,
+		Sequence:  sequence,
+	})
+}
+
+// postTransferMessage posts a transfer payload of amount to toAddress on toChain with the token bridge emitter and
+// returns the sequence of the message. amount and fee are in the 8 decimals of the payload. The message fee is paid by
+// payer.
+func (k Keeper) postTransferMessage(ctx sdk.Context, wormholeConfig whtypes.Config, payer sdk.AccAddress, amount sdk.Coin, fee sdk.Int, toChain uint16, toAddress []byte) (uint64, error) {
 	buf := new(bytes.Buffer)
 	// PayloadID
-	buf.WriteByte(1)
+	buf.WriteByte(byte(PayloadIDTransfer))
 	// Amount
 	tokenAmountBytes32 := bytes32(amount.Amount.BigInt())
 	buf.Write(tokenAmountBytes32[:])
-	tokenChain, tokenAddress, err := types.GetTokenMeta(wormholeConfig, msg.Amount.Denom)
+	tokenChain, tokenAddress, err := types.GetTokenMeta(wormholeConfig, amount.Denom)
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
 	// TokenAddress
 	buf.Write(tokenAddress[:])
 	// TokenChain
 	MustWrite(buf, binary.BigEndian, tokenChain)
 	// To
-	buf.Write(msg.ToAddress)
+	buf.Write(toAddress)
 	// ToChain
-	MustWrite(buf, binary.BigEndian, uint16(msg.ToChain))
+	MustWrite(buf, binary.BigEndian, toChain)
 	// Fee
-	feeBytes32 := bytes32(fees.Amount.BigInt())
+	feeBytes32 := bytes32(fee.BigInt())
 	buf.Write(feeBytes32[:])
 
-	// Post message
-	emitterAddress := whtypes.EmitterAddressFromAccAddress(moduleAddress)
-	err = k.wormholeKeeper.PostMessage(ctx, emitterAddress, 0, buf.Bytes())
+	emitterAddress, emitterCap, err := k.emitterCapability(ctx)
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
-
-	return &types.MsgTransferResponse{}, nil
+	// The message takes the next sequence of the emitter, which starts at 0.
+	sequence, _ := k.wormholeKeeper.GetSequenceCounter(ctx, hex.EncodeToString(emitterAddress.Bytes()))
+	if err := k.wormholeKeeper.PostMessage(ctx, emitterCap, emitterAddress, payer, 0, buf.Bytes()); err != nil {
+		return 0, err
+	}
+	return sequence.Sequence, nil
 }
 
 func bytes32(i *big.Int) [32]byte {
@@ -0,0 +1,35 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+	whtypes "github.com/wormhole-foundation/wormhole-chain/x/wormhole/types"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// PayloadHandler executes the payload of a token bridge VAA, without its payload ID. It is called with the keeper the
+// VAA is executed by, once the VAA has passed the precheck, its signatures and the guardian set of its message type
+// have been verified. Replay protection and the EventVAAConsumed event are left to ExecuteVAA, and an error reverts
+// the execution.
+type PayloadHandler func(k Keeper, ctx sdk.Context, logger log.Logger, msg *types.MsgExecuteVAA, v *vaa.VAA, wormholeConfig whtypes.Config, payload []byte) error
+
+// defaultPayloadHandlers are the payload types the token bridge executes itself.
+func defaultPayloadHandlers() map[PayloadID]PayloadHandler {
+	return map[PayloadID]PayloadHandler{
+		PayloadIDTransfer:            Keeper.executeTransfer,
+		PayloadIDAssetMeta:           Keeper.executeAssetMeta,
+		PayloadIDTransferWithPayload: Keeper.executeTransferWithPayload,
+	}
+}
+
+// RegisterPayloadHandler lets other modules execute token bridge VAAs of a new payload type. It must be called while
+// the app is set up, and panics if the payload type already has a handler.
+func (k *Keeper) RegisterPayloadHandler(id PayloadID, handler PayloadHandler) {
+	if _, exists := k.payloadHandlers[id]; exists {
+		panic(fmt.Sprintf("payload handler for payload ID %d already registered", id))
+	}
+	k.payloadHandlers[id] = handler
+}
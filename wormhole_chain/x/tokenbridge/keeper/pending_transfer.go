@@ -0,0 +1,204 @@
+package keeper
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+	whtypes "github.com/wormhole-foundation/wormhole-chain/x/wormhole/types"
+)
+
+// GetPendingTransferCount get the total number of pendingTransfer, which is the ID of the last one
+func (k Keeper) GetPendingTransferCount(ctx sdk.Context) uint64 {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), []byte{})
+	byteKey := types.KeyPrefix(types.PendingTransferCountKey)
+	bz := store.Get(byteKey)
+
+	// Count doesn't exist
+	if bz == nil {
+		return 0
+	}
+
+	return binary.BigEndian.Uint64(bz)
+}
+
+// SetPendingTransferCount set the total number of pendingTransfer
+func (k Keeper) SetPendingTransferCount(ctx sdk.Context, count uint64) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), []byte{})
+	byteKey := types.KeyPrefix(types.PendingTransferCountKey)
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, count)
+	store.Set(byteKey, bz)
+}
+
+// SetPendingTransfer set a specific pendingTransfer in the store
+func (k Keeper) SetPendingTransfer(ctx sdk.Context, pendingTransfer types.PendingTransfer) {
+	if old, found := k.GetPendingTransfer(ctx, pendingTransfer.Id); found {
+		k.addPendingTransferAmount(ctx, old.Amount.Denom, old.Amount.Amount.Neg())
+	}
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.PendingTransferKeyPrefix))
+	b := k.cdc.MustMarshal(&pendingTransfer)
+	store.Set(types.PendingTransferKey(pendingTransfer.Id), b)
+	k.addPendingTransferAmount(ctx, pendingTransfer.Amount.Denom, pendingTransfer.Amount.Amount)
+}
+
+// GetPendingTransfer returns a pendingTransfer from its id
+func (k Keeper) GetPendingTransfer(ctx sdk.Context, id uint64) (val types.PendingTransfer, found bool) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.PendingTransferKeyPrefix))
+	b := store.Get(types.PendingTransferKey(id))
+	if b == nil {
+		return val, false
+	}
+	k.cdc.MustUnmarshal(b, &val)
+	return val, true
+}
+
+// RemovePendingTransfer removes a pendingTransfer from the store
+func (k Keeper) RemovePendingTransfer(ctx sdk.Context, id uint64) {
+	pending, found := k.GetPendingTransfer(ctx, id)
+	if !found {
+		return
+	}
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.PendingTransferKeyPrefix))
+	store.Delete(types.PendingTransferKey(id))
+	k.addPendingTransferAmount(ctx, pending.Amount.Denom, pending.Amount.Amount.Neg())
+}
+
+// GetAllPendingTransfer returns all pendingTransfer
+func (k Keeper) GetAllPendingTransfer(ctx sdk.Context) (list []types.PendingTransfer) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.PendingTransferKeyPrefix))
+	iterator := sdk.KVStorePrefixIterator(store, []byte{})
+
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var val types.PendingTransfer
+		k.cdc.MustUnmarshal(iterator.Value(), &val)
+		list = append(list, val)
+	}
+
+	return
+}
+
+// GetPendingTransferAmount returns the amount of denom held in the module account for pending transfers. The total is
+// kept up to date by SetPendingTransfer and RemovePendingTransfer.
+func (k Keeper) GetPendingTransferAmount(ctx sdk.Context, denom string) sdk.Int {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.PendingTransferAmountKeyPrefix))
+	b := store.Get([]byte(denom))
+	if b == nil {
+		return sdk.ZeroInt()
+	}
+	var amount sdk.Int
+	if err := amount.Unmarshal(b); err != nil {
+		panic(err)
+	}
+	return amount
+}
+
+// addPendingTransferAmount adds delta to the total amount of denom in pending transfers.
+func (k Keeper) addPendingTransferAmount(ctx sdk.Context, denom string, delta sdk.Int) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.PendingTransferAmountKeyPrefix))
+	amount := k.GetPendingTransferAmount(ctx, denom).Add(delta)
+	if amount.IsZero() {
+		store.Delete([]byte(denom))
+		return
+	}
+	b, err := amount.Marshal()
+	if err != nil {
+		panic(err)
+	}
+	store.Set([]byte(denom), b)
+}
+
+// resetPendingTransferAmounts recomputes the per-denom totals of the pending transfers from the transfers themselves.
+func (k Keeper) resetPendingTransferAmounts(ctx sdk.Context) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.PendingTransferAmountKeyPrefix))
+	iterator := sdk.KVStorePrefixIterator(store, []byte{})
+	var denoms [][]byte
+	for ; iterator.Valid(); iterator.Next() {
+		denoms = append(denoms, iterator.Key())
+	}
+	iterator.Close()
+	for _, denom := range denoms {
+		store.Delete(denom)
+	}
+
+	for _, pending := range k.GetAllPendingTransfer(ctx) {
+		k.addPendingTransferAmount(ctx, pending.Amount.Denom, pending.Amount.Amount)
+	}
+}
+
+// ReleasePendingTransfers posts the pending transfers whose cancel window has closed. Transfers are released in the
+// order they were made, so a transfer made after the cancel window was shortened waits for the earlier ones. A
+// transfer that cannot be posted, e.g. because its sender cannot pay the message fee anymore, is returned to its
+// sender with an EventTransferCancelled event.
+func (k Keeper) ReleasePendingTransfers(ctx sdk.Context) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.PendingTransferKeyPrefix))
+	iterator := sdk.KVStorePrefixIterator(store, []byte{})
+
+	var due []types.PendingTransfer
+	for ; iterator.Valid(); iterator.Next() {
+		var val types.PendingTransfer
+		k.cdc.MustUnmarshal(iterator.Value(), &val)
+		if val.ReleaseHeight > ctx.BlockHeight() {
+			break
+		}
+		due = append(due, val)
+	}
+	iterator.Close()
+
+	for _, pending := range due {
+		k.RemovePendingTransfer(ctx, pending.Id)
+
+		cacheCtx, write := ctx.CacheContext()
+		err := k.releasePendingTransfer(cacheCtx, pending)
+		if err == nil {
+			write()
+			ctx.EventManager().EmitEvents(cacheCtx.EventManager().Events())
+			continue
+		}
+
+		k.Logger(ctx).Error("failed to release pending transfer, returning it to the sender",
+			"id", pending.Id,
+			"sender", pending.Sender,
+			"amount", pending.Amount.String(),
+			"error", err)
+		if err := k.refundPendingTransfer(ctx, pending, err.Error()); err != nil {
+			k.Logger(ctx).Error("failed to return pending transfer to the sender", "id", pending.Id, "error", err)
+		}
+	}
+}
+
+func (k Keeper) releasePendingTransfer(ctx sdk.Context, pending types.PendingTransfer) error {
+	wormholeConfig, ok := k.wormholeKeeper.GetConfig(ctx)
+	if !ok {
+		return whtypes.ErrNoConfig
+	}
+	sender, err := sdk.AccAddressFromBech32(pending.Sender)
+	if err != nil {
+		return err
+	}
+	return k.sendTransfer(ctx, wormholeConfig, sender, pending.Amount, pending.Fee, uint16(pending.ToChain), pending.ToAddress)
+}
+
+// refundPendingTransfer returns the coins of a pending transfer to its sender. releaseErr is the reason a released
+// transfer could not be posted, and empty if the sender cancelled it.
+func (k Keeper) refundPendingTransfer(ctx sdk.Context, pending types.PendingTransfer, releaseErr string) error {
+	sender, err := sdk.AccAddressFromBech32(pending.Sender)
+	if err != nil {
+		return err
+	}
+	moduleAddress := k.accountKeeper.GetModuleAddress(types.ModuleName)
+	if err := k.bankKeeper.SendCoins(ctx, moduleAddress, sender, sdk.Coins{pending.Amount}); err != nil {
+		return fmt.Errorf("failed to return coins to sender: %w", err)
+	}
+	return ctx.EventManager().EmitTypedEvent(&types.EventTransferCancelled{
+		PendingTransferID: pending.Id,
+		Sender:            pending.Sender,
+		Amount:            pending.Amount.Amount.String(),
+		Denom:             pending.Amount.Denom,
+		Error:             releaseErr,
+	})
+}
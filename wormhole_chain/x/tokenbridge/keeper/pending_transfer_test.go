@@ -0,0 +1,231 @@
+package keeper_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	btypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	keepertest "github.com/wormhole-foundation/wormhole-chain/testutil/keeper"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/keeper"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+func createNPendingTransfer(keeper *keeper.Keeper, ctx sdk.Context, n int) []types.PendingTransfer {
+	items := make([]types.PendingTransfer, n)
+	for i := range items {
+		items[i].Id = uint64(i + 1)
+		items[i].Amount = sdk.NewInt64Coin("uatom", int64(i+1))
+		items[i].Fee = sdk.ZeroInt()
+		keeper.SetPendingTransfer(ctx, items[i])
+	}
+	keeper.SetPendingTransferCount(ctx, uint64(n))
+	return items
+}
+
+func TestPendingTransferGet(t *testing.T) {
+	keeper, ctx := keepertest.TokenbridgeKeeper(t)
+	items := createNPendingTransfer(keeper, ctx, 10)
+	for _, item := range items {
+		got, found := keeper.GetPendingTransfer(ctx, item.Id)
+		require.True(t, found)
+		require.Equal(t, item, got)
+	}
+	require.Equal(t, uint64(10), keeper.GetPendingTransferCount(ctx))
+}
+
+func TestPendingTransferRemove(t *testing.T) {
+	keeper, ctx := keepertest.TokenbridgeKeeper(t)
+	items := createNPendingTransfer(keeper, ctx, 10)
+	for _, item := range items {
+		keeper.RemovePendingTransfer(ctx, item.Id)
+		_, found := keeper.GetPendingTransfer(ctx, item.Id)
+		require.False(t, found)
+	}
+}
+
+func TestPendingTransferAmount(t *testing.T) {
+	k, ctx := keepertest.TokenbridgeKeeper(t)
+	createNPendingTransfer(k, ctx, 4)
+	assert.Equal(t, sdk.NewInt(10), k.GetPendingTransferAmount(ctx, "uatom"))
+
+	// Replacing a transfer updates the totals of both denoms
+	k.SetPendingTransfer(ctx, types.PendingTransfer{Id: 4, Amount: sdk.NewInt64Coin("uosmo", 7), Fee: sdk.ZeroInt()})
+	assert.Equal(t, sdk.NewInt(6), k.GetPendingTransferAmount(ctx, "uatom"))
+	assert.Equal(t, sdk.NewInt(7), k.GetPendingTransferAmount(ctx, "uosmo"))
+
+	k.RemovePendingTransfer(ctx, 2)
+	k.RemovePendingTransfer(ctx, 2)
+	k.RemovePendingTransfer(ctx, 4)
+	assert.Equal(t, sdk.NewInt(4), k.GetPendingTransferAmount(ctx, "uatom"))
+	assert.True(t, k.GetPendingTransferAmount(ctx, "uosmo").IsZero())
+}
+
+func TestPendingTransferGetAll(t *testing.T) {
+	keeper, ctx := keepertest.TokenbridgeKeeper(t)
+	items := createNPendingTransfer(keeper, ctx, 10)
+	require.Equal(t, items, keeper.GetAllPendingTransfer(ctx))
+}
+
+func TestCancelTransfer(t *testing.T) {
+	msgServer, k, ctx, mocks := setupMockedMsgServer(t)
+	ctx = ctx.WithBlockHeight(100)
+	k.SetConfig(ctx, types.Config{TransferCancelWindow: 10})
+	wrappedDenom := registerWrappedAsset(k, ctx, mocks.bank, vaa.ChainIDEthereum, testTokenAddress)
+	mocks.bank.SetDenomMetaData(ctx, btypes.Metadata{
+		DenomUnits: []*btypes.DenomUnit{
+			{Denom: "uatom", Exponent: 0},
+			{Denom: "atom", Exponent: 6},
+		},
+		Base:    "uatom",
+		Display: "atom",
+	})
+
+	user := sdk.AccAddress(bytes.Repeat([]byte{0xcc}, 20))
+	other := sdk.AccAddress(bytes.Repeat([]byte{0xdd}, 20))
+	moduleAddress := mockAccountKeeper{}.GetModuleAddress(types.ModuleName)
+	mocks.bank.balances[user.String()] = sdk.NewCoins(sdk.NewInt64Coin("uatom", 1000))
+	balance := func(addr sdk.AccAddress) int64 {
+		return mocks.bank.GetBalance(ctx, addr, "uatom").Amount.Int64()
+	}
+
+	transfer := func() uint64 {
+		res, err := msgServer.Transfer(sdk.WrapSDKContext(ctx), &types.MsgTransfer{
+			Creator:   user.String(),
+			Amount:    sdk.NewInt64Coin("uatom", 400),
+			ToChain:   uint32(vaa.ChainIDEthereum),
+			ToAddress: make([]byte, 32),
+			Fee:       sdk.NewInt64Coin("uatom", 0),
+		})
+		require.NoError(t, err)
+		return res.PendingTransferID
+	}
+	cancel := func(sender sdk.AccAddress, id uint64) error {
+		_, err := msgServer.CancelTransfer(sdk.WrapSDKContext(ctx), &types.MsgCancelTransfer{Creator: sender.String(), PendingTransferID: id})
+		return err
+	}
+
+	// The coins are held in the module account, but not in custody, until the transfer is released
+	id := transfer()
+	assert.Equal(t, uint64(1), id)
+	assert.Empty(t, mocks.wormhole.messages)
+	assert.Equal(t, int64(600), balance(user))
+	assert.Equal(t, int64(400), balance(moduleAddress))
+	_, found := k.GetCustodyBalance(ctx, "uatom")
+	assert.False(t, found)
+
+	assert.ErrorIs(t, cancel(other, id), types.ErrNotTransferSender)
+	require.NoError(t, cancel(user, id))
+	assert.Equal(t, int64(1000), balance(user))
+	assert.Equal(t, int64(0), balance(moduleAddress))
+	assert.ErrorIs(t, cancel(user, id), types.ErrUnknownPendingTransfer)
+
+	// Transfers are released at the end of their release height
+	id = transfer()
+	assert.Equal(t, uint64(2), id)
+	ctx = ctx.WithBlockHeight(109)
+	k.ReleasePendingTransfers(ctx)
+	assert.Empty(t, mocks.wormhole.messages)
+
+	ctx = ctx.WithBlockHeight(110)
+	assert.ErrorIs(t, cancel(user, id), types.ErrCancelWindowClosed)
+	k.ReleasePendingTransfers(ctx)
+	assert.Len(t, mocks.wormhole.messages, 1)
+	custody, found := k.GetCustodyBalance(ctx, "uatom")
+	require.True(t, found)
+	assert.Equal(t, "400", custody.Amount.String())
+	assert.ErrorIs(t, cancel(user, id), types.ErrUnknownPendingTransfer)
+
+	// A transfer that cannot be posted is returned to the sender
+	id = transfer()
+	mocks.wormhole.postMessageErr = errors.New("insufficient message fee")
+	ctx = ctx.WithBlockHeight(120).WithEventManager(sdk.NewEventManager())
+	k.ReleasePendingTransfers(ctx)
+	assert.Len(t, mocks.wormhole.messages, 1)
+	assert.Equal(t, int64(600), balance(user))
+	custody, _ = k.GetCustodyBalance(ctx, "uatom")
+	assert.Equal(t, "400", custody.Amount.String())
+	assert.Empty(t, k.GetAllPendingTransfer(ctx))
+
+	var cancelled []*types.EventTransferCancelled
+	for _, event := range ctx.EventManager().Events() {
+		parsed, err := sdk.ParseTypedEvent(abci.Event(event))
+		require.NoError(t, err)
+		if e, ok := parsed.(*types.EventTransferCancelled); ok {
+			cancelled = append(cancelled, e)
+		}
+	}
+	assert.Equal(t, []*types.EventTransferCancelled{{
+		PendingTransferID: id,
+		Sender:            user.String(),
+		Amount:            "400",
+		Denom:             "uatom",
+		Error:             "insufficient message fee",
+	}}, cancelled)
+
+	// Wrapped coins are held in the module account as well, and only the held coins are burned on release
+	mocks.wormhole.postMessageErr = nil
+	mocks.bank.balances[user.String()] = mocks.bank.balances[user.String()].Add(sdk.NewInt64Coin(wrappedDenom, 500))
+	transferWrapped := func(sender sdk.AccAddress) (*types.MsgTransferResponse, error) {
+		return msgServer.Transfer(sdk.WrapSDKContext(ctx), &types.MsgTransfer{
+			Creator:   sender.String(),
+			Amount:    sdk.NewInt64Coin(wrappedDenom, 200),
+			ToChain:   uint32(vaa.ChainIDEthereum),
+			ToAddress: make([]byte, 32),
+			Fee:       sdk.NewInt64Coin(wrappedDenom, 0),
+		})
+	}
+	res, err := transferWrapped(user)
+	require.NoError(t, err)
+	assert.Equal(t, int64(300), mocks.bank.GetBalance(ctx, user, wrappedDenom).Amount.Int64())
+	assert.Equal(t, int64(200), mocks.bank.GetBalance(ctx, moduleAddress, wrappedDenom).Amount.Int64())
+
+	// Without a cancel window, a sender cannot burn the held coins of others
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	k.SetConfig(ctx, types.Config{})
+	_, err = transferWrapped(other)
+	assert.ErrorIs(t, err, sdkerrors.ErrInsufficientFunds)
+	assert.Len(t, mocks.wormhole.messages, 1)
+	assert.Equal(t, int64(200), mocks.bank.GetBalance(ctx, moduleAddress, wrappedDenom).Amount.Int64())
+
+	_, err = transferWrapped(user)
+	require.NoError(t, err)
+	assert.Len(t, mocks.wormhole.messages, 2)
+	assert.Equal(t, int64(100), mocks.bank.GetBalance(ctx, user, wrappedDenom).Amount.Int64())
+	assert.Equal(t, int64(200), mocks.bank.GetBalance(ctx, moduleAddress, wrappedDenom).Amount.Int64())
+
+	pending, found := k.GetPendingTransfer(ctx, res.PendingTransferID)
+	require.True(t, found)
+	ctx = ctx.WithBlockHeight(pending.ReleaseHeight)
+	k.ReleasePendingTransfers(ctx)
+	assert.Len(t, mocks.wormhole.messages, 3)
+	assert.True(t, mocks.bank.GetBalance(ctx, moduleAddress, wrappedDenom).IsZero())
+
+	// Transfers sent right away and released ones both emit EventTransferSent with their sequence
+	var sent []*types.EventTransferSent
+	for _, event := range ctx.EventManager().Events() {
+		parsed, err := sdk.ParseTypedEvent(abci.Event(event))
+		require.NoError(t, err)
+		if e, ok := parsed.(*types.EventTransferSent); ok {
+			sent = append(sent, e)
+		}
+	}
+	expected := func(sequence uint64) *types.EventTransferSent {
+		return &types.EventTransferSent{
+			Sender:    user.String(),
+			Amount:    "200",
+			Denom:     wrappedDenom,
+			Fee:       "0",
+			ToChain:   uint32(vaa.ChainIDEthereum),
+			ToAddress: make([]byte, 32),
+			Sequence:  sequence,
+		}
+	}
+	assert.Equal(t, []*types.EventTransferSent{expected(1), expected(2)}, sent)
+}
@@ -0,0 +1,151 @@
+package keeper_test
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keys/multisig"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authkeeper "github.com/cosmos/cosmos-sdk/x/auth/keeper"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	vestingtypes "github.com/cosmos/cosmos-sdk/x/auth/vesting/types"
+	bankkeeper "github.com/cosmos/cosmos-sdk/x/bank/keeper"
+	capabilitytypes "github.com/cosmos/cosmos-sdk/x/capability/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	keepertest "github.com/wormhole-foundation/wormhole-chain/testutil/keeper"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/keeper"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+	whtypes "github.com/wormhole-foundation/wormhole-chain/x/wormhole/types"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// TestExecuteVAARecipientAccounts redeems a transfer to recipients in different account states with the real auth and
+// bank keepers.
+func TestExecuteVAARecipientAccounts(t *testing.T) {
+	pubKey := secp256k1.GenPrivKey().PubKey()
+	multisigKey := multisig.NewLegacyAminoPubKey(2, []cryptotypes.PubKey{
+		secp256k1.GenPrivKey().PubKey(),
+		secp256k1.GenPrivKey().PubKey(),
+		secp256k1.GenPrivKey().PubKey(),
+	})
+	vested := sdk.NewCoins(sdk.NewInt64Coin("uworm", 1000))
+
+	tests := []struct {
+		label string
+		// setup creates the recipient account, if any, and returns the recipient
+		setup func(t *testing.T, ctx sdk.Context, ak authkeeper.AccountKeeper, bk bankkeeper.Keeper) sdk.AccAddress
+		// check verifies the account of the recipient after the redemption
+		check func(t *testing.T, acc authtypes.AccountI)
+		err   error
+	}{
+		{
+			label: "new account",
+			setup: func(t *testing.T, ctx sdk.Context, ak authkeeper.AccountKeeper, bk bankkeeper.Keeper) sdk.AccAddress {
+				return sdk.AccAddress(bytes.Repeat([]byte{0xaa}, 20))
+			},
+			check: func(t *testing.T, acc authtypes.AccountI) {
+				// The account is created without a public key, which is set by the first tx it signs
+				assert.IsType(t, &authtypes.BaseAccount{}, acc)
+				assert.Nil(t, acc.GetPubKey())
+				assert.Equal(t, uint64(0), acc.GetSequence())
+			},
+		},
+		{
+			label: "account without public key",
+			setup: func(t *testing.T, ctx sdk.Context, ak authkeeper.AccountKeeper, bk bankkeeper.Keeper) sdk.AccAddress {
+				addr := sdk.AccAddress(bytes.Repeat([]byte{0xab}, 20))
+				ak.SetAccount(ctx, ak.NewAccountWithAddress(ctx, addr))
+				return addr
+			},
+			check: func(t *testing.T, acc authtypes.AccountI) {
+				assert.Nil(t, acc.GetPubKey())
+			},
+		},
+		{
+			label: "account with public key",
+			setup: func(t *testing.T, ctx sdk.Context, ak authkeeper.AccountKeeper, bk bankkeeper.Keeper) sdk.AccAddress {
+				addr := sdk.AccAddress(pubKey.Address())
+				ak.SetAccount(ctx, authtypes.NewBaseAccount(addr, pubKey, ak.GetNextAccountNumber(ctx), 3))
+				return addr
+			},
+			check: func(t *testing.T, acc authtypes.AccountI) {
+				assert.True(t, pubKey.Equals(acc.GetPubKey()))
+				assert.Equal(t, uint64(3), acc.GetSequence())
+			},
+		},
+		{
+			label: "vesting account",
+			setup: func(t *testing.T, ctx sdk.Context, ak authkeeper.AccountKeeper, bk bankkeeper.Keeper) sdk.AccAddress {
+				addr := sdk.AccAddress(bytes.Repeat([]byte{0xac}, 20))
+				base := authtypes.NewBaseAccount(addr, nil, ak.GetNextAccountNumber(ctx), 0)
+				ak.SetAccount(ctx, vestingtypes.NewContinuousVestingAccount(base, vested, 1, 1<<40))
+				require.NoError(t, bk.MintCoins(ctx, types.ModuleName, vested))
+				require.NoError(t, bk.SendCoinsFromModuleToAccount(ctx, types.ModuleName, addr, vested))
+				return addr
+			},
+			check: func(t *testing.T, acc authtypes.AccountI) {
+				// The account keeps vesting its original coins, the redeemed coins are not locked
+				vesting, ok := acc.(*vestingtypes.ContinuousVestingAccount)
+				require.True(t, ok)
+				assert.Equal(t, vested, vesting.OriginalVesting)
+			},
+		},
+		{
+			label: "multisig account",
+			setup: func(t *testing.T, ctx sdk.Context, ak authkeeper.AccountKeeper, bk bankkeeper.Keeper) sdk.AccAddress {
+				addr := sdk.AccAddress(multisigKey.Address())
+				ak.SetAccount(ctx, authtypes.NewBaseAccount(addr, multisigKey, ak.GetNextAccountNumber(ctx), 0))
+				return addr
+			},
+			check: func(t *testing.T, acc authtypes.AccountI) {
+				assert.True(t, multisigKey.Equals(acc.GetPubKey()))
+			},
+		},
+		{
+			label: "blocked module account",
+			setup: func(t *testing.T, ctx sdk.Context, ak authkeeper.AccountKeeper, bk bankkeeper.Keeper) sdk.AccAddress {
+				return ak.GetModuleAddress(authtypes.FeeCollectorName)
+			},
+			err: types.ErrBlockedRecipient,
+		},
+		{
+			label: "token bridge module account",
+			setup: func(t *testing.T, ctx sdk.Context, ak authkeeper.AccountKeeper, bk bankkeeper.Keeper) sdk.AccAddress {
+				return ak.GetModuleAddress(types.ModuleName)
+			},
+			err: types.ErrBlockedRecipient,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.label, func(t *testing.T) {
+			wormholeKeeper := &mockWormholeKeeper{
+				config:       whtypes.Config{ChainId: uint32(vaa.ChainIDWormchain)},
+				capabilities: map[string]*capabilitytypes.Capability{},
+			}
+			k, ctx, ak, bk := keepertest.TokenbridgeKeeperWithBank(t, wormholeKeeper, authtypes.FeeCollectorName, types.ModuleName)
+			msgServer := keeper.NewMsgServerImpl(*k)
+			denom := registerWrappedAsset(k, ctx, bk, vaa.ChainIDEthereum, testTokenAddress)
+			to := tc.setup(t, ctx, ak, bk)
+
+			payload := createTransferPayload(big.NewInt(100), big.NewInt(0), uint16(vaa.ChainIDEthereum), testTokenAddress, to, uint16(vaa.ChainIDWormchain))
+			_, err := msgServer.ExecuteVAA(sdk.WrapSDKContext(ctx), &types.MsgExecuteVAA{Vaa: createTransferVAA(t, payload)})
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				assert.True(t, bk.GetBalance(ctx, to, denom).IsZero())
+				return
+			}
+			require.NoError(t, err)
+
+			acc := ak.GetAccount(ctx, to)
+			require.NotNil(t, acc)
+			tc.check(t, acc)
+			// The redeemed coins can be spent right away
+			assert.Equal(t, int64(100), bk.SpendableCoins(ctx, to).AmountOf(denom).Int64())
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package keeper
+
+import (
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+)
+
+// SetRegistrationBounty set a specific registrationBounty in the store from its index
+func (k Keeper) SetRegistrationBounty(ctx sdk.Context, registrationBounty types.RegistrationBounty) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.RegistrationBountyKeyPrefix))
+	b := k.cdc.MustMarshal(&registrationBounty)
+	store.Set(types.RegistrationBountyKey(
+		registrationBounty.Denom,
+	), b)
+}
+
+// GetRegistrationBounty returns a registrationBounty from its index
+func (k Keeper) GetRegistrationBounty(
+	ctx sdk.Context,
+	denom string,
+
+) (val types.RegistrationBounty, found bool) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.RegistrationBountyKeyPrefix))
+
+	b := store.Get(types.RegistrationBountyKey(
+		denom,
+	))
+	if b == nil {
+		return val, false
+	}
+
+	k.cdc.MustUnmarshal(b, &val)
+	return val, true
+}
+
+// RemoveRegistrationBounty removes a registrationBounty from the store
+func (k Keeper) RemoveRegistrationBounty(
+	ctx sdk.Context,
+	denom string,
+
+) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.RegistrationBountyKeyPrefix))
+	store.Delete(types.RegistrationBountyKey(
+		denom,
+	))
+}
+
+// GetAllRegistrationBounty returns all registrationBounty
+func (k Keeper) GetAllRegistrationBounty(ctx sdk.Context) (list []types.RegistrationBounty) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.RegistrationBountyKeyPrefix))
+	iterator := sdk.KVStorePrefixIterator(store, []byte{})
+
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var val types.RegistrationBounty
+		k.cdc.MustUnmarshal(iterator.Value(), &val)
+		list = append(list, val)
+	}
+
+	return
+}
+
+// registrationBounty returns the bounty for registering a wrapped asset, if one is configured.
+func (k Keeper) registrationBounty(ctx sdk.Context) (sdk.Coin, bool) {
+	config, found := k.GetConfig(ctx)
+	if !found || config.RegistrationBounty == nil || !config.RegistrationBounty.IsPositive() {
+		return sdk.Coin{}, false
+	}
+	return *config.RegistrationBounty, true
+}
+
+// recordRegistrationBounty records that registrant registered the wrapped asset denom, if registering assets is
+// rewarded.
+func (k Keeper) recordRegistrationBounty(ctx sdk.Context, denom string, registrant string) {
+	if _, enabled := k.registrationBounty(ctx); !enabled {
+		return
+	}
+	if _, err := sdk.AccAddressFromBech32(registrant); err != nil {
+		return
+	}
+	k.SetRegistrationBounty(ctx, types.RegistrationBounty{Denom: denom, Registrant: registrant})
+}
+
+// payRegistrationBounty pays the bounty owed for registering the wrapped asset denom, when a transfer of it is
+// redeemed. The bounty stays owed while the fee account cannot pay it, so redemptions never fail because of it.
+func (k Keeper) payRegistrationBounty(ctx sdk.Context, denom string) error {
+	owed, found := k.GetRegistrationBounty(ctx, denom)
+	if !found {
+		return nil
+	}
+	bounty, enabled := k.registrationBounty(ctx)
+	if !enabled {
+		k.RemoveRegistrationBounty(ctx, denom)
+		return nil
+	}
+
+	feeAddress := k.FeeAddress(ctx)
+	if k.bankKeeper.GetBalance(ctx, feeAddress, bounty.Denom).IsLT(bounty) {
+		k.Logger(ctx).Info("fee account cannot pay registration bounty", "denom", denom, "bounty", bounty.String())
+		return nil
+	}
+	recipient, err := sdk.AccAddressFromBech32(owed.Registrant)
+	if err != nil {
+		return err
+	}
+	if err := k.bankKeeper.SendCoins(ctx, feeAddress, recipient, sdk.NewCoins(bounty)); err != nil {
+		return err
+	}
+	k.RemoveRegistrationBounty(ctx, denom)
+
+	return ctx.EventManager().EmitTypedEvent(&types.EventRegistrationBountyPaid{
+		Recipient:    owed.Registrant,
+		Amount:       bounty.Amount.String(),
+		Denom:        bounty.Denom,
+		WrappedDenom: denom,
+	})
+}
@@ -0,0 +1,137 @@
+package keeper_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math/big"
+	"strconv"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	keepertest "github.com/wormhole-foundation/wormhole-chain/testutil/keeper"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/keeper"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+func createNRegistrationBounty(keeper *keeper.Keeper, ctx sdk.Context, n int) []types.RegistrationBounty {
+	items := make([]types.RegistrationBounty, n)
+	for i := range items {
+		items[i].Denom = "denom" + strconv.Itoa(i)
+		items[i].Registrant = "registrant" + strconv.Itoa(i)
+
+		keeper.SetRegistrationBounty(ctx, items[i])
+	}
+	return items
+}
+
+func TestRegistrationBountyGet(t *testing.T) {
+	keeper, ctx := keepertest.TokenbridgeKeeper(t)
+	items := createNRegistrationBounty(keeper, ctx, 10)
+	for _, item := range items {
+		rst, found := keeper.GetRegistrationBounty(ctx,
+			item.Denom,
+		)
+		require.True(t, found)
+		require.Equal(t, item, rst)
+	}
+}
+
+func TestRegistrationBountyRemove(t *testing.T) {
+	keeper, ctx := keepertest.TokenbridgeKeeper(t)
+	items := createNRegistrationBounty(keeper, ctx, 10)
+	for _, item := range items {
+		keeper.RemoveRegistrationBounty(ctx,
+			item.Denom,
+		)
+		_, found := keeper.GetRegistrationBounty(ctx,
+			item.Denom,
+		)
+		require.False(t, found)
+	}
+}
+
+func createAssetMetaVAA(t testing.TB, tokenAddress [32]byte, sequence uint64) []byte {
+	payload := make([]byte, 100)
+	payload[0] = byte(keeper.PayloadIDAssetMeta)
+	copy(payload[1:33], tokenAddress[:])
+	binary.BigEndian.PutUint16(payload[33:35], uint16(vaa.ChainIDEthereum))
+	payload[35] = 8
+	copy(payload[36:], "TKN")
+	copy(payload[68:], "Token")
+	v := &vaa.VAA{
+		Version:          vaa.SupportedVAAVersion,
+		Timestamp:        time.Unix(0, 0),
+		EmitterChain:     vaa.ChainIDEthereum,
+		EmitterAddress:   testEmitter,
+		Sequence:         sequence,
+		ConsistencyLevel: 1,
+		Payload:          payload,
+	}
+	bz, err := v.Marshal()
+	require.NoError(t, err)
+	return bz
+}
+
+func TestRegistrationBounty(t *testing.T) {
+	msgServer, k, ctx, mocks := setupMockedMsgServer(t)
+	k.SetConfig(ctx, types.Config{RegistrationBounty: &sdk.Coin{Denom: "uworm", Amount: sdk.NewInt(10)}})
+	k.SetChainRegistration(ctx, types.ChainRegistration{ChainID: uint32(vaa.ChainIDEthereum), EmitterAddress: testEmitter[:]})
+	require.NoError(t, mocks.bank.MintCoins(ctx, types.DefaultFeeAccountName, sdk.NewCoins(sdk.NewInt64Coin("uworm", 15))))
+
+	registrant := sdk.AccAddress(bytes.Repeat([]byte{0xaa}, 20))
+	relayer := sdk.AccAddress(bytes.Repeat([]byte{0xbb}, 20))
+	to := sdk.AccAddress(bytes.Repeat([]byte{0xcc}, 20))
+	worm := func(addr sdk.AccAddress) int64 {
+		return mocks.bank.GetBalance(ctx, addr, "uworm").Amount.Int64()
+	}
+	register := func(tokenAddress [32]byte) string {
+		_, err := msgServer.ExecuteVAA(sdk.WrapSDKContext(ctx), &types.MsgExecuteVAA{Creator: registrant.String(), Vaa: createAssetMetaVAA(t, tokenAddress, 1)})
+		require.NoError(t, err)
+		return "b" + types.GetWrappedCoinIdentifier(uint16(vaa.ChainIDEthereum), tokenAddress)
+	}
+	redeem := func(tokenAddress [32]byte, amount int64) {
+		payload := createTransferPayload(big.NewInt(amount), big.NewInt(0), uint16(vaa.ChainIDEthereum), tokenAddress, to, uint16(vaa.ChainIDWormchain))
+		_, err := msgServer.ExecuteVAA(sdk.WrapSDKContext(ctx), &types.MsgExecuteVAA{Creator: relayer.String(), Vaa: createTransferVAA(t, payload)})
+		require.NoError(t, err)
+	}
+
+	// The bounty is paid to the registrant when the asset is first redeemed
+	denom := register(testTokenAddress)
+	owed, found := k.GetRegistrationBounty(ctx, denom)
+	require.True(t, found)
+	assert.Equal(t, registrant.String(), owed.Registrant)
+	assert.Equal(t, int64(0), worm(registrant))
+
+	redeem(testTokenAddress, 100)
+	assert.Equal(t, int64(10), worm(registrant))
+	assert.Equal(t, int64(5), worm(k.FeeAddress(ctx)))
+	_, found = k.GetRegistrationBounty(ctx, denom)
+	assert.False(t, found)
+
+	redeem(testTokenAddress, 200)
+	assert.Equal(t, int64(10), worm(registrant))
+
+	// Updating the metadata of a registered asset does not earn another bounty
+	_, err := msgServer.ExecuteVAA(sdk.WrapSDKContext(ctx), &types.MsgExecuteVAA{Creator: registrant.String(), Vaa: createAssetMetaVAA(t, testTokenAddress, 2)})
+	require.NoError(t, err)
+	_, found = k.GetRegistrationBounty(ctx, denom)
+	assert.False(t, found)
+
+	// The bounty stays owed while the fee account cannot pay it
+	otherTokenAddress := [32]byte{0x03}
+	otherDenom := register(otherTokenAddress)
+	redeem(otherTokenAddress, 100)
+	assert.Equal(t, int64(10), worm(registrant))
+	_, found = k.GetRegistrationBounty(ctx, otherDenom)
+	assert.True(t, found)
+	assert.Equal(t, "100", mocks.bank.GetBalance(ctx, to, otherDenom).Amount.String())
+
+	require.NoError(t, mocks.bank.MintCoins(ctx, types.DefaultFeeAccountName, sdk.NewCoins(sdk.NewInt64Coin("uworm", 5))))
+	redeem(otherTokenAddress, 200)
+	assert.Equal(t, int64(20), worm(registrant))
+	assert.Equal(t, int64(0), worm(k.FeeAddress(ctx)))
+}
@@ -0,0 +1,78 @@
+package keeper
+
+import (
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+)
+
+// SetRelayer set a specific relayer in the store from its index
+func (k Keeper) SetRelayer(ctx sdk.Context, relayer types.Relayer) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.RelayerKeyPrefix))
+	b := k.cdc.MustMarshal(&relayer)
+	store.Set(types.RelayerKey(
+		relayer.Address,
+	), b)
+}
+
+// GetRelayer returns a relayer from its index
+func (k Keeper) GetRelayer(
+	ctx sdk.Context,
+	address string,
+
+) (val types.Relayer, found bool) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.RelayerKeyPrefix))
+
+	b := store.Get(types.RelayerKey(
+		address,
+	))
+	if b == nil {
+		return val, false
+	}
+
+	k.cdc.MustUnmarshal(b, &val)
+	return val, true
+}
+
+// RemoveRelayer removes a relayer from the store
+func (k Keeper) RemoveRelayer(
+	ctx sdk.Context,
+	address string,
+
+) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.RelayerKeyPrefix))
+	store.Delete(types.RelayerKey(
+		address,
+	))
+}
+
+// GetAllRelayer returns all relayer
+func (k Keeper) GetAllRelayer(ctx sdk.Context) (list []types.Relayer) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.RelayerKeyPrefix))
+	iterator := sdk.KVStorePrefixIterator(store, []byte{})
+
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var val types.Relayer
+		k.cdc.MustUnmarshal(iterator.Value(), &val)
+		list = append(list, val)
+	}
+
+	return
+}
+
+// recordRelayerRedemption adds a transfer redeemed by creator, which earned it fee, to its stats if it is a
+// registered relayer.
+func (k Keeper) recordRelayerRedemption(ctx sdk.Context, creator string, fee sdk.Coin) {
+	relayer, found := k.GetRelayer(ctx, creator)
+	if !found {
+		return
+	}
+	relayer.RedemptionsCompleted++
+	if fee.IsPositive() {
+		relayer.FeesEarned = relayer.FeesEarned.Add(fee)
+	}
+	relayer.LastRedemptionHeight = ctx.BlockHeight()
+	k.SetRelayer(ctx, relayer)
+}
@@ -0,0 +1,106 @@
+package keeper_test
+
+import (
+	"bytes"
+	"math/big"
+	"strconv"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	keepertest "github.com/wormhole-foundation/wormhole-chain/testutil/keeper"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/keeper"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+func createNRelayer(keeper *keeper.Keeper, ctx sdk.Context, n int) []types.Relayer {
+	items := make([]types.Relayer, n)
+	for i := range items {
+		items[i].Address = strconv.Itoa(i)
+		items[i].RedemptionsCompleted = uint64(i)
+
+		keeper.SetRelayer(ctx, items[i])
+	}
+	return items
+}
+
+func TestRelayerGet(t *testing.T) {
+	keeper, ctx := keepertest.TokenbridgeKeeper(t)
+	items := createNRelayer(keeper, ctx, 10)
+	for _, item := range items {
+		rst, found := keeper.GetRelayer(ctx,
+			item.Address,
+		)
+		require.True(t, found)
+		require.Equal(t, item, rst)
+	}
+}
+
+func TestRelayerRemove(t *testing.T) {
+	keeper, ctx := keepertest.TokenbridgeKeeper(t)
+	items := createNRelayer(keeper, ctx, 10)
+	for _, item := range items {
+		keeper.RemoveRelayer(ctx,
+			item.Address,
+		)
+		_, found := keeper.GetRelayer(ctx,
+			item.Address,
+		)
+		require.False(t, found)
+	}
+}
+
+func TestRelayerRegistry(t *testing.T) {
+	msgServer, k, ctx, mocks := setupMockedMsgServer(t)
+	denom := registerWrappedAsset(k, ctx, mocks.bank, vaa.ChainIDEthereum, testTokenAddress)
+	ctx = ctx.WithBlockHeight(10)
+
+	to := sdk.AccAddress(bytes.Repeat([]byte{0xaa}, 20))
+	relayer := sdk.AccAddress(bytes.Repeat([]byte{0xbb}, 20))
+	redeem := func(amount, fee int64) {
+		payload := createTransferPayload(big.NewInt(amount), big.NewInt(fee), uint16(vaa.ChainIDEthereum), testTokenAddress, to, uint16(vaa.ChainIDWormchain))
+		_, err := msgServer.ExecuteVAA(sdk.WrapSDKContext(ctx), &types.MsgExecuteVAA{Creator: relayer.String(), Vaa: createTransferVAA(t, payload)})
+		require.NoError(t, err)
+	}
+
+	// Redemptions are only tracked once the relayer opts in
+	redeem(100, 10)
+	_, found := k.GetRelayer(ctx, relayer.String())
+	assert.False(t, found)
+
+	_, err := msgServer.RegisterRelayer(sdk.WrapSDKContext(ctx), &types.MsgRegisterRelayer{Creator: relayer.String(), Endpoint: "https://relayer.example.com", Description: "Example"})
+	require.NoError(t, err)
+	ctx = ctx.WithBlockHeight(20)
+	redeem(200, 20)
+	redeem(300, 0)
+
+	got, found := k.GetRelayer(ctx, relayer.String())
+	require.True(t, found)
+	assert.Equal(t, types.Relayer{
+		Address:              relayer.String(),
+		Endpoint:             "https://relayer.example.com",
+		Description:          "Example",
+		RegisteredHeight:     10,
+		RedemptionsCompleted: 2,
+		FeesEarned:           sdk.NewCoins(sdk.NewInt64Coin(denom, 20)),
+		LastRedemptionHeight: 20,
+	}, got)
+
+	// Updating the metadata keeps the stats
+	_, err = msgServer.RegisterRelayer(sdk.WrapSDKContext(ctx), &types.MsgRegisterRelayer{Creator: relayer.String(), Endpoint: "https://relayer2.example.com"})
+	require.NoError(t, err)
+	got, _ = k.GetRelayer(ctx, relayer.String())
+	assert.Equal(t, "https://relayer2.example.com", got.Endpoint)
+	assert.Empty(t, got.Description)
+	assert.Equal(t, uint64(2), got.RedemptionsCompleted)
+	assert.Equal(t, int64(10), got.RegisteredHeight)
+
+	_, err = msgServer.DeregisterRelayer(sdk.WrapSDKContext(ctx), &types.MsgDeregisterRelayer{Creator: relayer.String()})
+	require.NoError(t, err)
+	_, found = k.GetRelayer(ctx, relayer.String())
+	assert.False(t, found)
+	_, err = msgServer.DeregisterRelayer(sdk.WrapSDKContext(ctx), &types.MsgDeregisterRelayer{Creator: relayer.String()})
+	assert.ErrorIs(t, err, types.ErrUnknownRelayer)
+}
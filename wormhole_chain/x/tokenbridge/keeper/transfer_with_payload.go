@@ -0,0 +1,152 @@
+package keeper
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+	whtypes "github.com/wormhole-foundation/wormhole-chain/x/wormhole/types"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// TransferWithPayload is a redeemed transfer with payload (payload ID 3), as it is delivered to the module it is sent
+// to.
+type TransferWithPayload struct {
+	// Amount is the redeemed amount in the local denom. Transfers with payload have no relayer fee.
+	Amount sdk.Coin
+	// To is the recipient the coins were paid out to.
+	To sdk.AccAddress
+	// FromChain and From are the origin chain of the transfer and its sender there.
+	FromChain vaa.ChainID
+	From      [32]byte
+	// Payload is the application payload of the transfer.
+	Payload []byte
+	// Redeemer is the sender of the ExecuteVAA message.
+	Redeemer string
+}
+
+// TransferPayloadReceiver is called when a transfer with payload to the address it is registered for is redeemed,
+// after the coins were paid out to that address. An error reverts the redemption.
+type TransferPayloadReceiver func(ctx sdk.Context, transfer TransferWithPayload) error
+
+// RegisterTransferPayloadReceiver lets another module receive the transfers with payload sent to address, usually its
+// module account. As module accounts cannot sign, the transfers sent to a registered address can be redeemed by
+// anyone. It must be called while the app is set up, and panics if the address already has a receiver.
+func (k *Keeper) RegisterTransferPayloadReceiver(address sdk.AccAddress, receiver TransferPayloadReceiver) {
+	if _, exists := k.payloadReceivers[address.String()]; exists {
+		panic(fmt.Sprintf("transfer payload receiver for %s already registered", address))
+	}
+	k.payloadReceivers[address.String()] = receiver
+}
+
+// executeTransferWithPayload redeems a transfer with payload. Transfers to the gateway account are forwarded over IBC,
+// all others are paid out to their recipient.
+func (k Keeper) executeTransferWithPayload(ctx sdk.Context, logger log.Logger, msg *types.MsgExecuteVAA, v *vaa.VAA, wormholeConfig whtypes.Config, payload []byte) error {
+	if len(payload) < 132 {
+		return types.ErrVAAPayloadInvalid
+	}
+	if bytes.Equal(payload[78:98], GatewayAddress()) {
+		return k.executeGatewayTransfer(ctx, logger, msg, v, wormholeConfig, payload)
+	}
+	return k.redeemTransferWithPayload(ctx, logger, msg, v, wormholeConfig, payload)
+}
+
+// redeemTransferWithPayload pays out a transfer with payload to its recipient and delivers the payload to the
+// receiver registered for it. Transfers to an address without a receiver can only be redeemed by the recipient
+// itself, so that contracts can act on the payload in the same tx.
+func (k Keeper) redeemTransferWithPayload(ctx sdk.Context, logger log.Logger, msg *types.MsgExecuteVAA, v *vaa.VAA, wormholeConfig whtypes.Config, payload []byte) error {
+	// Payload: amount (32) | token address (32) | token chain (2) | to (32) | to chain (2) | from (32) | payload
+	unnormalizedAmount := new(big.Int).SetBytes(payload[:32])
+	var tokenAddress [32]byte
+	copy(tokenAddress[:], payload[32:64])
+	tokenChain := binary.BigEndian.Uint16(payload[64:66])
+	toChain := binary.BigEndian.Uint16(payload[98:100])
+	var from [32]byte
+	copy(from[:], payload[100:132])
+
+	if uint32(toChain) != wormholeConfig.ChainId {
+		return types.ErrInvalidTargetChain
+	}
+	if !bytes.Equal(payload[66:78], make([]byte, 12)) {
+		return types.ErrInvalidPayloadRecipient
+	}
+	to := sdk.AccAddress(payload[78:98])
+
+	receiver, hasReceiver := k.payloadReceivers[to.String()]
+	if !hasReceiver {
+		if msg.Creator != to.String() {
+			return fmt.Errorf("%w: %s", types.ErrInvalidRedeemer, to)
+		}
+		if k.bankKeeper.BlockedAddr(to) {
+			return fmt.Errorf("%w: %s", types.ErrBlockedRecipient, to)
+		}
+	}
+
+	identifier, wrapped, meta, err := k.redeemedDenom(ctx, logger, wormholeConfig, tokenChain, tokenAddress)
+	if err != nil {
+		return err
+	}
+	amount, err := redeemedAmount(identifier, unnormalizedAmount, meta)
+	if err != nil {
+		return err
+	}
+
+	correlationID := v.HexDigest()
+	if err := k.releaseRedeemedCoin(ctx, correlationID, amount, wrapped); err != nil {
+		return err
+	}
+	moduleAddress := k.accountKeeper.GetModuleAddress(types.ModuleName)
+	if err := k.bankKeeper.SendCoins(ctx, moduleAddress, to, sdk.Coins{amount}); err != nil {
+		return fmt.Errorf("failed to pay out %s: %w", amount, err)
+	}
+	k.appendLedgerEntry(ctx, correlationID, types.PayoutKindPrincipal, to.String(), moduleAddress.String(), amount)
+
+	logger.Debug("redeemed transfer with payload",
+		"to", to.String(),
+		"amount", amount.String(),
+		"receiver", hasReceiver)
+
+	if hasReceiver {
+		err := receiver(ctx, TransferWithPayload{
+			Amount:    amount,
+			To:        to,
+			FromChain: v.EmitterChain,
+			From:      from,
+			Payload:   payload[132:],
+			Redeemer:  msg.Creator,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to deliver payload to %s: %w", to, err)
+		}
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeTransferWithPayloadRedeemed,
+		sdk.NewAttribute(types.AttributeKeyVAADigest, v.HexDigest()),
+		sdk.NewAttribute(types.AttributeKeyCorrelationID, correlationID),
+		sdk.NewAttribute(types.AttributeKeyEmitterChain, fmt.Sprint(uint16(v.EmitterChain))),
+		sdk.NewAttribute(types.AttributeKeyEmitterAddress, v.EmitterAddress.String()),
+		sdk.NewAttribute(types.AttributeKeySequence, fmt.Sprint(v.Sequence)),
+		sdk.NewAttribute(types.AttributeKeyRecipient, to.String()),
+		sdk.NewAttribute(types.AttributeKeyDenom, identifier),
+		sdk.NewAttribute(types.AttributeKeyAmount, amount.Amount.String()),
+		sdk.NewAttribute(types.AttributeKeySender, hex.EncodeToString(from[:])),
+		sdk.NewAttribute(types.AttributeKeyPayload, hex.EncodeToString(payload[132:])),
+	))
+
+	if msg.PostReceipt {
+		payer, err := sdk.AccAddressFromBech32(msg.Creator)
+		if err != nil {
+			return err
+		}
+		if err := k.postDeliveryReceipt(ctx, v, DeliveryStatusRedeemed, payer); err != nil {
+			return fmt.Errorf("failed to post delivery receipt: %w", err)
+		}
+	}
+	return nil
+}
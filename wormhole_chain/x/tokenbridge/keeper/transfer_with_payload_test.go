@@ -0,0 +1,70 @@
+package keeper_test
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/keeper"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+func TestExecuteVAATransferWithPayload(t *testing.T) {
+	from := [32]byte{31: 0xaa}
+	recipient := sdk.AccAddress(bytes.Repeat([]byte{0xbb}, 20))
+	appPayload := []byte("hello")
+
+	tests := []struct {
+		label    string
+		redeemer string
+		receiver bool
+		err      error
+	}{
+		{label: "redeemed by recipient", redeemer: recipient.String()},
+		{label: "redeemed by another account", redeemer: sdk.AccAddress(bytes.Repeat([]byte{0xcc}, 20)).String(), err: types.ErrInvalidRedeemer},
+		{label: "delivered to receiver", redeemer: sdk.AccAddress(bytes.Repeat([]byte{0xcc}, 20)).String(), receiver: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.label, func(t *testing.T) {
+			_, k, ctx, mocks := setupMockedMsgServer(t)
+			var delivered []keeper.TransferWithPayload
+			if tc.receiver {
+				k.RegisterTransferPayloadReceiver(recipient, func(ctx sdk.Context, transfer keeper.TransferWithPayload) error {
+					delivered = append(delivered, transfer)
+					return nil
+				})
+			}
+			msgServer := keeper.NewMsgServerImpl(*k)
+			denom := registerWrappedAsset(k, ctx, mocks.bank, vaa.ChainIDEthereum, testTokenAddress)
+
+			payload := createGatewayTransferPayload(big.NewInt(100), uint16(vaa.ChainIDEthereum), testTokenAddress, recipient, from, appPayload)
+			_, err := msgServer.ExecuteVAA(sdk.WrapSDKContext(ctx), &types.MsgExecuteVAA{Creator: tc.redeemer, Vaa: createTransferVAA(t, payload)})
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				assert.True(t, mocks.bank.GetBalance(ctx, recipient, denom).IsZero())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, int64(100), mocks.bank.GetBalance(ctx, recipient, denom).Amount.Int64())
+
+			if !tc.receiver {
+				assert.Empty(t, delivered)
+				return
+			}
+			require.Len(t, delivered, 1)
+			assert.Equal(t, keeper.TransferWithPayload{
+				Amount:    sdk.NewInt64Coin(denom, 100),
+				To:        recipient,
+				FromChain: vaa.ChainIDEthereum,
+				From:      from,
+				Payload:   appPayload,
+				Redeemer:  tc.redeemer,
+			}, delivered[0])
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package keeper
+
+import (
+	"bytes"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// PrecheckVAA runs the checks on a token bridge VAA that only need a store
+// lookup. They account for most failed redemptions (e.g. a relayer racing
+// another one), so they run before the expensive signature verification.
+func (k Keeper) PrecheckVAA(ctx sdk.Context, v *vaa.VAA) error {
+	// Replay protection
+	if _, known := k.GetReplayProtection(ctx, v.HexDigest()); known {
+		return types.ErrVAAAlreadyExecuted
+	}
+
+	// Check if emitter is a registered chain
+	registration, found := k.GetChainRegistration(ctx, uint32(v.EmitterChain))
+	if !found {
+		return types.ErrUnregisteredChain
+	}
+	if !bytes.Equal(v.EmitterAddress[:], registration.EmitterAddress) {
+		return types.ErrUnregisteredEmitter
+	}
+
+	return nil
+}
+
+// VerifyVAA verifies that v is signed by a quorum of its guardian set.
+func (k Keeper) VerifyVAA(ctx sdk.Context, v *vaa.VAA) error {
+	return k.wormholeKeeper.VerifyVAA(ctx, v)
+}
+
+// CountVAAExecutions records n VAA executions of the sender in the current block and fails if the sender exceeds the
+// per-sender limit of the wormhole config.
+func (k Keeper) CountVAAExecutions(ctx sdk.Context, sender sdk.AccAddress, n uint32) error {
+	return k.wormholeKeeper.CountVAAExecutions(ctx, sender, n)
+}
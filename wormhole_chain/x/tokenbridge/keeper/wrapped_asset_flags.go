@@ -0,0 +1,63 @@
+package keeper
+
+import (
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+)
+
+// SetWrappedAssetFlags set a specific wrappedAssetFlags in the store from its index
+func (k Keeper) SetWrappedAssetFlags(ctx sdk.Context, wrappedAssetFlags types.WrappedAssetFlags) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.WrappedAssetFlagsKeyPrefix))
+	b := k.cdc.MustMarshal(&wrappedAssetFlags)
+	store.Set(types.WrappedAssetFlagsKey(
+		wrappedAssetFlags.Denom,
+	), b)
+}
+
+// GetWrappedAssetFlags returns a wrappedAssetFlags from its index
+func (k Keeper) GetWrappedAssetFlags(
+	ctx sdk.Context,
+	denom string,
+
+) (val types.WrappedAssetFlags, found bool) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.WrappedAssetFlagsKeyPrefix))
+
+	b := store.Get(types.WrappedAssetFlagsKey(
+		denom,
+	))
+	if b == nil {
+		return val, false
+	}
+
+	k.cdc.MustUnmarshal(b, &val)
+	return val, true
+}
+
+// RemoveWrappedAssetFlags removes a wrappedAssetFlags from the store
+func (k Keeper) RemoveWrappedAssetFlags(
+	ctx sdk.Context,
+	denom string,
+
+) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.WrappedAssetFlagsKeyPrefix))
+	store.Delete(types.WrappedAssetFlagsKey(
+		denom,
+	))
+}
+
+// GetAllWrappedAssetFlags returns all wrappedAssetFlags
+func (k Keeper) GetAllWrappedAssetFlags(ctx sdk.Context) (list []types.WrappedAssetFlags) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.WrappedAssetFlagsKeyPrefix))
+	iterator := sdk.KVStorePrefixIterator(store, []byte{})
+
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var val types.WrappedAssetFlags
+		k.cdc.MustUnmarshal(iterator.Value(), &val)
+		list = append(list, val)
+	}
+
+	return
+}
@@ -0,0 +1,63 @@
+package keeper
+
+import (
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+)
+
+// SetWrappedAssetLocalization set a specific wrappedAssetLocalization in the store from its index
+func (k Keeper) SetWrappedAssetLocalization(ctx sdk.Context, wrappedAssetLocalization types.WrappedAssetLocalization) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.WrappedAssetLocalizationKeyPrefix))
+	b := k.cdc.MustMarshal(&wrappedAssetLocalization)
+	store.Set(types.WrappedAssetLocalizationKey(
+		wrappedAssetLocalization.Denom,
+	), b)
+}
+
+// GetWrappedAssetLocalization returns a wrappedAssetLocalization from its index
+func (k Keeper) GetWrappedAssetLocalization(
+	ctx sdk.Context,
+	denom string,
+
+) (val types.WrappedAssetLocalization, found bool) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.WrappedAssetLocalizationKeyPrefix))
+
+	b := store.Get(types.WrappedAssetLocalizationKey(
+		denom,
+	))
+	if b == nil {
+		return val, false
+	}
+
+	k.cdc.MustUnmarshal(b, &val)
+	return val, true
+}
+
+// RemoveWrappedAssetLocalization removes a wrappedAssetLocalization from the store
+func (k Keeper) RemoveWrappedAssetLocalization(
+	ctx sdk.Context,
+	denom string,
+
+) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.WrappedAssetLocalizationKeyPrefix))
+	store.Delete(types.WrappedAssetLocalizationKey(
+		denom,
+	))
+}
+
+// GetAllWrappedAssetLocalization returns all wrappedAssetLocalization
+func (k Keeper) GetAllWrappedAssetLocalization(ctx sdk.Context) (list []types.WrappedAssetLocalization) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.WrappedAssetLocalizationKeyPrefix))
+	iterator := sdk.KVStorePrefixIterator(store, []byte{})
+
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var val types.WrappedAssetLocalization
+		k.cdc.MustUnmarshal(iterator.Value(), &val)
+		list = append(list, val)
+	}
+
+	return
+}
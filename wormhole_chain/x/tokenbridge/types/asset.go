@@ -128,6 +128,19 @@ func GetWrappedCoinMeta(identifier string) (tokenChain uint16, tokenAddress [32]
 	return tokenChain, tokenAddress, true
 }
 
+// CanonicalWrappedDenom returns the base denom of the wrapped asset denom refers to in the current format, and whether
+// denom is in a legacy format instead. Early testnets minted wrapped assets under their display denom, without the "b"
+// prefix, and GetWrappedCoinMeta also accepts token addresses in upper-case hex. WORM is never wrapped under a legacy
+// denom.
+func CanonicalWrappedDenom(denom string) (canonical string, legacy bool) {
+	tokenChain, tokenAddress, wrapped := GetWrappedCoinMeta(denom)
+	if !wrapped || IsWORMToken(tokenChain, tokenAddress) {
+		return "", false
+	}
+	canonical = "b" + GetWrappedCoinIdentifier(tokenChain, tokenAddress)
+	return canonical, canonical != denom
+}
+
 // Get the token chain and address. For wrapped assets, this is the address on
 // the original chain, for native assets, it's the (left-padded) display name.
 func GetTokenMeta(config whtypes.Config, identifier string) (chainId uint16, tokenAddress [32]byte, err error) {
@@ -144,10 +157,13 @@ func GetTokenMeta(config whtypes.Config, identifier string) (chainId uint16, tok
 	}
 }
 
+// MaxNativeDenomLength is the longest native denom that fits into a token address.
+const MaxNativeDenomLength = 32
+
 // PadStringToByte32 left zero pads a string to the ethereum type bytes32
 func PadStringToByte32(s string) (padded [32]byte, err error) {
-	if len(s) > 32 {
-		return [32]byte{}, fmt.Errorf("string is too long; %d > 32", len(s))
+	if len(s) > MaxNativeDenomLength {
+		return [32]byte{}, fmt.Errorf("string is too long; %d > %d", len(s), MaxNativeDenomLength)
 	}
 
 	b := []byte(s)
@@ -156,3 +172,21 @@ func PadStringToByte32(s string) (padded [32]byte, err error) {
 	copy(padded[:], append(left[:], b[:]...))
 	return padded, nil
 }
+
+// DenomFromTokenAddress recovers the denom of a native coin from its token
+// address. This is the inverse of PadStringToByte32, so the address must be
+// exactly a valid denom, left-padded with zeros.
+func DenomFromTokenAddress(tokenAddress [32]byte) (string, error) {
+	denom := strings.TrimLeft(string(tokenAddress[:]), "\x00")
+	if strings.ContainsRune(denom, 0) {
+		return "", fmt.Errorf("%w: token address contains embedded zero bytes", ErrInvalidNativeDenom)
+	}
+	if err := sdk.ValidateDenom(denom); err != nil {
+		return "", fmt.Errorf("%w: %s", ErrInvalidNativeDenom, err)
+	}
+	// Wrapped assets are minted, never held as native coins.
+	if _, _, wrapped := GetWrappedCoinMeta(denom); wrapped {
+		return "", fmt.Errorf("%w: %s is a wrapped asset", ErrInvalidNativeDenom, denom)
+	}
+	return denom, nil
+}
@@ -3,6 +3,7 @@ package types
 import (
 	"encoding/hex"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -106,3 +107,74 @@ func TestGetWrappedCoinMeta(t *testing.T) {
 		})
 	}
 }
+
+func TestCanonicalWrappedDenom(t *testing.T) {
+	lower := hex.EncodeToString(testToken[:])
+	upper := strings.ToUpper(lower)
+	tests := []struct {
+		name      string
+		denom     string
+		canonical string
+		legacy    bool
+	}{
+		{name: "canonical", denom: "bwh/00001/" + lower, canonical: "bwh/00001/" + lower, legacy: false},
+		{name: "without b prefix", denom: "wh/00001/" + lower, canonical: "bwh/00001/" + lower, legacy: true},
+		{name: "upper-case address", denom: "bwh/00001/" + upper, canonical: "bwh/00001/" + lower, legacy: true},
+		{name: "upper-case address without b prefix", denom: "wh/00001/" + upper, canonical: "bwh/00001/" + lower, legacy: true},
+		{name: "uworm", denom: "uworm", legacy: false},
+		{name: "uworm address", denom: "wh/00001/" + hex.EncodeToString(uworm[:]), legacy: false},
+		{name: "native", denom: "uatom", legacy: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			canonical, legacy := CanonicalWrappedDenom(tt.denom)
+			require.Equal(t, tt.canonical, canonical)
+			require.Equal(t, tt.legacy, legacy)
+		})
+	}
+}
+
+func TestDenomFromTokenAddress(t *testing.T) {
+	pad := func(b []byte) [32]byte {
+		var out [32]byte
+		copy(out[32-len(b):], b)
+		return out
+	}
+
+	tests := []struct {
+		name         string
+		tokenAddress [32]byte
+		denom        string
+		valid        bool
+	}{
+		{name: "native denom", tokenAddress: pad([]byte("uatom")), denom: "uatom", valid: true},
+		{name: "denom with path separators", tokenAddress: pad([]byte("factory/x/y-1")), denom: "factory/x/y-1", valid: true},
+		{name: "full length denom", tokenAddress: pad([]byte("abcdefghijklmnopqrstuvwxyz012345")), denom: "abcdefghijklmnopqrstuvwxyz012345", valid: true},
+		{name: "empty", tokenAddress: [32]byte{}, valid: false},
+		{name: "too short", tokenAddress: pad([]byte("ab")), valid: false},
+		{name: "embedded zero byte", tokenAddress: pad([]byte("uat\x00om")), valid: false},
+		{name: "trailing zero byte", tokenAddress: pad([]byte("uatom\x00")), valid: false},
+		{name: "coin list separator", tokenAddress: pad([]byte("uatom,1000uworm")), valid: false},
+		{name: "whitespace", tokenAddress: pad([]byte("uatom 1")), valid: false},
+		{name: "leading digit", tokenAddress: pad([]byte("1uatom")), valid: false},
+		{name: "non-ascii", tokenAddress: pad([]byte("uatöm")), valid: false},
+		{name: "wrapped wormhole token", tokenAddress: pad([]byte("uworm")), valid: false},
+		{name: "wormhole token address", tokenAddress: uworm, valid: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			denom, err := DenomFromTokenAddress(tt.tokenAddress)
+			if !tt.valid {
+				require.ErrorIs(t, err, ErrInvalidNativeDenom)
+				return
+			}
+			require.NoError(t, err)
+			require.EqualValues(t, tt.denom, denom)
+
+			// Round-trips to the same token address
+			padded, err := PadStringToByte32(denom)
+			require.NoError(t, err)
+			require.EqualValues(t, tt.tokenAddress, padded)
+		})
+	}
+}
@@ -10,8 +10,13 @@ import (
 func RegisterCodec(cdc *codec.LegacyAmino) {
 	cdc.RegisterConcrete(&MsgExecuteGovernanceVAA{}, "tokenbridge/ExecuteGovernanceVAA", nil)
 	cdc.RegisterConcrete(&MsgExecuteVAA{}, "tokenbridge/ExecuteVAA", nil)
+	cdc.RegisterConcrete(&MsgExecuteVAAs{}, "tokenbridge/ExecuteVAAs", nil)
 	cdc.RegisterConcrete(&MsgAttestToken{}, "tokenbridge/AttestToken", nil)
 	cdc.RegisterConcrete(&MsgTransfer{}, "tokenbridge/Transfer", nil)
+	cdc.RegisterConcrete(&MsgCancelTransfer{}, "tokenbridge/CancelTransfer", nil)
+	cdc.RegisterConcrete(&MsgCompleteEscrowedTransfer{}, "tokenbridge/CompleteEscrowedTransfer", nil)
+	cdc.RegisterConcrete(&MsgRegisterRelayer{}, "tokenbridge/RegisterRelayer", nil)
+	cdc.RegisterConcrete(&MsgDeregisterRelayer{}, "tokenbridge/DeregisterRelayer", nil)
 	// this line is used by starport scaffolding # 2
 }
 
@@ -21,6 +26,7 @@ func RegisterInterfaces(registry cdctypes.InterfaceRegistry) {
 	)
 	registry.RegisterImplementations((*sdk.Msg)(nil),
 		&MsgExecuteVAA{},
+		&MsgExecuteVAAs{},
 	)
 	registry.RegisterImplementations((*sdk.Msg)(nil),
 		&MsgAttestToken{},
@@ -28,6 +34,16 @@ func RegisterInterfaces(registry cdctypes.InterfaceRegistry) {
 	registry.RegisterImplementations((*sdk.Msg)(nil),
 		&MsgTransfer{},
 	)
+	registry.RegisterImplementations((*sdk.Msg)(nil),
+		&MsgCancelTransfer{},
+	)
+	registry.RegisterImplementations((*sdk.Msg)(nil),
+		&MsgCompleteEscrowedTransfer{},
+	)
+	registry.RegisterImplementations((*sdk.Msg)(nil),
+		&MsgRegisterRelayer{},
+		&MsgDeregisterRelayer{},
+	)
 	// this line is used by starport scaffolding # 3
 
 	msgservice.RegisterMsgServiceDesc(registry, &_Msg_serviceDesc)
@@ -0,0 +1,23 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Validate checks that the fee account of the config is a sub-account of the module other than the gateway, and that
+// the registration bounty is a valid coin.
+func (c Config) Validate() error {
+	if c.RegistrationBounty != nil {
+		if err := c.RegistrationBounty.Validate(); err != nil {
+			return fmt.Errorf("invalid registration bounty: %w", err)
+		}
+	}
+	if c.FeeAccountName == "" {
+		return nil
+	}
+	if !strings.HasPrefix(c.FeeAccountName, ModuleName+"/") || c.FeeAccountName == ModuleName+"/" || c.FeeAccountName == GatewayAccountName {
+		return fmt.Errorf("%w: %s", ErrInvalidFeeAccountName, c.FeeAccountName)
+	}
+	return nil
+}
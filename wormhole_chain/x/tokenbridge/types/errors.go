@@ -31,11 +31,41 @@ var (
 	ErrInvalidToAddress               = sdkerrors.Register(ModuleName, 1128, "to address is invalid (must be 32 bytes)")
 	ErrInvalidFee                     = sdkerrors.Register(ModuleName, 1130, "fee is invalid (must fit in uint256)")
 	ErrInvalidAmount                  = sdkerrors.Register(ModuleName, 1131, "amount is invalid (must fit in uint256)")
-	ErrFeeTooHigh                     = sdkerrors.Register(ModuleName, 1132, "fee must be < amount")
+	ErrFeeTooHigh                     = sdkerrors.Register(ModuleName, 1132, "fee must be <= amount")
 	ErrAmountTooHigh                  = sdkerrors.Register(ModuleName, 1133, "the amount would exceed the bridges capacity of u64")
 	ErrAssetMetaRollback              = sdkerrors.Register(ModuleName, 1134, "asset meta must have a higher sequence than the last update")
 	ErrNegativeFee                    = sdkerrors.Register(ModuleName, 1135, "fee cannot be negative")
 	ErrRegisterWormholeChain          = sdkerrors.Register(ModuleName, 1136, "cannot register an emitter for wormhole-chain on wormhole-chain")
 	ErrChangeDecimals                 = sdkerrors.Register(ModuleName, 1137, "cannot change decimals of registered asset metadata")
 	ErrUnregisteredChain              = sdkerrors.Register(ModuleName, 1138, "chain is not registered")
+	ErrNoFeeRecipient                 = sdkerrors.Register(ModuleName, 1139, "transfer has a nonzero fee but no valid tx sender to pay it to")
+	ErrZeroAmount                     = sdkerrors.Register(ModuleName, 1140, "transfer amount must be greater than zero")
+	ErrInvalidNativeDenom             = sdkerrors.Register(ModuleName, 1141, "token address is not a valid native denom")
+	ErrInsufficientCustody            = sdkerrors.Register(ModuleName, 1142, "redemption exceeds the custody balance of the native asset")
+	ErrInvalidUpgradeName             = sdkerrors.Register(ModuleName, 1143, "contract upgrade does not name a valid upgrade")
+	ErrInsufficientFees               = sdkerrors.Register(ModuleName, 1144, "fee transfer exceeds the balance of the fee account")
+	ErrInvalidFeeRecipient            = sdkerrors.Register(ModuleName, 1145, "fee recipient must be a 20 byte address left-padded with zeros")
+	ErrEmptyVAA                       = sdkerrors.Register(ModuleName, 1146, "VAA is empty")
+	ErrInvalidDenom                   = sdkerrors.Register(ModuleName, 1147, "denom is invalid")
+	ErrGatewayDisabled                = sdkerrors.Register(ModuleName, 1148, "the IBC gateway is not enabled")
+	ErrInvalidGatewayRecipient        = sdkerrors.Register(ModuleName, 1149, "transfer with payload must be sent to the gateway account")
+	ErrInvalidGatewayPayload          = sdkerrors.Register(ModuleName, 1150, "invalid gateway payload")
+	ErrUnknownIbcChain                = sdkerrors.Register(ModuleName, 1151, "no IBC channel is registered for the chain")
+	ErrInvalidIbcChannel              = sdkerrors.Register(ModuleName, 1152, "invalid IBC chain or channel identifier")
+	ErrUnknownPendingTransfer         = sdkerrors.Register(ModuleName, 1153, "no pending transfer with this ID")
+	ErrNotTransferSender              = sdkerrors.Register(ModuleName, 1154, "only the sender of a transfer can cancel it")
+	ErrCancelWindowClosed             = sdkerrors.Register(ModuleName, 1155, "the cancel window of the transfer has closed")
+	ErrNoFeesToSweep                  = sdkerrors.Register(ModuleName, 1156, "the module account holds no fees of the denom")
+	ErrInvalidFeeAccountName          = sdkerrors.Register(ModuleName, 1157, "invalid fee account name")
+	ErrUnknownEscrowedTransfer        = sdkerrors.Register(ModuleName, 1158, "no escrowed transfer with this digest")
+	ErrEscrowedTransferExpired        = sdkerrors.Register(ModuleName, 1159, "the escrowed transfer has expired")
+	ErrInvalidRelayerMetadata         = sdkerrors.Register(ModuleName, 1160, "invalid relayer endpoint or description")
+	ErrUnknownRelayer                 = sdkerrors.Register(ModuleName, 1161, "the relayer is not registered")
+	ErrInvalidWrappedAssetFlags       = sdkerrors.Register(ModuleName, 1162, "invalid wrapped asset flags")
+	ErrBlockedRecipient               = sdkerrors.Register(ModuleName, 1163, "the recipient is not allowed to receive funds")
+	ErrInvalidAssetLocalization       = sdkerrors.Register(ModuleName, 1164, "invalid wrapped asset localization")
+	ErrInvalidRedeemer                = sdkerrors.Register(ModuleName, 1165, "only the recipient of a transfer with payload can redeem it")
+	ErrInvalidPayloadRecipient        = sdkerrors.Register(ModuleName, 1166, "recipient of a transfer with payload must be a 20 byte address left-padded with zeros")
+	ErrTooManyVAAs                    = sdkerrors.Register(ModuleName, 1167, "too many VAAs in the batch")
+	ErrEmitterNotBound                = sdkerrors.Register(ModuleName, 1168, "the token bridge emitter is not bound")
 )
@@ -0,0 +1,53 @@
+package types
+
+// Tendermint event types and attribute keys emitted by the token bridge in addition to its typed events. The keys are
+// part of the module's API and must not change. Unlike the attributes of typed events, whose values are JSON encoded,
+// values are plain strings, so clients can match them in event queries, e.g.
+//
+//	tm.event='Tx' AND transfer_redeemed.recipient='wormhole1...'
+//
+// See docs/events.md.
+const (
+	// EventTypeTransferRedeemed is emitted when a transfer VAA is redeemed on this chain.
+	EventTypeTransferRedeemed = "transfer_redeemed"
+	// EventTypeRedemptionPayout is emitted for each bank transfer paying out a redeemed transfer, in the order of the
+	// transfers.
+	EventTypeRedemptionPayout = "redemption_payout"
+	// EventTypeTransferWithPayloadRedeemed is emitted when a transfer with payload is paid out to its recipient on
+	// this chain. Transfers forwarded by the gateway emit EventGatewayTransfer instead.
+	EventTypeTransferWithPayloadRedeemed = "transfer_with_payload_redeemed"
+
+	// AttributeKeyVAADigest is the hex encoded digest of the redeemed VAA.
+	AttributeKeyVAADigest = "vaa_digest"
+	// AttributeKeyEmitterChain and AttributeKeyEmitterAddress identify the emitter of the VAA. The chain is a decimal
+	// wormhole chain ID and the address is hex encoded.
+	AttributeKeyEmitterChain   = "emitter_chain"
+	AttributeKeyEmitterAddress = "emitter_address"
+	// AttributeKeySequence is the decimal sequence of the VAA.
+	AttributeKeySequence = "sequence"
+	// AttributeKeyRecipient is the bech32 address that received the transferred coins.
+	AttributeKeyRecipient = "recipient"
+	// AttributeKeyDenom, AttributeKeyAmount and AttributeKeyFee describe the redeemed coins. The fee is part of the
+	// amount and was paid to the tx sender.
+	AttributeKeyDenom  = "denom"
+	AttributeKeyAmount = "amount"
+	AttributeKeyFee    = "fee"
+	// AttributeKeyCorrelationID is shared by all events of one redemption. It is the hex encoded digest of the VAA.
+	AttributeKeyCorrelationID = "correlation_id"
+	// AttributeKeyPayouts is the decimal number of bank transfers paying out a redemption.
+	AttributeKeyPayouts = "payouts"
+	// AttributeKeyPayoutIndex is the decimal position of a payout among the bank transfers of its redemption,
+	// starting at 0, and AttributeKeyPayoutKind tells whether it pays out the principal or the fee.
+	AttributeKeyPayoutIndex = "index"
+	AttributeKeyPayoutKind  = "kind"
+	// AttributeKeySender is the hex encoded 32-byte sender of a transfer with payload on its origin chain, and
+	// AttributeKeyPayload its hex encoded application payload.
+	AttributeKeySender  = "sender"
+	AttributeKeyPayload = "payload"
+)
+
+// Kinds of payouts of a redemption. The principal, if any, is always paid out before the fee.
+const (
+	PayoutKindPrincipal = "principal"
+	PayoutKindFee       = "fee"
+)
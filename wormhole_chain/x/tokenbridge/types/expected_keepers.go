@@ -3,6 +3,9 @@ package types
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	btypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	capabilitytypes "github.com/cosmos/cosmos-sdk/x/capability/types"
+	upgradetypes "github.com/cosmos/cosmos-sdk/x/upgrade/types"
+	clienttypes "github.com/cosmos/ibc-go/v3/modules/core/02-client/types"
 	"github.com/wormhole-foundation/wormhole-chain/x/wormhole/types"
 	"github.com/wormhole-foundation/wormhole/sdk/vaa"
 )
@@ -17,15 +20,45 @@ type BankKeeper interface {
 	MintCoins(ctx sdk.Context, moduleName string, amt sdk.Coins) error
 	BurnCoins(ctx sdk.Context, moduleName string, amt sdk.Coins) error
 	SendCoins(ctx sdk.Context, fromAddr sdk.AccAddress, toAddr sdk.AccAddress, amt sdk.Coins) error
+	InputOutputCoins(ctx sdk.Context, inputs []btypes.Input, outputs []btypes.Output) error
 	SetDenomMetaData(ctx sdk.Context, denomMetaData btypes.Metadata)
 	GetDenomMetaData(ctx sdk.Context, denom string) (denomMetaData btypes.Metadata, found bool)
 	GetBalance(ctx sdk.Context, addr sdk.AccAddress, denom string) sdk.Coin
+	GetAllBalances(ctx sdk.Context, addr sdk.AccAddress) sdk.Coins
+	IterateAllBalances(ctx sdk.Context, cb func(address sdk.AccAddress, coin sdk.Coin) (stop bool))
+	IterateTotalSupply(ctx sdk.Context, cb func(sdk.Coin) bool)
+	IterateAllDenomMetaData(ctx sdk.Context, cb func(btypes.Metadata) bool)
+	BlockedAddr(addr sdk.AccAddress) bool
 }
 
 type WormholeKeeper interface {
 	// Methods imported from wormhole should be defined here
 	VerifyVAA(ctx sdk.Context, vaa *vaa.VAA) error
-	VerifyGovernanceVAA(ctx sdk.Context, v *vaa.VAA, module [32]byte) (action byte, payload []byte, err error)
+	VerifyGovernanceVAA(ctx sdk.Context, v *vaa.VAA, module [32]byte, msgType string) (action byte, targetChain uint16, payload []byte, err error)
 	GetConfig(ctx sdk.Context) (val types.Config, found bool)
-	PostMessage(ctx sdk.Context, emitter types.EmitterAddress, nonce uint32, data []byte) error
+	BindEmitter(ctx sdk.Context, emitter types.EmitterAddress) (*capabilitytypes.Capability, error)
+	PostMessage(ctx sdk.Context, capability *capabilitytypes.Capability, emitter types.EmitterAddress, payer sdk.AccAddress, nonce uint32, data []byte) error
+	GetSequenceCounter(ctx sdk.Context, index string) (val types.SequenceCounter, found bool)
+	CountVAAExecutions(ctx sdk.Context, sender sdk.AccAddress, n uint32) error
+}
+
+type UpgradeKeeper interface {
+	// Methods imported from upgrade should be defined here
+	ScheduleUpgrade(ctx sdk.Context, plan upgradetypes.Plan) error
+}
+
+type ScopedKeeper interface {
+	// Methods imported from the module's capability scope should be defined here
+	GetCapability(ctx sdk.Context, name string) (*capabilitytypes.Capability, bool)
+	ClaimCapability(ctx sdk.Context, cap *capabilitytypes.Capability, name string) error
+}
+
+type TransferKeeper interface {
+	// Methods imported from ibc transfer should be defined here
+	SendTransfer(ctx sdk.Context, sourcePort, sourceChannel string, token sdk.Coin, sender sdk.AccAddress, receiver string, timeoutHeight clienttypes.Height, timeoutTimestamp uint64) error
+}
+
+type ChannelKeeper interface {
+	// Methods imported from ibc channel should be defined here
+	GetNextSequenceSend(ctx sdk.Context, portID, channelID string) (uint64, bool)
 }
@@ -14,6 +14,18 @@ func DefaultGenesis() *GenesisState {
 		ReplayProtectionList:           []ReplayProtection{},
 		ChainRegistrationList:          []ChainRegistration{},
 		CoinMetaRollbackProtectionList: []CoinMetaRollbackProtection{},
+		CustodyBalanceList:             []CustodyBalance{},
+		IbcChannelList:                 []IbcChannel{},
+		GatewayTransferList:            []GatewayTransfer{},
+		AttestedAssetMetaList:          []AttestedAssetMeta{},
+		PendingTransferList:            []PendingTransfer{},
+		RegistrationBountyList:         []RegistrationBounty{},
+		EscrowedTransferList:           []EscrowedTransfer{},
+		RelayerList:                    []Relayer{},
+		WrappedAssetFlagsList:          []WrappedAssetFlags{},
+		WrappedAssetLocalizationList:   []WrappedAssetLocalization{},
+		LedgerEntryList:                []LedgerEntry{},
+		LedgerBalanceList:              []LedgerBalance{},
 		// this line is used by starport scaffolding # genesis/types/default
 	}
 }
@@ -21,6 +33,11 @@ func DefaultGenesis() *GenesisState {
 // Validate performs basic genesis state validation returning an error upon any
 // failure.
 func (gs GenesisState) Validate() error {
+	if gs.Config != nil {
+		if err := gs.Config.Validate(); err != nil {
+			return err
+		}
+	}
 	// Check for duplicated index in replayProtection
 	replayProtectionIndexMap := make(map[string]struct{})
 
@@ -51,6 +68,145 @@ func (gs GenesisState) Validate() error {
 		}
 		coinMetaRollbackProtectionIndexMap[index] = struct{}{}
 	}
+	// Check for duplicated index in custodyBalance
+	custodyBalanceIndexMap := make(map[string]struct{})
+
+	for _, elem := range gs.CustodyBalanceList {
+		index := string(CustodyBalanceKey(elem.Denom))
+		if _, ok := custodyBalanceIndexMap[index]; ok {
+			return fmt.Errorf("duplicated index for custodyBalance")
+		}
+		custodyBalanceIndexMap[index] = struct{}{}
+
+		if elem.Amount.IsNil() || elem.Amount.IsNegative() {
+			return fmt.Errorf("invalid custody balance for %s", elem.Denom)
+		}
+	}
+	// Check for duplicated index in ibcChannel
+	ibcChannelIndexMap := make(map[string]struct{})
+
+	for _, elem := range gs.IbcChannelList {
+		index := string(IbcChannelKey(elem.ChainID))
+		if _, ok := ibcChannelIndexMap[index]; ok {
+			return fmt.Errorf("duplicated index for ibcChannel")
+		}
+		ibcChannelIndexMap[index] = struct{}{}
+	}
+	// Check for duplicated index in gatewayTransfer
+	gatewayTransferIndexMap := make(map[string]struct{})
+
+	for _, elem := range gs.GatewayTransferList {
+		index := string(GatewayTransferKey(elem.ChannelID, elem.Sequence))
+		if _, ok := gatewayTransferIndexMap[index]; ok {
+			return fmt.Errorf("duplicated index for gatewayTransfer")
+		}
+		gatewayTransferIndexMap[index] = struct{}{}
+	}
+	// Check for duplicated index in attestedAssetMeta
+	attestedAssetMetaIndexMap := make(map[string]struct{})
+
+	for _, elem := range gs.AttestedAssetMetaList {
+		index := string(AttestedAssetMetaKey(elem.Denom))
+		if _, ok := attestedAssetMetaIndexMap[index]; ok {
+			return fmt.Errorf("duplicated index for attestedAssetMeta")
+		}
+		attestedAssetMetaIndexMap[index] = struct{}{}
+	}
+	// Check for duplicated ID in pendingTransfer
+	pendingTransferIdMap := make(map[uint64]bool)
+
+	for _, elem := range gs.PendingTransferList {
+		if _, ok := pendingTransferIdMap[elem.Id]; ok {
+			return fmt.Errorf("duplicated id for pendingTransfer")
+		}
+		if elem.Id == 0 || elem.Id > gs.PendingTransferCount {
+			return fmt.Errorf("pendingTransfer id should be between 1 and pendingTransferCount")
+		}
+		pendingTransferIdMap[elem.Id] = true
+	}
+	// Check for duplicated index in registrationBounty
+	registrationBountyIndexMap := make(map[string]struct{})
+
+	for _, elem := range gs.RegistrationBountyList {
+		index := string(RegistrationBountyKey(elem.Denom))
+		if _, ok := registrationBountyIndexMap[index]; ok {
+			return fmt.Errorf("duplicated index for registrationBounty")
+		}
+		registrationBountyIndexMap[index] = struct{}{}
+	}
+	// Check for duplicated index in escrowedTransfer
+	escrowedTransferIndexMap := make(map[string]struct{})
+
+	for _, elem := range gs.EscrowedTransferList {
+		index := string(EscrowedTransferKey(elem.Digest))
+		if _, ok := escrowedTransferIndexMap[index]; ok {
+			return fmt.Errorf("duplicated index for escrowedTransfer")
+		}
+		escrowedTransferIndexMap[index] = struct{}{}
+	}
+	// Check for duplicated index in relayer
+	relayerIndexMap := make(map[string]struct{})
+
+	for _, elem := range gs.RelayerList {
+		index := string(RelayerKey(elem.Address))
+		if _, ok := relayerIndexMap[index]; ok {
+			return fmt.Errorf("duplicated index for relayer")
+		}
+		relayerIndexMap[index] = struct{}{}
+	}
+	// Check for duplicated index in wrappedAssetFlags
+	wrappedAssetFlagsIndexMap := make(map[string]struct{})
+
+	for _, elem := range gs.WrappedAssetFlagsList {
+		if err := elem.Validate(); err != nil {
+			return err
+		}
+		index := string(WrappedAssetFlagsKey(elem.Denom))
+		if _, ok := wrappedAssetFlagsIndexMap[index]; ok {
+			return fmt.Errorf("duplicated index for wrappedAssetFlags")
+		}
+		wrappedAssetFlagsIndexMap[index] = struct{}{}
+	}
+	// Check for duplicated index in wrappedAssetLocalization
+	wrappedAssetLocalizationIndexMap := make(map[string]struct{})
+
+	for _, elem := range gs.WrappedAssetLocalizationList {
+		if err := elem.Validate(); err != nil {
+			return err
+		}
+		index := string(WrappedAssetLocalizationKey(elem.Denom))
+		if _, ok := wrappedAssetLocalizationIndexMap[index]; ok {
+			return fmt.Errorf("duplicated index for wrappedAssetLocalization")
+		}
+		wrappedAssetLocalizationIndexMap[index] = struct{}{}
+	}
+	// Check for duplicated ID in ledgerEntry
+	ledgerEntryIdMap := make(map[uint64]bool)
+	for _, elem := range gs.LedgerEntryList {
+		if _, ok := ledgerEntryIdMap[elem.Id]; ok {
+			return fmt.Errorf("duplicated id for ledgerEntry")
+		}
+		if elem.Id == 0 || elem.Id > gs.LedgerEntryCount {
+			return fmt.Errorf("ledgerEntry id should be between 1 and ledgerEntryCount")
+		}
+		if err := elem.Amount.Validate(); err != nil {
+			return fmt.Errorf("invalid amount of ledgerEntry %d: %w", elem.Id, err)
+		}
+		ledgerEntryIdMap[elem.Id] = true
+	}
+	// Check for duplicated index in ledgerBalance
+	ledgerBalanceIndexMap := make(map[string]struct{})
+
+	for _, elem := range gs.LedgerBalanceList {
+		if elem.Debits.IsNil() || elem.Debits.IsNegative() || elem.Credits.IsNil() || elem.Credits.IsNegative() {
+			return fmt.Errorf("ledgerBalance of %s in %s must not be negative", elem.Account, elem.Denom)
+		}
+		index := string(LedgerBalanceKey(elem.Account, elem.Denom))
+		if _, ok := ledgerBalanceIndexMap[index]; ok {
+			return fmt.Errorf("duplicated index for ledgerBalance")
+		}
+		ledgerBalanceIndexMap[index] = struct{}{}
+	}
 	// this line is used by starport scaffolding # genesis/types/validate
 
 	return nil
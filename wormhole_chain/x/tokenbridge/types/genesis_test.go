@@ -3,11 +3,24 @@ package types_test
 import (
 	"testing"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/stretchr/testify/require"
 	"github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
 )
 
 func TestGenesisState_Validate(t *testing.T) {
+	flags, err := types.NewWrappedAssetFlags(2, [32]byte{1}, types.WrappedAssetFlagRebasing)
+	require.NoError(t, err)
+	mismatched := flags
+	mismatched.TokenChain = 3
+	localization, err := types.ParseWrappedAssetLocalization(types.WrappedAssetLocalization{
+		TokenChain:     2,
+		TokenAddress:   flags.TokenAddress,
+		AssetListId:    "weth",
+		LocalizedNames: []types.LocalizedName{{Locale: "en", Name: "Wrapped Ether"}},
+	}.Serialize())
+	require.NoError(t, err)
+
 	for _, tc := range []struct {
 		desc     string
 		genState *types.GenesisState
@@ -21,7 +34,7 @@ func TestGenesisState_Validate(t *testing.T) {
 		{
 			desc: "valid genesis state",
 			genState: &types.GenesisState{
-				Config: &types.Config{},
+				Config: &types.Config{FeeAccountName: "tokenbridge/protocol-fees"},
 				ReplayProtectionList: []types.ReplayProtection{
 					{
 						Index: "0",
@@ -46,6 +59,16 @@ func TestGenesisState_Validate(t *testing.T) {
 						Index: "1",
 					},
 				},
+				CustodyBalanceList: []types.CustodyBalance{
+					{
+						Denom:  "uatom",
+						Amount: sdk.NewInt(1),
+					},
+					{
+						Denom:  "uosmo",
+						Amount: sdk.NewInt(2),
+					},
+				},
 				// this line is used by starport scaffolding # types/genesis/validField
 			},
 			valid: true,
@@ -92,6 +115,250 @@ func TestGenesisState_Validate(t *testing.T) {
 			},
 			valid: false,
 		},
+		{
+			desc: "duplicated custodyBalance",
+			genState: &types.GenesisState{
+				CustodyBalanceList: []types.CustodyBalance{
+					{
+						Denom:  "uatom",
+						Amount: sdk.NewInt(1),
+					},
+					{
+						Denom:  "uatom",
+						Amount: sdk.NewInt(1),
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			desc: "negative custodyBalance",
+			genState: &types.GenesisState{
+				CustodyBalanceList: []types.CustodyBalance{
+					{
+						Denom:  "uatom",
+						Amount: sdk.NewInt(-1),
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			desc: "duplicated ibcChannel",
+			genState: &types.GenesisState{
+				IbcChannelList: []types.IbcChannel{
+					{
+						ChainID:   "osmosis-1",
+						ChannelID: "channel-0",
+					},
+					{
+						ChainID:   "osmosis-1",
+						ChannelID: "channel-1",
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			desc: "duplicated gatewayTransfer",
+			genState: &types.GenesisState{
+				GatewayTransferList: []types.GatewayTransfer{
+					{
+						ChannelID: "channel-0",
+						Sequence:  1,
+					},
+					{
+						ChannelID: "channel-0",
+						Sequence:  1,
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			desc: "duplicated attestedAssetMeta",
+			genState: &types.GenesisState{
+				AttestedAssetMetaList: []types.AttestedAssetMeta{
+					{
+						Denom: "uatom",
+					},
+					{
+						Denom: "uatom",
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			desc: "duplicated pendingTransfer",
+			genState: &types.GenesisState{
+				PendingTransferList: []types.PendingTransfer{
+					{
+						Id: 1,
+					},
+					{
+						Id: 1,
+					},
+				},
+				PendingTransferCount: 2,
+			},
+			valid: false,
+		},
+		{
+			desc: "invalid pendingTransfer count",
+			genState: &types.GenesisState{
+				PendingTransferList: []types.PendingTransfer{
+					{
+						Id: 1,
+					},
+					{
+						Id: 2,
+					},
+				},
+				PendingTransferCount: 1,
+			},
+			valid: false,
+		},
+		{
+			desc: "fee account name outside of the module",
+			genState: &types.GenesisState{
+				Config: &types.Config{FeeAccountName: "distribution"},
+			},
+			valid: false,
+		},
+		{
+			desc: "gateway as fee account",
+			genState: &types.GenesisState{
+				Config: &types.Config{FeeAccountName: types.GatewayAccountName},
+			},
+			valid: false,
+		},
+		{
+			desc: "invalid registration bounty",
+			genState: &types.GenesisState{
+				Config: &types.Config{RegistrationBounty: &sdk.Coin{Denom: "uworm", Amount: sdk.NewInt(-1)}},
+			},
+			valid: false,
+		},
+		{
+			desc: "duplicated registrationBounty",
+			genState: &types.GenesisState{
+				RegistrationBountyList: []types.RegistrationBounty{
+					{
+						Denom: "bwrapped",
+					},
+					{
+						Denom: "bwrapped",
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			desc: "duplicated escrowedTransfer",
+			genState: &types.GenesisState{
+				EscrowedTransferList: []types.EscrowedTransfer{
+					{
+						Digest: "0",
+					},
+					{
+						Digest: "0",
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			desc: "duplicated relayer",
+			genState: &types.GenesisState{
+				RelayerList: []types.Relayer{
+					{
+						Address: "0",
+					},
+					{
+						Address: "0",
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			desc:     "wrapped asset flags",
+			genState: &types.GenesisState{WrappedAssetFlagsList: []types.WrappedAssetFlags{flags}},
+			valid:    true,
+		},
+		{
+			desc:     "duplicated wrappedAssetFlags",
+			genState: &types.GenesisState{WrappedAssetFlagsList: []types.WrappedAssetFlags{flags, flags}},
+			valid:    false,
+		},
+		{
+			desc:     "wrappedAssetFlags of another token",
+			genState: &types.GenesisState{WrappedAssetFlagsList: []types.WrappedAssetFlags{mismatched}},
+			valid:    false,
+		},
+		{
+			desc:     "wrappedAssetFlags without flags",
+			genState: &types.GenesisState{WrappedAssetFlagsList: []types.WrappedAssetFlags{{Denom: flags.Denom, TokenChain: 2, TokenAddress: flags.TokenAddress}}},
+			valid:    false,
+		},
+		{
+			desc:     "wrapped asset localization",
+			genState: &types.GenesisState{WrappedAssetLocalizationList: []types.WrappedAssetLocalization{localization}},
+			valid:    true,
+		},
+		{
+			desc:     "duplicated wrappedAssetLocalization",
+			genState: &types.GenesisState{WrappedAssetLocalizationList: []types.WrappedAssetLocalization{localization, localization}},
+			valid:    false,
+		},
+		{
+			desc:     "empty wrappedAssetLocalization",
+			genState: &types.GenesisState{WrappedAssetLocalizationList: []types.WrappedAssetLocalization{{Denom: localization.Denom, TokenChain: 2, TokenAddress: localization.TokenAddress}}},
+			valid:    false,
+		},
+		{
+			desc: "ledger",
+			genState: &types.GenesisState{
+				LedgerEntryList:   []types.LedgerEntry{{Id: 1, Amount: sdk.NewInt64Coin("uworm", 1)}},
+				LedgerEntryCount:  1,
+				LedgerBalanceList: []types.LedgerBalance{{Account: "supply", Denom: "uworm", Debits: sdk.ZeroInt(), Credits: sdk.NewInt(1)}},
+			},
+			valid: true,
+		},
+		{
+			desc: "duplicated ledgerEntry",
+			genState: &types.GenesisState{
+				LedgerEntryList:  []types.LedgerEntry{{Id: 1, Amount: sdk.NewInt64Coin("uworm", 1)}, {Id: 1, Amount: sdk.NewInt64Coin("uworm", 1)}},
+				LedgerEntryCount: 2,
+			},
+			valid: false,
+		},
+		{
+			desc: "invalid ledgerEntry count",
+			genState: &types.GenesisState{
+				LedgerEntryList:  []types.LedgerEntry{{Id: 2, Amount: sdk.NewInt64Coin("uworm", 1)}},
+				LedgerEntryCount: 1,
+			},
+			valid: false,
+		},
+		{
+			desc: "negative ledgerBalance",
+			genState: &types.GenesisState{
+				LedgerBalanceList: []types.LedgerBalance{{Account: "supply", Denom: "uworm", Debits: sdk.NewInt(-1), Credits: sdk.ZeroInt()}},
+			},
+			valid: false,
+		},
+		{
+			desc: "duplicated ledgerBalance",
+			genState: &types.GenesisState{
+				LedgerBalanceList: []types.LedgerBalance{
+					{Account: "supply", Denom: "uworm", Debits: sdk.ZeroInt(), Credits: sdk.ZeroInt()},
+					{Account: "supply", Denom: "uworm", Debits: sdk.ZeroInt(), Credits: sdk.ZeroInt()},
+				},
+			},
+			valid: false,
+		},
 		// this line is used by starport scaffolding # types/genesis/testcase
 	} {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -0,0 +1,23 @@
+package types
+
+import "encoding/binary"
+
+var _ binary.ByteOrder
+
+const (
+	// AttestedAssetMetaKeyPrefix is the prefix to retrieve all AttestedAssetMeta
+	AttestedAssetMetaKeyPrefix = "AttestedAssetMeta/value/"
+)
+
+// AttestedAssetMetaKey returns the store key to retrieve a AttestedAssetMeta from the index fields
+func AttestedAssetMetaKey(
+	denom string,
+) []byte {
+	var key []byte
+
+	denomBytes := []byte(denom)
+	key = append(key, denomBytes...)
+	key = append(key, []byte("/")...)
+
+	return key
+}
@@ -0,0 +1,23 @@
+package types
+
+import "encoding/binary"
+
+var _ binary.ByteOrder
+
+const (
+	// CustodyBalanceKeyPrefix is the prefix to retrieve all CustodyBalance
+	CustodyBalanceKeyPrefix = "CustodyBalance/value/"
+)
+
+// CustodyBalanceKey returns the store key to retrieve a CustodyBalance from the index fields
+func CustodyBalanceKey(
+	denom string,
+) []byte {
+	var key []byte
+
+	denomBytes := []byte(denom)
+	key = append(key, denomBytes...)
+	key = append(key, []byte("/")...)
+
+	return key
+}
@@ -0,0 +1,45 @@
+package types
+
+import "encoding/binary"
+
+const (
+	// EscrowedTransferKeyPrefix is the prefix to retrieve all EscrowedTransfer
+	EscrowedTransferKeyPrefix = "EscrowedTransfer/value/"
+
+	// EscrowedTransferExpiryKeyPrefix is the prefix of the index of EscrowedTransfer by expiry height
+	EscrowedTransferExpiryKeyPrefix = "EscrowedTransfer/expiry/"
+)
+
+// EscrowedTransferKey returns the store key to retrieve a EscrowedTransfer from the index fields
+func EscrowedTransferKey(
+	digest string,
+) []byte {
+	var key []byte
+
+	digestBytes := []byte(digest)
+	key = append(key, digestBytes...)
+	key = append(key, []byte("/")...)
+
+	return key
+}
+
+// EscrowedTransferExpiryKey returns the store key of an EscrowedTransfer in the index by expiry height. Keys are ordered
+// by expiry height.
+func EscrowedTransferExpiryKey(
+	expiryHeight int64,
+	digest string,
+) []byte {
+	key := EscrowedTransferExpiryHeightKey(expiryHeight)
+	key = append(key, []byte(digest)...)
+	key = append(key, []byte("/")...)
+
+	return key
+}
+
+// EscrowedTransferExpiryHeightKey returns the prefix of the keys of the EscrowedTransfers expiring at expiryHeight in
+// the index by expiry height.
+func EscrowedTransferExpiryHeightKey(expiryHeight int64) []byte {
+	heightBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(heightBytes, uint64(expiryHeight))
+	return heightBytes
+}
@@ -0,0 +1,43 @@
+package types
+
+import "encoding/binary"
+
+const (
+	// IbcChannelKeyPrefix is the prefix to retrieve all IbcChannel
+	IbcChannelKeyPrefix = "IbcChannel/value/"
+
+	// GatewayTransferKeyPrefix is the prefix to retrieve all GatewayTransfer
+	GatewayTransferKeyPrefix = "GatewayTransfer/value/"
+)
+
+// IbcChannelKey returns the store key to retrieve a IbcChannel from the index fields
+func IbcChannelKey(
+	chainID string,
+) []byte {
+	var key []byte
+
+	chainIDBytes := []byte(chainID)
+	key = append(key, chainIDBytes...)
+	key = append(key, []byte("/")...)
+
+	return key
+}
+
+// GatewayTransferKey returns the store key to retrieve a GatewayTransfer from the index fields
+func GatewayTransferKey(
+	channelID string,
+	sequence uint64,
+) []byte {
+	var key []byte
+
+	channelIDBytes := []byte(channelID)
+	key = append(key, channelIDBytes...)
+	key = append(key, []byte("/")...)
+
+	sequenceBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(sequenceBytes, sequence)
+	key = append(key, sequenceBytes...)
+	key = append(key, []byte("/")...)
+
+	return key
+}
@@ -0,0 +1,71 @@
+package types
+
+import "encoding/binary"
+
+const (
+	// LedgerEntryKeyPrefix is the prefix to retrieve all LedgerEntry
+	LedgerEntryKeyPrefix = "LedgerEntry/value/"
+	// LedgerRedemptionKeyPrefix is the prefix of the index of LedgerEntry by redemption
+	LedgerRedemptionKeyPrefix = "LedgerEntry/redemption/"
+	// LedgerBalanceKeyPrefix is the prefix to retrieve all LedgerBalance
+	LedgerBalanceKeyPrefix = "LedgerBalance/value/"
+)
+
+const (
+	// LedgerAccountSupply is the account credited with the wrapped assets minted by redemptions.
+	LedgerAccountSupply = "supply"
+	// LedgerAccountCustody is the account credited with the native coins unlocked by redemptions.
+	LedgerAccountCustody = "custody"
+)
+
+const (
+	LedgerKindMint   = "mint"
+	LedgerKindUnlock = "unlock"
+	// LedgerKindGateway is the payout of a transfer forwarded over IBC to the gateway account. Principal and fee
+	// payouts use PayoutKindPrincipal and PayoutKindFee.
+	LedgerKindGateway = "gateway"
+)
+
+// LedgerEntryKey returns the store key to retrieve a LedgerEntry from the index fields. Keys are ordered by ID.
+func LedgerEntryKey(
+	id uint64,
+) []byte {
+	var key []byte
+
+	idBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(idBytes, id)
+	key = append(key, idBytes...)
+	key = append(key, []byte("/")...)
+
+	return key
+}
+
+// LedgerRedemptionPrefix returns the prefix of the index keys of the LedgerEntry of a redemption.
+func LedgerRedemptionPrefix(
+	redemption string,
+) []byte {
+	var key []byte
+
+	redemptionBytes := []byte(redemption)
+	key = append(key, redemptionBytes...)
+	key = append(key, []byte("/")...)
+
+	return key
+}
+
+// LedgerBalanceKey returns the store key to retrieve a LedgerBalance from the index fields
+func LedgerBalanceKey(
+	account string,
+	denom string,
+) []byte {
+	var key []byte
+
+	accountBytes := []byte(account)
+	key = append(key, accountBytes...)
+	key = append(key, []byte("/")...)
+	denomBytes := []byte(denom)
+	key = append(key, denomBytes...)
+	key = append(key, []byte("/")...)
+
+	return key
+}
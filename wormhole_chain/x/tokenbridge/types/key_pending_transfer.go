@@ -0,0 +1,25 @@
+package types
+
+import "encoding/binary"
+
+const (
+	// PendingTransferKeyPrefix is the prefix to retrieve all PendingTransfer
+	PendingTransferKeyPrefix = "PendingTransfer/value/"
+
+	// PendingTransferAmountKeyPrefix is the prefix to retrieve the total amount of a denom in pending transfers
+	PendingTransferAmountKeyPrefix = "PendingTransfer/amount/"
+)
+
+// PendingTransferKey returns the store key to retrieve a PendingTransfer from the index fields. Keys are ordered by ID.
+func PendingTransferKey(
+	id uint64,
+) []byte {
+	var key []byte
+
+	idBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(idBytes, id)
+	key = append(key, idBytes...)
+	key = append(key, []byte("/")...)
+
+	return key
+}
@@ -0,0 +1,23 @@
+package types
+
+import "encoding/binary"
+
+var _ binary.ByteOrder
+
+const (
+	// RegistrationBountyKeyPrefix is the prefix to retrieve all RegistrationBounty
+	RegistrationBountyKeyPrefix = "RegistrationBounty/value/"
+)
+
+// RegistrationBountyKey returns the store key to retrieve a RegistrationBounty from the index fields
+func RegistrationBountyKey(
+	denom string,
+) []byte {
+	var key []byte
+
+	denomBytes := []byte(denom)
+	key = append(key, denomBytes...)
+	key = append(key, []byte("/")...)
+
+	return key
+}
@@ -0,0 +1,23 @@
+package types
+
+import "encoding/binary"
+
+var _ binary.ByteOrder
+
+const (
+	// RelayerKeyPrefix is the prefix to retrieve all Relayer
+	RelayerKeyPrefix = "Relayer/value/"
+)
+
+// RelayerKey returns the store key to retrieve a Relayer from the index fields
+func RelayerKey(
+	address string,
+) []byte {
+	var key []byte
+
+	addressBytes := []byte(address)
+	key = append(key, addressBytes...)
+	key = append(key, []byte("/")...)
+
+	return key
+}
@@ -0,0 +1,19 @@
+package types
+
+const (
+	// WrappedAssetFlagsKeyPrefix is the prefix to retrieve all WrappedAssetFlags
+	WrappedAssetFlagsKeyPrefix = "WrappedAssetFlags/value/"
+)
+
+// WrappedAssetFlagsKey returns the store key to retrieve a WrappedAssetFlags from the index fields
+func WrappedAssetFlagsKey(
+	denom string,
+) []byte {
+	var key []byte
+
+	denomBytes := []byte(denom)
+	key = append(key, denomBytes...)
+	key = append(key, []byte("/")...)
+
+	return key
+}
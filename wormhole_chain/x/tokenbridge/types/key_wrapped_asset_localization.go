@@ -0,0 +1,19 @@
+package types
+
+const (
+	// WrappedAssetLocalizationKeyPrefix is the prefix to retrieve all WrappedAssetLocalization
+	WrappedAssetLocalizationKeyPrefix = "WrappedAssetLocalization/value/"
+)
+
+// WrappedAssetLocalizationKey returns the store key to retrieve a WrappedAssetLocalization from the index fields
+func WrappedAssetLocalizationKey(
+	denom string,
+) []byte {
+	var key []byte
+
+	denomBytes := []byte(denom)
+	key = append(key, denomBytes...)
+	key = append(key, []byte("/")...)
+
+	return key
+}
@@ -15,6 +15,15 @@ const (
 
 	// MemStoreKey defines the in-memory store key
 	MemStoreKey = "mem_tokenbridge"
+
+	// GatewayAccountName is the name the address of the gateway account is derived from. Transfers are forwarded over
+	// IBC from this account rather than from the module account, which is not allowed to send IBC transfers.
+	GatewayAccountName = ModuleName + "/gateway"
+
+	// DefaultFeeAccountName is the name the address of the fee account is derived from, unless the config sets
+	// another one. Fees are collected in this account rather than in the module account, which holds the coins in
+	// custody.
+	DefaultFeeAccountName = ModuleName + "/fees"
 )
 
 func KeyPrefix(p string) []byte {
@@ -22,5 +31,7 @@ func KeyPrefix(p string) []byte {
 }
 
 const (
-	ConfigKey = "Config-value-"
+	ConfigKey               = "Config-value-"
+	PendingTransferCountKey = "PendingTransfer-count-"
+	LedgerEntryCountKey     = "LedgerEntry-count-"
 )
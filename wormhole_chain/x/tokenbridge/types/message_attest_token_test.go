@@ -3,6 +3,7 @@ package types
 import (
 	"testing"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/stretchr/testify/require"
 	"github.com/wormhole-foundation/wormhole-chain/testutil/sample"
@@ -24,7 +25,15 @@ func TestMsgAttestToken_ValidateBasic(t *testing.T) {
 			name: "valid address",
 			msg: MsgAttestToken{
 				Creator: sample.AccAddress(),
+				Denom:   sdk.DefaultBondDenom,
 			},
+		}, {
+			name: "invalid denom",
+			msg: MsgAttestToken{
+				Creator: sample.AccAddress(),
+				Denom:   "007test",
+			},
+			err: ErrInvalidDenom,
 		},
 	}
 	for _, tt := range tests {
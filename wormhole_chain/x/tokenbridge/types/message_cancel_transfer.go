@@ -0,0 +1,47 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+var _ sdk.Msg = &MsgCancelTransfer{}
+
+func NewMsgCancelTransfer(creator string, pendingTransferID uint64) *MsgCancelTransfer {
+	return &MsgCancelTransfer{
+		Creator:           creator,
+		PendingTransferID: pendingTransferID,
+	}
+}
+
+func (msg *MsgCancelTransfer) Route() string {
+	return RouterKey
+}
+
+func (msg *MsgCancelTransfer) Type() string {
+	return "CancelTransfer"
+}
+
+func (msg *MsgCancelTransfer) GetSigners() []sdk.AccAddress {
+	creator, err := sdk.AccAddressFromBech32(msg.Creator)
+	if err != nil {
+		panic(err)
+	}
+	return []sdk.AccAddress{creator}
+}
+
+func (msg *MsgCancelTransfer) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg *MsgCancelTransfer) ValidateBasic() error {
+	_, err := sdk.AccAddressFromBech32(msg.Creator)
+	if err != nil {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "invalid creator address (%s)", err)
+	}
+	if msg.PendingTransferID == 0 {
+		return ErrUnknownPendingTransfer
+	}
+	return nil
+}
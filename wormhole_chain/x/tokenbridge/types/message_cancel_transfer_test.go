@@ -0,0 +1,48 @@
+package types
+
+import (
+	"testing"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole-chain/testutil/sample"
+)
+
+func TestMsgCancelTransfer_ValidateBasic(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  MsgCancelTransfer
+		err  error
+	}{
+		{
+			name: "invalid address",
+			msg: MsgCancelTransfer{
+				Creator:           "invalid_address",
+				PendingTransferID: 1,
+			},
+			err: sdkerrors.ErrInvalidAddress,
+		}, {
+			name: "valid address",
+			msg: MsgCancelTransfer{
+				Creator:           sample.AccAddress(),
+				PendingTransferID: 1,
+			},
+		}, {
+			name: "zero ID",
+			msg: MsgCancelTransfer{
+				Creator: sample.AccAddress(),
+			},
+			err: ErrUnknownPendingTransfer,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.msg.ValidateBasic()
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package types
+
+import (
+	"encoding/hex"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+var _ sdk.Msg = &MsgCompleteEscrowedTransfer{}
+
+func NewMsgCompleteEscrowedTransfer(creator string, digest string) *MsgCompleteEscrowedTransfer {
+	return &MsgCompleteEscrowedTransfer{
+		Creator: creator,
+		Digest:  digest,
+	}
+}
+
+func (msg *MsgCompleteEscrowedTransfer) Route() string {
+	return RouterKey
+}
+
+func (msg *MsgCompleteEscrowedTransfer) Type() string {
+	return "CompleteEscrowedTransfer"
+}
+
+func (msg *MsgCompleteEscrowedTransfer) GetSigners() []sdk.AccAddress {
+	creator, err := sdk.AccAddressFromBech32(msg.Creator)
+	if err != nil {
+		panic(err)
+	}
+	return []sdk.AccAddress{creator}
+}
+
+func (msg *MsgCompleteEscrowedTransfer) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg *MsgCompleteEscrowedTransfer) ValidateBasic() error {
+	_, err := sdk.AccAddressFromBech32(msg.Creator)
+	if err != nil {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "invalid creator address (%s)", err)
+	}
+	if digest, err := hex.DecodeString(msg.Digest); err != nil || len(digest) != 32 {
+		return ErrUnknownEscrowedTransfer
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package types
+
+import (
+	"strings"
+	"testing"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole-chain/testutil/sample"
+)
+
+func TestMsgCompleteEscrowedTransfer_ValidateBasic(t *testing.T) {
+	digest := strings.Repeat("ab", 32)
+	tests := []struct {
+		name string
+		msg  MsgCompleteEscrowedTransfer
+		err  error
+	}{
+		{
+			name: "invalid address",
+			msg: MsgCompleteEscrowedTransfer{
+				Creator: "invalid_address",
+				Digest:  digest,
+			},
+			err: sdkerrors.ErrInvalidAddress,
+		}, {
+			name: "valid address",
+			msg: MsgCompleteEscrowedTransfer{
+				Creator: sample.AccAddress(),
+				Digest:  digest,
+			},
+		}, {
+			name: "short digest",
+			msg: MsgCompleteEscrowedTransfer{
+				Creator: sample.AccAddress(),
+				Digest:  digest[2:],
+			},
+			err: ErrUnknownEscrowedTransfer,
+		}, {
+			name: "invalid digest",
+			msg: MsgCompleteEscrowedTransfer{
+				Creator: sample.AccAddress(),
+				Digest:  "0x" + digest[2:],
+			},
+			err: ErrUnknownEscrowedTransfer,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.msg.ValidateBasic()
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -3,9 +3,10 @@ package types
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	whtypes "github.com/wormhole-foundation/wormhole-chain/x/wormhole/types"
 )
 
-var _ sdk.Msg = &MsgExecuteGovernanceVAA{}
+var _ whtypes.GovernanceVAAMsg = &MsgExecuteGovernanceVAA{}
 
 func NewMsgExecuteGovernanceVAA(creator string, vaa []byte) *MsgExecuteGovernanceVAA {
 	return &MsgExecuteGovernanceVAA{
@@ -22,6 +23,10 @@ func (msg *MsgExecuteGovernanceVAA) Type() string {
 	return "ExecuteGovernanceVAA"
 }
 
+func (msg *MsgExecuteGovernanceVAA) GovernanceVAA() []byte {
+	return msg.Vaa
+}
+
 func (msg *MsgExecuteGovernanceVAA) GetSigners() []sdk.AccAddress {
 	creator, err := sdk.AccAddressFromBech32(msg.Creator)
 	if err != nil {
@@ -40,5 +45,8 @@ func (msg *MsgExecuteGovernanceVAA) ValidateBasic() error {
 	if err != nil {
 		return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "invalid creator address (%s)", err)
 	}
-	return nil
+	if len(msg.Vaa) == 0 {
+		return ErrEmptyVAA
+	}
+	return whtypes.ValidateVAASize(msg.Vaa)
 }
@@ -24,7 +24,14 @@ func TestMsgExecuteGovernanceVAA_ValidateBasic(t *testing.T) {
 			name: "valid address",
 			msg: MsgExecuteGovernanceVAA{
 				Creator: sample.AccAddress(),
+				Vaa:     []byte{1},
 			},
+		}, {
+			name: "empty vaa",
+			msg: MsgExecuteGovernanceVAA{
+				Creator: sample.AccAddress(),
+			},
+			err: ErrEmptyVAA,
 		},
 	}
 	for _, tt := range tests {
@@ -3,9 +3,10 @@ package types
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	whtypes "github.com/wormhole-foundation/wormhole-chain/x/wormhole/types"
 )
 
-var _ sdk.Msg = &MsgExecuteVAA{}
+var _ whtypes.RedemptionMsg = &MsgExecuteVAA{}
 
 func NewMsgExecuteVAA(creator string, vaa []byte) *MsgExecuteVAA {
 	return &MsgExecuteVAA{
@@ -22,6 +23,10 @@ func (msg *MsgExecuteVAA) Type() string {
 	return "ExecuteVAA"
 }
 
+func (msg *MsgExecuteVAA) RedeemedVAAs() [][]byte {
+	return [][]byte{msg.Vaa}
+}
+
 func (msg *MsgExecuteVAA) GetSigners() []sdk.AccAddress {
 	creator, err := sdk.AccAddressFromBech32(msg.Creator)
 	if err != nil {
@@ -40,5 +45,8 @@ func (msg *MsgExecuteVAA) ValidateBasic() error {
 	if err != nil {
 		return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "invalid creator address (%s)", err)
 	}
-	return nil
+	if len(msg.Vaa) == 0 {
+		return ErrEmptyVAA
+	}
+	return whtypes.ValidateVAASize(msg.Vaa)
 }
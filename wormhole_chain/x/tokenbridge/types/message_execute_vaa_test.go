@@ -6,6 +6,7 @@ import (
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/stretchr/testify/require"
 	"github.com/wormhole-foundation/wormhole-chain/testutil/sample"
+	whtypes "github.com/wormhole-foundation/wormhole-chain/x/wormhole/types"
 )
 
 func TestMsgExecuteVAA_ValidateBasic(t *testing.T) {
@@ -24,7 +25,21 @@ func TestMsgExecuteVAA_ValidateBasic(t *testing.T) {
 			name: "valid address",
 			msg: MsgExecuteVAA{
 				Creator: sample.AccAddress(),
+				Vaa:     []byte{1},
 			},
+		}, {
+			name: "empty vaa",
+			msg: MsgExecuteVAA{
+				Creator: sample.AccAddress(),
+			},
+			err: ErrEmptyVAA,
+		}, {
+			name: "oversized vaa",
+			msg: MsgExecuteVAA{
+				Creator: sample.AccAddress(),
+				Vaa:     make([]byte, 6+51+whtypes.MaxVAAPayloadSize+1),
+			},
+			err: whtypes.ErrVAAPayloadTooLarge,
 		},
 	}
 	for _, tt := range tests {
@@ -0,0 +1,66 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	whtypes "github.com/wormhole-foundation/wormhole-chain/x/wormhole/types"
+)
+
+// MaxExecuteVAAsBatchSize bounds the VAAs a single MsgExecuteVAAs executes.
+const MaxExecuteVAAsBatchSize = 64
+
+var _ whtypes.RedemptionMsg = &MsgExecuteVAAs{}
+
+func NewMsgExecuteVAAs(creator string, vaas [][]byte) *MsgExecuteVAAs {
+	return &MsgExecuteVAAs{
+		Creator: creator,
+		Vaas:    vaas,
+	}
+}
+
+func (msg *MsgExecuteVAAs) Route() string {
+	return RouterKey
+}
+
+func (msg *MsgExecuteVAAs) Type() string {
+	return "ExecuteVAAs"
+}
+
+func (msg *MsgExecuteVAAs) RedeemedVAAs() [][]byte {
+	return msg.Vaas
+}
+
+func (msg *MsgExecuteVAAs) GetSigners() []sdk.AccAddress {
+	creator, err := sdk.AccAddressFromBech32(msg.Creator)
+	if err != nil {
+		panic(err)
+	}
+	return []sdk.AccAddress{creator}
+}
+
+func (msg *MsgExecuteVAAs) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg *MsgExecuteVAAs) ValidateBasic() error {
+	_, err := sdk.AccAddressFromBech32(msg.Creator)
+	if err != nil {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "invalid creator address (%s)", err)
+	}
+	if len(msg.Vaas) == 0 {
+		return ErrEmptyVAA
+	}
+	if len(msg.Vaas) > MaxExecuteVAAsBatchSize {
+		return sdkerrors.Wrapf(ErrTooManyVAAs, "%d VAAs, at most %d allowed", len(msg.Vaas), MaxExecuteVAAsBatchSize)
+	}
+	for i, vaa := range msg.Vaas {
+		if len(vaa) == 0 {
+			return sdkerrors.Wrapf(ErrEmptyVAA, "VAA %d", i)
+		}
+		if err := whtypes.ValidateVAASize(vaa); err != nil {
+			return sdkerrors.Wrapf(err, "VAA %d", i)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package types
+
+import (
+	"testing"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole-chain/testutil/sample"
+	whtypes "github.com/wormhole-foundation/wormhole-chain/x/wormhole/types"
+)
+
+func TestMsgExecuteVAAs_ValidateBasic(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  MsgExecuteVAAs
+		err  error
+	}{
+		{
+			name: "invalid address",
+			msg: MsgExecuteVAAs{
+				Creator: "invalid_address",
+			},
+			err: sdkerrors.ErrInvalidAddress,
+		}, {
+			name: "valid address",
+			msg: MsgExecuteVAAs{
+				Creator: sample.AccAddress(),
+				Vaas:    [][]byte{{1}, {2}},
+			},
+		}, {
+			name: "no vaas",
+			msg: MsgExecuteVAAs{
+				Creator: sample.AccAddress(),
+			},
+			err: ErrEmptyVAA,
+		}, {
+			name: "empty vaa",
+			msg: MsgExecuteVAAs{
+				Creator: sample.AccAddress(),
+				Vaas:    [][]byte{{1}, {}},
+			},
+			err: ErrEmptyVAA,
+		}, {
+			name: "too many vaas",
+			msg: MsgExecuteVAAs{
+				Creator: sample.AccAddress(),
+				Vaas:    make([][]byte, MaxExecuteVAAsBatchSize+1),
+			},
+			err: ErrTooManyVAAs,
+		}, {
+			name: "oversized vaa",
+			msg: MsgExecuteVAAs{
+				Creator: sample.AccAddress(),
+				Vaas:    [][]byte{{1}, make([]byte, 6+51+whtypes.MaxVAAPayloadSize+1)},
+			},
+			err: whtypes.ErrVAAPayloadTooLarge,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.msg.ValidateBasic()
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package types
+
+import (
+	"net/url"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+const (
+	// MaxRelayerEndpointLength and MaxRelayerDescriptionLength bound the metadata a relayer stores in the registry.
+	MaxRelayerEndpointLength    = 256
+	MaxRelayerDescriptionLength = 512
+)
+
+var _ sdk.Msg = &MsgRegisterRelayer{}
+
+func NewMsgRegisterRelayer(creator string, endpoint string, description string) *MsgRegisterRelayer {
+	return &MsgRegisterRelayer{
+		Creator:     creator,
+		Endpoint:    endpoint,
+		Description: description,
+	}
+}
+
+func (msg *MsgRegisterRelayer) Route() string {
+	return RouterKey
+}
+
+func (msg *MsgRegisterRelayer) Type() string {
+	return "RegisterRelayer"
+}
+
+func (msg *MsgRegisterRelayer) GetSigners() []sdk.AccAddress {
+	creator, err := sdk.AccAddressFromBech32(msg.Creator)
+	if err != nil {
+		panic(err)
+	}
+	return []sdk.AccAddress{creator}
+}
+
+func (msg *MsgRegisterRelayer) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg *MsgRegisterRelayer) ValidateBasic() error {
+	_, err := sdk.AccAddressFromBech32(msg.Creator)
+	if err != nil {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "invalid creator address (%s)", err)
+	}
+	if len(msg.Endpoint) > MaxRelayerEndpointLength {
+		return sdkerrors.Wrapf(ErrInvalidRelayerMetadata, "endpoint longer than %d bytes", MaxRelayerEndpointLength)
+	}
+	endpoint, err := url.Parse(msg.Endpoint)
+	if err != nil || (endpoint.Scheme != "https" && endpoint.Scheme != "http") || endpoint.Host == "" {
+		return sdkerrors.Wrapf(ErrInvalidRelayerMetadata, "endpoint must be an http(s) URL: %q", msg.Endpoint)
+	}
+	if len(msg.Description) > MaxRelayerDescriptionLength {
+		return sdkerrors.Wrapf(ErrInvalidRelayerMetadata, "description longer than %d bytes", MaxRelayerDescriptionLength)
+	}
+	return nil
+}
+
+var _ sdk.Msg = &MsgDeregisterRelayer{}
+
+func NewMsgDeregisterRelayer(creator string) *MsgDeregisterRelayer {
+	return &MsgDeregisterRelayer{
+		Creator: creator,
+	}
+}
+
+func (msg *MsgDeregisterRelayer) Route() string {
+	return RouterKey
+}
+
+func (msg *MsgDeregisterRelayer) Type() string {
+	return "DeregisterRelayer"
+}
+
+func (msg *MsgDeregisterRelayer) GetSigners() []sdk.AccAddress {
+	creator, err := sdk.AccAddressFromBech32(msg.Creator)
+	if err != nil {
+		panic(err)
+	}
+	return []sdk.AccAddress{creator}
+}
+
+func (msg *MsgDeregisterRelayer) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg *MsgDeregisterRelayer) ValidateBasic() error {
+	_, err := sdk.AccAddressFromBech32(msg.Creator)
+	if err != nil {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "invalid creator address (%s)", err)
+	}
+	return nil
+}
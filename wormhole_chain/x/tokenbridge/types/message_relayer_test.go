@@ -0,0 +1,103 @@
+package types
+
+import (
+	"strings"
+	"testing"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole-chain/testutil/sample"
+)
+
+func TestMsgRegisterRelayer_ValidateBasic(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  MsgRegisterRelayer
+		err  error
+	}{
+		{
+			name: "invalid address",
+			msg: MsgRegisterRelayer{
+				Creator:  "invalid_address",
+				Endpoint: "https://relayer.example.com",
+			},
+			err: sdkerrors.ErrInvalidAddress,
+		}, {
+			name: "valid address",
+			msg: MsgRegisterRelayer{
+				Creator:     sample.AccAddress(),
+				Endpoint:    "https://relayer.example.com/v1",
+				Description: "Example relayer",
+			},
+		}, {
+			name: "missing endpoint",
+			msg: MsgRegisterRelayer{
+				Creator: sample.AccAddress(),
+			},
+			err: ErrInvalidRelayerMetadata,
+		}, {
+			name: "endpoint not http",
+			msg: MsgRegisterRelayer{
+				Creator:  sample.AccAddress(),
+				Endpoint: "ftp://relayer.example.com",
+			},
+			err: ErrInvalidRelayerMetadata,
+		}, {
+			name: "endpoint too long",
+			msg: MsgRegisterRelayer{
+				Creator:  sample.AccAddress(),
+				Endpoint: "https://relayer.example.com/" + strings.Repeat("a", MaxRelayerEndpointLength),
+			},
+			err: ErrInvalidRelayerMetadata,
+		}, {
+			name: "description too long",
+			msg: MsgRegisterRelayer{
+				Creator:     sample.AccAddress(),
+				Endpoint:    "https://relayer.example.com",
+				Description: strings.Repeat("a", MaxRelayerDescriptionLength+1),
+			},
+			err: ErrInvalidRelayerMetadata,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.msg.ValidateBasic()
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMsgDeregisterRelayer_ValidateBasic(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  MsgDeregisterRelayer
+		err  error
+	}{
+		{
+			name: "invalid address",
+			msg: MsgDeregisterRelayer{
+				Creator: "invalid_address",
+			},
+			err: sdkerrors.ErrInvalidAddress,
+		}, {
+			name: "valid address",
+			msg: MsgDeregisterRelayer{
+				Creator: sample.AccAddress(),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.msg.ValidateBasic()
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package types
 
 import (
+	"bytes"
 	"fmt"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -50,7 +51,12 @@ func (msg *MsgTransfer) ValidateBasic() error {
 		return fmt.Errorf("%w: %s", ErrInvalidAmount, err)
 	}
 
-	if msg.ToChain > uint32(^uint16(0)) {
+	if !msg.Amount.IsPositive() {
+		return ErrZeroAmount
+	}
+
+	// Chain 0 is reserved and can never be registered
+	if msg.ToChain == 0 || msg.ToChain > uint32(^uint16(0)) {
 		return ErrInvalidTargetChain
 	}
 
@@ -58,6 +64,10 @@ func (msg *MsgTransfer) ValidateBasic() error {
 		return ErrInvalidToAddress
 	}
 
+	if bytes.Equal(msg.ToAddress, make([]byte, 32)) {
+		return fmt.Errorf("%w: to address must not be zero", ErrInvalidToAddress)
+	}
+
 	if err := msg.Fee.Validate(); err != nil {
 		return fmt.Errorf("%w: %s", ErrInvalidFee, err)
 	}
@@ -66,7 +76,8 @@ func (msg *MsgTransfer) ValidateBasic() error {
 		return fmt.Errorf("%w: Fee must have the same denom as Amount", ErrInvalidFee)
 	}
 
-	if msg.Amount.Amount.BigInt().Cmp(msg.Fee.Amount.BigInt()) != 1 {
+	// Like on redemption, the fee may take the whole amount
+	if msg.Amount.IsLT(msg.Fee) {
 		return ErrFeeTooHigh
 	}
 
@@ -10,6 +10,9 @@ import (
 )
 
 func TestMsgTransfer_ValidateBasic(t *testing.T) {
+	toAddress := make([]byte, 32)
+	toAddress[31] = 1
+
 	tests := []struct {
 		name string
 		msg  MsgTransfer
@@ -27,7 +30,7 @@ func TestMsgTransfer_ValidateBasic(t *testing.T) {
 				Creator:   sample.AccAddress(),
 				Amount:    sdk.NewCoin(sdk.DefaultBondDenom, sdk.NewInt(10)),
 				ToChain:   1,
-				ToAddress: make([]byte, 32),
+				ToAddress: toAddress,
 				Fee:       sdk.NewCoin(sdk.DefaultBondDenom, sdk.NewInt(1)),
 			},
 		}, {
@@ -36,7 +39,7 @@ func TestMsgTransfer_ValidateBasic(t *testing.T) {
 				Creator:   sample.AccAddress(),
 				Amount:    sdk.Coin{Denom: sdk.DefaultBondDenom, Amount: sdk.NewInt(-10)},
 				ToChain:   0,
-				ToAddress: make([]byte, 32),
+				ToAddress: toAddress,
 				Fee:       sdk.NewCoin(sdk.DefaultBondDenom, sdk.NewInt(0)),
 			},
 			err: ErrInvalidAmount,
@@ -46,7 +49,7 @@ func TestMsgTransfer_ValidateBasic(t *testing.T) {
 				Creator:   sample.AccAddress(),
 				Amount:    sdk.Coin{Denom: "007test", Amount: sdk.NewInt(10)},
 				ToChain:   0,
-				ToAddress: make([]byte, 32),
+				ToAddress: toAddress,
 				Fee:       sdk.NewCoin(sdk.DefaultBondDenom, sdk.NewInt(0)),
 			},
 			err: ErrInvalidAmount,
@@ -54,9 +57,9 @@ func TestMsgTransfer_ValidateBasic(t *testing.T) {
 			name: "negative fee",
 			msg: MsgTransfer{
 				Creator:   sample.AccAddress(),
-				Amount:    sdk.NewCoin(sdk.DefaultBondDenom, sdk.NewInt(0)),
-				ToChain:   0,
-				ToAddress: make([]byte, 32),
+				Amount:    sdk.NewCoin(sdk.DefaultBondDenom, sdk.NewInt(10)),
+				ToChain:   1,
+				ToAddress: toAddress,
 				Fee:       sdk.Coin{Denom: sdk.DefaultBondDenom, Amount: sdk.NewInt(-10)},
 			},
 			err: ErrInvalidFee,
@@ -64,9 +67,9 @@ func TestMsgTransfer_ValidateBasic(t *testing.T) {
 			name: "invalid fee denom",
 			msg: MsgTransfer{
 				Creator:   sample.AccAddress(),
-				Amount:    sdk.NewCoin(sdk.DefaultBondDenom, sdk.NewInt(0)),
-				ToChain:   0,
-				ToAddress: make([]byte, 32),
+				Amount:    sdk.NewCoin(sdk.DefaultBondDenom, sdk.NewInt(10)),
+				ToChain:   1,
+				ToAddress: toAddress,
 				Fee:       sdk.Coin{Denom: "007test", Amount: sdk.NewInt(10)},
 			},
 			err: ErrInvalidFee,
@@ -76,7 +79,7 @@ func TestMsgTransfer_ValidateBasic(t *testing.T) {
 				Creator:   sample.AccAddress(),
 				Amount:    sdk.NewCoin(sdk.DefaultBondDenom, sdk.NewInt(10)),
 				ToChain:   uint32(^uint16(0)) + 1,
-				ToAddress: make([]byte, 32),
+				ToAddress: toAddress,
 				Fee:       sdk.NewCoin(sdk.DefaultBondDenom, sdk.NewInt(1)),
 			},
 			err: ErrInvalidTargetChain,
@@ -96,7 +99,7 @@ func TestMsgTransfer_ValidateBasic(t *testing.T) {
 				Creator:   sample.AccAddress(),
 				Amount:    sdk.NewCoin(sdk.DefaultBondDenom, sdk.NewInt(10)),
 				ToChain:   1,
-				ToAddress: make([]byte, 32),
+				ToAddress: toAddress,
 				Fee:       sdk.NewCoin("test", sdk.NewInt(1)),
 			},
 			err: ErrInvalidFee,
@@ -106,10 +109,49 @@ func TestMsgTransfer_ValidateBasic(t *testing.T) {
 				Creator:   sample.AccAddress(),
 				Amount:    sdk.NewCoin(sdk.DefaultBondDenom, sdk.NewInt(10)),
 				ToChain:   1,
-				ToAddress: make([]byte, 32),
-				Fee:       sdk.NewCoin(sdk.DefaultBondDenom, sdk.NewInt(10)),
+				ToAddress: toAddress,
+				Fee:       sdk.NewCoin(sdk.DefaultBondDenom, sdk.NewInt(11)),
 			},
 			err: ErrFeeTooHigh,
+		}, {
+			name: "fee equal to amount",
+			msg: MsgTransfer{
+				Creator:   sample.AccAddress(),
+				Amount:    sdk.NewCoin(sdk.DefaultBondDenom, sdk.NewInt(10)),
+				ToChain:   1,
+				ToAddress: toAddress,
+				Fee:       sdk.NewCoin(sdk.DefaultBondDenom, sdk.NewInt(10)),
+			},
+		}, {
+			name: "zero amount",
+			msg: MsgTransfer{
+				Creator:   sample.AccAddress(),
+				Amount:    sdk.NewCoin(sdk.DefaultBondDenom, sdk.NewInt(0)),
+				ToChain:   1,
+				ToAddress: toAddress,
+				Fee:       sdk.NewCoin(sdk.DefaultBondDenom, sdk.NewInt(0)),
+			},
+			err: ErrZeroAmount,
+		}, {
+			name: "unset target chain",
+			msg: MsgTransfer{
+				Creator:   sample.AccAddress(),
+				Amount:    sdk.NewCoin(sdk.DefaultBondDenom, sdk.NewInt(10)),
+				ToChain:   0,
+				ToAddress: toAddress,
+				Fee:       sdk.NewCoin(sdk.DefaultBondDenom, sdk.NewInt(1)),
+			},
+			err: ErrInvalidTargetChain,
+		}, {
+			name: "zero target address",
+			msg: MsgTransfer{
+				Creator:   sample.AccAddress(),
+				Amount:    sdk.NewCoin(sdk.DefaultBondDenom, sdk.NewInt(10)),
+				ToChain:   1,
+				ToAddress: make([]byte, 32),
+				Fee:       sdk.NewCoin(sdk.DefaultBondDenom, sdk.NewInt(1)),
+			},
+			err: ErrInvalidToAddress,
 		},
 	}
 	for _, tt := range tests {
@@ -0,0 +1,49 @@
+package types
+
+import (
+	"fmt"
+)
+
+// Bits of the flags byte of the governance payload that sets the flags of a wrapped asset.
+const (
+	WrappedAssetFlagFeeOnTransfer byte = 1 << 0
+	WrappedAssetFlagRebasing      byte = 1 << 1
+
+	wrappedAssetFlagsMask = WrappedAssetFlagFeeOnTransfer | WrappedAssetFlagRebasing
+)
+
+// NewWrappedAssetFlags returns the flags of the wrapped asset of the token with the bits of flags set. It fails if
+// flags has bits set that are not defined.
+func NewWrappedAssetFlags(tokenChain uint16, tokenAddress [32]byte, flags byte) (WrappedAssetFlags, error) {
+	if flags&^wrappedAssetFlagsMask != 0 {
+		return WrappedAssetFlags{}, fmt.Errorf("%w: unknown flags %#x", ErrInvalidWrappedAssetFlags, flags&^wrappedAssetFlagsMask)
+	}
+	return WrappedAssetFlags{
+		Denom:         "b" + GetWrappedCoinIdentifier(tokenChain, tokenAddress),
+		TokenChain:    uint32(tokenChain),
+		TokenAddress:  tokenAddress[:],
+		FeeOnTransfer: flags&WrappedAssetFlagFeeOnTransfer != 0,
+		Rebasing:      flags&WrappedAssetFlagRebasing != 0,
+	}, nil
+}
+
+// IsEmpty returns true if no flag is set. Empty flags are not stored.
+func (f WrappedAssetFlags) IsEmpty() bool {
+	return !f.FeeOnTransfer && !f.Rebasing
+}
+
+// Validate checks that the denom is the one of the wrapped asset of the token, and that a flag is set.
+func (f WrappedAssetFlags) Validate() error {
+	if f.TokenChain > 0xffff || len(f.TokenAddress) != 32 {
+		return fmt.Errorf("%w: invalid token of %s", ErrInvalidWrappedAssetFlags, f.Denom)
+	}
+	var tokenAddress [32]byte
+	copy(tokenAddress[:], f.TokenAddress)
+	if denom := "b" + GetWrappedCoinIdentifier(uint16(f.TokenChain), tokenAddress); f.Denom != denom {
+		return fmt.Errorf("%w: denom %s does not match token, expected %s", ErrInvalidWrappedAssetFlags, f.Denom, denom)
+	}
+	if f.IsEmpty() {
+		return fmt.Errorf("%w: no flag set for %s", ErrInvalidWrappedAssetFlags, f.Denom)
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package types
+
+import (
+	"encoding/binary"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// MaxLocalizedNames is the maximum number of localized names of a wrapped asset.
+	MaxLocalizedNames = 32
+	// MaxLocalizedNameLength is the maximum length of a localized name in bytes.
+	MaxLocalizedNameLength = 64
+)
+
+var (
+	// localeRegex matches BCP 47 language tags made of a language and optional subtags, like "en", "pt-BR" or
+	// "zh-Hant".
+	localeRegex = regexp.MustCompile(`^[a-zA-Z]{2,3}(-[a-zA-Z0-9]{1,8}){0,4}$`)
+	// assetListIDRegex matches the identifiers of asset lists, like "usd-coin" on CoinGecko.
+	assetListIDRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]{0,63}$`)
+)
+
+// ParseWrappedAssetLocalization parses the payload of the governance action that sets the display information of a
+// wrapped asset: token chain (2) | token address (32) | asset list ID length (1) | asset list ID | name count (1),
+// followed by locale length (1) | locale | name length (1) | name for each name. The names are returned sorted by
+// locale.
+func ParseWrappedAssetLocalization(payload []byte) (WrappedAssetLocalization, error) {
+	if len(payload) < 36 {
+		return WrappedAssetLocalization{}, ErrInvalidGovernancePayloadLength
+	}
+	tokenChain := binary.BigEndian.Uint16(payload[:2])
+	var tokenAddress [32]byte
+	copy(tokenAddress[:], payload[2:34])
+	l := WrappedAssetLocalization{
+		Denom:        "b" + GetWrappedCoinIdentifier(tokenChain, tokenAddress),
+		TokenChain:   uint32(tokenChain),
+		TokenAddress: tokenAddress[:],
+	}
+
+	rest := payload[34:]
+	next := func() (string, bool) {
+		if len(rest) < 1 || len(rest) < 1+int(rest[0]) {
+			return "", false
+		}
+		s := string(rest[1 : 1+int(rest[0])])
+		rest = rest[1+int(rest[0]):]
+		return s, true
+	}
+
+	var ok bool
+	if l.AssetListId, ok = next(); !ok || len(rest) < 1 {
+		return WrappedAssetLocalization{}, ErrInvalidGovernancePayloadLength
+	}
+	count := int(rest[0])
+	rest = rest[1:]
+	for i := 0; i < count; i++ {
+		var name LocalizedName
+		if name.Locale, ok = next(); !ok {
+			return WrappedAssetLocalization{}, ErrInvalidGovernancePayloadLength
+		}
+		if name.Name, ok = next(); !ok {
+			return WrappedAssetLocalization{}, ErrInvalidGovernancePayloadLength
+		}
+		l.LocalizedNames = append(l.LocalizedNames, name)
+	}
+	if len(rest) != 0 {
+		return WrappedAssetLocalization{}, ErrInvalidGovernancePayloadLength
+	}
+
+	sort.SliceStable(l.LocalizedNames, func(i, j int) bool { return l.LocalizedNames[i].Locale < l.LocalizedNames[j].Locale })
+	if err := l.validateFields(); err != nil {
+		return WrappedAssetLocalization{}, err
+	}
+	return l, nil
+}
+
+// Serialize returns the governance payload that sets l, the inverse of ParseWrappedAssetLocalization.
+func (l WrappedAssetLocalization) Serialize() []byte {
+	payload := make([]byte, 2, 36+len(l.AssetListId))
+	binary.BigEndian.PutUint16(payload, uint16(l.TokenChain))
+	payload = append(payload, l.TokenAddress...)
+	payload = append(payload, byte(len(l.AssetListId)))
+	payload = append(payload, l.AssetListId...)
+	payload = append(payload, byte(len(l.LocalizedNames)))
+	for _, name := range l.LocalizedNames {
+		payload = append(payload, byte(len(name.Locale)))
+		payload = append(payload, name.Locale...)
+		payload = append(payload, byte(len(name.Name)))
+		payload = append(payload, name.Name...)
+	}
+	return payload
+}
+
+// IsEmpty returns true if neither an asset list ID nor a name is set. Empty localizations are not stored.
+func (l WrappedAssetLocalization) IsEmpty() bool {
+	return l.AssetListId == "" && len(l.LocalizedNames) == 0
+}
+
+// Validate checks that the denom is the one of the wrapped asset of the token, and that the asset list ID and the
+// names are well-formed and not all empty.
+func (l WrappedAssetLocalization) Validate() error {
+	if l.TokenChain > 0xffff || len(l.TokenAddress) != 32 {
+		return fmt.Errorf("%w: invalid token of %s", ErrInvalidAssetLocalization, l.Denom)
+	}
+	var tokenAddress [32]byte
+	copy(tokenAddress[:], l.TokenAddress)
+	if denom := "b" + GetWrappedCoinIdentifier(uint16(l.TokenChain), tokenAddress); l.Denom != denom {
+		return fmt.Errorf("%w: denom %s does not match token, expected %s", ErrInvalidAssetLocalization, l.Denom, denom)
+	}
+	if l.IsEmpty() {
+		return fmt.Errorf("%w: nothing set for %s", ErrInvalidAssetLocalization, l.Denom)
+	}
+	return l.validateFields()
+}
+
+func (l WrappedAssetLocalization) validateFields() error {
+	if l.AssetListId != "" && !assetListIDRegex.MatchString(l.AssetListId) {
+		return fmt.Errorf("%w: invalid asset list ID %q", ErrInvalidAssetLocalization, l.AssetListId)
+	}
+	if len(l.LocalizedNames) > MaxLocalizedNames {
+		return fmt.Errorf("%w: more than %d names", ErrInvalidAssetLocalization, MaxLocalizedNames)
+	}
+	for i, name := range l.LocalizedNames {
+		if len(name.Locale) > 35 || !localeRegex.MatchString(name.Locale) {
+			return fmt.Errorf("%w: invalid locale %q", ErrInvalidAssetLocalization, name.Locale)
+		}
+		if i > 0 && l.LocalizedNames[i-1].Locale >= name.Locale {
+			return fmt.Errorf("%w: locales are not sorted or contain %q twice", ErrInvalidAssetLocalization, name.Locale)
+		}
+		if !validLocalizedName(name.Name) {
+			return fmt.Errorf("%w: invalid name for locale %s", ErrInvalidAssetLocalization, name.Locale)
+		}
+	}
+	return nil
+}
+
+// validLocalizedName returns true if name is non-empty UTF-8 of at most MaxLocalizedNameLength bytes, without control
+// characters and surrounding whitespace.
+func validLocalizedName(name string) bool {
+	if name == "" || len(name) > MaxLocalizedNameLength || !utf8.ValidString(name) || strings.TrimSpace(name) != name {
+		return false
+	}
+	return strings.IndexFunc(name, unicode.IsControl) < 0
+}
@@ -0,0 +1,52 @@
+package types
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseWrappedAssetLocalization(t *testing.T) {
+	localization := func(assetListID string, names ...LocalizedName) WrappedAssetLocalization {
+		return WrappedAssetLocalization{
+			Denom:          "b" + GetWrappedCoinIdentifier(2, testToken),
+			TokenChain:     2,
+			TokenAddress:   testToken[:],
+			AssetListId:    assetListID,
+			LocalizedNames: names,
+		}
+	}
+	en := LocalizedName{Locale: "en", Name: "Wrapped Ether"}
+	zh := LocalizedName{Locale: "zh-Hant", Name: "包裝以太幣"}
+
+	for _, tc := range []struct {
+		desc     string
+		payload  []byte
+		expected WrappedAssetLocalization
+		err      error
+	}{
+		{desc: "asset list ID", payload: localization("weth").Serialize(), expected: localization("weth")},
+		{desc: "names", payload: localization("", en, zh).Serialize(), expected: localization("", en, zh)},
+		{desc: "names are sorted", payload: localization("weth", zh, en).Serialize(), expected: localization("weth", en, zh)},
+		{desc: "empty", payload: localization("").Serialize(), expected: localization("")},
+		{desc: "duplicated locale", payload: localization("", en, en).Serialize(), err: ErrInvalidAssetLocalization},
+		{desc: "invalid locale", payload: localization("", LocalizedName{Locale: "english", Name: "Wrapped Ether"}).Serialize(), err: ErrInvalidAssetLocalization},
+		{desc: "empty name", payload: localization("", LocalizedName{Locale: "en"}).Serialize(), err: ErrInvalidAssetLocalization},
+		{desc: "control character", payload: localization("", LocalizedName{Locale: "en", Name: "Wrapped\nEther"}).Serialize(), err: ErrInvalidAssetLocalization},
+		{desc: "long name", payload: localization("", LocalizedName{Locale: "en", Name: strings.Repeat("x", 65)}).Serialize(), err: ErrInvalidAssetLocalization},
+		{desc: "invalid asset list ID", payload: localization("Wrapped Ether").Serialize(), err: ErrInvalidAssetLocalization},
+		{desc: "truncated", payload: localization("weth", en).Serialize()[:40], err: ErrInvalidGovernancePayloadLength},
+		{desc: "trailing bytes", payload: append(localization("weth").Serialize(), 0), err: ErrInvalidGovernancePayloadLength},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			parsed, err := ParseWrappedAssetLocalization(tc.payload)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, parsed)
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package wormhole
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/wormhole-foundation/wormhole-chain/x/wormhole/keeper"
+)
+
+// EndBlocker summarizes the messages posted in the block, adjusts the base gas
+// price of the fee market to the gas used by the block and brings the guardian
+// validators in line with the consensus guardian set. It has to run before the
+// staking EndBlocker, which allocates voting power based on it.
+func EndBlocker(ctx sdk.Context, k keeper.Keeper) {
+	k.EmitBlockMessages(ctx)
+
+	// The block gas meter is only set by BeginBlock
+	if meter := ctx.BlockGasMeter(); meter != nil {
+		k.UpdateBaseGasPrice(ctx, meter.GasConsumed())
+	}
+
+	// Nothing to sync on a chain without guardian sets (e.g. in tests)
+	if k.GetGuardianSetCount(ctx) == 0 {
+		return
+	}
+
+	if err := k.SyncGuardianValidators(ctx); err != nil {
+		k.Logger(ctx).Error("failed to sync guardian validators", "error", err)
+	}
+}
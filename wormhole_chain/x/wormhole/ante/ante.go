@@ -0,0 +1,105 @@
+package ante
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/wormhole-foundation/wormhole-chain/x/wormhole/keeper"
+	"github.com/wormhole-foundation/wormhole-chain/x/wormhole/types"
+)
+
+// GovernanceSubmitterDecorator rejects transactions executing governance VAAs
+// of any module unless all their signers are governance submitters of the
+// wormhole config. Governance can set submitters during an incident, so that
+// only designated accounts can land governance actions and nobody can front-run
+// them with VAAs picked from the mempool. Without submitters anyone can submit
+// governance VAAs. It runs ahead of the default ante handler, so rejected
+// transactions fail before fees are deducted.
+type GovernanceSubmitterDecorator struct {
+	k keeper.Keeper
+}
+
+func NewGovernanceSubmitterDecorator(k keeper.Keeper) GovernanceSubmitterDecorator {
+	return GovernanceSubmitterDecorator{k: k}
+}
+
+func (d GovernanceSubmitterDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (sdk.Context, error) {
+	config, ok := d.k.GetConfig(ctx)
+	if !ok || len(config.GovernanceSubmitters) == 0 {
+		return next(ctx, tx, simulate)
+	}
+
+	for _, msg := range tx.GetMsgs() {
+		if _, ok := msg.(types.GovernanceVAAMsg); !ok {
+			continue
+		}
+		for _, signer := range msg.GetSigners() {
+			if !config.AllowsGovernanceSubmitter(signer) {
+				return ctx, sdkerrors.Wrapf(types.ErrGovernanceSubmitterNotAllowed, "%s", signer)
+			}
+		}
+	}
+
+	return next(ctx, tx, simulate)
+}
+
+// FeeMarketDecorator rejects transactions whose fee does not cover their gas
+// limit at the base gas price of the fee market, if the config sets one.
+// Transactions that only redeem VAAs need to cover the minimum gas price of
+// the fee market instead, so that redemptions stay affordable while a surge
+// drives the base gas price up. Unlike the minimum gas prices of validators,
+// it applies in DeliverTx too. Genesis transactions and simulations are exempt.
+type FeeMarketDecorator struct {
+	k keeper.Keeper
+}
+
+func NewFeeMarketDecorator(k keeper.Keeper) FeeMarketDecorator {
+	return FeeMarketDecorator{k: k}
+}
+
+func (d FeeMarketDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (sdk.Context, error) {
+	if simulate || ctx.BlockHeight() == 0 {
+		return next(ctx, tx, simulate)
+	}
+	market, gasPrice, found := d.k.GetFeeMarket(ctx)
+	if !found {
+		return next(ctx, tx, simulate)
+	}
+
+	feeTx, ok := tx.(sdk.FeeTx)
+	if !ok {
+		return ctx, sdkerrors.Wrap(sdkerrors.ErrTxDecode, "Tx must be a FeeTx")
+	}
+	if onlyRedemptions(tx) {
+		gasPrice = market.MinGasPrice
+	}
+
+	required := gasPrice.Mul(sdk.NewDecFromInt(sdk.NewIntFromUint64(feeTx.GetGas()))).Ceil().RoundInt()
+	if fee := feeTx.GetFee().AmountOf(market.Denom); fee.LT(required) {
+		return ctx, sdkerrors.Wrapf(types.ErrInsufficientGasPrice, "got %s%s, required %s%s at a gas price of %s", fee, market.Denom, required, market.Denom, gasPrice)
+	}
+
+	return next(ctx, tx, simulate)
+}
+
+// onlyRedemptions returns whether all messages of tx redeem VAAs.
+func onlyRedemptions(tx sdk.Tx) bool {
+	msgs := tx.GetMsgs()
+	for _, msg := range msgs {
+		if _, ok := msg.(types.RedemptionMsg); !ok {
+			return false
+		}
+	}
+	return len(msgs) > 0
+}
+
+// NewAnteHandler returns an ante handler that restricts the submitters of
+// governance VAAs and enforces the fee market before next.
+func NewAnteHandler(k keeper.Keeper, next sdk.AnteHandler) sdk.AnteHandler {
+	submitters := NewGovernanceSubmitterDecorator(k)
+	feeMarket := NewFeeMarketDecorator(k)
+	return func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, error) {
+		return submitters.AnteHandle(ctx, tx, simulate, func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, error) {
+			return feeMarket.AnteHandle(ctx, tx, simulate, next)
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package ante_test
+
+import (
+	"math"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	keepertest "github.com/wormhole-foundation/wormhole-chain/testutil/keeper"
+	tokenbridgetypes "github.com/wormhole-foundation/wormhole-chain/x/tokenbridge/types"
+	"github.com/wormhole-foundation/wormhole-chain/x/wormhole/ante"
+	"github.com/wormhole-foundation/wormhole-chain/x/wormhole/types"
+)
+
+type mockTx struct {
+	msgs []sdk.Msg
+}
+
+func (tx mockTx) GetMsgs() []sdk.Msg   { return tx.msgs }
+func (tx mockTx) ValidateBasic() error { return nil }
+
+type mockFeeTx struct {
+	mockTx
+	gas uint64
+	fee sdk.Coins
+}
+
+func (tx mockFeeTx) GetGas() uint64             { return tx.gas }
+func (tx mockFeeTx) GetFee() sdk.Coins          { return tx.fee }
+func (tx mockFeeTx) FeePayer() sdk.AccAddress   { return nil }
+func (tx mockFeeTx) FeeGranter() sdk.AccAddress { return nil }
+
+func TestGovernanceSubmitterDecorator(t *testing.T) {
+	k, ctx := keepertest.WormholeKeeper(t)
+	alice := sdk.AccAddress([]byte("alice_______________")).String()
+	bob := sdk.AccAddress([]byte("bob_________________")).String()
+
+	tests := []struct {
+		label      string
+		submitters []string
+		msgs       []sdk.Msg
+		err        error
+	}{
+		{label: "no submitters", msgs: []sdk.Msg{types.NewMsgExecuteGovernanceVAA([]byte{0x01}, bob)}},
+		{label: "allowed submitter", submitters: []string{alice}, msgs: []sdk.Msg{types.NewMsgExecuteGovernanceVAA([]byte{0x01}, alice)}},
+		{label: "other submitter", submitters: []string{alice}, msgs: []sdk.Msg{types.NewMsgExecuteGovernanceVAA([]byte{0x01}, bob)}, err: types.ErrGovernanceSubmitterNotAllowed},
+		{label: "store code", submitters: []string{alice}, msgs: []sdk.Msg{&types.MsgStoreCode{Signer: bob}}, err: types.ErrGovernanceSubmitterNotAllowed},
+		{label: "instantiate contract", submitters: []string{alice}, msgs: []sdk.Msg{&types.MsgInstantiateContract{Signer: bob}}, err: types.ErrGovernanceSubmitterNotAllowed},
+		{label: "token bridge governance", submitters: []string{alice}, msgs: []sdk.Msg{tokenbridgetypes.NewMsgExecuteGovernanceVAA(bob, []byte{0x01})}, err: types.ErrGovernanceSubmitterNotAllowed},
+		{label: "token bridge transfer", submitters: []string{alice}, msgs: []sdk.Msg{tokenbridgetypes.NewMsgExecuteVAA(bob, []byte{0x01})}},
+		{label: "other message", submitters: []string{alice}, msgs: []sdk.Msg{&types.MsgPostMessage{Signer: bob}}},
+		{
+			label:      "any message of the transaction",
+			submitters: []string{alice},
+			msgs:       []sdk.Msg{&types.MsgPostMessage{Signer: bob}, types.NewMsgExecuteGovernanceVAA([]byte{0x01}, bob)},
+			err:        types.ErrGovernanceSubmitterNotAllowed,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.label, func(t *testing.T) {
+			k.SetConfig(ctx, types.Config{GovernanceSubmitters: tc.submitters})
+
+			nextCalled := false
+			next := func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, error) {
+				nextCalled = true
+				return ctx, nil
+			}
+
+			_, err := ante.NewAnteHandler(*k, next)(ctx, mockTx{msgs: tc.msgs}, false)
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				assert.False(t, nextCalled)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, nextCalled)
+		})
+	}
+}
+
+func TestFeeMarketDecorator(t *testing.T) {
+	k, ctx := keepertest.WormholeKeeper(t)
+	ctx = ctx.WithBlockHeight(1)
+	relayer := sdk.AccAddress([]byte("relayer_____________")).String()
+	redemption := tokenbridgetypes.NewMsgExecuteVAA(relayer, []byte{0x01})
+	post := &types.MsgPostMessage{Signer: relayer}
+
+	k.SetConfig(ctx, types.Config{FeeMarket: &types.FeeMarket{
+		Denom:             "uworm",
+		MinGasPrice:       sdk.MustNewDecFromStr("0.01"),
+		MaxGasPrice:       sdk.ZeroDec(),
+		TargetBlockGas:    1000,
+		ChangeDenominator: 8,
+	}})
+	k.SetBaseGasPrice(ctx, sdk.MustNewDecFromStr("0.05"))
+
+	tests := []struct {
+		label    string
+		ctx      sdk.Context
+		tx       sdk.Tx
+		simulate bool
+		err      error
+	}{
+		{label: "base gas price", ctx: ctx, tx: mockFeeTx{mockTx{[]sdk.Msg{post}}, 1000, sdk.NewCoins(sdk.NewInt64Coin("uworm", 50))}},
+		{label: "below base gas price", ctx: ctx, tx: mockFeeTx{mockTx{[]sdk.Msg{post}}, 1000, sdk.NewCoins(sdk.NewInt64Coin("uworm", 49))}, err: types.ErrInsufficientGasPrice},
+		{label: "other denom", ctx: ctx, tx: mockFeeTx{mockTx{[]sdk.Msg{post}}, 1000, sdk.NewCoins(sdk.NewInt64Coin("uusdc", 1000))}, err: types.ErrInsufficientGasPrice},
+		{label: "fractional fee rounds up", ctx: ctx, tx: mockFeeTx{mockTx{[]sdk.Msg{post}}, 1001, sdk.NewCoins(sdk.NewInt64Coin("uworm", 50))}, err: types.ErrInsufficientGasPrice},
+		{label: "gas above max int64", ctx: ctx, tx: mockFeeTx{mockTx{[]sdk.Msg{post}}, math.MaxUint64, sdk.NewCoins(sdk.NewInt64Coin("uworm", 50))}, err: types.ErrInsufficientGasPrice},
+		{label: "redemptions at min gas price", ctx: ctx, tx: mockFeeTx{mockTx{[]sdk.Msg{redemption, redemption}}, 1000, sdk.NewCoins(sdk.NewInt64Coin("uworm", 10))}},
+		{label: "redemptions below min gas price", ctx: ctx, tx: mockFeeTx{mockTx{[]sdk.Msg{redemption}}, 1000, sdk.NewCoins(sdk.NewInt64Coin("uworm", 9))}, err: types.ErrInsufficientGasPrice},
+		{label: "redemption with other message", ctx: ctx, tx: mockFeeTx{mockTx{[]sdk.Msg{redemption, post}}, 1000, sdk.NewCoins(sdk.NewInt64Coin("uworm", 10))}, err: types.ErrInsufficientGasPrice},
+		{label: "simulation", ctx: ctx, tx: mockFeeTx{mockTx{[]sdk.Msg{post}}, 1000, nil}, simulate: true},
+		{label: "genesis transaction", ctx: ctx.WithBlockHeight(0), tx: mockFeeTx{mockTx{[]sdk.Msg{post}}, 1000, nil}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.label, func(t *testing.T) {
+			nextCalled := false
+			next := func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, error) {
+				nextCalled = true
+				return ctx, nil
+			}
+
+			_, err := ante.NewAnteHandler(*k, next)(tc.ctx, tc.tx, tc.simulate)
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				assert.False(t, nextCalled)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, nextCalled)
+		})
+	}
+
+	// Without a fee market any fee is accepted
+	k.SetConfig(ctx, types.Config{})
+	_, err := ante.NewAnteHandler(*k, func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, error) {
+		return ctx, nil
+	})(ctx, mockFeeTx{mockTx{[]sdk.Msg{post}}, 1000, nil}, false)
+	require.NoError(t, err)
+}
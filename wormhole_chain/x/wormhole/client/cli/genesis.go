@@ -167,8 +167,6 @@ func ImportKeyFromHex(privHex string) (*ecdsa.PrivateKey, error) {
 }
 
 func parseVaaFromFlags(cmd *cobra.Command) (vaa.VAA, error) {
-	var GOVERNANCE_EMITTER = [32]byte{00, 00, 00, 00, 00, 00, 00, 00, 00, 00, 00, 00, 00, 00, 00, 00, 00, 00, 00, 00, 00, 00, 00, 00, 00, 00, 00, 00, 00, 00, 00, 04}
-
 	emitterChain, err := cmd.Flags().GetUint16(FLAG_EMITTER_CHAIN)
 	if err != nil {
 		return vaa.VAA{}, err
@@ -203,7 +201,7 @@ func parseVaaFromFlags(cmd *cobra.Command) (vaa.VAA, error) {
 		Sequence:         seq,
 		ConsistencyLevel: uint8(32),
 		EmitterChain:     vaa.ChainID(emitterChain),
-		EmitterAddress:   vaa.Address(GOVERNANCE_EMITTER),
+		EmitterAddress:   vaa.GovernanceEmitter,
 		Payload:          payload,
 	}
 	return v, nil
@@ -35,6 +35,14 @@ func GetQueryCmd(queryRoute string) *cobra.Command {
 	cmd.AddCommand(CmdListGuardianValidator())
 	cmd.AddCommand(CmdShowGuardianValidator())
 	cmd.AddCommand(CmdLatestGuardianSetIndex())
+	cmd.AddCommand(CmdListEmitterRateLimit())
+	cmd.AddCommand(CmdShowEmitterRateLimit())
+	cmd.AddCommand(CmdListGuardianSetUpgrade())
+	cmd.AddCommand(CmdShowGuardianSetUpgrade())
+	cmd.AddCommand(CmdListGovernanceVAA())
+	cmd.AddCommand(CmdShowGovernanceVAA())
+	cmd.AddCommand(CmdVerifyVAA())
+	cmd.AddCommand(CmdBaseGasPrice())
 
 	// this line is used by starport scaffolding # 1
 
@@ -10,6 +10,7 @@ import (
 	"google.golang.org/grpc/status"
 
 	"github.com/wormhole-foundation/wormhole-chain/testutil/network"
+	"github.com/wormhole-foundation/wormhole-chain/testutil/nullify"
 	"github.com/wormhole-foundation/wormhole-chain/x/wormhole/client/cli"
 	"github.com/wormhole-foundation/wormhole-chain/x/wormhole/types"
 )
@@ -60,7 +61,10 @@ func TestShowConfig(t *testing.T) {
 				var resp types.QueryGetConfigResponse
 				require.NoError(t, net.Config.Codec.UnmarshalJSON(out.Bytes(), &resp))
 				require.NotNil(t, resp.Config)
-				require.Equal(t, tc.obj, resp.Config)
+				require.Equal(t,
+					nullify.Fill(&tc.obj),
+					nullify.Fill(&resp.Config),
+				)
 			}
 		})
 	}
@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"context"
+
+	"encoding/hex"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/spf13/cobra"
+	"github.com/wormhole-foundation/wormhole-chain/x/wormhole/types"
+)
+
+func CmdListEmitterRateLimit() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list-emitter-rate-limit",
+		Short: "list all emitter-rate-limit",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+
+			pageReq, err := client.ReadPageRequest(cmd.Flags())
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			params := &types.QueryAllEmitterRateLimitRequest{
+				Pagination: pageReq,
+			}
+
+			res, err := queryClient.EmitterRateLimitAll(context.Background(), params)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddPaginationFlagsToCmd(cmd, cmd.Use)
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
+
+func CmdShowEmitterRateLimit() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show-emitter-rate-limit [emitter]",
+		Short: "shows an emitter-rate-limit",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			argEmitter, err := hex.DecodeString(args[0])
+
+			if err != nil {
+				return err
+			}
+
+			params := &types.QueryGetEmitterRateLimitRequest{
+				Emitter: argEmitter,
+			}
+
+			res, err := queryClient.EmitterRateLimit(context.Background(), params)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
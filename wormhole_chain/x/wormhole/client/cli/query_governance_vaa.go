@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/spf13/cobra"
+	"github.com/wormhole-foundation/wormhole-chain/x/wormhole/types"
+)
+
+const (
+	FlagGovernanceModule = "module"
+	FlagGovernanceAction = "action"
+)
+
+func CmdListGovernanceVAA() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list-governance-vaa",
+		Short: "list all executed GovernanceVAA, ordered by module, action and sequence",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+
+			pageReq, err := client.ReadPageRequest(cmd.Flags())
+			if err != nil {
+				return err
+			}
+
+			module, err := cmd.Flags().GetString(FlagGovernanceModule)
+			if err != nil {
+				return err
+			}
+
+			action, err := cmd.Flags().GetUint32(FlagGovernanceAction)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			params := &types.QueryAllGovernanceVAARequest{
+				Pagination: pageReq,
+				Module:     module,
+				Action:     action,
+			}
+
+			res, err := queryClient.GovernanceVAAAll(context.Background(), params)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	cmd.Flags().String(FlagGovernanceModule, "", "only list the VAAs of the module, e.g. Core or TokenBridge")
+	cmd.Flags().Uint32(FlagGovernanceAction, 0, "only list the VAAs of the action of --module")
+	flags.AddPaginationFlagsToCmd(cmd, cmd.Use)
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
+
+func CmdShowGovernanceVAA() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show-governance-vaa [module] [action] [sequence]",
+		Short: "shows an executed GovernanceVAA",
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			action, err := strconv.ParseUint(args[1], 10, 8)
+			if err != nil {
+				return err
+			}
+
+			sequence, err := strconv.ParseUint(args[2], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			params := &types.QueryGetGovernanceVAARequest{
+				Module:   args[0],
+				Action:   uint32(action),
+				Sequence: sequence,
+			}
+
+			res, err := queryClient.GovernanceVAA(context.Background(), params)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/spf13/cobra"
+	"github.com/wormhole-foundation/wormhole-chain/x/wormhole/types"
+)
+
+func CmdListGuardianSetUpgrade() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list-guardian-set-upgrade",
+		Short: "list all GuardianSetUpgrade in the order of the installed guardian sets",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+
+			pageReq, err := client.ReadPageRequest(cmd.Flags())
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			params := &types.QueryAllGuardianSetUpgradeRequest{
+				Pagination: pageReq,
+			}
+
+			res, err := queryClient.GuardianSetUpgradeAll(context.Background(), params)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddPaginationFlagsToCmd(cmd, cmd.Use)
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
+
+func CmdShowGuardianSetUpgrade() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show-guardian-set-upgrade [id]",
+		Short: "shows the GuardianSetUpgrade that installed a guardian set",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			id, err := strconv.ParseUint(args[0], 10, 32)
+			if err != nil {
+				return err
+			}
+
+			params := &types.QueryGetGuardianSetUpgradeRequest{
+				Index: uint32(id),
+			}
+
+			res, err := queryClient.GuardianSetUpgrade(context.Background(), params)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
@@ -24,6 +24,7 @@ func GetTxCmd() *cobra.Command {
 	cmd.AddCommand(CmdRegisterAccountAsGuardian())
 	cmd.AddCommand(CmdStoreCode())
 	cmd.AddCommand(CmdInstantiateContract())
+	cmd.AddCommand(CmdPostMessage())
 	// this line is used by starport scaffolding # 1
 
 	return cmd
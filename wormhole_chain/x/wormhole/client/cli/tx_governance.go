@@ -192,3 +192,830 @@ func NewCmdSubmitWormholeGovernanceMessageProposal() *cobra.Command {
 
 	return cmd
 }
+
+const FlagEmitter = "emitter"
+const FlagMaxMessages = "max-messages"
+const FlagWindowBlocks = "window-blocks"
+const FlagRemove = "remove"
+
+// NewCmdSubmitEmitterRateLimitProposal implements a command handler for submitting an emitter rate limit governance
+// proposal.
+func NewCmdSubmitEmitterRateLimitProposal() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "emitter-rate-limit [flags]",
+		Args:  cobra.ExactArgs(0),
+		Short: "Submit an emitter rate limit proposal",
+		Long:  "Submit a proposal to limit the number of messages an emitter can post per window of blocks (0 max messages blocks the emitter), or to remove the limit",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			from := clientCtx.GetFromAddress()
+
+			depositStr, err := cmd.Flags().GetString(cli.FlagDeposit)
+			if err != nil {
+				return err
+			}
+
+			deposit, err := sdk.ParseCoinsNormalized(depositStr)
+			if err != nil {
+				return err
+			}
+
+			title, err := cmd.Flags().GetString(cli.FlagTitle)
+			if err != nil {
+				return err
+			}
+
+			description, err := cmd.Flags().GetString(cli.FlagDescription)
+			if err != nil {
+				return err
+			}
+
+			emitter, err := cmd.Flags().GetBytesHex(FlagEmitter)
+			if err != nil {
+				return err
+			}
+
+			maxMessages, err := cmd.Flags().GetUint64(FlagMaxMessages)
+			if err != nil {
+				return err
+			}
+
+			windowBlocks, err := cmd.Flags().GetUint64(FlagWindowBlocks)
+			if err != nil {
+				return err
+			}
+
+			remove, err := cmd.Flags().GetBool(FlagRemove)
+			if err != nil {
+				return err
+			}
+
+			content := types.NewEmitterRateLimitProposal(title, description, types.EmitterRateLimit{
+				Emitter:      emitter,
+				MaxMessages:  maxMessages,
+				WindowBlocks: windowBlocks,
+			})
+			content.Remove = remove
+			err = content.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			msg, err := gov.NewMsgSubmitProposal(content, deposit, from)
+			if err != nil {
+				return err
+			}
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	cmd.Flags().String(cli.FlagTitle, "", "title of proposal")
+	cmd.Flags().String(cli.FlagDescription, "", "description of proposal")
+	cmd.Flags().String(cli.FlagDeposit, "", "deposit of proposal")
+	cmd.Flags().BytesHex(FlagEmitter, []byte{}, "emitter address to rate limit (32 bytes)")
+	cmd.Flags().Uint64(FlagMaxMessages, 0, "number of messages the emitter can post per window (0 to block the emitter)")
+	cmd.Flags().Uint64(FlagWindowBlocks, 1, "length of a window in blocks")
+	cmd.Flags().Bool(FlagRemove, false, "remove the rate limit of the emitter instead of setting it")
+	cmd.MarkFlagRequired(cli.FlagTitle)
+	cmd.MarkFlagRequired(cli.FlagDescription)
+	cmd.MarkFlagRequired(FlagEmitter)
+
+	return cmd
+}
+
+const FlagVAAVersions = "vaa-versions"
+
+// NewCmdSubmitAcceptedVAAVersionsProposal implements a command handler for submitting a governance proposal to set the
+// accepted VAA versions.
+func NewCmdSubmitAcceptedVAAVersionsProposal() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "accepted-vaa-versions [flags]",
+		Args:  cobra.ExactArgs(0),
+		Short: "Submit an accepted VAA versions proposal",
+		Long:  "Submit a proposal to set the VAA versions that are accepted by the chain",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			from := clientCtx.GetFromAddress()
+
+			depositStr, err := cmd.Flags().GetString(cli.FlagDeposit)
+			if err != nil {
+				return err
+			}
+
+			deposit, err := sdk.ParseCoinsNormalized(depositStr)
+			if err != nil {
+				return err
+			}
+
+			title, err := cmd.Flags().GetString(cli.FlagTitle)
+			if err != nil {
+				return err
+			}
+
+			description, err := cmd.Flags().GetString(cli.FlagDescription)
+			if err != nil {
+				return err
+			}
+
+			versions, err := cmd.Flags().GetUintSlice(FlagVAAVersions)
+			if err != nil {
+				return err
+			}
+
+			acceptedVersions := make([]uint32, len(versions))
+			for i, version := range versions {
+				acceptedVersions[i] = uint32(version)
+			}
+
+			content := types.NewAcceptedVAAVersionsProposal(title, description, acceptedVersions)
+			err = content.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			msg, err := gov.NewMsgSubmitProposal(content, deposit, from)
+			if err != nil {
+				return err
+			}
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	cmd.Flags().String(cli.FlagTitle, "", "title of proposal")
+	cmd.Flags().String(cli.FlagDescription, "", "description of proposal")
+	cmd.Flags().String(cli.FlagDeposit, "", "deposit of proposal")
+	cmd.Flags().UintSlice(FlagVAAVersions, []uint{}, "comma separated list of accepted VAA versions")
+	cmd.MarkFlagRequired(cli.FlagTitle)
+	cmd.MarkFlagRequired(cli.FlagDescription)
+	cmd.MarkFlagRequired(FlagVAAVersions)
+
+	return cmd
+}
+
+const FlagMessageFees = "fees"
+
+// NewCmdSubmitMessageFeesProposal implements a command handler for submitting a governance proposal to set the fees
+// charged for posting messages.
+func NewCmdSubmitMessageFeesProposal() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "message-fees [flags]",
+		Args:  cobra.ExactArgs(0),
+		Short: "Submit a message fees proposal",
+		Long:  "Submit a proposal to set the fee charged for posting a message, as one coin per denom it can be paid in. An empty list makes posting messages free",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			from := clientCtx.GetFromAddress()
+
+			depositStr, err := cmd.Flags().GetString(cli.FlagDeposit)
+			if err != nil {
+				return err
+			}
+
+			deposit, err := sdk.ParseCoinsNormalized(depositStr)
+			if err != nil {
+				return err
+			}
+
+			title, err := cmd.Flags().GetString(cli.FlagTitle)
+			if err != nil {
+				return err
+			}
+
+			description, err := cmd.Flags().GetString(cli.FlagDescription)
+			if err != nil {
+				return err
+			}
+
+			feesStr, err := cmd.Flags().GetString(FlagMessageFees)
+			if err != nil {
+				return err
+			}
+
+			fees, err := sdk.ParseCoinsNormalized(feesStr)
+			if err != nil {
+				return err
+			}
+
+			content := types.NewMessageFeesProposal(title, description, fees)
+			err = content.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			msg, err := gov.NewMsgSubmitProposal(content, deposit, from)
+			if err != nil {
+				return err
+			}
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	cmd.Flags().String(cli.FlagTitle, "", "title of proposal")
+	cmd.Flags().String(cli.FlagDescription, "", "description of proposal")
+	cmd.Flags().String(cli.FlagDeposit, "", "deposit of proposal")
+	cmd.Flags().String(FlagMessageFees, "", "comma separated list of message fees, e.g. 100uworm,1000uusdc")
+	cmd.MarkFlagRequired(cli.FlagTitle)
+	cmd.MarkFlagRequired(cli.FlagDescription)
+
+	return cmd
+}
+
+const (
+	FlagMaxPayloadSize = "max-payload-size"
+	FlagMaxSignatures  = "max-signatures"
+)
+
+// NewCmdSubmitVAALimitsProposal implements a command handler for submitting a governance proposal to limit the payload
+// size and signature count of the VAAs accepted by the chain.
+func NewCmdSubmitVAALimitsProposal() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "vaa-limits [flags]",
+		Args:  cobra.ExactArgs(0),
+		Short: "Submit a VAA limits proposal",
+		Long:  "Submit a proposal to set the maximum payload size and signature count of the VAAs accepted by the chain. A zero limit defaults to the bound enforced on every VAA",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			from := clientCtx.GetFromAddress()
+
+			depositStr, err := cmd.Flags().GetString(cli.FlagDeposit)
+			if err != nil {
+				return err
+			}
+
+			deposit, err := sdk.ParseCoinsNormalized(depositStr)
+			if err != nil {
+				return err
+			}
+
+			title, err := cmd.Flags().GetString(cli.FlagTitle)
+			if err != nil {
+				return err
+			}
+
+			description, err := cmd.Flags().GetString(cli.FlagDescription)
+			if err != nil {
+				return err
+			}
+
+			maxPayloadSize, err := cmd.Flags().GetUint32(FlagMaxPayloadSize)
+			if err != nil {
+				return err
+			}
+
+			maxSignatures, err := cmd.Flags().GetUint32(FlagMaxSignatures)
+			if err != nil {
+				return err
+			}
+
+			content := types.NewVAALimitsProposal(title, description, maxPayloadSize, maxSignatures)
+			err = content.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			msg, err := gov.NewMsgSubmitProposal(content, deposit, from)
+			if err != nil {
+				return err
+			}
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	cmd.Flags().String(cli.FlagTitle, "", "title of proposal")
+	cmd.Flags().String(cli.FlagDescription, "", "description of proposal")
+	cmd.Flags().String(cli.FlagDeposit, "", "deposit of proposal")
+	cmd.Flags().Uint32(FlagMaxPayloadSize, 0, "maximum VAA payload size in bytes")
+	cmd.Flags().Uint32(FlagMaxSignatures, 0, "maximum number of VAA signatures")
+	cmd.MarkFlagRequired(cli.FlagTitle)
+	cmd.MarkFlagRequired(cli.FlagDescription)
+
+	return cmd
+}
+
+const FlagMessageType = "message-type"
+
+// NewCmdSubmitMinGuardianSetIndexProposal implements a command handler for submitting a governance proposal to require
+// VAAs of a message type to be signed by a recent guardian set.
+func NewCmdSubmitMinGuardianSetIndexProposal() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "min-guardian-set-index [flags]",
+		Args:  cobra.ExactArgs(0),
+		Short: "Submit a minimum guardian set index proposal",
+		Long:  "Submit a proposal to reject VAAs of a message type that are signed by a guardian set older than the given index, even before the set expires. A zero index accepts any guardian set again",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			from := clientCtx.GetFromAddress()
+
+			depositStr, err := cmd.Flags().GetString(cli.FlagDeposit)
+			if err != nil {
+				return err
+			}
+
+			deposit, err := sdk.ParseCoinsNormalized(depositStr)
+			if err != nil {
+				return err
+			}
+
+			title, err := cmd.Flags().GetString(cli.FlagTitle)
+			if err != nil {
+				return err
+			}
+
+			description, err := cmd.Flags().GetString(cli.FlagDescription)
+			if err != nil {
+				return err
+			}
+
+			messageType, err := cmd.Flags().GetString(FlagMessageType)
+			if err != nil {
+				return err
+			}
+
+			guardianSetIndex, err := cmd.Flags().GetUint32(FlagGuardianSetIndex)
+			if err != nil {
+				return err
+			}
+
+			content := types.NewMinGuardianSetIndexProposal(title, description, messageType, guardianSetIndex)
+			err = content.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			msg, err := gov.NewMsgSubmitProposal(content, deposit, from)
+			if err != nil {
+				return err
+			}
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	cmd.Flags().String(cli.FlagTitle, "", "title of proposal")
+	cmd.Flags().String(cli.FlagDescription, "", "description of proposal")
+	cmd.Flags().String(cli.FlagDeposit, "", "deposit of proposal")
+	cmd.Flags().String(FlagMessageType, "", "message type: governance, token_transfer, asset_meta or token_transfer_with_payload")
+	cmd.Flags().Uint32(FlagGuardianSetIndex, 0, "oldest guardian set allowed to sign VAAs of the message type")
+	cmd.MarkFlagRequired(cli.FlagTitle)
+	cmd.MarkFlagRequired(cli.FlagDescription)
+	cmd.MarkFlagRequired(FlagMessageType)
+
+	return cmd
+}
+
+const FlagMaxExecutionsPerSender = "max-executions-per-sender"
+
+// NewCmdSubmitVAAExecutionLimitProposal implements a command handler for submitting a governance proposal to limit the
+// VAAs a single signer can execute per block.
+func NewCmdSubmitVAAExecutionLimitProposal() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "vaa-execution-limit [flags]",
+		Args:  cobra.ExactArgs(0),
+		Short: "Submit a VAA execution limit proposal",
+		Long:  "Submit a proposal to set the maximum number of VAAs a single signer can execute per block. A zero limit removes it",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			from := clientCtx.GetFromAddress()
+
+			depositStr, err := cmd.Flags().GetString(cli.FlagDeposit)
+			if err != nil {
+				return err
+			}
+
+			deposit, err := sdk.ParseCoinsNormalized(depositStr)
+			if err != nil {
+				return err
+			}
+
+			title, err := cmd.Flags().GetString(cli.FlagTitle)
+			if err != nil {
+				return err
+			}
+
+			description, err := cmd.Flags().GetString(cli.FlagDescription)
+			if err != nil {
+				return err
+			}
+
+			maxExecutions, err := cmd.Flags().GetUint32(FlagMaxExecutionsPerSender)
+			if err != nil {
+				return err
+			}
+
+			content := types.NewVAAExecutionLimitProposal(title, description, maxExecutions)
+			err = content.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			msg, err := gov.NewMsgSubmitProposal(content, deposit, from)
+			if err != nil {
+				return err
+			}
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	cmd.Flags().String(cli.FlagTitle, "", "title of proposal")
+	cmd.Flags().String(cli.FlagDescription, "", "description of proposal")
+	cmd.Flags().String(cli.FlagDeposit, "", "deposit of proposal")
+	cmd.Flags().Uint32(FlagMaxExecutionsPerSender, 0, "maximum number of VAAs a signer can execute per block")
+	cmd.MarkFlagRequired(cli.FlagTitle)
+	cmd.MarkFlagRequired(cli.FlagDescription)
+
+	return cmd
+}
+
+const FlagSubmitters = "submitters"
+
+// NewCmdSubmitGovernanceSubmittersProposal implements a command handler for submitting a governance proposal to
+// restrict the accounts allowed to submit governance VAAs.
+func NewCmdSubmitGovernanceSubmittersProposal() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "governance-submitters [flags]",
+		Args:  cobra.ExactArgs(0),
+		Short: "Submit a governance submitters proposal",
+		Long:  "Submit a proposal to restrict the accounts allowed to submit governance VAAs. Without submitters anyone can submit them",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			from := clientCtx.GetFromAddress()
+
+			depositStr, err := cmd.Flags().GetString(cli.FlagDeposit)
+			if err != nil {
+				return err
+			}
+
+			deposit, err := sdk.ParseCoinsNormalized(depositStr)
+			if err != nil {
+				return err
+			}
+
+			title, err := cmd.Flags().GetString(cli.FlagTitle)
+			if err != nil {
+				return err
+			}
+
+			description, err := cmd.Flags().GetString(cli.FlagDescription)
+			if err != nil {
+				return err
+			}
+
+			submitters, err := cmd.Flags().GetStringSlice(FlagSubmitters)
+			if err != nil {
+				return err
+			}
+
+			content := types.NewGovernanceSubmittersProposal(title, description, submitters)
+			err = content.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			msg, err := gov.NewMsgSubmitProposal(content, deposit, from)
+			if err != nil {
+				return err
+			}
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	cmd.Flags().String(cli.FlagTitle, "", "title of proposal")
+	cmd.Flags().String(cli.FlagDescription, "", "description of proposal")
+	cmd.Flags().String(cli.FlagDeposit, "", "deposit of proposal")
+	cmd.Flags().StringSlice(FlagSubmitters, []string{}, "comma separated list of the accounts allowed to submit governance VAAs")
+	cmd.MarkFlagRequired(cli.FlagTitle)
+	cmd.MarkFlagRequired(cli.FlagDescription)
+
+	return cmd
+}
+
+const (
+	FlagFeeDenom          = "denom"
+	FlagMinGasPrice       = "min-gas-price"
+	FlagMaxGasPrice       = "max-gas-price"
+	FlagTargetBlockGas    = "target-block-gas"
+	FlagChangeDenominator = "change-denominator"
+)
+
+// NewCmdSubmitFeeMarketProposal implements a command handler for submitting a governance proposal to set the fee
+// market.
+func NewCmdSubmitFeeMarketProposal() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "fee-market [flags]",
+		Args:  cobra.ExactArgs(0),
+		Short: "Submit a fee market proposal",
+		Long:  "Submit a proposal to set the fee market, which adjusts the gas price transactions have to pay to the gas used by each block, or to remove it",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			from := clientCtx.GetFromAddress()
+
+			depositStr, err := cmd.Flags().GetString(cli.FlagDeposit)
+			if err != nil {
+				return err
+			}
+
+			deposit, err := sdk.ParseCoinsNormalized(depositStr)
+			if err != nil {
+				return err
+			}
+
+			title, err := cmd.Flags().GetString(cli.FlagTitle)
+			if err != nil {
+				return err
+			}
+
+			description, err := cmd.Flags().GetString(cli.FlagDescription)
+			if err != nil {
+				return err
+			}
+
+			remove, err := cmd.Flags().GetBool(FlagRemove)
+			if err != nil {
+				return err
+			}
+
+			var feeMarket *types.FeeMarket
+			if !remove {
+				denom, err := cmd.Flags().GetString(FlagFeeDenom)
+				if err != nil {
+					return err
+				}
+
+				minGasPriceStr, err := cmd.Flags().GetString(FlagMinGasPrice)
+				if err != nil {
+					return err
+				}
+
+				minGasPrice, err := sdk.NewDecFromStr(minGasPriceStr)
+				if err != nil {
+					return err
+				}
+
+				maxGasPriceStr, err := cmd.Flags().GetString(FlagMaxGasPrice)
+				if err != nil {
+					return err
+				}
+
+				maxGasPrice, err := sdk.NewDecFromStr(maxGasPriceStr)
+				if err != nil {
+					return err
+				}
+
+				targetBlockGas, err := cmd.Flags().GetUint64(FlagTargetBlockGas)
+				if err != nil {
+					return err
+				}
+
+				changeDenominator, err := cmd.Flags().GetUint32(FlagChangeDenominator)
+				if err != nil {
+					return err
+				}
+
+				feeMarket = &types.FeeMarket{
+					Denom:             denom,
+					MinGasPrice:       minGasPrice,
+					MaxGasPrice:       maxGasPrice,
+					TargetBlockGas:    targetBlockGas,
+					ChangeDenominator: changeDenominator,
+				}
+			}
+
+			content := types.NewFeeMarketProposal(title, description, feeMarket)
+			err = content.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			msg, err := gov.NewMsgSubmitProposal(content, deposit, from)
+			if err != nil {
+				return err
+			}
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	cmd.Flags().String(cli.FlagTitle, "", "title of proposal")
+	cmd.Flags().String(cli.FlagDescription, "", "description of proposal")
+	cmd.Flags().String(cli.FlagDeposit, "", "deposit of proposal")
+	cmd.Flags().String(FlagFeeDenom, "uworm", "denom fees are paid in")
+	cmd.Flags().String(FlagMinGasPrice, "", "lowest base gas price, paid by transactions that only redeem VAAs")
+	cmd.Flags().String(FlagMaxGasPrice, "0", "highest base gas price (0 for no limit)")
+	cmd.Flags().Uint64(FlagTargetBlockGas, 0, "gas used per block at which the base gas price stays the same")
+	cmd.Flags().Uint32(FlagChangeDenominator, 8, "inverse of the largest change of the base gas price per block")
+	cmd.Flags().Bool(FlagRemove, false, "remove the fee market instead of setting it")
+	cmd.MarkFlagRequired(cli.FlagTitle)
+	cmd.MarkFlagRequired(cli.FlagDescription)
+
+	return cmd
+}
+
+const (
+	FlagEmitterChainID = "emitter-chain-id"
+	FlagTxHash         = "tx-hash"
+	FlagSequence       = "sequence"
+)
+
+// NewCmdSubmitObservationRequestProposal implements a command handler for submitting a governance proposal to have
+// the guardians observe a message again.
+func NewCmdSubmitObservationRequestProposal() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "observation-request [flags]",
+		Args:  cobra.ExactArgs(0),
+		Short: "Submit an observation request proposal",
+		Long:  "Submit a proposal asking the guardians to observe a message again, identified either by the hash of the transaction that emitted it or by its emitter address and sequence",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			from := clientCtx.GetFromAddress()
+
+			depositStr, err := cmd.Flags().GetString(cli.FlagDeposit)
+			if err != nil {
+				return err
+			}
+
+			deposit, err := sdk.ParseCoinsNormalized(depositStr)
+			if err != nil {
+				return err
+			}
+
+			title, err := cmd.Flags().GetString(cli.FlagTitle)
+			if err != nil {
+				return err
+			}
+
+			description, err := cmd.Flags().GetString(cli.FlagDescription)
+			if err != nil {
+				return err
+			}
+
+			chainID, err := cmd.Flags().GetUint16(FlagEmitterChainID)
+			if err != nil {
+				return err
+			}
+
+			txHash, err := cmd.Flags().GetBytesHex(FlagTxHash)
+			if err != nil {
+				return err
+			}
+
+			emitter, err := cmd.Flags().GetBytesHex(FlagEmitter)
+			if err != nil {
+				return err
+			}
+
+			sequence, err := cmd.Flags().GetUint64(FlagSequence)
+			if err != nil {
+				return err
+			}
+
+			content := types.NewObservationRequestProposal(title, description, uint32(chainID), txHash, emitter, sequence)
+			err = content.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			msg, err := gov.NewMsgSubmitProposal(content, deposit, from)
+			if err != nil {
+				return err
+			}
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	cmd.Flags().String(cli.FlagTitle, "", "title of proposal")
+	cmd.Flags().String(cli.FlagDescription, "", "description of proposal")
+	cmd.Flags().String(cli.FlagDeposit, "", "deposit of proposal")
+	cmd.Flags().Uint16(FlagEmitterChainID, 0, "wormhole chain id of the chain the message was emitted on")
+	cmd.Flags().BytesHex(FlagTxHash, []byte{}, "hash of the transaction that emitted the message")
+	cmd.Flags().BytesHex(FlagEmitter, []byte{}, "emitter address of the message (32 bytes), instead of the transaction hash")
+	cmd.Flags().Uint64(FlagSequence, 0, "sequence of the message, with its emitter address")
+	cmd.MarkFlagRequired(cli.FlagTitle)
+	cmd.MarkFlagRequired(cli.FlagDescription)
+	cmd.MarkFlagRequired(FlagEmitterChainID)
+
+	return cmd
+}
+
+const (
+	FlagNumerator   = "numerator"
+	FlagDenominator = "denominator"
+)
+
+// NewCmdSubmitQuorumThresholdProposal implements a command handler for submitting a governance proposal to set the
+// share of a guardian set that has to sign a VAA.
+func NewCmdSubmitQuorumThresholdProposal() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "quorum-threshold [flags]",
+		Args:  cobra.ExactArgs(0),
+		Short: "Submit a quorum threshold proposal",
+		Long:  "Submit a proposal to require VAAs to be signed by more than numerator/denominator of their guardian set, between 2/3 and 1 (all signatures), or to restore the default quorum of more than 2/3",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			from := clientCtx.GetFromAddress()
+
+			depositStr, err := cmd.Flags().GetString(cli.FlagDeposit)
+			if err != nil {
+				return err
+			}
+
+			deposit, err := sdk.ParseCoinsNormalized(depositStr)
+			if err != nil {
+				return err
+			}
+
+			title, err := cmd.Flags().GetString(cli.FlagTitle)
+			if err != nil {
+				return err
+			}
+
+			description, err := cmd.Flags().GetString(cli.FlagDescription)
+			if err != nil {
+				return err
+			}
+
+			remove, err := cmd.Flags().GetBool(FlagRemove)
+			if err != nil {
+				return err
+			}
+
+			var threshold *types.QuorumThreshold
+			if !remove {
+				numerator, err := cmd.Flags().GetUint32(FlagNumerator)
+				if err != nil {
+					return err
+				}
+
+				denominator, err := cmd.Flags().GetUint32(FlagDenominator)
+				if err != nil {
+					return err
+				}
+
+				threshold = &types.QuorumThreshold{
+					Numerator:   numerator,
+					Denominator: denominator,
+				}
+			}
+
+			content := types.NewQuorumThresholdProposal(title, description, threshold)
+			err = content.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			msg, err := gov.NewMsgSubmitProposal(content, deposit, from)
+			if err != nil {
+				return err
+			}
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	cmd.Flags().String(cli.FlagTitle, "", "title of proposal")
+	cmd.Flags().String(cli.FlagDescription, "", "description of proposal")
+	cmd.Flags().String(cli.FlagDeposit, "", "deposit of proposal")
+	cmd.Flags().Uint32(FlagNumerator, 2, "numerator of the share of the guardian set that has to be exceeded")
+	cmd.Flags().Uint32(FlagDenominator, 3, "denominator of the share of the guardian set that has to be exceeded")
+	cmd.Flags().Bool(FlagRemove, false, "restore the default quorum instead of setting a threshold")
+	cmd.MarkFlagRequired(cli.FlagTitle)
+	cmd.MarkFlagRequired(cli.FlagDescription)
+
+	return cmd
+}
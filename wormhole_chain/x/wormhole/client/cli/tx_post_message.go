@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	"github.com/spf13/cobra"
+	"github.com/wormhole-foundation/wormhole-chain/x/wormhole/types"
+)
+
+func CmdPostMessage() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "post-message [nonce] [payload]",
+		Short: "Post a message with the sender as emitter. The payload is hex encoded.",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			argNonce, err := strconv.ParseUint(args[0], 10, 32)
+			if err != nil {
+				return fmt.Errorf("malformed nonce: %w", err)
+			}
+			argPayload, err := hex.DecodeString(args[1])
+			if err != nil {
+				return fmt.Errorf("malformed payload: %w", err)
+			}
+
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgPostMessage(
+				clientCtx.GetFromAddress().String(),
+				uint32(argNonce),
+				argPayload,
+			)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	flags.AddTxFlagsToCmd(cmd)
+
+	return cmd
+}
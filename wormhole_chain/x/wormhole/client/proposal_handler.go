@@ -8,3 +8,13 @@ import (
 
 var GuardianSetUpdateProposalHandler = govclient.NewProposalHandler(cli.NewCmdSubmitGuardianSetUpdateProposal, rest.ProposalGuardianSetUpdateRESTHandler)
 var WormholeGovernanceMessageProposalHandler = govclient.NewProposalHandler(cli.NewCmdSubmitWormholeGovernanceMessageProposal, rest.ProposalWormholeGovernanceMessageRESTHandler)
+var EmitterRateLimitProposalHandler = govclient.NewProposalHandler(cli.NewCmdSubmitEmitterRateLimitProposal, rest.ProposalEmitterRateLimitRESTHandler)
+var AcceptedVAAVersionsProposalHandler = govclient.NewProposalHandler(cli.NewCmdSubmitAcceptedVAAVersionsProposal, rest.ProposalAcceptedVAAVersionsRESTHandler)
+var MessageFeesProposalHandler = govclient.NewProposalHandler(cli.NewCmdSubmitMessageFeesProposal, rest.ProposalMessageFeesRESTHandler)
+var VAALimitsProposalHandler = govclient.NewProposalHandler(cli.NewCmdSubmitVAALimitsProposal, rest.ProposalVAALimitsRESTHandler)
+var MinGuardianSetIndexProposalHandler = govclient.NewProposalHandler(cli.NewCmdSubmitMinGuardianSetIndexProposal, rest.ProposalMinGuardianSetIndexRESTHandler)
+var VAAExecutionLimitProposalHandler = govclient.NewProposalHandler(cli.NewCmdSubmitVAAExecutionLimitProposal, rest.ProposalVAAExecutionLimitRESTHandler)
+var GovernanceSubmittersProposalHandler = govclient.NewProposalHandler(cli.NewCmdSubmitGovernanceSubmittersProposal, rest.ProposalGovernanceSubmittersRESTHandler)
+var FeeMarketProposalHandler = govclient.NewProposalHandler(cli.NewCmdSubmitFeeMarketProposal, rest.ProposalFeeMarketRESTHandler)
+var ObservationRequestProposalHandler = govclient.NewProposalHandler(cli.NewCmdSubmitObservationRequestProposal, rest.ProposalObservationRequestRESTHandler)
+var QuorumThresholdProposalHandler = govclient.NewProposalHandler(cli.NewCmdSubmitQuorumThresholdProposal, rest.ProposalQuorumThresholdRESTHandler)
@@ -39,6 +39,123 @@ type (
 		Proposer    sdk.AccAddress `json:"proposer" yaml:"proposer"`
 		Deposit     sdk.Coins      `json:"deposit" yaml:"deposit"`
 	}
+
+	// EmitterRateLimitProposalReq defines an emitter rate limit proposal request body.
+	EmitterRateLimitProposalReq struct {
+		BaseReq rest.BaseReq `json:"base_req" yaml:"base_req"`
+
+		Title        string         `json:"title" yaml:"title"`
+		Description  string         `json:"description" yaml:"description"`
+		Emitter      []byte         `json:"emitter" yaml:"emitter"`
+		MaxMessages  uint64         `json:"maxMessages" yaml:"maxMessages"`
+		WindowBlocks uint64         `json:"windowBlocks" yaml:"windowBlocks"`
+		Proposer     sdk.AccAddress `json:"proposer" yaml:"proposer"`
+		Deposit      sdk.Coins      `json:"deposit" yaml:"deposit"`
+	}
+
+	// AcceptedVAAVersionsProposalReq defines an accepted VAA versions proposal request body.
+	AcceptedVAAVersionsProposalReq struct {
+		BaseReq rest.BaseReq `json:"base_req" yaml:"base_req"`
+
+		Title       string         `json:"title" yaml:"title"`
+		Description string         `json:"description" yaml:"description"`
+		Versions    []uint32       `json:"versions" yaml:"versions"`
+		Proposer    sdk.AccAddress `json:"proposer" yaml:"proposer"`
+		Deposit     sdk.Coins      `json:"deposit" yaml:"deposit"`
+	}
+
+	// MessageFeesProposalReq defines a message fees proposal request body.
+	MessageFeesProposalReq struct {
+		BaseReq rest.BaseReq `json:"base_req" yaml:"base_req"`
+
+		Title       string         `json:"title" yaml:"title"`
+		Description string         `json:"description" yaml:"description"`
+		Fees        sdk.Coins      `json:"fees" yaml:"fees"`
+		Proposer    sdk.AccAddress `json:"proposer" yaml:"proposer"`
+		Deposit     sdk.Coins      `json:"deposit" yaml:"deposit"`
+	}
+
+	// VAALimitsProposalReq defines a VAA limits proposal request body.
+	VAALimitsProposalReq struct {
+		BaseReq rest.BaseReq `json:"base_req" yaml:"base_req"`
+
+		Title          string         `json:"title" yaml:"title"`
+		Description    string         `json:"description" yaml:"description"`
+		MaxPayloadSize uint32         `json:"max_payload_size" yaml:"max_payload_size"`
+		MaxSignatures  uint32         `json:"max_signatures" yaml:"max_signatures"`
+		Proposer       sdk.AccAddress `json:"proposer" yaml:"proposer"`
+		Deposit        sdk.Coins      `json:"deposit" yaml:"deposit"`
+	}
+
+	// MinGuardianSetIndexProposalReq defines a minimum guardian set index proposal request body.
+	MinGuardianSetIndexProposalReq struct {
+		BaseReq rest.BaseReq `json:"base_req" yaml:"base_req"`
+
+		Title            string         `json:"title" yaml:"title"`
+		Description      string         `json:"description" yaml:"description"`
+		MessageType      string         `json:"message_type" yaml:"message_type"`
+		GuardianSetIndex uint32         `json:"guardian_set_index" yaml:"guardian_set_index"`
+		Proposer         sdk.AccAddress `json:"proposer" yaml:"proposer"`
+		Deposit          sdk.Coins      `json:"deposit" yaml:"deposit"`
+	}
+
+	// VAAExecutionLimitProposalReq defines a VAA execution limit proposal request body.
+	VAAExecutionLimitProposalReq struct {
+		BaseReq rest.BaseReq `json:"base_req" yaml:"base_req"`
+
+		Title                  string         `json:"title" yaml:"title"`
+		Description            string         `json:"description" yaml:"description"`
+		MaxExecutionsPerSender uint32         `json:"max_executions_per_sender" yaml:"max_executions_per_sender"`
+		Proposer               sdk.AccAddress `json:"proposer" yaml:"proposer"`
+		Deposit                sdk.Coins      `json:"deposit" yaml:"deposit"`
+	}
+
+	// GovernanceSubmittersProposalReq defines a governance submitters proposal request body.
+	GovernanceSubmittersProposalReq struct {
+		BaseReq rest.BaseReq `json:"base_req" yaml:"base_req"`
+
+		Title       string         `json:"title" yaml:"title"`
+		Description string         `json:"description" yaml:"description"`
+		Submitters  []string       `json:"submitters" yaml:"submitters"`
+		Proposer    sdk.AccAddress `json:"proposer" yaml:"proposer"`
+		Deposit     sdk.Coins      `json:"deposit" yaml:"deposit"`
+	}
+
+	// FeeMarketProposalReq defines a fee market proposal request body.
+	FeeMarketProposalReq struct {
+		BaseReq rest.BaseReq `json:"base_req" yaml:"base_req"`
+
+		Title       string           `json:"title" yaml:"title"`
+		Description string           `json:"description" yaml:"description"`
+		FeeMarket   *types.FeeMarket `json:"fee_market" yaml:"fee_market"`
+		Proposer    sdk.AccAddress   `json:"proposer" yaml:"proposer"`
+		Deposit     sdk.Coins        `json:"deposit" yaml:"deposit"`
+	}
+
+	// ObservationRequestProposalReq defines an observation request proposal request body.
+	ObservationRequestProposalReq struct {
+		BaseReq rest.BaseReq `json:"base_req" yaml:"base_req"`
+
+		Title          string         `json:"title" yaml:"title"`
+		Description    string         `json:"description" yaml:"description"`
+		ChainID        uint32         `json:"chain_id" yaml:"chain_id"`
+		TxHash         []byte         `json:"tx_hash" yaml:"tx_hash"`
+		EmitterAddress []byte         `json:"emitter_address" yaml:"emitter_address"`
+		Sequence       uint64         `json:"sequence" yaml:"sequence"`
+		Proposer       sdk.AccAddress `json:"proposer" yaml:"proposer"`
+		Deposit        sdk.Coins      `json:"deposit" yaml:"deposit"`
+	}
+
+	// QuorumThresholdProposalReq defines a quorum threshold proposal request body.
+	QuorumThresholdProposalReq struct {
+		BaseReq rest.BaseReq `json:"base_req" yaml:"base_req"`
+
+		Title           string                 `json:"title" yaml:"title"`
+		Description     string                 `json:"description" yaml:"description"`
+		QuorumThreshold *types.QuorumThreshold `json:"quorum_threshold" yaml:"quorum_threshold"`
+		Proposer        sdk.AccAddress         `json:"proposer" yaml:"proposer"`
+		Deposit         sdk.Coins              `json:"deposit" yaml:"deposit"`
+	}
 )
 
 // ProposalGuardianSetUpdateRESTHandler returns a ProposalRESTHandler that exposes the guardian set update
@@ -123,3 +240,357 @@ func postProposalWormholeGovernanceMessageHandlerFn(clientCtx client.Context) ht
 		tx.WriteGeneratedTxResponse(clientCtx, w, req.BaseReq, msg)
 	}
 }
+
+// ProposalEmitterRateLimitRESTHandler returns a ProposalRESTHandler that exposes the emitter rate limit REST handler
+// with a given sub-route.
+func ProposalEmitterRateLimitRESTHandler(clientCtx client.Context) govrest.ProposalRESTHandler {
+	return govrest.ProposalRESTHandler{
+		SubRoute: "wormhole_emitter_rate_limit",
+		Handler:  postProposalEmitterRateLimitHandlerFn(clientCtx),
+	}
+}
+
+func postProposalEmitterRateLimitHandlerFn(clientCtx client.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req EmitterRateLimitProposalReq
+		if !rest.ReadRESTReq(w, r, clientCtx.LegacyAmino, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		content := types.NewEmitterRateLimitProposal(req.Title, req.Description, types.EmitterRateLimit{
+			Emitter:      req.Emitter,
+			MaxMessages:  req.MaxMessages,
+			WindowBlocks: req.WindowBlocks,
+		})
+
+		msg, err := govtypes.NewMsgSubmitProposal(content, req.Deposit, req.Proposer)
+		if rest.CheckBadRequestError(w, err) {
+			return
+		}
+		if rest.CheckBadRequestError(w, msg.ValidateBasic()) {
+			return
+		}
+
+		tx.WriteGeneratedTxResponse(clientCtx, w, req.BaseReq, msg)
+	}
+}
+
+// ProposalAcceptedVAAVersionsRESTHandler returns a ProposalRESTHandler that exposes the accepted VAA versions REST
+// handler with a given sub-route.
+func ProposalAcceptedVAAVersionsRESTHandler(clientCtx client.Context) govrest.ProposalRESTHandler {
+	return govrest.ProposalRESTHandler{
+		SubRoute: "wormhole_accepted_vaa_versions",
+		Handler:  postProposalAcceptedVAAVersionsHandlerFn(clientCtx),
+	}
+}
+
+func postProposalAcceptedVAAVersionsHandlerFn(clientCtx client.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req AcceptedVAAVersionsProposalReq
+		if !rest.ReadRESTReq(w, r, clientCtx.LegacyAmino, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		content := types.NewAcceptedVAAVersionsProposal(req.Title, req.Description, req.Versions)
+
+		msg, err := govtypes.NewMsgSubmitProposal(content, req.Deposit, req.Proposer)
+		if rest.CheckBadRequestError(w, err) {
+			return
+		}
+		if rest.CheckBadRequestError(w, msg.ValidateBasic()) {
+			return
+		}
+
+		tx.WriteGeneratedTxResponse(clientCtx, w, req.BaseReq, msg)
+	}
+}
+
+// ProposalMessageFeesRESTHandler returns a ProposalRESTHandler that exposes the message fees REST handler with a given
+// sub-route.
+func ProposalMessageFeesRESTHandler(clientCtx client.Context) govrest.ProposalRESTHandler {
+	return govrest.ProposalRESTHandler{
+		SubRoute: "wormhole_message_fees",
+		Handler:  postProposalMessageFeesHandlerFn(clientCtx),
+	}
+}
+
+func postProposalMessageFeesHandlerFn(clientCtx client.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req MessageFeesProposalReq
+		if !rest.ReadRESTReq(w, r, clientCtx.LegacyAmino, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		content := types.NewMessageFeesProposal(req.Title, req.Description, req.Fees)
+
+		msg, err := govtypes.NewMsgSubmitProposal(content, req.Deposit, req.Proposer)
+		if rest.CheckBadRequestError(w, err) {
+			return
+		}
+		if rest.CheckBadRequestError(w, msg.ValidateBasic()) {
+			return
+		}
+
+		tx.WriteGeneratedTxResponse(clientCtx, w, req.BaseReq, msg)
+	}
+}
+
+// ProposalVAALimitsRESTHandler returns a ProposalRESTHandler that exposes the VAA limits REST handler with a given
+// sub-route.
+func ProposalVAALimitsRESTHandler(clientCtx client.Context) govrest.ProposalRESTHandler {
+	return govrest.ProposalRESTHandler{
+		SubRoute: "wormhole_vaa_limits",
+		Handler:  postProposalVAALimitsHandlerFn(clientCtx),
+	}
+}
+
+func postProposalVAALimitsHandlerFn(clientCtx client.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req VAALimitsProposalReq
+		if !rest.ReadRESTReq(w, r, clientCtx.LegacyAmino, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		content := types.NewVAALimitsProposal(req.Title, req.Description, req.MaxPayloadSize, req.MaxSignatures)
+
+		msg, err := govtypes.NewMsgSubmitProposal(content, req.Deposit, req.Proposer)
+		if rest.CheckBadRequestError(w, err) {
+			return
+		}
+		if rest.CheckBadRequestError(w, msg.ValidateBasic()) {
+			return
+		}
+
+		tx.WriteGeneratedTxResponse(clientCtx, w, req.BaseReq, msg)
+	}
+}
+
+// ProposalMinGuardianSetIndexRESTHandler returns a ProposalRESTHandler that exposes the minimum guardian set index REST
+// handler with a given sub-route.
+func ProposalMinGuardianSetIndexRESTHandler(clientCtx client.Context) govrest.ProposalRESTHandler {
+	return govrest.ProposalRESTHandler{
+		SubRoute: "wormhole_min_guardian_set_index",
+		Handler:  postProposalMinGuardianSetIndexHandlerFn(clientCtx),
+	}
+}
+
+func postProposalMinGuardianSetIndexHandlerFn(clientCtx client.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req MinGuardianSetIndexProposalReq
+		if !rest.ReadRESTReq(w, r, clientCtx.LegacyAmino, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		content := types.NewMinGuardianSetIndexProposal(req.Title, req.Description, req.MessageType, req.GuardianSetIndex)
+
+		msg, err := govtypes.NewMsgSubmitProposal(content, req.Deposit, req.Proposer)
+		if rest.CheckBadRequestError(w, err) {
+			return
+		}
+		if rest.CheckBadRequestError(w, msg.ValidateBasic()) {
+			return
+		}
+
+		tx.WriteGeneratedTxResponse(clientCtx, w, req.BaseReq, msg)
+	}
+}
+
+// ProposalVAAExecutionLimitRESTHandler returns a ProposalRESTHandler that exposes the VAA execution limit REST handler
+// with a given sub-route.
+func ProposalVAAExecutionLimitRESTHandler(clientCtx client.Context) govrest.ProposalRESTHandler {
+	return govrest.ProposalRESTHandler{
+		SubRoute: "wormhole_vaa_execution_limit",
+		Handler:  postProposalVAAExecutionLimitHandlerFn(clientCtx),
+	}
+}
+
+func postProposalVAAExecutionLimitHandlerFn(clientCtx client.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req VAAExecutionLimitProposalReq
+		if !rest.ReadRESTReq(w, r, clientCtx.LegacyAmino, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		content := types.NewVAAExecutionLimitProposal(req.Title, req.Description, req.MaxExecutionsPerSender)
+
+		msg, err := govtypes.NewMsgSubmitProposal(content, req.Deposit, req.Proposer)
+		if rest.CheckBadRequestError(w, err) {
+			return
+		}
+		if rest.CheckBadRequestError(w, msg.ValidateBasic()) {
+			return
+		}
+
+		tx.WriteGeneratedTxResponse(clientCtx, w, req.BaseReq, msg)
+	}
+}
+
+// ProposalGovernanceSubmittersRESTHandler returns a ProposalRESTHandler that exposes the governance submitters REST
+// handler with a given sub-route.
+func ProposalGovernanceSubmittersRESTHandler(clientCtx client.Context) govrest.ProposalRESTHandler {
+	return govrest.ProposalRESTHandler{
+		SubRoute: "wormhole_governance_submitters",
+		Handler:  postProposalGovernanceSubmittersHandlerFn(clientCtx),
+	}
+}
+
+func postProposalGovernanceSubmittersHandlerFn(clientCtx client.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req GovernanceSubmittersProposalReq
+		if !rest.ReadRESTReq(w, r, clientCtx.LegacyAmino, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		content := types.NewGovernanceSubmittersProposal(req.Title, req.Description, req.Submitters)
+
+		msg, err := govtypes.NewMsgSubmitProposal(content, req.Deposit, req.Proposer)
+		if rest.CheckBadRequestError(w, err) {
+			return
+		}
+		if rest.CheckBadRequestError(w, msg.ValidateBasic()) {
+			return
+		}
+
+		tx.WriteGeneratedTxResponse(clientCtx, w, req.BaseReq, msg)
+	}
+}
+
+// ProposalFeeMarketRESTHandler returns a ProposalRESTHandler that exposes the fee market REST handler with a given
+// sub-route.
+func ProposalFeeMarketRESTHandler(clientCtx client.Context) govrest.ProposalRESTHandler {
+	return govrest.ProposalRESTHandler{
+		SubRoute: "wormhole_fee_market",
+		Handler:  postProposalFeeMarketHandlerFn(clientCtx),
+	}
+}
+
+func postProposalFeeMarketHandlerFn(clientCtx client.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req FeeMarketProposalReq
+		if !rest.ReadRESTReq(w, r, clientCtx.LegacyAmino, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		content := types.NewFeeMarketProposal(req.Title, req.Description, req.FeeMarket)
+
+		msg, err := govtypes.NewMsgSubmitProposal(content, req.Deposit, req.Proposer)
+		if rest.CheckBadRequestError(w, err) {
+			return
+		}
+		if rest.CheckBadRequestError(w, msg.ValidateBasic()) {
+			return
+		}
+
+		tx.WriteGeneratedTxResponse(clientCtx, w, req.BaseReq, msg)
+	}
+}
+
+// ProposalObservationRequestRESTHandler returns a ProposalRESTHandler that exposes the observation request REST handler
+// with a given sub-route.
+func ProposalObservationRequestRESTHandler(clientCtx client.Context) govrest.ProposalRESTHandler {
+	return govrest.ProposalRESTHandler{
+		SubRoute: "wormhole_observation_request",
+		Handler:  postProposalObservationRequestHandlerFn(clientCtx),
+	}
+}
+
+func postProposalObservationRequestHandlerFn(clientCtx client.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req ObservationRequestProposalReq
+		if !rest.ReadRESTReq(w, r, clientCtx.LegacyAmino, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		content := types.NewObservationRequestProposal(req.Title, req.Description, req.ChainID, req.TxHash, req.EmitterAddress, req.Sequence)
+
+		msg, err := govtypes.NewMsgSubmitProposal(content, req.Deposit, req.Proposer)
+		if rest.CheckBadRequestError(w, err) {
+			return
+		}
+		if rest.CheckBadRequestError(w, msg.ValidateBasic()) {
+			return
+		}
+
+		tx.WriteGeneratedTxResponse(clientCtx, w, req.BaseReq, msg)
+	}
+}
+
+// ProposalQuorumThresholdRESTHandler returns a ProposalRESTHandler that exposes the quorum threshold REST handler with
+// a given sub-route.
+func ProposalQuorumThresholdRESTHandler(clientCtx client.Context) govrest.ProposalRESTHandler {
+	return govrest.ProposalRESTHandler{
+		SubRoute: "wormhole_quorum_threshold",
+		Handler:  postProposalQuorumThresholdHandlerFn(clientCtx),
+	}
+}
+
+func postProposalQuorumThresholdHandlerFn(clientCtx client.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req QuorumThresholdProposalReq
+		if !rest.ReadRESTReq(w, r, clientCtx.LegacyAmino, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		content := types.NewQuorumThresholdProposal(req.Title, req.Description, req.QuorumThreshold)
+
+		msg, err := govtypes.NewMsgSubmitProposal(content, req.Deposit, req.Proposer)
+		if rest.CheckBadRequestError(w, err) {
+			return
+		}
+		if rest.CheckBadRequestError(w, msg.ValidateBasic()) {
+			return
+		}
+
+		tx.WriteGeneratedTxResponse(clientCtx, w, req.BaseReq, msg)
+	}
+}
@@ -36,6 +36,22 @@ func InitGenesis(ctx sdk.Context, k keeper.Keeper, genState types.GenesisState)
 	for _, elem := range genState.GuardianValidatorList {
 		k.SetGuardianValidator(ctx, elem)
 	}
+	// Set all the emitterRateLimit
+	for _, elem := range genState.EmitterRateLimitList {
+		k.SetEmitterRateLimit(ctx, elem)
+	}
+	// Set all the guardianSetUpgrade
+	for _, elem := range genState.GuardianSetUpgradeList {
+		k.SetGuardianSetUpgrade(ctx, elem)
+	}
+	// Set all the governanceVAA
+	for _, elem := range genState.GovernanceVAAList {
+		k.SetGovernanceVAA(ctx, elem)
+	}
+	// Set if defined
+	if genState.BaseGasPrice != nil {
+		k.SetBaseGasPrice(ctx, *genState.BaseGasPrice)
+	}
 	// this line is used by starport scaffolding # genesis/module/init
 }
 
@@ -58,6 +74,14 @@ func ExportGenesis(ctx sdk.Context, k keeper.Keeper) *types.GenesisState {
 		genesis.ConsensusGuardianSetIndex = &consensusGuardianSetIndex
 	}
 	genesis.GuardianValidatorList = k.GetAllGuardianValidator(ctx)
+	genesis.EmitterRateLimitList = k.GetAllEmitterRateLimit(ctx)
+	genesis.GuardianSetUpgradeList = k.GetAllGuardianSetUpgrade(ctx)
+	genesis.GovernanceVAAList = k.GetAllGovernanceVAA(ctx)
+	// Get baseGasPrice
+	baseGasPrice, found := k.GetStoredBaseGasPrice(ctx)
+	if found {
+		genesis.BaseGasPrice = &baseGasPrice
+	}
 	// this line is used by starport scaffolding # genesis/module/export
 
 	return genesis
@@ -3,6 +3,7 @@ package wormhole_test
 import (
 	"testing"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/stretchr/testify/require"
 	keepertest "github.com/wormhole-foundation/wormhole-chain/testutil/keeper"
 	"github.com/wormhole-foundation/wormhole-chain/x/wormhole"
@@ -10,6 +11,7 @@ import (
 )
 
 func TestGenesis(t *testing.T) {
+	baseGasPrice := sdk.NewDecWithPrec(25, 3)
 	genesisState := types.GenesisState{
 		GuardianSetList: []types.GuardianSet{
 			{
@@ -19,7 +21,15 @@ func TestGenesis(t *testing.T) {
 				Index: 1,
 			},
 		},
-		Config: &types.Config{},
+		Config: &types.Config{
+			FeeMarket: &types.FeeMarket{
+				Denom:             "uworm",
+				MinGasPrice:       sdk.NewDecWithPrec(1, 2),
+				MaxGasPrice:       sdk.ZeroDec(),
+				TargetBlockGas:    10000000,
+				ChangeDenominator: 8,
+			},
+		},
 		ReplayProtectionList: []types.ReplayProtection{
 			{
 				Index: "0",
@@ -47,6 +57,36 @@ func TestGenesis(t *testing.T) {
 				GuardianKey: []byte{1},
 			},
 		},
+		EmitterRateLimitList: []types.EmitterRateLimit{
+			{
+				Emitter:      make([]byte, 32),
+				MaxMessages:  1,
+				WindowBlocks: 1,
+			},
+		},
+		GuardianSetUpgradeList: []types.GuardianSetUpgrade{
+			{
+				Index:  1,
+				Vaa:    []byte{1},
+				Digest: []byte{2},
+				Height: 3,
+			},
+		},
+		GovernanceVAAList: []types.GovernanceVAA{
+			{
+				Module:   "Core",
+				Action:   2,
+				Sequence: 1,
+				Vaa:      []byte{1},
+				Height:   3,
+			},
+			{
+				Module:   "TokenBridge",
+				Action:   1,
+				Sequence: 2,
+			},
+		},
+		BaseGasPrice: &baseGasPrice,
 		// this line is used by starport scaffolding # genesis/test/state
 	}
 
@@ -66,5 +106,9 @@ func TestGenesis(t *testing.T) {
 	require.Subset(t, genesisState.SequenceCounterList, got.SequenceCounterList)
 	require.Equal(t, genesisState.ConsensusGuardianSetIndex, got.ConsensusGuardianSetIndex)
 	require.ElementsMatch(t, genesisState.GuardianValidatorList, got.GuardianValidatorList)
+	require.ElementsMatch(t, genesisState.EmitterRateLimitList, got.EmitterRateLimitList)
+	require.ElementsMatch(t, genesisState.GuardianSetUpgradeList, got.GuardianSetUpgradeList)
+	require.ElementsMatch(t, genesisState.GovernanceVAAList, got.GovernanceVAAList)
+	require.Equal(t, genesisState.BaseGasPrice, got.BaseGasPrice)
 	// this line is used by starport scaffolding # genesis/test/assert
 }
@@ -15,8 +15,15 @@ import (
 )
 
 // NewWormholeGovernanceProposalHandler creates a governance handler to manage new proposal types.
-// It enables GuardianSetProposal to update the guardian set and GenericWormholeMessageProposal to emit a generic wormhole
-// message from the governance emitter.
+// It enables GuardianSetProposal to update the guardian set, GenericWormholeMessageProposal to emit a generic wormhole
+// message from the governance emitter, EmitterRateLimitProposal to rate limit the messages of an emitter,
+// AcceptedVAAVersionsProposal to set the VAA versions accepted by the chain, MessageFeesProposal to set the fees
+// charged for posting messages, VAALimitsProposal to limit the size of the VAAs accepted by the chain,
+// MinGuardianSetIndexProposal to require VAAs of a message type to be signed by a recent guardian set,
+// VAAExecutionLimitProposal to limit the VAAs a single signer can execute per block, GovernanceSubmittersProposal to
+// restrict the accounts allowed to submit governance VAAs, FeeMarketProposal to set the fee market,
+// ObservationRequestProposal to ask guardians to observe a message again and QuorumThresholdProposal to set the share of
+// a guardian set that has to sign a VAA.
 func NewWormholeGovernanceProposalHandler(k keeper.Keeper) govtypes.Handler {
 	return func(ctx sdk.Context, content govtypes.Content) error {
 		switch c := content.(type) {
@@ -26,6 +33,36 @@ func NewWormholeGovernanceProposalHandler(k keeper.Keeper) govtypes.Handler {
 		case *types.GovernanceWormholeMessageProposal:
 			return handleGovernanceWormholeMessageProposal(ctx, k, c)
 
+		case *types.EmitterRateLimitProposal:
+			return handleEmitterRateLimitProposal(ctx, k, c)
+
+		case *types.AcceptedVAAVersionsProposal:
+			return handleAcceptedVAAVersionsProposal(ctx, k, c)
+
+		case *types.MessageFeesProposal:
+			return handleMessageFeesProposal(ctx, k, c)
+
+		case *types.VAALimitsProposal:
+			return handleVAALimitsProposal(ctx, k, c)
+
+		case *types.MinGuardianSetIndexProposal:
+			return handleMinGuardianSetIndexProposal(ctx, k, c)
+
+		case *types.VAAExecutionLimitProposal:
+			return handleVAAExecutionLimitProposal(ctx, k, c)
+
+		case *types.GovernanceSubmittersProposal:
+			return handleGovernanceSubmittersProposal(ctx, k, c)
+
+		case *types.FeeMarketProposal:
+			return handleFeeMarketProposal(ctx, k, c)
+
+		case *types.ObservationRequestProposal:
+			return handleObservationRequestProposal(ctx, k, c)
+
+		case *types.QuorumThresholdProposal:
+			return handleQuorumThresholdProposal(ctx, k, c)
+
 		default:
 			return sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unrecognized wormhole proposal content type: %T", c)
 		}
@@ -37,16 +74,11 @@ func handleGuardianSetUpdateProposal(ctx sdk.Context, k keeper.Keeper, proposal
 		Index:          proposal.NewGuardianSet.Index,
 		Keys:           proposal.NewGuardianSet.Keys,
 		ExpirationTime: 0,
-	})
+	}, nil)
 	if err != nil {
 		return fmt.Errorf("failed to update guardian set: %w", err)
 	}
 
-	config, ok := k.GetConfig(ctx)
-	if !ok {
-		return types.ErrNoConfig
-	}
-
 	// Post a wormhole guardian set update governance message
 	message := &bytes.Buffer{}
 
@@ -62,12 +94,7 @@ func handleGuardianSetUpdateProposal(ctx sdk.Context, k keeper.Keeper, proposal
 		message.Write(key)
 	}
 
-	emitterAddress, err := types.EmitterAddressFromBytes32(config.GovernanceEmitter)
-	if err != nil {
-		return fmt.Errorf("failed to post message: %w", err)
-	}
-
-	err = k.PostMessage(ctx, emitterAddress, 0, message.Bytes())
+	err = k.PostGovernanceMessage(ctx, message.Bytes())
 	if err != nil {
 		return fmt.Errorf("failed to post message: %w", err)
 	}
@@ -76,11 +103,6 @@ func handleGuardianSetUpdateProposal(ctx sdk.Context, k keeper.Keeper, proposal
 }
 
 func handleGovernanceWormholeMessageProposal(ctx sdk.Context, k keeper.Keeper, proposal *types.GovernanceWormholeMessageProposal) error {
-	config, ok := k.GetConfig(ctx)
-	if !ok {
-		return types.ErrNoConfig
-	}
-
 	// Post a wormhole governance message
 	message := &bytes.Buffer{}
 	message.Write(proposal.Module)
@@ -88,16 +110,133 @@ func handleGovernanceWormholeMessageProposal(ctx sdk.Context, k keeper.Keeper, p
 	MustWrite(message, binary.BigEndian, uint16(proposal.TargetChain))
 	message.Write(proposal.Payload)
 
-	emitterAddress, err := types.EmitterAddressFromBytes32(config.GovernanceEmitter)
+	err := k.PostGovernanceMessage(ctx, message.Bytes())
 	if err != nil {
 		return fmt.Errorf("failed to post message: %w", err)
 	}
 
-	err = k.PostMessage(ctx, emitterAddress, 0, message.Bytes())
-	if err != nil {
-		return fmt.Errorf("failed to post message: %w", err)
+	return nil
+}
+
+func handleEmitterRateLimitProposal(ctx sdk.Context, k keeper.Keeper, proposal *types.EmitterRateLimitProposal) error {
+	if proposal.Remove {
+		k.RemoveEmitterRateLimit(ctx, proposal.RateLimit.Emitter)
+		return nil
+	}
+
+	k.SetEmitterRateLimit(ctx, proposal.RateLimit)
+	return nil
+}
+
+func handleAcceptedVAAVersionsProposal(ctx sdk.Context, k keeper.Keeper, proposal *types.AcceptedVAAVersionsProposal) error {
+	config, ok := k.GetConfig(ctx)
+	if !ok {
+		return types.ErrNoConfig
+	}
+
+	config.AcceptedVaaVersions = proposal.Versions
+	k.SetConfig(ctx, config)
+	return nil
+}
+
+func handleMessageFeesProposal(ctx sdk.Context, k keeper.Keeper, proposal *types.MessageFeesProposal) error {
+	config, ok := k.GetConfig(ctx)
+	if !ok {
+		return types.ErrNoConfig
+	}
+
+	config.MessageFees = proposal.Fees
+	k.SetConfig(ctx, config)
+	return nil
+}
+
+func handleVAALimitsProposal(ctx sdk.Context, k keeper.Keeper, proposal *types.VAALimitsProposal) error {
+	config, ok := k.GetConfig(ctx)
+	if !ok {
+		return types.ErrNoConfig
+	}
+
+	config.MaxVaaPayloadSize = proposal.MaxPayloadSize
+	config.MaxVaaSignatures = proposal.MaxSignatures
+	k.SetConfig(ctx, config)
+	return nil
+}
+
+func handleMinGuardianSetIndexProposal(ctx sdk.Context, k keeper.Keeper, proposal *types.MinGuardianSetIndexProposal) error {
+	config, ok := k.GetConfig(ctx)
+	if !ok {
+		return types.ErrNoConfig
+	}
+
+	// Requiring a guardian set that does not exist yet would reject every VAA of the message type
+	if latest := k.GetLatestGuardianSetIndex(ctx); proposal.GuardianSetIndex > latest {
+		return fmt.Errorf("%w: index %d is newer than the latest guardian set %d", types.ErrGuardianSetNotFound, proposal.GuardianSetIndex, latest)
+	}
+
+	config.SetMinGuardianSetIndex(proposal.MessageType, proposal.GuardianSetIndex)
+	k.SetConfig(ctx, config)
+	return nil
+}
+
+func handleVAAExecutionLimitProposal(ctx sdk.Context, k keeper.Keeper, proposal *types.VAAExecutionLimitProposal) error {
+	config, ok := k.GetConfig(ctx)
+	if !ok {
+		return types.ErrNoConfig
+	}
+
+	config.MaxVaaExecutionsPerSender = proposal.MaxExecutionsPerSender
+	k.SetConfig(ctx, config)
+	return nil
+}
+
+func handleGovernanceSubmittersProposal(ctx sdk.Context, k keeper.Keeper, proposal *types.GovernanceSubmittersProposal) error {
+	config, ok := k.GetConfig(ctx)
+	if !ok {
+		return types.ErrNoConfig
+	}
+
+	config.GovernanceSubmitters = proposal.Submitters
+	k.SetConfig(ctx, config)
+	return nil
+}
+
+func handleFeeMarketProposal(ctx sdk.Context, k keeper.Keeper, proposal *types.FeeMarketProposal) error {
+	config, ok := k.GetConfig(ctx)
+	if !ok {
+		return types.ErrNoConfig
+	}
+
+	config.FeeMarket = proposal.FeeMarket
+	k.SetConfig(ctx, config)
+	// A fee market set again later starts over at its minimum gas price
+	if proposal.FeeMarket == nil {
+		k.RemoveBaseGasPrice(ctx)
+	}
+	return nil
+}
+
+func handleObservationRequestProposal(ctx sdk.Context, k keeper.Keeper, proposal *types.ObservationRequestProposal) error {
+	return ctx.EventManager().EmitTypedEvent(&types.EventObservationRequest{
+		ChainId:        proposal.ChainId,
+		TxHash:         proposal.TxHash,
+		EmitterAddress: proposal.EmitterAddress,
+		Sequence:       proposal.Sequence,
+	})
+}
+
+func handleQuorumThresholdProposal(ctx sdk.Context, k keeper.Keeper, proposal *types.QuorumThresholdProposal) error {
+	if proposal.QuorumThreshold != nil {
+		if err := proposal.QuorumThreshold.Validate(); err != nil {
+			return err
+		}
+	}
+	config, ok := k.GetConfig(ctx)
+	if !ok {
+		return types.ErrNoConfig
 	}
 
+	config.QuorumThreshold = proposal.QuorumThreshold
+	k.SetConfig(ctx, config)
 	return nil
 }
 
@@ -0,0 +1,85 @@
+package keeper
+
+import (
+	"encoding/binary"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/tendermint/tendermint/crypto/merkle"
+	"github.com/wormhole-foundation/wormhole-chain/x/wormhole/types"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// messageDigest returns the digest guardians sign for a message posted on wormhole chain, which the watcher observes
+// with instant finality.
+func messageDigest(emitter types.EmitterAddress, sequence uint64, nonce uint32, timestamp int64, payload []byte) []byte {
+	var emitterAddress vaa.Address
+	copy(emitterAddress[:], emitter.Bytes())
+	v := &vaa.VAA{
+		Version:          vaa.SupportedVAAVersion,
+		Timestamp:        time.Unix(timestamp, 0),
+		Nonce:            nonce,
+		EmitterChain:     vaa.ChainIDWormchain,
+		EmitterAddress:   emitterAddress,
+		Sequence:         sequence,
+		ConsistencyLevel: 0,
+		Payload:          payload,
+	}
+	return v.SigningMsg().Bytes()
+}
+
+// appendBlockMessageDigest records the digest of a message posted in the current block. Digests live in the transient
+// store, so they are discarded with the block and dropped along with the state of failed transactions.
+func (k Keeper) appendBlockMessageDigest(ctx sdk.Context, digest []byte) {
+	store := ctx.TransientStore(k.tKey)
+	count := k.GetBlockMessageCount(ctx)
+
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, count)
+	prefix.NewStore(store, types.KeyPrefix(types.BlockMessageDigestKey)).Set(key, digest)
+
+	binary.BigEndian.PutUint64(key, count+1)
+	store.Set(types.KeyPrefix(types.BlockMessageDigestCountKey), key)
+}
+
+// GetBlockMessageCount returns the number of messages posted in the current block so far.
+func (k Keeper) GetBlockMessageCount(ctx sdk.Context) uint64 {
+	b := ctx.TransientStore(k.tKey).Get(types.KeyPrefix(types.BlockMessageDigestCountKey))
+	if b == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint64(b)
+}
+
+// GetBlockMessageDigests returns the digests of the messages posted in the current block so far, in the order they
+// were posted.
+func (k Keeper) GetBlockMessageDigests(ctx sdk.Context) [][]byte {
+	store := prefix.NewStore(ctx.TransientStore(k.tKey), types.KeyPrefix(types.BlockMessageDigestKey))
+	iterator := store.Iterator(nil, nil)
+	defer iterator.Close()
+
+	var digests [][]byte
+	for ; iterator.Valid(); iterator.Next() {
+		digests = append(digests, iterator.Value())
+	}
+	return digests
+}
+
+// EmitBlockMessages emits an EventBlockMessages summarizing the messages posted in the current block, if there were
+// any. The Merkle root is computed over the message digests as laid out by RFC 6962, like Tendermint's own hashes.
+func (k Keeper) EmitBlockMessages(ctx sdk.Context) {
+	digests := k.GetBlockMessageDigests(ctx)
+	if len(digests) == 0 {
+		return
+	}
+
+	err := ctx.EventManager().EmitTypedEvent(&types.EventBlockMessages{
+		Height:       ctx.BlockHeight(),
+		MessageCount: uint64(len(digests)),
+		MerkleRoot:   merkle.HashFromByteSlices(digests),
+	})
+	if err != nil {
+		panic(err)
+	}
+}
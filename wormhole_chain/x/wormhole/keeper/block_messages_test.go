@@ -0,0 +1,67 @@
+package keeper_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/merkle"
+	keepertest "github.com/wormhole-foundation/wormhole-chain/testutil/keeper"
+	"github.com/wormhole-foundation/wormhole-chain/x/wormhole/types"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+func TestBlockMessages(t *testing.T) {
+	k, ctx := keepertest.WormholeKeeper(t)
+	ctx = ctx.WithBlockHeight(42).WithBlockTime(time.Unix(1660000000, 0))
+	emitter := types.EmitterAddressFromAccAddress(sdk.AccAddress(bytes.Repeat([]byte{0x01}, 20)))
+	capability, err := k.BindEmitter(ctx, emitter)
+	require.NoError(t, err)
+
+	// Blocks without messages are not summarized
+	emptyCtx := ctx.WithEventManager(sdk.NewEventManager())
+	k.EmitBlockMessages(emptyCtx)
+	assert.Empty(t, emptyCtx.EventManager().Events())
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, k.PostMessage(ctx, capability, emitter, nil, uint32(i), []byte{byte(i)}))
+	}
+
+	// Messages of failed transactions are dropped with the rest of their state
+	cacheCtx, _ := ctx.CacheContext()
+	require.NoError(t, k.PostMessage(cacheCtx, capability, emitter, nil, 0, []byte{0xff}))
+	assert.Equal(t, uint64(4), k.GetBlockMessageCount(cacheCtx))
+	assert.Equal(t, uint64(3), k.GetBlockMessageCount(ctx))
+
+	// Digests are those the guardians sign
+	digests := k.GetBlockMessageDigests(ctx)
+	require.Len(t, digests, 3)
+	var emitterAddress vaa.Address
+	copy(emitterAddress[:], emitter.Bytes())
+	v := &vaa.VAA{
+		Version:        vaa.SupportedVAAVersion,
+		Timestamp:      ctx.BlockTime(),
+		Nonce:          1,
+		EmitterChain:   vaa.ChainIDWormchain,
+		EmitterAddress: emitterAddress,
+		Sequence:       1,
+		Payload:        []byte{1},
+	}
+	assert.Equal(t, v.SigningMsg().Bytes(), digests[1])
+
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	k.EmitBlockMessages(ctx)
+	events := ctx.EventManager().Events()
+	require.Len(t, events, 1)
+	summary, err := sdk.ParseTypedEvent(abci.Event(events[0]))
+	require.NoError(t, err)
+	assert.Equal(t, &types.EventBlockMessages{
+		Height:       42,
+		MessageCount: 3,
+		MerkleRoot:   merkle.HashFromByteSlices(digests),
+	}, summary)
+}
@@ -31,3 +31,13 @@ func (k Keeper) RemoveConfig(ctx sdk.Context) {
 	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.ConfigKey))
 	store.Delete([]byte{0})
 }
+
+// ValidateQuorumThreshold checks the quorum threshold of the stored config against the bounds of this binary, so that
+// a software upgrade with tighter bounds fails instead of running with a threshold it would not accept.
+func (k Keeper) ValidateQuorumThreshold(ctx sdk.Context) error {
+	config, found := k.GetConfig(ctx)
+	if !found || config.QuorumThreshold == nil {
+		return nil
+	}
+	return config.QuorumThreshold.Validate()
+}
@@ -31,3 +31,17 @@ func TestConfigRemove(t *testing.T) {
 	_, found := keeper.GetConfig(ctx)
 	require.False(t, found)
 }
+
+func TestValidateQuorumThreshold(t *testing.T) {
+	keeper, ctx := keepertest.WormholeKeeper(t)
+	require.NoError(t, keeper.ValidateQuorumThreshold(ctx))
+
+	createTestConfig(keeper, ctx)
+	require.NoError(t, keeper.ValidateQuorumThreshold(ctx))
+
+	keeper.SetConfig(ctx, types.Config{QuorumThreshold: &types.QuorumThreshold{Numerator: 3, Denominator: 4}})
+	require.NoError(t, keeper.ValidateQuorumThreshold(ctx))
+
+	keeper.SetConfig(ctx, types.Config{QuorumThreshold: &types.QuorumThreshold{Numerator: 1, Denominator: 2}})
+	require.ErrorIs(t, keeper.ValidateQuorumThreshold(ctx), types.ErrInvalidQuorumThreshold)
+}
@@ -2,12 +2,97 @@ package keeper
 
 import (
 	"encoding/hex"
+	"fmt"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	capabilitytypes "github.com/cosmos/cosmos-sdk/x/capability/types"
 	"github.com/wormhole-foundation/wormhole-chain/x/wormhole/types"
 )
 
-func (k Keeper) PostMessage(ctx sdk.Context, emitter types.EmitterAddress, nonce uint32, data []byte) error {
+// BindEmitter creates the capability to post messages from emitter. The
+// calling module has to claim it with its own scoped keeper and present it to
+// PostMessage. Each emitter can only be bound once, from InitGenesis or an
+// upgrade handler: calls made while executing a transaction are rejected.
+func (k Keeper) BindEmitter(ctx sdk.Context, emitter types.EmitterAddress) (*capabilitytypes.Capability, error) {
+	if len(ctx.TxBytes()) != 0 {
+		return nil, types.ErrEmitterBindInTx
+	}
+	capability, err := k.scopedKeeper.NewCapability(ctx, types.EmitterCapabilityName(emitter))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", types.ErrEmitterAlreadyBound, err)
+	}
+	return capability, nil
+}
+
+// PostMessage publishes a message from emitter. capability must be the one
+// returned by BindEmitter for emitter. Messages count against the rate limit
+// of the emitter, if governance set one, and payer is charged the message fee.
+func (k Keeper) PostMessage(ctx sdk.Context, capability *capabilitytypes.Capability, emitter types.EmitterAddress, payer sdk.AccAddress, nonce uint32, data []byte) error {
+	if !k.scopedKeeper.AuthenticateCapability(ctx, capability, types.EmitterCapabilityName(emitter)) {
+		return types.ErrInvalidEmitterCapability
+	}
+	return k.postPaidMessage(ctx, emitter, payer, nonce, data)
+}
+
+// PostAccountMessage publishes a message with the address of account as
+// emitter, which is how CosmWasm contracts post messages. Emitters bound to a
+// module cannot be used. Like for PostMessage, messages count against the rate
+// limit of the emitter and account is charged the message fee.
+func (k Keeper) PostAccountMessage(ctx sdk.Context, account sdk.AccAddress, nonce uint32, data []byte) error {
+	emitter := types.EmitterAddressFromAccAddress(account)
+	if _, bound := k.scopedKeeper.GetCapability(ctx, types.EmitterCapabilityName(emitter)); bound {
+		return types.ErrEmitterAlreadyBound
+	}
+	return k.postPaidMessage(ctx, emitter, account, nonce, data)
+}
+
+func (k Keeper) postPaidMessage(ctx sdk.Context, emitter types.EmitterAddress, payer sdk.AccAddress, nonce uint32, data []byte) error {
+	if err := k.consumeEmitterRateLimit(ctx, emitter); err != nil {
+		return err
+	}
+	if err := k.chargeMessageFee(ctx, payer); err != nil {
+		return err
+	}
+	return k.postMessage(ctx, emitter, nonce, data)
+}
+
+// chargeMessageFee moves the message fee from payer to the module account,
+// from where governance can pay it out with a TransferFees VAA. The fee is paid
+// in the first denom, in sorted order, of which payer holds enough.
+func (k Keeper) chargeMessageFee(ctx sdk.Context, payer sdk.AccAddress) error {
+	config, ok := k.GetConfig(ctx)
+	if !ok || config.MessageFees.Empty() {
+		return nil
+	}
+	if payer.Empty() {
+		return types.ErrInsufficientMessageFee
+	}
+
+	for _, fee := range config.MessageFees {
+		if k.bankKeeper.GetBalance(ctx, payer, fee.Denom).IsLT(fee) {
+			continue
+		}
+		return k.bankKeeper.SendCoinsFromAccountToModule(ctx, payer, types.ModuleName, sdk.Coins{fee})
+	}
+	return fmt.Errorf("%w: fee is one of %s", types.ErrInsufficientMessageFee, config.MessageFees)
+}
+
+// PostGovernanceMessage publishes a message from the governance emitter.
+func (k Keeper) PostGovernanceMessage(ctx sdk.Context, data []byte) error {
+	config, ok := k.GetConfig(ctx)
+	if !ok {
+		return types.ErrNoConfig
+	}
+
+	emitterAddress, err := types.EmitterAddressFromBytes32(config.GovernanceEmitter)
+	if err != nil {
+		return err
+	}
+
+	return k.postMessage(ctx, emitterAddress, 0, data)
+}
+
+func (k Keeper) postMessage(ctx sdk.Context, emitter types.EmitterAddress, nonce uint32, data []byte) error {
 	emitterHex := hex.EncodeToString(emitter.Bytes())
 	sequence, found := k.GetSequenceCounter(ctx, emitterHex)
 	if !found {
@@ -30,6 +115,7 @@ func (k Keeper) PostMessage(ctx sdk.Context, emitter types.EmitterAddress, nonce
 	if err != nil {
 		panic(err)
 	}
+	k.appendBlockMessageDigest(ctx, messageDigest(emitter, sequence.Sequence, nonce, time, data))
 
 	// Increment sequence counter
 	sequence.Sequence++
@@ -0,0 +1,103 @@
+package keeper
+
+import (
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/wormhole-foundation/wormhole-chain/x/wormhole/types"
+)
+
+// SetEmitterRateLimit set a specific emitterRateLimit in the store from its index
+func (k Keeper) SetEmitterRateLimit(ctx sdk.Context, emitterRateLimit types.EmitterRateLimit) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.EmitterRateLimitKeyPrefix))
+	b := k.cdc.MustMarshal(&emitterRateLimit)
+	store.Set(types.EmitterRateLimitKey(
+		emitterRateLimit.Emitter,
+	), b)
+}
+
+// GetEmitterRateLimit returns a emitterRateLimit from its index
+func (k Keeper) GetEmitterRateLimit(
+	ctx sdk.Context,
+	emitter []byte,
+
+) (val types.EmitterRateLimit, found bool) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.EmitterRateLimitKeyPrefix))
+
+	b := store.Get(types.EmitterRateLimitKey(
+		emitter,
+	))
+	if b == nil {
+		return val, false
+	}
+
+	k.cdc.MustUnmarshal(b, &val)
+	return val, true
+}
+
+// RemoveEmitterRateLimit removes a emitterRateLimit from the store, along with the message count of the emitter
+func (k Keeper) RemoveEmitterRateLimit(
+	ctx sdk.Context,
+	emitter []byte,
+
+) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.EmitterRateLimitKeyPrefix))
+	store.Delete(types.EmitterRateLimitKey(
+		emitter,
+	))
+
+	countStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.EmitterMessageCountKeyPrefix))
+	countStore.Delete(types.EmitterMessageCountKey(emitter))
+}
+
+// GetAllEmitterRateLimit returns all emitterRateLimit
+func (k Keeper) GetAllEmitterRateLimit(ctx sdk.Context) (list []types.EmitterRateLimit) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.EmitterRateLimitKeyPrefix))
+	iterator := sdk.KVStorePrefixIterator(store, []byte{})
+
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var val types.EmitterRateLimit
+		k.cdc.MustUnmarshal(iterator.Value(), &val)
+		list = append(list, val)
+	}
+
+	return
+}
+
+// consumeEmitterRateLimit counts a message posted by emitter against its rate limit, if it has one, and fails if the
+// emitter already posted the maximum number of messages in the current window. Emitters limited to zero messages are
+// blocked.
+func (k Keeper) consumeEmitterRateLimit(ctx sdk.Context, emitter types.EmitterAddress) error {
+	limit, found := k.GetEmitterRateLimit(ctx, emitter.Bytes())
+	if !found {
+		return nil
+	}
+	if limit.MaxMessages == 0 {
+		return sdkerrors.Wrap(types.ErrEmitterRateLimited, "emitter is blocked")
+	}
+
+	height := ctx.BlockHeight()
+	windowStart := height - height%int64(limit.WindowBlocks)
+
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.EmitterMessageCountKeyPrefix))
+	var count types.EmitterMessageCount
+	if b := store.Get(types.EmitterMessageCountKey(emitter.Bytes())); b != nil {
+		k.cdc.MustUnmarshal(b, &count)
+	}
+	if count.WindowStart != windowStart {
+		count = types.EmitterMessageCount{
+			Emitter:     emitter.Bytes(),
+			WindowStart: windowStart,
+		}
+	}
+
+	if count.Count >= limit.MaxMessages {
+		return types.ErrEmitterRateLimited
+	}
+
+	count.Count++
+	store.Set(types.EmitterMessageCountKey(emitter.Bytes()), k.cdc.MustMarshal(&count))
+	return nil
+}
@@ -0,0 +1,134 @@
+package keeper_test
+
+import (
+	"bytes"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	keepertest "github.com/wormhole-foundation/wormhole-chain/testutil/keeper"
+	"github.com/wormhole-foundation/wormhole-chain/testutil/nullify"
+	"github.com/wormhole-foundation/wormhole-chain/x/wormhole/keeper"
+	"github.com/wormhole-foundation/wormhole-chain/x/wormhole/types"
+)
+
+func createNEmitterRateLimit(keeper *keeper.Keeper, ctx sdk.Context, n int) []types.EmitterRateLimit {
+	items := make([]types.EmitterRateLimit, n)
+	for i := range items {
+		items[i].Emitter = bytes.Repeat([]byte{byte(i)}, 32)
+		items[i].MaxMessages = uint64(i + 1)
+		items[i].WindowBlocks = 1
+
+		keeper.SetEmitterRateLimit(ctx, items[i])
+	}
+	return items
+}
+
+func TestEmitterRateLimitGet(t *testing.T) {
+	keeper, ctx := keepertest.WormholeKeeper(t)
+	items := createNEmitterRateLimit(keeper, ctx, 10)
+	for _, item := range items {
+		rst, found := keeper.GetEmitterRateLimit(ctx,
+			item.Emitter,
+		)
+		require.True(t, found)
+		require.Equal(t,
+			nullify.Fill(&item),
+			nullify.Fill(&rst),
+		)
+	}
+}
+func TestEmitterRateLimitRemove(t *testing.T) {
+	keeper, ctx := keepertest.WormholeKeeper(t)
+	items := createNEmitterRateLimit(keeper, ctx, 10)
+	for _, item := range items {
+		keeper.RemoveEmitterRateLimit(ctx,
+			item.Emitter,
+		)
+		_, found := keeper.GetEmitterRateLimit(ctx,
+			item.Emitter,
+		)
+		require.False(t, found)
+	}
+}
+
+func TestEmitterRateLimitGetAll(t *testing.T) {
+	keeper, ctx := keepertest.WormholeKeeper(t)
+	items := createNEmitterRateLimit(keeper, ctx, 10)
+	require.ElementsMatch(t,
+		nullify.Fill(items),
+		nullify.Fill(keeper.GetAllEmitterRateLimit(ctx)),
+	)
+}
+
+func TestPostMessageEmitterRateLimit(t *testing.T) {
+	k, ctx := keepertest.WormholeKeeper(t)
+	emitter := types.EmitterAddressFromAccAddress(sdk.AccAddress(bytes.Repeat([]byte{0x01}, 20)))
+	capability, err := k.BindEmitter(ctx, emitter)
+	require.NoError(t, err)
+
+	k.SetEmitterRateLimit(ctx, types.EmitterRateLimit{
+		Emitter:      emitter.Bytes(),
+		MaxMessages:  2,
+		WindowBlocks: 10,
+	})
+
+	ctx = ctx.WithBlockHeight(10)
+	require.NoError(t, k.PostMessage(ctx, capability, emitter, nil, 0, []byte{1}))
+	ctx = ctx.WithBlockHeight(19)
+	require.NoError(t, k.PostMessage(ctx, capability, emitter, nil, 0, []byte{2}))
+	assert.ErrorIs(t, k.PostMessage(ctx, capability, emitter, nil, 0, []byte{3}), types.ErrEmitterRateLimited)
+
+	// The count resets in the next window
+	ctx = ctx.WithBlockHeight(20)
+	require.NoError(t, k.PostMessage(ctx, capability, emitter, nil, 0, []byte{4}))
+
+	// Removing the limit lifts the restriction
+	k.RemoveEmitterRateLimit(ctx, emitter.Bytes())
+	for i := 0; i < 3; i++ {
+		require.NoError(t, k.PostMessage(ctx, capability, emitter, nil, 0, []byte{5}))
+	}
+
+	// Removing the limit also cleared the count of the window, so a new limit starts from zero
+	k.SetEmitterRateLimit(ctx, types.EmitterRateLimit{
+		Emitter:      emitter.Bytes(),
+		MaxMessages:  2,
+		WindowBlocks: 10,
+	})
+	require.NoError(t, k.PostMessage(ctx, capability, emitter, nil, 0, []byte{6}))
+	require.NoError(t, k.PostMessage(ctx, capability, emitter, nil, 0, []byte{7}))
+	assert.ErrorIs(t, k.PostMessage(ctx, capability, emitter, nil, 0, []byte{8}), types.ErrEmitterRateLimited)
+}
+
+func TestPostMessageEmitterBlocked(t *testing.T) {
+	k, ctx := keepertest.WormholeKeeper(t)
+	emitter := types.EmitterAddressFromAccAddress(sdk.AccAddress(bytes.Repeat([]byte{0x01}, 20)))
+	capability, err := k.BindEmitter(ctx, emitter)
+	require.NoError(t, err)
+
+	// A limit of zero messages blocks the emitter, regardless of the window
+	k.SetEmitterRateLimit(ctx, types.EmitterRateLimit{Emitter: emitter.Bytes()})
+	assert.ErrorIs(t, k.PostMessage(ctx, capability, emitter, nil, 0, []byte{1}), types.ErrEmitterRateLimited)
+	ctx = ctx.WithBlockHeight(1000)
+	assert.ErrorIs(t, k.PostMessage(ctx, capability, emitter, nil, 0, []byte{2}), types.ErrEmitterRateLimited)
+}
+
+func TestPostAccountMessageEmitterRateLimit(t *testing.T) {
+	k, ctx := keepertest.WormholeKeeper(t)
+	contract := sdk.AccAddress(bytes.Repeat([]byte{0xcc}, 32))
+	emitter := types.EmitterAddressFromAccAddress(contract)
+
+	// Messages posted by CosmWasm contracts count against their rate limit like those of modules
+	k.SetEmitterRateLimit(ctx, types.EmitterRateLimit{
+		Emitter:      emitter.Bytes(),
+		MaxMessages:  1,
+		WindowBlocks: 10,
+	})
+	require.NoError(t, k.PostAccountMessage(ctx, contract, 0, []byte{1}))
+	assert.ErrorIs(t, k.PostAccountMessage(ctx, contract, 0, []byte{2}), types.ErrEmitterRateLimited)
+
+	k.SetEmitterRateLimit(ctx, types.EmitterRateLimit{Emitter: emitter.Bytes()})
+	ctx = ctx.WithBlockHeight(10)
+	assert.ErrorIs(t, k.PostAccountMessage(ctx, contract, 0, []byte{3}), types.ErrEmitterRateLimited)
+}
@@ -0,0 +1,149 @@
+package keeper_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	capabilitytypes "github.com/cosmos/cosmos-sdk/x/capability/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	keepertest "github.com/wormhole-foundation/wormhole-chain/testutil/keeper"
+	"github.com/wormhole-foundation/wormhole-chain/x/wormhole/keeper"
+	"github.com/wormhole-foundation/wormhole-chain/x/wormhole/types"
+)
+
+func TestPostMessageRequiresEmitterCapability(t *testing.T) {
+	k, ctx := keepertest.WormholeKeeper(t)
+	emitter := types.EmitterAddressFromAccAddress(sdk.AccAddress(bytes.Repeat([]byte{0x01}, 20)))
+	other := types.EmitterAddressFromAccAddress(sdk.AccAddress(bytes.Repeat([]byte{0x02}, 20)))
+
+	capability, err := k.BindEmitter(ctx, emitter)
+	require.NoError(t, err)
+
+	// An emitter can only be bound once
+	_, err = k.BindEmitter(ctx, emitter)
+	assert.ErrorIs(t, err, types.ErrEmitterAlreadyBound)
+
+	// Emitters cannot be bound from within a transaction
+	_, err = k.BindEmitter(ctx.WithTxBytes([]byte{1}), other)
+	assert.ErrorIs(t, err, types.ErrEmitterBindInTx)
+
+	otherCapability, err := k.BindEmitter(ctx, other)
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, k.PostMessage(ctx, nil, emitter, nil, 0, []byte{1}), types.ErrInvalidEmitterCapability)
+	assert.ErrorIs(t, k.PostMessage(ctx, capabilitytypes.NewCapability(capability.GetIndex()), emitter, nil, 0, []byte{1}), types.ErrInvalidEmitterCapability)
+	assert.ErrorIs(t, k.PostMessage(ctx, otherCapability, emitter, nil, 0, []byte{1}), types.ErrInvalidEmitterCapability)
+	_, found := k.GetSequenceCounter(ctx, hex.EncodeToString(emitter.Bytes()))
+	assert.False(t, found)
+
+	require.NoError(t, k.PostMessage(ctx, capability, emitter, nil, 0, []byte{1}))
+	sequence, found := k.GetSequenceCounter(ctx, hex.EncodeToString(emitter.Bytes()))
+	require.True(t, found)
+	assert.Equal(t, uint64(1), sequence.Sequence)
+}
+
+// mockBankKeeper keeps account balances in memory.
+type mockBankKeeper struct {
+	balances map[string]sdk.Coins
+}
+
+func (b *mockBankKeeper) GetBalance(ctx sdk.Context, addr sdk.AccAddress, denom string) sdk.Coin {
+	return sdk.NewCoin(denom, b.balances[addr.String()].AmountOf(denom))
+}
+
+func (b *mockBankKeeper) SendCoinsFromAccountToModule(ctx sdk.Context, senderAddr sdk.AccAddress, recipientModule string, amt sdk.Coins) error {
+	balance, negative := b.balances[senderAddr.String()].SafeSub(amt)
+	if negative {
+		return sdkerrors.ErrInsufficientFunds
+	}
+	b.balances[senderAddr.String()] = balance
+	module := authtypes.NewModuleAddress(recipientModule).String()
+	b.balances[module] = b.balances[module].Add(amt...)
+	return nil
+}
+
+func (b *mockBankKeeper) SendCoinsFromModuleToAccount(ctx sdk.Context, senderModule string, recipientAddr sdk.AccAddress, amt sdk.Coins) error {
+	module := authtypes.NewModuleAddress(senderModule).String()
+	balance, negative := b.balances[module].SafeSub(amt)
+	if negative {
+		return sdkerrors.ErrInsufficientFunds
+	}
+	b.balances[module] = balance
+	b.balances[recipientAddr.String()] = b.balances[recipientAddr.String()].Add(amt...)
+	return nil
+}
+
+func TestPostMessageFee(t *testing.T) {
+	payer := sdk.AccAddress(bytes.Repeat([]byte{0xaa}, 20))
+	module := authtypes.NewModuleAddress(types.ModuleName).String()
+	fees := sdk.NewCoins(sdk.NewInt64Coin("uworm", 10), sdk.NewInt64Coin("uusdc", 1000))
+
+	tests := []struct {
+		label    string
+		fees     sdk.Coins
+		payer    sdk.AccAddress
+		balance  sdk.Coins
+		err      error
+		expected sdk.Coins
+	}{
+		{label: "free", fees: nil, payer: nil, expected: nil},
+		{label: "first denom", fees: fees, payer: payer, balance: sdk.NewCoins(sdk.NewInt64Coin("uworm", 10), sdk.NewInt64Coin("uusdc", 1000)), expected: sdk.NewCoins(sdk.NewInt64Coin("uusdc", 1000))},
+		{label: "alternative denom", fees: fees, payer: payer, balance: sdk.NewCoins(sdk.NewInt64Coin("uworm", 10)), expected: sdk.NewCoins(sdk.NewInt64Coin("uworm", 10))},
+		{label: "insufficient balance", fees: fees, payer: payer, balance: sdk.NewCoins(sdk.NewInt64Coin("uworm", 9), sdk.NewInt64Coin("uusdc", 999)), err: types.ErrInsufficientMessageFee},
+		{label: "no payer", fees: fees, payer: nil, err: types.ErrInsufficientMessageFee},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.label, func(t *testing.T) {
+			bank := &mockBankKeeper{balances: map[string]sdk.Coins{payer.String(): tc.balance}}
+			k, ctx := keepertest.WormholeKeeperWithBank(t, bank)
+			k.SetConfig(ctx, types.Config{MessageFees: tc.fees})
+
+			emitter := types.EmitterAddressFromAccAddress(sdk.AccAddress(bytes.Repeat([]byte{0x01}, 20)))
+			capability, err := k.BindEmitter(ctx, emitter)
+			require.NoError(t, err)
+
+			err = k.PostMessage(ctx, capability, emitter, tc.payer, 0, []byte{1})
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				assert.True(t, bank.balances[module].IsZero())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected.String(), bank.balances[module].String())
+			assert.Equal(t, tc.balance.Sub(tc.expected).String(), bank.balances[payer.String()].String())
+		})
+	}
+}
+
+func TestPostAccountMessage(t *testing.T) {
+	contract := sdk.AccAddress(bytes.Repeat([]byte{0xcc}, 32))
+	module := authtypes.NewModuleAddress(types.ModuleName).String()
+	bank := &mockBankKeeper{balances: map[string]sdk.Coins{contract.String(): sdk.NewCoins(sdk.NewInt64Coin("uworm", 15))}}
+	k, ctx := keepertest.WormholeKeeperWithBank(t, bank)
+	k.SetConfig(ctx, types.Config{MessageFees: sdk.NewCoins(sdk.NewInt64Coin("uworm", 10))})
+	msgServer := keeper.NewMsgServerImpl(*k)
+
+	_, err := msgServer.PostMessage(sdk.WrapSDKContext(ctx), types.NewMsgPostMessage(contract.String(), 0, []byte{1}))
+	require.NoError(t, err)
+	emitter := types.EmitterAddressFromAccAddress(contract)
+	sequence, found := k.GetSequenceCounter(ctx, hex.EncodeToString(emitter.Bytes()))
+	require.True(t, found)
+	assert.Equal(t, uint64(1), sequence.Sequence)
+	assert.Equal(t, "10uworm", bank.balances[module].String())
+
+	// The contract pays the fee for every message
+	_, err = msgServer.PostMessage(sdk.WrapSDKContext(ctx), types.NewMsgPostMessage(contract.String(), 0, []byte{2}))
+	assert.ErrorIs(t, err, types.ErrInsufficientMessageFee)
+
+	// Emitters bound to a module can only post with their capability
+	bound := sdk.AccAddress(bytes.Repeat([]byte{0x01}, 20))
+	_, err = k.BindEmitter(ctx, types.EmitterAddressFromAccAddress(bound))
+	require.NoError(t, err)
+	assert.ErrorIs(t, k.PostAccountMessage(ctx, bound, 0, []byte{1}), types.ErrEmitterAlreadyBound)
+}
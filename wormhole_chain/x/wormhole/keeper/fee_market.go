@@ -0,0 +1,65 @@
+package keeper
+
+import (
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/wormhole-foundation/wormhole-chain/x/wormhole/types"
+)
+
+// SetBaseGasPrice sets the base gas price of the fee market in the store
+func (k Keeper) SetBaseGasPrice(ctx sdk.Context, price sdk.Dec) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.BaseGasPriceKey))
+	b, err := price.Marshal()
+	if err != nil {
+		panic(err)
+	}
+	store.Set([]byte{0}, b)
+}
+
+// GetStoredBaseGasPrice returns the base gas price of the fee market in the store
+func (k Keeper) GetStoredBaseGasPrice(ctx sdk.Context) (price sdk.Dec, found bool) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.BaseGasPriceKey))
+
+	b := store.Get([]byte{0})
+	if b == nil {
+		return price, false
+	}
+
+	if err := price.Unmarshal(b); err != nil {
+		panic(err)
+	}
+	return price, true
+}
+
+// RemoveBaseGasPrice removes the base gas price of the fee market from the store
+func (k Keeper) RemoveBaseGasPrice(ctx sdk.Context) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.BaseGasPriceKey))
+	store.Delete([]byte{0})
+}
+
+// GetFeeMarket returns the fee market of the config and its current base gas price, or false if there is none. The base
+// gas price starts at the minimum gas price and stays within the bounds of the fee market, also after governance
+// changed them.
+func (k Keeper) GetFeeMarket(ctx sdk.Context) (market types.FeeMarket, baseGasPrice sdk.Dec, found bool) {
+	config, ok := k.GetConfig(ctx)
+	if !ok || config.FeeMarket == nil {
+		return market, baseGasPrice, false
+	}
+	market = *config.FeeMarket
+
+	baseGasPrice, found = k.GetStoredBaseGasPrice(ctx)
+	if !found {
+		return market, market.MinGasPrice, true
+	}
+	return market, market.ClampGasPrice(baseGasPrice), true
+}
+
+// UpdateBaseGasPrice adjusts the base gas price of the fee market, if there is one, to the gas used by the current
+// block.
+func (k Keeper) UpdateBaseGasPrice(ctx sdk.Context, gasUsed uint64) {
+	market, baseGasPrice, found := k.GetFeeMarket(ctx)
+	if !found {
+		return
+	}
+	k.SetBaseGasPrice(ctx, market.NextBaseGasPrice(baseGasPrice, gasUsed))
+}
@@ -0,0 +1,64 @@
+package keeper_test
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	keepertest "github.com/wormhole-foundation/wormhole-chain/testutil/keeper"
+	"github.com/wormhole-foundation/wormhole-chain/x/wormhole/types"
+)
+
+func TestUpdateBaseGasPrice(t *testing.T) {
+	k, ctx := keepertest.WormholeKeeper(t)
+	k.SetConfig(ctx, types.Config{})
+
+	// Without a fee market there is no base gas price to update
+	k.UpdateBaseGasPrice(ctx, 1000)
+	_, _, found := k.GetFeeMarket(ctx)
+	assert.False(t, found)
+	_, found = k.GetStoredBaseGasPrice(ctx)
+	assert.False(t, found)
+
+	market := types.FeeMarket{
+		Denom:             "uworm",
+		MinGasPrice:       sdk.MustNewDecFromStr("0.01"),
+		MaxGasPrice:       sdk.MustNewDecFromStr("0.0128"),
+		TargetBlockGas:    1000,
+		ChangeDenominator: 8,
+	}
+	k.SetConfig(ctx, types.Config{FeeMarket: &market})
+	basePrice := func() sdk.Dec {
+		_, price, found := k.GetFeeMarket(ctx)
+		require.True(t, found)
+		return price
+	}
+	assert.Equal(t, sdk.MustNewDecFromStr("0.01"), basePrice())
+
+	for _, step := range []struct {
+		gasUsed uint64
+		price   string
+	}{
+		// Full blocks raise the price by an eighth
+		{gasUsed: 2000, price: "0.01125"},
+		// Blocks using more than twice the target count as full
+		{gasUsed: 100000, price: "0.01265625"},
+		{gasUsed: 1000, price: "0.01265625"},
+		{gasUsed: 1500, price: "0.0128"},
+		// Empty blocks lower it by an eighth, down to the minimum
+		{gasUsed: 0, price: "0.0112"},
+		{gasUsed: 0, price: "0.01"},
+	} {
+		k.UpdateBaseGasPrice(ctx, step.gasUsed)
+		assert.Equal(t, sdk.MustNewDecFromStr(step.price).String(), basePrice().String(), "after %d gas", step.gasUsed)
+	}
+
+	// The base gas price follows the bounds set by governance right away
+	market.MinGasPrice = sdk.MustNewDecFromStr("0.02")
+	market.MaxGasPrice = sdk.ZeroDec()
+	k.SetConfig(ctx, types.Config{FeeMarket: &market})
+	assert.Equal(t, sdk.MustNewDecFromStr("0.02").String(), basePrice().String())
+	k.UpdateBaseGasPrice(ctx, 2000)
+	assert.Equal(t, sdk.MustNewDecFromStr("0.0225").String(), basePrice().String())
+}
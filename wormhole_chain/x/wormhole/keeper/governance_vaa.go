@@ -0,0 +1,69 @@
+package keeper
+
+import (
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/wormhole-foundation/wormhole-chain/x/wormhole/types"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// SetGovernanceVAA set a specific governanceVAA in the store from its index
+func (k Keeper) SetGovernanceVAA(ctx sdk.Context, governanceVAA types.GovernanceVAA) {
+	key, err := types.GovernanceVAAKey(governanceVAA.Module, governanceVAA.Action, governanceVAA.Sequence)
+	if err != nil {
+		panic(err)
+	}
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.GovernanceVAAKeyPrefix))
+	b := k.cdc.MustMarshal(&governanceVAA)
+	store.Set(key, b)
+}
+
+// GetGovernanceVAA returns a governanceVAA from its index
+func (k Keeper) GetGovernanceVAA(ctx sdk.Context, module string, action uint32, sequence uint64) (val types.GovernanceVAA, found bool) {
+	key, err := types.GovernanceVAAKey(module, action, sequence)
+	if err != nil {
+		return val, false
+	}
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.GovernanceVAAKeyPrefix))
+	b := store.Get(key)
+	if b == nil {
+		return val, false
+	}
+	k.cdc.MustUnmarshal(b, &val)
+	return val, true
+}
+
+// GetAllGovernanceVAA returns all governanceVAA ordered by module, action and sequence
+func (k Keeper) GetAllGovernanceVAA(ctx sdk.Context) (list []types.GovernanceVAA) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.GovernanceVAAKeyPrefix))
+	iterator := sdk.KVStorePrefixIterator(store, []byte{})
+
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var val types.GovernanceVAA
+		k.cdc.MustUnmarshal(iterator.Value(), &val)
+		list = append(list, val)
+	}
+
+	return
+}
+
+// recordGovernanceVAA adds an executed governance VAA to the governance history.
+func (k Keeper) recordGovernanceVAA(ctx sdk.Context, v *vaa.VAA, module [32]byte, action byte, targetChain uint16) error {
+	bz, err := v.Marshal()
+	if err != nil {
+		return err
+	}
+	k.SetGovernanceVAA(ctx, types.GovernanceVAA{
+		Module:      strings.TrimLeft(string(module[:]), "\x00"),
+		Action:      uint32(action),
+		Sequence:    v.Sequence,
+		TargetChain: uint32(targetChain),
+		Vaa:         bz,
+		Height:      ctx.BlockHeight(),
+	})
+	return nil
+}
@@ -0,0 +1,27 @@
+package keeper
+
+import (
+	"context"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/wormhole-foundation/wormhole-chain/x/wormhole/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (k Keeper) BaseGasPrice(c context.Context, req *types.QueryBaseGasPriceRequest) (*types.QueryBaseGasPriceResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+
+	market, baseGasPrice, found := k.GetFeeMarket(ctx)
+	if !found {
+		return nil, status.Error(codes.NotFound, "no fee market")
+	}
+
+	return &types.QueryBaseGasPriceResponse{
+		BaseGasPrice: sdk.NewDecCoinFromDec(market.Denom, baseGasPrice),
+		MinGasPrice:  sdk.NewDecCoinFromDec(market.Denom, market.MinGasPrice),
+	}, nil
+}
@@ -0,0 +1,57 @@
+package keeper
+
+import (
+	"context"
+
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	"github.com/wormhole-foundation/wormhole-chain/x/wormhole/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (k Keeper) EmitterRateLimitAll(c context.Context, req *types.QueryAllEmitterRateLimitRequest) (*types.QueryAllEmitterRateLimitResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	var emitterRateLimits []types.EmitterRateLimit
+	ctx := sdk.UnwrapSDKContext(c)
+
+	store := ctx.KVStore(k.storeKey)
+	emitterRateLimitStore := prefix.NewStore(store, types.KeyPrefix(types.EmitterRateLimitKeyPrefix))
+
+	pageRes, err := query.Paginate(emitterRateLimitStore, req.Pagination, func(key []byte, value []byte) error {
+		var emitterRateLimit types.EmitterRateLimit
+		if err := k.cdc.Unmarshal(value, &emitterRateLimit); err != nil {
+			return err
+		}
+
+		emitterRateLimits = append(emitterRateLimits, emitterRateLimit)
+		return nil
+	})
+
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &types.QueryAllEmitterRateLimitResponse{EmitterRateLimit: emitterRateLimits, Pagination: pageRes}, nil
+}
+
+func (k Keeper) EmitterRateLimit(c context.Context, req *types.QueryGetEmitterRateLimitRequest) (*types.QueryGetEmitterRateLimitResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+
+	val, found := k.GetEmitterRateLimit(
+		ctx,
+		req.Emitter,
+	)
+	if !found {
+		return nil, status.Error(codes.InvalidArgument, "not found")
+	}
+
+	return &types.QueryGetEmitterRateLimitResponse{EmitterRateLimit: val}, nil
+}
@@ -0,0 +1,71 @@
+package keeper
+
+import (
+	"context"
+
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	"github.com/wormhole-foundation/wormhole-chain/x/wormhole/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (k Keeper) GovernanceVAAAll(c context.Context, req *types.QueryAllGovernanceVAARequest) (*types.QueryAllGovernanceVAAResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	// Restrict the VAAs to the prefix of the module and action, if set
+	var filter []byte
+	if req.Module != "" {
+		moduleKey, err := types.GovernanceModuleKey(req.Module)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		filter = moduleKey
+	}
+	if req.Action != 0 {
+		if filter == nil || req.Action > 0xff {
+			return nil, status.Error(codes.InvalidArgument, "invalid action filter")
+		}
+		filter = append(filter, byte(req.Action))
+	}
+
+	var governanceVAAs []types.GovernanceVAA
+	ctx := sdk.UnwrapSDKContext(c)
+
+	store := ctx.KVStore(k.storeKey)
+	governanceVAAStore := prefix.NewStore(store, append(types.KeyPrefix(types.GovernanceVAAKeyPrefix), filter...))
+
+	pageRes, err := query.Paginate(governanceVAAStore, req.Pagination, func(key []byte, value []byte) error {
+		var governanceVAA types.GovernanceVAA
+		if err := k.cdc.Unmarshal(value, &governanceVAA); err != nil {
+			return err
+		}
+
+		governanceVAAs = append(governanceVAAs, governanceVAA)
+		return nil
+	})
+
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &types.QueryAllGovernanceVAAResponse{GovernanceVAA: governanceVAAs, Pagination: pageRes}, nil
+}
+
+func (k Keeper) GovernanceVAA(c context.Context, req *types.QueryGetGovernanceVAARequest) (*types.QueryGetGovernanceVAAResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+	governanceVAA, found := k.GetGovernanceVAA(ctx, req.Module, req.Action, req.Sequence)
+	if !found {
+		return nil, sdkerrors.ErrKeyNotFound
+	}
+
+	return &types.QueryGetGovernanceVAAResponse{GovernanceVAA: governanceVAA}, nil
+}
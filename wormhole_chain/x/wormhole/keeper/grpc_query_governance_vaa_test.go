@@ -0,0 +1,120 @@
+package keeper_test
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	keepertest "github.com/wormhole-foundation/wormhole-chain/testutil/keeper"
+	"github.com/wormhole-foundation/wormhole-chain/x/wormhole/keeper"
+	"github.com/wormhole-foundation/wormhole-chain/x/wormhole/types"
+)
+
+func createGovernanceVAAs(keeper *keeper.Keeper, ctx sdk.Context) []types.GovernanceVAA {
+	items := []types.GovernanceVAA{
+		{Module: "Core", Action: 2, Sequence: 1, Vaa: []byte{1}},
+		{Module: "Core", Action: 2, Sequence: 4, Vaa: []byte{4}},
+		{Module: "Core", Action: 4, Sequence: 3, Vaa: []byte{3}},
+		{Module: "TokenBridge", Action: 1, Sequence: 2, Vaa: []byte{2}},
+	}
+	for _, item := range items {
+		keeper.SetGovernanceVAA(ctx, item)
+	}
+	return items
+}
+
+func TestGovernanceVAAQuerySingle(t *testing.T) {
+	keeper, ctx := keepertest.WormholeKeeper(t)
+	wctx := sdk.WrapSDKContext(ctx)
+	msgs := createGovernanceVAAs(keeper, ctx)
+	for _, tc := range []struct {
+		desc     string
+		request  *types.QueryGetGovernanceVAARequest
+		response *types.QueryGetGovernanceVAAResponse
+		err      error
+	}{
+		{
+			desc:     "First",
+			request:  &types.QueryGetGovernanceVAARequest{Module: "Core", Action: 2, Sequence: 1},
+			response: &types.QueryGetGovernanceVAAResponse{GovernanceVAA: msgs[0]},
+		},
+		{
+			desc:     "OtherModule",
+			request:  &types.QueryGetGovernanceVAARequest{Module: "TokenBridge", Action: 1, Sequence: 2},
+			response: &types.QueryGetGovernanceVAAResponse{GovernanceVAA: msgs[3]},
+		},
+		{
+			desc:    "KeyNotFound",
+			request: &types.QueryGetGovernanceVAARequest{Module: "TokenBridge", Action: 2, Sequence: 1},
+			err:     sdkerrors.ErrKeyNotFound,
+		},
+		{
+			desc:    "InvalidModule",
+			request: &types.QueryGetGovernanceVAARequest{Action: 2, Sequence: 1},
+			err:     sdkerrors.ErrKeyNotFound,
+		},
+		{
+			desc: "InvalidRequest",
+			err:  status.Error(codes.InvalidArgument, "invalid request"),
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			response, err := keeper.GovernanceVAA(wctx, tc.request)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+			} else {
+				require.Equal(t, tc.response, response)
+			}
+		})
+	}
+}
+
+func TestGovernanceVAAQueryPaginated(t *testing.T) {
+	keeper, ctx := keepertest.WormholeKeeper(t)
+	wctx := sdk.WrapSDKContext(ctx)
+	msgs := createGovernanceVAAs(keeper, ctx)
+
+	t.Run("Ordered", func(t *testing.T) {
+		resp, err := keeper.GovernanceVAAAll(wctx, &types.QueryAllGovernanceVAARequest{})
+		require.NoError(t, err)
+		require.Equal(t, msgs, resp.GovernanceVAA)
+	})
+	t.Run("ByKey", func(t *testing.T) {
+		step := 3
+		var next []byte
+		var got []types.GovernanceVAA
+		for i := 0; i < len(msgs); i += step {
+			resp, err := keeper.GovernanceVAAAll(wctx, &types.QueryAllGovernanceVAARequest{Pagination: &query.PageRequest{Key: next, Limit: uint64(step)}})
+			require.NoError(t, err)
+			got = append(got, resp.GovernanceVAA...)
+			next = resp.Pagination.NextKey
+		}
+		require.Equal(t, msgs, got)
+	})
+	t.Run("Filtered", func(t *testing.T) {
+		resp, err := keeper.GovernanceVAAAll(wctx, &types.QueryAllGovernanceVAARequest{Module: "Core"})
+		require.NoError(t, err)
+		require.Equal(t, msgs[:3], resp.GovernanceVAA)
+
+		resp, err = keeper.GovernanceVAAAll(wctx, &types.QueryAllGovernanceVAARequest{Module: "Core", Action: 2})
+		require.NoError(t, err)
+		require.Equal(t, msgs[:2], resp.GovernanceVAA)
+
+		// Module names are not prefixes of each other
+		resp, err = keeper.GovernanceVAAAll(wctx, &types.QueryAllGovernanceVAARequest{Module: "Bridge"})
+		require.NoError(t, err)
+		require.Empty(t, resp.GovernanceVAA)
+
+		_, err = keeper.GovernanceVAAAll(wctx, &types.QueryAllGovernanceVAARequest{Action: 2})
+		require.ErrorIs(t, err, status.Error(codes.InvalidArgument, "invalid action filter"))
+	})
+	t.Run("InvalidRequest", func(t *testing.T) {
+		_, err := keeper.GovernanceVAAAll(wctx, nil)
+		require.ErrorIs(t, err, status.Error(codes.InvalidArgument, "invalid request"))
+	})
+}
@@ -0,0 +1,55 @@
+package keeper
+
+import (
+	"context"
+
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	"github.com/wormhole-foundation/wormhole-chain/x/wormhole/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (k Keeper) GuardianSetUpgradeAll(c context.Context, req *types.QueryAllGuardianSetUpgradeRequest) (*types.QueryAllGuardianSetUpgradeResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	var guardianSetUpgrades []types.GuardianSetUpgrade
+	ctx := sdk.UnwrapSDKContext(c)
+
+	store := ctx.KVStore(k.storeKey)
+	guardianSetUpgradeStore := prefix.NewStore(store, types.KeyPrefix(types.GuardianSetUpgradeKey))
+
+	pageRes, err := query.Paginate(guardianSetUpgradeStore, req.Pagination, func(key []byte, value []byte) error {
+		var guardianSetUpgrade types.GuardianSetUpgrade
+		if err := k.cdc.Unmarshal(value, &guardianSetUpgrade); err != nil {
+			return err
+		}
+
+		guardianSetUpgrades = append(guardianSetUpgrades, guardianSetUpgrade)
+		return nil
+	})
+
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &types.QueryAllGuardianSetUpgradeResponse{GuardianSetUpgrade: guardianSetUpgrades, Pagination: pageRes}, nil
+}
+
+func (k Keeper) GuardianSetUpgrade(c context.Context, req *types.QueryGetGuardianSetUpgradeRequest) (*types.QueryGetGuardianSetUpgradeResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+	guardianSetUpgrade, found := k.GetGuardianSetUpgrade(ctx, req.Index)
+	if !found {
+		return nil, sdkerrors.ErrKeyNotFound
+	}
+
+	return &types.QueryGetGuardianSetUpgradeResponse{GuardianSetUpgrade: guardianSetUpgrade}, nil
+}
@@ -0,0 +1,33 @@
+package keeper
+
+import (
+	"context"
+	"encoding/hex"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/wormhole-foundation/wormhole-chain/x/wormhole/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// VAAVerification verifies a VAA against the state of the queried height. A VAA that fails verification is not an
+// error of the query, its report says why it failed.
+func (k Keeper) VAAVerification(c context.Context, req *types.QueryVAAVerificationRequest) (*types.QueryVAAVerificationResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+	b, err := hex.DecodeString(strings.TrimPrefix(req.Vaa, "0x"))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid hex encoded VAA")
+	}
+	v, err := ParseVAA(b)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+	report, _ := k.VerifyVAAWithReport(ctx, v)
+
+	return &types.QueryVAAVerificationResponse{Report: report}, nil
+}
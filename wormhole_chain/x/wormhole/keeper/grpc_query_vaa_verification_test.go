@@ -0,0 +1,45 @@
+package keeper_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	keepertest "github.com/wormhole-foundation/wormhole-chain/testutil/keeper"
+	"github.com/wormhole-foundation/wormhole-chain/x/wormhole/types"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+func TestVAAVerificationQuery(t *testing.T) {
+	keeper, ctx := keepertest.WormholeKeeper(t)
+	wctx := sdk.WrapSDKContext(ctx)
+	guardians, privateKeys := createNGuardianValidator(keeper, ctx, 4)
+	set := createNewGuardianSet(keeper, ctx, guardians)
+
+	v := generateVaa(set.Index, privateKeys, vaa.ChainIDSolana, []byte{1})
+	b, err := v.Marshal()
+	require.NoError(t, err)
+	res, err := keeper.VAAVerification(wctx, &types.QueryVAAVerificationRequest{Vaa: "0x" + hex.EncodeToString(b)})
+	require.NoError(t, err)
+	require.True(t, res.Report.Verified)
+	require.Equal(t, uint32(4), res.Report.ValidSignatures)
+
+	// A VAA failing verification is reported, not an error
+	v.Signatures[0].Signature[1] ^= 0x40
+	b, err = v.Marshal()
+	require.NoError(t, err)
+	res, err = keeper.VAAVerification(wctx, &types.QueryVAAVerificationRequest{Vaa: hex.EncodeToString(b)})
+	require.NoError(t, err)
+	require.False(t, res.Report.Verified)
+	require.Equal(t, types.ErrSignaturesInvalid.Error(), res.Report.Reason)
+	require.False(t, res.Report.Signatures[0].Valid)
+
+	for _, req := range []*types.QueryVAAVerificationRequest{nil, {Vaa: "zz"}, {Vaa: "01"}} {
+		_, err = keeper.VAAVerification(wctx, req)
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	}
+}
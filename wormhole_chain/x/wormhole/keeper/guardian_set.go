@@ -6,14 +6,16 @@ import (
 
 	"github.com/cosmos/cosmos-sdk/store/prefix"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/wormhole-foundation/wormhole-chain/x/wormhole/types"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
 )
 
 func (k Keeper) GetLatestGuardianSetIndex(ctx sdk.Context) uint32 {
 	return k.GetGuardianSetCount(ctx) - 1
 }
 
-func (k Keeper) UpdateGuardianSet(ctx sdk.Context, newGuardianSet types.GuardianSet) error {
+func (k Keeper) UpdateGuardianSet(ctx sdk.Context, newGuardianSet types.GuardianSet, upgradeVAA *vaa.VAA) error {
 	config, ok := k.GetConfig(ctx)
 	if !ok {
 		return types.ErrNoConfig
@@ -42,6 +44,20 @@ func (k Keeper) UpdateGuardianSet(ctx sdk.Context, newGuardianSet types.Guardian
 	oldSet.ExpirationTime = uint64(ctx.BlockTime().Unix()) + config.GuardianSetExpiration
 	k.setGuardianSet(ctx, oldSet)
 
+	// Record the upgrade so the lineage of the guardian set can be verified
+	upgrade := types.GuardianSetUpgrade{
+		Index:  newGuardianSet.Index,
+		Height: ctx.BlockHeight(),
+	}
+	if upgradeVAA != nil {
+		upgrade.Vaa, err = upgradeVAA.Marshal()
+		if err != nil {
+			return err
+		}
+		upgrade.Digest = upgradeVAA.SigningMsg().Bytes()
+	}
+	k.SetGuardianSetUpgrade(ctx, upgrade)
+
 	// Emit event
 	err = ctx.EventManager().EmitTypedEvent(&types.EventGuardianSetUpdate{
 		OldIndex: oldSet.Index,
@@ -96,6 +112,46 @@ func (k Keeper) TrySwitchToNewConsensusGuardianSet(ctx sdk.Context) error {
 	return err
 }
 
+// SyncGuardianValidators completes a pending consensus guardian set switch and,
+// once the latest set is the consensus set, removes the registrations of
+// guardians that are no longer part of it. x/staking only allocates voting
+// power to consensus guardians, so this keeps the validator set in line with
+// guardian membership and frees validators of rotated guardian keys to
+// register again.
+func (k Keeper) SyncGuardianValidators(ctx sdk.Context) error {
+	if err := k.TrySwitchToNewConsensusGuardianSet(ctx); err != nil {
+		return err
+	}
+
+	consensusGuardianSetIndex, _ := k.GetConsensusGuardianSetIndex(ctx)
+	if consensusGuardianSetIndex.Index != k.GetLatestGuardianSetIndex(ctx) {
+		// Registrations for the latest set are still being collected
+		return nil
+	}
+
+	consensusGuardianSet, found := k.GetGuardianSet(ctx, consensusGuardianSetIndex.Index)
+	if !found {
+		return types.ErrGuardianSetNotFound
+	}
+
+	for _, gv := range k.GetAllGuardianValidator(ctx) {
+		if consensusGuardianSet.ContainsKey(common.BytesToAddress(gv.GuardianKey)) {
+			continue
+		}
+
+		k.RemoveGuardianValidator(ctx, gv.GuardianKey)
+		err := ctx.EventManager().EmitTypedEvent(&types.EventGuardianValidatorRemoved{
+			GuardianKey:  gv.GuardianKey,
+			ValidatorKey: gv.ValidatorAddr,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // GetGuardianSetCount get the total number of guardianSet
 func (k Keeper) GetGuardianSetCount(ctx sdk.Context) uint32 {
 	store := prefix.NewStore(ctx.KVStore(k.storeKey), []byte{})
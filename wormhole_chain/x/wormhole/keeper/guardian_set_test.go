@@ -1,9 +1,11 @@
 package keeper_test
 
 import (
+	"bytes"
 	"testing"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/require"
 	keepertest "github.com/wormhole-foundation/wormhole-chain/testutil/keeper"
 	"github.com/wormhole-foundation/wormhole-chain/x/wormhole/keeper"
@@ -44,3 +46,91 @@ func TestGuardianSetCount(t *testing.T) {
 	count := uint32(len(items))
 	require.Equal(t, count, keeper.GetGuardianSetCount(ctx))
 }
+
+func TestSyncGuardianValidators(t *testing.T) {
+	k, ctx := keepertest.WormholeKeeper(t)
+	k.SetConfig(ctx, types.Config{GuardianSetExpiration: 86400})
+
+	validators := func(n byte) []sdk.ValAddress {
+		addrs := make([]sdk.ValAddress, n)
+		for i := range addrs {
+			addrs[i] = sdk.ValAddress(bytes.Repeat([]byte{byte(i) + 1}, 20))
+		}
+		return addrs
+	}(5)
+
+	// Guardian set 0 with three registered guardians
+	guardians, _ := createNGuardianValidator(k, ctx, 3)
+	for i := range guardians {
+		guardians[i].ValidatorAddr = validators[i]
+		k.SetGuardianValidator(ctx, guardians[i])
+	}
+	set0 := createNewGuardianSet(k, ctx, guardians)
+	k.SetConsensusGuardianSetIndex(ctx, types.ConsensusGuardianSetIndex{Index: set0.Index})
+
+	// Guardian set 1 keeps the first guardian and replaces the others
+	newKeys := [][]byte{guardians[0].GuardianKey}
+	for i := 0; i < 2; i++ {
+		key, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		newKeys = append(newKeys, crypto.PubkeyToAddress(key.PublicKey).Bytes())
+	}
+	require.NoError(t, k.UpdateGuardianSet(ctx, types.GuardianSet{Index: 1, Keys: newKeys}, nil))
+	upgrade, found := k.GetGuardianSetUpgrade(ctx, 1)
+	require.True(t, found)
+	require.Empty(t, upgrade.Vaa)
+
+	// The new guardians did not register yet, so the old set stays in charge
+	require.NoError(t, k.SyncGuardianValidators(ctx))
+	consensusIndex, _ := k.GetConsensusGuardianSetIndex(ctx)
+	require.Equal(t, uint32(0), consensusIndex.Index)
+	require.Len(t, k.GetAllGuardianValidator(ctx), 3)
+	isGuardian, err := k.IsConsensusGuardian(ctx, validators[2])
+	require.NoError(t, err)
+	require.True(t, isGuardian)
+
+	for i, key := range newKeys[1:] {
+		k.SetGuardianValidator(ctx, types.GuardianValidator{GuardianKey: key, ValidatorAddr: validators[3+i]})
+	}
+
+	// Once everyone is registered, the removed guardians lose their registration
+	require.NoError(t, k.SyncGuardianValidators(ctx))
+	consensusIndex, _ = k.GetConsensusGuardianSetIndex(ctx)
+	require.Equal(t, uint32(1), consensusIndex.Index)
+	require.Len(t, k.GetAllGuardianValidator(ctx), 3)
+
+	for i, validator := range validators {
+		isGuardian, err := k.IsConsensusGuardian(ctx, validator)
+		require.NoError(t, err)
+		require.Equal(t, i != 1 && i != 2, isGuardian, "validator %d", i)
+	}
+	for _, gv := range guardians[1:] {
+		_, found := k.GetGuardianValidator(ctx, gv.GuardianKey)
+		require.False(t, found)
+	}
+}
+
+func TestMigrate2to3(t *testing.T) {
+	k, ctx := keepertest.WormholeKeeper(t)
+	createNGuardianSet(t, k, ctx, 4)
+	recorded := types.GuardianSetUpgrade{Index: 3, Vaa: []byte{0x01}, Digest: []byte{0x02}, Height: 42}
+	k.SetGuardianSetUpgrade(ctx, recorded)
+
+	require.NoError(t, keeper.NewMigrator(*k).Migrate2to3(ctx))
+
+	// The genesis set was not installed by an upgrade
+	_, found := k.GetGuardianSetUpgrade(ctx, 0)
+	require.False(t, found)
+
+	// Upgrades that were not recorded are backfilled without their VAA and height
+	for _, index := range []uint32{1, 2} {
+		upgrade, found := k.GetGuardianSetUpgrade(ctx, index)
+		require.True(t, found)
+		require.Equal(t, types.GuardianSetUpgrade{Index: index}, upgrade)
+	}
+
+	// Recorded upgrades are kept
+	upgrade, found := k.GetGuardianSetUpgrade(ctx, 3)
+	require.True(t, found)
+	require.Equal(t, recorded, upgrade)
+}
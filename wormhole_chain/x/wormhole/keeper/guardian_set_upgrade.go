@@ -0,0 +1,41 @@
+package keeper
+
+import (
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/wormhole-foundation/wormhole-chain/x/wormhole/types"
+)
+
+// SetGuardianSetUpgrade set a specific guardianSetUpgrade in the store from its index
+func (k Keeper) SetGuardianSetUpgrade(ctx sdk.Context, guardianSetUpgrade types.GuardianSetUpgrade) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.GuardianSetUpgradeKey))
+	b := k.cdc.MustMarshal(&guardianSetUpgrade)
+	store.Set(GetGuardianSetIDBytes(guardianSetUpgrade.Index), b)
+}
+
+// GetGuardianSetUpgrade returns the upgrade that installed the guardian set with the given index
+func (k Keeper) GetGuardianSetUpgrade(ctx sdk.Context, index uint32) (val types.GuardianSetUpgrade, found bool) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.GuardianSetUpgradeKey))
+	b := store.Get(GetGuardianSetIDBytes(index))
+	if b == nil {
+		return val, false
+	}
+	k.cdc.MustUnmarshal(b, &val)
+	return val, true
+}
+
+// GetAllGuardianSetUpgrade returns all guardianSetUpgrade in ascending order of the guardian set index
+func (k Keeper) GetAllGuardianSetUpgrade(ctx sdk.Context) (list []types.GuardianSetUpgrade) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.GuardianSetUpgradeKey))
+	iterator := sdk.KVStorePrefixIterator(store, []byte{})
+
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var val types.GuardianSetUpgrade
+		k.cdc.MustUnmarshal(iterator.Value(), &val)
+		list = append(list, val)
+	}
+
+	return
+}
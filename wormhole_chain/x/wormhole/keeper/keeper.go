@@ -7,6 +7,7 @@ import (
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	capabilitykeeper "github.com/cosmos/cosmos-sdk/x/capability/keeper"
 	"github.com/wormhole-foundation/wormhole-chain/x/wormhole/types"
 )
 
@@ -15,27 +16,39 @@ type (
 		cdc      codec.BinaryCodec
 		storeKey sdk.StoreKey
 		memKey   sdk.StoreKey
+		tKey     sdk.StoreKey
 
 		accountKeeper types.AccountKeeper
 		bankKeeper    types.BankKeeper
+		scopedKeeper  capabilitykeeper.ScopedKeeper
 		wasmdKeeper   types.WasmdKeeper
 		setWasmd      bool
+
+		// VAAs that passed signature verification, shared between CheckTx and DeliverTx
+		verifiedVAAs *verifiedVAACache
+		// Guardian addresses recovered from VAA signatures, shared between CheckTx and DeliverTx
+		recoveredSigners *recoveredSignerCache
 	}
 )
 
 func NewKeeper(
 	cdc codec.BinaryCodec,
 	storeKey,
-	memKey sdk.StoreKey,
+	memKey,
+	tKey sdk.StoreKey,
 
-	accountKeeper types.AccountKeeper, bankKeeper types.BankKeeper,
+	accountKeeper types.AccountKeeper, bankKeeper types.BankKeeper, scopedKeeper capabilitykeeper.ScopedKeeper,
 ) *Keeper {
 	return &Keeper{
 		cdc:      cdc,
 		storeKey: storeKey,
 		memKey:   memKey,
+		tKey:     tKey,
+
+		accountKeeper: accountKeeper, bankKeeper: bankKeeper, scopedKeeper: scopedKeeper,
 
-		accountKeeper: accountKeeper, bankKeeper: bankKeeper,
+		verifiedVAAs:     newVerifiedVAACache(verifiedVAACacheSize),
+		recoveredSigners: newRecoveredSignerCache(recoveredSignerCacheSize),
 	}
 }
 
@@ -0,0 +1,30 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/wormhole-foundation/wormhole-chain/x/wormhole/types"
+)
+
+// Migrator handles in-place store migrations.
+type Migrator struct {
+	keeper Keeper
+}
+
+func NewMigrator(keeper Keeper) Migrator {
+	return Migrator{keeper: keeper}
+}
+
+// Migrate2to3 records an upgrade for every guardian set installed before
+// upgrades were recorded, so that the lineage of every set past the genesis
+// set has an entry. Neither the upgrade VAA nor the height of those upgrades
+// was kept, so the records leave both empty: the lineage of those sets remains
+// a gap that cannot be verified from chain state.
+func (m Migrator) Migrate2to3(ctx sdk.Context) error {
+	for index := uint32(1); index < m.keeper.GetGuardianSetCount(ctx); index++ {
+		if _, found := m.keeper.GetGuardianSetUpgrade(ctx, index); found {
+			continue
+		}
+		m.keeper.SetGuardianSetUpgrade(ctx, types.GuardianSetUpgrade{Index: index})
+	}
+	return nil
+}
@@ -1,8 +1,12 @@
 package keeper
 
 import (
+	"bytes"
 	"context"
 	"encoding/binary"
+	"fmt"
+	"math/big"
+	"strings"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/wormhole-foundation/wormhole-chain/x/wormhole/types"
@@ -14,6 +18,7 @@ type GovernanceAction uint8
 var (
 	ActionContractUpgrade   GovernanceAction = 1
 	ActionGuardianSetUpdate GovernanceAction = 2
+	ActionTransferFees      GovernanceAction = 4
 )
 
 func (k msgServer) ExecuteGovernanceVAA(goCtx context.Context, msg *types.MsgExecuteGovernanceVAA) (*types.MsgExecuteGovernanceVAAResponse, error) {
@@ -28,7 +33,8 @@ func (k msgServer) ExecuteGovernanceVAA(goCtx context.Context, msg *types.MsgExe
 	coreModule := [32]byte{}
 	copy(coreModule[:], vaa.CoreModule)
 	// Verify VAA
-	action, payload, err := k.VerifyGovernanceVAA(ctx, v, coreModule)
+	// Guardian set updates apply to all chains, so any valid target chain is accepted
+	action, targetChain, payload, err := k.VerifyGovernanceVAA(ctx, v, coreModule, sdk.MsgTypeURL(msg))
 	if err != nil {
 		return nil, err
 	}
@@ -62,10 +68,22 @@ func (k msgServer) ExecuteGovernanceVAA(goCtx context.Context, msg *types.MsgExe
 		err := k.UpdateGuardianSet(ctx, types.GuardianSet{
 			Keys:  keys,
 			Index: newIndex,
-		})
+		}, v)
 		if err != nil {
 			return nil, err
 		}
+	case ActionTransferFees:
+		// Fees can only be paid out by the chain holding them
+		config, ok := k.GetConfig(ctx)
+		if !ok {
+			return nil, types.ErrNoConfig
+		}
+		if !types.IsGovernanceTarget(targetChain, uint16(config.ChainId), false) {
+			return nil, types.ErrInvalidGovernanceTargetChain
+		}
+		if err := k.transferFees(ctx, payload); err != nil {
+			return nil, err
+		}
 	default:
 		return nil, types.ErrUnknownGovernanceAction
 
@@ -73,3 +91,36 @@ func (k msgServer) ExecuteGovernanceVAA(goCtx context.Context, msg *types.MsgExe
 
 	return &types.MsgExecuteGovernanceVAAResponse{}, nil
 }
+
+// transferFees pays out collected message fees from the module account.
+//
+// Payload: amount (32) | denom, left-padded with zeros (32) | recipient, left-padded with zeros (32)
+func (k msgServer) transferFees(ctx sdk.Context, payload []byte) error {
+	if len(payload) != 96 {
+		return types.ErrInvalidGovernancePayloadLength
+	}
+
+	amount := sdk.NewIntFromBigInt(new(big.Int).SetBytes(payload[:32]))
+	if amount.IsZero() {
+		return types.ErrZeroFeeAmount
+	}
+	denom := strings.TrimLeft(string(payload[32:64]), "\x00")
+	if err := sdk.ValidateDenom(denom); err != nil {
+		return fmt.Errorf("%w: %s", types.ErrInvalidFeeDenom, err)
+	}
+	if !bytes.Equal(payload[64:76], make([]byte, 12)) {
+		return types.ErrInvalidFeeRecipient
+	}
+	recipient := sdk.AccAddress(payload[76:96])
+
+	fee := sdk.NewCoin(denom, amount)
+	if err := k.bankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, recipient, sdk.NewCoins(fee)); err != nil {
+		return fmt.Errorf("%w: %s", types.ErrInsufficientFees, err)
+	}
+
+	return ctx.EventManager().EmitTypedEvent(&types.EventMessageFeesTransferred{
+		Recipient: recipient.String(),
+		Amount:    amount.String(),
+		Denom:     denom,
+	})
+}
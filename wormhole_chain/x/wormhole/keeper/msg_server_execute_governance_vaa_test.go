@@ -1,12 +1,15 @@
 package keeper_test
 
 import (
+	"bytes"
 	"crypto/ecdsa"
 	"encoding/binary"
 	"testing"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	keepertest "github.com/wormhole-foundation/wormhole-chain/testutil/keeper"
 	"github.com/wormhole-foundation/wormhole-chain/x/wormhole/keeper"
 	"github.com/wormhole-foundation/wormhole-chain/x/wormhole/types"
@@ -66,6 +69,26 @@ func TestExecuteGovernanceVAA(t *testing.T) {
 	new_set, _ := k.GetGuardianSet(ctx, new_index)
 	assert.Len(t, new_set.Keys, 11)
 
+	// the upgrade VAA is kept as proof of the new set
+	upgrade, found := k.GetGuardianSetUpgrade(ctx, new_index)
+	assert.True(t, found)
+	assert.Equal(t, vBz, upgrade.Vaa)
+	assert.Equal(t, v.SigningMsg().Bytes(), upgrade.Digest)
+	_, found = k.GetGuardianSetUpgrade(ctx, set.Index)
+	assert.False(t, found)
+
+	// and so is the governance history
+	governanceVAA, found := k.GetGovernanceVAA(ctx, "Core", uint32(keeper.ActionGuardianSetUpdate), v.Sequence)
+	assert.True(t, found)
+	assert.Equal(t, types.GovernanceVAA{
+		Module:      "Core",
+		Action:      uint32(keeper.ActionGuardianSetUpdate),
+		Sequence:    v.Sequence,
+		TargetChain: uint32(vaa.ChainIDWormchain),
+		Vaa:         vBz,
+		Height:      ctx.BlockHeight(),
+	}, governanceVAA)
+
 	// Submitting another change with the old set doesn't work
 	v = generateVaa(set.Index, privateKeys, vaa.ChainID(vaa.GovernanceChain), payload)
 	vBz, _ = v.Marshal()
@@ -107,3 +130,67 @@ func TestExecuteGovernanceVAA(t *testing.T) {
 	new_index2 := k.GetLatestGuardianSetIndex(ctx)
 	assert.Equal(t, new_set.Index+1, new_index2)
 }
+
+func createTransferFeesPayload(amount int64, denom string, recipient []byte) []byte {
+	payload := make([]byte, 96)
+	binary.BigEndian.PutUint64(payload[24:32], uint64(amount))
+	copy(payload[64-len(denom):64], denom)
+	copy(payload[96-len(recipient):], recipient)
+	return payload
+}
+
+func TestExecuteGovernanceVAATransferFees(t *testing.T) {
+	recipient := sdk.AccAddress(bytes.Repeat([]byte{0xaa}, 20))
+	module := authtypes.NewModuleAddress(types.ModuleName).String()
+
+	tests := []struct {
+		label       string
+		targetChain vaa.ChainID
+		payload     []byte
+		err         error
+	}{
+		{label: "transfer", targetChain: vaa.ChainIDWormchain, payload: createTransferFeesPayload(60, "uworm", recipient)},
+		{label: "all chains", targetChain: 0, payload: createTransferFeesPayload(60, "uworm", recipient), err: types.ErrInvalidGovernanceTargetChain},
+		{label: "other chain", targetChain: vaa.ChainIDEthereum, payload: createTransferFeesPayload(60, "uworm", recipient), err: types.ErrInvalidGovernanceTargetChain},
+		{label: "short payload", targetChain: vaa.ChainIDWormchain, payload: createTransferFeesPayload(60, "uworm", recipient)[:95], err: types.ErrInvalidGovernancePayloadLength},
+		{label: "zero amount", targetChain: vaa.ChainIDWormchain, payload: createTransferFeesPayload(0, "uworm", recipient), err: types.ErrZeroFeeAmount},
+		{label: "invalid denom", targetChain: vaa.ChainIDWormchain, payload: createTransferFeesPayload(60, "u", recipient), err: types.ErrInvalidFeeDenom},
+		{label: "unpadded recipient", targetChain: vaa.ChainIDWormchain, payload: createTransferFeesPayload(60, "uworm", bytes.Repeat([]byte{0xaa}, 21)), err: types.ErrInvalidFeeRecipient},
+		{label: "more than collected", targetChain: vaa.ChainIDWormchain, payload: createTransferFeesPayload(101, "uworm", recipient), err: types.ErrInsufficientFees},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.label, func(t *testing.T) {
+			bank := &mockBankKeeper{balances: map[string]sdk.Coins{module: sdk.NewCoins(sdk.NewInt64Coin("uworm", 100))}}
+			k, ctx := keepertest.WormholeKeeperWithBank(t, bank)
+			guardians, privateKeys := createNGuardianValidator(k, ctx, 1)
+			k.SetConfig(ctx, types.Config{
+				GovernanceEmitter: vaa.GovernanceEmitter[:],
+				GovernanceChain:   uint32(vaa.GovernanceChain),
+				ChainId:           uint32(vaa.ChainIDWormchain),
+			})
+			set := createNewGuardianSet(k, ctx, guardians)
+
+			coreModule := [32]byte{}
+			copy(coreModule[:], vaa.CoreModule)
+			govMsg := types.NewGovernanceMessage(coreModule, byte(keeper.ActionTransferFees), uint16(tc.targetChain), tc.payload)
+			v := generateVaa(set.Index, privateKeys, vaa.ChainID(vaa.GovernanceChain), govMsg.MarshalBinary())
+			vBz, err := v.Marshal()
+			require.NoError(t, err)
+
+			msgServer := keeper.NewMsgServerImpl(*k)
+			_, err = msgServer.ExecuteGovernanceVAA(sdk.WrapSDKContext(ctx), &types.MsgExecuteGovernanceVAA{
+				Signer: recipient.String(),
+				Vaa:    vBz,
+			})
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				assert.Equal(t, "100uworm", bank.balances[module].String())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "40uworm", bank.balances[module].String())
+			assert.Equal(t, "60uworm", bank.balances[recipient.String()].String())
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package keeper
+
+import (
+	"context"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/wormhole-foundation/wormhole-chain/x/wormhole/types"
+)
+
+func (k msgServer) PostMessage(goCtx context.Context, msg *types.MsgPostMessage) (*types.MsgPostMessageResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	signer, err := sdk.AccAddressFromBech32(msg.Signer)
+	if err != nil {
+		return nil, err
+	}
+	if err := k.PostAccountMessage(ctx, signer, msg.Nonce, msg.Payload); err != nil {
+		return nil, err
+	}
+
+	return &types.MsgPostMessageResponse{}, nil
+}
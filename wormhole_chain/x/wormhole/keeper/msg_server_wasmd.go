@@ -33,7 +33,7 @@ func (k msgServer) StoreCode(goCtx context.Context, msg *types.MsgStoreCode) (*t
 		return nil, err
 	}
 	// Verify VAA
-	action, payload, err := k.VerifyGovernanceVAA(ctx, v, WasmdModule)
+	action, _, payload, err := k.VerifyGovernanceVAA(ctx, v, WasmdModule, sdk.MsgTypeURL(msg))
 	if err != nil {
 		return nil, err
 	}
@@ -83,7 +83,7 @@ func (k msgServer) InstantiateContract(goCtx context.Context, msg *types.MsgInst
 	}
 
 	// Verify VAA
-	action, payload, err := k.VerifyGovernanceVAA(ctx, v, WasmdModule)
+	action, _, payload, err := k.VerifyGovernanceVAA(ctx, v, WasmdModule, sdk.MsgTypeURL(msg))
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,143 @@
+package keeper
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/wormhole-foundation/wormhole-chain/x/wormhole/types"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// recoveredSignerCacheSize is the number of recovered signers remembered by a keeper, enough for the signatures of
+// the VAAs in verifiedVAACacheSize with a full guardian set.
+const recoveredSignerCacheSize = 32 * verifiedVAACacheSize
+
+// recoveredSignerCache remembers the guardian address recovered from a signature of a digest. Unlike verifiedVAACache
+// it also saves work for VAAs that are not byte-identical to one verified before, e.g. when a VAA is submitted again
+// with a different subset of signatures or one of its signatures is invalid. It is shared by all copies of a keeper
+// and safe for concurrent use. The oldest entry is evicted once the cache is full.
+type recoveredSignerCache struct {
+	mu      sync.Mutex
+	entries map[[32]byte]common.Address
+	order   [][32]byte
+	next    int
+}
+
+func newRecoveredSignerCache(size int) *recoveredSignerCache {
+	return &recoveredSignerCache{
+		entries: make(map[[32]byte]common.Address, size),
+		order:   make([][32]byte, 0, size),
+	}
+}
+
+// recoveredSignerKey identifies a signature of a digest.
+func recoveredSignerKey(digest common.Hash, signature vaa.SignatureData) [32]byte {
+	h := sha256.New()
+	h.Write(digest.Bytes())
+	h.Write(signature[:])
+	var key [32]byte
+	copy(key[:], h.Sum(nil))
+	return key
+}
+
+func (c *recoveredSignerCache) get(key [32]byte) (common.Address, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	addr, ok := c.entries[key]
+	return addr, ok
+}
+
+func (c *recoveredSignerCache) add(key [32]byte, addr common.Address) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.entries[key]; ok {
+		return
+	}
+	if len(c.order) < cap(c.order) {
+		c.order = append(c.order, key)
+	} else {
+		delete(c.entries, c.order[c.next])
+		c.order[c.next] = key
+		c.next = (c.next + 1) % len(c.order)
+	}
+	c.entries[key] = addr
+}
+
+// recoverSigner returns the address that produced signature for digest. Signatures that fail to recover are not
+// cached.
+func (c *recoveredSignerCache) recoverSigner(digest common.Hash, signature vaa.SignatureData) (common.Address, error) {
+	key := recoveredSignerKey(digest, signature)
+	if addr, ok := c.get(key); ok {
+		return addr, nil
+	}
+	pubKey, err := crypto.Ecrecover(digest.Bytes(), signature[:])
+	if err != nil {
+		return common.Address{}, err
+	}
+	addr := common.BytesToAddress(crypto.Keccak256(pubKey[1:])[12:])
+	c.add(key, addr)
+	return addr, nil
+}
+
+// verifySignatures verifies the signatures of v against the guardian addresses like vaa.VAA.VerifySignatures, but
+// recovers the signers through the cache. Unlike vaa.VAA.VerifySignatures it checks all signatures and returns the
+// outcome of each, unless v has more signatures than there are guardians.
+func (c *recoveredSignerCache) verifySignatures(v *vaa.VAA, addresses []common.Address) ([]types.SignatureVerification, bool) {
+	if len(addresses) < len(v.Signatures) {
+		return nil, false
+	}
+
+	digest := v.SigningMsg()
+	lastIndex := -1
+	signers := make(map[common.Address]bool, len(v.Signatures))
+	results := make([]types.SignatureVerification, len(v.Signatures))
+	valid := true
+
+	for i, sig := range v.Signatures {
+		results[i] = c.verifySignature(digest, sig, addresses, lastIndex, signers)
+		valid = valid && results[i].Valid
+		if int(sig.Index) > lastIndex && int(sig.Index) < len(addresses) {
+			lastIndex = int(sig.Index)
+		}
+	}
+
+	return results, valid
+}
+
+// verifySignature checks a signature of digest that follows a signature of the guardian at lastIndex. signers holds
+// the guardians that signed so far.
+func (c *recoveredSignerCache) verifySignature(digest common.Hash, sig *vaa.Signature, addresses []common.Address, lastIndex int, signers map[common.Address]bool) types.SignatureVerification {
+	result := types.SignatureVerification{GuardianIndex: uint32(sig.Index)}
+	if int(sig.Index) >= len(addresses) {
+		result.Reason = fmt.Sprintf("guardian index out of range of %d guardians", len(addresses))
+		return result
+	}
+	// Ensure increasing indexes
+	if int(sig.Index) <= lastIndex {
+		result.Reason = fmt.Sprintf("guardian index not greater than previous index %d", lastIndex)
+		return result
+	}
+
+	addr, err := c.recoverSigner(digest, sig.Signature)
+	if err != nil {
+		result.Reason = fmt.Sprintf("cannot recover signer: %v", err)
+		return result
+	}
+	result.Signer = addr.Hex()
+	// Ensure the signer is at the correct positional index, and never signed twice
+	if addr != addresses[sig.Index] {
+		result.Reason = fmt.Sprintf("signer is not guardian %s", addresses[sig.Index].Hex())
+		return result
+	}
+	if signers[addr] {
+		result.Reason = "guardian signed twice"
+		return result
+	}
+	signers[addr] = true
+	result.Valid = true
+	return result
+}
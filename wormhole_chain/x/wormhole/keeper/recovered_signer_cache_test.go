@@ -0,0 +1,86 @@
+package keeper
+
+import (
+	"crypto/ecdsa"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+func TestRecoveredSignerCacheVerifySignatures(t *testing.T) {
+	var keys []*ecdsa.PrivateKey
+	var addresses []common.Address
+	for i := 0; i < 3; i++ {
+		key, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		keys = append(keys, key)
+		addresses = append(addresses, crypto.PubkeyToAddress(key.PublicKey))
+	}
+	signed := func(indexes ...uint8) *vaa.VAA {
+		v := &vaa.VAA{Version: vaa.SupportedVAAVersion, Timestamp: time.Unix(0, 0), Payload: []byte{1}}
+		for _, i := range indexes {
+			v.AddSignature(keys[i], i)
+		}
+		return v
+	}
+
+	tests := []struct {
+		label string
+		v     *vaa.VAA
+		valid []bool
+	}{
+		{label: "valid", v: signed(0, 1, 2), valid: []bool{true, true, true}},
+		{label: "subset", v: signed(0, 2), valid: []bool{true, true}},
+		{label: "unordered", v: signed(1, 0), valid: []bool{true, false}},
+		{label: "duplicate", v: signed(1, 1), valid: []bool{true, false}},
+		{label: "unknown index", v: &vaa.VAA{Signatures: []*vaa.Signature{{Index: 3}}}, valid: []bool{false}},
+	}
+
+	c := newRecoveredSignerCache(16)
+	for _, tc := range tests {
+		t.Run(tc.label, func(t *testing.T) {
+			results, ok := c.verifySignatures(tc.v, addresses)
+			assert.Equal(t, tc.v.VerifySignatures(addresses), ok)
+			require.Len(t, results, len(tc.valid))
+			for i, result := range results {
+				assert.Equal(t, uint32(tc.v.Signatures[i].Index), result.GuardianIndex)
+				assert.Equal(t, tc.valid[i], result.Valid, "signature %d", i)
+				assert.Equal(t, result.Valid, result.Reason == "", "signature %d", i)
+			}
+		})
+	}
+
+	// A signature at the wrong index is rejected even if its signer was cached
+	v := signed(0)
+	v.Signatures[0].Index = 1
+	results, ok := c.verifySignatures(v, addresses)
+	assert.False(t, ok)
+	assert.Equal(t, addresses[0].Hex(), results[0].Signer)
+	assert.Equal(t, "signer is not guardian "+addresses[1].Hex(), results[0].Reason)
+	assert.Len(t, c.entries, 3)
+
+	// More signatures than guardians are rejected without checking them
+	results, ok = c.verifySignatures(signed(0, 1, 2, 2), addresses)
+	assert.False(t, ok)
+	assert.Nil(t, results)
+}
+
+func TestRecoveredSignerCacheEviction(t *testing.T) {
+	c := newRecoveredSignerCache(2)
+	a, b, d := [32]byte{1}, [32]byte{2}, [32]byte{3}
+
+	c.add(a, common.Address{1})
+	c.add(b, common.Address{2})
+	c.add(d, common.Address{3})
+	_, ok := c.get(a)
+	assert.False(t, ok)
+	addr, ok := c.get(d)
+	assert.True(t, ok)
+	assert.Equal(t, common.Address{3}, addr)
+	assert.Len(t, c.entries, 2)
+}
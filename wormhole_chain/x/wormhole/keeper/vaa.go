@@ -9,8 +9,15 @@ import (
 	"github.com/wormhole-foundation/wormhole/sdk/vaa"
 )
 
+// ParseVAA decodes a VAA of any version. Whether its version is accepted by
+// the chain is checked by VerifyVAA against the config, since the SDK parser
+// on its own only accepts vaa.SupportedVAAVersion. VAAs exceeding the
+// bounds of types.ValidateVAASize are rejected before they are decoded.
 func ParseVAA(data []byte) (*vaa.VAA, error) {
-	v, err := vaa.Unmarshal(data)
+	if err := types.ValidateVAASize(data); err != nil {
+		return nil, err
+	}
+	v, err := vaa.UnmarshalVersions(data, func(uint8) bool { return true })
 	if err != nil {
 		return nil, err
 	}
@@ -18,46 +25,117 @@ func ParseVAA(data []byte) (*vaa.VAA, error) {
 	return v, nil
 }
 
-// CalculateQuorum returns the minimum number of guardians that need to sign a VAA for a given guardian set.
+// CalculateQuorum returns the minimum number of guardians that need to sign a VAA for a given guardian set, unless the
+// config sets a quorum threshold.
 //
 // The canonical source is the calculation in the contracts (solana/bridge/src/processor.rs and
 // ethereum/contracts/Wormhole.sol), and this needs to match the implementation in the contracts.
 func CalculateQuorum(numGuardians int) int {
-	return (numGuardians*2)/3 + 1
+	return vaa.CalculateQuorum(numGuardians)
 }
 
-func (k Keeper) VerifyVAA(ctx sdk.Context, vaa *vaa.VAA) error {
-	guardianSet, exists := k.GetGuardianSet(ctx, vaa.GuardianSetIndex)
+// VerifyVAA verifies that v is accepted by the config and signed by a quorum of its guardian set. Failures are logged
+// with the verification report at debug level.
+func (k Keeper) VerifyVAA(ctx sdk.Context, v *vaa.VAA) error {
+	report, err := k.VerifyVAAWithReport(ctx, v)
+	if err != nil {
+		k.Logger(ctx).Debug("VAA verification failed", "digest", report.Digest, "report", report.String())
+	}
+	return err
+}
+
+// VerifyVAAWithReport verifies v like VerifyVAA and also returns a report of the verification, e.g. which signatures
+// are invalid and why.
+func (k Keeper) VerifyVAAWithReport(ctx sdk.Context, v *vaa.VAA) (types.VAAVerificationReport, error) {
+	report := types.VAAVerificationReport{
+		Digest:           v.HexDigest(),
+		GuardianSetIndex: v.GuardianSetIndex,
+	}
+	fail := func(err error) (types.VAAVerificationReport, error) {
+		report.Reason = err.Error()
+		return report, err
+	}
+
+	config, _ := k.GetConfig(ctx)
+	if !config.AcceptsVAAVersion(v.Version) {
+		return fail(types.ErrUnsupportedVAAVersion)
+	}
+	if err := config.ValidateVAALimits(v); err != nil {
+		return fail(err)
+	}
+
+	guardianSet, exists := k.GetGuardianSet(ctx, v.GuardianSetIndex)
 	if !exists {
-		return types.ErrGuardianSetNotFound
+		return fail(types.ErrGuardianSetNotFound)
 	}
+	report.GuardianSetSize = uint32(len(guardianSet.Keys))
 
 	if 0 < guardianSet.ExpirationTime && guardianSet.ExpirationTime < uint64(ctx.BlockTime().Unix()) {
-		return types.ErrGuardianSetExpired
+		return fail(types.ErrGuardianSetExpired)
 	}
 
 	// Verify quorum
-	quorum := CalculateQuorum(len(guardianSet.Keys))
-	if len(vaa.Signatures) < quorum {
-		return types.ErrNoQuorum
+	quorum := config.Quorum(len(guardianSet.Keys))
+	report.Quorum = uint32(quorum)
+	if len(v.Signatures) < quorum {
+		return fail(types.ErrNoQuorum)
 	}
 
-	// Verify signatures
-	ok := vaa.VerifySignatures(guardianSet.KeysAsAddresses())
-	if !ok {
-		return types.ErrSignaturesInvalid
+	// Verify signatures, unless the same VAA was verified before, e.g. in CheckTx. The outcome only depends on the
+	// VAA and its guardian set, which never changes, and all checks above that read state still run, so skipping
+	// verification does not affect consensus.
+	addresses := guardianSet.KeysAsAddresses()
+	key, err := verifiedVAAKey(v)
+	if err == nil && k.verifiedVAAs.contains(key) {
+		for _, sig := range v.Signatures {
+			report.Signatures = append(report.Signatures, types.SignatureVerification{
+				GuardianIndex: uint32(sig.Index),
+				Signer:        addresses[sig.Index].Hex(),
+				Valid:         true,
+			})
+		}
+	} else {
+		var ok bool
+		report.Signatures, ok = k.recoveredSigners.verifySignatures(v, addresses)
+		if !ok {
+			report.ValidSignatures = countValidSignatures(report.Signatures)
+			return fail(types.ErrSignaturesInvalid)
+		}
+		if err == nil {
+			k.verifiedVAAs.add(key)
+		}
 	}
 
-	return nil
+	report.ValidSignatures = uint32(len(report.Signatures))
+	report.Verified = true
+	return report, nil
+}
+
+func countValidSignatures(signatures []types.SignatureVerification) uint32 {
+	n := uint32(0)
+	for _, sig := range signatures {
+		if sig.Valid {
+			n++
+		}
+	}
+	return n
 }
 
 // Verify a governance VAA:
 // - Check signatures
+// - Check the guardian set is not older than required for governance VAAs
 // - Replay protection
 // - Check the source chain and address is governance
-// - Check the governance payload is for wormchain and the specified module
-// - return the parsed action and governance payload
-func (k Keeper) VerifyGovernanceVAA(ctx sdk.Context, v *vaa.VAA, module [32]byte) (action byte, payload []byte, err error) {
+// - Check the governance payload is for the specified module and wormchain or all chains
+// - Record the VAA in the governance history
+// - return the parsed action, target chain and governance payload
+//
+// Actions that must not apply to all chains have to check the returned target
+// chain with types.IsGovernanceTarget.
+//
+// msgType is the type URL of the message consuming the VAA. It is reported in
+// the EventVAAConsumed emitted when the VAA is marked as executed.
+func (k Keeper) VerifyGovernanceVAA(ctx sdk.Context, v *vaa.VAA, module [32]byte, msgType string) (action byte, targetChain uint16, payload []byte, err error) {
 	if err = k.VerifyVAA(ctx, v); err != nil {
 		return
 	}
@@ -66,14 +144,14 @@ func (k Keeper) VerifyGovernanceVAA(ctx sdk.Context, v *vaa.VAA, module [32]byte
 		err = types.ErrVAAAlreadyExecuted
 		return
 	}
-	// Prevent replay
-	k.SetReplayProtection(ctx, types.ReplayProtection{Index: v.HexDigest()})
-
 	config, ok := k.GetConfig(ctx)
 	if !ok {
 		err = types.ErrNoConfig
 		return
 	}
+	if err = config.ValidateGuardianSetIndex(v, types.MessageTypeGovernance); err != nil {
+		return
+	}
 
 	if !bytes.Equal(v.EmitterAddress[:], config.GovernanceEmitter) {
 		err = types.ErrInvalidGovernanceEmitter
@@ -96,13 +174,23 @@ func (k Keeper) VerifyGovernanceVAA(ctx sdk.Context, v *vaa.VAA, module [32]byte
 
 	// Decode header
 	action = v.Payload[32]
-	chain := binary.BigEndian.Uint16(v.Payload[33:35])
+	targetChain = binary.BigEndian.Uint16(v.Payload[33:35])
 	payload = v.Payload[35:]
 
-	if chain != 0 && chain != uint16(config.ChainId) {
+	if !types.IsGovernanceTarget(targetChain, uint16(config.ChainId), true) {
 		err = types.ErrInvalidGovernanceTargetChain
 		return
 	}
 
+	// Prevent replay
+	k.SetReplayProtection(ctx, types.ReplayProtection{Index: v.HexDigest()})
+	if err = k.recordGovernanceVAA(ctx, v, module, action, targetChain); err != nil {
+		return
+	}
+	err = ctx.EventManager().EmitTypedEvent(&types.EventVAAConsumed{
+		Digest:      v.HexDigest(),
+		PayloadType: uint32(action),
+		MsgType:     msgType,
+	})
 	return
 }
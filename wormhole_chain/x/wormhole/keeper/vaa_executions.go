@@ -0,0 +1,40 @@
+package keeper
+
+import (
+	"encoding/binary"
+
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/wormhole-foundation/wormhole-chain/x/wormhole/types"
+)
+
+// GetVAAExecutionCount returns the number of VAAs the sender executed in the current block so far.
+func (k Keeper) GetVAAExecutionCount(ctx sdk.Context, sender sdk.AccAddress) uint32 {
+	store := prefix.NewStore(ctx.TransientStore(k.tKey), types.KeyPrefix(types.VAAExecutionCountKey))
+	b := store.Get(sender)
+	if b == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint32(b)
+}
+
+// CountVAAExecutions records n VAA executions of the sender in the current block and fails if that exceeds the
+// configured per-sender limit. Counts live in the transient store, so they are discarded with the block, and during
+// CheckTx they accumulate in the check state until the next commit, which caps the sender's share of the mempool too.
+func (k Keeper) CountVAAExecutions(ctx sdk.Context, sender sdk.AccAddress, n uint32) error {
+	config, ok := k.GetConfig(ctx)
+	if !ok {
+		return types.ErrNoConfig
+	}
+
+	count := k.GetVAAExecutionCount(ctx, sender) + n
+	if limit := config.MaxVaaExecutionsPerSender; limit != 0 && count > limit {
+		return sdkerrors.Wrapf(types.ErrVAAExecutionLimitExceeded, "%s executed %d VAAs, limit is %d", sender, count, limit)
+	}
+
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, count)
+	prefix.NewStore(ctx.TransientStore(k.tKey), types.KeyPrefix(types.VAAExecutionCountKey)).Set(sender, b)
+	return nil
+}
@@ -0,0 +1,39 @@
+package keeper_test
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	keepertest "github.com/wormhole-foundation/wormhole-chain/testutil/keeper"
+	"github.com/wormhole-foundation/wormhole-chain/x/wormhole/types"
+)
+
+func TestCountVAAExecutions(t *testing.T) {
+	k, ctx := keepertest.WormholeKeeper(t)
+	alice, bob := sdk.AccAddress{0x0a}, sdk.AccAddress{0x0b}
+
+	require.ErrorIs(t, k.CountVAAExecutions(ctx, alice, 1), types.ErrNoConfig)
+
+	// A zero limit is unlimited
+	k.SetConfig(ctx, types.Config{})
+	require.NoError(t, k.CountVAAExecutions(ctx, alice, 100))
+	assert.Equal(t, uint32(100), k.GetVAAExecutionCount(ctx, alice))
+
+	k.SetConfig(ctx, types.Config{MaxVaaExecutionsPerSender: 3})
+	require.ErrorIs(t, k.CountVAAExecutions(ctx, alice, 1), types.ErrVAAExecutionLimitExceeded)
+	assert.Equal(t, uint32(100), k.GetVAAExecutionCount(ctx, alice))
+
+	require.NoError(t, k.CountVAAExecutions(ctx, bob, 2))
+	require.ErrorIs(t, k.CountVAAExecutions(ctx, bob, 2), types.ErrVAAExecutionLimitExceeded)
+	require.NoError(t, k.CountVAAExecutions(ctx, bob, 1))
+	assert.Equal(t, uint32(3), k.GetVAAExecutionCount(ctx, bob))
+
+	// Counts of failed transactions are dropped with the rest of their state
+	cacheCtx, _ := ctx.CacheContext()
+	k.SetConfig(cacheCtx, types.Config{MaxVaaExecutionsPerSender: 10})
+	require.NoError(t, k.CountVAAExecutions(cacheCtx, bob, 5))
+	assert.Equal(t, uint32(8), k.GetVAAExecutionCount(cacheCtx, bob))
+	assert.Equal(t, uint32(3), k.GetVAAExecutionCount(ctx, bob))
+}
@@ -8,8 +8,11 @@ import (
 	"testing"
 	"time"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
 	keepertest "github.com/wormhole-foundation/wormhole-chain/testutil/keeper"
 	"github.com/wormhole-foundation/wormhole-chain/x/wormhole/keeper"
 	"github.com/wormhole-foundation/wormhole-chain/x/wormhole/types"
@@ -44,6 +47,50 @@ func TestCalculateQuorum(t *testing.T) {
 	}
 }
 
+func TestConfigQuorum(t *testing.T) {
+	tests := []struct {
+		threshold *types.QuorumThreshold
+		guardians int
+		quorum    int
+	}{
+		{threshold: nil, guardians: 19, quorum: 13},
+		{threshold: &types.QuorumThreshold{Numerator: 2, Denominator: 3}, guardians: 19, quorum: 13},
+		{threshold: &types.QuorumThreshold{Numerator: 3, Denominator: 4}, guardians: 19, quorum: 15},
+		{threshold: &types.QuorumThreshold{Numerator: 3, Denominator: 4}, guardians: 4, quorum: 4},
+		{threshold: &types.QuorumThreshold{Numerator: 1, Denominator: 1}, guardians: 19, quorum: 19},
+		{threshold: &types.QuorumThreshold{Numerator: 1, Denominator: 1}, guardians: 1, quorum: 1},
+		{threshold: &types.QuorumThreshold{Numerator: 1, Denominator: 1}, guardians: 0, quorum: 1},
+	}
+
+	for _, tc := range tests {
+		t.Run(fmt.Sprintf("%v/%v", tc.threshold, tc.guardians), func(t *testing.T) {
+			config := types.Config{QuorumThreshold: tc.threshold}
+			assert.Equal(t, tc.quorum, config.Quorum(tc.guardians))
+		})
+	}
+}
+
+func TestVerifyVAAQuorumThreshold(t *testing.T) {
+	keeper, ctx := keepertest.WormholeKeeper(t)
+	guardians, privateKeys := createNGuardianValidator(keeper, ctx, 4)
+	set := createNewGuardianSet(keeper, ctx, guardians)
+	payload := []byte{97, 97, 97, 97, 97, 97}
+
+	config, _ := keeper.GetConfig(ctx)
+	config.QuorumThreshold = &types.QuorumThreshold{Numerator: 1, Denominator: 1}
+	keeper.SetConfig(ctx, config)
+
+	v := generateVaa(set.Index, privateKeys[:3], vaa.ChainIDSolana, payload)
+	report, err := keeper.VerifyVAAWithReport(ctx, &v)
+	assert.ErrorIs(t, err, types.ErrNoQuorum)
+	assert.Equal(t, uint32(4), report.Quorum)
+
+	v = generateVaa(set.Index, privateKeys, vaa.ChainIDSolana, payload)
+	report, err = keeper.VerifyVAAWithReport(ctx, &v)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(4), report.Quorum)
+}
+
 var lastestSequence = 1
 
 func generateVaa(index uint32, signers []*ecdsa.PrivateKey, emitterChain vaa.ChainID, payload []byte) vaa.VAA {
@@ -122,6 +169,79 @@ func TestVerifyVAA(t *testing.T) {
 	}
 }
 
+func TestVerifyVAAAcceptedVersions(t *testing.T) {
+	keeper, ctx := keepertest.WormholeKeeper(t)
+	guardians, privateKeys := createNGuardianValidator(keeper, ctx, 4)
+	set := createNewGuardianSet(keeper, ctx, guardians)
+	payload := []byte{97, 97, 97, 97, 97, 97}
+
+	// without a configured list only the supported version is accepted
+	v := generateVaa(set.Index, privateKeys, vaa.ChainIDSolana, payload)
+	assert.NoError(t, keeper.VerifyVAA(ctx, &v))
+	v.Version = 2
+	assert.ErrorIs(t, keeper.VerifyVAA(ctx, &v), types.ErrUnsupportedVAAVersion)
+
+	keeper.SetConfig(ctx, types.Config{AcceptedVaaVersions: []uint32{2}})
+	v = generateVaa(set.Index, privateKeys, vaa.ChainIDSolana, payload)
+	assert.ErrorIs(t, keeper.VerifyVAA(ctx, &v), types.ErrUnsupportedVAAVersion)
+
+	keeper.SetConfig(ctx, types.Config{AcceptedVaaVersions: []uint32{1, 2}})
+	assert.NoError(t, keeper.VerifyVAA(ctx, &v))
+}
+
+func TestParseVAAAcceptedVersions(t *testing.T) {
+	k, ctx := keepertest.WormholeKeeper(t)
+	guardians, privateKeys := createNGuardianValidator(k, ctx, 4)
+	set := createNewGuardianSet(k, ctx, guardians)
+	payload := []byte{97, 97, 97, 97, 97, 97}
+
+	// The version is not part of the signed body, so the signatures stay valid.
+	v := generateVaa(set.Index, privateKeys, vaa.ChainIDSolana, payload)
+	v.Version = 2
+	data, err := v.Marshal()
+	require.NoError(t, err)
+
+	parsed, err := keeper.ParseVAA(data)
+	require.NoError(t, err)
+	assert.Equal(t, uint8(2), parsed.Version)
+	assert.ErrorIs(t, k.VerifyVAA(ctx, parsed), types.ErrUnsupportedVAAVersion)
+
+	k.SetConfig(ctx, types.Config{AcceptedVaaVersions: []uint32{1, 2}})
+	assert.NoError(t, k.VerifyVAA(ctx, parsed))
+}
+
+func TestVerifyVAALimits(t *testing.T) {
+	keeper, ctx := keepertest.WormholeKeeper(t)
+	guardians, privateKeys := createNGuardianValidator(keeper, ctx, 4)
+	set := createNewGuardianSet(keeper, ctx, guardians)
+
+	v := generateVaa(set.Index, privateKeys, vaa.ChainIDSolana, make([]byte, 100))
+	assert.NoError(t, keeper.VerifyVAA(ctx, &v))
+
+	keeper.SetConfig(ctx, types.Config{MaxVaaPayloadSize: 99})
+	assert.ErrorIs(t, keeper.VerifyVAA(ctx, &v), types.ErrVAAPayloadTooLarge)
+
+	keeper.SetConfig(ctx, types.Config{MaxVaaPayloadSize: 100, MaxVaaSignatures: 3})
+	assert.ErrorIs(t, keeper.VerifyVAA(ctx, &v), types.ErrTooManyVAASignatures)
+
+	keeper.SetConfig(ctx, types.Config{MaxVaaPayloadSize: 100, MaxVaaSignatures: 4})
+	assert.NoError(t, keeper.VerifyVAA(ctx, &v))
+}
+
+func TestParseVAASizeBounds(t *testing.T) {
+	v := generateVaa(0, nil, vaa.ChainIDSolana, make([]byte, types.MaxVAAPayloadSize))
+	b, err := v.Marshal()
+	require.NoError(t, err)
+	_, err = keeper.ParseVAA(b)
+	assert.NoError(t, err)
+
+	v.Payload = append(v.Payload, 0)
+	b, err = v.Marshal()
+	require.NoError(t, err)
+	_, err = keeper.ParseVAA(b)
+	assert.ErrorIs(t, err, types.ErrVAAPayloadTooLarge)
+}
+
 func TestVerifyVAA2(t *testing.T) {
 	keeper, ctx := keepertest.WormholeKeeper(t)
 	guardians, privateKeys := createNGuardianValidator(keeper, ctx, 25)
@@ -173,45 +293,174 @@ func TestVerifyVAAGovernance(t *testing.T) {
 	v := generateVaa(set.Index, privateKeys, vaa.ChainID(vaa.GovernanceChain), payload)
 	err := keeper.VerifyVAA(ctx, &v)
 	assert.NoError(t, err)
-	parsed_action, parsed_payload, err := keeper.VerifyGovernanceVAA(ctx, &v, our_module)
+	parsed_action, parsed_chain, parsed_payload, err := keeper.VerifyGovernanceVAA(ctx, &v, our_module, "test")
 	assert.NoError(t, err)
 	assert.Equal(t, action, parsed_action)
+	assert.Equal(t, uint16(vaa.ChainIDWormchain), parsed_chain)
 	assert.Equal(t, custom_payload, parsed_payload)
 
+	// consuming the VAA is reported in an event
+	events := ctx.EventManager().Events()
+	consumed, err := sdk.ParseTypedEvent(abci.Event(events[len(events)-1]))
+	assert.NoError(t, err)
+	assert.Equal(t, &types.EventVAAConsumed{Digest: v.HexDigest(), PayloadType: uint32(action), MsgType: "test"}, consumed)
+
 	// verifying a second time will return error because of replay protection
-	_, _, err = keeper.VerifyGovernanceVAA(ctx, &v, our_module)
+	_, _, _, err = keeper.VerifyGovernanceVAA(ctx, &v, our_module, "")
 	assert.ErrorIs(t, err, types.ErrVAAAlreadyExecuted)
 
 	// Expect error if module-id is different
 	v = generateVaa(set.Index, privateKeys, vaa.ChainID(vaa.GovernanceChain), payload)
 	bad_module := [32]byte{}
 	bad_module[31] = 0xff
-	_, _, err = keeper.VerifyGovernanceVAA(ctx, &v, bad_module)
+	_, _, _, err = keeper.VerifyGovernanceVAA(ctx, &v, bad_module, "")
 	assert.ErrorIs(t, err, types.ErrUnknownGovernanceModule)
 
 	// Expect error if we're not using the right governance emitter address
 	v = generateVaa(set.Index, privateKeys, vaa.ChainID(vaa.GovernanceChain), payload)
 	v.EmitterAddress[5] = 0xff
 	v = resignVaa(v, privateKeys)
-	_, _, err = keeper.VerifyGovernanceVAA(ctx, &v, our_module)
+	_, _, _, err = keeper.VerifyGovernanceVAA(ctx, &v, our_module, "")
 	assert.ErrorIs(t, err, types.ErrInvalidGovernanceEmitter)
 
 	// Expect error if we're not using the right governance emitter chain
 	v = generateVaa(set.Index, privateKeys, vaa.ChainID(vaa.GovernanceChain), payload)
 	v.EmitterChain = vaa.ChainIDEthereum
 	v = resignVaa(v, privateKeys)
-	_, _, err = keeper.VerifyGovernanceVAA(ctx, &v, our_module)
+	_, _, _, err = keeper.VerifyGovernanceVAA(ctx, &v, our_module, "")
 	assert.ErrorIs(t, err, types.ErrInvalidGovernanceEmitter)
 
 	// Expect error if we're using a small payload
 	v = generateVaa(set.Index, privateKeys, vaa.ChainID(vaa.GovernanceChain), payload[:34])
-	_, _, err = keeper.VerifyGovernanceVAA(ctx, &v, our_module)
+	_, _, _, err = keeper.VerifyGovernanceVAA(ctx, &v, our_module, "")
 	assert.ErrorIs(t, err, types.ErrGovernanceHeaderTooShort)
 
 	// Expect error if we're using a different target chain
 	payload[33] = 0xff
 	payload[34] = 0xff
 	v = generateVaa(set.Index, privateKeys, vaa.ChainID(vaa.GovernanceChain), payload)
-	_, _, err = keeper.VerifyGovernanceVAA(ctx, &v, our_module)
+	_, _, _, err = keeper.VerifyGovernanceVAA(ctx, &v, our_module, "")
 	assert.ErrorIs(t, err, types.ErrInvalidGovernanceTargetChain)
+
+	// Actions targeting all chains are accepted and report it as their target
+	payload[33] = 0
+	payload[34] = 0
+	v = generateVaa(set.Index, privateKeys, vaa.ChainID(vaa.GovernanceChain), payload)
+	_, parsed_chain, _, err = keeper.VerifyGovernanceVAA(ctx, &v, our_module, "")
+	assert.NoError(t, err)
+	assert.Equal(t, types.GovernanceChainAll, parsed_chain)
+}
+
+func TestVerifyGovernanceVAAMinGuardianSetIndex(t *testing.T) {
+	keeper, ctx := keepertest.WormholeKeeper(t)
+	oldGuardians, oldKeys := createNGuardianValidator(keeper, ctx, 4)
+	oldSet := createNewGuardianSet(keeper, ctx, oldGuardians)
+	newGuardians, newKeys := createNGuardianValidator(keeper, ctx, 4)
+	newSet := createNewGuardianSet(keeper, ctx, newGuardians)
+	config := types.Config{
+		GovernanceEmitter: vaa.GovernanceEmitter[:],
+		GovernanceChain:   uint32(vaa.GovernanceChain),
+		ChainId:           uint32(vaa.ChainIDWormchain),
+	}
+	config.SetMinGuardianSetIndex(types.MessageTypeGovernance, newSet.Index)
+	keeper.SetConfig(ctx, config)
+
+	module := [32]byte{31: 1}
+	payload := append(module[:], 1, 0, 0)
+
+	// The old set has not expired, but is too old for governance
+	v := generateVaa(oldSet.Index, oldKeys, vaa.ChainID(vaa.GovernanceChain), payload)
+	assert.NoError(t, keeper.VerifyVAA(ctx, &v))
+	_, _, _, err := keeper.VerifyGovernanceVAA(ctx, &v, module, "")
+	assert.ErrorIs(t, err, types.ErrGuardianSetTooOld)
+
+	v = generateVaa(newSet.Index, newKeys, vaa.ChainID(vaa.GovernanceChain), payload)
+	_, _, _, err = keeper.VerifyGovernanceVAA(ctx, &v, module, "")
+	assert.NoError(t, err)
+}
+
+func TestVerifyVAACached(t *testing.T) {
+	keeper, ctx := keepertest.WormholeKeeper(t)
+	guardians, privateKeys := createNGuardianValidator(keeper, ctx, 4)
+	keys := [][]byte{}
+	for _, guardian := range guardians {
+		keys = append(keys, guardian.GuardianKey)
+	}
+	keeper.AppendGuardianSet(ctx, types.GuardianSet{Index: 0, Keys: keys, ExpirationTime: 100})
+	payload := []byte{97, 97, 97, 97, 97, 97}
+
+	ctx = ctx.WithBlockTime(time.Unix(50, 0))
+	v := generateVaa(0, privateKeys, vaa.ChainIDSolana, payload)
+	assert.NoError(t, keeper.VerifyVAA(ctx, &v))
+	assert.NoError(t, keeper.VerifyVAA(ctx, &v))
+
+	// A cached VAA still fails the checks that depend on state
+	assert.ErrorIs(t, keeper.VerifyVAA(ctx.WithBlockTime(time.Unix(200, 0)), &v), types.ErrGuardianSetExpired)
+
+	// The cache is keyed by the signatures too
+	v.Signatures[0].Signature[1] ^= 0x40
+	assert.ErrorIs(t, keeper.VerifyVAA(ctx, &v), types.ErrSignaturesInvalid)
+}
+
+func TestVerifyVAAWithReport(t *testing.T) {
+	keeper, ctx := keepertest.WormholeKeeper(t)
+	guardians, privateKeys := createNGuardianValidator(keeper, ctx, 4)
+	set := createNewGuardianSet(keeper, ctx, guardians)
+	payload := []byte{97, 97, 97, 97, 97, 97}
+
+	v := generateVaa(set.Index, privateKeys, vaa.ChainIDSolana, payload)
+	report, err := keeper.VerifyVAAWithReport(ctx, &v)
+	require.NoError(t, err)
+	assert.True(t, report.Verified)
+	assert.Empty(t, report.Reason)
+	assert.Equal(t, v.HexDigest(), report.Digest)
+	assert.Equal(t, set.Index, report.GuardianSetIndex)
+	assert.Equal(t, uint32(4), report.GuardianSetSize)
+	assert.Equal(t, uint32(3), report.Quorum)
+	assert.Equal(t, uint32(4), report.ValidSignatures)
+	require.Len(t, report.Signatures, 4)
+	for i, sig := range report.Signatures {
+		assert.Equal(t, types.SignatureVerification{
+			GuardianIndex: uint32(i),
+			Signer:        crypto.PubkeyToAddress(privateKeys[i].PublicKey).Hex(),
+			Valid:         true,
+		}, sig)
+	}
+
+	// The report of a cached VAA is the same
+	cached, err := keeper.VerifyVAAWithReport(ctx, &v)
+	require.NoError(t, err)
+	assert.Equal(t, report, cached)
+
+	// Each invalid signature is reported
+	v = generateVaa(set.Index, privateKeys, vaa.ChainIDSolana, payload)
+	v.Signatures[1].Signature[1] ^= 0x40
+	v.Signatures[3].Index = 2
+	report, err = keeper.VerifyVAAWithReport(ctx, &v)
+	assert.ErrorIs(t, err, types.ErrSignaturesInvalid)
+	assert.False(t, report.Verified)
+	assert.Equal(t, types.ErrSignaturesInvalid.Error(), report.Reason)
+	assert.Equal(t, uint32(2), report.ValidSignatures)
+	require.Len(t, report.Signatures, 4)
+	assert.True(t, report.Signatures[0].Valid)
+	assert.False(t, report.Signatures[1].Valid)
+	assert.NotEmpty(t, report.Signatures[1].Reason)
+	assert.True(t, report.Signatures[2].Valid)
+	assert.False(t, report.Signatures[3].Valid)
+	assert.Equal(t, "guardian index not greater than previous index 2", report.Signatures[3].Reason)
+
+	// Signatures are not checked without quorum
+	v = generateVaa(set.Index, privateKeys[:2], vaa.ChainIDSolana, payload)
+	report, err = keeper.VerifyVAAWithReport(ctx, &v)
+	assert.ErrorIs(t, err, types.ErrNoQuorum)
+	assert.Equal(t, types.ErrNoQuorum.Error(), report.Reason)
+	assert.Equal(t, uint32(3), report.Quorum)
+	assert.Empty(t, report.Signatures)
+
+	// The guardian set is reported missing
+	v = generateVaa(set.Index+1, privateKeys, vaa.ChainIDSolana, payload)
+	report, err = keeper.VerifyVAAWithReport(ctx, &v)
+	assert.ErrorIs(t, err, types.ErrGuardianSetNotFound)
+	assert.Equal(t, set.Index+1, report.GuardianSetIndex)
+	assert.Equal(t, uint32(0), report.GuardianSetSize)
 }
@@ -0,0 +1,62 @@
+package keeper
+
+import (
+	"crypto/sha256"
+	"sync"
+
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// verifiedVAACacheSize is the number of verified VAAs remembered by a keeper.
+const verifiedVAACacheSize = 1024
+
+// verifiedVAACache remembers VAAs whose signatures were recently verified, so that a VAA verified in CheckTx is not
+// verified again when its transaction is delivered. It is shared by all copies of a keeper and safe for concurrent
+// use. The oldest entry is evicted once the cache is full.
+type verifiedVAACache struct {
+	mu      sync.Mutex
+	entries map[[32]byte]struct{}
+	order   [][32]byte
+	next    int
+}
+
+func newVerifiedVAACache(size int) *verifiedVAACache {
+	return &verifiedVAACache{
+		entries: make(map[[32]byte]struct{}, size),
+		order:   make([][32]byte, 0, size),
+	}
+}
+
+// verifiedVAAKey identifies a VAA including its guardian set index and signatures, since both determine the outcome
+// of signature verification.
+func verifiedVAAKey(v *vaa.VAA) ([32]byte, error) {
+	b, err := v.Marshal()
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return sha256.Sum256(b), nil
+}
+
+func (c *verifiedVAACache) contains(key [32]byte) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.entries[key]
+	return ok
+}
+
+func (c *verifiedVAACache) add(key [32]byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.entries[key]; ok {
+		return
+	}
+	if len(c.order) < cap(c.order) {
+		c.order = append(c.order, key)
+	} else {
+		delete(c.entries, c.order[c.next])
+		c.order[c.next] = key
+		c.next = (c.next + 1) % len(c.order)
+	}
+	c.entries[key] = struct{}{}
+}
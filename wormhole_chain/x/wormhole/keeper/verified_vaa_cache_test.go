@@ -0,0 +1,25 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifiedVAACacheEviction(t *testing.T) {
+	c := newVerifiedVAACache(2)
+	a, b, d := [32]byte{1}, [32]byte{2}, [32]byte{3}
+
+	c.add(a)
+	c.add(b)
+	c.add(a)
+	assert.True(t, c.contains(a))
+	assert.True(t, c.contains(b))
+
+	// The oldest entry makes room for a new one
+	c.add(d)
+	assert.False(t, c.contains(a))
+	assert.True(t, c.contains(b))
+	assert.True(t, c.contains(d))
+	assert.Len(t, c.entries, 2)
+}
@@ -13,6 +13,7 @@ func RegisterCodec(cdc *codec.LegacyAmino) {
 	cdc.RegisterConcrete(&MsgRegisterAccountAsGuardian{}, "wormhole/RegisterAccountAsGuardian", nil)
 	cdc.RegisterConcrete(&MsgStoreCode{}, "wormhole/StoreCode", nil)
 	cdc.RegisterConcrete(&MsgInstantiateContract{}, "wormhole/InstantiateContract", nil)
+	cdc.RegisterConcrete(&MsgPostMessage{}, "wormhole/PostMessage", nil)
 	// this line is used by starport scaffolding # 2
 }
 
@@ -21,10 +22,21 @@ func RegisterInterfaces(registry cdctypes.InterfaceRegistry) {
 		&MsgExecuteGovernanceVAA{},
 		&MsgStoreCode{},
 		&MsgInstantiateContract{},
+		&MsgPostMessage{},
 	)
 	registry.RegisterImplementations((*gov.Content)(nil),
 		&GovernanceWormholeMessageProposal{},
-		&GuardianSetUpdateProposal{})
+		&GuardianSetUpdateProposal{},
+		&EmitterRateLimitProposal{},
+		&AcceptedVAAVersionsProposal{},
+		&MessageFeesProposal{},
+		&VAALimitsProposal{},
+		&MinGuardianSetIndexProposal{},
+		&VAAExecutionLimitProposal{},
+		&GovernanceSubmittersProposal{},
+		&FeeMarketProposal{},
+		&ObservationRequestProposal{},
+		&QuorumThresholdProposal{})
 	registry.RegisterImplementations((*sdk.Msg)(nil),
 		&MsgRegisterAccountAsGuardian{},
 	)
@@ -0,0 +1,173 @@
+package types
+
+import (
+	"fmt"
+	"sort"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// Message types of the VAAs that can be required to be signed by a recent guardian set.
+const (
+	// MessageTypeGovernance are governance VAAs of any module.
+	MessageTypeGovernance = "governance"
+	// MessageTypeTokenTransfer, MessageTypeAssetMeta and MessageTypeTokenTransferWithPayload are the token bridge
+	// payloads redeemed or registered by x/tokenbridge.
+	MessageTypeTokenTransfer            = "token_transfer"
+	MessageTypeAssetMeta                = "asset_meta"
+	MessageTypeTokenTransferWithPayload = "token_transfer_with_payload"
+)
+
+var messageTypes = []string{
+	MessageTypeGovernance,
+	MessageTypeTokenTransfer,
+	MessageTypeAssetMeta,
+	MessageTypeTokenTransferWithPayload,
+}
+
+// ValidateMessageType returns ErrUnknownMessageType if messageType is not one of the MessageType constants.
+func ValidateMessageType(messageType string) error {
+	for _, t := range messageTypes {
+		if t == messageType {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %q", ErrUnknownMessageType, messageType)
+}
+
+// AcceptsVAAVersion returns whether VAAs of the given version pass verification.
+func (c Config) AcceptsVAAVersion(version uint8) bool {
+	if len(c.AcceptedVaaVersions) == 0 {
+		return version == vaa.SupportedVAAVersion
+	}
+	for _, accepted := range c.AcceptedVaaVersions {
+		if accepted == uint32(version) {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateVAALimits checks the payload size and signature count of v against the configured limits. Zero limits
+// default to MaxVAAPayloadSize and MaxVAASignatures.
+func (c Config) ValidateVAALimits(v *vaa.VAA) error {
+	maxPayloadSize := int(c.MaxVaaPayloadSize)
+	if maxPayloadSize == 0 {
+		maxPayloadSize = MaxVAAPayloadSize
+	}
+	if len(v.Payload) > maxPayloadSize {
+		return fmt.Errorf("%w: %d > %d bytes", ErrVAAPayloadTooLarge, len(v.Payload), maxPayloadSize)
+	}
+
+	maxSignatures := int(c.MaxVaaSignatures)
+	if maxSignatures == 0 {
+		maxSignatures = MaxVAASignatures
+	}
+	if len(v.Signatures) > maxSignatures {
+		return fmt.Errorf("%w: %d > %d", ErrTooManyVAASignatures, len(v.Signatures), maxSignatures)
+	}
+	return nil
+}
+
+// MinGuardianSetIndex returns the oldest guardian set allowed to sign VAAs of the message type, zero if any set is.
+func (c Config) MinGuardianSetIndex(messageType string) uint32 {
+	for _, m := range c.MinGuardianSetIndexes {
+		if m.MessageType == messageType {
+			return m.GuardianSetIndex
+		}
+	}
+	return 0
+}
+
+// SetMinGuardianSetIndex sets the oldest guardian set allowed to sign VAAs of the message type. A zero index removes
+// the requirement. Entries are kept sorted by message type.
+func (c *Config) SetMinGuardianSetIndex(messageType string, index uint32) {
+	entries := make([]MinGuardianSetIndex, 0, len(c.MinGuardianSetIndexes)+1)
+	for _, m := range c.MinGuardianSetIndexes {
+		if m.MessageType != messageType {
+			entries = append(entries, m)
+		}
+	}
+	if index != 0 {
+		entries = append(entries, MinGuardianSetIndex{MessageType: messageType, GuardianSetIndex: index})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].MessageType < entries[j].MessageType })
+	c.MinGuardianSetIndexes = entries
+}
+
+// ValidateGuardianSetIndex rejects v with ErrGuardianSetTooOld if it is signed by a guardian set older than the one
+// required for the message type. Unlike the expiration of guardian sets this applies right after a guardian set
+// upgrade, so governance can stop honoring the previous set for sensitive actions without waiting out the grace period.
+func (c Config) ValidateGuardianSetIndex(v *vaa.VAA, messageType string) error {
+	if min := c.MinGuardianSetIndex(messageType); v.GuardianSetIndex < min {
+		return fmt.Errorf("%w: %s VAAs must be signed by guardian set %d or newer, got %d", ErrGuardianSetTooOld, messageType, min, v.GuardianSetIndex)
+	}
+	return nil
+}
+
+// ValidateGovernanceSubmitters checks that the governance submitters are valid addresses without duplicates.
+func ValidateGovernanceSubmitters(submitters []string) error {
+	seen := make(map[string]struct{})
+	for _, submitter := range submitters {
+		if _, err := sdk.AccAddressFromBech32(submitter); err != nil {
+			return fmt.Errorf("invalid governance submitter %q: %w", submitter, err)
+		}
+		if _, ok := seen[submitter]; ok {
+			return fmt.Errorf("duplicated governance submitter %s", submitter)
+		}
+		seen[submitter] = struct{}{}
+	}
+	return nil
+}
+
+// AllowsGovernanceSubmitter returns whether the signer may sign transactions executing governance VAAs. Anyone may if
+// no governance submitters are configured.
+func (c Config) AllowsGovernanceSubmitter(signer sdk.AccAddress) bool {
+	if len(c.GovernanceSubmitters) == 0 {
+		return true
+	}
+	for _, submitter := range c.GovernanceSubmitters {
+		if submitter == signer.String() {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks that the threshold is between 2/3 and 1, so that two quorums always share more than a third of the
+// guardian set.
+func (q QuorumThreshold) Validate() error {
+	if q.Denominator == 0 {
+		return fmt.Errorf("%w: denominator must be positive", ErrInvalidQuorumThreshold)
+	}
+	if q.Numerator > q.Denominator {
+		return fmt.Errorf("%w: %d/%d is above 1", ErrInvalidQuorumThreshold, q.Numerator, q.Denominator)
+	}
+	if 3*uint64(q.Numerator) < 2*uint64(q.Denominator) {
+		return fmt.Errorf("%w: %d/%d is below 2/3", ErrInvalidQuorumThreshold, q.Numerator, q.Denominator)
+	}
+	return nil
+}
+
+// Quorum returns the signatures required from a guardian set of numGuardians: more than numerator/denominator of them,
+// but at most all of them, and at least one.
+func (q QuorumThreshold) Quorum(numGuardians int) int {
+	quorum := int(uint64(numGuardians)*uint64(q.Numerator)/uint64(q.Denominator)) + 1
+	if quorum > numGuardians {
+		quorum = numGuardians
+	}
+	if quorum < 1 {
+		quorum = 1
+	}
+	return quorum
+}
+
+// Quorum returns the minimum number of guardians that need to sign a VAA for a guardian set of numGuardians, with the
+// configured quorum threshold or, if there is none, the quorum of the contracts.
+func (c Config) Quorum(numGuardians int) int {
+	if c.QuorumThreshold == nil {
+		return vaa.CalculateQuorum(numGuardians)
+	}
+	return c.QuorumThreshold.Quorum(numGuardians)
+}
@@ -36,3 +36,9 @@ func EmitterAddressFromAccAddress(addr sdk.AccAddress) EmitterAddress {
 		bytes: append(zeros[:], bytes[:]...),
 	}
 }
+
+// EmitterCapabilityName is the name of the capability that authorizes posting
+// messages from emitter.
+func EmitterCapabilityName(emitter EmitterAddress) string {
+	return fmt.Sprintf("emitters/%x", emitter.Bytes())
+}
@@ -0,0 +1,15 @@
+package types
+
+import "fmt"
+
+// ValidateBasic checks that the rate limit applies to a 32 byte emitter and, unless it blocks the emitter by allowing
+// no messages, has a non-empty window.
+func (l EmitterRateLimit) ValidateBasic() error {
+	if len(l.Emitter) != 32 {
+		return fmt.Errorf("invalid emitter length: %d != 32", len(l.Emitter))
+	}
+	if l.MaxMessages != 0 && l.WindowBlocks == 0 {
+		return fmt.Errorf("window of the rate limit must be at least one block")
+	}
+	return nil
+}
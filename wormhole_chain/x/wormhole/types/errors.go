@@ -30,4 +30,23 @@ var (
 	ErrSignerAlreadyRegistered        = sdkerrors.Register(ModuleName, 1121, "transaction signer already registered as a guardian validator")
 	ErrConsensusSetNotUpdatable       = sdkerrors.Register(ModuleName, 1122, "cannot make changes to active consensus guardian set")
 	ErrInvalidHash                    = sdkerrors.Register(ModuleName, 1123, "could not verify the hash in governance action")
+	ErrInvalidEmitterCapability       = sdkerrors.Register(ModuleName, 1124, "capability does not authorize posting messages for the emitter")
+	ErrEmitterAlreadyBound            = sdkerrors.Register(ModuleName, 1125, "emitter is already bound to a module")
+	ErrEmitterRateLimited             = sdkerrors.Register(ModuleName, 1126, "emitter exceeded its message rate limit")
+	ErrUnsupportedVAAVersion          = sdkerrors.Register(ModuleName, 1127, "VAA version is not accepted")
+	ErrInsufficientMessageFee         = sdkerrors.Register(ModuleName, 1128, "payer cannot pay the message fee in any accepted denom")
+	ErrInvalidFeeDenom                = sdkerrors.Register(ModuleName, 1129, "fee transfer denom is invalid")
+	ErrInvalidFeeRecipient            = sdkerrors.Register(ModuleName, 1130, "fee transfer recipient must be a 20 byte address left-padded with zeros")
+	ErrInsufficientFees               = sdkerrors.Register(ModuleName, 1131, "fee transfer exceeds the collected message fees")
+	ErrZeroFeeAmount                  = sdkerrors.Register(ModuleName, 1132, "fee transfer amount is zero")
+	ErrVAAPayloadTooLarge             = sdkerrors.Register(ModuleName, 1133, "VAA payload exceeds the maximum size")
+	ErrTooManyVAASignatures           = sdkerrors.Register(ModuleName, 1134, "VAA has more signatures than allowed")
+	ErrGuardianSetTooOld              = sdkerrors.Register(ModuleName, 1135, "VAA is signed by a guardian set older than allowed for its message type")
+	ErrUnknownMessageType             = sdkerrors.Register(ModuleName, 1136, "unknown VAA message type")
+	ErrVAAExecutionLimitExceeded      = sdkerrors.Register(ModuleName, 1137, "sender exceeded the VAA executions allowed per block")
+	ErrGovernanceSubmitterNotAllowed  = sdkerrors.Register(ModuleName, 1138, "signer is not allowed to submit governance VAAs")
+	ErrInsufficientGasPrice           = sdkerrors.Register(ModuleName, 1139, "fee is below the gas price of the fee market")
+	ErrInvalidObservationRequest      = sdkerrors.Register(ModuleName, 1140, "observation request must identify either a transaction or a message")
+	ErrInvalidQuorumThreshold         = sdkerrors.Register(ModuleName, 1141, "invalid quorum threshold")
+	ErrEmitterBindInTx                = sdkerrors.Register(ModuleName, 1142, "emitters can only be bound at genesis or in an upgrade handler")
 )
@@ -0,0 +1,63 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// RedemptionMsg is implemented by the messages of any module that redeem a VAA. Transactions that only contain
+// redemptions pay the minimum gas price of the fee market instead of its base gas price.
+type RedemptionMsg interface {
+	sdk.Msg
+	// RedeemedVAAs returns the VAAs redeemed by the message.
+	RedeemedVAAs() [][]byte
+}
+
+// Validate checks that the fee market has a valid denom, a positive minimum gas price not above the maximum, if any, a
+// target block gas and a change denominator.
+func (m FeeMarket) Validate() error {
+	if err := sdk.ValidateDenom(m.Denom); err != nil {
+		return fmt.Errorf("invalid fee market denom: %w", err)
+	}
+	if m.MinGasPrice.IsNil() || !m.MinGasPrice.IsPositive() {
+		return fmt.Errorf("fee market min gas price must be positive")
+	}
+	if m.MaxGasPrice.IsNil() || m.MaxGasPrice.IsNegative() {
+		return fmt.Errorf("fee market max gas price must not be negative")
+	}
+	if !m.MaxGasPrice.IsZero() && m.MaxGasPrice.LT(m.MinGasPrice) {
+		return fmt.Errorf("fee market max gas price %s is below the min gas price %s", m.MaxGasPrice, m.MinGasPrice)
+	}
+	if m.TargetBlockGas == 0 {
+		return fmt.Errorf("fee market target block gas must be positive")
+	}
+	if m.ChangeDenominator == 0 {
+		return fmt.Errorf("fee market change denominator must be positive")
+	}
+	return nil
+}
+
+// ClampGasPrice returns the gas price within the minimum and maximum gas price.
+func (m FeeMarket) ClampGasPrice(price sdk.Dec) sdk.Dec {
+	if price.LT(m.MinGasPrice) {
+		return m.MinGasPrice
+	}
+	if !m.MaxGasPrice.IsZero() && price.GT(m.MaxGasPrice) {
+		return m.MaxGasPrice
+	}
+	return price
+}
+
+// NextBaseGasPrice returns the base gas price after a block that used gasUsed gas at the base gas price. As in EIP-1559,
+// the price changes by (gasUsed - target) / target / change denominator of itself. Blocks using more than twice the
+// target gas count as using twice the target, so the price changes by at most 1 / change denominator per block.
+func (m FeeMarket) NextBaseGasPrice(base sdk.Dec, gasUsed uint64) sdk.Dec {
+	if gasUsed/2 >= m.TargetBlockGas {
+		gasUsed = 2 * m.TargetBlockGas
+	}
+	target := sdk.NewDecFromInt(sdk.NewIntFromUint64(m.TargetBlockGas))
+	delta := sdk.NewDecFromInt(sdk.NewIntFromUint64(gasUsed)).Sub(target)
+	change := base.Mul(delta).Quo(target).QuoInt64(int64(m.ChangeDenominator))
+	return m.ClampGasPrice(base.Add(change))
+}
@@ -17,7 +17,10 @@ func DefaultGenesis() *GenesisState {
 		ConsensusGuardianSetIndex: &ConsensusGuardianSetIndex{
 			Index: 0,
 		},
-		GuardianValidatorList: []GuardianValidator{},
+		GuardianValidatorList:  []GuardianValidator{},
+		EmitterRateLimitList:   []EmitterRateLimit{},
+		GuardianSetUpgradeList: []GuardianSetUpgrade{},
+		GovernanceVAAList:      []GovernanceVAA{},
 		// this line is used by starport scaffolding # genesis/types/default
 	}
 }
@@ -63,6 +66,73 @@ func (gs GenesisState) Validate() error {
 		}
 		guardianValidatorIndexMap[index] = struct{}{}
 	}
+	// Check for duplicated index in emitterRateLimit
+	emitterRateLimitIndexMap := make(map[string]struct{})
+
+	for _, elem := range gs.EmitterRateLimitList {
+		if err := elem.ValidateBasic(); err != nil {
+			return fmt.Errorf("invalid emitterRateLimit: %w", err)
+		}
+		index := string(EmitterRateLimitKey(elem.Emitter))
+		if _, ok := emitterRateLimitIndexMap[index]; ok {
+			return fmt.Errorf("duplicated index for emitterRateLimit")
+		}
+		emitterRateLimitIndexMap[index] = struct{}{}
+	}
+	// Check for duplicated index in guardianSetUpgrade
+	guardianSetUpgradeIndexMap := make(map[uint32]struct{})
+
+	for _, elem := range gs.GuardianSetUpgradeList {
+		if _, ok := guardianSetUpgradeIndexMap[elem.Index]; ok {
+			return fmt.Errorf("duplicated index for guardianSetUpgrade")
+		}
+		guardianSetUpgradeIndexMap[elem.Index] = struct{}{}
+	}
+	// Check for duplicated index in governanceVAA
+	governanceVAAIndexMap := make(map[string]struct{})
+
+	for _, elem := range gs.GovernanceVAAList {
+		key, err := GovernanceVAAKey(elem.Module, elem.Action, elem.Sequence)
+		if err != nil {
+			return fmt.Errorf("invalid governanceVAA: %w", err)
+		}
+		index := string(key)
+		if _, ok := governanceVAAIndexMap[index]; ok {
+			return fmt.Errorf("duplicated index for governanceVAA")
+		}
+		governanceVAAIndexMap[index] = struct{}{}
+	}
+	if gs.Config != nil {
+		if err := gs.Config.MessageFees.Validate(); err != nil {
+			return fmt.Errorf("invalid message fees: %w", err)
+		}
+		minGuardianSetIndexMap := make(map[string]struct{})
+		for _, elem := range gs.Config.MinGuardianSetIndexes {
+			if err := ValidateMessageType(elem.MessageType); err != nil {
+				return err
+			}
+			if _, ok := minGuardianSetIndexMap[elem.MessageType]; ok {
+				return fmt.Errorf("duplicated message type for minGuardianSetIndexes")
+			}
+			minGuardianSetIndexMap[elem.MessageType] = struct{}{}
+		}
+		if err := ValidateGovernanceSubmitters(gs.Config.GovernanceSubmitters); err != nil {
+			return err
+		}
+		if gs.Config.FeeMarket != nil {
+			if err := gs.Config.FeeMarket.Validate(); err != nil {
+				return err
+			}
+		}
+		if gs.Config.QuorumThreshold != nil {
+			if err := gs.Config.QuorumThreshold.Validate(); err != nil {
+				return err
+			}
+		}
+	}
+	if gs.BaseGasPrice != nil && (gs.BaseGasPrice.IsNil() || gs.BaseGasPrice.IsNegative()) {
+		return fmt.Errorf("base gas price must not be negative")
+	}
 	// this line is used by starport scaffolding # genesis/types/validate
 
 	return nil
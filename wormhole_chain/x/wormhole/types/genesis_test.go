@@ -3,11 +3,14 @@ package types_test
 import (
 	"testing"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/stretchr/testify/require"
 	"github.com/wormhole-foundation/wormhole-chain/x/wormhole/types"
 )
 
 func TestGenesisState_Validate(t *testing.T) {
+	submitter := sdk.AccAddress(make([]byte, 20)).String()
+	negativeGasPrice := sdk.NewDec(-1)
 	for _, tc := range []struct {
 		desc     string
 		genState *types.GenesisState
@@ -29,7 +32,16 @@ func TestGenesisState_Validate(t *testing.T) {
 						Index: 1,
 					},
 				},
-				Config: &types.Config{},
+				Config: &types.Config{
+					GovernanceSubmitters: []string{submitter},
+					FeeMarket: &types.FeeMarket{
+						Denom:             "uworm",
+						MinGasPrice:       sdk.NewDecWithPrec(1, 2),
+						MaxGasPrice:       sdk.ZeroDec(),
+						TargetBlockGas:    1000,
+						ChangeDenominator: 8,
+					},
+				},
 				ReplayProtectionList: []types.ReplayProtection{
 					{
 						Index: "0",
@@ -59,6 +71,13 @@ func TestGenesisState_Validate(t *testing.T) {
 						ValidatorAddr: []byte{4},
 					},
 				},
+				EmitterRateLimitList: []types.EmitterRateLimit{
+					{
+						Emitter:      make([]byte, 32),
+						MaxMessages:  1,
+						WindowBlocks: 1,
+					},
+				},
 				// this line is used by starport scaffolding # types/genesis/validField
 			},
 			valid: true,
@@ -121,6 +140,217 @@ func TestGenesisState_Validate(t *testing.T) {
 			},
 			valid: true,
 		},
+		{
+			desc: "duplicated emitterRateLimit",
+			genState: &types.GenesisState{
+				EmitterRateLimitList: []types.EmitterRateLimit{
+					{
+						Emitter:      make([]byte, 32),
+						MaxMessages:  1,
+						WindowBlocks: 1,
+					},
+					{
+						Emitter:      make([]byte, 32),
+						MaxMessages:  2,
+						WindowBlocks: 1,
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			desc: "emitterRateLimit without window",
+			genState: &types.GenesisState{
+				EmitterRateLimitList: []types.EmitterRateLimit{
+					{
+						Emitter:     make([]byte, 32),
+						MaxMessages: 1,
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			desc: "duplicated guardianSetUpgrade",
+			genState: &types.GenesisState{
+				GuardianSetUpgradeList: []types.GuardianSetUpgrade{
+					{
+						Index: 1,
+					},
+					{
+						Index: 1,
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			desc: "duplicated governanceVAA",
+			genState: &types.GenesisState{
+				GovernanceVAAList: []types.GovernanceVAA{
+					{
+						Module:   "Core",
+						Action:   2,
+						Sequence: 1,
+					},
+					{
+						Module:   "Core",
+						Action:   2,
+						Sequence: 1,
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			desc: "governanceVAA without module",
+			genState: &types.GenesisState{
+				GovernanceVAAList: []types.GovernanceVAA{
+					{
+						Action:   2,
+						Sequence: 1,
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			desc: "unsorted message fees",
+			genState: &types.GenesisState{
+				Config: &types.Config{
+					MessageFees: sdk.Coins{sdk.NewInt64Coin("uworm", 10), sdk.NewInt64Coin("uusdc", 1000)},
+				},
+			},
+			valid: false,
+		},
+		{
+			desc: "duplicated minGuardianSetIndexes",
+			genState: &types.GenesisState{
+				Config: &types.Config{
+					MinGuardianSetIndexes: []types.MinGuardianSetIndex{
+						{MessageType: types.MessageTypeGovernance, GuardianSetIndex: 1},
+						{MessageType: types.MessageTypeGovernance, GuardianSetIndex: 2},
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			desc: "unknown minGuardianSetIndexes message type",
+			genState: &types.GenesisState{
+				Config: &types.Config{
+					MinGuardianSetIndexes: []types.MinGuardianSetIndex{
+						{MessageType: "attestation", GuardianSetIndex: 1},
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			desc: "duplicated governanceSubmitters",
+			genState: &types.GenesisState{
+				Config: &types.Config{
+					GovernanceSubmitters: []string{submitter, submitter},
+				},
+			},
+			valid: false,
+		},
+		{
+			desc: "invalid governanceSubmitters address",
+			genState: &types.GenesisState{
+				Config: &types.Config{
+					GovernanceSubmitters: []string{"wormhole1invalid"},
+				},
+			},
+			valid: false,
+		},
+		{
+			desc: "fee market without min gas price",
+			genState: &types.GenesisState{
+				Config: &types.Config{
+					FeeMarket: &types.FeeMarket{
+						Denom:             "uworm",
+						MinGasPrice:       sdk.ZeroDec(),
+						MaxGasPrice:       sdk.ZeroDec(),
+						TargetBlockGas:    1000,
+						ChangeDenominator: 8,
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			desc: "fee market max gas price below min gas price",
+			genState: &types.GenesisState{
+				Config: &types.Config{
+					FeeMarket: &types.FeeMarket{
+						Denom:             "uworm",
+						MinGasPrice:       sdk.NewDecWithPrec(2, 2),
+						MaxGasPrice:       sdk.NewDecWithPrec(1, 2),
+						TargetBlockGas:    1000,
+						ChangeDenominator: 8,
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			desc: "fee market without change denominator",
+			genState: &types.GenesisState{
+				Config: &types.Config{
+					FeeMarket: &types.FeeMarket{
+						Denom:          "uworm",
+						MinGasPrice:    sdk.NewDecWithPrec(1, 2),
+						MaxGasPrice:    sdk.ZeroDec(),
+						TargetBlockGas: 1000,
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			desc: "quorum threshold of all signatures",
+			genState: &types.GenesisState{
+				Config: &types.Config{
+					QuorumThreshold: &types.QuorumThreshold{Numerator: 1, Denominator: 1},
+				},
+			},
+			valid: true,
+		},
+		{
+			desc: "quorum threshold below 2/3",
+			genState: &types.GenesisState{
+				Config: &types.Config{
+					QuorumThreshold: &types.QuorumThreshold{Numerator: 3, Denominator: 5},
+				},
+			},
+			valid: false,
+		},
+		{
+			desc: "quorum threshold above 1",
+			genState: &types.GenesisState{
+				Config: &types.Config{
+					QuorumThreshold: &types.QuorumThreshold{Numerator: 4, Denominator: 3},
+				},
+			},
+			valid: false,
+		},
+		{
+			desc: "quorum threshold without denominator",
+			genState: &types.GenesisState{
+				Config: &types.Config{
+					QuorumThreshold: &types.QuorumThreshold{},
+				},
+			},
+			valid: false,
+		},
+		{
+			desc: "negative baseGasPrice",
+			genState: &types.GenesisState{
+				BaseGasPrice: &negativeGasPrice,
+			},
+			valid: false,
+		},
 		// this line is used by starport scaffolding # types/genesis/testcase
 	} {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -0,0 +1,37 @@
+package types
+
+import "encoding/binary"
+
+var _ binary.ByteOrder
+
+const (
+	// EmitterRateLimitKeyPrefix is the prefix to retrieve all EmitterRateLimit
+	EmitterRateLimitKeyPrefix = "EmitterRateLimit/value/"
+
+	// EmitterMessageCountKeyPrefix is the prefix to retrieve all EmitterMessageCount
+	EmitterMessageCountKeyPrefix = "EmitterMessageCount/value/"
+)
+
+// EmitterRateLimitKey returns the store key to retrieve an EmitterRateLimit from the index fields
+func EmitterRateLimitKey(
+	emitter []byte,
+) []byte {
+	var key []byte
+
+	key = append(key, emitter...)
+	key = append(key, []byte("/")...)
+
+	return key
+}
+
+// EmitterMessageCountKey returns the store key to retrieve an EmitterMessageCount from the index fields
+func EmitterMessageCountKey(
+	emitter []byte,
+) []byte {
+	var key []byte
+
+	key = append(key, emitter...)
+	key = append(key, []byte("/")...)
+
+	return key
+}
@@ -0,0 +1,37 @@
+package types
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+const (
+	// GovernanceVAAKeyPrefix is the prefix to retrieve all GovernanceVAA
+	GovernanceVAAKeyPrefix = "GovernanceVAA/value/"
+)
+
+// GovernanceModuleKey returns the 32 bytes a module name is left-padded to in governance payloads.
+func GovernanceModuleKey(module string) ([]byte, error) {
+	if len(module) == 0 || len(module) > 32 {
+		return nil, fmt.Errorf("invalid governance module %q", module)
+	}
+	key := make([]byte, 32)
+	copy(key[32-len(module):], module)
+	return key, nil
+}
+
+// GovernanceVAAKey returns the store key to retrieve a GovernanceVAA from the index fields. Keys of the same module
+// and action share their prefix and are ordered by sequence.
+func GovernanceVAAKey(module string, action uint32, sequence uint64) ([]byte, error) {
+	key, err := GovernanceModuleKey(module)
+	if err != nil {
+		return nil, err
+	}
+	if action > 0xff {
+		return nil, fmt.Errorf("invalid governance action %d", action)
+	}
+	key = append(key, byte(action))
+	seq := make([]byte, 8)
+	binary.BigEndian.PutUint64(seq, sequence)
+	return append(key, seq...), nil
+}
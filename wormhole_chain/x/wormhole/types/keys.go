@@ -15,6 +15,9 @@ const (
 
 	// MemStoreKey defines the in-memory store key
 	MemStoreKey = "mem_wormhole"
+
+	// TStoreKey defines the transient store key, which holds state that is discarded at the end of each block
+	TStoreKey = "transient_wormhole"
 )
 
 func KeyPrefix(p string) []byte {
@@ -26,6 +29,10 @@ const (
 	GuardianSetCountKey = "GuardianSet-count-"
 )
 
+const (
+	GuardianSetUpgradeKey = "GuardianSetUpgrade-value-"
+)
+
 const (
 	ConfigKey = "Config-value-"
 )
@@ -33,3 +40,16 @@ const (
 const (
 	ConsensusGuardianSetIndexKey = "ConsensusGuardianSetIndex-value-"
 )
+
+const (
+	BlockMessageDigestKey      = "BlockMessageDigest-value-"
+	BlockMessageDigestCountKey = "BlockMessageDigest-count-"
+)
+
+const (
+	VAAExecutionCountKey = "VAAExecution-count-"
+)
+
+const (
+	BaseGasPriceKey = "BaseGasPrice-value-"
+)
@@ -5,7 +5,15 @@ import (
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 )
 
-var _ sdk.Msg = &MsgExecuteGovernanceVAA{}
+var _ GovernanceVAAMsg = &MsgExecuteGovernanceVAA{}
+
+// GovernanceVAAMsg is implemented by the messages of any module that execute a governance VAA, whose signers must be
+// among the governance submitters of the config, if any are set.
+type GovernanceVAAMsg interface {
+	sdk.Msg
+	// GovernanceVAA returns the governance VAA executed by the message.
+	GovernanceVAA() []byte
+}
 
 func NewMsgExecuteGovernanceVAA(vaa []byte, signer string) *MsgExecuteGovernanceVAA {
 	return &MsgExecuteGovernanceVAA{
@@ -22,6 +30,10 @@ func (msg *MsgExecuteGovernanceVAA) Type() string {
 	return "ExecuteGovernanceVAA"
 }
 
+func (msg *MsgExecuteGovernanceVAA) GovernanceVAA() []byte {
+	return msg.Vaa
+}
+
 func (msg *MsgExecuteGovernanceVAA) GetSigners() []sdk.AccAddress {
 	signer, err := sdk.AccAddressFromBech32(msg.Signer)
 	if err != nil {
@@ -41,5 +53,5 @@ func (msg *MsgExecuteGovernanceVAA) ValidateBasic() error {
 		return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "string could not be parsed as address: %v", err)
 	}
 
-	return nil
+	return ValidateVAASize(msg.Vaa)
 }
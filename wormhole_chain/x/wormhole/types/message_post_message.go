@@ -0,0 +1,47 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+const TypeMsgPostMessage = "post_message"
+
+var _ sdk.Msg = &MsgPostMessage{}
+
+func NewMsgPostMessage(signer string, nonce uint32, payload []byte) *MsgPostMessage {
+	return &MsgPostMessage{
+		Signer:  signer,
+		Nonce:   nonce,
+		Payload: payload,
+	}
+}
+
+func (msg *MsgPostMessage) Route() string {
+	return RouterKey
+}
+
+func (msg *MsgPostMessage) Type() string {
+	return TypeMsgPostMessage
+}
+
+func (msg *MsgPostMessage) GetSigners() []sdk.AccAddress {
+	signer, err := sdk.AccAddressFromBech32(msg.Signer)
+	if err != nil {
+		panic(err)
+	}
+	return []sdk.AccAddress{signer}
+}
+
+func (msg *MsgPostMessage) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg *MsgPostMessage) ValidateBasic() error {
+	_, err := sdk.AccAddressFromBech32(msg.Signer)
+	if err != nil {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "invalid signer address (%s)", err)
+	}
+	return nil
+}
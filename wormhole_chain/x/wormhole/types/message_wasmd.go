@@ -4,8 +4,8 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
-var _ sdk.Msg = &MsgInstantiateContract{}
-var _ sdk.Msg = &MsgStoreCode{}
+var _ GovernanceVAAMsg = &MsgInstantiateContract{}
+var _ GovernanceVAAMsg = &MsgStoreCode{}
 
 func (msg *MsgInstantiateContract) Route() string {
 	return RouterKey
@@ -15,6 +15,10 @@ func (msg *MsgInstantiateContract) Type() string {
 	return "InstantiateContract"
 }
 
+func (msg *MsgInstantiateContract) GovernanceVAA() []byte {
+	return msg.Vaa
+}
+
 func (msg *MsgInstantiateContract) GetSigners() []sdk.AccAddress {
 	signer, err := sdk.AccAddressFromBech32(msg.Signer)
 	if err != nil {
@@ -29,6 +33,9 @@ func (msg *MsgInstantiateContract) GetSignBytes() []byte {
 }
 
 func (msg *MsgInstantiateContract) ValidateBasic() error {
+	if err := ValidateVAASize(msg.Vaa); err != nil {
+		return err
+	}
 	return msg.ToWasmd().ValidateBasic()
 }
 
@@ -40,6 +47,10 @@ func (msg *MsgStoreCode) Type() string {
 	return "StoreCode"
 }
 
+func (msg *MsgStoreCode) GovernanceVAA() []byte {
+	return msg.Vaa
+}
+
 func (msg *MsgStoreCode) GetSigners() []sdk.AccAddress {
 	signer, err := sdk.AccAddressFromBech32(msg.Signer)
 	if err != nil {
@@ -54,5 +65,8 @@ func (msg *MsgStoreCode) GetSignBytes() []byte {
 }
 
 func (msg *MsgStoreCode) ValidateBasic() error {
+	if err := ValidateVAASize(msg.Vaa); err != nil {
+		return err
+	}
 	return msg.ToWasmd().ValidateBasic()
 }
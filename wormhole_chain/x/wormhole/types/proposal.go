@@ -2,13 +2,26 @@ package types
 
 import (
 	"fmt"
+	"math"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	gov "github.com/cosmos/cosmos-sdk/x/gov/types"
 )
 
 const (
 	ProposalTypeGuardianSetUpdate         string = "GuardianSetUpdate"
 	ProposalTypeGovernanceWormholeMessage string = "GovernanceWormholeMessage"
+	ProposalTypeEmitterRateLimit          string = "EmitterRateLimit"
+	ProposalTypeAcceptedVAAVersions       string = "AcceptedVAAVersions"
+	ProposalTypeMessageFees               string = "MessageFees"
+	ProposalTypeVAALimits                 string = "VAALimits"
+	ProposalTypeMinGuardianSetIndex       string = "MinGuardianSetIndex"
+	ProposalTypeVAAExecutionLimit         string = "VAAExecutionLimit"
+	ProposalTypeGovernanceSubmitters      string = "GovernanceSubmitters"
+	ProposalTypeFeeMarket                 string = "FeeMarket"
+	ProposalTypeObservationRequest        string = "ObservationRequest"
+	ProposalTypeQuorumThreshold           string = "QuorumThreshold"
 )
 
 func init() {
@@ -16,6 +29,26 @@ func init() {
 	gov.RegisterProposalTypeCodec(&GuardianSetUpdateProposal{}, "wormhole/GuardianSetUpdate")
 	gov.RegisterProposalType(ProposalTypeGovernanceWormholeMessage)
 	gov.RegisterProposalTypeCodec(&GovernanceWormholeMessageProposal{}, "wormhole/GovernanceWormholeMessage")
+	gov.RegisterProposalType(ProposalTypeEmitterRateLimit)
+	gov.RegisterProposalTypeCodec(&EmitterRateLimitProposal{}, "wormhole/EmitterRateLimit")
+	gov.RegisterProposalType(ProposalTypeAcceptedVAAVersions)
+	gov.RegisterProposalTypeCodec(&AcceptedVAAVersionsProposal{}, "wormhole/AcceptedVAAVersions")
+	gov.RegisterProposalType(ProposalTypeMessageFees)
+	gov.RegisterProposalTypeCodec(&MessageFeesProposal{}, "wormhole/MessageFees")
+	gov.RegisterProposalType(ProposalTypeVAALimits)
+	gov.RegisterProposalTypeCodec(&VAALimitsProposal{}, "wormhole/VAALimits")
+	gov.RegisterProposalType(ProposalTypeMinGuardianSetIndex)
+	gov.RegisterProposalTypeCodec(&MinGuardianSetIndexProposal{}, "wormhole/MinGuardianSetIndex")
+	gov.RegisterProposalType(ProposalTypeVAAExecutionLimit)
+	gov.RegisterProposalTypeCodec(&VAAExecutionLimitProposal{}, "wormhole/VAAExecutionLimit")
+	gov.RegisterProposalType(ProposalTypeGovernanceSubmitters)
+	gov.RegisterProposalTypeCodec(&GovernanceSubmittersProposal{}, "wormhole/GovernanceSubmitters")
+	gov.RegisterProposalType(ProposalTypeFeeMarket)
+	gov.RegisterProposalTypeCodec(&FeeMarketProposal{}, "wormhole/FeeMarket")
+	gov.RegisterProposalType(ProposalTypeObservationRequest)
+	gov.RegisterProposalTypeCodec(&ObservationRequestProposal{}, "wormhole/ObservationRequest")
+	gov.RegisterProposalType(ProposalTypeQuorumThreshold)
+	gov.RegisterProposalTypeCodec(&QuorumThresholdProposal{}, "wormhole/QuorumThreshold")
 }
 
 func NewGuardianSetUpdateProposal(title, description string, guardianSet GuardianSet) *GuardianSetUpdateProposal {
@@ -72,3 +105,306 @@ func (sup *GovernanceWormholeMessageProposal) String() string {
   TargetChain: %d
   Payload: %x`, sup.Title, sup.Description, sup.Module, sup.TargetChain, sup.Payload)
 }
+
+func NewEmitterRateLimitProposal(title, description string, rateLimit EmitterRateLimit) *EmitterRateLimitProposal {
+	return &EmitterRateLimitProposal{
+		Title:       title,
+		Description: description,
+		RateLimit:   rateLimit,
+	}
+}
+
+func (sup *EmitterRateLimitProposal) ProposalRoute() string { return RouterKey }
+func (sup *EmitterRateLimitProposal) ProposalType() string  { return ProposalTypeEmitterRateLimit }
+func (sup *EmitterRateLimitProposal) ValidateBasic() error {
+	if err := sup.RateLimit.ValidateBasic(); err != nil {
+		return err
+	}
+	return gov.ValidateAbstract(sup)
+}
+
+func (sup *EmitterRateLimitProposal) String() string {
+	return fmt.Sprintf(`Emitter Rate Limit Proposal: 
+  Title:        %s
+  Description:  %s
+  Emitter:      %x
+  MaxMessages:  %d
+  WindowBlocks: %d
+  Remove:       %t`, sup.Title, sup.Description, sup.RateLimit.Emitter, sup.RateLimit.MaxMessages, sup.RateLimit.WindowBlocks, sup.Remove)
+}
+
+func NewAcceptedVAAVersionsProposal(title, description string, versions []uint32) *AcceptedVAAVersionsProposal {
+	return &AcceptedVAAVersionsProposal{
+		Title:       title,
+		Description: description,
+		Versions:    versions,
+	}
+}
+
+func (sup *AcceptedVAAVersionsProposal) ProposalRoute() string { return RouterKey }
+func (sup *AcceptedVAAVersionsProposal) ProposalType() string  { return ProposalTypeAcceptedVAAVersions }
+func (sup *AcceptedVAAVersionsProposal) ValidateBasic() error {
+	if len(sup.Versions) == 0 {
+		return fmt.Errorf("at least one VAA version must be accepted")
+	}
+	seen := make(map[uint32]bool)
+	for _, version := range sup.Versions {
+		if version > math.MaxUint8 {
+			return fmt.Errorf("invalid VAA version: %d", version)
+		}
+		if seen[version] {
+			return fmt.Errorf("duplicate VAA version: %d", version)
+		}
+		seen[version] = true
+	}
+	return gov.ValidateAbstract(sup)
+}
+
+func (sup *AcceptedVAAVersionsProposal) String() string {
+	return fmt.Sprintf(`Accepted VAA Versions Proposal: 
+  Title:       %s
+  Description: %s
+  Versions:    %v`, sup.Title, sup.Description, sup.Versions)
+}
+
+func NewMessageFeesProposal(title, description string, fees sdk.Coins) *MessageFeesProposal {
+	return &MessageFeesProposal{
+		Title:       title,
+		Description: description,
+		Fees:        fees,
+	}
+}
+
+func (sup *MessageFeesProposal) ProposalRoute() string { return RouterKey }
+func (sup *MessageFeesProposal) ProposalType() string  { return ProposalTypeMessageFees }
+func (sup *MessageFeesProposal) ValidateBasic() error {
+	// An empty list makes posting messages free
+	if err := sup.Fees.Validate(); err != nil {
+		return fmt.Errorf("invalid message fees: %w", err)
+	}
+	return gov.ValidateAbstract(sup)
+}
+
+func (sup *MessageFeesProposal) String() string {
+	return fmt.Sprintf(`Message Fees Proposal: 
+  Title:       %s
+  Description: %s
+  Fees:        %s`, sup.Title, sup.Description, sup.Fees)
+}
+
+func NewVAALimitsProposal(title, description string, maxPayloadSize, maxSignatures uint32) *VAALimitsProposal {
+	return &VAALimitsProposal{
+		Title:          title,
+		Description:    description,
+		MaxPayloadSize: maxPayloadSize,
+		MaxSignatures:  maxSignatures,
+	}
+}
+
+func (sup *VAALimitsProposal) ProposalRoute() string { return RouterKey }
+func (sup *VAALimitsProposal) ProposalType() string  { return ProposalTypeVAALimits }
+func (sup *VAALimitsProposal) ValidateBasic() error {
+	// The limits can only lower the bounds enforced before VAAs are decoded
+	if sup.MaxPayloadSize > MaxVAAPayloadSize {
+		return fmt.Errorf("max payload size must be <= %d, is %d", MaxVAAPayloadSize, sup.MaxPayloadSize)
+	}
+	if sup.MaxSignatures > MaxVAASignatures {
+		return fmt.Errorf("max signatures must be <= %d, is %d", MaxVAASignatures, sup.MaxSignatures)
+	}
+	return gov.ValidateAbstract(sup)
+}
+
+func (sup *VAALimitsProposal) String() string {
+	return fmt.Sprintf(`VAA Limits Proposal: 
+  Title:          %s
+  Description:    %s
+  MaxPayloadSize: %d
+  MaxSignatures:  %d`, sup.Title, sup.Description, sup.MaxPayloadSize, sup.MaxSignatures)
+}
+
+func NewMinGuardianSetIndexProposal(title, description, messageType string, guardianSetIndex uint32) *MinGuardianSetIndexProposal {
+	return &MinGuardianSetIndexProposal{
+		Title:            title,
+		Description:      description,
+		MessageType:      messageType,
+		GuardianSetIndex: guardianSetIndex,
+	}
+}
+
+func (sup *MinGuardianSetIndexProposal) ProposalRoute() string { return RouterKey }
+func (sup *MinGuardianSetIndexProposal) ProposalType() string  { return ProposalTypeMinGuardianSetIndex }
+func (sup *MinGuardianSetIndexProposal) ValidateBasic() error {
+	if err := ValidateMessageType(sup.MessageType); err != nil {
+		return err
+	}
+	return gov.ValidateAbstract(sup)
+}
+
+func (sup *MinGuardianSetIndexProposal) String() string {
+	return fmt.Sprintf(`Min Guardian Set Index Proposal: 
+  Title:            %s
+  Description:      %s
+  MessageType:      %s
+  GuardianSetIndex: %d`, sup.Title, sup.Description, sup.MessageType, sup.GuardianSetIndex)
+}
+
+func NewVAAExecutionLimitProposal(title, description string, maxExecutionsPerSender uint32) *VAAExecutionLimitProposal {
+	return &VAAExecutionLimitProposal{
+		Title:                  title,
+		Description:            description,
+		MaxExecutionsPerSender: maxExecutionsPerSender,
+	}
+}
+
+func (sup *VAAExecutionLimitProposal) ProposalRoute() string { return RouterKey }
+func (sup *VAAExecutionLimitProposal) ProposalType() string  { return ProposalTypeVAAExecutionLimit }
+func (sup *VAAExecutionLimitProposal) ValidateBasic() error {
+	return gov.ValidateAbstract(sup)
+}
+
+func (sup *VAAExecutionLimitProposal) String() string {
+	return fmt.Sprintf(`VAA Execution Limit Proposal: 
+  Title:                  %s
+  Description:            %s
+  MaxExecutionsPerSender: %d`, sup.Title, sup.Description, sup.MaxExecutionsPerSender)
+}
+
+func NewGovernanceSubmittersProposal(title, description string, submitters []string) *GovernanceSubmittersProposal {
+	return &GovernanceSubmittersProposal{
+		Title:       title,
+		Description: description,
+		Submitters:  submitters,
+	}
+}
+
+func (sup *GovernanceSubmittersProposal) ProposalRoute() string { return RouterKey }
+func (sup *GovernanceSubmittersProposal) ProposalType() string {
+	return ProposalTypeGovernanceSubmitters
+}
+func (sup *GovernanceSubmittersProposal) ValidateBasic() error {
+	if err := ValidateGovernanceSubmitters(sup.Submitters); err != nil {
+		return err
+	}
+	return gov.ValidateAbstract(sup)
+}
+
+func (sup *GovernanceSubmittersProposal) String() string {
+	return fmt.Sprintf(`Governance Submitters Proposal: 
+  Title:       %s
+  Description: %s
+  Submitters:  %v`, sup.Title, sup.Description, sup.Submitters)
+}
+
+func NewFeeMarketProposal(title, description string, feeMarket *FeeMarket) *FeeMarketProposal {
+	return &FeeMarketProposal{
+		Title:       title,
+		Description: description,
+		FeeMarket:   feeMarket,
+	}
+}
+
+func (sup *FeeMarketProposal) ProposalRoute() string { return RouterKey }
+func (sup *FeeMarketProposal) ProposalType() string  { return ProposalTypeFeeMarket }
+func (sup *FeeMarketProposal) ValidateBasic() error {
+	if sup.FeeMarket != nil {
+		if err := sup.FeeMarket.Validate(); err != nil {
+			return err
+		}
+	}
+	return gov.ValidateAbstract(sup)
+}
+
+func (sup *FeeMarketProposal) String() string {
+	if sup.FeeMarket == nil {
+		return fmt.Sprintf(`Fee Market Proposal: 
+  Title:       %s
+  Description: %s
+  FeeMarket:   none`, sup.Title, sup.Description)
+	}
+	return fmt.Sprintf(`Fee Market Proposal: 
+  Title:             %s
+  Description:       %s
+  Denom:             %s
+  MinGasPrice:       %s
+  MaxGasPrice:       %s
+  TargetBlockGas:    %d
+  ChangeDenominator: %d`, sup.Title, sup.Description, sup.FeeMarket.Denom, sup.FeeMarket.MinGasPrice,
+		sup.FeeMarket.MaxGasPrice, sup.FeeMarket.TargetBlockGas, sup.FeeMarket.ChangeDenominator)
+}
+
+func NewObservationRequestProposal(title, description string, chainID uint32, txHash, emitterAddress []byte, sequence uint64) *ObservationRequestProposal {
+	return &ObservationRequestProposal{
+		Title:          title,
+		Description:    description,
+		ChainId:        chainID,
+		TxHash:         txHash,
+		EmitterAddress: emitterAddress,
+		Sequence:       sequence,
+	}
+}
+
+func (sup *ObservationRequestProposal) ProposalRoute() string { return RouterKey }
+func (sup *ObservationRequestProposal) ProposalType() string {
+	return ProposalTypeObservationRequest
+}
+func (sup *ObservationRequestProposal) ValidateBasic() error {
+	if sup.ChainId == 0 || sup.ChainId > math.MaxUint16 {
+		return sdkerrors.Wrapf(ErrInvalidObservationRequest, "invalid chain id %d", sup.ChainId)
+	}
+	if len(sup.EmitterAddress) == 0 {
+		if len(sup.TxHash) == 0 {
+			return sdkerrors.Wrap(ErrInvalidObservationRequest, "neither a tx hash nor an emitter address is set")
+		}
+		if sup.Sequence != 0 {
+			return sdkerrors.Wrap(ErrInvalidObservationRequest, "a request by tx hash must not have a sequence")
+		}
+	} else {
+		if len(sup.TxHash) != 0 {
+			return sdkerrors.Wrap(ErrInvalidObservationRequest, "both a tx hash and an emitter address are set")
+		}
+		if len(sup.EmitterAddress) != 32 {
+			return sdkerrors.Wrapf(ErrInvalidObservationRequest, "emitter address must be 32 bytes, got %d", len(sup.EmitterAddress))
+		}
+	}
+	return gov.ValidateAbstract(sup)
+}
+
+func (sup *ObservationRequestProposal) String() string {
+	return fmt.Sprintf(`Observation Request Proposal: 
+  Title:          %s
+  Description:    %s
+  ChainId:        %d
+  TxHash:         %x
+  EmitterAddress: %x
+  Sequence:       %d`, sup.Title, sup.Description, sup.ChainId, sup.TxHash, sup.EmitterAddress, sup.Sequence)
+}
+
+func NewQuorumThresholdProposal(title, description string, threshold *QuorumThreshold) *QuorumThresholdProposal {
+	return &QuorumThresholdProposal{
+		Title:           title,
+		Description:     description,
+		QuorumThreshold: threshold,
+	}
+}
+
+func (sup *QuorumThresholdProposal) ProposalRoute() string { return RouterKey }
+func (sup *QuorumThresholdProposal) ProposalType() string  { return ProposalTypeQuorumThreshold }
+func (sup *QuorumThresholdProposal) ValidateBasic() error {
+	if sup.QuorumThreshold != nil {
+		if err := sup.QuorumThreshold.Validate(); err != nil {
+			return err
+		}
+	}
+	return gov.ValidateAbstract(sup)
+}
+
+func (sup *QuorumThresholdProposal) String() string {
+	threshold := "default"
+	if sup.QuorumThreshold != nil {
+		threshold = fmt.Sprintf("%d/%d", sup.QuorumThreshold.Numerator, sup.QuorumThreshold.Denominator)
+	}
+	return fmt.Sprintf(`Quorum Threshold Proposal: 
+  Title:           %s
+  Description:     %s
+  QuorumThreshold: %s`, sup.Title, sup.Description, threshold)
+}
@@ -1,6 +1,50 @@
 package types
 
-import "encoding/binary"
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+const (
+	// MaxVAAPayloadSize and MaxVAASignatures bound every VAA the chain processes. Larger VAAs are rejected by
+	// ValidateVAASize without being decoded. The config can lower both limits further.
+	MaxVAAPayloadSize = 64 * 1024
+	MaxVAASignatures  = 255
+
+	vaaHeaderLength    = 6
+	vaaSignatureLength = 66
+	vaaBodyLength      = 51
+)
+
+// ValidateVAASize rejects VAAs whose signature count or payload exceed MaxVAASignatures or MaxVAAPayloadSize. It only
+// reads the signature count, so it is cheap enough to run before decoding untrusted VAAs.
+func ValidateVAASize(data []byte) error {
+	if len(data) < vaaHeaderLength {
+		return nil
+	}
+	numSignatures := int(data[vaaHeaderLength-1])
+	if numSignatures > MaxVAASignatures {
+		return fmt.Errorf("%w: %d > %d", ErrTooManyVAASignatures, numSignatures, MaxVAASignatures)
+	}
+	payloadSize := len(data) - vaaHeaderLength - numSignatures*vaaSignatureLength - vaaBodyLength
+	if payloadSize > MaxVAAPayloadSize {
+		return fmt.Errorf("%w: %d > %d bytes", ErrVAAPayloadTooLarge, payloadSize, MaxVAAPayloadSize)
+	}
+	return nil
+}
+
+// GovernanceChainAll is the target chain of governance actions that apply to every chain.
+const GovernanceChainAll uint16 = 0
+
+// IsGovernanceTarget returns true if a governance action with the given target chain is meant for the chain with ID
+// chainID. Actions for all chains only match if allowAll is set; actions that only make sense for a single deployment,
+// like upgrades, have to target it explicitly.
+func IsGovernanceTarget(targetChain uint16, chainID uint16, allowAll bool) bool {
+	if targetChain == GovernanceChainAll {
+		return allowAll
+	}
+	return targetChain == chainID
+}
 
 type GovernanceMessage struct {
 	Module  [32]byte
@@ -0,0 +1,36 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+func TestIsGovernanceTarget(t *testing.T) {
+	wormchain := uint16(vaa.ChainIDWormchain)
+
+	assert.True(t, IsGovernanceTarget(wormchain, wormchain, true))
+	assert.True(t, IsGovernanceTarget(wormchain, wormchain, false))
+	assert.True(t, IsGovernanceTarget(GovernanceChainAll, wormchain, true))
+	assert.False(t, IsGovernanceTarget(GovernanceChainAll, wormchain, false))
+	assert.False(t, IsGovernanceTarget(uint16(vaa.ChainIDEthereum), wormchain, true))
+	assert.False(t, IsGovernanceTarget(uint16(vaa.ChainIDEthereum), wormchain, false))
+}
+
+func TestValidateVAASize(t *testing.T) {
+	v := vaa.VAA{Version: vaa.SupportedVAAVersion, Payload: make([]byte, MaxVAAPayloadSize)}
+	v.Signatures = []*vaa.Signature{{Index: 0}, {Index: 1}}
+	b, err := v.Marshal()
+	require.NoError(t, err)
+	assert.NoError(t, ValidateVAASize(b))
+
+	v.Payload = append(v.Payload, 0)
+	b, err = v.Marshal()
+	require.NoError(t, err)
+	assert.ErrorIs(t, ValidateVAASize(b), ErrVAAPayloadTooLarge)
+
+	// Truncated VAAs are left for the parser to reject
+	assert.NoError(t, ValidateVAASize(b[:3]))
+}